@@ -0,0 +1,99 @@
+package bean
+
+import (
+	entity "unibee/internal/model/entity/default"
+	"unibee/utility"
+)
+
+type BankStatement struct {
+	Id               uint64 `json:"id"               description:"id"`
+	MerchantId       uint64 `json:"merchantId"       description:"merchant_id"`
+	Format           string `json:"format"           description:"statement format, camt053|mt940|csv"`
+	FileName         string `json:"fileName"         description:"imported file name"`
+	BankStatementId  string `json:"bankStatementId"  description:"statement id in the file"`
+	Account          string `json:"account"          description:"bank account of the statement, iban or account number"`
+	Currency         string `json:"currency"         description:"currency of the account"`
+	CreditCount      int    `json:"creditCount"      description:"count of imported credit lines"`
+	DuplicateCount   int    `json:"duplicateCount"   description:"count of credit lines skipped as imported before"`
+	AutoMatchedCount int    `json:"autoMatchedCount" description:"count of lines matched and marked paid automatically"`
+	ReviewCount      int    `json:"reviewCount"      description:"count of lines queued for review"`
+	UnmatchedCount   int    `json:"unmatchedCount"   description:"count of lines without matching invoice"`
+	CreateTime       int64  `json:"createTime"       description:"create utc time"`
+}
+
+type BankStatementLine struct {
+	Id                  uint64                         `json:"id"                  description:"id"`
+	MerchantId          uint64                         `json:"merchantId"          description:"merchant_id"`
+	StatementId         uint64                         `json:"statementId"         description:"id of bank statement"`
+	BankReference       string                         `json:"bankReference"       description:"reference of the credit given by the bank"`
+	BookingTime         int64                          `json:"bookingTime"         description:"booking utc time"`
+	Currency            string                         `json:"currency"            description:"currency"`
+	Amount              int64                          `json:"amount"              description:"credit amount, cent"`
+	CounterpartyName    string                         `json:"counterpartyName"    description:"name of the payer"`
+	CounterpartyAccount string                         `json:"counterpartyAccount" description:"account of the payer"`
+	Remittance          string                         `json:"remittance"          description:"remittance information of the credit"`
+	Status              int                            `json:"status"              description:"status, 1-auto matched|2-need review|3-unmatched|4-manual matched|5-ignored"`
+	InvoiceId           string                         `json:"invoiceId"           description:"matched invoice_id"`
+	Confidence          int                            `json:"confidence"          description:"confidence of the best match, 0-100"`
+	Candidates          []*BankStatementMatchCandidate `json:"candidates"          description:"candidate invoices of the credit, sorted by confidence"`
+	Note                string                         `json:"note"                description:"note of the match"`
+	CreateTime          int64                          `json:"createTime"          description:"create utc time"`
+}
+
+type BankStatementMatchCandidate struct {
+	InvoiceId         string   `json:"invoiceId"         description:"invoice_id"`
+	InvoiceNumber     string   `json:"invoiceNumber"     description:"display number of the invoice"`
+	Currency          string   `json:"currency"          description:"currency of the invoice"`
+	OutstandingAmount int64    `json:"outstandingAmount" description:"outstanding amount of the invoice when matched, cent"`
+	Confidence        int      `json:"confidence"        description:"confidence of the match, 0-100"`
+	Reasons           []string `json:"reasons"           description:"matched criteria, reference|amount|partial_amount|currency|name"`
+}
+
+func SimplifyBankStatement(one *entity.BankStatement) *BankStatement {
+	if one == nil {
+		return nil
+	}
+	return &BankStatement{
+		Id:               one.Id,
+		MerchantId:       one.MerchantId,
+		Format:           one.Format,
+		FileName:         one.FileName,
+		BankStatementId:  one.BankStatementId,
+		Account:          one.Account,
+		Currency:         one.Currency,
+		CreditCount:      one.CreditCount,
+		DuplicateCount:   one.DuplicateCount,
+		AutoMatchedCount: one.AutoMatchedCount,
+		ReviewCount:      one.ReviewCount,
+		UnmatchedCount:   one.UnmatchedCount,
+		CreateTime:       one.CreateTime,
+	}
+}
+
+func SimplifyBankStatementLine(one *entity.BankStatementLine) *BankStatementLine {
+	if one == nil {
+		return nil
+	}
+	var candidates = make([]*BankStatementMatchCandidate, 0)
+	if len(one.Candidates) > 0 {
+		_ = utility.UnmarshalFromJsonString(one.Candidates, &candidates)
+	}
+	return &BankStatementLine{
+		Id:                  one.Id,
+		MerchantId:          one.MerchantId,
+		StatementId:         one.StatementId,
+		BankReference:       one.BankReference,
+		BookingTime:         one.BookingTime,
+		Currency:            one.Currency,
+		Amount:              one.Amount,
+		CounterpartyName:    one.CounterpartyName,
+		CounterpartyAccount: one.CounterpartyAccount,
+		Remittance:          one.Remittance,
+		Status:              one.Status,
+		InvoiceId:           one.InvoiceId,
+		Confidence:          one.Confidence,
+		Candidates:          candidates,
+		Note:                one.Note,
+		CreateTime:          one.CreateTime,
+	}
+}
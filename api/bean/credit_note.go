@@ -0,0 +1,66 @@
+package bean
+
+import (
+	entity "unibee/internal/model/entity/default"
+	"unibee/utility"
+)
+
+type CreditNote struct {
+	Id                      uint64            `json:"id"                      description:"id"`                                                                    // id
+	MerchantId              uint64            `json:"merchantId"              description:"merchant_id"`                                                           // merchant_id
+	UserId                  uint64            `json:"userId"                  description:"user_id"`                                                               // user_id
+	CreditNoteId            string            `json:"creditNoteId"            description:"credit_note_id"`                                                        // credit_note_id
+	CreditNoteNumber        string            `json:"creditNoteNumber"        description:"sequential number of the credit note series"`                           // sequential number of the credit note series
+	InvoiceId               string            `json:"invoiceId"               description:"id of the credited invoice"`                                            // id of the credited invoice
+	PaymentId               string            `json:"paymentId"               description:"payment_id of the credited invoice"`                                    // payment_id of the credited invoice
+	RefundId                string            `json:"refundId"                description:"refund_id, type 1 only"`                                                // refund_id, type 1 only
+	CreditTransactionId     string            `json:"creditTransactionId"     description:"credit transaction id, type 2 only"`                                    // credit transaction id, type 2 only
+	SubscriptionId          string            `json:"subscriptionId"          description:"subscription_id"`                                                       // subscription_id
+	Type                    int               `json:"type"                    description:"1-refund to the original payment method, 2-credit to customer balance"` // 1-refund to the original payment method, 2-credit to customer balance
+	Status                  int               `json:"status"                  description:"10-issued, 20-failed"`                                                  // 10-issued, 20-failed
+	Currency                string            `json:"currency"                description:"currency"`                                                              // currency
+	TotalAmount             int64             `json:"totalAmount"             description:"total amount including tax, cent"`                                      // total amount including tax, cent
+	TotalAmountExcludingTax int64             `json:"totalAmountExcludingTax" description:"total amount excluding tax, cent"`                                      // total amount excluding tax, cent
+	TaxAmount               int64             `json:"taxAmount"               description:"tax amount, cent"`                                                      // tax amount, cent
+	Lines                   []*CreditNoteItem `json:"lines"                   description:"credited lines"`                                                        // credited lines
+	Reason                  string            `json:"reason"                  description:"reason"`                                                                // reason
+	CreateTime              int64             `json:"createTime"              description:"create utc time"`                                                       // create utc time
+}
+
+// CreditNoteItem is the credited part of one line of the original invoice, amounts are positive
+type CreditNoteItem struct {
+	LineIndex      int   `json:"lineIndex"      description:"index of the credited line in the original invoice lines"`
+	CreditQuantity int64 `json:"creditQuantity" description:"quantity credited from the original line, 0 when credited by amount"`
+	InvoiceItemSimplify
+}
+
+func SimplifyCreditNote(one *entity.CreditNote) *CreditNote {
+	if one == nil {
+		return nil
+	}
+	var lines []*CreditNoteItem
+	if len(one.Lines) > 0 {
+		_ = utility.UnmarshalFromJsonString(one.Lines, &lines)
+	}
+	return &CreditNote{
+		Id:                      one.Id,
+		MerchantId:              one.MerchantId,
+		UserId:                  one.UserId,
+		CreditNoteId:            one.CreditNoteId,
+		CreditNoteNumber:        one.CreditNoteNumber,
+		InvoiceId:               one.InvoiceId,
+		PaymentId:               one.PaymentId,
+		RefundId:                one.RefundId,
+		CreditTransactionId:     one.CreditTransactionId,
+		SubscriptionId:          one.SubscriptionId,
+		Type:                    one.Type,
+		Status:                  one.Status,
+		Currency:                one.Currency,
+		TotalAmount:             one.TotalAmount,
+		TotalAmountExcludingTax: one.TotalAmountExcludingTax,
+		TaxAmount:               one.TaxAmount,
+		Lines:                   lines,
+		Reason:                  one.Reason,
+		CreateTime:              one.CreateTime,
+	}
+}
@@ -22,6 +22,7 @@ type InvoiceDetail struct {
 	InvoiceName                    string                                  `json:"invoiceName"                    description:"InvoiceName"`
 	ProductName                    string                                  `json:"productName"`
 	InvoiceId                      string                                  `json:"invoiceId"                      description:"InvoiceId"`
+	InvoiceNumber                  string                                  `json:"invoiceNumber"                  description:"sequential legal invoice number, empty before the invoice is issued or when numbering is disabled"`
 	GatewayPaymentType             string                                  `json:"gatewayPaymentType"               description:"GatewayPaymentType"`
 	UniqueId                       string                                  `json:"uniqueId"                       description:"UniqueId"`
 	GmtCreate                      *gtime.Time                             `json:"gmtCreate"                      description:"GmtCreate"`
@@ -33,6 +34,7 @@ type InvoiceDetail struct {
 	SubscriptionAmount             int64                                   `json:"subscriptionAmount"             description:"SubscriptionAmount,Cents"`
 	Currency                       string                                  `json:"currency"                       description:"Currency"`
 	Lines                          []*bean.InvoiceItemSimplify             `json:"lines"                          description:"lines json data"`
+	TaxLines                       []*bean.InvoiceItemTaxLine              `json:"taxLines"                       description:"tax breakdown of the invoice, aggregated from the line tax lines"`
 	GatewayId                      uint64                                  `json:"gatewayId"                      description:"Id"`
 	Status                         int                                     `json:"status"                         description:"Status，1-pending｜2-processing｜3-paid | 4-failed | 5-cancelled"`
 	SendStatus                     int                                     `json:"sendStatus"                     description:"SendStatus，0-No | 1- YES"`
@@ -70,6 +72,12 @@ type InvoiceDetail struct {
 	Metadata                       map[string]interface{}                  `json:"metadata" dc:"Metadata，Map"`
 	CountryCode                    string                                  `json:"countryCode"                    description:""`
 	VatNumber                      string                                  `json:"vatNumber"                      description:""`
+	TaxTreatment                   string                                  `json:"taxTreatment"                   description:"tax treatment, empty-standard，reverse_charge，exempt"`
+	ReviewFinalizeTime             int64                                   `json:"reviewFinalizeTime"             description:"utc time the draft invoice in review is finalised，0-not in review"`
+	ReviewHold                     int                                     `json:"reviewHold"                     description:"0-not held，1-held, the draft invoice in review is not finalised automatically"`
+	ConsolidatedInvoiceId          string                                  `json:"consolidatedInvoiceId"          description:"id of the consolidated invoice this cycle invoice is charged by"`
+	NetTermDays                    int                                     `json:"netTermDays"                    description:"payment terms in days of the net term invoice，0-not net term"`
+	PaidAmount                     int64                                   `json:"paidAmount"                     description:"amount received by partial payments, cent"`
 	FinishTime                     int64                                   `json:"finishTime"`
 	CreateTime                     int64                                   `json:"createTime"`
 	PaidTime                       int64                                   `json:"paidTime"`
@@ -91,7 +99,9 @@ func ConvertInvoiceToDetail(ctx context.Context, invoice *entity.Invoice) *Invoi
 	err := utility.UnmarshalFromJsonString(invoice.Lines, &lines)
 	for _, line := range lines {
 		line.Currency = invoice.Currency
-		line.TaxPercentage = invoice.TaxPercentage
+		if len(line.TaxLines) == 0 {
+			line.TaxPercentage = invoice.TaxPercentage
+		}
 	}
 	if err != nil {
 		fmt.Printf("ConvertInvoiceLines err:%s", err)
@@ -150,6 +160,7 @@ func ConvertInvoiceToDetail(ctx context.Context, invoice *entity.Invoice) *Invoi
 		MerchantId:                     invoice.MerchantId,
 		SubscriptionId:                 invoice.SubscriptionId,
 		InvoiceId:                      invoice.InvoiceId,
+		InvoiceNumber:                  invoice.InvoiceNumber,
 		InvoiceName:                    invoice.InvoiceName,
 		ProductName:                    invoice.ProductName,
 		GmtCreate:                      invoice.GmtCreate,
@@ -159,6 +170,7 @@ func ConvertInvoiceToDetail(ctx context.Context, invoice *entity.Invoice) *Invoi
 		SubscriptionAmount:             invoice.SubscriptionAmount,
 		Currency:                       invoice.Currency,
 		Lines:                          lines,
+		TaxLines:                       bean.SummaryInvoiceTaxLines(lines),
 		GatewayId:                      invoice.GatewayId,
 		Status:                         invoice.Status,
 		SendStatus:                     invoice.SendStatus,
@@ -195,6 +207,12 @@ func ConvertInvoiceToDetail(ctx context.Context, invoice *entity.Invoice) *Invoi
 		CreateFrom:                     invoice.CreateFrom,
 		CountryCode:                    invoice.CountryCode,
 		VatNumber:                      invoice.VatNumber,
+		TaxTreatment:                   invoice.TaxTreatment,
+		ReviewFinalizeTime:             invoice.ReviewFinalizeTime,
+		ReviewHold:                     invoice.ReviewHold,
+		ConsolidatedInvoiceId:          invoice.ConsolidatedInvoiceId,
+		NetTermDays:                    invoice.NetTermDays,
+		PaidAmount:                     invoice.PaidAmount,
 		Metadata:                       metadata,
 		FinishTime:                     invoice.FinishTime,
 		TrialEnd:                       invoice.TrialEnd,
@@ -310,6 +328,7 @@ type CreditNoteDetail struct {
 	InvoiceName                    string                      `json:"invoiceName"                    description:"InvoiceName"`
 	ProductName                    string                      `json:"productName"`
 	InvoiceId                      string                      `json:"invoiceId"                      description:"InvoiceId"`
+	InvoiceNumber                  string                      `json:"invoiceNumber"                  description:"sequential credit note number, empty when numbering is disabled"`
 	OriginAmount                   int64                       `json:"originAmount"                   description:"OriginAmount,Cents"`
 	TotalAmount                    int64                       `json:"totalAmount"                    description:"TotalAmount,Cents"`
 	DiscountCode                   string                      `json:"discountCode"`
@@ -338,6 +357,7 @@ type CreditNoteDetail struct {
 	Metadata                       map[string]interface{}      `json:"metadata" dc:"Metadata，Map"`
 	CountryCode                    string                      `json:"countryCode"                    description:""`
 	VatNumber                      string                      `json:"vatNumber"                      description:""`
+	TaxTreatment                   string                      `json:"taxTreatment"                   description:"tax treatment, empty-standard，reverse_charge，exempt"`
 	FinishTime                     int64                       `json:"finishTime"`
 	CreateTime                     int64                       `json:"createTime"`
 	PaidTime                       int64                       `json:"paidTime"`
@@ -355,7 +375,9 @@ func ConvertInvoiceToCreditNoteDetail(ctx context.Context, invoice *entity.Invoi
 	err := utility.UnmarshalFromJsonString(invoice.Lines, &lines)
 	for _, line := range lines {
 		line.Currency = invoice.Currency
-		line.TaxPercentage = invoice.TaxPercentage
+		if len(line.TaxLines) == 0 {
+			line.TaxPercentage = invoice.TaxPercentage
+		}
 	}
 	if err != nil {
 		fmt.Printf("ConvertInvoiceLines err:%s", err)
@@ -412,6 +434,7 @@ func ConvertInvoiceToCreditNoteDetail(ctx context.Context, invoice *entity.Invoi
 		MerchantId:                     invoice.MerchantId,
 		SubscriptionId:                 invoice.SubscriptionId,
 		InvoiceId:                      invoice.InvoiceId,
+		InvoiceNumber:                  invoice.InvoiceNumber,
 		InvoiceName:                    invoice.InvoiceName,
 		ProductName:                    invoice.ProductName,
 		OriginAmount:                   invoice.TotalAmount + invoice.DiscountAmount + invoice.PromoCreditDiscountAmount,
@@ -440,6 +463,7 @@ func ConvertInvoiceToCreditNoteDetail(ctx context.Context, invoice *entity.Invoi
 		CreateFrom:                     invoice.CreateFrom,
 		CountryCode:                    invoice.CountryCode,
 		VatNumber:                      invoice.VatNumber,
+		TaxTreatment:                   invoice.TaxTreatment,
 		Metadata:                       metadata,
 		FinishTime:                     invoice.FinishTime,
 		CreateTime:                     invoice.CreateTime,
@@ -142,7 +142,9 @@ func convertInvoiceToDetailWithPreload(
 	err := utility.UnmarshalFromJsonString(invoice.Lines, &lines)
 	for _, line := range lines {
 		line.Currency = invoice.Currency
-		line.TaxPercentage = invoice.TaxPercentage
+		if len(line.TaxLines) == 0 {
+			line.TaxPercentage = invoice.TaxPercentage
+		}
 	}
 	if err != nil {
 		fmt.Printf("ConvertInvoiceLines err:%s", err)
@@ -225,6 +227,7 @@ func convertInvoiceToDetailWithPreload(
 		SubscriptionAmount:             invoice.SubscriptionAmount,
 		Currency:                       invoice.Currency,
 		Lines:                          lines,
+		TaxLines:                       bean.SummaryInvoiceTaxLines(lines),
 		GatewayId:                      invoice.GatewayId,
 		Status:                         invoice.Status,
 		SendStatus:                     invoice.SendStatus,
@@ -262,6 +265,12 @@ func convertInvoiceToDetailWithPreload(
 		Metadata:                       metadata,
 		CountryCode:                    invoice.CountryCode,
 		VatNumber:                      invoice.VatNumber,
+		TaxTreatment:                   invoice.TaxTreatment,
+		ReviewFinalizeTime:             invoice.ReviewFinalizeTime,
+		ReviewHold:                     invoice.ReviewHold,
+		ConsolidatedInvoiceId:          invoice.ConsolidatedInvoiceId,
+		NetTermDays:                    invoice.NetTermDays,
+		PaidAmount:                     invoice.PaidAmount,
 		FinishTime:                     invoice.FinishTime,
 		TrialEnd:                       invoice.TrialEnd,
 		CreateTime:                     invoice.CreateTime,
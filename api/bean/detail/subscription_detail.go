@@ -80,6 +80,8 @@ type SubscriptionTimeLineDetail struct {
 	GatewayId       uint64                  `json:"gatewayId"       description:"gateway_id"`                                  // gateway_id
 	CreateTime      int64                   `json:"createTime"      description:"create utc time"`                             // create utc time
 	Status          int                     `json:"status"          description:"1-processing,2-finish,3-cancelled,4-expired"` // 1-processing,2-finish
+	ScheduleId      string                  `json:"scheduleId"      description:"id of the subscription schedule the period billed by"`
+	SchedulePhase   int                     `json:"schedulePhase"   description:"phase of the subscription schedule the period billed by"`
 }
 
 type SubscriptionPendingUpdateDetail struct {
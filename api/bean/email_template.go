@@ -12,6 +12,7 @@ import (
 
 type EmailTemplateVariable struct {
 	InvoiceId             string      `json:"InvoiceId" group:"Invoice Information"`
+	InvoiceNumber         string      `json:"Invoice Number" key:"InvoiceNumber" group:"Invoice Information"`
 	UserName              string      `json:"User name" key:"UserName" group:"User Information"`
 	MerchantProductName   string      `json:"Merchant Product Name" key:"ProductName" group:"Subscription Information"`
 	MerchantCustomerEmail string      `json:"Merchant’s customer support email address" key:"SupportEmail" group:"Company Information"`
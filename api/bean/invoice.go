@@ -13,6 +13,7 @@ type Invoice struct {
 	Id                             uint64                             `json:"id"                             description:""`
 	UserId                         uint64                             `json:"userId"                         description:"UserId"`
 	InvoiceId                      string                             `json:"invoiceId"`
+	InvoiceNumber                  string                             `json:"invoiceNumber"                  description:"sequential legal invoice number, empty before the invoice is issued or when numbering is disabled"`
 	InvoiceName                    string                             `json:"invoiceName"`
 	ProductName                    string                             `json:"productName"`
 	DiscountCode                   string                             `json:"discountCode"`
@@ -63,6 +64,10 @@ type Invoice struct {
 	PaymentType                    string                             `json:"paymentType"               description:""`
 	PaymentMethodId                string                             `json:"PaymentMethodId"               description:""`
 	PlanSnapshot                   *InvoicePlanSnapshot               `json:"planSnapshot" description:"Snapshot of the plan and addons at the time of billing. Includes both the current and previous plans when applicable (e.g., upgrade or downgrade)."`
+	TaxTreatment                   string                             `json:"taxTreatment"                   description:"tax treatment, empty-standard，reverse_charge，exempt"`
+	ConsolidatedInvoiceId          string                             `json:"consolidatedInvoiceId"          description:"id of the consolidated invoice this cycle invoice is charged by"`
+	NetTermDays                    int                                `json:"netTermDays"                    description:"payment terms in days of the net term invoice，0-not net term"`
+	PaidAmount                     int64                              `json:"paidAmount"                     description:"amount received by partial payments, cent"`
 }
 
 type InvoiceItemSimplify struct {
@@ -84,6 +89,37 @@ type InvoiceItemSimplify struct {
 	PeriodStart                int64                        `json:"periodStart"`
 	Plan                       *Plan                        `json:"plan"`
 	MetricCharge               *UserMetricChargeInvoiceItem `json:"metricCharge"`
+	TaxLines                   []*InvoiceItemTaxLine        `json:"taxLines"                       description:"tax lines from the tax engine, empty when the single tax percentage applies"`
+}
+
+type InvoiceItemTaxLine struct {
+	Name         string `json:"name"         description:"tax name, like GST, PST, State Tax"`
+	Jurisdiction string `json:"jurisdiction" description:"jurisdiction of the tax"`
+	Rate         int64  `json:"rate"         description:"Tax Rate，1000 = 10%"`
+	Amount       int64  `json:"amount"       description:"tax amount, cent"`
+	Compound     bool   `json:"compound"     description:"compound on the amount and the previous taxes"`
+}
+
+// SummaryInvoiceTaxLines aggregates the tax lines of the invoice items by name, jurisdiction and rate
+func SummaryInvoiceTaxLines(lines []*InvoiceItemSimplify) []*InvoiceItemTaxLine {
+	var list = make([]*InvoiceItemTaxLine, 0)
+	var summary = make(map[string]*InvoiceItemTaxLine)
+	for _, line := range lines {
+		if line == nil {
+			continue
+		}
+		for _, taxLine := range line.TaxLines {
+			key := fmt.Sprintf("%s|%s|%d|%v", taxLine.Name, taxLine.Jurisdiction, taxLine.Rate, taxLine.Compound)
+			if one, ok := summary[key]; ok {
+				one.Amount = one.Amount + taxLine.Amount
+				continue
+			}
+			one := *taxLine
+			summary[key] = &one
+			list = append(list, &one)
+		}
+	}
+	return list
 }
 
 type InvoiceSnapshotChargeType int
@@ -136,6 +172,7 @@ func SimplifyInvoice(one *entity.Invoice) *Invoice {
 		InvoiceName:                    one.InvoiceName,
 		ProductName:                    one.ProductName,
 		InvoiceId:                      one.InvoiceId,
+		InvoiceNumber:                  one.InvoiceNumber,
 		OriginAmount:                   one.TotalAmount + one.DiscountAmount + one.PromoCreditDiscountAmount,
 		TotalAmount:                    one.TotalAmount,
 		DiscountCode:                   one.DiscountCode,
@@ -174,5 +211,9 @@ func SimplifyInvoice(one *entity.Invoice) *Invoice {
 		PartialCreditPaidAmount:        one.PartialCreditPaidAmount,
 		UserMetricChargeForInvoice:     userMetricChargeForInvoice,
 		PaymentType:                    one.GatewayInvoiceId,
+		TaxTreatment:                   one.TaxTreatment,
+		ConsolidatedInvoiceId:          one.ConsolidatedInvoiceId,
+		NetTermDays:                    one.NetTermDays,
+		PaidAmount:                     one.PaidAmount,
 	}
 }
@@ -37,14 +37,19 @@ type Plan struct {
 	TrialDurationTime      int64                           `json:"trialDurationTime"         description:"duration of trial"`              // duration of trial
 	TrialDemand            string                          `json:"trialDemand"               description:""`
 	CancelAtTrialEnd       int                             `json:"cancelAtTrialEnd"          description:"whether cancel at subscription first trial end，0-false | 1-true, will pass to cancelAtPeriodEnd of subscription"` // whether cancel at subscripiton first trial end，0-false | 1-true, will pass to cancelAtPeriodEnd of subscription
-	ExternalPlanId         string                          `json:"externalPlanId"            description:"external_user_id"`                                                                                                // external_user_id
-	ProductId              int64                           `json:"productId"                 description:"product id"`                                                                                                      // product id
-	DisableAutoCharge      int                             `json:"disableAutoCharge"         description:"disable auto-charge, 0-false,1-true"`                                                                             // disable auto-charge, 0-false,1-true
+	TrialEndBehavior       string                          `json:"trialEndBehavior"          description:"behaviour at trial end of the subscription without payment method，convert|pause|cancel, default convert"`
+	ExternalPlanId         string                          `json:"externalPlanId"            description:"external_user_id"`                    // external_user_id
+	ProductId              int64                           `json:"productId"                 description:"product id"`                          // product id
+	DisableAutoCharge      int                             `json:"disableAutoCharge"         description:"disable auto-charge, 0-false,1-true"` // disable auto-charge, 0-false,1-true
 	MetricLimits           []*PlanMetricLimitParam         `json:"metricLimits"  dc:"Plan's MetricLimit List" `
 	MetricMeteredCharge    []*PlanMetricMeteredChargeParam `json:"metricMeteredCharge"  dc:"Plan's MetricMeteredCharge" `
 	MetricRecurringCharge  []*PlanMetricMeteredChargeParam `json:"metricRecurringCharge"  dc:"Plan's MetricRecurringCharge" `
 	CheckoutUrl            string                          `json:"checkoutUrl"                 description:"CheckoutUrl"`
 	MultiCurrencies        []*PlanMultiCurrency            `json:"multiCurrencies"  dc:"Plan's MultiCurrencies" `
+	TaxCategory            string                          `json:"taxCategory"               description:"tax category of the plan, matched by tax rules"`
+	InvoiceReviewHours     int                             `json:"invoiceReviewHours"        description:"hours the cycle invoices stay in draft for review before finalised，0-review disabled"`
+	MinSeats               int                             `json:"minSeats"                  description:"min seats (quantity) of the subscription，0-no limit"`
+	MaxSeats               int                             `json:"maxSeats"                  description:"max seats (quantity) of the subscription，0-no limit"`
 }
 
 const MerchantMultiCurrenciesConfig = "MerchantMultiCurrenciesConfig"
@@ -148,9 +153,14 @@ func SimplifyPlan(one *entity.Plan) *Plan {
 		TrialDurationTime:      one.TrialDurationTime,
 		TrialAmount:            one.TrialAmount,
 		CancelAtTrialEnd:       one.CancelAtTrialEnd,
+		TrialEndBehavior:       one.TrialEndBehavior,
 		ExternalPlanId:         one.ExternalPlanId,
 		ProductId:              one.ProductId,
 		DisableAutoCharge:      one.DisableAutoCharge,
+		TaxCategory:            one.TaxCategory,
+		InvoiceReviewHours:     one.InvoiceReviewHours,
+		MinSeats:               one.MinSeats,
+		MaxSeats:               one.MaxSeats,
 		MetricLimits:           metricPlanCharge.MetricLimits,
 		MetricMeteredCharge:    metricPlanCharge.MetricMeteredCharge,
 		MetricRecurringCharge:  metricPlanCharge.MetricRecurringCharge,
@@ -0,0 +1,48 @@
+package bean
+
+import (
+	entity "unibee/internal/model/entity/default"
+)
+
+type InvoicePartialPayment struct {
+	Id             uint64 `json:"id"             description:"id"`
+	MerchantId     uint64 `json:"merchantId"     description:"merchant_id"`
+	UserId         uint64 `json:"userId"         description:"user_id"`
+	InvoiceId      string `json:"invoiceId"      description:"id of the invoice the payment is applied to"`
+	Currency       string `json:"currency"       description:"currency"`
+	Amount         int64  `json:"amount"         description:"applied amount, cent"`
+	TransferNumber string `json:"transferNumber" description:"bank transfer number or reference of the payment"`
+	Reason         string `json:"reason"         description:"reason"`
+	PaidTime       int64  `json:"paidTime"       description:"utc time the payment is received"`
+	CreateTime     int64  `json:"createTime"     description:"create utc time"`
+}
+
+// ReceivableAgeing is the outstanding amount of the open invoices in one currency, bucketed by days past due
+type ReceivableAgeing struct {
+	Currency     string `json:"currency"     description:"currency"`
+	Current      int64  `json:"current"      description:"outstanding amount not yet due, cent"`
+	Days1To30    int64  `json:"days1To30"    description:"outstanding amount 1-30 days past due, cent"`
+	Days31To60   int64  `json:"days31To60"   description:"outstanding amount 31-60 days past due, cent"`
+	Days61To90   int64  `json:"days61To90"   description:"outstanding amount 61-90 days past due, cent"`
+	DaysOver90   int64  `json:"daysOver90"   description:"outstanding amount more than 90 days past due, cent"`
+	Total        int64  `json:"total"        description:"total outstanding amount, cent"`
+	InvoiceCount int    `json:"invoiceCount" description:"count of open invoices"`
+}
+
+func SimplifyInvoicePartialPayment(one *entity.InvoicePartialPayment) *InvoicePartialPayment {
+	if one == nil {
+		return nil
+	}
+	return &InvoicePartialPayment{
+		Id:             one.Id,
+		MerchantId:     one.MerchantId,
+		UserId:         one.UserId,
+		InvoiceId:      one.InvoiceId,
+		Currency:       one.Currency,
+		Amount:         one.Amount,
+		TransferNumber: one.TransferNumber,
+		Reason:         one.Reason,
+		PaidTime:       one.PaidTime,
+		CreateTime:     one.CreateTime,
+	}
+}
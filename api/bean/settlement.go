@@ -0,0 +1,83 @@
+package bean
+
+import (
+	entity "unibee/internal/model/entity/default"
+)
+
+type GatewaySettlement struct {
+	Id                  uint64 `json:"id"                  description:"id"`                                                         // id
+	MerchantId          uint64 `json:"merchantId"          description:"merchant_id"`                                                // merchant_id
+	GatewayId           uint64 `json:"gatewayId"           description:"gateway_id"`                                                 // gateway_id
+	GatewaySettlementId string `json:"gatewaySettlementId" description:"gateway payout or settlement id"`                            // gateway payout or settlement id
+	Source              string `json:"source"              description:"source of the settlement, gateway|import"`                   // source of the settlement, gateway|import
+	Currency            string `json:"currency"            description:"currency"`                                                   // currency
+	GrossAmount         int64  `json:"grossAmount"         description:"sum of transaction gross amount, cents"`                     // sum of transaction gross amount, cents
+	FeeAmount           int64  `json:"feeAmount"           description:"sum of transaction fee amount, cents"`                       // sum of transaction fee amount, cents
+	NetAmount           int64  `json:"netAmount"           description:"settled amount, cents"`                                      // settled amount, cents
+	Status              int    `json:"status"              description:"status, 1-pending,2-paid,3-failed,4-cancelled"`              // status, 1-pending,2-paid,3-failed,4-cancelled
+	ArrivalTime         int64  `json:"arrivalTime"         description:"expected or actual arrival utc time of the deposit"`         // expected or actual arrival utc time of the deposit
+	ItemCount           int    `json:"itemCount"           description:"count of transactions"`                                      // count of transactions
+	MatchedCount        int    `json:"matchedCount"        description:"count of transactions matched with local payment or refund"` // count of transactions matched with local payment or refund
+	CreateTime          int64  `json:"createTime"          description:"create utc time"`                                            // create utc time
+}
+
+func SimplifyGatewaySettlement(one *entity.GatewaySettlement) *GatewaySettlement {
+	if one == nil {
+		return nil
+	}
+	return &GatewaySettlement{
+		Id:                  one.Id,
+		MerchantId:          one.MerchantId,
+		GatewayId:           one.GatewayId,
+		GatewaySettlementId: one.GatewaySettlementId,
+		Source:              one.Source,
+		Currency:            one.Currency,
+		GrossAmount:         one.GrossAmount,
+		FeeAmount:           one.FeeAmount,
+		NetAmount:           one.NetAmount,
+		Status:              one.Status,
+		ArrivalTime:         one.ArrivalTime,
+		ItemCount:           one.ItemCount,
+		MatchedCount:        one.MatchedCount,
+		CreateTime:          one.CreateTime,
+	}
+}
+
+type GatewaySettlementItem struct {
+	Id                   uint64 `json:"id"                   description:"id"`                                                // id
+	SettlementId         uint64 `json:"settlementId"         description:"id of gateway_settlement"`                          // id of gateway_settlement
+	GatewayTransactionId string `json:"gatewayTransactionId" description:"gateway balance transaction id"`                    // gateway balance transaction id
+	Type                 string `json:"type"                 description:"type, payment|refund|fee|dispute|adjustment|other"` // type, payment|refund|fee|dispute|adjustment|other
+	GatewayPaymentId     string `json:"gatewayPaymentId"     description:"gateway_payment_id"`                                // gateway_payment_id
+	GatewayRefundId      string `json:"gatewayRefundId"      description:"gateway_refund_id"`                                 // gateway_refund_id
+	PaymentId            string `json:"paymentId"            description:"matched payment_id"`                                // matched payment_id
+	RefundId             string `json:"refundId"             description:"matched refund_id"`                                 // matched refund_id
+	InvoiceId            string `json:"invoiceId"            description:"matched invoice_id"`                                // matched invoice_id
+	Currency             string `json:"currency"             description:"currency"`                                          // currency
+	GrossAmount          int64  `json:"grossAmount"          description:"gross amount, cents, negative for refund"`          // gross amount, cents, negative for refund
+	FeeAmount            int64  `json:"feeAmount"            description:"fee amount, cents"`                                 // fee amount, cents
+	NetAmount            int64  `json:"netAmount"            description:"net amount, cents"`                                 // net amount, cents
+	TransactionTime      int64  `json:"transactionTime"      description:"transaction utc time"`                              // transaction utc time
+}
+
+func SimplifyGatewaySettlementItem(one *entity.GatewaySettlementItem) *GatewaySettlementItem {
+	if one == nil {
+		return nil
+	}
+	return &GatewaySettlementItem{
+		Id:                   one.Id,
+		SettlementId:         one.SettlementId,
+		GatewayTransactionId: one.GatewayTransactionId,
+		Type:                 one.Type,
+		GatewayPaymentId:     one.GatewayPaymentId,
+		GatewayRefundId:      one.GatewayRefundId,
+		PaymentId:            one.PaymentId,
+		RefundId:             one.RefundId,
+		InvoiceId:            one.InvoiceId,
+		Currency:             one.Currency,
+		GrossAmount:          one.GrossAmount,
+		FeeAmount:            one.FeeAmount,
+		NetAmount:            one.NetAmount,
+		TransactionTime:      one.TransactionTime,
+	}
+}
@@ -14,15 +14,21 @@ import (
 )
 
 type SubscriptionConfig struct {
-	DowngradeEffectImmediately         bool   `json:"downgradeEffectImmediately" dc:"DowngradeEffectImmediately, whether subscription update should effect immediately or at period end, default at period end"`
-	UpgradeProration                   bool   `json:"upgradeProration" dc:"UpgradeProration, whether subscription update generation proration invoice or not, default yes"`
-	IncompleteExpireTime               int64  `json:"incompleteExpireTime" dc:"IncompleteExpireTime, em.. default 1day for plan of month type"`
-	InvoiceEmail                       bool   `json:"invoiceEmail" dc:"InvoiceEmail, whether to send invoice email to user, default yes"`
-	InvoicePdfGenerate                 bool   `json:"invoicePdfGenerate" dc:"InvoicePdfGenerate, whether to generate invoice pdf to user, default yes"`
-	TryAutomaticPaymentBeforePeriodEnd int64  `json:"tryAutomaticPaymentBeforePeriodEnd" dc:"TryAutomaticPaymentBeforePeriodEnd, default 30 min"`
-	GatewayVATRule                     string `json:"gatewayVATRule" dc:""`
-	ShowZeroInvoice                    bool   `json:"showZeroInvoice" dc:"ShowZeroInvoice, show zero invoice or not, default no"`
-	FiatExchangeApiKey                 string `json:"fiatExchangeApiKey" dc:""`
+	DowngradeEffectImmediately         bool     `json:"downgradeEffectImmediately" dc:"DowngradeEffectImmediately, whether subscription update should effect immediately or at period end, default at period end"`
+	UpgradeProration                   bool     `json:"upgradeProration" dc:"UpgradeProration, whether subscription update generation proration invoice or not, default yes"`
+	IncompleteExpireTime               int64    `json:"incompleteExpireTime" dc:"IncompleteExpireTime, em.. default 1day for plan of month type"`
+	InvoiceEmail                       bool     `json:"invoiceEmail" dc:"InvoiceEmail, whether to send invoice email to user, default yes"`
+	InvoicePdfGenerate                 bool     `json:"invoicePdfGenerate" dc:"InvoicePdfGenerate, whether to generate invoice pdf to user, default yes"`
+	TryAutomaticPaymentBeforePeriodEnd int64    `json:"tryAutomaticPaymentBeforePeriodEnd" dc:"TryAutomaticPaymentBeforePeriodEnd, default 30 min"`
+	GatewayVATRule                     string   `json:"gatewayVATRule" dc:""`
+	ShowZeroInvoice                    bool     `json:"showZeroInvoice" dc:"ShowZeroInvoice, show zero invoice or not, default no"`
+	FiatExchangeApiKey                 string   `json:"fiatExchangeApiKey" dc:""`
+	PauseMode                          string   `json:"pauseMode" dc:"PauseMode, billing behaviour of the open invoice when subscription paused without mode specified, keep_as_draft|void|mark_uncollectible, default keep_as_draft"`
+	UserPauseEnable                    bool     `json:"userPauseEnable" dc:"UserPauseEnable, whether users can pause and resume their subscription in user portal, default no"`
+	SeatProrationMode                  string   `json:"seatProrationMode" dc:"SeatProrationMode, billing of the seats added, immediate|true_up, immediate charges the prorated seats at once, true_up charges the prorated seats added with the next cycle invoice, default immediate"`
+	CancellationReasons                []string `json:"cancellationReasons" dc:"CancellationReasons, reasons the user chooses from when cancelling subscription in user portal"`
+	TrialReminderDays                  int64    `json:"trialReminderDays" dc:"TrialReminderDays, days before trial end to remind the user of the trial without payment method, 0 to disable, default 3"`
+	CommitmentReminderDays             int64    `json:"commitmentReminderDays" dc:"CommitmentReminderDays, days before the notice deadline of commitment term end to remind the user of the renewal, 0 to disable, default 30"`
 }
 
 type Subscription struct {
@@ -67,6 +73,10 @@ type Subscription struct {
 	DefaultPaymentMethodId string                 `json:"defaultPaymentMethodId"    description:""`
 	ProductId              int64                  `json:"productId"                 description:"product id"`                                                         // product id
 	CurrentPeriodPaid      int64                  `json:"currentPeriodPaid"           description:"current period paid or not, 1-paid, other-the utc time to expire"` // current period paid or not, 1-paid, other-the utc time to expire
+	InvoiceReviewHours     int                    `json:"invoiceReviewHours"        description:"hours the cycle invoices stay in draft for review，0-follow plan，-1-review disabled"`
+	PauseMode              string                 `json:"pauseMode"                 description:"pause billing behaviour，keep_as_draft|void|mark_uncollectible"`
+	PauseTime              int64                  `json:"pauseTime"                 description:"utc time the subscription paused, 0-not paused"`
+	ResumeTime             int64                  `json:"resumeTime"                description:"utc time the paused subscription resumes automatically, 0-resume manually"`
 }
 
 func SimplifySubscription(ctx context.Context, one *entity.Subscription) *Subscription {
@@ -156,6 +166,10 @@ func SimplifySubscription(ctx context.Context, one *entity.Subscription) *Subscr
 		ProductId:              productId,
 		CancelOrExpireTime:     cancelOrExpireTime,
 		CurrentPeriodPaid:      one.CurrentPeriodPaid,
+		InvoiceReviewHours:     one.InvoiceReviewHours,
+		PauseMode:              one.PauseMode,
+		PauseTime:              one.PauseTime,
+		ResumeTime:             one.ResumeTime,
 	}
 }
 
@@ -0,0 +1,99 @@
+package bean
+
+import (
+	entity "unibee/internal/model/entity/default"
+	"unibee/utility"
+)
+
+type SubscriptionCancellation struct {
+	CancellationId    string   `json:"cancellationId"    description:"cancellation flow unique id"`
+	SubscriptionId    string   `json:"subscriptionId"    description:"subscription id"`
+	UserId            uint64   `json:"userId"            description:"userId"`
+	PlanId            uint64   `json:"planId"            description:"plan id of the subscription when the flow started"`
+	Reason            string   `json:"reason"            description:"cancellation reason from the merchant reason list"`
+	ReasonText        string   `json:"reasonText"        description:"free text of the reason"`
+	OfferIds          []uint64 `json:"offerIds"          description:"retention offer ids presented"`
+	AcceptedOfferId   uint64   `json:"acceptedOfferId"   description:"retention offer id accepted"`
+	AcceptedOfferType string   `json:"acceptedOfferType" description:"type of the retention offer accepted，discount|pause|downgrade"`
+	Status            int      `json:"status"            description:"status，1-Started｜2-Saved｜3-Cancelled"`
+	FinishTime        int64    `json:"finishTime"        description:"utc time the subscription saved or cancelled"`
+	CreateTime        int64    `json:"createTime"        description:"create utc time"`
+}
+
+type SubscriptionRetentionOffer struct {
+	Id           uint64   `json:"id"           description:"id"`
+	Name         string   `json:"name"         description:"name"`
+	Description  string   `json:"description"  description:"description shown to the customer"`
+	OfferType    string   `json:"offerType"    description:"offer type，discount|pause|downgrade"`
+	DiscountCode string   `json:"discountCode" description:"discount code applied to the next invoice, discount offer"`
+	PauseDays    int64    `json:"pauseDays"    description:"days the subscription paused before resumed automatically, 0 to resume manually, pause offer"`
+	TargetPlanId uint64   `json:"targetPlanId" description:"cheaper plan the subscription downgraded to at period end, downgrade offer"`
+	Reasons      []string `json:"reasons"      description:"cancellation reasons the offer presented for, empty for all reasons"`
+	PlanIds      []uint64 `json:"planIds"      description:"plan ids the offer presented for, empty for all plans"`
+	Priority     int      `json:"priority"     description:"offers presented by priority desc"`
+	Status       int      `json:"status"       description:"status，1-Active｜2-Archived"`
+	CreateTime   int64    `json:"createTime"   description:"create utc time"`
+}
+
+type SubscriptionCancellationReport struct {
+	PlanId         uint64           `json:"planId"         description:"plan id"`
+	PlanName       string           `json:"planName"       description:"plan name"`
+	Started        int64            `json:"started"        description:"cancellation flows started"`
+	Saved          int64            `json:"saved"          description:"subscriptions saved by the retention offers"`
+	Cancelled      int64            `json:"cancelled"      description:"subscriptions cancelled"`
+	SaveRate       int64            `json:"saveRate"       description:"saved / (saved + cancelled)，10000 = 100%"`
+	Reasons        map[string]int64 `json:"reasons"        description:"cancellation flows by reason"`
+	AcceptedOffers map[string]int64 `json:"acceptedOffers" description:"retention offers accepted by offer type"`
+}
+
+func SimplifySubscriptionCancellation(one *entity.SubscriptionCancellation) *SubscriptionCancellation {
+	if one == nil {
+		return nil
+	}
+	var offerIds = make([]uint64, 0)
+	if len(one.OfferIds) > 0 {
+		_ = utility.UnmarshalFromJsonString(one.OfferIds, &offerIds)
+	}
+	return &SubscriptionCancellation{
+		CancellationId:    one.CancellationId,
+		SubscriptionId:    one.SubscriptionId,
+		UserId:            one.UserId,
+		PlanId:            one.PlanId,
+		Reason:            one.Reason,
+		ReasonText:        one.ReasonText,
+		OfferIds:          offerIds,
+		AcceptedOfferId:   one.AcceptedOfferId,
+		AcceptedOfferType: one.AcceptedOfferType,
+		Status:            one.Status,
+		FinishTime:        one.FinishTime,
+		CreateTime:        one.CreateTime,
+	}
+}
+
+func SimplifySubscriptionRetentionOffer(one *entity.SubscriptionRetentionOffer) *SubscriptionRetentionOffer {
+	if one == nil {
+		return nil
+	}
+	var reasons = make([]string, 0)
+	if len(one.Reasons) > 0 {
+		_ = utility.UnmarshalFromJsonString(one.Reasons, &reasons)
+	}
+	var planIds = make([]uint64, 0)
+	if len(one.PlanIds) > 0 {
+		_ = utility.UnmarshalFromJsonString(one.PlanIds, &planIds)
+	}
+	return &SubscriptionRetentionOffer{
+		Id:           one.Id,
+		Name:         one.Name,
+		Description:  one.Description,
+		OfferType:    one.OfferType,
+		DiscountCode: one.DiscountCode,
+		PauseDays:    one.PauseDays,
+		TargetPlanId: one.TargetPlanId,
+		Reasons:      reasons,
+		PlanIds:      planIds,
+		Priority:     one.Priority,
+		Status:       one.Status,
+		CreateTime:   one.CreateTime,
+	}
+}
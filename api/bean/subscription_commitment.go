@@ -0,0 +1,64 @@
+package bean
+
+import (
+	entity "unibee/internal/model/entity/default"
+)
+
+type SubscriptionCommitment struct {
+	CommitmentId                  string `json:"commitmentId"                  description:"commitment unique id"`
+	SubscriptionId                string `json:"subscriptionId"                description:"subscription id"`
+	UserId                        uint64 `json:"userId"                        description:"userId"`
+	TermMonths                    int    `json:"termMonths"                    description:"length of the minimum commitment term in months"`
+	RenewTermMonths               int    `json:"renewTermMonths"               description:"length of the term renewed automatically in months"`
+	AutoRenew                     bool   `json:"autoRenew"                     description:"whether the term renews at term end"`
+	NoticeDays                    int    `json:"noticeDays"                    description:"days before term end the cancellation should be noticed"`
+	EarlyTerminationFeePercentage int64  `json:"earlyTerminationFeePercentage" description:"percentage of the remaining commitment charged at early termination，10000 = 100%"`
+	TermStart                     int64  `json:"termStart"                     description:"utc time the current term started"`
+	TermEnd                       int64  `json:"termEnd"                       description:"utc time the current term ends"`
+	TermCount                     int    `json:"termCount"                     description:"count of the terms, increased by renewal"`
+	NoticeDeadline                int64  `json:"noticeDeadline"                description:"utc time the cancellation of the current term should be noticed before"`
+	ReminderTime                  int64  `json:"reminderTime"                  description:"utc time the renewal reminder of the current term sent"`
+	CancelAtTermEnd               bool   `json:"cancelAtTermEnd"               description:"whether the subscription is cancelled at term end as noticed"`
+	NoticeTime                    int64  `json:"noticeTime"                    description:"utc time the cancellation at term end noticed"`
+	Status                        int    `json:"status"                        description:"status，1-Active｜2-Completed｜3-Terminated｜4-Cancelled"`
+	TerminationFee                int64  `json:"terminationFee"                description:"early termination fee excluding tax, cent"`
+	TerminationInvoiceId          string `json:"terminationInvoiceId"          description:"invoice id of the early termination fee"`
+	FinishTime                    int64  `json:"finishTime"                    description:"utc time the commitment completed, terminated or cancelled"`
+	CreateTime                    int64  `json:"createTime"                    description:"create utc time"`
+}
+
+type SubscriptionCommitmentTerminationPreview struct {
+	RemainingPeriods         int64  `json:"remainingPeriods"         description:"billing periods left from the current period end to the term end"`
+	PeriodAmountExcludingTax int64  `json:"periodAmountExcludingTax" description:"recurring amount of the subscription excluding tax, cent"`
+	TerminationFee           int64  `json:"terminationFee"           description:"early termination fee excluding tax if terminated now, cent"`
+	TaxPercentage            int64  `json:"taxPercentage"            description:"tax percentage of the fee invoice，1000 = 10%"`
+	Currency                 string `json:"currency"                 description:"currency"`
+}
+
+func SimplifySubscriptionCommitment(one *entity.SubscriptionCommitment) *SubscriptionCommitment {
+	if one == nil {
+		return nil
+	}
+	return &SubscriptionCommitment{
+		CommitmentId:                  one.CommitmentId,
+		SubscriptionId:                one.SubscriptionId,
+		UserId:                        one.UserId,
+		TermMonths:                    one.TermMonths,
+		RenewTermMonths:               one.RenewTermMonths,
+		AutoRenew:                     one.AutoRenew == 1,
+		NoticeDays:                    one.NoticeDays,
+		EarlyTerminationFeePercentage: one.EarlyTerminationFeePercentage,
+		TermStart:                     one.TermStart,
+		TermEnd:                       one.TermEnd,
+		TermCount:                     one.TermCount,
+		NoticeDeadline:                one.TermEnd - int64(one.NoticeDays)*86400,
+		ReminderTime:                  one.ReminderTime,
+		CancelAtTermEnd:               one.CancelAtTermEnd == 1,
+		NoticeTime:                    one.NoticeTime,
+		Status:                        one.Status,
+		TerminationFee:                one.TerminationFee,
+		TerminationInvoiceId:          one.TerminationInvoiceId,
+		FinishTime:                    one.FinishTime,
+		CreateTime:                    one.CreateTime,
+	}
+}
@@ -0,0 +1,54 @@
+package bean
+
+import (
+	entity "unibee/internal/model/entity/default"
+)
+
+type SubscriptionPendingInvoiceItem struct {
+	SubscriptionId  string `json:"subscriptionId"  description:"subscription id"`
+	ItemId          string `json:"itemId"          description:"pending invoice item unique id"`
+	PlanId          uint64 `json:"planId"          description:"plan id of the plan-based item, 0 for the amount-based item"`
+	Name            string `json:"name"            description:"name"`
+	Description     string `json:"description"     description:"description"`
+	Currency        string `json:"currency"        description:"currency"`
+	UnitAmount      int64  `json:"unitAmount"      description:"unit amount excluding tax, cent, negative for credit"`
+	Quantity        int64  `json:"quantity"        description:"quantity"`
+	Amount          int64  `json:"amount"          description:"amount excluding tax, cent, unitAmount * quantity"`
+	Proration       bool   `json:"proration"       description:"proration or not"`
+	TaxPercentage   *int64 `json:"taxPercentage"   description:"tax percentage override，1000 = 10%, null follow the invoice"`
+	Source          string `json:"source"          description:"source，SubscriptionUpdate|Merchant"`
+	PendingUpdateId string `json:"pendingUpdateId" description:"pending update id of the subscription update created the item"`
+	Status          int    `json:"status"          description:"status，1-Pending｜2-Invoiced"`
+	InvoiceId       string `json:"invoiceId"       description:"invoice id of the cycle invoice the item merged into"`
+	InvoiceTime     int64  `json:"invoiceTime"     description:"utc time the item merged into invoice"`
+	CreateTime      int64  `json:"createTime"      description:"create utc time"`
+}
+
+func SimplifySubscriptionPendingInvoiceItem(one *entity.SubscriptionPendingInvoiceItem) *SubscriptionPendingInvoiceItem {
+	if one == nil {
+		return nil
+	}
+	var taxPercentage *int64
+	if one.TaxPercentage >= 0 {
+		taxPercentage = &one.TaxPercentage
+	}
+	return &SubscriptionPendingInvoiceItem{
+		SubscriptionId:  one.SubscriptionId,
+		ItemId:          one.ItemId,
+		PlanId:          one.PlanId,
+		Name:            one.Name,
+		Description:     one.Description,
+		Currency:        one.Currency,
+		UnitAmount:      one.UnitAmount,
+		Quantity:        one.Quantity,
+		Amount:          one.Amount,
+		Proration:       one.Proration == 1,
+		TaxPercentage:   taxPercentage,
+		Source:          one.Source,
+		PendingUpdateId: one.PendingUpdateId,
+		Status:          one.Status,
+		InvoiceId:       one.InvoiceId,
+		InvoiceTime:     one.InvoiceTime,
+		CreateTime:      one.CreateTime,
+	}
+}
@@ -0,0 +1,88 @@
+package bean
+
+import (
+	entity "unibee/internal/model/entity/default"
+	"unibee/utility"
+)
+
+type SubscriptionSchedule struct {
+	MerchantId      uint64                       `json:"merchantId"      description:"merchant id"`
+	UserId          uint64                       `json:"userId"          description:"userId"`
+	SubscriptionId  string                       `json:"subscriptionId"  description:"subscription id"`
+	ScheduleId      string                       `json:"scheduleId"      description:"schedule unique id"`
+	Status          int                          `json:"status"          description:"status，1-Active｜2-Completed｜3-Released"`
+	EndBehavior     string                       `json:"endBehavior"     description:"behavior after the last phase，release-subscription renews on the last phase|cancel-subscription cancelled at the end of the last phase"`
+	CurrentPhase    int                          `json:"currentPhase"    description:"index of the current phase, 0-not started"`
+	PhaseCycleCount int                          `json:"phaseCycleCount" description:"billing cycles of the current phase started"`
+	StartTime       int64                        `json:"startTime"       description:"utc time the first phase started"`
+	EndTime         int64                        `json:"endTime"         description:"utc time the schedule completed or released"`
+	CreateTime      int64                        `json:"createTime"      description:"create utc time"`
+	Phases          []*SubscriptionSchedulePhase `json:"phases"          description:"phases of the schedule, ordered by phase index"`
+}
+
+type SubscriptionSchedulePhase struct {
+	PhaseIndex      int               `json:"phaseIndex"      description:"index of the phase, start with 1"`
+	PlanId          uint64            `json:"planId"          description:"plan id of the phase"`
+	Quantity        int64             `json:"quantity"        description:"quantity of the phase"`
+	AddonParams     []*PlanAddonParam `json:"addonParams"     description:"addons of the phase"`
+	DiscountCode    string            `json:"discountCode"    description:"recurring discount code of the phase"`
+	Cycles          int               `json:"cycles"          description:"billing cycles of the phase, 0-renew on the phase without end"`
+	Status          int               `json:"status"          description:"status，1-Pending｜2-Active｜3-Completed｜4-Cancelled"`
+	StartTime       int64             `json:"startTime"       description:"utc time the phase started"`
+	EndTime         int64             `json:"endTime"         description:"utc time the phase ended"`
+	PendingUpdateId string            `json:"pendingUpdateId" description:"pending update applying the phase to the subscription"`
+}
+
+type SubscriptionSchedulePhaseParam struct {
+	PlanId       uint64            `json:"planId"       dc:"PlanId of the phase" v:"required"`
+	Quantity     int64             `json:"quantity"     dc:"Quantity of the phase，Default 1"`
+	AddonParams  []*PlanAddonParam `json:"addonParams"  dc:"Addons of the phase"`
+	DiscountCode string            `json:"discountCode" dc:"Recurring DiscountCode applied to the invoices of the phase"`
+	Cycles       int               `json:"cycles"       dc:"Billing cycles of the phase, 0 renews on the phase without end and only available for the last phase"`
+}
+
+func SimplifySubscriptionSchedule(one *entity.SubscriptionSchedule, phases []*entity.SubscriptionSchedulePhase) *SubscriptionSchedule {
+	if one == nil {
+		return nil
+	}
+	list := make([]*SubscriptionSchedulePhase, 0)
+	for _, phase := range phases {
+		list = append(list, SimplifySubscriptionSchedulePhase(phase))
+	}
+	return &SubscriptionSchedule{
+		MerchantId:      one.MerchantId,
+		UserId:          one.UserId,
+		SubscriptionId:  one.SubscriptionId,
+		ScheduleId:      one.ScheduleId,
+		Status:          one.Status,
+		EndBehavior:     one.EndBehavior,
+		CurrentPhase:    one.CurrentPhase,
+		PhaseCycleCount: one.PhaseCycleCount,
+		StartTime:       one.StartTime,
+		EndTime:         one.EndTime,
+		CreateTime:      one.CreateTime,
+		Phases:          list,
+	}
+}
+
+func SimplifySubscriptionSchedulePhase(one *entity.SubscriptionSchedulePhase) *SubscriptionSchedulePhase {
+	if one == nil {
+		return nil
+	}
+	var addonParams []*PlanAddonParam
+	if len(one.AddonData) > 0 {
+		_ = utility.UnmarshalFromJsonString(one.AddonData, &addonParams)
+	}
+	return &SubscriptionSchedulePhase{
+		PhaseIndex:      one.PhaseIndex,
+		PlanId:          one.PlanId,
+		Quantity:        one.Quantity,
+		AddonParams:     addonParams,
+		DiscountCode:    one.DiscountCode,
+		Cycles:          one.Cycles,
+		Status:          one.Status,
+		StartTime:       one.StartTime,
+		EndTime:         one.EndTime,
+		PendingUpdateId: one.PendingUpdateId,
+	}
+}
@@ -0,0 +1,69 @@
+package bean
+
+import (
+	entity "unibee/internal/model/entity/default"
+)
+
+type SubscriptionSeat struct {
+	SubscriptionId string `json:"subscriptionId" description:"subscription id"`
+	SeatId         string `json:"seatId"         description:"seat unique id"`
+	Email          string `json:"email"          description:"email of the seat member"`
+	ExternalId     string `json:"externalId"     description:"external id of the seat member"`
+	Name           string `json:"name"           description:"name of the seat member"`
+	Status         int    `json:"status"         description:"status，1-Assigned｜2-Removed"`
+	AssignTime     int64  `json:"assignTime"     description:"utc time the seat assigned"`
+	RemoveTime     int64  `json:"removeTime"     description:"utc time the seat removed"`
+}
+
+type SubscriptionSeatChange struct {
+	SubscriptionId  string `json:"subscriptionId"  description:"subscription id"`
+	FromQuantity    int64  `json:"fromQuantity"    description:"seats before the change"`
+	ToQuantity      int64  `json:"toQuantity"      description:"seats after the change"`
+	ProrationMode   string `json:"prorationMode"   description:"proration mode，immediate|true_up"`
+	ChangeTime      int64  `json:"changeTime"      description:"utc time of the change"`
+	PeriodStart     int64  `json:"periodStart"     description:"period start of the subscription when changed"`
+	PeriodEnd       int64  `json:"periodEnd"       description:"period end of the subscription when changed"`
+	Status          int    `json:"status"          description:"status，1-Pending true-up｜2-Billed｜3-Applied without true-up"`
+	PendingUpdateId string `json:"pendingUpdateId" description:"pending update id of the immediate change"`
+	InvoiceId       string `json:"invoiceId"       description:"invoice id billed the true-up of the change"`
+}
+
+type SubscriptionSeatParam struct {
+	Email      string `json:"email"      dc:"Email of the seat member, either Email or ExternalId needed"`
+	ExternalId string `json:"externalId" dc:"ExternalId of the seat member, either Email or ExternalId needed"`
+	Name       string `json:"name"       dc:"Name of the seat member"`
+}
+
+func SimplifySubscriptionSeat(one *entity.SubscriptionSeat) *SubscriptionSeat {
+	if one == nil {
+		return nil
+	}
+	return &SubscriptionSeat{
+		SubscriptionId: one.SubscriptionId,
+		SeatId:         one.SeatId,
+		Email:          one.Email,
+		ExternalId:     one.ExternalId,
+		Name:           one.Name,
+		Status:         one.Status,
+		AssignTime:     one.AssignTime,
+		RemoveTime:     one.RemoveTime,
+	}
+}
+
+func SimplifySubscriptionSeatChange(one *entity.SubscriptionSeatChange) *SubscriptionSeatChange {
+	if one == nil {
+		return nil
+	}
+	return &SubscriptionSeatChange{
+		SubscriptionId:  one.SubscriptionId,
+		FromQuantity:    one.FromQuantity,
+		ToQuantity:      one.ToQuantity,
+		ProrationMode:   one.ProrationMode,
+		ChangeTime:      one.ChangeTime,
+		PeriodStart:     one.PeriodStart,
+		PeriodEnd:       one.PeriodEnd,
+		Status:          one.Status,
+		PendingUpdateId: one.PendingUpdateId,
+		InvoiceId:       one.InvoiceId,
+	}
+}
@@ -0,0 +1,44 @@
+package bean
+
+import (
+	"strings"
+
+	entity "unibee/internal/model/entity/default"
+)
+
+type SubscriptionTransfer struct {
+	TransferId       string   `json:"transferId"       description:"transfer unique id"`
+	SubscriptionId   string   `json:"subscriptionId"   description:"subscription id"`
+	FromUserId       uint64   `json:"fromUserId"       description:"userId the subscription transferred from"`
+	ToUserId         uint64   `json:"toUserId"         description:"userId the subscription transferred to"`
+	PlanId           uint64   `json:"planId"           description:"plan id of the subscription at transfer"`
+	InvoiceIds       []string `json:"invoiceIds"       description:"unpaid invoice ids transferred"`
+	MetricEventCount int64    `json:"metricEventCount" description:"count of unbilled metric events transferred"`
+	GatewayId        uint64   `json:"gatewayId"        description:"gateway id of the payment method used after transfer"`
+	PaymentMethodId  string   `json:"paymentMethodId"  description:"payment method used after transfer"`
+	Reason           string   `json:"reason"           description:"transfer reason"`
+	CreateTime       int64    `json:"createTime"       description:"create utc time"`
+}
+
+func SimplifySubscriptionTransfer(one *entity.SubscriptionTransfer) *SubscriptionTransfer {
+	if one == nil {
+		return nil
+	}
+	var invoiceIds = make([]string, 0)
+	if len(one.InvoiceIds) > 0 {
+		invoiceIds = strings.Split(one.InvoiceIds, ",")
+	}
+	return &SubscriptionTransfer{
+		TransferId:       one.TransferId,
+		SubscriptionId:   one.SubscriptionId,
+		FromUserId:       one.FromUserId,
+		ToUserId:         one.ToUserId,
+		PlanId:           one.PlanId,
+		InvoiceIds:       invoiceIds,
+		MetricEventCount: one.MetricEventCount,
+		GatewayId:        one.GatewayId,
+		PaymentMethodId:  one.PaymentMethodId,
+		Reason:           one.Reason,
+		CreateTime:       one.CreateTime,
+	}
+}
@@ -0,0 +1,53 @@
+package bean
+
+import (
+	entity "unibee/internal/model/entity/default"
+)
+
+type SubscriptionTrial struct {
+	TrialId           string `json:"trialId"           description:"trial unique id"`
+	SubscriptionId    string `json:"subscriptionId"    description:"subscription id"`
+	UserId            uint64 `json:"userId"            description:"userId"`
+	PlanId            uint64 `json:"planId"            description:"plan id of the trial"`
+	TrialStart        int64  `json:"trialStart"        description:"utc time the trial started"`
+	TrialEnd          int64  `json:"trialEnd"          description:"utc time the trial ends"`
+	TrialEndBehavior  string `json:"trialEndBehavior"  description:"behaviour at trial end without payment method，convert|pause|cancel"`
+	ReminderTime      int64  `json:"reminderTime"      description:"utc time the trial ending reminder sent"`
+	PaymentMethodTime int64  `json:"paymentMethodTime" description:"utc time the payment method attached"`
+	Status            int    `json:"status"            description:"status，1-Trialing｜2-Converted｜3-Paused｜4-Cancelled｜5-Expired"`
+	FinishTime        int64  `json:"finishTime"        description:"utc time the trial converted or ended"`
+	CreateTime        int64  `json:"createTime"        description:"create utc time"`
+}
+
+type SubscriptionTrialReport struct {
+	PlanId                uint64 `json:"planId"                description:"plan id"`
+	PlanName              string `json:"planName"              description:"plan name"`
+	Started               int64  `json:"started"               description:"card-less trials started"`
+	Trialing              int64  `json:"trialing"              description:"trials not ended yet"`
+	PaymentMethodAttached int64  `json:"paymentMethodAttached" description:"trials the payment method attached to"`
+	Converted             int64  `json:"converted"             description:"trials converted to paid"`
+	Paused                int64  `json:"paused"                description:"trials paused at trial end"`
+	Cancelled             int64  `json:"cancelled"             description:"trials cancelled"`
+	Expired               int64  `json:"expired"               description:"trials expired"`
+	ConversionRate        int64  `json:"conversionRate"        description:"converted / finished trials，10000 = 100%"`
+}
+
+func SimplifySubscriptionTrial(one *entity.SubscriptionTrial) *SubscriptionTrial {
+	if one == nil {
+		return nil
+	}
+	return &SubscriptionTrial{
+		TrialId:           one.TrialId,
+		SubscriptionId:    one.SubscriptionId,
+		UserId:            one.UserId,
+		PlanId:            one.PlanId,
+		TrialStart:        one.TrialStart,
+		TrialEnd:          one.TrialEnd,
+		TrialEndBehavior:  one.TrialEndBehavior,
+		ReminderTime:      one.ReminderTime,
+		PaymentMethodTime: one.PaymentMethodTime,
+		Status:            one.Status,
+		FinishTime:        one.FinishTime,
+		CreateTime:        one.CreateTime,
+	}
+}
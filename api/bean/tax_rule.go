@@ -0,0 +1,50 @@
+package bean
+
+import (
+	entity "unibee/internal/model/entity/default"
+)
+
+type MerchantTaxRule struct {
+	Id            uint64 `json:"id"            description:"id"`                                                                     // id
+	MerchantId    uint64 `json:"merchantId"    description:"merchant_id"`                                                            // merchant_id
+	Name          string `json:"name"          description:"tax name shown on invoice, like GST, PST, State Tax"`                    // tax name shown on invoice, like GST, PST, State Tax
+	Jurisdiction  string `json:"jurisdiction"  description:"jurisdiction label, like US-CA, US-CA-Los Angeles"`                      // jurisdiction label, like US-CA, US-CA-Los Angeles
+	CountryCode   string `json:"countryCode"   description:"country code"`                                                           // country code
+	RegionCode    string `json:"regionCode"    description:"state or province code, empty matches all regions"`                      // state or province code, empty matches all regions
+	City          string `json:"city"          description:"city, empty matches all cities"`                                         // city, empty matches all cities
+	ZipCodePrefix string `json:"zipCodePrefix" description:"zip code prefix, empty matches all zip codes"`                           // zip code prefix, empty matches all zip codes
+	TaxCategory   string `json:"taxCategory"   description:"tax category of plan, empty matches all categories"`                     // tax category of plan, empty matches all categories
+	Rate          int64  `json:"rate"          description:"tax rate, 1000 = 10%"`                                                   // tax rate, 1000 = 10%
+	Compound      int    `json:"compound"      description:"0-on amount excluding tax, 1-compound on amount and the previous taxes"` // 0-on amount excluding tax, 1-compound on amount and the previous taxes
+	Priority      int    `json:"priority"      description:"apply order, lower first"`                                               // apply order, lower first
+	CreateTime    int64  `json:"createTime"    description:"create utc time"`                                                        // create utc time
+}
+
+func SimplifyMerchantTaxRule(one *entity.MerchantTaxRule) *MerchantTaxRule {
+	if one == nil {
+		return nil
+	}
+	return &MerchantTaxRule{
+		Id:            one.Id,
+		MerchantId:    one.MerchantId,
+		Name:          one.Name,
+		Jurisdiction:  one.Jurisdiction,
+		CountryCode:   one.CountryCode,
+		RegionCode:    one.RegionCode,
+		City:          one.City,
+		ZipCodePrefix: one.ZipCodePrefix,
+		TaxCategory:   one.TaxCategory,
+		Rate:          one.Rate,
+		Compound:      one.Compound,
+		Priority:      one.Priority,
+		CreateTime:    one.CreateTime,
+	}
+}
+
+func SimplifyMerchantTaxRuleList(list []*entity.MerchantTaxRule) []*MerchantTaxRule {
+	var result = make([]*MerchantTaxRule, 0)
+	for _, one := range list {
+		result = append(result, SimplifyMerchantTaxRule(one))
+	}
+	return result
+}
@@ -0,0 +1,65 @@
+package bank_statement
+
+import (
+	"unibee/api/bean"
+	"unibee/api/bean/detail"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+)
+
+type ImportReq struct {
+	g.Meta `path:"/import" method:"post" mime:"multipart/form-data" tags:"BankStatement" summary:"Import Bank Statement" dc:"Import the bank statement and match its incoming credits to the processing invoices by reference, amount and currency, the exact matches are marked paid, the ambiguous ones are queued for review, the credits imported before are skipped"`
+	File   *ghttp.UploadFile `json:"file" type:"file" dc:"The statement file, CAMT.053 xml, MT940 or csv with header row" v:"required"`
+	Format string            `json:"format" dc:"Format of the file, camt053|mt940|csv, detected by the file if not specified"`
+}
+
+type ImportRes struct {
+	Statement *bean.BankStatement       `json:"statement" dc:"Bank Statement Object"`
+	Lines     []*bean.BankStatementLine `json:"lines" dc:"Credit lines imported from the statement"`
+}
+
+type ListReq struct {
+	g.Meta `path:"/list" tags:"BankStatement" method:"get,post" summary:"Get Bank Statement List"`
+	Page   int `json:"page"  dc:"Page, Start With 0" `
+	Count  int `json:"count"  dc:"Count Of Page" `
+}
+
+type ListRes struct {
+	Statements []*bean.BankStatement `json:"statements" dc:"Bank Statement List"`
+	Total      int                   `json:"total" dc:"Total"`
+}
+
+type LineListReq struct {
+	g.Meta      `path:"/line_list" tags:"BankStatement" method:"get,post" summary:"Get Bank Statement Line List" dc:"Credit lines of the imported statements, filter status 2 for the review queue"`
+	StatementId uint64 `json:"statementId" dc:"The id of bank statement"`
+	Status      []int  `json:"status" dc:"Filter Status, 1-auto matched|2-need review|3-unmatched|4-manual matched|5-ignored"`
+	Page        int    `json:"page"  dc:"Page, Start With 0" `
+	Count       int    `json:"count"  dc:"Count Of Page" `
+}
+
+type LineListRes struct {
+	Lines []*bean.BankStatementLine `json:"lines" dc:"Bank Statement Line List"`
+	Total int                       `json:"total" dc:"Total"`
+}
+
+type LineConfirmReq struct {
+	g.Meta    `path:"/line_confirm" tags:"BankStatement" method:"post" summary:"Confirm Bank Statement Line" dc:"Apply the credit in review or unmatched to the invoice, the invoice is marked paid when the credit covers its outstanding amount, otherwise the credit is recorded as partial payment"`
+	LineId    uint64 `json:"lineId" dc:"The id of bank statement line" v:"required"`
+	InvoiceId string `json:"invoiceId" dc:"The unique id of the processing invoice the credit pays" v:"required"`
+}
+
+type LineConfirmRes struct {
+	Line    *bean.BankStatementLine `json:"line" dc:"Bank Statement Line Object"`
+	Invoice *detail.InvoiceDetail   `json:"invoice" dc:"Invoice Detail Object"`
+}
+
+type LineIgnoreReq struct {
+	g.Meta `path:"/line_ignore" tags:"BankStatement" method:"post" summary:"Ignore Bank Statement Line" dc:"Remove the credit not paying any invoice from the review queue"`
+	LineId uint64 `json:"lineId" dc:"The id of bank statement line" v:"required"`
+	Note   string `json:"note" dc:"The note of ignore action"`
+}
+
+type LineIgnoreRes struct {
+	Line *bean.BankStatementLine `json:"line" dc:"Bank Statement Line Object"`
+}
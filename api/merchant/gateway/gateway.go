@@ -150,3 +150,13 @@ type SetupExchangeApiReq struct {
 type SetupExchangeApiRes struct {
 	Data string `json:"data"  dc:"The hide star key of exchange rate api"`
 }
+
+type SetupReconciliationReq struct {
+	g.Meta   `path:"/setup_reconciliation" tags:"Gateway" method:"post" summary:"Gateway Reconciliation Setup" dc:"Setup the daily reconciliation of all gateways, each run creates a GatewayReconciliationExport batch task for the previous utc day. Run it manually with /merchant/task/new_export, task GatewayReconciliationExport, payload gatewayId, startTime, endTime, autoHeal"`
+	Enable   *bool `json:"enable"  dc:"Run the daily reconciliation, keep unchanged if not specified"`
+	AutoHeal *bool `json:"autoHeal"  dc:"Apply the gateway final status to local pending payments and refunds, keep unchanged if not specified"`
+}
+type SetupReconciliationRes struct {
+	Enable   bool `json:"enable"  dc:"Daily reconciliation enabled"`
+	AutoHeal bool `json:"autoHeal"  dc:"Auto heal enabled"`
+}
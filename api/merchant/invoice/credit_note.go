@@ -3,7 +3,9 @@ package invoice
 import (
 	"github.com/gogf/gf/v2/frame/g"
 	"github.com/gogf/gf/v2/net/ghttp"
+	"unibee/api/bean"
 	"unibee/api/bean/detail"
+	"unibee/internal/logic/invoice/credit_note"
 )
 
 type CreditNoteListReq struct {
@@ -27,3 +29,53 @@ type CreditNoteListRes struct {
 	CreditNotes []*detail.CreditNoteDetail `json:"creditNotes" dc:"CreditNote Detail Object List"`
 	Total       int                        `json:"total" dc:"Total"`
 }
+
+type CreditNoteNewReq struct {
+	g.Meta    `path:"/credit_note/new" tags:"Invoice" method:"post" summary:"New Credit Note" dc:"Issue a credit note against lines of a paid invoice, refunded to the original payment method or credited to the customer balance"`
+	InvoiceId string                       `json:"invoiceId" dc:"The unique id of the paid invoice to credit" v:"required"`
+	Type      int                          `json:"type" dc:"1-refund to the original payment method, 2-credit to customer balance, default 1"`
+	Lines     []*credit_note.CreditLineReq `json:"lines" dc:"The credited lines, each line credits either a quantity or an amount excluding tax" v:"required"`
+	Reason    string                       `json:"reason" dc:"Reason"`
+}
+
+type CreditNoteNewRes struct {
+	CreditNote *bean.CreditNote `json:"creditNote" dc:"Credit Note Object"`
+}
+
+type CreditNotePreviewReq struct {
+	g.Meta    `path:"/credit_note/preview" tags:"Invoice" method:"post" summary:"Preview Credit Note" dc:"Compute the credited lines and tax of a credit note without issuing it"`
+	InvoiceId string                       `json:"invoiceId" dc:"The unique id of the paid invoice to credit" v:"required"`
+	Type      int                          `json:"type" dc:"1-refund to the original payment method, 2-credit to customer balance, default 1"`
+	Lines     []*credit_note.CreditLineReq `json:"lines" dc:"The credited lines, each line credits either a quantity or an amount excluding tax" v:"required"`
+}
+
+type CreditNotePreviewRes struct {
+	Preview *credit_note.PreviewInternalRes `json:"preview" dc:"Credit Note Preview"`
+}
+
+type CreditNoteDetailReq struct {
+	g.Meta       `path:"/credit_note/detail" tags:"Invoice" method:"get,post" summary:"Credit Note Detail"`
+	CreditNoteId string `json:"creditNoteId" dc:"The unique id of credit note" v:"required"`
+}
+
+type CreditNoteDetailRes struct {
+	CreditNote      *bean.CreditNote `json:"creditNote" dc:"Credit Note Object"`
+	OriginalInvoice *bean.Invoice    `json:"originalInvoice" dc:"The credited invoice"`
+	Refund          *bean.Refund     `json:"refund" dc:"The refund of credit note, type 1 only"`
+	PdfLink         string           `json:"pdfLink" dc:"The pdf link of credit note"`
+}
+
+type CreditNoteDocumentListReq struct {
+	g.Meta    `path:"/credit_note/document_list" tags:"Invoice" method:"get,post" summary:"Credit Note Document List" dc:"List the credit notes issued against invoice lines"`
+	InvoiceId string `json:"invoiceId" dc:"Filter by the credited invoice"`
+	UserId    uint64 `json:"userId" dc:"Filter by user"`
+	Type      int    `json:"type" dc:"Filter by type, 1-refund, 2-balance"`
+	Status    []int  `json:"status" dc:"Filter by status, 10-issued, 20-failed"`
+	Page      int    `json:"page"  dc:"Page, Start 0" `
+	Count     int    `json:"count"  dc:"Count By Page" `
+}
+
+type CreditNoteDocumentListRes struct {
+	CreditNotes []*bean.CreditNote `json:"creditNotes" dc:"Credit Note Object List"`
+	Total       int                `json:"total" dc:"Total"`
+}
@@ -0,0 +1,39 @@
+package invoice
+
+import (
+	"unibee/api/bean/detail"
+	"unibee/internal/logic/invoice/draft"
+
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+type DraftEditReq struct {
+	g.Meta       `path:"/draft/edit" tags:"Invoice" method:"post" summary:"Edit Draft Invoice" dc:"Edit the lines of the cycle invoice in review, add or remove lines, adjust quantities or add a one-off credit. Member needs the review permission of invoice"`
+	InvoiceId    string           `json:"invoiceId" dc:"The unique id of the draft invoice in review" v:"required"`
+	Lines        []*draft.LineReq `json:"lines" dc:"Lines of the draft invoice, existing lines not listed are removed" v:"required"`
+	CreditAmount int64            `json:"creditAmount" dc:"One-off credit excluding tax added to the draft invoice as a negative line, cent"`
+	CreditReason string           `json:"creditReason" dc:"Reason of the one-off credit"`
+}
+
+type DraftEditRes struct {
+	Invoice *detail.InvoiceDetail `json:"invoice" dc:"Invoice Detail Object"`
+}
+
+type DraftHoldReq struct {
+	g.Meta    `path:"/draft/hold" tags:"Invoice" method:"post" summary:"Hold Draft Invoice" dc:"Hold or release the automatic finalisation of the cycle invoice in review. Member needs the review permission of invoice"`
+	InvoiceId string `json:"invoiceId" dc:"The unique id of the draft invoice in review" v:"required"`
+	Hold      bool   `json:"hold" dc:"true-hold, false-release, the released draft is finalised by the billing cycle once its review window passed"`
+}
+
+type DraftHoldRes struct {
+	Invoice *detail.InvoiceDetail `json:"invoice" dc:"Invoice Detail Object"`
+}
+
+type DraftFinalizeReq struct {
+	g.Meta    `path:"/draft/finalize" tags:"Invoice" method:"post" summary:"Finalize Draft Invoice" dc:"Finalize the cycle invoice in review immediately, the billing cycle charges it afterwards. Member needs the review permission of invoice"`
+	InvoiceId string `json:"invoiceId" dc:"The unique id of the draft invoice in review" v:"required"`
+}
+
+type DraftFinalizeRes struct {
+	Invoice *detail.InvoiceDetail `json:"invoice" dc:"Invoice Detail Object"`
+}
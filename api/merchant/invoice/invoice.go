@@ -4,6 +4,9 @@ import (
 	"github.com/gogf/gf/v2/frame/g"
 	"unibee/api/bean"
 	"unibee/api/bean/detail"
+	"unibee/internal/logic/invoice/einvoice"
+	"unibee/internal/logic/invoice/handler/generator"
+	"unibee/internal/logic/invoice/invoice_number"
 )
 
 type PdfGenerateReq struct {
@@ -193,3 +196,84 @@ type MarkRefundInvoiceSuccessReq struct {
 
 type MarkRefundInvoiceSuccessRes struct {
 }
+
+type NumberingConfigReq struct {
+	g.Meta `path:"/numbering_config" tags:"Invoice" method:"get" summary:"Get Invoice Numbering Config" dc:"Get the sequential invoice numbering config of merchant"`
+}
+
+type NumberingConfigRes struct {
+	Config *invoice_number.Config `json:"config" dc:"Invoice Numbering Config"`
+}
+
+type NumberingSetupReq struct {
+	g.Meta           `path:"/numbering_setup" tags:"Invoice" method:"post" summary:"Setup Invoice Numbering" dc:"Setup the sequential invoice numbering, a number is allocated when an invoice leaves pending and never reused, credit notes use a separate series. Invoices issued before enable keep their invoiceId as number"`
+	Enable           *bool   `json:"enable" dc:"Allocate sequential invoice numbers, keep unchanged if not specified"`
+	Pattern          *string `json:"pattern" dc:"Number pattern, placeholders {PREFIX} {YYYY} {YY} {MM} {SEQ}, {PREFIX} and {SEQ} are required, the counter restarts every year when {YYYY} or {YY} is used and every month when {MM} is used as well, default {PREFIX}{YYYY}-{SEQ}"`
+	Prefix           *string `json:"prefix" dc:"Prefix of the invoice series, default INV-"`
+	CreditNotePrefix *string `json:"creditNotePrefix" dc:"Prefix of the credit note series, should differ from the invoice prefix, default CN-"`
+	Padding          *int    `json:"padding" dc:"Zero padded width of the counter, default 6"`
+}
+
+type NumberingSetupRes struct {
+	Config *invoice_number.Config `json:"config" dc:"Invoice Numbering Config"`
+}
+
+type EInvoiceReq struct {
+	g.Meta    `path:"/e_invoice" tags:"Invoice" method:"get" summary:"Get E-Invoice" dc:"Render the invoice or credit note as UBL 2.1 (Peppol BIS Billing 3.0) or CII (Factur-X/ZUGFeRD EN16931) xml with the business rule validation results"`
+	InvoiceId string `json:"invoiceId" dc:"The unique id of invoice" v:"required"`
+	Format    string `json:"format" dc:"ubl|cii, default ubl"`
+}
+
+type EInvoiceRes struct {
+	Xml          string                       `json:"xml" dc:"E-Invoice xml"`
+	Validations  []*einvoice.ValidationResult `json:"validations" dc:"Failed business rules, the xml is only valid for exchange when no fatal rule failed"`
+	Valid        bool                         `json:"valid" dc:"No fatal rule failed"`
+	DownloadLink string                       `json:"downloadLink" dc:"Public download link of the xml"`
+}
+
+type EInvoiceConfigReq struct {
+	g.Meta `path:"/e_invoice_config" tags:"Invoice" method:"get" summary:"Get E-Invoice Config" dc:"Get the e-invoicing config of merchant"`
+}
+
+type EInvoiceConfigRes struct {
+	Config *einvoice.Config `json:"config" dc:"E-Invoice Config"`
+}
+
+type EInvoiceSetupReq struct {
+	g.Meta         `path:"/e_invoice_setup" tags:"Invoice" method:"post" summary:"Setup E-Invoice" dc:"Setup the e-invoicing, the Factur-X xml is embedded into invoice PDFs generated afterwards"`
+	EmbedFacturX   *bool   `json:"embedFacturX" dc:"Embed the CII xml into invoice PDFs as factur-x.xml, keep unchanged if not specified"`
+	EndpointScheme *string `json:"endpointScheme" dc:"EAS scheme of the seller Peppol electronic address, eg 0088 or 9930, default EM with the merchant email"`
+	EndpointId     *string `json:"endpointId" dc:"Seller Peppol electronic address, default the merchant email"`
+}
+
+type EInvoiceSetupRes struct {
+	Config *einvoice.Config `json:"config" dc:"E-Invoice Config"`
+}
+
+type PdfTemplateReq struct {
+	g.Meta `path:"/pdf_template" tags:"Invoice" method:"get" summary:"Get Invoice PDF Template" dc:"Get the invoice PDF template of merchant, null if the default layout is used"`
+}
+
+type PdfTemplateRes struct {
+	Template *generator.Template `json:"template" dc:"Invoice PDF Template"`
+	Locales  []string            `json:"locales" dc:"Supported locales"`
+	Labels   []string            `json:"labels" dc:"Label names accepted by the template labels"`
+}
+
+type PdfTemplateSetupReq struct {
+	g.Meta   `path:"/pdf_template_setup" tags:"Invoice" method:"post" summary:"Setup Invoice PDF Template" dc:"Setup the invoice PDF template of merchant, applied to invoice PDFs generated afterwards"`
+	Template *generator.Template `json:"template" dc:"Invoice PDF Template, replace the current one" v:"required"`
+}
+
+type PdfTemplateSetupRes struct {
+	Template *generator.Template `json:"template" dc:"Invoice PDF Template"`
+}
+
+type PdfTemplatePreviewReq struct {
+	g.Meta   `path:"/pdf_template_preview" tags:"Invoice" method:"post" summary:"Preview Invoice PDF Template" dc:"Render a sample invoice PDF with the template"`
+	Template *generator.Template `json:"template" dc:"Invoice PDF Template to preview, the current one if not specified"`
+}
+
+type PdfTemplatePreviewRes struct {
+	Url string `json:"url" dc:"The url of sample invoice PDF"`
+}
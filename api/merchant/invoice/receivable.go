@@ -0,0 +1,42 @@
+package invoice
+
+import (
+	"unibee/api/bean"
+	"unibee/api/bean/detail"
+
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+type PartialPaymentNewReq struct {
+	g.Meta         `path:"/partial_payment_new" tags:"Invoice" method:"post" summary:"Record Invoice Partial Payment" dc:"Record a payment received outside the gateway against the processing net term or wire transfer invoice, the invoice is marked as paid once the received amount reaches its total amount, the partially paid invoice is no longer charged by the gateway"`
+	InvoiceId      string `json:"invoiceId" dc:"The unique id of invoice" v:"required"`
+	Amount         int64  `json:"amount" dc:"The received amount, cent" v:"required"`
+	Currency       string `json:"currency" dc:"The currency of the received amount, should match the invoice's currency"`
+	TransferNumber string `json:"transferNumber" dc:"The transfer number of the payment" v:"required"`
+	Reason         string `json:"reason" dc:"The reason of record action"`
+	PaidTime       int64  `json:"paidTime" dc:"The utc time the payment received, default now"`
+}
+
+type PartialPaymentNewRes struct {
+	Invoice        *detail.InvoiceDetail       `json:"invoice" dc:"Invoice Detail Object"`
+	PartialPayment *bean.InvoicePartialPayment `json:"partialPayment" dc:"Partial Payment Object"`
+}
+
+type PartialPaymentListReq struct {
+	g.Meta    `path:"/partial_payment_list" tags:"Invoice" method:"get,post" summary:"Invoice Partial Payment List" dc:"Get the partial payments recorded against the invoice"`
+	InvoiceId string `json:"invoiceId" dc:"The unique id of invoice" v:"required"`
+}
+
+type PartialPaymentListRes struct {
+	PartialPayments []*bean.InvoicePartialPayment `json:"partialPayments" dc:"Partial Payment Object List"`
+}
+
+type ReceivableAgeingReq struct {
+	g.Meta   `path:"/receivable_ageing" tags:"Invoice" method:"get,post" summary:"Accounts Receivable Ageing" dc:"Get the outstanding amounts of the open invoices per currency, bucketed by days past due into current|1-30|31-60|61-90|90+, export the invoice rows with /merchant/task/new_export, task ReceivableAgeingExport"`
+	Currency string `json:"currency" dc:"Filter by currency, default all currencies"`
+	AsOfTime int64  `json:"asOfTime" dc:"The utc time the ageing computed at, default now"`
+}
+
+type ReceivableAgeingRes struct {
+	Ageings []*bean.ReceivableAgeing `json:"ageings" dc:"Receivable Ageing Object List"`
+}
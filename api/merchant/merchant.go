@@ -8,6 +8,7 @@ import (
 	"context"
 
 	"unibee/api/merchant/auth"
+	"unibee/api/merchant/bank_statement"
 	"unibee/api/merchant/checkout"
 	"unibee/api/merchant/credit"
 	"unibee/api/merchant/discount"
@@ -25,6 +26,7 @@ import (
 	"unibee/api/merchant/role"
 	"unibee/api/merchant/search"
 	"unibee/api/merchant/session"
+	"unibee/api/merchant/settlement"
 	"unibee/api/merchant/subscription"
 	"unibee/api/merchant/task"
 	"unibee/api/merchant/track"
@@ -33,6 +35,14 @@ import (
 	"unibee/api/merchant/webhook"
 )
 
+type IMerchantBankStatement interface {
+	Import(ctx context.Context, req *bank_statement.ImportReq) (res *bank_statement.ImportRes, err error)
+	List(ctx context.Context, req *bank_statement.ListReq) (res *bank_statement.ListRes, err error)
+	LineList(ctx context.Context, req *bank_statement.LineListReq) (res *bank_statement.LineListRes, err error)
+	LineConfirm(ctx context.Context, req *bank_statement.LineConfirmReq) (res *bank_statement.LineConfirmRes, err error)
+	LineIgnore(ctx context.Context, req *bank_statement.LineIgnoreReq) (res *bank_statement.LineIgnoreRes, err error)
+}
+
 type IMerchantAuth interface {
 	Login(ctx context.Context, req *auth.LoginReq) (res *auth.LoginRes, err error)
 	LoginOAuth(ctx context.Context, req *auth.LoginOAuthReq) (res *auth.LoginOAuthRes, err error)
@@ -125,6 +135,7 @@ type IMerchantGateway interface {
 	WireTransferSetup(ctx context.Context, req *gateway.WireTransferSetupReq) (res *gateway.WireTransferSetupRes, err error)
 	WireTransferEdit(ctx context.Context, req *gateway.WireTransferEditReq) (res *gateway.WireTransferEditRes, err error)
 	SetupExchangeApi(ctx context.Context, req *gateway.SetupExchangeApiReq) (res *gateway.SetupExchangeApiRes, err error)
+	SetupReconciliation(ctx context.Context, req *gateway.SetupReconciliationReq) (res *gateway.SetupReconciliationRes, err error)
 }
 
 type IMerchantIntegration interface {
@@ -134,6 +145,10 @@ type IMerchantIntegration interface {
 
 type IMerchantInvoice interface {
 	CreditNoteList(ctx context.Context, req *invoice.CreditNoteListReq) (res *invoice.CreditNoteListRes, err error)
+	CreditNoteNew(ctx context.Context, req *invoice.CreditNoteNewReq) (res *invoice.CreditNoteNewRes, err error)
+	CreditNotePreview(ctx context.Context, req *invoice.CreditNotePreviewReq) (res *invoice.CreditNotePreviewRes, err error)
+	CreditNoteDetail(ctx context.Context, req *invoice.CreditNoteDetailReq) (res *invoice.CreditNoteDetailRes, err error)
+	CreditNoteDocumentList(ctx context.Context, req *invoice.CreditNoteDocumentListReq) (res *invoice.CreditNoteDocumentListRes, err error)
 	PdfGenerate(ctx context.Context, req *invoice.PdfGenerateReq) (res *invoice.PdfGenerateRes, err error)
 	PdfUpdate(ctx context.Context, req *invoice.PdfUpdateReq) (res *invoice.PdfUpdateRes, err error)
 	SendEmail(ctx context.Context, req *invoice.SendEmailReq) (res *invoice.SendEmailRes, err error)
@@ -150,6 +165,20 @@ type IMerchantInvoice interface {
 	MarkRefund(ctx context.Context, req *invoice.MarkRefundReq) (res *invoice.MarkRefundRes, err error)
 	MarkWireTransferSuccess(ctx context.Context, req *invoice.MarkWireTransferSuccessReq) (res *invoice.MarkWireTransferSuccessRes, err error)
 	MarkRefundInvoiceSuccess(ctx context.Context, req *invoice.MarkRefundInvoiceSuccessReq) (res *invoice.MarkRefundInvoiceSuccessRes, err error)
+	NumberingConfig(ctx context.Context, req *invoice.NumberingConfigReq) (res *invoice.NumberingConfigRes, err error)
+	NumberingSetup(ctx context.Context, req *invoice.NumberingSetupReq) (res *invoice.NumberingSetupRes, err error)
+	EInvoice(ctx context.Context, req *invoice.EInvoiceReq) (res *invoice.EInvoiceRes, err error)
+	EInvoiceConfig(ctx context.Context, req *invoice.EInvoiceConfigReq) (res *invoice.EInvoiceConfigRes, err error)
+	EInvoiceSetup(ctx context.Context, req *invoice.EInvoiceSetupReq) (res *invoice.EInvoiceSetupRes, err error)
+	PdfTemplate(ctx context.Context, req *invoice.PdfTemplateReq) (res *invoice.PdfTemplateRes, err error)
+	PdfTemplateSetup(ctx context.Context, req *invoice.PdfTemplateSetupReq) (res *invoice.PdfTemplateSetupRes, err error)
+	PdfTemplatePreview(ctx context.Context, req *invoice.PdfTemplatePreviewReq) (res *invoice.PdfTemplatePreviewRes, err error)
+	DraftEdit(ctx context.Context, req *invoice.DraftEditReq) (res *invoice.DraftEditRes, err error)
+	DraftHold(ctx context.Context, req *invoice.DraftHoldReq) (res *invoice.DraftHoldRes, err error)
+	DraftFinalize(ctx context.Context, req *invoice.DraftFinalizeReq) (res *invoice.DraftFinalizeRes, err error)
+	PartialPaymentNew(ctx context.Context, req *invoice.PartialPaymentNewReq) (res *invoice.PartialPaymentNewRes, err error)
+	PartialPaymentList(ctx context.Context, req *invoice.PartialPaymentListReq) (res *invoice.PartialPaymentListRes, err error)
+	ReceivableAgeing(ctx context.Context, req *invoice.ReceivableAgeingReq) (res *invoice.ReceivableAgeingRes, err error)
 }
 
 type IMerchantMember interface {
@@ -256,6 +285,14 @@ type IMerchantRole interface {
 	Delete(ctx context.Context, req *role.DeleteReq) (res *role.DeleteRes, err error)
 }
 
+type IMerchantSettlement interface {
+	List(ctx context.Context, req *settlement.ListReq) (res *settlement.ListRes, err error)
+	Detail(ctx context.Context, req *settlement.DetailReq) (res *settlement.DetailRes, err error)
+	ItemList(ctx context.Context, req *settlement.ItemListReq) (res *settlement.ItemListRes, err error)
+	Sync(ctx context.Context, req *settlement.SyncReq) (res *settlement.SyncRes, err error)
+	Setup(ctx context.Context, req *settlement.SetupReq) (res *settlement.SetupRes, err error)
+}
+
 type IMerchantSearch interface {
 	Search(ctx context.Context, req *search.SearchReq) (res *search.SearchRes, err error)
 }
@@ -284,6 +321,37 @@ type IMerchantSubscription interface {
 	Cancel(ctx context.Context, req *subscription.CancelReq) (res *subscription.CancelRes, err error)
 	CancelAtPeriodEnd(ctx context.Context, req *subscription.CancelAtPeriodEndReq) (res *subscription.CancelAtPeriodEndRes, err error)
 	CancelLastCancelAtPeriodEnd(ctx context.Context, req *subscription.CancelLastCancelAtPeriodEndReq) (res *subscription.CancelLastCancelAtPeriodEndRes, err error)
+	Pause(ctx context.Context, req *subscription.PauseReq) (res *subscription.PauseRes, err error)
+	Resume(ctx context.Context, req *subscription.ResumeReq) (res *subscription.ResumeRes, err error)
+	ScheduleNew(ctx context.Context, req *subscription.ScheduleNewReq) (res *subscription.ScheduleNewRes, err error)
+	ScheduleDetail(ctx context.Context, req *subscription.ScheduleDetailReq) (res *subscription.ScheduleDetailRes, err error)
+	ScheduleUpdate(ctx context.Context, req *subscription.ScheduleUpdateReq) (res *subscription.ScheduleUpdateRes, err error)
+	ScheduleRelease(ctx context.Context, req *subscription.ScheduleReleaseReq) (res *subscription.ScheduleReleaseRes, err error)
+	SchedulePreview(ctx context.Context, req *subscription.SchedulePreviewReq) (res *subscription.SchedulePreviewRes, err error)
+	SeatList(ctx context.Context, req *subscription.SeatListReq) (res *subscription.SeatListRes, err error)
+	SeatAssign(ctx context.Context, req *subscription.SeatAssignReq) (res *subscription.SeatAssignRes, err error)
+	SeatUnassign(ctx context.Context, req *subscription.SeatUnassignReq) (res *subscription.SeatUnassignRes, err error)
+	SeatChange(ctx context.Context, req *subscription.SeatChangeReq) (res *subscription.SeatChangeRes, err error)
+	PendingInvoiceItemNew(ctx context.Context, req *subscription.PendingInvoiceItemNewReq) (res *subscription.PendingInvoiceItemNewRes, err error)
+	PendingInvoiceItemList(ctx context.Context, req *subscription.PendingInvoiceItemListReq) (res *subscription.PendingInvoiceItemListRes, err error)
+	PendingInvoiceItemDelete(ctx context.Context, req *subscription.PendingInvoiceItemDeleteReq) (res *subscription.PendingInvoiceItemDeleteRes, err error)
+	RetentionOfferNew(ctx context.Context, req *subscription.RetentionOfferNewReq) (res *subscription.RetentionOfferNewRes, err error)
+	RetentionOfferEdit(ctx context.Context, req *subscription.RetentionOfferEditReq) (res *subscription.RetentionOfferEditRes, err error)
+	RetentionOfferList(ctx context.Context, req *subscription.RetentionOfferListReq) (res *subscription.RetentionOfferListRes, err error)
+	RetentionOfferArchive(ctx context.Context, req *subscription.RetentionOfferArchiveReq) (res *subscription.RetentionOfferArchiveRes, err error)
+	CancellationList(ctx context.Context, req *subscription.CancellationListReq) (res *subscription.CancellationListRes, err error)
+	CancellationReport(ctx context.Context, req *subscription.CancellationReportReq) (res *subscription.CancellationReportRes, err error)
+	TrialCreate(ctx context.Context, req *subscription.TrialCreateReq) (res *subscription.TrialCreateRes, err error)
+	TrialList(ctx context.Context, req *subscription.TrialListReq) (res *subscription.TrialListRes, err error)
+	TrialReport(ctx context.Context, req *subscription.TrialReportReq) (res *subscription.TrialReportRes, err error)
+	TrialLink(ctx context.Context, req *subscription.TrialLinkReq) (res *subscription.TrialLinkRes, err error)
+	Transfer(ctx context.Context, req *subscription.TransferReq) (res *subscription.TransferRes, err error)
+	TransferList(ctx context.Context, req *subscription.TransferListReq) (res *subscription.TransferListRes, err error)
+	CommitmentCreate(ctx context.Context, req *subscription.CommitmentCreateReq) (res *subscription.CommitmentCreateRes, err error)
+	CommitmentUpdate(ctx context.Context, req *subscription.CommitmentUpdateReq) (res *subscription.CommitmentUpdateRes, err error)
+	CommitmentTerminate(ctx context.Context, req *subscription.CommitmentTerminateReq) (res *subscription.CommitmentTerminateRes, err error)
+	CommitmentDetail(ctx context.Context, req *subscription.CommitmentDetailReq) (res *subscription.CommitmentDetailRes, err error)
+	CommitmentList(ctx context.Context, req *subscription.CommitmentListReq) (res *subscription.CommitmentListRes, err error)
 	ChangeGateway(ctx context.Context, req *subscription.ChangeGatewayReq) (res *subscription.ChangeGatewayRes, err error)
 	AddNewTrialStart(ctx context.Context, req *subscription.AddNewTrialStartReq) (res *subscription.AddNewTrialStartRes, err error)
 	CreatePreview(ctx context.Context, req *subscription.CreatePreviewReq) (res *subscription.CreatePreviewRes, err error)
@@ -297,6 +365,7 @@ type IMerchantSubscription interface {
 	UpdatePreview(ctx context.Context, req *subscription.UpdatePreviewReq) (res *subscription.UpdatePreviewRes, err error)
 	Update(ctx context.Context, req *subscription.UpdateReq) (res *subscription.UpdateRes, err error)
 	UpdateMetadata(ctx context.Context, req *subscription.UpdateMetadataReq) (res *subscription.UpdateMetadataRes, err error)
+	UpdateInvoiceReview(ctx context.Context, req *subscription.UpdateInvoiceReviewReq) (res *subscription.UpdateInvoiceReviewRes, err error)
 }
 
 type IMerchantTask interface {
@@ -326,6 +395,7 @@ type IMerchantUser interface {
 	ChangeGateway(ctx context.Context, req *user.ChangeGatewayReq) (res *user.ChangeGatewayRes, err error)
 	ChangeEmail(ctx context.Context, req *user.ChangeEmailReq) (res *user.ChangeEmailRes, err error)
 	ClearAutoChargeMethod(ctx context.Context, req *user.ClearAutoChargeMethodReq) (res *user.ClearAutoChargeMethodRes, err error)
+	ConsolidatedBillingSetup(ctx context.Context, req *user.ConsolidatedBillingSetupReq) (res *user.ConsolidatedBillingSetupRes, err error)
 	NewAdminNote(ctx context.Context, req *user.NewAdminNoteReq) (res *user.NewAdminNoteRes, err error)
 	AdminNoteList(ctx context.Context, req *user.AdminNoteListReq) (res *user.AdminNoteListRes, err error)
 }
@@ -338,6 +408,13 @@ type IMerchantVat interface {
 	NumberValidateHistory(ctx context.Context, req *vat.NumberValidateHistoryReq) (res *vat.NumberValidateHistoryRes, err error)
 	NumberValidateHistoryActivate(ctx context.Context, req *vat.NumberValidateHistoryActivateReq) (res *vat.NumberValidateHistoryActivateRes, err error)
 	NumberValidateHistoryDeactivate(ctx context.Context, req *vat.NumberValidateHistoryDeactivateReq) (res *vat.NumberValidateHistoryDeactivateRes, err error)
+	TaxEngineConfig(ctx context.Context, req *vat.TaxEngineConfigReq) (res *vat.TaxEngineConfigRes, err error)
+	TaxEngineSetup(ctx context.Context, req *vat.TaxEngineSetupReq) (res *vat.TaxEngineSetupRes, err error)
+	TaxRuleList(ctx context.Context, req *vat.TaxRuleListReq) (res *vat.TaxRuleListRes, err error)
+	TaxRuleNew(ctx context.Context, req *vat.TaxRuleNewReq) (res *vat.TaxRuleNewRes, err error)
+	TaxRuleEdit(ctx context.Context, req *vat.TaxRuleEditReq) (res *vat.TaxRuleEditRes, err error)
+	TaxRuleDelete(ctx context.Context, req *vat.TaxRuleDeleteReq) (res *vat.TaxRuleDeleteRes, err error)
+	TaxComputePreview(ctx context.Context, req *vat.TaxComputePreviewReq) (res *vat.TaxComputePreviewRes, err error)
 }
 
 type IMerchantWebhook interface {
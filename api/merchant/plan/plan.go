@@ -32,8 +32,13 @@ type NewReq struct {
 	TrialDurationTime     int64                                `json:"trialDurationTime"         description:"duration of trial， not available for addon"`      // duration of trial
 	TrialDemand           string                               `json:"trialDemand"               description:"demand of trial， not available for addon, example, paymentMethod, payment method will ask for subscription trial start"`
 	CancelAtTrialEnd      int                                  `json:"cancelAtTrialEnd"          description:"whether cancel at subscription first trial end，0-false | 1-true, will pass to cancelAtPeriodEnd of subscription"` // whether cancel at subscripiton first trial end，0-false | 1-true, will pass to cancelAtPeriodEnd of subscription
+	TrialEndBehavior      string                               `json:"trialEndBehavior"          description:"behaviour at trial end of the subscription without payment method，convert|pause|cancel, default convert"`
 	ProductId             int64                                `json:"productId"   dc:"Id of product which plan to linked" `
 	MultiCurrencies       []*bean.PlanMultiCurrency            `json:"multiCurrencies"  dc:"Plan's MultiCurrencies" `
+	TaxCategory           string                               `json:"taxCategory"  dc:"Tax category of plan, matched by the tax rules of tax engine" `
+	InvoiceReviewHours    int                                  `json:"invoiceReviewHours"  dc:"Hours the cycle invoices stay in draft for review before finalised automatically, no later than the period end, 0-review disabled" `
+	MinSeats              int                                  `json:"minSeats"  dc:"Min seats (quantity) of the subscription, 0-no limit" `
+	MaxSeats              int                                  `json:"maxSeats"  dc:"Max seats (quantity) of the subscription, 0-no limit" `
 }
 type NewRes struct {
 	Plan *bean.Plan `json:"plan" dc:"Plan"`
@@ -65,8 +70,13 @@ type EditReq struct {
 	TrialDurationTime     *int64                                `json:"trialDurationTime"         description:"duration of trial，seconds, not available for addon"` // duration of trial
 	TrialDemand           *string                               `json:"trialDemand"               description:"demand of trial, not available for addon, example, paymentMethod, payment method will ask for subscription trial start"`
 	CancelAtTrialEnd      *int                                  `json:"cancelAtTrialEnd"          description:"whether cancel at subscription first trial end，0-false | 1-true, will pass to cancelAtPeriodEnd of subscription"` // whether cancel at subscripiton first trial end，0-false | 1-true, will pass to cancelAtPeriodEnd of subscription
+	TrialEndBehavior      *string                               `json:"trialEndBehavior"          description:"behaviour at trial end of the subscription without payment method，convert|pause|cancel, default convert"`
 	ProductId             *int64                                `json:"productId"   dc:"Id of product which plan to linked" `
 	MultiCurrencies       *[]*bean.PlanMultiCurrency            `json:"multiCurrencies"  dc:"Plan's MultiCurrencies" `
+	TaxCategory           *string                               `json:"taxCategory"  dc:"Tax category of plan, matched by the tax rules of tax engine" `
+	InvoiceReviewHours    *int                                  `json:"invoiceReviewHours"  dc:"Hours the cycle invoices stay in draft for review before finalised automatically, no later than the period end, 0-review disabled" `
+	MinSeats              *int                                  `json:"minSeats"  dc:"Min seats (quantity) of the subscription, 0-no limit" `
+	MaxSeats              *int                                  `json:"maxSeats"  dc:"Max seats (quantity) of the subscription, 0-no limit" `
 }
 type EditRes struct {
 	Plan *bean.Plan `json:"plan" dc:"Plan"`
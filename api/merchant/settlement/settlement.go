@@ -0,0 +1,67 @@
+package settlement
+
+import (
+	"github.com/gogf/gf/v2/frame/g"
+	"unibee/api/bean"
+)
+
+type ListReq struct {
+	g.Meta           `path:"/list" tags:"Settlement" method:"get,post" summary:"Get Settlement List" dc:"Payouts and settlement reports ingested from gateways, export them with /merchant/task/new_export, task SettlementExport"`
+	GatewayId        uint64 `json:"gatewayId" dc:"GatewayId"`
+	Currency         string `json:"currency" dc:"Currency"`
+	Status           int    `json:"status" dc:"Status, 1-Pending|2-Paid|3-Failed|4-Cancelled"`
+	ArrivalTimeStart int64  `json:"arrivalTimeStart" dc:"ArrivalTimeStart, utc time"`
+	ArrivalTimeEnd   int64  `json:"arrivalTimeEnd" dc:"ArrivalTimeEnd, utc time"`
+	Page             int    `json:"page"  dc:"Page, Start With 0" `
+	Count            int    `json:"count"  dc:"Count Of Page" `
+}
+
+type ListRes struct {
+	Settlements []*bean.GatewaySettlement `json:"settlements" dc:"Settlement List"`
+	Total       int                       `json:"total" dc:"Total"`
+}
+
+type DetailReq struct {
+	g.Meta `path:"/detail" tags:"Settlement" method:"get,post" summary:"Settlement Detail"`
+	Id     uint64 `json:"id" dc:"The id of settlement" v:"required"`
+}
+
+type DetailRes struct {
+	Settlement *bean.GatewaySettlement       `json:"settlement" dc:"Settlement"`
+	Items      []*bean.GatewaySettlementItem `json:"items" dc:"Transactions settled in the settlement"`
+}
+
+type ItemListReq struct {
+	g.Meta       `path:"/item_list" tags:"Settlement" method:"get,post" summary:"Get Settlement Item List" dc:"Settled transactions of a settlement, or the fee and net of a payment or invoice"`
+	SettlementId uint64 `json:"settlementId" dc:"The id of settlement"`
+	PaymentId    string `json:"paymentId" dc:"PaymentId"`
+	InvoiceId    string `json:"invoiceId" dc:"InvoiceId"`
+	Type         string `json:"type" dc:"Type, payment|refund|fee|dispute|adjustment|other"`
+	Page         int    `json:"page"  dc:"Page, Start With 0" `
+	Count        int    `json:"count"  dc:"Count Of Page" `
+}
+
+type ItemListRes struct {
+	Items []*bean.GatewaySettlementItem `json:"items" dc:"Settlement Item List"`
+	Total int                           `json:"total" dc:"Total"`
+}
+
+type SyncReq struct {
+	g.Meta           `path:"/sync" tags:"Settlement" method:"post" summary:"Sync Gateway Settlement" dc:"Fetch the payouts arrived in the window from gateway, Stripe and Paypal supported, other gateways import settlements with batch import task GatewaySettlementImport"`
+	GatewayId        uint64 `json:"gatewayId" dc:"GatewayId" v:"required"`
+	ArrivalTimeStart int64  `json:"arrivalTimeStart" dc:"ArrivalTimeStart, utc time" v:"required"`
+	ArrivalTimeEnd   int64  `json:"arrivalTimeEnd" dc:"ArrivalTimeEnd, utc time" v:"required"`
+}
+
+type SyncRes struct {
+	Settlements []*bean.GatewaySettlement `json:"settlements" dc:"Settlement List"`
+}
+
+type SetupReq struct {
+	g.Meta `path:"/setup" tags:"Settlement" method:"post" summary:"Settlement Setup" dc:"Setup the daily sync of the settlements arrived in the previous utc day, for all gateways support settlement report"`
+	Enable *bool `json:"enable" dc:"Run the daily sync, keep unchanged if not specified"`
+}
+
+type SetupRes struct {
+	Enable bool `json:"enable" dc:"Daily sync enabled"`
+}
@@ -0,0 +1,65 @@
+package subscription
+
+import (
+	"unibee/api/bean"
+
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+type CommitmentCreateReq struct {
+	g.Meta                        `path:"/commitment/create" tags:"Subscription Commitment" method:"post" summary:"Create Subscription Commitment" dc:"Commit the subscription to the minimum term, the subscription can not be cancelled immediately or downgraded before the term end, the cancel at period end or the downgrade noticed before the notice deadline takes effect at the term end"`
+	SubscriptionId                string `json:"subscriptionId" dc:"SubscriptionId" v:"required"`
+	TermMonths                    int    `json:"termMonths" dc:"TermMonths, length of the minimum commitment term in months" v:"required"`
+	AutoRenew                     bool   `json:"autoRenew" dc:"AutoRenew, whether the term renews at term end without the cancellation noticed, default false"`
+	RenewTermMonths               int    `json:"renewTermMonths" dc:"RenewTermMonths, length of the term renewed automatically in months, default the termMonths"`
+	NoticeDays                    int    `json:"noticeDays" dc:"NoticeDays, days before term end the cancellation should be noticed, default 0"`
+	EarlyTerminationFeePercentage *int64 `json:"earlyTerminationFeePercentage" dc:"EarlyTerminationFeePercentage, percentage of the remaining commitment charged at early termination，10000 = 100%, default 10000"`
+	TermStart                     int64  `json:"termStart" dc:"TermStart, utc time, default the current period start of subscription"`
+}
+type CommitmentCreateRes struct {
+	Commitment *bean.SubscriptionCommitment `json:"commitment" dc:"Commitment"`
+}
+
+type CommitmentUpdateReq struct {
+	g.Meta                        `path:"/commitment/update" tags:"Subscription Commitment" method:"post" summary:"Update Subscription Commitment" dc:"Update the renewal, notice and early termination terms of the active commitment, the current term stays"`
+	CommitmentId                  string `json:"commitmentId" dc:"CommitmentId" v:"required"`
+	AutoRenew                     *bool  `json:"autoRenew" dc:"AutoRenew, whether the term renews at term end"`
+	RenewTermMonths               *int   `json:"renewTermMonths" dc:"RenewTermMonths, length of the term renewed automatically in months"`
+	NoticeDays                    *int   `json:"noticeDays" dc:"NoticeDays, days before term end the cancellation should be noticed"`
+	EarlyTerminationFeePercentage *int64 `json:"earlyTerminationFeePercentage" dc:"EarlyTerminationFeePercentage, percentage of the remaining commitment charged at early termination，10000 = 100%"`
+}
+type CommitmentUpdateRes struct {
+	Commitment *bean.SubscriptionCommitment `json:"commitment" dc:"Commitment"`
+}
+
+type CommitmentTerminateReq struct {
+	g.Meta       `path:"/commitment/terminate" tags:"Subscription Commitment" method:"post" summary:"Terminate Subscription Commitment" dc:"Terminate the commitment before the term end and cancel the subscription immediately, the invoice of the early termination fee is created and sent to the user unless waived"`
+	CommitmentId string `json:"commitmentId" dc:"CommitmentId" v:"required"`
+	WaiveFee     bool   `json:"waiveFee" dc:"WaiveFee, terminate without the early termination fee invoice, default false"`
+	Reason       string `json:"reason" dc:"Reason"`
+}
+type CommitmentTerminateRes struct {
+	Commitment *bean.SubscriptionCommitment `json:"commitment" dc:"Commitment"`
+}
+
+type CommitmentDetailReq struct {
+	g.Meta       `path:"/commitment/detail" tags:"Subscription Commitment" method:"get,post" summary:"Subscription Commitment Detail" dc:"Get the commitment and the early termination fee if terminated now"`
+	CommitmentId string `json:"commitmentId" dc:"CommitmentId" v:"required"`
+}
+type CommitmentDetailRes struct {
+	Commitment         *bean.SubscriptionCommitment                   `json:"commitment" dc:"Commitment"`
+	TerminationPreview *bean.SubscriptionCommitmentTerminationPreview `json:"terminationPreview" dc:"TerminationPreview, the early termination fee if terminated now"`
+}
+
+type CommitmentListReq struct {
+	g.Meta         `path:"/commitment/list" tags:"Subscription Commitment" method:"get,post" summary:"Subscription Commitment List" dc:"Get the commitments of the merchant"`
+	SubscriptionId string `json:"subscriptionId" dc:"Filter SubscriptionId, Default All"`
+	UserId         uint64 `json:"userId" dc:"Filter UserId, Default All"`
+	Status         int    `json:"status" dc:"Filter Status，1-Active｜2-Completed｜3-Terminated｜4-Cancelled, Default All"`
+	Page           int    `json:"page"  dc:"Page, Start With 0" `
+	Count          int    `json:"count"  dc:"Count Of Page" `
+}
+type CommitmentListRes struct {
+	Commitments []*bean.SubscriptionCommitment `json:"commitments" dc:"Commitments"`
+	Total       int                            `json:"total" dc:"Total"`
+}
@@ -21,6 +21,12 @@ type ConfigUpdateReq struct {
 	TryAutomaticPaymentBeforePeriodEnd *int64                  `json:"tryAutomaticPaymentBeforePeriodEnd" dc:"TryAutomaticPaymentBeforePeriodEnd, Auto-charge Start Before Period End （Time Difference for Auto-Payment Activation Before Period End）"`
 	GatewayVATRule                     []*bean.MerchantVatRule `json:"gatewayVATRule" dc:""`
 	ShowZeroInvoice                    *bool                   `json:"showZeroInvoice" dc:"ShowZeroInvoice, Display Invoices With Zero Amount (Invoice With Zero Amount will hidden in list by default)"`
+	PauseMode                          *string                 `json:"pauseMode" dc:"PauseMode, Default Pause Billing Behaviour (keep_as_draft|void|mark_uncollectible, the open invoice is kept as draft by default)"`
+	UserPauseEnable                    *bool                   `json:"userPauseEnable" dc:"UserPauseEnable, Enable User Pause (Toggle to let users pause and resume their subscription in user portal)"`
+	SeatProrationMode                  *string                 `json:"seatProrationMode" dc:"SeatProrationMode, Default Seat Proration (immediate|true_up, the seats added are charged with proration at once by default, true_up charges them with the next cycle invoice)"`
+	CancellationReasons                []string                `json:"cancellationReasons" dc:"CancellationReasons, Cancellation Reasons (The reasons the user chooses from when cancelling subscription in user portal)"`
+	TrialReminderDays                  *int64                  `json:"trialReminderDays" dc:"TrialReminderDays, Trial Ending Reminder (Days before trial end to remind the user to attach payment method, 0 to disable, 3 days by default)"`
+	CommitmentReminderDays             *int64                  `json:"commitmentReminderDays" dc:"CommitmentReminderDays, Commitment Renewal Reminder (Days before the notice deadline of commitment term end to remind the user of the renewal, 0 to disable, 30 days by default)"`
 }
 
 type ConfigUpdateRes struct {
@@ -0,0 +1,40 @@
+package subscription
+
+import (
+	"unibee/api/bean"
+
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+type PendingInvoiceItemNewReq struct {
+	g.Meta         `path:"/pending_invoice_item/new" tags:"Subscription Pending Invoice Item" method:"post" summary:"New Subscription Pending Invoice Item" dc:"Add a one-off charge or credit to the next cycle invoice of subscription, either planId or unitAmount needed. The item rolls into the next cycle invoice again if the invoice merged into closed without paid"`
+	SubscriptionId string `json:"subscriptionId" dc:"SubscriptionId, id of subscription" v:"required"`
+	PlanId         uint64 `json:"planId" dc:"PlanId, plan-based item charged with the plan amount in the subscription currency"`
+	UnitAmount     *int64 `json:"unitAmount" dc:"UnitAmount, amount-based item, unit amount excluding tax, cent, negative for credit, the credit over the cycle invoice amount carries forward to the next cycle invoice as a new item"`
+	Quantity       int64  `json:"quantity" dc:"Quantity, default 1"`
+	Name           string `json:"name" dc:"Name, default plan name for the plan-based item, required for the amount-based item"`
+	Description    string `json:"description" dc:"Description"`
+	TaxPercentage  *int64 `json:"taxPercentage" dc:"TaxPercentage override of the item，1000 = 10%, follow the invoice if not specified"`
+}
+type PendingInvoiceItemNewRes struct {
+	PendingInvoiceItem *bean.SubscriptionPendingInvoiceItem `json:"pendingInvoiceItem" dc:"PendingInvoiceItem"`
+}
+
+type PendingInvoiceItemListReq struct {
+	g.Meta         `path:"/pending_invoice_item/list" tags:"Subscription Pending Invoice Item" method:"get,post" summary:"Subscription Pending Invoice Item List"`
+	SubscriptionId string `json:"subscriptionId" dc:"SubscriptionId, id of subscription" v:"required"`
+	Status         []int  `json:"status" dc:"Filter, Default All，1-Pending｜2-Invoiced"`
+	Page           int    `json:"page"  dc:"Page, Start With 0" `
+	Count          int    `json:"count"  dc:"Count Of Page" `
+}
+type PendingInvoiceItemListRes struct {
+	PendingInvoiceItems []*bean.SubscriptionPendingInvoiceItem `json:"pendingInvoiceItems" dc:"PendingInvoiceItems"`
+	Total               int                                    `json:"total" dc:"Total"`
+}
+
+type PendingInvoiceItemDeleteReq struct {
+	g.Meta `path:"/pending_invoice_item/delete" tags:"Subscription Pending Invoice Item" method:"post" summary:"Delete Subscription Pending Invoice Item" dc:"Delete the item not charged yet, the item merged into an invoice waiting for payment can not be deleted"`
+	ItemId string `json:"itemId" dc:"ItemId, id of pending invoice item" v:"required"`
+}
+type PendingInvoiceItemDeleteRes struct {
+}
@@ -0,0 +1,80 @@
+package subscription
+
+import (
+	"unibee/api/bean"
+
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+type RetentionOfferNewReq struct {
+	g.Meta       `path:"/retention_offer/new" tags:"Subscription Retention" method:"post" summary:"New Retention Offer" dc:"Create the retention offer presented to the users cancelling their subscription in user portal"`
+	Name         string   `json:"name" dc:"Name" v:"required"`
+	Description  string   `json:"description" dc:"Description shown to the user"`
+	OfferType    string   `json:"offerType" dc:"OfferType, discount|pause|downgrade" v:"required"`
+	DiscountCode string   `json:"discountCode" dc:"DiscountCode applied to the next invoice, required for discount offer"`
+	PauseDays    int64    `json:"pauseDays" dc:"PauseDays, days the subscription paused before resumed automatically, 0 to resume manually, pause offer"`
+	TargetPlanId uint64   `json:"targetPlanId" dc:"TargetPlanId, cheaper plan of the same product the subscription downgraded to at period end, required for downgrade offer"`
+	Reasons      []string `json:"reasons" dc:"Reasons, cancellation reasons the offer presented for, default all reasons"`
+	PlanIds      []uint64 `json:"planIds" dc:"PlanIds, plans the offer presented for, default all plans"`
+	Priority     int      `json:"priority" dc:"Priority, offers presented by priority desc"`
+}
+type RetentionOfferNewRes struct {
+	RetentionOffer *bean.SubscriptionRetentionOffer `json:"retentionOffer" dc:"RetentionOffer"`
+}
+
+type RetentionOfferEditReq struct {
+	g.Meta       `path:"/retention_offer/edit" tags:"Subscription Retention" method:"post" summary:"Edit Retention Offer" dc:"Edit the active retention offer, the cancellations started keep the offers presented"`
+	OfferId      uint64   `json:"offerId" dc:"OfferId, id of retention offer" v:"required"`
+	Name         string   `json:"name" dc:"Name" v:"required"`
+	Description  string   `json:"description" dc:"Description shown to the user"`
+	OfferType    string   `json:"offerType" dc:"OfferType, discount|pause|downgrade" v:"required"`
+	DiscountCode string   `json:"discountCode" dc:"DiscountCode applied to the next invoice, required for discount offer"`
+	PauseDays    int64    `json:"pauseDays" dc:"PauseDays, days the subscription paused before resumed automatically, 0 to resume manually, pause offer"`
+	TargetPlanId uint64   `json:"targetPlanId" dc:"TargetPlanId, cheaper plan of the same product the subscription downgraded to at period end, required for downgrade offer"`
+	Reasons      []string `json:"reasons" dc:"Reasons, cancellation reasons the offer presented for, default all reasons"`
+	PlanIds      []uint64 `json:"planIds" dc:"PlanIds, plans the offer presented for, default all plans"`
+	Priority     int      `json:"priority" dc:"Priority, offers presented by priority desc"`
+}
+type RetentionOfferEditRes struct {
+	RetentionOffer *bean.SubscriptionRetentionOffer `json:"retentionOffer" dc:"RetentionOffer"`
+}
+
+type RetentionOfferListReq struct {
+	g.Meta `path:"/retention_offer/list" tags:"Subscription Retention" method:"get,post" summary:"Retention Offer List"`
+	Status int `json:"status" dc:"Filter, Default All，1-Active｜2-Archived"`
+	Page   int `json:"page"  dc:"Page, Start With 0" `
+	Count  int `json:"count"  dc:"Count Of Page" `
+}
+type RetentionOfferListRes struct {
+	RetentionOffers []*bean.SubscriptionRetentionOffer `json:"retentionOffers" dc:"RetentionOffers"`
+	Total           int                                `json:"total" dc:"Total"`
+}
+
+type RetentionOfferArchiveReq struct {
+	g.Meta  `path:"/retention_offer/archive" tags:"Subscription Retention" method:"post" summary:"Archive Retention Offer" dc:"Stop presenting the retention offer, the offers presented before can still be accepted"`
+	OfferId uint64 `json:"offerId" dc:"OfferId, id of retention offer" v:"required"`
+}
+type RetentionOfferArchiveRes struct {
+}
+
+type CancellationListReq struct {
+	g.Meta         `path:"/cancellation/list" tags:"Subscription Retention" method:"get,post" summary:"Subscription Cancellation List" dc:"Get the cancellation flows started by the users with the reasons captured and the retention offers accepted"`
+	SubscriptionId string `json:"subscriptionId" dc:"Filter SubscriptionId, Default All"`
+	UserId         uint64 `json:"userId" dc:"Filter UserId, Default All"`
+	Status         int    `json:"status" dc:"Filter, Default All，1-Started｜2-Saved｜3-Cancelled"`
+	Page           int    `json:"page"  dc:"Page, Start With 0" `
+	Count          int    `json:"count"  dc:"Count Of Page" `
+}
+type CancellationListRes struct {
+	Cancellations []*bean.SubscriptionCancellation `json:"cancellations" dc:"Cancellations"`
+	Total         int                              `json:"total" dc:"Total"`
+}
+
+type CancellationReportReq struct {
+	g.Meta    `path:"/cancellation/report" tags:"Subscription Retention" method:"get,post" summary:"Subscription Cancellation Report" dc:"Get the cancellation reasons and save rates per plan of the cancellation flows started in the time range"`
+	StartTime int64 `json:"startTime" dc:"StartTime, utc time, default all"`
+	EndTime   int64 `json:"endTime" dc:"EndTime, utc time, default all"`
+}
+type CancellationReportRes struct {
+	Reports []*bean.SubscriptionCancellationReport `json:"reports" dc:"Reports per plan"`
+}
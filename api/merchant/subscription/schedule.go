@@ -0,0 +1,55 @@
+package subscription
+
+import (
+	"github.com/gogf/gf/v2/frame/g"
+	"unibee/api/bean"
+)
+
+type ScheduleNewReq struct {
+	g.Meta         `path:"/schedule/new" tags:"Subscription Schedule" method:"post" summary:"New Subscription Schedule" dc:"Attach multi-phase plans to the active subscription, the first phase starts from the next billing cycle and each phase lasts its cycles, the billing cycle applies the next phase at period end"`
+	SubscriptionId string                                 `json:"subscriptionId" dc:"SubscriptionId, id of subscription" v:"required"`
+	Phases         []*bean.SubscriptionSchedulePhaseParam `json:"phases" dc:"Phases of the schedule in order, only the last phase can set cycles 0 to renew without end" v:"required"`
+	EndBehavior    string                                 `json:"endBehavior" dc:"EndBehavior, release|cancel, release keeps the subscription renewing on the last phase, cancel sets the subscription cancel at the end of the last phase, default release"`
+}
+type ScheduleNewRes struct {
+	Schedule *bean.SubscriptionSchedule `json:"schedule" dc:"Schedule"`
+}
+
+type ScheduleDetailReq struct {
+	g.Meta         `path:"/schedule/detail" tags:"Subscription Schedule" method:"get" summary:"Subscription Schedule Detail" dc:"Get the schedule by scheduleId, or the latest schedule of the subscription"`
+	ScheduleId     string `json:"scheduleId" dc:"ScheduleId, either ScheduleId or SubscriptionId needed"`
+	SubscriptionId string `json:"subscriptionId" dc:"SubscriptionId, either ScheduleId or SubscriptionId needed"`
+}
+type ScheduleDetailRes struct {
+	Schedule *bean.SubscriptionSchedule `json:"schedule" dc:"Schedule"`
+}
+
+type ScheduleUpdateReq struct {
+	g.Meta      `path:"/schedule/update" tags:"Subscription Schedule" method:"post" summary:"Update Subscription Schedule" dc:"Replace the phases not started of the active schedule, the phases started keep unchanged"`
+	ScheduleId  string                                 `json:"scheduleId" dc:"ScheduleId" v:"required"`
+	Phases      []*bean.SubscriptionSchedulePhaseParam `json:"phases" dc:"Phases after the current phase, replace all the phases not started"`
+	EndBehavior *string                                `json:"endBehavior" dc:"EndBehavior, release|cancel"`
+}
+type ScheduleUpdateRes struct {
+	Schedule *bean.SubscriptionSchedule `json:"schedule" dc:"Schedule"`
+}
+
+type ScheduleReleaseReq struct {
+	g.Meta     `path:"/schedule/release" tags:"Subscription Schedule" method:"post" summary:"Release Subscription Schedule" dc:"Release the subscription from the schedule, the subscription keeps its current plan and the phases not started are cancelled"`
+	ScheduleId string `json:"scheduleId" dc:"ScheduleId" v:"required"`
+	Reason     string `json:"reason" dc:"Reason of the release"`
+}
+type ScheduleReleaseRes struct {
+	Schedule *bean.SubscriptionSchedule `json:"schedule" dc:"Schedule"`
+}
+
+type SchedulePreviewReq struct {
+	g.Meta         `path:"/schedule/preview" tags:"Subscription Schedule" method:"post" summary:"Preview Subscription Schedule" dc:"Preview the cycle invoices of the schedule, either ScheduleId of the saved schedule or SubscriptionId with phases not saved needed"`
+	ScheduleId     string                                 `json:"scheduleId" dc:"ScheduleId, preview the saved schedule"`
+	SubscriptionId string                                 `json:"subscriptionId" dc:"SubscriptionId, preview the phases for the subscription"`
+	Phases         []*bean.SubscriptionSchedulePhaseParam `json:"phases" dc:"Phases to preview, required if ScheduleId not specified"`
+	EndBehavior    string                                 `json:"endBehavior" dc:"EndBehavior, release|cancel, default release"`
+}
+type SchedulePreviewRes struct {
+	Invoices []*bean.Invoice `json:"invoices" dc:"Cycle invoices in order, stop at the end of schedule or the first cycle renewing on the last phase"`
+}
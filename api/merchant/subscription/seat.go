@@ -0,0 +1,52 @@
+package subscription
+
+import (
+	"unibee/api/bean"
+	"unibee/api/bean/detail"
+
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+type SeatListReq struct {
+	g.Meta         `path:"/seat/list" tags:"Subscription Seat" method:"get" summary:"Subscription Seat List" dc:"Get the seats assigned and the seat changes of the current period of subscription"`
+	SubscriptionId string `json:"subscriptionId" dc:"SubscriptionId, id of subscription" v:"required"`
+}
+type SeatListRes struct {
+	Quantity    int64                          `json:"quantity" dc:"Seats (quantity) of subscription"`
+	Assigned    int64                          `json:"assigned" dc:"Seats assigned"`
+	Seats       []*bean.SubscriptionSeat       `json:"seats" dc:"Seats assigned"`
+	SeatChanges []*bean.SubscriptionSeatChange `json:"seatChanges" dc:"Seat changes of the current period"`
+}
+
+type SeatAssignReq struct {
+	g.Meta         `path:"/seat/assign" tags:"Subscription Seat" method:"post" summary:"Assign Subscription Seats" dc:"Assign the members to the free seats of subscription, the seats (quantity) of subscription are not changed, add seats first if no enough free seats"`
+	SubscriptionId string                        `json:"subscriptionId" dc:"SubscriptionId, id of subscription" v:"required"`
+	Seats          []*bean.SubscriptionSeatParam `json:"seats" dc:"Members to assign, either email or externalId needed" v:"required"`
+}
+type SeatAssignRes struct {
+	Seats []*bean.SubscriptionSeat `json:"seats" dc:"Seats assigned"`
+}
+
+type SeatUnassignReq struct {
+	g.Meta         `path:"/seat/unassign" tags:"Subscription Seat" method:"post" summary:"Unassign Subscription Seats" dc:"Free the seats assigned, the seats (quantity) of subscription are not changed"`
+	SubscriptionId string   `json:"subscriptionId" dc:"SubscriptionId, id of subscription" v:"required"`
+	SeatIds        []string `json:"seatIds" dc:"SeatIds to unassign" v:"required"`
+}
+type SeatUnassignRes struct {
+}
+
+type SeatChangeReq struct {
+	g.Meta         `path:"/seat/change" tags:"Subscription Seat" method:"post" summary:"Change Subscription Seats" dc:"Add or remove the seats (quantity) of subscription within the min and max seats of plan. In immediate mode the seats added are charged with proration at once and the seats removed follow the downgrade config, in true_up mode the seats change at once and the seats added are charged with proration in the next cycle invoice"`
+	SubscriptionId string `json:"subscriptionId" dc:"SubscriptionId, id of subscription" v:"required"`
+	Quantity       int64  `json:"quantity" dc:"Seats (quantity) after the change" v:"required"`
+	ProrationMode  string `json:"prorationMode" dc:"ProrationMode, immediate|true_up, default follow the seatProrationMode of subscription config"`
+}
+type SeatChangeRes struct {
+	Subscription              *bean.Subscription                      `json:"subscription" dc:"Subscription"`
+	SeatChange                *bean.SubscriptionSeatChange            `json:"seatChange" dc:"SeatChange"`
+	SubscriptionPendingUpdate *detail.SubscriptionPendingUpdateDetail `json:"subscriptionPendingUpdate" dc:"SubscriptionPendingUpdate of the immediate change"`
+	PaymentId                 string                                  `json:"paymentId" dc:"The unique id of payment of the immediate change"`
+	InvoiceId                 string                                  `json:"invoiceId" dc:"The unique id of invoice of the immediate change"`
+	Paid                      bool                                    `json:"paid" dc:"Paid or not, the immediate change takes effect after paid"`
+	Link                      string                                  `json:"link" dc:"The payment link of the immediate change, need redirect customer to link if paid=false"`
+}
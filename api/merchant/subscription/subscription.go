@@ -87,6 +87,25 @@ type CancelLastCancelAtPeriodEndReq struct {
 type CancelLastCancelAtPeriodEndRes struct {
 }
 
+type PauseReq struct {
+	g.Meta         `path:"/pause" tags:"Subscription" method:"post" summary:"Pause Subscription" dc:"Pause the active subscription, the subscription turns to 'suspended' and no invoice will generate or charge until resumed, the open invoice is handled by pauseMode, the period left when paused continues after resume"`
+	SubscriptionId string `json:"subscriptionId" dc:"SubscriptionId, id of subscription" v:"required"`
+	PauseMode      string `json:"pauseMode" dc:"PauseMode, billing behaviour of the open invoice, keep_as_draft|void|mark_uncollectible, default follow the pauseMode of subscription config"`
+	ResumeTime     int64  `json:"resumeTime" dc:"ResumeTime, the utc time the subscription resumes automatically, 0 to resume manually"`
+	Reason         string `json:"reason" dc:"Reason of the pause"`
+}
+type PauseRes struct {
+	Subscription *bean.Subscription `json:"subscription" dc:"Subscription"`
+}
+
+type ResumeReq struct {
+	g.Meta         `path:"/resume" tags:"Subscription" method:"post" summary:"Resume Subscription" dc:"Resume the paused subscription, the period end is extended by the time paused and the billing cycle continues"`
+	SubscriptionId string `json:"subscriptionId" dc:"SubscriptionId, id of subscription" v:"required"`
+}
+type ResumeRes struct {
+	Subscription *bean.Subscription `json:"subscription" dc:"Subscription"`
+}
+
 type ChangeGatewayReq struct {
 	g.Meta          `path:"/change_gateway" tags:"Subscription" method:"post" summary:"Change Subscription Gateway" `
 	SubscriptionId  string `json:"subscriptionId" dc:"SubscriptionId" v:"required"`
@@ -124,6 +143,9 @@ type CreatePreviewReq struct {
 	FreeInInitialPeriod    *bool                  `json:"freeInInitialPeriod"  dc:"Is free or not for the first period, true or false"`
 	ApplyPromoCredit       *bool                  `json:"applyPromoCredit"  dc:"apply promo credit or not"`
 	ApplyPromoCreditAmount *int64                 `json:"applyPromoCreditAmount"  dc:"apply promo credit amount, auto compute if not specified"`
+	BackdateStartTime      int64                  `json:"backdateStartTime" dc:"The start time of subscription in the past, utc time, the subscription starts in the period covering now, not available for trial"`
+	BackdateCatchUp        bool                   `json:"backdateCatchUp" dc:"Charge the elapsed periods from backdateStartTime with the first invoice as catch-up items if true, skip them if false"`
+	BillingCycleAnchorDay  int                    `json:"billingCycleAnchorDay" dc:"The day of month the billing cycle anchors to, 1-28, month or year plan only, the first period is prorated to the anchor, not available for trial"`
 }
 
 type CreatePreviewRes struct {
@@ -185,6 +207,9 @@ type CreateReq struct {
 	ProductData            *bean.PlanProductParam      `json:"productData"  dc:"ProductData"  `
 	ApplyPromoCredit       bool                        `json:"applyPromoCredit" dc:"apply promo credit or not"`
 	ApplyPromoCreditAmount *int64                      `json:"applyPromoCreditAmount"  dc:"apply promo credit amount, auto compute if not specified"`
+	BackdateStartTime      int64                       `json:"backdateStartTime" dc:"The start time of subscription in the past, utc time, the subscription starts in the period covering now, not available for trial"`
+	BackdateCatchUp        bool                        `json:"backdateCatchUp" dc:"Charge the elapsed periods from backdateStartTime with the first invoice as catch-up items if true, skip them if false"`
+	BillingCycleAnchorDay  int                         `json:"billingCycleAnchorDay" dc:"The day of month the billing cycle anchors to, 1-28, month or year plan only, the first period is prorated to the anchor, not available for trial"`
 }
 
 type CreateRes struct {
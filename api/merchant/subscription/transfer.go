@@ -0,0 +1,31 @@
+package subscription
+
+import (
+	"unibee/api/bean"
+
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+type TransferReq struct {
+	g.Meta               `path:"/transfer" tags:"Subscription Transfer" method:"post" summary:"Transfer Subscription Ownership" dc:"Move the subscription to another user, the unpaid invoices, pending items and the usage not billed yet go with the subscription, the paid invoices stay with the previous user, the subscription with the invoice in payment can not be transferred"`
+	SubscriptionId       string `json:"subscriptionId" dc:"SubscriptionId" v:"required"`
+	ToUserId             uint64 `json:"toUserId" dc:"ToUserId, the user the subscription transferred to" v:"required"`
+	RequirePaymentMethod bool   `json:"requirePaymentMethod" dc:"RequirePaymentMethod, reject the transfer if the user has no default payment method, default false"`
+	Reason               string `json:"reason" dc:"Reason"`
+}
+type TransferRes struct {
+	Transfer     *bean.SubscriptionTransfer `json:"transfer" dc:"Transfer"`
+	Subscription *bean.Subscription         `json:"subscription" dc:"Subscription"`
+}
+
+type TransferListReq struct {
+	g.Meta         `path:"/transfer/list" tags:"Subscription Transfer" method:"get,post" summary:"Subscription Transfer List" dc:"Get the ownership transfers of the merchant"`
+	SubscriptionId string `json:"subscriptionId" dc:"Filter SubscriptionId, Default All"`
+	UserId         uint64 `json:"userId" dc:"Filter UserId transferred from or to, Default All"`
+	Page           int    `json:"page"  dc:"Page, Start With 0" `
+	Count          int    `json:"count"  dc:"Count Of Page" `
+}
+type TransferListRes struct {
+	Transfers []*bean.SubscriptionTransfer `json:"transfers" dc:"Transfers"`
+	Total     int                          `json:"total" dc:"Total"`
+}
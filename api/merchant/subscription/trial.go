@@ -0,0 +1,59 @@
+package subscription
+
+import (
+	"unibee/api/bean"
+
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+type TrialCreateReq struct {
+	g.Meta         `path:"/trial/create" tags:"Subscription Trial" method:"post" summary:"Create Card-less Trial" dc:"Start the trial subscription without gateway and payment method, the user attaches payment method through the hosted link before trial end, the plan's trialEndBehavior applies if not attached"`
+	PlanId         uint64                 `json:"planId" dc:"PlanId" v:"required"`
+	UserId         uint64                 `json:"userId" dc:"UserId, one of UserId or Email needed"`
+	Email          string                 `json:"email" dc:"Email, one of UserId or Email needed"`
+	ExternalUserId string                 `json:"externalUserId" dc:"ExternalUserId, unique, used with Email"`
+	Currency       string                 `json:"currency" dc:"The currency of subscription"`
+	Quantity       int64                  `json:"quantity" dc:"Quantity，Default 1"`
+	AddonParams    []*bean.PlanAddonParam `json:"addonParams" dc:"addonParams"`
+	TrialEnd       int64                  `json:"trialEnd" dc:"trial_end, utc time, override plan's trial duration if specified"`
+	VatCountryCode string                 `json:"vatCountryCode" dc:"VatCountryCode, CountryName"`
+	VatNumber      string                 `json:"vatNumber" dc:"VatNumber"`
+	TaxPercentage  *int64                 `json:"taxPercentage" dc:"TaxPercentage，1000 = 10%"`
+	ReturnUrl      string                 `json:"returnUrl" dc:"ReturnUrl, back to returnUrl after the payment method attached"`
+	Metadata       map[string]interface{} `json:"metadata" dc:"Metadata，Map"`
+}
+type TrialCreateRes struct {
+	Trial        *bean.SubscriptionTrial `json:"trial" dc:"Trial"`
+	Subscription *bean.Subscription      `json:"subscription" dc:"Subscription"`
+	Link         string                  `json:"link" dc:"Hosted link the user attaches payment method through"`
+}
+
+type TrialListReq struct {
+	g.Meta         `path:"/trial/list" tags:"Subscription Trial" method:"get,post" summary:"Card-less Trial List" dc:"Get the card-less trials of the merchant"`
+	SubscriptionId string `json:"subscriptionId" dc:"Filter SubscriptionId, Default All"`
+	UserId         uint64 `json:"userId" dc:"Filter UserId, Default All"`
+	Status         int    `json:"status" dc:"Filter, Default All，1-Trialing｜2-Converted｜3-Paused｜4-Cancelled｜5-Expired"`
+	Page           int    `json:"page"  dc:"Page, Start With 0" `
+	Count          int    `json:"count"  dc:"Count Of Page" `
+}
+type TrialListRes struct {
+	Trials []*bean.SubscriptionTrial `json:"trials" dc:"Trials"`
+	Total  int                       `json:"total" dc:"Total"`
+}
+
+type TrialReportReq struct {
+	g.Meta    `path:"/trial/report" tags:"Subscription Trial" method:"get,post" summary:"Card-less Trial Conversion Report" dc:"Get the trial conversion per plan of the card-less trials started in the time range"`
+	StartTime int64 `json:"startTime" dc:"StartTime, utc time, default all"`
+	EndTime   int64 `json:"endTime" dc:"EndTime, utc time, default all"`
+}
+type TrialReportRes struct {
+	Reports []*bean.SubscriptionTrialReport `json:"reports" dc:"Reports per plan"`
+}
+
+type TrialLinkReq struct {
+	g.Meta  `path:"/trial/link" tags:"Subscription Trial" method:"get,post" summary:"Card-less Trial Hosted Link" dc:"Get the hosted link the user attaches payment method to the trial through"`
+	TrialId string `json:"trialId" dc:"TrialId" v:"required"`
+}
+type TrialLinkRes struct {
+	Link string `json:"link" dc:"Hosted link"`
+}
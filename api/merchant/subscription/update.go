@@ -71,6 +71,8 @@ type UpdatePreviewReq struct {
 	DiscountCode           string                 `json:"discountCode"        dc:"DiscountCode"`
 	ApplyPromoCredit       *bool                  `json:"applyPromoCredit" dc:"apply promo credit or not"`
 	ApplyPromoCreditAmount *int64                 `json:"applyPromoCreditAmount"  dc:"apply promo credit amount, auto compute if not specified"`
+	ProrationDate          *int64                 `json:"prorationDate" dc:"The utc time to start Proration, default current time, should be within the current period if prorationBehavior specified"`
+	ProrationBehavior      string                 `json:"prorationBehavior" dc:"ProrationBehavior, create_prorations|prorate_to_next_invoice|none|always_invoice, the update effects immediately if specified. create_prorations-invoice the proration now, prorate_to_next_invoice-add the proration to the next cycle invoice as pending invoice item, none-no proration, always_invoice-invoice the proration now and the credit of downgrade added to the next cycle invoice. Follow the subscription config if not specified"`
 }
 
 type UpdatePreviewRes struct {
@@ -110,6 +112,7 @@ type UpdateReq struct {
 	ProductData            *bean.PlanProductParam      `json:"productData"  dc:"ProductData"  `
 	ApplyPromoCredit       bool                        `json:"applyPromoCredit" dc:"apply promo credit or not"`
 	ApplyPromoCreditAmount *int64                      `json:"applyPromoCreditAmount"  dc:"apply promo credit amount, auto compute if not specified"`
+	ProrationBehavior      string                      `json:"prorationBehavior" dc:"ProrationBehavior, create_prorations|prorate_to_next_invoice|none|always_invoice, the update effects immediately if specified. create_prorations-invoice the proration now, prorate_to_next_invoice-add the proration to the next cycle invoice as pending invoice item, none-no proration, always_invoice-invoice the proration now and the credit of downgrade added to the next cycle invoice. Follow the subscription config if not specified"`
 }
 
 type UpdateRes struct {
@@ -130,3 +133,13 @@ type UpdateMetadataReq struct {
 
 type UpdateMetadataRes struct {
 }
+
+type UpdateInvoiceReviewReq struct {
+	g.Meta             `path:"/invoice_review_update" tags:"Subscription Update" method:"post" summary:"Update Subscription Invoice Review" dc:"Create the cycle invoices of the subscription as drafts for review, which are finalised automatically after the hours unless held"`
+	SubscriptionId     string `json:"subscriptionId" dc:"SubscriptionId" v:"required"`
+	InvoiceReviewHours int    `json:"invoiceReviewHours" dc:"Hours the cycle invoices stay in draft for review, 0-follow plan，-1-review disabled"`
+}
+
+type UpdateInvoiceReviewRes struct {
+	Subscription *bean.Subscription `json:"subscription" dc:"Subscription"`
+}
@@ -43,7 +43,7 @@ type NewRes struct {
 type NewImportReq struct {
 	g.Meta `path:"/new_import" method:"post" mime:"multipart/form-data" tags:"Task" summary:"New Import"`
 	File   *ghttp.UploadFile `json:"file" type:"file" dc:"File To Upload" v:"required"`
-	Task   string            `json:"task" dc:"Task,UserImport|ActiveSubscriptionImport|HistorySubscriptionImport" v:"required"`
+	Task   string            `json:"task" dc:"Task,UserImport|ActiveSubscriptionImport|HistorySubscriptionImport|GatewaySettlementImport, xlsx or csv file" v:"required"`
 }
 type NewImportRes struct {
 }
@@ -92,34 +92,39 @@ type SearchRes struct {
 }
 
 type UpdateReq struct {
-	g.Meta             `path:"/update" tags:"User" method:"post" summary:"Update User Profile"`
-	UserId             *uint64                 `json:"userId" dc:"The id of user, either Email or UserId needed"`
-	Email              *string                 `json:"email" dc:"The email of user, either Email or UserId needed"`
-	FirstName          *string                 `json:"firstName" dc:"First name"`
-	LastName           *string                 `json:"lastName" dc:"Last Name"`
-	Address            *string                 `json:"address" dc:"Billing Address"`
-	CompanyName        *string                 `json:"companyName" dc:"Company Name"`
-	VATNumber          *string                 `json:"vATNumber" dc:"VAT Number"`
-	RegistrationNumber *string                 `json:"registrationNumber" dc:"RegistrationNumber"`
-	Phone              *string                 `json:"phone" dc:"Phone"`
-	Telegram           *string                 `json:"telegram" dc:"Telegram"`
-	WhatsApp           *string                 `json:"whatsApp" dc:"WhatsApp"`
-	WeChat             *string                 `json:"weChat" dc:"WeChat"`
-	LinkedIn           *string                 `json:"LinkedIn" dc:"LinkedIn"`
-	Facebook           *string                 `json:"facebook" dc:"Facebook"`
-	TikTok             *string                 `json:"tiktok" dc:"Tiktok"`
-	OtherSocialInfo    *string                 `json:"otherSocialInfo" dc:"Other Social Info"`
-	CountryCode        *string                 `json:"countryCode" dc:"Country Code"`
-	CountryName        *string                 `json:"countryName" dc:"Country Name"`
-	Type               *int64                  `json:"type" dc:"User type, 1-Individual|2-Business"`
-	GatewayId          *uint64                 `json:"gatewayId" dc:"GatewayId"`
-	GatewayPaymentType *string                 `json:"gatewayPaymentType" dc:"Gateway Payment Type"`
-	PaymentMethodId    *string                 `json:"paymentMethodId" dc:"PaymentMethodId of gateway, available for card type gateway, payment automatic will enable if set" `
-	City               *string                 `json:"city" dc:"city"`
-	ZipCode            *string                 `json:"zipCode" dc:"zip_code"`
-	Language           *string                 `json:"language" dc:"User Language, en|ru|cn|vi|bp"`
-	ExternalUserId     *string                 `json:"externalUserId" dc:"ExternalUserId"`
-	Metadata           *map[string]interface{} `json:"metadata" dc:"Metadata，Map"`
+	g.Meta               `path:"/update" tags:"User" method:"post" summary:"Update User Profile"`
+	UserId               *uint64                 `json:"userId" dc:"The id of user, either Email or UserId needed"`
+	Email                *string                 `json:"email" dc:"The email of user, either Email or UserId needed"`
+	FirstName            *string                 `json:"firstName" dc:"First name"`
+	LastName             *string                 `json:"lastName" dc:"Last Name"`
+	Address              *string                 `json:"address" dc:"Billing Address"`
+	CompanyName          *string                 `json:"companyName" dc:"Company Name"`
+	VATNumber            *string                 `json:"vATNumber" dc:"VAT Number"`
+	RegistrationNumber   *string                 `json:"registrationNumber" dc:"RegistrationNumber"`
+	Phone                *string                 `json:"phone" dc:"Phone"`
+	Telegram             *string                 `json:"telegram" dc:"Telegram"`
+	WhatsApp             *string                 `json:"whatsApp" dc:"WhatsApp"`
+	WeChat               *string                 `json:"weChat" dc:"WeChat"`
+	LinkedIn             *string                 `json:"LinkedIn" dc:"LinkedIn"`
+	Facebook             *string                 `json:"facebook" dc:"Facebook"`
+	TikTok               *string                 `json:"tiktok" dc:"Tiktok"`
+	OtherSocialInfo      *string                 `json:"otherSocialInfo" dc:"Other Social Info"`
+	CountryCode          *string                 `json:"countryCode" dc:"Country Code"`
+	CountryName          *string                 `json:"countryName" dc:"Country Name"`
+	Type                 *int64                  `json:"type" dc:"User type, 1-Individual|2-Business"`
+	GatewayId            *uint64                 `json:"gatewayId" dc:"GatewayId"`
+	GatewayPaymentType   *string                 `json:"gatewayPaymentType" dc:"Gateway Payment Type"`
+	PaymentMethodId      *string                 `json:"paymentMethodId" dc:"PaymentMethodId of gateway, available for card type gateway, payment automatic will enable if set" `
+	City                 *string                 `json:"city" dc:"city"`
+	ZipCode              *string                 `json:"zipCode" dc:"zip_code"`
+	RegionCode           *string                 `json:"regionCode" dc:"state or province code, matched by the tax rules of tax engine"`
+	TaxStatus            *int                    `json:"taxStatus" dc:"Tax status，0-taxable，1-exempt，2-reverse charge. Exempt requires taxExemptCertificate, reverse charge requires vat number"`
+	TaxExemptCertificate *string                 `json:"taxExemptCertificate" dc:"Tax exempt certificate reference, printed on invoices of exempt customer"`
+	TaxExemptExpireTime  *int64                  `json:"taxExemptExpireTime" dc:"Tax exempt certificate expire utc time，0-never expire. The customer is taxed again after expiry"`
+	NetTermDays          *int                    `json:"netTermDays" dc:"Payment terms in days，0-due on receipt，30|60|90-net terms. The cycle invoices of the net terms customer are sent to pay within the terms instead of charged automatically"`
+	Language             *string                 `json:"language" dc:"User Language, en|ru|cn|vi|bp"`
+	ExternalUserId       *string                 `json:"externalUserId" dc:"ExternalUserId"`
+	Metadata             *map[string]interface{} `json:"metadata" dc:"Metadata，Map"`
 }
 
 type UpdateRes struct {
@@ -155,6 +160,17 @@ type ClearAutoChargeMethodReq struct {
 type ClearAutoChargeMethodRes struct {
 }
 
+type ConsolidatedBillingSetupReq struct {
+	g.Meta             `path:"/consolidated_billing_setup" tags:"User" method:"post" summary:"Setup User Consolidated Billing" dc:"Merge the cycle invoices of the user's subscriptions in the same currency into one consolidated invoice and payment, the monthly subscriptions are aligned to the anchor from their next cycle with a prorated period"`
+	UserId             uint64 `json:"userId" dc:"The id of user" v:"required"`
+	Enable             bool   `json:"enable" dc:"Enable the consolidated billing or not"`
+	BillingCycleAnchor int64  `json:"billingCycleAnchor" dc:"The utc time the subscriptions are aligned to, the day of month and the clock are used, default the earliest period end of the user's subscriptions"`
+}
+
+type ConsolidatedBillingSetupRes struct {
+	User *bean.UserAccount `json:"user" dc:"User"`
+}
+
 type NewAdminNoteReq struct {
 	g.Meta `path:"/new_admin_note" tags:"User" method:"post" summary:"New Admin Note"`
 	UserId uint64 `json:"userId" dc:"The id of user, either ExternalUserId or UserId needed" v:"required"`
@@ -0,0 +1,90 @@
+package vat
+
+import (
+	"unibee/api/bean"
+	"unibee/internal/logic/tax"
+	"unibee/internal/logic/tax/tax_bean"
+
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+type TaxEngineConfigReq struct {
+	g.Meta `path:"/tax_engine_config" tags:"Tax Engine" method:"get" summary:"Get Tax Engine Config"`
+}
+type TaxEngineConfigRes struct {
+	Config    *tax.Config `json:"config" dc:"Tax Engine Config"`
+	Providers []string    `json:"providers" dc:"Available tax providers"`
+}
+
+type TaxEngineSetupReq struct {
+	g.Meta   `path:"/tax_engine_setup" tags:"Tax Engine" method:"post" summary:"Setup Tax Engine" dc:"When enabled, subscription invoices compute the tax lines with the tax provider, the vat tax percentage applies if no tax rule matches"`
+	Enable   bool   `json:"enable" dc:"Enable the tax engine"`
+	Provider string `json:"provider" dc:"Tax provider, default local"`
+}
+type TaxEngineSetupRes struct {
+	Config *tax.Config `json:"config" dc:"Tax Engine Config"`
+}
+
+type TaxRuleListReq struct {
+	g.Meta      `path:"/tax_rule_list" tags:"Tax Engine" method:"get,post" summary:"Get Tax Rule List"`
+	CountryCode string `json:"countryCode" dc:"Filter country code, default all"`
+}
+type TaxRuleListRes struct {
+	TaxRules []*bean.MerchantTaxRule `json:"taxRules" dc:"Tax Rule List"`
+}
+
+type TaxRuleNewReq struct {
+	g.Meta        `path:"/tax_rule_new" tags:"Tax Engine" method:"post" summary:"New Tax Rule" dc:"All rules matching the customer address and plan tax category stack on the item in priority order, a rule with tax category replaces the rule without category of the same name and jurisdiction"`
+	Name          string `json:"name" dc:"Tax name shown on invoice, like GST, PST, State Tax" v:"required"`
+	Jurisdiction  string `json:"jurisdiction" dc:"Jurisdiction label, like US-CA, US-CA-Los Angeles"`
+	CountryCode   string `json:"countryCode" dc:"Country code" v:"required"`
+	RegionCode    string `json:"regionCode" dc:"State or province code, empty matches all regions"`
+	City          string `json:"city" dc:"City, empty matches all cities"`
+	ZipCodePrefix string `json:"zipCodePrefix" dc:"Zip code prefix, empty matches all zip codes"`
+	TaxCategory   string `json:"taxCategory" dc:"Tax category of plan, empty matches all categories"`
+	Rate          int64  `json:"rate" dc:"Tax rate，1000 = 10%"`
+	Compound      bool   `json:"compound" dc:"Compound on the amount and the previous taxes of the item"`
+	Priority      int    `json:"priority" dc:"Apply order, lower first"`
+}
+type TaxRuleNewRes struct {
+	TaxRule *bean.MerchantTaxRule `json:"taxRule" dc:"Tax Rule"`
+}
+
+type TaxRuleEditReq struct {
+	g.Meta        `path:"/tax_rule_edit" tags:"Tax Engine" method:"post" summary:"Edit Tax Rule"`
+	RuleId        uint64  `json:"ruleId" dc:"Id of tax rule" v:"required"`
+	Name          *string `json:"name" dc:"Tax name shown on invoice, like GST, PST, State Tax"`
+	Jurisdiction  *string `json:"jurisdiction" dc:"Jurisdiction label, like US-CA, US-CA-Los Angeles"`
+	CountryCode   *string `json:"countryCode" dc:"Country code"`
+	RegionCode    *string `json:"regionCode" dc:"State or province code, empty matches all regions"`
+	City          *string `json:"city" dc:"City, empty matches all cities"`
+	ZipCodePrefix *string `json:"zipCodePrefix" dc:"Zip code prefix, empty matches all zip codes"`
+	TaxCategory   *string `json:"taxCategory" dc:"Tax category of plan, empty matches all categories"`
+	Rate          *int64  `json:"rate" dc:"Tax rate，1000 = 10%"`
+	Compound      *bool   `json:"compound" dc:"Compound on the amount and the previous taxes of the item"`
+	Priority      *int    `json:"priority" dc:"Apply order, lower first"`
+}
+type TaxRuleEditRes struct {
+	TaxRule *bean.MerchantTaxRule `json:"taxRule" dc:"Tax Rule"`
+}
+
+type TaxRuleDeleteReq struct {
+	g.Meta `path:"/tax_rule_delete" tags:"Tax Engine" method:"post" summary:"Delete Tax Rule"`
+	RuleId uint64 `json:"ruleId" dc:"Id of tax rule" v:"required"`
+}
+type TaxRuleDeleteRes struct {
+}
+
+type TaxComputePreviewReq struct {
+	g.Meta      `path:"/tax_compute_preview" tags:"Tax Engine" method:"post" summary:"Tax Compute Preview" dc:"Compute the tax lines of the items with the tax provider, whether the tax engine is enabled or not"`
+	UserId      uint64                     `json:"userId" dc:"Id of user, the address of user used if specified"`
+	Currency    string                     `json:"currency" dc:"Currency"`
+	CountryCode string                     `json:"countryCode" dc:"Country code"`
+	RegionCode  string                     `json:"regionCode" dc:"State or province code"`
+	City        string                     `json:"city" dc:"City"`
+	ZipCode     string                     `json:"zipCode" dc:"Zip code"`
+	Items       []*tax_bean.ComputeTaxItem `json:"items" dc:"Items to compute" v:"required"`
+}
+type TaxComputePreviewRes struct {
+	Items []*tax_bean.ComputeTaxItemResult `json:"items" dc:"Tax result of the items, same order as the request items"`
+}
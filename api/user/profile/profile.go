@@ -45,6 +45,7 @@ type UpdateReq struct {
 	PaymentMethodId    *string `json:"paymentMethodId" dc:"PaymentMethodId of gateway, available for card type gateway, payment automatic will enable if set" `
 	City               string  `json:"city" dc:"city"`
 	ZipCode            string  `json:"zipCode" dc:"zip_code"`
+	RegionCode         *string `json:"regionCode" dc:"state or province code, matched by the tax rules of tax engine"`
 	Language           string  `json:"language" dc:"User Language, en|ru|cn|vi|bp"`
 	RegistrationNumber string  `json:"registrationNumber" dc:"RegistrationNumber"`
 }
@@ -214,11 +214,21 @@ type SuspendReq struct {
 type SuspendRes struct {
 }
 
+type PauseReq struct {
+	g.Meta         `path:"/pause" tags:"User-Subscription" method:"post" summary:"User Pause Subscription" dc:"Pause the active subscription, available when userPauseEnable of subscription config is on"`
+	SubscriptionId string `json:"subscriptionId" dc:"SubscriptionId" v:"required"`
+	ResumeTime     int64  `json:"resumeTime" dc:"ResumeTime, the utc time the subscription resumes automatically, 0 to resume manually"`
+}
+type PauseRes struct {
+	Subscription *bean.Subscription `json:"subscription" dc:"Subscription"`
+}
+
 type ResumeReq struct {
-	g.Meta         `path:"/resume" tags:"User-Subscription" method:"post" summary:"User Edit Subscription-Resume"  deprecated:"true"`
+	g.Meta         `path:"/resume" tags:"User-Subscription" method:"post" summary:"User Resume Subscription" dc:"Resume the paused subscription"`
 	SubscriptionId string `json:"subscriptionId" dc:"SubscriptionId" v:"required"`
 }
 type ResumeRes struct {
+	Subscription *bean.Subscription `json:"subscription" dc:"Subscription"`
 }
 
 type ChangeGatewayReq struct {
@@ -317,3 +327,51 @@ type RenewRes struct {
 	Link         string             `json:"link"`
 	Action       *gjson.Json        `json:"action"`
 }
+
+type CancellationReasonsReq struct {
+	g.Meta `path:"/cancellation/reasons" tags:"User-Subscription" method:"get" summary:"User Subscription Cancellation Reasons" dc:"Get the reasons to choose from when cancelling subscription"`
+}
+type CancellationReasonsRes struct {
+	Reasons []string `json:"reasons" dc:"Reasons"`
+}
+
+type CancellationStartReq struct {
+	g.Meta         `path:"/cancellation/start" tags:"User-Subscription" method:"post" summary:"User Start Subscription Cancellation" dc:"Capture the cancellation reason and get the retention offers presented, accept one of them to keep the subscription or confirm to cancel it at period end"`
+	SubscriptionId string `json:"subscriptionId" dc:"SubscriptionId" v:"required"`
+	Reason         string `json:"reason" dc:"Reason, one of the cancellation reasons" v:"required"`
+	ReasonText     string `json:"reasonText" dc:"ReasonText, free text of the reason"`
+}
+type CancellationStartRes struct {
+	Cancellation    *bean.SubscriptionCancellation     `json:"cancellation" dc:"Cancellation"`
+	RetentionOffers []*bean.SubscriptionRetentionOffer `json:"retentionOffers" dc:"RetentionOffers presented"`
+}
+
+type CancellationAcceptOfferReq struct {
+	g.Meta         `path:"/cancellation/accept_offer" tags:"User-Subscription" method:"post" summary:"User Accept Retention Offer" dc:"Accept the retention offer presented and keep the subscription"`
+	CancellationId string `json:"cancellationId" dc:"CancellationId" v:"required"`
+	OfferId        uint64 `json:"offerId" dc:"OfferId, id of the retention offer presented" v:"required"`
+}
+type CancellationAcceptOfferRes struct {
+	Cancellation *bean.SubscriptionCancellation `json:"cancellation" dc:"Cancellation"`
+	Subscription *bean.Subscription             `json:"subscription" dc:"Subscription"`
+}
+
+type CancellationConfirmReq struct {
+	g.Meta         `path:"/cancellation/confirm" tags:"User-Subscription" method:"post" summary:"User Confirm Subscription Cancellation" dc:"Decline the retention offers and cancel the subscription at period end"`
+	CancellationId string `json:"cancellationId" dc:"CancellationId" v:"required"`
+}
+type CancellationConfirmRes struct {
+	Cancellation *bean.SubscriptionCancellation `json:"cancellation" dc:"Cancellation"`
+	Subscription *bean.Subscription             `json:"subscription" dc:"Subscription"`
+}
+
+type TrialPaymentMethodReq struct {
+	g.Meta         `path:"/trial/payment_method" tags:"User-Subscription" method:"post" summary:"User Attach Payment Method To Trial" dc:"Get the gateway link to attach payment method to the card-less trial, the subscription is charged at trial end once attached"`
+	SubscriptionId string `json:"subscriptionId" dc:"SubscriptionId" v:"required"`
+	GatewayId      uint64 `json:"gatewayId" dc:"GatewayId, user's default gateway if not specified"`
+	ReturnUrl      string `json:"returnUrl" dc:"ReturnUrl, back to returnUrl after the payment method attached"`
+}
+type TrialPaymentMethodRes struct {
+	Trial *bean.SubscriptionTrial `json:"trial" dc:"Trial"`
+	Link  string                  `json:"link" dc:"Gateway link to attach payment method"`
+}
@@ -91,7 +91,13 @@ type IUserSubscription interface {
 	CancelAtPeriodEnd(ctx context.Context, req *subscription.CancelAtPeriodEndReq) (res *subscription.CancelAtPeriodEndRes, err error)
 	CancelLastCancelAtPeriodEnd(ctx context.Context, req *subscription.CancelLastCancelAtPeriodEndReq) (res *subscription.CancelLastCancelAtPeriodEndRes, err error)
 	Suspend(ctx context.Context, req *subscription.SuspendReq) (res *subscription.SuspendRes, err error)
+	Pause(ctx context.Context, req *subscription.PauseReq) (res *subscription.PauseRes, err error)
 	Resume(ctx context.Context, req *subscription.ResumeReq) (res *subscription.ResumeRes, err error)
+	CancellationReasons(ctx context.Context, req *subscription.CancellationReasonsReq) (res *subscription.CancellationReasonsRes, err error)
+	CancellationStart(ctx context.Context, req *subscription.CancellationStartReq) (res *subscription.CancellationStartRes, err error)
+	CancellationAcceptOffer(ctx context.Context, req *subscription.CancellationAcceptOfferReq) (res *subscription.CancellationAcceptOfferRes, err error)
+	CancellationConfirm(ctx context.Context, req *subscription.CancellationConfirmReq) (res *subscription.CancellationConfirmRes, err error)
+	TrialPaymentMethod(ctx context.Context, req *subscription.TrialPaymentMethodReq) (res *subscription.TrialPaymentMethodRes, err error)
 	ChangeGateway(ctx context.Context, req *subscription.ChangeGatewayReq) (res *subscription.ChangeGatewayRes, err error)
 	TimeLineList(ctx context.Context, req *subscription.TimeLineListReq) (res *subscription.TimeLineListRes, err error)
 	OnetimeAddonNew(ctx context.Context, req *subscription.OnetimeAddonNewReq) (res *subscription.OnetimeAddonNewRes, err error)
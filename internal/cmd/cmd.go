@@ -17,6 +17,7 @@ import (
 	"unibee/internal/controller/link/invoice"
 	"unibee/internal/controller/link/oss"
 	"unibee/internal/controller/link/payment"
+	"unibee/internal/controller/link/subscription"
 	"unibee/internal/controller/merchant"
 	"unibee/internal/controller/system"
 	"unibee/internal/controller/user"
@@ -252,6 +253,16 @@ var (
 						merchant.NewIntegration(),
 					)
 				})
+				group.Group("/settlement", func(group *ghttp.RouterGroup) {
+					group.Bind(
+						merchant.NewSettlement(),
+					)
+				})
+				group.Group("/bank_statement", func(group *ghttp.RouterGroup) {
+					group.Bind(
+						merchant.NewBankStatement(),
+					)
+				})
 			})
 
 			s.Group("/user", func(group *ghttp.RouterGroup) {
@@ -430,10 +441,13 @@ var (
 			// Invoice Link
 			s.BindHandler("GET:/in/{invoiceId}", invoice.LinkEntry)
 			s.BindHandler("GET:/in/pdf/{invoiceId}", invoice.LinkPdfEntry)
+			s.BindHandler("GET:/in/xml/{invoiceId}", invoice.LinkXmlEntry)
+			s.BindHandler("GET:/cn/pdf/{creditNoteId}", invoice.LinkCreditNotePdfEntry)
 			s.BindHandler("GET:/oss/file/{filename}", oss.FileEntry)
 			s.BindHandler("GET:/export/{taskId}", export.LinkExportEntry)
 			s.BindHandler("GET:/import/template/{task}", _import.LinkImportTemplateEntry)
 			s.BindHandler("GET:/pay/{paymentId}", payment.LinkEntry)
+			s.BindHandler("GET:/trial/{trialId}", subscription.LinkTrialEntry)
 			// Integration Link
 			s.BindHandler("GET:/integrate/quickbooks/auth_back", integrations.QuickBooksAuthorizationEntry)
 			// Gateway Payment Redirect
@@ -30,6 +30,8 @@ var (
 	TopicSubscriptionPaymentSuccess       = redismq.MQTopicEnum{Topic: "unibee_subscription", Tag: "subscription_payment_success", Description: "subscription payment success"}
 	TopicSubscriptionAutoRenewSuccess     = redismq.MQTopicEnum{Topic: "unibee_subscription", Tag: "subscription_auto_renew_success", Description: "subscription auto renew success"}
 	TopicSubscriptionAutoRenewFailure     = redismq.MQTopicEnum{Topic: "unibee_subscription", Tag: "subscription_auto_renew_failure", Description: "subscription auto renew failure"}
+	TopicSubscriptionPaused               = redismq.MQTopicEnum{Topic: "unibee_subscription", Tag: "subscription_paused", Description: "subscription paused"}
+	TopicSubscriptionResumed              = redismq.MQTopicEnum{Topic: "unibee_subscription", Tag: "subscription_resumed", Description: "subscription resumed"}
 	TopicMerchantWebhook                  = redismq.MQTopicEnum{Topic: "unibee_merchant_webhook", Tag: "webhook", Description: "merchant webhook"}
 	TopicInternalWebhook                  = redismq.MQTopicEnum{Topic: "unibee_internal_webhook", Tag: "webhook", Description: "internal webhook"}
 	TopicInvoiceCreated                   = redismq.MQTopicEnum{Topic: "unibee_invoice", Tag: "invoice_created", Description: "invoice created"}
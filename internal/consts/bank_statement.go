@@ -0,0 +1,34 @@
+package consts
+
+const (
+	BankStatementFormatCamt053 = "camt053"
+	BankStatementFormatMT940   = "mt940"
+	BankStatementFormatCsv     = "csv"
+)
+
+type BankStatementLineStatusEnum int
+
+const (
+	BankStatementLineAutoMatched   = 1
+	BankStatementLineNeedReview    = 2
+	BankStatementLineUnmatched     = 3
+	BankStatementLineManualMatched = 4
+	BankStatementLineIgnored       = 5
+)
+
+func (status BankStatementLineStatusEnum) Description() string {
+	switch status {
+	case BankStatementLineAutoMatched:
+		return "AutoMatched"
+	case BankStatementLineNeedReview:
+		return "NeedReview"
+	case BankStatementLineUnmatched:
+		return "Unmatched"
+	case BankStatementLineManualMatched:
+		return "ManualMatched"
+	case BankStatementLineIgnored:
+		return "Ignored"
+	default:
+		return "NeedReview"
+	}
+}
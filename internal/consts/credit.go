@@ -14,7 +14,7 @@ const (
 type TransactionTypeEnum int
 
 const (
-	// Transaction type。1-recharge income，2-payment out，3-refund income，4-withdraw out，5-withdraw failed income, 6-admin change，7-recharge refund out
+	// Transaction type。1-recharge income，2-payment out，3-refund income，4-withdraw out，5-withdraw failed income, 6-admin change，7-recharge refund out, 8-credit note income
 	CreditTransactionRechargeIncome       = 1
 	CreditTransactionPayout               = 2
 	CreditTransactionRefundIncome         = 3
@@ -22,6 +22,7 @@ const (
 	CreditTransactionWithdrawFailedIncome = 5
 	CreditTransactionAdminChange          = 6
 	CreditTransactionRechargeRefundOut    = 7
+	CreditTransactionCreditNoteIncome     = 8
 )
 
 func (transactionType TransactionTypeEnum) Description() string {
@@ -38,6 +39,8 @@ func (transactionType TransactionTypeEnum) Description() string {
 		return "WithdrawFailedIncome"
 	case CreditTransactionRechargeRefundOut:
 		return "RechargeRefundOut"
+	case CreditTransactionCreditNoteIncome:
+		return "CreditNoteIncome"
 	default:
 		return "AdminChange"
 	}
@@ -57,6 +60,8 @@ func (transactionType TransactionTypeEnum) ExportDescription(amount int64) strin
 		return "WithdrawFailedIncome"
 	case CreditTransactionRechargeRefundOut:
 		return "RechargeRefundOut"
+	case CreditTransactionCreditNoteIncome:
+		return "From credit note"
 	default:
 		if amount > 0 {
 			return "Added by admin"
@@ -80,6 +85,8 @@ func CreditTransactionTypeToEnum(transactionType int) TransactionTypeEnum {
 		return CreditTransactionWithdrawFailedIncome
 	case CreditTransactionRechargeRefundOut:
 		return CreditTransactionRechargeRefundOut
+	case CreditTransactionCreditNoteIncome:
+		return CreditTransactionCreditNoteIncome
 	default:
 		return CreditTransactionAdminChange
 	}
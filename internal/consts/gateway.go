@@ -25,3 +25,38 @@ func (status GatewayTypeEnum) Description() string {
 		return "GatewayTypeCard"
 	}
 }
+
+type SettlementStatusEnum int
+
+const (
+	SettlementPending   = 1
+	SettlementPaid      = 2
+	SettlementFailed    = 3
+	SettlementCancelled = 4
+)
+
+func (status SettlementStatusEnum) Description() string {
+	switch status {
+	case SettlementPending:
+		return "Pending"
+	case SettlementPaid:
+		return "Paid"
+	case SettlementFailed:
+		return "Failed"
+	case SettlementCancelled:
+		return "Cancelled"
+	default:
+		return "Pending"
+	}
+}
+
+const (
+	SettlementItemTypePayment    = "payment"
+	SettlementItemTypeRefund     = "refund"
+	SettlementItemTypeFee        = "fee"
+	SettlementItemTypeDispute    = "dispute"
+	SettlementItemTypeAdjustment = "adjustment"
+	SettlementItemTypeOther      = "other"
+	SettlementSourceGateway      = "gateway"
+	SettlementSourceImport       = "import"
+)
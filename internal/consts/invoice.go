@@ -64,3 +64,28 @@ func InvoiceStatusToEnum(status int) InvoiceStatusEnum {
 }
 
 const InvoiceAutoChargeFlag = "AutoRenew"
+
+const (
+	CreditNoteTypeRefund  = 1
+	CreditNoteTypeBalance = 2
+
+	CreditNoteStatusIssued = 10
+	CreditNoteStatusFailed = 20
+)
+
+const (
+	InvoiceTaxTreatmentStandard      = ""
+	InvoiceTaxTreatmentReverseCharge = "reverse_charge"
+	InvoiceTaxTreatmentExempt        = "exempt"
+)
+
+// MaxInvoiceReviewHours limits the review window of the draft cycle invoices to 30 days
+const MaxInvoiceReviewHours = 720
+
+const (
+	InvoiceAgeingBucketCurrent = "current"
+	InvoiceAgeingBucket1To30   = "1-30"
+	InvoiceAgeingBucket31To60  = "31-60"
+	InvoiceAgeingBucket61To90  = "61-90"
+	InvoiceAgeingBucketOver90  = "90+"
+)
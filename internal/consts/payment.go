@@ -5,6 +5,7 @@ const (
 	BizTypeInvoice        = 2
 	BizTypeSubscription   = 3
 	BizTypeCreditRecharge = 4
+	BizTypeConsolidated   = 5
 
 	WaitingAuthorized = 0
 	Authorized        = 1
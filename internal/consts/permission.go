@@ -7,6 +7,7 @@ const (
 	PermissionRead     PermissionType = "read"
 	PermissionWrite    PermissionType = "write"
 	PermissionDownload PermissionType = "download"
+	PermissionReview   PermissionType = "review"
 )
 
 type PermissionTypeGroup string
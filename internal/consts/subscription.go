@@ -85,3 +85,9 @@ const (
 	SubTimeLineStatusExpired    = 4
 	SubTimeLineStatusFailed     = 5
 )
+
+const (
+	SubPauseModeKeepAsDraft       = "keep_as_draft"      // the open cycle invoice goes back to draft and is finalised again after resume
+	SubPauseModeVoid              = "void"               // the open cycle invoice is cancelled
+	SubPauseModeMarkUncollectible = "mark_uncollectible" // the open cycle invoice is closed as failed without further collection
+)
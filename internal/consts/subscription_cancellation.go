@@ -0,0 +1,18 @@
+package consts
+
+const (
+	SubCancellationStatusStarted   = 1
+	SubCancellationStatusSaved     = 2
+	SubCancellationStatusCancelled = 3
+)
+
+const (
+	RetentionOfferStatusActive   = 1
+	RetentionOfferStatusArchived = 2
+)
+
+const (
+	RetentionOfferTypeDiscount  = "discount"
+	RetentionOfferTypePause     = "pause"
+	RetentionOfferTypeDowngrade = "downgrade"
+)
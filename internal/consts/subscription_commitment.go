@@ -0,0 +1,8 @@
+package consts
+
+const (
+	SubCommitmentStatusActive     = 1
+	SubCommitmentStatusCompleted  = 2
+	SubCommitmentStatusTerminated = 3
+	SubCommitmentStatusCancelled  = 4
+)
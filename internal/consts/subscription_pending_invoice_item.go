@@ -0,0 +1,18 @@
+package consts
+
+const (
+	PendingInvoiceItemStatusPending  = 1
+	PendingInvoiceItemStatusInvoiced = 2
+)
+
+const (
+	PendingInvoiceItemSourceSubscriptionUpdate = "SubscriptionUpdate"
+	PendingInvoiceItemSourceMerchant           = "Merchant"
+)
+
+const (
+	ProrationBehaviorCreateProrations     = "create_prorations"
+	ProrationBehaviorProrateToNextInvoice = "prorate_to_next_invoice"
+	ProrationBehaviorNone                 = "none"
+	ProrationBehaviorAlwaysInvoice        = "always_invoice"
+)
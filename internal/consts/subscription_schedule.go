@@ -0,0 +1,19 @@
+package consts
+
+const (
+	SubScheduleStatusActive    = 1
+	SubScheduleStatusCompleted = 2
+	SubScheduleStatusReleased  = 3
+)
+
+const (
+	SubSchedulePhaseStatusPending   = 1
+	SubSchedulePhaseStatusActive    = 2
+	SubSchedulePhaseStatusCompleted = 3
+	SubSchedulePhaseStatusCancelled = 4
+)
+
+const (
+	SubScheduleEndBehaviorRelease = "release"
+	SubScheduleEndBehaviorCancel  = "cancel"
+)
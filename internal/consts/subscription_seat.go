@@ -0,0 +1,17 @@
+package consts
+
+const (
+	SubSeatStatusAssigned = 1
+	SubSeatStatusRemoved  = 2
+)
+
+const (
+	SubSeatChangeStatusPending = 1
+	SubSeatChangeStatusBilled  = 2
+	SubSeatChangeStatusApplied = 3
+)
+
+const (
+	SeatProrationModeImmediate = "immediate"
+	SeatProrationModeTrueUp    = "true_up"
+)
@@ -0,0 +1,15 @@
+package consts
+
+const (
+	SubTrialStatusTrialing  = 1
+	SubTrialStatusConverted = 2
+	SubTrialStatusPaused    = 3
+	SubTrialStatusCancelled = 4
+	SubTrialStatusExpired   = 5
+)
+
+const (
+	TrialEndBehaviorConvert = "convert"
+	TrialEndBehaviorPause   = "pause"
+	TrialEndBehaviorCancel  = "cancel"
+)
@@ -55,3 +55,16 @@ func UserTypeToEnum(userType int64) UserTypeEnum {
 		return UserTypeIndividual
 	}
 }
+
+const (
+	UserTaxStatusTaxable       = 0
+	UserTaxStatusExempt        = 1
+	UserTaxStatusReverseCharge = 2
+)
+
+const (
+	UserNetTermDueOnReceipt = 0
+	UserNetTerm30           = 30
+	UserNetTerm60           = 60
+	UserNetTerm90           = 90
+)
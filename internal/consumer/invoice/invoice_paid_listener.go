@@ -47,7 +47,10 @@ func (t InvoicePaidListener) Consume(ctx context.Context, message *redismq.Messa
 		if len(one.SubscriptionId) > 0 {
 			next.ClearSubscriptionNextInvoiceData(ctx, one.SubscriptionId, one.InvoiceId)
 		}
-		quickbooks.UploadPaidInvoice(ctx, one.InvoiceId)
+		if len(one.ConsolidatedInvoiceId) == 0 {
+			// the cycle invoice charged by a consolidated invoice is uploaded with the consolidated invoice
+			quickbooks.UploadPaidInvoice(ctx, one.InvoiceId)
+		}
 	}
 	return redismq.CommitMessage
 }
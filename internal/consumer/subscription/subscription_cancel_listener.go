@@ -12,6 +12,7 @@ import (
 	dao "unibee/internal/dao/default"
 	"unibee/internal/logic/invoice/service"
 	service2 "unibee/internal/logic/subscription/pending_update_cancel"
+	"unibee/internal/logic/subscription/schedule"
 	"unibee/internal/logic/subscription/timeline"
 	"unibee/internal/logic/subscription/user_sub_plan"
 	"unibee/internal/logic/user/sub_update"
@@ -56,6 +57,7 @@ func (t SubscriptionCancelListener) Consume(ctx context.Context, message *redism
 			g.Log().Errorf(ctx, "SubscriptionCancelListener SubscriptionPendingUpdateCancel error:%s", err.Error())
 		}
 	}
+	schedule.ReleaseSubscriptionScheduleBySubEnd(ctx, sub.SubscriptionId, "SubscriptionCancelled")
 	//Cancel All Invoice
 	service.TryCancelSubscriptionLatestInvoice(ctx, sub)
 	user_sub_plan.ReloadUserSubPlanCacheListBackground(sub.MerchantId, sub.UserId)
@@ -0,0 +1,48 @@
+package subscription
+
+import (
+	"context"
+	"fmt"
+	"github.com/gogf/gf/v2/frame/g"
+	redismq "github.com/jackyang-hk/go-redismq"
+	redismq2 "unibee/internal/cmd/redismq"
+	"unibee/internal/consumer/webhook/event"
+	subscription3 "unibee/internal/consumer/webhook/subscription"
+	"unibee/internal/logic/subscription/user_sub_plan"
+	"unibee/internal/logic/user/sub_update"
+	"unibee/internal/query"
+	"unibee/utility"
+)
+
+type SubscriptionResumedListener struct {
+}
+
+func (t SubscriptionResumedListener) GetTopic() string {
+	return redismq2.TopicSubscriptionResumed.Topic
+}
+
+func (t SubscriptionResumedListener) GetTag() string {
+	return redismq2.TopicSubscriptionResumed.Tag
+}
+
+func (t SubscriptionResumedListener) Consume(ctx context.Context, message *redismq.Message) redismq.Action {
+	utility.Assert(len(message.Body) > 0, "body is nil")
+	utility.Assert(len(message.Body) != 0, "body length is 0")
+	g.Log().Infof(ctx, "SubscriptionResumedListener Receive Message:%s", utility.MarshalToJsonString(message))
+	sub := query.GetSubscriptionBySubscriptionId(ctx, message.Body)
+	if sub != nil {
+		sub_update.UpdateUserDefaultSubscriptionForUpdate(ctx, sub.UserId, sub.SubscriptionId)
+		user_sub_plan.ReloadUserSubPlanCacheListBackground(sub.MerchantId, sub.UserId)
+		subscription3.SendMerchantSubscriptionWebhookBackground(sub, -10000, event.UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_RESUMED, message.CustomData)
+	}
+	return redismq.CommitMessage
+}
+
+func init() {
+	redismq.RegisterListener(NewSubscriptionResumedListener())
+	fmt.Println("SubscriptionResumedListener RegisterListener")
+}
+
+func NewSubscriptionResumedListener() *SubscriptionResumedListener {
+	return &SubscriptionResumedListener{}
+}
@@ -39,7 +39,7 @@ func (t SubscriptionUpdateListener) Consume(ctx context.Context, message *redism
 		user_sub_plan.ReloadUserSubPlanCacheListBackground(sub.MerchantId, sub.UserId)
 		subscription3.SendMerchantSubscriptionWebhookBackground(sub, -10000, event.UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_UPDATED, message.CustomData)
 		//user2.SendMerchantUserMetricWebhookBackground(sub.UserId, sub.SubscriptionId, event.UNIBEE_WEBHOOK_EVENT_USER_METRIC_UPDATED, fmt.Sprintf("SubscriptionUpdate#%s", sub.SubscriptionId))
-		if len(sub.PendingUpdateId) > 0 {
+		if len(sub.PendingUpdateId) > 0 && !pending_update_cancel.IsWaitingForCommitmentTermEnd(query.GetSubscriptionPendingUpdateByPendingUpdateId(ctx, sub.PendingUpdateId), sub.CurrentPeriodStart) {
 			err := pending_update_cancel.SubscriptionPendingUpdateCancel(ctx, sub.PendingUpdateId, "CancelByUpdate-"+sub.PendingUpdateId)
 			if err != nil {
 				g.Log().Errorf(ctx, "HandleSubscriptionNextBillingCycleUpdate SubscriptionPendingUpdateCancel pendingUpdateId:%s error:%s", sub.PendingUpdateId, err.Error())
@@ -0,0 +1,40 @@
+package credit_note
+
+import (
+	"context"
+	"fmt"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"unibee/api/bean"
+	"unibee/internal/consumer/webhook/event"
+	"unibee/internal/consumer/webhook/log"
+	"unibee/internal/consumer/webhook/message"
+	entity "unibee/internal/model/entity/default"
+	"unibee/utility"
+)
+
+func SendMerchantCreditNoteWebhookBackground(one *entity.CreditNote, event event.WebhookEvent, metadata map[string]interface{}) {
+	go func() {
+		ctx := context.Background()
+		var err error
+		defer func() {
+			if exception := recover(); exception != nil {
+				if v, ok := exception.(error); ok && gerror.HasStack(v) {
+					err = v
+				} else {
+					err = gerror.NewCodef(gcode.CodeInternalPanic, "%+v", exception)
+				}
+				log.PrintPanic(ctx, err)
+				return
+			}
+		}()
+		if one != nil {
+			g.Log().Infof(ctx, "SendMerchantCreditNoteWebhookBackground_creditNoteId:%s， event:%s", one.CreditNoteId, event)
+			key := fmt.Sprintf("webhook_credit_note_lock_%s_%s", one.CreditNoteId, event)
+			if utility.TryLock(ctx, key, 60) {
+				message.SendWebhookMessage(ctx, event, one.MerchantId, utility.FormatToGJson(bean.SimplifyCreditNote(one)), "", "", metadata)
+			}
+		}
+	}()
+}
@@ -19,6 +19,16 @@ const (
 	UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_INVOICE_TRACK             = "subscription.latest_invoice.track"      // pending every day at 3 days before period end
 	UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_EXPIRED                   = "subscription.expired"
 	UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_FAILED                    = "subscription.failed"
+	UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_PAUSED                    = "subscription.paused"
+	UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_RESUMED                   = "subscription.resumed"
+	UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_TRIAL_WILL_END            = "subscription.trial.will_end" // trial without payment method ends in days
+	UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_TRIAL_CONVERTED           = "subscription.trial.converted"
+	UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_TRANSFERRED               = "subscription.transferred"
+	UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_COMMITMENT_CREATED        = "subscription.commitment.created"
+	UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_COMMITMENT_TERM_WILL_END  = "subscription.commitment.term_will_end" // commitment term ends in days
+	UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_COMMITMENT_RENEWED        = "subscription.commitment.renewed"
+	UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_COMMITMENT_COMPLETED      = "subscription.commitment.completed"
+	UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_COMMITMENT_TERMINATED     = "subscription.commitment.terminated"
 
 	UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_PENDING_UPDATE_CREATE    = "subscription.pending_update.create"
 	UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_PENDING_UPDATE_SUCCESS   = "subscription.pending_update.success"
@@ -29,6 +39,10 @@ const (
 	UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_ONETIME_ADDON_CANCELLED = "subscription.onetime_addon.cancelled"
 	UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_ONETIME_ADDON_EXPIRED   = "subscription.onetime_addon.expired"
 
+	UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_PENDING_INVOICE_ITEM_CREATED  = "subscription.pending_invoice_item.created"
+	UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_PENDING_INVOICE_ITEM_DELETED  = "subscription.pending_invoice_item.deleted"
+	UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_PENDING_INVOICE_ITEM_INVOICED = "subscription.pending_invoice_item.invoiced"
+
 	UNIBEE_WEBHOOK_EVENT_USER_CREATED              = "user.created"
 	UNIBEE_WEBHOOK_EVENT_USER_UPDATED              = "user.updated"
 	UNIBEE_WEBHOOK_EVENT_USER_METRIC_UPDATED       = "user.metric.update"
@@ -51,6 +65,8 @@ const (
 	UNIBEE_WEBHOOK_EVENT_INVOICE_CANCELLED = "invoice.cancelled"
 	UNIBEE_WEBHOOK_EVENT_INVOICE_FAILED    = "invoice.failed"
 	UNIBEE_WEBHOOK_EVENT_INVOICE_REVERSED  = "invoice.reversed"
+
+	UNIBEE_WEBHOOK_EVENT_CREDIT_NOTE_CREATED = "credit_note.created"
 )
 
 var ListeningEventList = []string{
@@ -70,6 +86,16 @@ var ListeningEventList = []string{
 	UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_INVOICE_TRACK,
 	UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_EXPIRED,
 	UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_FAILED,
+	UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_PAUSED,
+	UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_RESUMED,
+	UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_TRIAL_WILL_END,
+	UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_TRIAL_CONVERTED,
+	UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_TRANSFERRED,
+	UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_COMMITMENT_CREATED,
+	UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_COMMITMENT_TERM_WILL_END,
+	UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_COMMITMENT_RENEWED,
+	UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_COMMITMENT_COMPLETED,
+	UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_COMMITMENT_TERMINATED,
 	UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_PENDING_UPDATE_CREATE,
 	UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_PENDING_UPDATE_SUCCESS,
 	UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_PENDING_UPDATE_CANCELLED,
@@ -77,6 +103,9 @@ var ListeningEventList = []string{
 	UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_ONETIME_ADDON_SUCCESS,
 	UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_ONETIME_ADDON_CANCELLED,
 	UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_ONETIME_ADDON_EXPIRED,
+	UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_PENDING_INVOICE_ITEM_CREATED,
+	UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_PENDING_INVOICE_ITEM_DELETED,
+	UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_PENDING_INVOICE_ITEM_INVOICED,
 	UNIBEE_WEBHOOK_EVENT_USER_CREATED,
 	UNIBEE_WEBHOOK_EVENT_USER_UPDATED,
 	UNIBEE_WEBHOOK_EVENT_USER_METRIC_UPDATED,
@@ -97,6 +126,7 @@ var ListeningEventList = []string{
 	UNIBEE_WEBHOOK_EVENT_INVOICE_CANCELLED,
 	UNIBEE_WEBHOOK_EVENT_INVOICE_FAILED,
 	UNIBEE_WEBHOOK_EVENT_INVOICE_REVERSED,
+	UNIBEE_WEBHOOK_EVENT_CREDIT_NOTE_CREATED,
 }
 
 func WebhookEventInListeningEvents(target WebhookEvent) bool {
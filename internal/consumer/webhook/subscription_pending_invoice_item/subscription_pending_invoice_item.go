@@ -0,0 +1,35 @@
+package subscription_pending_invoice_item
+
+import (
+	"context"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"unibee/api/bean"
+	"unibee/internal/consumer/webhook/event"
+	"unibee/internal/consumer/webhook/log"
+	"unibee/internal/consumer/webhook/message"
+	entity "unibee/internal/model/entity/default"
+	"unibee/utility"
+)
+
+func SendMerchantSubscriptionPendingInvoiceItemWebhookBackground(one *entity.SubscriptionPendingInvoiceItem, event event.WebhookEvent) {
+	if one == nil {
+		return
+	}
+	go func() {
+		ctx := context.Background()
+		var err error
+		defer func() {
+			if exception := recover(); exception != nil {
+				if v, ok := exception.(error); ok && gerror.HasStack(v) {
+					err = v
+				} else {
+					err = gerror.NewCodef(gcode.CodeInternalPanic, "%+v", exception)
+				}
+				log.PrintPanic(ctx, err)
+				return
+			}
+		}()
+		message.SendWebhookMessage(ctx, event, one.MerchantId, utility.FormatToGJson(bean.SimplifySubscriptionPendingInvoiceItem(one)), "", "", nil)
+	}()
+}
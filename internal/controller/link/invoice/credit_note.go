@@ -0,0 +1,62 @@
+package invoice
+
+import (
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"io"
+	"net/http"
+	"os"
+	"unibee/internal/logic/invoice/handler"
+	"unibee/internal/query"
+)
+
+func LinkCreditNotePdfEntry(r *ghttp.Request) {
+	r.Response.Header().Add("Access-Control-Allow-Headers", "Origin, X-Requested-With, Content-Type, Accept, Authorization")
+	r.Response.Header().Add("Access-Control-Allow-Methods", "GET, POST, PUT,DELETE,OPTIONS,PATCH")
+	r.Response.Header().Add("Access-Control-Allow-Origin", "*")
+	creditNoteId := r.Get("creditNoteId").String()
+	if len(creditNoteId) == 0 {
+		r.Response.Writeln("CreditNoteId not found")
+		return
+	}
+	st := r.Get("st").String()
+	download := r.Get("download").Bool()
+	one := query.GetCreditNoteByCreditNoteId(r.Context(), creditNoteId)
+	if one == nil || len(st) == 0 || st != one.LinkToken {
+		r.Response.Writeln("Invalid link")
+		return
+	}
+	pdfFileName := handler.GenerateCreditNotePdf(r.Context(), one)
+	if len(pdfFileName) == 0 {
+		g.Log().Errorf(r.Context(), "LinkCreditNotePdfEntry pdfFile generate error")
+		r.Response.WriteHeader(http.StatusBadRequest)
+		r.Response.Writeln("Bad request")
+		return
+	}
+	if download {
+		r.Response.Header().Add("Content-type", "application/octet-stream")
+		r.Response.Header().Add("content-disposition", "attachment; filename=\""+pdfFileName+"\"")
+	} else {
+		r.Response.Header().Add("Content-type", "application/pdf")
+	}
+	file, err := os.Open(pdfFileName)
+	if err != nil {
+		g.Log().Errorf(r.Context(), "LinkCreditNotePdfEntry error:%s", err.Error())
+		r.Response.WriteHeader(http.StatusBadRequest)
+		r.Response.Writeln("Bad request")
+		return
+	}
+	defer func(file *os.File) {
+		err = file.Close()
+		if err != nil {
+			g.Log().Errorf(r.Context(), "LinkCreditNotePdfEntry error:%s", err.Error())
+		}
+	}(file)
+
+	_, err = io.Copy(r.Response.ResponseWriter, file)
+	if err != nil {
+		g.Log().Errorf(r.Context(), "LinkCreditNotePdfEntry error:%s", err.Error())
+		r.Response.WriteHeader(http.StatusBadRequest)
+		r.Response.Writeln("Bad request")
+	}
+}
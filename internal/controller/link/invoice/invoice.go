@@ -8,6 +8,7 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"unibee/internal/logic/invoice/einvoice"
 	"unibee/internal/logic/invoice/handler"
 	"unibee/internal/logic/invoice/service"
 	"unibee/internal/query"
@@ -107,3 +108,36 @@ func LinkPdfEntry(r *ghttp.Request) {
 		r.Response.Writeln("Bad request")
 	}
 }
+
+func LinkXmlEntry(r *ghttp.Request) {
+	r.Response.Header().Add("Access-Control-Allow-Headers", "Origin, X-Requested-With, Content-Type, Accept, Authorization")
+	r.Response.Header().Add("Access-Control-Allow-Methods", "GET, POST, PUT,DELETE,OPTIONS,PATCH")
+	r.Response.Header().Add("Access-Control-Allow-Origin", "*")
+	invoiceId := r.Get("invoiceId").String()
+	if len(invoiceId) == 0 {
+		r.Response.Writeln("InvoiceId not found")
+		return
+	}
+
+	st := r.Get("st").String()
+	format := r.Get("format").String()
+	if !VerifyInvoiceLinkSecurityToken(r.Context(), invoiceId, st) {
+		r.Response.Writeln("Invalid link")
+		return
+	}
+	one := query.GetInvoiceByInvoiceId(r.Context(), invoiceId)
+	if one == nil {
+		r.Response.Writeln("Invoice not found")
+		return
+	}
+	content, _, err := einvoice.Generate(r.Context(), one, format)
+	if err != nil {
+		g.Log().Errorf(r.Context(), "LinkXmlEntry error:%s", err.Error())
+		r.Response.WriteHeader(http.StatusBadRequest)
+		r.Response.Writeln("Bad request")
+		return
+	}
+	r.Response.Header().Add("Content-type", "application/xml")
+	r.Response.Header().Add("content-disposition", "attachment; filename=\""+invoiceId+".xml\"")
+	r.Response.Write(content)
+}
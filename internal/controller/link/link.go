@@ -25,6 +25,20 @@ func GetInvoicePdfLink(invoiceId string, st string) string {
 	return fmt.Sprintf("%s/in/pdf/%s?st=%s&t=%d", config.GetConfigInstance().Server.GetServerPath(), invoiceId, st, gtime.Now().Timestamp())
 }
 
+func GetInvoiceXmlLink(invoiceId string, st string, format string) string {
+	if len(invoiceId) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s/in/xml/%s?st=%s&format=%s&t=%d", config.GetConfigInstance().Server.GetServerPath(), invoiceId, st, format, gtime.Now().Timestamp())
+}
+
+func GetCreditNotePdfLink(creditNoteId string, st string) string {
+	if len(creditNoteId) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s/cn/pdf/%s?st=%s&t=%d", config.GetConfigInstance().Server.GetServerPath(), creditNoteId, st, gtime.Now().Timestamp())
+}
+
 func GetPaymentLink(paymentId string) string {
 	if len(paymentId) == 0 {
 		return ""
@@ -38,3 +52,10 @@ func GetTaskDownloadUrl(task *entity.MerchantBatchTask) string {
 	}
 	return fmt.Sprintf("%s/export/%v", config.GetConfigInstance().Server.GetServerPath(), task.Id)
 }
+
+func GetSubscriptionTrialLink(trialId string, st string) string {
+	if len(trialId) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s/trial/%s?st=%s", config.GetConfigInstance().Server.GetServerPath(), trialId, st)
+}
@@ -0,0 +1,28 @@
+package subscription
+
+import (
+	"github.com/gogf/gf/v2/net/ghttp"
+	"unibee/internal/logic/subscription/trial"
+)
+
+func LinkTrialEntry(r *ghttp.Request) {
+	r.Response.Header().Add("Access-Control-Allow-Headers", "Origin, X-Requested-With, Content-Type, Accept, Authorization")
+	r.Response.Header().Add("Access-Control-Allow-Methods", "GET, POST, PUT,DELETE,OPTIONS,PATCH")
+	r.Response.Header().Add("Access-Control-Allow-Origin", "*")
+	if r.Method == "OPTIONS" {
+		return
+	}
+	trialId := r.Get("trialId").String()
+	if len(trialId) == 0 {
+		r.Response.Writeln("TrialId not found")
+		return
+	}
+	res := trial.LinkCheck(r.Context(), trialId, r.Get("st").String(), r.Get("gatewayId").Uint64())
+	if len(res.Link) > 0 {
+		r.Response.RedirectTo(res.Link)
+	} else if len(res.Message) > 0 {
+		r.Response.Writeln(res.Message)
+	} else {
+		r.Response.Writeln("Server Error")
+	}
+}
@@ -0,0 +1,46 @@
+package merchant
+
+import (
+	"context"
+	"io"
+
+	"unibee/api/bean"
+	"unibee/api/merchant/bank_statement"
+	_interface "unibee/internal/interface/context"
+	bank_statement2 "unibee/internal/logic/bank_statement"
+	"unibee/utility"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+func (c *ControllerBankStatement) Import(ctx context.Context, req *bank_statement.ImportReq) (res *bank_statement.ImportRes, err error) {
+	if req.File == nil {
+		return nil, gerror.NewCode(gcode.CodeMissingParameter, "Please Specify The File")
+	}
+	const maxFileSize = 10 * 1024 * 1024
+	utility.Assert(req.File.Size <= maxFileSize, "Statement file size exceeds 10MB limit")
+	file, err := req.File.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+	statement, lines, err := bank_statement2.Import(ctx, &bank_statement2.ImportInternalReq{
+		MerchantId: _interface.GetMerchantId(ctx),
+		FileName:   req.File.Filename,
+		Format:     req.Format,
+		Data:       data,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var list = make([]*bean.BankStatementLine, 0)
+	for _, one := range lines {
+		list = append(list, bean.SimplifyBankStatementLine(one))
+	}
+	return &bank_statement.ImportRes{Statement: bean.SimplifyBankStatement(statement), Lines: list}, nil
+}
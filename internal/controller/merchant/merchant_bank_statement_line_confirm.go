@@ -0,0 +1,19 @@
+package merchant
+
+import (
+	"context"
+
+	"unibee/api/bean"
+	"unibee/api/bean/detail"
+	"unibee/api/merchant/bank_statement"
+	_interface "unibee/internal/interface/context"
+	bank_statement2 "unibee/internal/logic/bank_statement"
+)
+
+func (c *ControllerBankStatement) LineConfirm(ctx context.Context, req *bank_statement.LineConfirmReq) (res *bank_statement.LineConfirmRes, err error) {
+	line, one, err := bank_statement2.ConfirmLine(ctx, _interface.GetMerchantId(ctx), req.LineId, req.InvoiceId)
+	if err != nil {
+		return nil, err
+	}
+	return &bank_statement.LineConfirmRes{Line: bean.SimplifyBankStatementLine(line), Invoice: detail.ConvertInvoiceToDetail(ctx, one)}, nil
+}
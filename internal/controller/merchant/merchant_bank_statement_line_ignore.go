@@ -0,0 +1,18 @@
+package merchant
+
+import (
+	"context"
+
+	"unibee/api/bean"
+	"unibee/api/merchant/bank_statement"
+	_interface "unibee/internal/interface/context"
+	bank_statement2 "unibee/internal/logic/bank_statement"
+)
+
+func (c *ControllerBankStatement) LineIgnore(ctx context.Context, req *bank_statement.LineIgnoreReq) (res *bank_statement.LineIgnoreRes, err error) {
+	line, err := bank_statement2.IgnoreLine(ctx, _interface.GetMerchantId(ctx), req.LineId, req.Note)
+	if err != nil {
+		return nil, err
+	}
+	return &bank_statement.LineIgnoreRes{Line: bean.SimplifyBankStatementLine(line)}, nil
+}
@@ -0,0 +1,23 @@
+package merchant
+
+import (
+	"context"
+
+	"unibee/api/merchant/bank_statement"
+	_interface "unibee/internal/interface/context"
+	bank_statement2 "unibee/internal/logic/bank_statement"
+)
+
+func (c *ControllerBankStatement) LineList(ctx context.Context, req *bank_statement.LineListReq) (res *bank_statement.LineListRes, err error) {
+	list, total, err := bank_statement2.LineList(ctx, &bank_statement2.LineListInternalReq{
+		MerchantId:  _interface.GetMerchantId(ctx),
+		StatementId: req.StatementId,
+		Status:      req.Status,
+		Page:        req.Page,
+		Count:       req.Count,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &bank_statement.LineListRes{Lines: list, Total: total}, nil
+}
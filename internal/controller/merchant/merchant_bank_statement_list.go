@@ -0,0 +1,17 @@
+package merchant
+
+import (
+	"context"
+
+	"unibee/api/merchant/bank_statement"
+	_interface "unibee/internal/interface/context"
+	bank_statement2 "unibee/internal/logic/bank_statement"
+)
+
+func (c *ControllerBankStatement) List(ctx context.Context, req *bank_statement.ListReq) (res *bank_statement.ListRes, err error) {
+	list, total, err := bank_statement2.List(ctx, _interface.GetMerchantId(ctx), req.Page, req.Count)
+	if err != nil {
+		return nil, err
+	}
+	return &bank_statement.ListRes{Statements: list, Total: total}, nil
+}
@@ -0,0 +1,28 @@
+package merchant
+
+import (
+	"context"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/gateway/reconciliation"
+	"unibee/internal/logic/merchant_config/update"
+	"unibee/utility"
+
+	"unibee/api/merchant/gateway"
+)
+
+func (c *ControllerGateway) SetupReconciliation(ctx context.Context, req *gateway.SetupReconciliationReq) (res *gateway.SetupReconciliationRes, err error) {
+	config := reconciliation.GetMerchantReconciliationConfig(ctx, _interface.GetMerchantId(ctx))
+	if req.Enable != nil || req.AutoHeal != nil {
+		if req.Enable != nil {
+			config.Enable = *req.Enable
+		}
+		if req.AutoHeal != nil {
+			config.AutoHeal = *req.AutoHeal
+		}
+		err = update.SetMerchantConfig(ctx, _interface.GetMerchantId(ctx), reconciliation.MerchantGatewayReconciliationConfig, utility.MarshalToJsonString(config))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &gateway.SetupReconciliationRes{Enable: config.Enable, AutoHeal: config.AutoHeal}, nil
+}
@@ -0,0 +1,24 @@
+package merchant
+
+import (
+	"context"
+	"unibee/api/bean"
+	"unibee/internal/controller/link"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/query"
+	"unibee/utility"
+
+	"unibee/api/merchant/invoice"
+)
+
+func (c *ControllerInvoice) CreditNoteDetail(ctx context.Context, req *invoice.CreditNoteDetailReq) (res *invoice.CreditNoteDetailRes, err error) {
+	one := query.GetCreditNoteByCreditNoteId(ctx, req.CreditNoteId)
+	utility.Assert(one != nil, "credit note not found")
+	utility.Assert(one.MerchantId == _interface.GetMerchantId(ctx), "wrong merchant account")
+	return &invoice.CreditNoteDetailRes{
+		CreditNote:      bean.SimplifyCreditNote(one),
+		OriginalInvoice: bean.SimplifyInvoice(query.GetInvoiceByInvoiceId(ctx, one.InvoiceId)),
+		Refund:          bean.SimplifyRefund(query.GetRefundByRefundId(ctx, one.RefundId)),
+		PdfLink:         link.GetCreditNotePdfLink(one.CreditNoteId, one.LinkToken),
+	}, nil
+}
@@ -0,0 +1,22 @@
+package merchant
+
+import (
+	"context"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/invoice/credit_note"
+
+	"unibee/api/merchant/invoice"
+)
+
+func (c *ControllerInvoice) CreditNoteDocumentList(ctx context.Context, req *invoice.CreditNoteDocumentListReq) (res *invoice.CreditNoteDocumentListRes, err error) {
+	list, total := credit_note.List(ctx, &credit_note.ListInternalReq{
+		MerchantId: _interface.GetMerchantId(ctx),
+		InvoiceId:  req.InvoiceId,
+		UserId:     req.UserId,
+		Type:       req.Type,
+		Status:     req.Status,
+		Page:       req.Page,
+		Count:      req.Count,
+	})
+	return &invoice.CreditNoteDocumentListRes{CreditNotes: list, Total: total}, nil
+}
@@ -0,0 +1,36 @@
+package merchant
+
+import (
+	"context"
+	"fmt"
+	"unibee/api/bean"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/invoice/credit_note"
+	"unibee/internal/logic/operation_log"
+
+	"unibee/api/merchant/invoice"
+)
+
+func (c *ControllerInvoice) CreditNoteNew(ctx context.Context, req *invoice.CreditNoteNewReq) (res *invoice.CreditNoteNewRes, err error) {
+	one, err := credit_note.CreateCreditNote(ctx, &credit_note.CreateInternalReq{
+		MerchantId: _interface.GetMerchantId(ctx),
+		InvoiceId:  req.InvoiceId,
+		Type:       req.Type,
+		Lines:      req.Lines,
+		Reason:     req.Reason,
+	})
+	var target = fmt.Sprintf("Invoice(%s)", req.InvoiceId)
+	if one != nil {
+		target = fmt.Sprintf("CreditNote(%s)", one.CreditNoteId)
+	}
+	operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
+		MerchantId: _interface.GetMerchantId(ctx),
+		Target:     target,
+		Content:    "New",
+		InvoiceId:  req.InvoiceId,
+	}, err)
+	if err != nil {
+		return nil, err
+	}
+	return &invoice.CreditNoteNewRes{CreditNote: bean.SimplifyCreditNote(one)}, nil
+}
@@ -0,0 +1,22 @@
+package merchant
+
+import (
+	"context"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/invoice/credit_note"
+
+	"unibee/api/merchant/invoice"
+)
+
+func (c *ControllerInvoice) CreditNotePreview(ctx context.Context, req *invoice.CreditNotePreviewReq) (res *invoice.CreditNotePreviewRes, err error) {
+	preview, err := credit_note.PreviewCreditNote(ctx, &credit_note.CreateInternalReq{
+		MerchantId: _interface.GetMerchantId(ctx),
+		InvoiceId:  req.InvoiceId,
+		Type:       req.Type,
+		Lines:      req.Lines,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &invoice.CreditNotePreviewRes{Preview: preview}, nil
+}
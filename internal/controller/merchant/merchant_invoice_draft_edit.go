@@ -0,0 +1,25 @@
+package merchant
+
+import (
+	"context"
+
+	"unibee/api/bean/detail"
+	"unibee/api/merchant/invoice"
+	"unibee/internal/consts"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/invoice/draft"
+	"unibee/internal/logic/member/permission"
+)
+
+func (c *ControllerInvoice) DraftEdit(ctx context.Context, req *invoice.DraftEditReq) (res *invoice.DraftEditRes, err error) {
+	permission.AssertContextMemberPermission(ctx, consts.PermissionGroupInvoice, consts.PermissionReview)
+	one, err := draft.EditDraftInvoice(ctx, _interface.GetMerchantId(ctx), req.InvoiceId, &draft.EditReq{
+		Lines:        req.Lines,
+		CreditAmount: req.CreditAmount,
+		CreditReason: req.CreditReason,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &invoice.DraftEditRes{Invoice: detail.ConvertInvoiceToDetail(ctx, one)}, nil
+}
@@ -0,0 +1,21 @@
+package merchant
+
+import (
+	"context"
+
+	"unibee/api/bean/detail"
+	"unibee/api/merchant/invoice"
+	"unibee/internal/consts"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/invoice/draft"
+	"unibee/internal/logic/member/permission"
+)
+
+func (c *ControllerInvoice) DraftFinalize(ctx context.Context, req *invoice.DraftFinalizeReq) (res *invoice.DraftFinalizeRes, err error) {
+	permission.AssertContextMemberPermission(ctx, consts.PermissionGroupInvoice, consts.PermissionReview)
+	one, err := draft.FinalizeDraftInvoice(ctx, _interface.GetMerchantId(ctx), req.InvoiceId, "Merchant")
+	if err != nil {
+		return nil, err
+	}
+	return &invoice.DraftFinalizeRes{Invoice: detail.ConvertInvoiceToDetail(ctx, one)}, nil
+}
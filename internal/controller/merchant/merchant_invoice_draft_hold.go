@@ -0,0 +1,21 @@
+package merchant
+
+import (
+	"context"
+
+	"unibee/api/bean/detail"
+	"unibee/api/merchant/invoice"
+	"unibee/internal/consts"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/invoice/draft"
+	"unibee/internal/logic/member/permission"
+)
+
+func (c *ControllerInvoice) DraftHold(ctx context.Context, req *invoice.DraftHoldReq) (res *invoice.DraftHoldRes, err error) {
+	permission.AssertContextMemberPermission(ctx, consts.PermissionGroupInvoice, consts.PermissionReview)
+	one, err := draft.HoldDraftInvoice(ctx, _interface.GetMerchantId(ctx), req.InvoiceId, req.Hold)
+	if err != nil {
+		return nil, err
+	}
+	return &invoice.DraftHoldRes{Invoice: detail.ConvertInvoiceToDetail(ctx, one)}, nil
+}
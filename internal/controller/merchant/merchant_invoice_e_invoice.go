@@ -0,0 +1,32 @@
+package merchant
+
+import (
+	"context"
+	"unibee/internal/controller/link"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/invoice/einvoice"
+	"unibee/internal/query"
+	"unibee/utility"
+
+	"unibee/api/merchant/invoice"
+)
+
+func (c *ControllerInvoice) EInvoice(ctx context.Context, req *invoice.EInvoiceReq) (res *invoice.EInvoiceRes, err error) {
+	utility.Assert(len(req.InvoiceId) > 0, "InvoiceId Invalid")
+	one := query.GetInvoiceByInvoiceId(ctx, req.InvoiceId)
+	utility.Assert(one != nil, "invoice not found")
+	utility.Assert(one.MerchantId == _interface.GetMerchantId(ctx), "wrong merchant account")
+	if len(req.Format) == 0 {
+		req.Format = einvoice.FormatUBL
+	}
+	content, validations, err := einvoice.Generate(ctx, one, req.Format)
+	if err != nil {
+		return nil, err
+	}
+	return &invoice.EInvoiceRes{
+		Xml:          string(content),
+		Validations:  validations,
+		Valid:        !einvoice.HasFatal(validations),
+		DownloadLink: link.GetInvoiceXmlLink(one.InvoiceId, one.SendTerms, req.Format),
+	}, nil
+}
@@ -0,0 +1,13 @@
+package merchant
+
+import (
+	"context"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/invoice/einvoice"
+
+	"unibee/api/merchant/invoice"
+)
+
+func (c *ControllerInvoice) EInvoiceConfig(ctx context.Context, req *invoice.EInvoiceConfigReq) (res *invoice.EInvoiceConfigRes, err error) {
+	return &invoice.EInvoiceConfigRes{Config: einvoice.GetMerchantEInvoiceConfig(ctx, _interface.GetMerchantId(ctx))}, nil
+}
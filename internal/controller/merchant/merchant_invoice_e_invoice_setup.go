@@ -0,0 +1,36 @@
+package merchant
+
+import (
+	"context"
+	"fmt"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/invoice/einvoice"
+	"unibee/internal/logic/operation_log"
+	"unibee/utility"
+
+	"unibee/api/merchant/invoice"
+)
+
+func (c *ControllerInvoice) EInvoiceSetup(ctx context.Context, req *invoice.EInvoiceSetupReq) (res *invoice.EInvoiceSetupRes, err error) {
+	merchantId := _interface.GetMerchantId(ctx)
+	config := einvoice.GetMerchantEInvoiceConfig(ctx, merchantId)
+	if req.EmbedFacturX != nil {
+		config.EmbedFacturX = *req.EmbedFacturX
+	}
+	if req.EndpointScheme != nil {
+		config.EndpointScheme = *req.EndpointScheme
+	}
+	if req.EndpointId != nil {
+		config.EndpointId = *req.EndpointId
+	}
+	err = einvoice.SetupMerchantEInvoiceConfig(ctx, merchantId, config)
+	operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
+		MerchantId: merchantId,
+		Target:     fmt.Sprintf("EInvoice(%v)", config.EmbedFacturX),
+		Content:    fmt.Sprintf("Setup(%s)", utility.MarshalToJsonString(config)),
+	}, err)
+	if err != nil {
+		return nil, err
+	}
+	return &invoice.EInvoiceSetupRes{Config: config}, nil
+}
@@ -0,0 +1,13 @@
+package merchant
+
+import (
+	"context"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/invoice/invoice_number"
+
+	"unibee/api/merchant/invoice"
+)
+
+func (c *ControllerInvoice) NumberingConfig(ctx context.Context, req *invoice.NumberingConfigReq) (res *invoice.NumberingConfigRes, err error) {
+	return &invoice.NumberingConfigRes{Config: invoice_number.GetMerchantInvoiceNumberingConfig(ctx, _interface.GetMerchantId(ctx))}, nil
+}
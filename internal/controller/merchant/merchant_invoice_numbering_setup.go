@@ -0,0 +1,42 @@
+package merchant
+
+import (
+	"context"
+	"fmt"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/invoice/invoice_number"
+	"unibee/internal/logic/operation_log"
+	"unibee/utility"
+
+	"unibee/api/merchant/invoice"
+)
+
+func (c *ControllerInvoice) NumberingSetup(ctx context.Context, req *invoice.NumberingSetupReq) (res *invoice.NumberingSetupRes, err error) {
+	merchantId := _interface.GetMerchantId(ctx)
+	config := invoice_number.GetMerchantInvoiceNumberingConfig(ctx, merchantId)
+	if req.Enable != nil {
+		config.Enable = *req.Enable
+	}
+	if req.Pattern != nil {
+		config.Pattern = *req.Pattern
+	}
+	if req.Prefix != nil {
+		config.Prefix = *req.Prefix
+	}
+	if req.CreditNotePrefix != nil {
+		config.CreditNotePrefix = *req.CreditNotePrefix
+	}
+	if req.Padding != nil {
+		config.Padding = *req.Padding
+	}
+	err = invoice_number.SetupMerchantInvoiceNumberingConfig(ctx, merchantId, config)
+	operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
+		MerchantId: merchantId,
+		Target:     fmt.Sprintf("InvoiceNumbering(%v)", config.Enable),
+		Content:    fmt.Sprintf("Setup(%s)", utility.MarshalToJsonString(config)),
+	}, err)
+	if err != nil {
+		return nil, err
+	}
+	return &invoice.NumberingSetupRes{Config: config}, nil
+}
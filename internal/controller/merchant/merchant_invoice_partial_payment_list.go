@@ -0,0 +1,13 @@
+package merchant
+
+import (
+	"context"
+
+	"unibee/api/merchant/invoice"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/invoice/receivable"
+)
+
+func (c *ControllerInvoice) PartialPaymentList(ctx context.Context, req *invoice.PartialPaymentListReq) (res *invoice.PartialPaymentListRes, err error) {
+	return &invoice.PartialPaymentListRes{PartialPayments: receivable.GetPartialPaymentList(ctx, _interface.GetMerchantId(ctx), req.InvoiceId)}, nil
+}
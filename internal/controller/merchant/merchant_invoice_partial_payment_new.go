@@ -0,0 +1,30 @@
+package merchant
+
+import (
+	"context"
+
+	"unibee/api/bean"
+	"unibee/api/bean/detail"
+	"unibee/api/merchant/invoice"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/invoice/receivable"
+)
+
+func (c *ControllerInvoice) PartialPaymentNew(ctx context.Context, req *invoice.PartialPaymentNewReq) (res *invoice.PartialPaymentNewRes, err error) {
+	partialPayment, one, err := receivable.ApplyPartialPayment(ctx, &receivable.PartialPaymentInternalReq{
+		MerchantId:     _interface.GetMerchantId(ctx),
+		InvoiceId:      req.InvoiceId,
+		Amount:         req.Amount,
+		Currency:       req.Currency,
+		TransferNumber: req.TransferNumber,
+		Reason:         req.Reason,
+		PaidTime:       req.PaidTime,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &invoice.PartialPaymentNewRes{
+		Invoice:        detail.ConvertInvoiceToDetail(ctx, one),
+		PartialPayment: bean.SimplifyInvoicePartialPayment(partialPayment),
+	}, nil
+}
@@ -0,0 +1,18 @@
+package merchant
+
+import (
+	"context"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/invoice/handler"
+	"unibee/internal/logic/invoice/handler/generator"
+
+	"unibee/api/merchant/invoice"
+)
+
+func (c *ControllerInvoice) PdfTemplate(ctx context.Context, req *invoice.PdfTemplateReq) (res *invoice.PdfTemplateRes, err error) {
+	return &invoice.PdfTemplateRes{
+		Template: handler.GetMerchantInvoicePdfTemplate(ctx, _interface.GetMerchantId(ctx)),
+		Locales:  generator.SupportedLocales(),
+		Labels:   generator.TextKeyList(),
+	}, nil
+}
@@ -0,0 +1,22 @@
+package merchant
+
+import (
+	"context"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/invoice/handler"
+
+	"unibee/api/merchant/invoice"
+)
+
+func (c *ControllerInvoice) PdfTemplatePreview(ctx context.Context, req *invoice.PdfTemplatePreviewReq) (res *invoice.PdfTemplatePreviewRes, err error) {
+	merchantId := _interface.GetMerchantId(ctx)
+	template := req.Template
+	if template == nil {
+		template = handler.GetMerchantInvoicePdfTemplate(ctx, merchantId)
+	}
+	url, err := handler.GenerateInvoicePdfPreview(ctx, merchantId, template)
+	if err != nil {
+		return nil, err
+	}
+	return &invoice.PdfTemplatePreviewRes{Url: url}, nil
+}
@@ -0,0 +1,26 @@
+package merchant
+
+import (
+	"context"
+	"fmt"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/invoice/handler"
+	"unibee/internal/logic/operation_log"
+	"unibee/utility"
+
+	"unibee/api/merchant/invoice"
+)
+
+func (c *ControllerInvoice) PdfTemplateSetup(ctx context.Context, req *invoice.PdfTemplateSetupReq) (res *invoice.PdfTemplateSetupRes, err error) {
+	merchantId := _interface.GetMerchantId(ctx)
+	err = handler.SetupMerchantInvoicePdfTemplate(ctx, merchantId, req.Template)
+	operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
+		MerchantId: merchantId,
+		Target:     "InvoicePdfTemplate",
+		Content:    fmt.Sprintf("Setup(%s)", utility.MarshalToJsonString(req.Template)),
+	}, err)
+	if err != nil {
+		return nil, err
+	}
+	return &invoice.PdfTemplateSetupRes{Template: req.Template}, nil
+}
@@ -0,0 +1,17 @@
+package merchant
+
+import (
+	"context"
+
+	"unibee/api/merchant/invoice"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/invoice/receivable"
+)
+
+func (c *ControllerInvoice) ReceivableAgeing(ctx context.Context, req *invoice.ReceivableAgeingReq) (res *invoice.ReceivableAgeingRes, err error) {
+	list, err := receivable.GetAgeingReport(ctx, _interface.GetMerchantId(ctx), req.Currency, req.AsOfTime)
+	if err != nil {
+		return nil, err
+	}
+	return &invoice.ReceivableAgeingRes{Ageings: list}, nil
+}
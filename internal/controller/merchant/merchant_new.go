@@ -104,6 +104,18 @@ func NewProfile() merchant.IMerchantProfile {
 	return &ControllerProfile{}
 }
 
+type ControllerBankStatement struct{}
+
+func NewBankStatement() merchant.IMerchantBankStatement {
+	return &ControllerBankStatement{}
+}
+
+type ControllerSettlement struct{}
+
+func NewSettlement() merchant.IMerchantSettlement {
+	return &ControllerSettlement{}
+}
+
 type ControllerRole struct{}
 
 func NewRole() merchant.IMerchantRole {
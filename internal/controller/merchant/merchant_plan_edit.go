@@ -36,8 +36,13 @@ func (c *ControllerPlan) Edit(ctx context.Context, req *plan.EditReq) (res *plan
 		TrialDurationTime:     req.TrialDurationTime,
 		TrialDemand:           req.TrialDemand,
 		CancelAtTrialEnd:      req.CancelAtTrialEnd,
+		TrialEndBehavior:      req.TrialEndBehavior,
 		ProductId:             req.ProductId,
 		MultiCurrencies:       req.MultiCurrencies,
+		TaxCategory:           req.TaxCategory,
+		InvoiceReviewHours:    req.InvoiceReviewHours,
+		MinSeats:              req.MinSeats,
+		MaxSeats:              req.MaxSeats,
 	})
 	if err != nil {
 		return nil, err
@@ -36,8 +36,13 @@ func (c *ControllerPlan) New(ctx context.Context, req *plan.NewReq) (res *plan.N
 		TrialAmount:           req.TrialAmount,
 		TrialDurationTime:     req.TrialDurationTime,
 		CancelAtTrialEnd:      req.CancelAtTrialEnd,
+		TrialEndBehavior:      req.TrialEndBehavior,
 		ProductId:             req.ProductId,
 		MultiCurrencies:       req.MultiCurrencies,
+		TaxCategory:           req.TaxCategory,
+		InvoiceReviewHours:    req.InvoiceReviewHours,
+		MinSeats:              req.MinSeats,
+		MaxSeats:              req.MaxSeats,
 	})
 	if err != nil {
 		return nil, err
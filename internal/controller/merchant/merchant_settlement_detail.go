@@ -0,0 +1,21 @@
+package merchant
+
+import (
+	"context"
+	"unibee/api/bean"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/query"
+	"unibee/utility"
+
+	"unibee/api/merchant/settlement"
+)
+
+func (c *ControllerSettlement) Detail(ctx context.Context, req *settlement.DetailReq) (res *settlement.DetailRes, err error) {
+	one := query.GetSettlementById(ctx, req.Id)
+	utility.Assert(one != nil && one.MerchantId == _interface.GetMerchantId(ctx), "Settlement not found")
+	var items = make([]*bean.GatewaySettlementItem, 0)
+	for _, item := range query.GetSettlementItemList(ctx, one.Id) {
+		items = append(items, bean.SimplifyGatewaySettlementItem(item))
+	}
+	return &settlement.DetailRes{Settlement: bean.SimplifyGatewaySettlement(one), Items: items}, nil
+}
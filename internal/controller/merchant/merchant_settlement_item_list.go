@@ -0,0 +1,25 @@
+package merchant
+
+import (
+	"context"
+	_interface "unibee/internal/interface/context"
+	settlement2 "unibee/internal/logic/settlement"
+
+	"unibee/api/merchant/settlement"
+)
+
+func (c *ControllerSettlement) ItemList(ctx context.Context, req *settlement.ItemListReq) (res *settlement.ItemListRes, err error) {
+	list, total, err := settlement2.ItemList(ctx, &settlement2.ItemListInternalReq{
+		MerchantId:   _interface.GetMerchantId(ctx),
+		SettlementId: req.SettlementId,
+		PaymentId:    req.PaymentId,
+		InvoiceId:    req.InvoiceId,
+		Type:         req.Type,
+		Page:         req.Page,
+		Count:        req.Count,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &settlement.ItemListRes{Items: list, Total: total}, nil
+}
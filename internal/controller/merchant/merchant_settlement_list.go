@@ -0,0 +1,26 @@
+package merchant
+
+import (
+	"context"
+	_interface "unibee/internal/interface/context"
+	settlement2 "unibee/internal/logic/settlement"
+
+	"unibee/api/merchant/settlement"
+)
+
+func (c *ControllerSettlement) List(ctx context.Context, req *settlement.ListReq) (res *settlement.ListRes, err error) {
+	list, total, err := settlement2.List(ctx, &settlement2.ListInternalReq{
+		MerchantId:       _interface.GetMerchantId(ctx),
+		GatewayId:        req.GatewayId,
+		Currency:         req.Currency,
+		Status:           req.Status,
+		ArrivalTimeStart: req.ArrivalTimeStart,
+		ArrivalTimeEnd:   req.ArrivalTimeEnd,
+		Page:             req.Page,
+		Count:            req.Count,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &settlement.ListRes{Settlements: list, Total: total}, nil
+}
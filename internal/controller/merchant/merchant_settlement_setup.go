@@ -0,0 +1,23 @@
+package merchant
+
+import (
+	"context"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/merchant_config/update"
+	settlement2 "unibee/internal/logic/settlement"
+	"unibee/utility"
+
+	"unibee/api/merchant/settlement"
+)
+
+func (c *ControllerSettlement) Setup(ctx context.Context, req *settlement.SetupReq) (res *settlement.SetupRes, err error) {
+	config := settlement2.GetMerchantSettlementConfig(ctx, _interface.GetMerchantId(ctx))
+	if req.Enable != nil {
+		config.Enable = *req.Enable
+		err = update.SetMerchantConfig(ctx, _interface.GetMerchantId(ctx), settlement2.MerchantGatewaySettlementConfig, utility.MarshalToJsonString(config))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &settlement.SetupRes{Enable: config.Enable}, nil
+}
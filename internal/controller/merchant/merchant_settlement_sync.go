@@ -0,0 +1,33 @@
+package merchant
+
+import (
+	"context"
+	"fmt"
+	"unibee/api/bean"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/operation_log"
+	settlement2 "unibee/internal/logic/settlement"
+	"unibee/internal/query"
+	"unibee/utility"
+
+	"unibee/api/merchant/settlement"
+)
+
+func (c *ControllerSettlement) Sync(ctx context.Context, req *settlement.SyncReq) (res *settlement.SyncRes, err error) {
+	gateway := query.GetGatewayById(ctx, req.GatewayId)
+	utility.Assert(gateway != nil && gateway.MerchantId == _interface.GetMerchantId(ctx), "Gateway not found")
+	list, err := settlement2.Sync(ctx, gateway, req.ArrivalTimeStart, req.ArrivalTimeEnd)
+	operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
+		MerchantId: gateway.MerchantId,
+		Target:     fmt.Sprintf("Gateway(%v)", gateway.Id),
+		Content:    fmt.Sprintf("SettlementSync(%d-%d)", req.ArrivalTimeStart, req.ArrivalTimeEnd),
+	}, err)
+	if err != nil {
+		return nil, err
+	}
+	var settlements = make([]*bean.GatewaySettlement, 0)
+	for _, one := range list {
+		settlements = append(settlements, bean.SimplifyGatewaySettlement(one))
+	}
+	return &settlement.SyncRes{Settlements: settlements}, nil
+}
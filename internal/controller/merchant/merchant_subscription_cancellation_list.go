@@ -0,0 +1,21 @@
+package merchant
+
+import (
+	"context"
+	"unibee/api/bean"
+	"unibee/api/merchant/subscription"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/subscription/cancellation"
+)
+
+func (c *ControllerSubscription) CancellationList(ctx context.Context, req *subscription.CancellationListReq) (res *subscription.CancellationListRes, err error) {
+	list, total, err := cancellation.GetCancellationList(ctx, _interface.GetMerchantId(ctx), req.SubscriptionId, req.UserId, req.Status, req.Page, req.Count)
+	if err != nil {
+		return nil, err
+	}
+	var cancellations = make([]*bean.SubscriptionCancellation, 0)
+	for _, one := range list {
+		cancellations = append(cancellations, bean.SimplifySubscriptionCancellation(one))
+	}
+	return &subscription.CancellationListRes{Cancellations: cancellations, Total: total}, nil
+}
@@ -0,0 +1,18 @@
+package merchant
+
+import (
+	"context"
+	"unibee/api/merchant/subscription"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/subscription/cancellation"
+	"unibee/utility"
+)
+
+func (c *ControllerSubscription) CancellationReport(ctx context.Context, req *subscription.CancellationReportReq) (res *subscription.CancellationReportRes, err error) {
+	utility.Assert(req.EndTime == 0 || req.EndTime >= req.StartTime, "endTime should not be earlier than startTime")
+	reports, err := cancellation.CancellationReport(ctx, _interface.GetMerchantId(ctx), req.StartTime, req.EndTime)
+	if err != nil {
+		return nil, err
+	}
+	return &subscription.CancellationReportRes{Reports: reports}, nil
+}
@@ -0,0 +1,26 @@
+package merchant
+
+import (
+	"context"
+	"unibee/api/bean"
+	"unibee/api/merchant/subscription"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/subscription/commitment"
+)
+
+func (c *ControllerSubscription) CommitmentCreate(ctx context.Context, req *subscription.CommitmentCreateReq) (res *subscription.CommitmentCreateRes, err error) {
+	one, err := commitment.CommitmentCreate(ctx, &commitment.CreateInternalReq{
+		MerchantId:                    _interface.GetMerchantId(ctx),
+		SubscriptionId:                req.SubscriptionId,
+		TermMonths:                    req.TermMonths,
+		AutoRenew:                     req.AutoRenew,
+		RenewTermMonths:               req.RenewTermMonths,
+		NoticeDays:                    req.NoticeDays,
+		EarlyTerminationFeePercentage: req.EarlyTerminationFeePercentage,
+		TermStart:                     req.TermStart,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &subscription.CommitmentCreateRes{Commitment: bean.SimplifySubscriptionCommitment(one)}, nil
+}
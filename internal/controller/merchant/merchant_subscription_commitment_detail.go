@@ -0,0 +1,21 @@
+package merchant
+
+import (
+	"context"
+	"unibee/api/bean"
+	"unibee/api/merchant/subscription"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/subscription/commitment"
+	"unibee/internal/query"
+	"unibee/utility"
+)
+
+func (c *ControllerSubscription) CommitmentDetail(ctx context.Context, req *subscription.CommitmentDetailReq) (res *subscription.CommitmentDetailRes, err error) {
+	one := query.GetSubscriptionCommitmentByCommitmentId(ctx, req.CommitmentId)
+	utility.Assert(one != nil, "commitment not found")
+	utility.Assert(one.MerchantId == _interface.GetMerchantId(ctx), "merchant not match")
+	return &subscription.CommitmentDetailRes{
+		Commitment:         bean.SimplifySubscriptionCommitment(one),
+		TerminationPreview: commitment.TerminationPreviewForCommitment(ctx, one, query.GetSubscriptionBySubscriptionId(ctx, one.SubscriptionId)),
+	}, nil
+}
@@ -0,0 +1,21 @@
+package merchant
+
+import (
+	"context"
+	"unibee/api/bean"
+	"unibee/api/merchant/subscription"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/subscription/commitment"
+)
+
+func (c *ControllerSubscription) CommitmentList(ctx context.Context, req *subscription.CommitmentListReq) (res *subscription.CommitmentListRes, err error) {
+	list, total, err := commitment.GetCommitmentList(ctx, _interface.GetMerchantId(ctx), req.SubscriptionId, req.UserId, req.Status, req.Page, req.Count)
+	if err != nil {
+		return nil, err
+	}
+	var commitments = make([]*bean.SubscriptionCommitment, 0)
+	for _, one := range list {
+		commitments = append(commitments, bean.SimplifySubscriptionCommitment(one))
+	}
+	return &subscription.CommitmentListRes{Commitments: commitments, Total: total}, nil
+}
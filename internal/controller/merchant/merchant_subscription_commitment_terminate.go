@@ -0,0 +1,22 @@
+package merchant
+
+import (
+	"context"
+	"unibee/api/bean"
+	"unibee/api/merchant/subscription"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/subscription/commitment"
+)
+
+func (c *ControllerSubscription) CommitmentTerminate(ctx context.Context, req *subscription.CommitmentTerminateReq) (res *subscription.CommitmentTerminateRes, err error) {
+	one, err := commitment.CommitmentTerminate(ctx, &commitment.TerminateInternalReq{
+		MerchantId:   _interface.GetMerchantId(ctx),
+		CommitmentId: req.CommitmentId,
+		WaiveFee:     req.WaiveFee,
+		Reason:       req.Reason,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &subscription.CommitmentTerminateRes{Commitment: bean.SimplifySubscriptionCommitment(one)}, nil
+}
@@ -0,0 +1,24 @@
+package merchant
+
+import (
+	"context"
+	"unibee/api/bean"
+	"unibee/api/merchant/subscription"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/subscription/commitment"
+)
+
+func (c *ControllerSubscription) CommitmentUpdate(ctx context.Context, req *subscription.CommitmentUpdateReq) (res *subscription.CommitmentUpdateRes, err error) {
+	one, err := commitment.CommitmentUpdate(ctx, &commitment.UpdateInternalReq{
+		MerchantId:                    _interface.GetMerchantId(ctx),
+		CommitmentId:                  req.CommitmentId,
+		AutoRenew:                     req.AutoRenew,
+		RenewTermMonths:               req.RenewTermMonths,
+		NoticeDays:                    req.NoticeDays,
+		EarlyTerminationFeePercentage: req.EarlyTerminationFeePercentage,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &subscription.CommitmentUpdateRes{Commitment: bean.SimplifySubscriptionCommitment(one)}, nil
+}
@@ -3,9 +3,12 @@ package merchant
 import (
 	"context"
 	"fmt"
+	"strings"
 	_interface "unibee/internal/interface/context"
 	"unibee/internal/logic/merchant_config/update"
 	"unibee/internal/logic/subscription/config"
+	"unibee/internal/logic/subscription/pause"
+	"unibee/internal/logic/subscription/seat"
 	"unibee/utility"
 
 	"unibee/api/merchant/subscription"
@@ -56,6 +59,55 @@ func (c *ControllerSubscription) ConfigUpdate(ctx context.Context, req *subscrip
 			return nil, err
 		}
 	}
+	if req.PauseMode != nil {
+		utility.Assert(pause.IsValidPauseMode(*req.PauseMode), "Value should be one of keep_as_draft|void|mark_uncollectible")
+		err = update.SetMerchantConfig(ctx, _interface.GetMerchantId(ctx), config.PauseMode, *req.PauseMode)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if req.UserPauseEnable != nil {
+		err = update.SetMerchantConfig(ctx, _interface.GetMerchantId(ctx), config.UserPauseEnable, fmt.Sprintf("%v", *req.UserPauseEnable))
+		if err != nil {
+			return nil, err
+		}
+	}
+	if req.SeatProrationMode != nil {
+		utility.Assert(seat.IsValidSeatProrationMode(*req.SeatProrationMode), "Value should be one of immediate|true_up")
+		err = update.SetMerchantConfig(ctx, _interface.GetMerchantId(ctx), config.SeatProrationMode, *req.SeatProrationMode)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(req.CancellationReasons) > 0 {
+		var reasons = make([]string, 0)
+		var exist = make(map[string]bool)
+		for _, reason := range req.CancellationReasons {
+			reason = strings.TrimSpace(reason)
+			utility.Assert(len(reason) > 0, "cancellation reason should not be blank")
+			utility.Assert(!exist[reason], fmt.Sprintf("duplicate cancellation reason:%s", reason))
+			exist[reason] = true
+			reasons = append(reasons, reason)
+		}
+		err = update.SetMerchantConfig(ctx, _interface.GetMerchantId(ctx), config.CancellationReasons, utility.MarshalToJsonString(reasons))
+		if err != nil {
+			return nil, err
+		}
+	}
+	if req.TrialReminderDays != nil {
+		utility.Assert(*req.TrialReminderDays >= 0 && *req.TrialReminderDays <= 30, "Value should between 0 and 30")
+		err = update.SetMerchantConfig(ctx, _interface.GetMerchantId(ctx), config.TrialReminderDays, fmt.Sprintf("%v", *req.TrialReminderDays))
+		if err != nil {
+			return nil, err
+		}
+	}
+	if req.CommitmentReminderDays != nil {
+		utility.Assert(*req.CommitmentReminderDays >= 0 && *req.CommitmentReminderDays <= 90, "Value should between 0 and 90")
+		err = update.SetMerchantConfig(ctx, _interface.GetMerchantId(ctx), config.CommitmentReminderDays, fmt.Sprintf("%v", *req.CommitmentReminderDays))
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	return &subscription.ConfigUpdateRes{Config: config.GetMerchantSubscriptionConfig(ctx, _interface.GetMerchantId(ctx))}, nil
 }
@@ -38,6 +38,7 @@ func (c *ControllerSubscription) Create(ctx context.Context, req *subscription.C
 				dao.UserAccount.Columns().City:               req.User.City,
 				dao.UserAccount.Columns().Type:               req.User.Type,
 				dao.UserAccount.Columns().ZipCode:            req.User.ZipCode,
+				dao.UserAccount.Columns().RegionCode:         req.User.RegionCode,
 				dao.UserAccount.Columns().Language:           req.User.Language,
 				dao.UserAccount.Columns().Address:            req.User.Address,
 				dao.UserAccount.Columns().CompanyName:        req.User.CompanyName,
@@ -61,6 +62,7 @@ func (c *ControllerSubscription) Create(ctx context.Context, req *subscription.C
 			VATNumber:          req.User.VatNumber,
 			City:               req.User.City,
 			ZipCode:            req.User.ZipCode,
+			RegionCode:         req.User.RegionCode,
 			Language:           req.User.Language,
 			RegistrationNumber: req.User.RegistrationNumber,
 			MerchantId:         _interface.GetMerchantId(ctx),
@@ -104,6 +106,9 @@ func (c *ControllerSubscription) Create(ctx context.Context, req *subscription.C
 		ProductData:            req.ProductData,
 		ApplyPromoCredit:       req.ApplyPromoCredit,
 		ApplyPromoCreditAmount: req.ApplyPromoCreditAmount,
+		BackdateStartTime:      req.BackdateStartTime,
+		BackdateCatchUp:        req.BackdateCatchUp,
+		BillingCycleAnchorDay:  req.BillingCycleAnchorDay,
 	})
 	utility.AssertError(err, "Server Error")
 	if err == nil && _interface.Context().Get(ctx).IsAdminPortalCall {
@@ -34,6 +34,7 @@ func (c *ControllerSubscription) CreatePreview(ctx context.Context, req *subscri
 				dao.UserAccount.Columns().City:               req.User.City,
 				dao.UserAccount.Columns().Type:               req.User.Type,
 				dao.UserAccount.Columns().ZipCode:            req.User.ZipCode,
+				dao.UserAccount.Columns().RegionCode:         req.User.RegionCode,
 				dao.UserAccount.Columns().Language:           req.User.Language,
 				dao.UserAccount.Columns().Address:            req.User.Address,
 				dao.UserAccount.Columns().CompanyName:        req.User.CompanyName,
@@ -57,6 +58,7 @@ func (c *ControllerSubscription) CreatePreview(ctx context.Context, req *subscri
 			VATNumber:          req.User.VatNumber,
 			City:               req.User.City,
 			ZipCode:            req.User.ZipCode,
+			RegionCode:         req.User.RegionCode,
 			Language:           req.User.Language,
 			RegistrationNumber: req.User.RegistrationNumber,
 			MerchantId:         _interface.GetMerchantId(ctx),
@@ -90,6 +92,9 @@ func (c *ControllerSubscription) CreatePreview(ctx context.Context, req *subscri
 		FreeInInitialPeriod:    req.FreeInInitialPeriod,
 		ApplyPromoCredit:       req.ApplyPromoCredit,
 		ApplyPromoCreditAmount: req.ApplyPromoCreditAmount,
+		BackdateStartTime:      req.BackdateStartTime,
+		BackdateCatchUp:        req.BackdateCatchUp,
+		BillingCycleAnchorDay:  req.BillingCycleAnchorDay,
 	})
 	if err != nil {
 		return nil, err
@@ -0,0 +1,16 @@
+package merchant
+
+import (
+	"context"
+	"unibee/api/merchant/subscription"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/subscription/pending_item"
+)
+
+func (c *ControllerSubscription) PendingInvoiceItemDelete(ctx context.Context, req *subscription.PendingInvoiceItemDeleteReq) (res *subscription.PendingInvoiceItemDeleteRes, err error) {
+	err = pending_item.SubscriptionPendingInvoiceItemDelete(ctx, _interface.GetMerchantId(ctx), req.ItemId)
+	if err != nil {
+		return nil, err
+	}
+	return &subscription.PendingInvoiceItemDeleteRes{}, nil
+}
@@ -0,0 +1,26 @@
+package merchant
+
+import (
+	"context"
+	"unibee/api/bean"
+	"unibee/api/merchant/subscription"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/subscription/pending_item"
+	"unibee/internal/query"
+	"unibee/utility"
+)
+
+func (c *ControllerSubscription) PendingInvoiceItemList(ctx context.Context, req *subscription.PendingInvoiceItemListReq) (res *subscription.PendingInvoiceItemListRes, err error) {
+	sub := query.GetSubscriptionBySubscriptionId(ctx, req.SubscriptionId)
+	utility.Assert(sub != nil, "subscription not found")
+	utility.Assert(sub.MerchantId == _interface.GetMerchantId(ctx), "wrong merchant account")
+	list, total, err := pending_item.SubscriptionPendingInvoiceItemList(ctx, sub.MerchantId, sub.SubscriptionId, req.Status, req.Page, req.Count)
+	if err != nil {
+		return nil, err
+	}
+	var items = make([]*bean.SubscriptionPendingInvoiceItem, 0)
+	for _, one := range list {
+		items = append(items, bean.SimplifySubscriptionPendingInvoiceItem(one))
+	}
+	return &subscription.PendingInvoiceItemListRes{PendingInvoiceItems: items, Total: total}, nil
+}
@@ -0,0 +1,26 @@
+package merchant
+
+import (
+	"context"
+	"unibee/api/bean"
+	"unibee/api/merchant/subscription"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/subscription/pending_item"
+)
+
+func (c *ControllerSubscription) PendingInvoiceItemNew(ctx context.Context, req *subscription.PendingInvoiceItemNewReq) (res *subscription.PendingInvoiceItemNewRes, err error) {
+	one, err := pending_item.SubscriptionPendingInvoiceItemNew(ctx, &pending_item.NewInternalReq{
+		MerchantId:     _interface.GetMerchantId(ctx),
+		SubscriptionId: req.SubscriptionId,
+		PlanId:         req.PlanId,
+		UnitAmount:     req.UnitAmount,
+		Quantity:       req.Quantity,
+		Name:           req.Name,
+		Description:    req.Description,
+		TaxPercentage:  req.TaxPercentage,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &subscription.PendingInvoiceItemNewRes{PendingInvoiceItem: bean.SimplifySubscriptionPendingInvoiceItem(one)}, nil
+}
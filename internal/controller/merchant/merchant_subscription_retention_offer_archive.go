@@ -0,0 +1,16 @@
+package merchant
+
+import (
+	"context"
+	"unibee/api/merchant/subscription"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/subscription/cancellation"
+)
+
+func (c *ControllerSubscription) RetentionOfferArchive(ctx context.Context, req *subscription.RetentionOfferArchiveReq) (res *subscription.RetentionOfferArchiveRes, err error) {
+	err = cancellation.RetentionOfferArchive(ctx, _interface.GetMerchantId(ctx), req.OfferId)
+	if err != nil {
+		return nil, err
+	}
+	return &subscription.RetentionOfferArchiveRes{}, nil
+}
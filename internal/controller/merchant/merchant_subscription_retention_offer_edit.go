@@ -0,0 +1,29 @@
+package merchant
+
+import (
+	"context"
+	"unibee/api/bean"
+	"unibee/api/merchant/subscription"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/subscription/cancellation"
+)
+
+func (c *ControllerSubscription) RetentionOfferEdit(ctx context.Context, req *subscription.RetentionOfferEditReq) (res *subscription.RetentionOfferEditRes, err error) {
+	one, err := cancellation.RetentionOfferEdit(ctx, &cancellation.RetentionOfferInternalReq{
+		MerchantId:   _interface.GetMerchantId(ctx),
+		OfferId:      req.OfferId,
+		Name:         req.Name,
+		Description:  req.Description,
+		OfferType:    req.OfferType,
+		DiscountCode: req.DiscountCode,
+		PauseDays:    req.PauseDays,
+		TargetPlanId: req.TargetPlanId,
+		Reasons:      req.Reasons,
+		PlanIds:      req.PlanIds,
+		Priority:     req.Priority,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &subscription.RetentionOfferEditRes{RetentionOffer: bean.SimplifySubscriptionRetentionOffer(one)}, nil
+}
@@ -0,0 +1,21 @@
+package merchant
+
+import (
+	"context"
+	"unibee/api/bean"
+	"unibee/api/merchant/subscription"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/subscription/cancellation"
+)
+
+func (c *ControllerSubscription) RetentionOfferList(ctx context.Context, req *subscription.RetentionOfferListReq) (res *subscription.RetentionOfferListRes, err error) {
+	list, total, err := cancellation.GetRetentionOfferList(ctx, _interface.GetMerchantId(ctx), req.Status, req.Page, req.Count)
+	if err != nil {
+		return nil, err
+	}
+	var offers = make([]*bean.SubscriptionRetentionOffer, 0)
+	for _, one := range list {
+		offers = append(offers, bean.SimplifySubscriptionRetentionOffer(one))
+	}
+	return &subscription.RetentionOfferListRes{RetentionOffers: offers, Total: total}, nil
+}
@@ -0,0 +1,28 @@
+package merchant
+
+import (
+	"context"
+	"unibee/api/bean"
+	"unibee/api/merchant/subscription"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/subscription/cancellation"
+)
+
+func (c *ControllerSubscription) RetentionOfferNew(ctx context.Context, req *subscription.RetentionOfferNewReq) (res *subscription.RetentionOfferNewRes, err error) {
+	one, err := cancellation.RetentionOfferNew(ctx, &cancellation.RetentionOfferInternalReq{
+		MerchantId:   _interface.GetMerchantId(ctx),
+		Name:         req.Name,
+		Description:  req.Description,
+		OfferType:    req.OfferType,
+		DiscountCode: req.DiscountCode,
+		PauseDays:    req.PauseDays,
+		TargetPlanId: req.TargetPlanId,
+		Reasons:      req.Reasons,
+		PlanIds:      req.PlanIds,
+		Priority:     req.Priority,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &subscription.RetentionOfferNewRes{RetentionOffer: bean.SimplifySubscriptionRetentionOffer(one)}, nil
+}
@@ -0,0 +1,24 @@
+package merchant
+
+import (
+	"context"
+	"unibee/api/bean"
+	"unibee/api/merchant/subscription"
+	_interface "unibee/internal/interface/context"
+	entity "unibee/internal/model/entity/default"
+	"unibee/internal/query"
+	"unibee/utility"
+)
+
+func (c *ControllerSubscription) ScheduleDetail(ctx context.Context, req *subscription.ScheduleDetailReq) (res *subscription.ScheduleDetailRes, err error) {
+	var one *entity.SubscriptionSchedule
+	if len(req.ScheduleId) > 0 {
+		one = query.GetSubscriptionScheduleByScheduleId(ctx, req.ScheduleId)
+	} else {
+		utility.Assert(len(req.SubscriptionId) > 0, "one of ScheduleId and SubscriptionId should provide")
+		one = query.GetLatestSubscriptionScheduleBySubscriptionId(ctx, req.SubscriptionId)
+	}
+	utility.Assert(one != nil, "schedule not found")
+	utility.Assert(one.MerchantId == _interface.GetMerchantId(ctx), "wrong merchant account")
+	return &subscription.ScheduleDetailRes{Schedule: bean.SimplifySubscriptionSchedule(one, query.GetSubscriptionSchedulePhases(ctx, one.ScheduleId))}, nil
+}
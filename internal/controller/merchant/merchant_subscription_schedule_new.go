@@ -0,0 +1,27 @@
+package merchant
+
+import (
+	"context"
+	"unibee/api/bean"
+	"unibee/api/merchant/subscription"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/subscription/schedule"
+)
+
+func (c *ControllerSubscription) ScheduleNew(ctx context.Context, req *subscription.ScheduleNewReq) (res *subscription.ScheduleNewRes, err error) {
+	var merchantMemberId int64 = -1
+	if _interface.Context().Get(ctx).MerchantMember != nil {
+		merchantMemberId = int64(_interface.Context().Get(ctx).MerchantMember.Id)
+	}
+	one, phases, err := schedule.SubscriptionScheduleCreate(ctx, &schedule.CreateInternalReq{
+		MerchantId:       _interface.GetMerchantId(ctx),
+		SubscriptionId:   req.SubscriptionId,
+		Phases:           req.Phases,
+		EndBehavior:      req.EndBehavior,
+		MerchantMemberId: merchantMemberId,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &subscription.ScheduleNewRes{Schedule: bean.SimplifySubscriptionSchedule(one, phases)}, nil
+}
@@ -0,0 +1,25 @@
+package merchant
+
+import (
+	"context"
+	"unibee/api/merchant/subscription"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/subscription/schedule"
+	"unibee/internal/query"
+	"unibee/utility"
+)
+
+func (c *ControllerSubscription) SchedulePreview(ctx context.Context, req *subscription.SchedulePreviewReq) (res *subscription.SchedulePreviewRes, err error) {
+	if len(req.ScheduleId) > 0 {
+		one := query.GetSubscriptionScheduleByScheduleId(ctx, req.ScheduleId)
+		utility.Assert(one != nil, "schedule not found")
+		utility.Assert(one.MerchantId == _interface.GetMerchantId(ctx), "wrong merchant account")
+		sub := query.GetSubscriptionBySubscriptionId(ctx, one.SubscriptionId)
+		return &subscription.SchedulePreviewRes{Invoices: schedule.PreviewSubscriptionSchedule(ctx, sub, one, query.GetSubscriptionSchedulePhases(ctx, one.ScheduleId))}, nil
+	}
+	utility.Assert(len(req.SubscriptionId) > 0, "one of ScheduleId and SubscriptionId should provide")
+	sub := query.GetSubscriptionBySubscriptionId(ctx, req.SubscriptionId)
+	utility.Assert(sub != nil, "subscription not found")
+	utility.Assert(sub.MerchantId == _interface.GetMerchantId(ctx), "wrong merchant account")
+	return &subscription.SchedulePreviewRes{Invoices: schedule.PreviewSubscriptionSchedulePhases(ctx, sub, req.Phases, req.EndBehavior)}, nil
+}
@@ -0,0 +1,22 @@
+package merchant
+
+import (
+	"context"
+	"unibee/api/bean"
+	"unibee/api/merchant/subscription"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/subscription/schedule"
+	"unibee/internal/query"
+)
+
+func (c *ControllerSubscription) ScheduleRelease(ctx context.Context, req *subscription.ScheduleReleaseReq) (res *subscription.ScheduleReleaseRes, err error) {
+	var reason = req.Reason
+	if len(reason) == 0 {
+		reason = "ReleaseByMerchant"
+	}
+	one, err := schedule.SubscriptionScheduleRelease(ctx, _interface.GetMerchantId(ctx), req.ScheduleId, reason)
+	if err != nil {
+		return nil, err
+	}
+	return &subscription.ScheduleReleaseRes{Schedule: bean.SimplifySubscriptionSchedule(one, query.GetSubscriptionSchedulePhases(ctx, one.ScheduleId))}, nil
+}
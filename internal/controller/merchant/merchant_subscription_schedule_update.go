@@ -0,0 +1,22 @@
+package merchant
+
+import (
+	"context"
+	"unibee/api/bean"
+	"unibee/api/merchant/subscription"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/subscription/schedule"
+)
+
+func (c *ControllerSubscription) ScheduleUpdate(ctx context.Context, req *subscription.ScheduleUpdateReq) (res *subscription.ScheduleUpdateRes, err error) {
+	one, phases, err := schedule.SubscriptionScheduleUpdate(ctx, &schedule.UpdateInternalReq{
+		MerchantId:  _interface.GetMerchantId(ctx),
+		ScheduleId:  req.ScheduleId,
+		Phases:      req.Phases,
+		EndBehavior: req.EndBehavior,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &subscription.ScheduleUpdateRes{Schedule: bean.SimplifySubscriptionSchedule(one, phases)}, nil
+}
@@ -0,0 +1,25 @@
+package merchant
+
+import (
+	"context"
+	"unibee/api/bean"
+	"unibee/api/merchant/subscription"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/subscription/seat"
+)
+
+func (c *ControllerSubscription) SeatAssign(ctx context.Context, req *subscription.SeatAssignReq) (res *subscription.SeatAssignRes, err error) {
+	list, err := seat.SubscriptionSeatAssign(ctx, &seat.AssignInternalReq{
+		MerchantId:     _interface.GetMerchantId(ctx),
+		SubscriptionId: req.SubscriptionId,
+		Seats:          req.Seats,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var seats = make([]*bean.SubscriptionSeat, 0)
+	for _, one := range list {
+		seats = append(seats, bean.SimplifySubscriptionSeat(one))
+	}
+	return &subscription.SeatAssignRes{Seats: seats}, nil
+}
@@ -0,0 +1,39 @@
+package merchant
+
+import (
+	"context"
+	"unibee/api/bean"
+	"unibee/api/merchant/subscription"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/subscription/seat"
+)
+
+func (c *ControllerSubscription) SeatChange(ctx context.Context, req *subscription.SeatChangeReq) (res *subscription.SeatChangeRes, err error) {
+	var merchantMemberId int64 = -1
+	if _interface.Context().Get(ctx).MerchantMember != nil {
+		merchantMemberId = int64(_interface.Context().Get(ctx).MerchantMember.Id)
+	}
+	change, err := seat.SubscriptionSeatChange(ctx, &seat.ChangeInternalReq{
+		MerchantId:       _interface.GetMerchantId(ctx),
+		SubscriptionId:   req.SubscriptionId,
+		Quantity:         req.Quantity,
+		ProrationMode:    req.ProrationMode,
+		MerchantMemberId: merchantMemberId,
+	})
+	if err != nil {
+		return nil, err
+	}
+	res = &subscription.SeatChangeRes{
+		Subscription: bean.SimplifySubscription(ctx, change.Subscription),
+		SeatChange:   bean.SimplifySubscriptionSeatChange(change.SeatChange),
+		Paid:         true,
+	}
+	if change.Update != nil {
+		res.SubscriptionPendingUpdate = change.Update.SubscriptionPendingUpdate
+		res.PaymentId = change.Update.PaymentId
+		res.InvoiceId = change.Update.InvoiceId
+		res.Paid = change.Update.Paid
+		res.Link = change.Update.Link
+	}
+	return res, nil
+}
@@ -0,0 +1,30 @@
+package merchant
+
+import (
+	"context"
+	"unibee/api/bean"
+	"unibee/api/merchant/subscription"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/query"
+	"unibee/utility"
+)
+
+func (c *ControllerSubscription) SeatList(ctx context.Context, req *subscription.SeatListReq) (res *subscription.SeatListRes, err error) {
+	sub := query.GetSubscriptionBySubscriptionId(ctx, req.SubscriptionId)
+	utility.Assert(sub != nil, "subscription not found")
+	utility.Assert(sub.MerchantId == _interface.GetMerchantId(ctx), "wrong merchant account")
+	var seats = make([]*bean.SubscriptionSeat, 0)
+	for _, one := range query.GetAssignedSubscriptionSeats(ctx, sub.SubscriptionId) {
+		seats = append(seats, bean.SimplifySubscriptionSeat(one))
+	}
+	var changes = make([]*bean.SubscriptionSeatChange, 0)
+	for _, one := range query.GetSubscriptionSeatChangesByPeriodStart(ctx, sub.SubscriptionId, sub.CurrentPeriodStart) {
+		changes = append(changes, bean.SimplifySubscriptionSeatChange(one))
+	}
+	return &subscription.SeatListRes{
+		Quantity:    sub.Quantity,
+		Assigned:    int64(len(seats)),
+		Seats:       seats,
+		SeatChanges: changes,
+	}, nil
+}
@@ -0,0 +1,16 @@
+package merchant
+
+import (
+	"context"
+	"unibee/api/merchant/subscription"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/subscription/seat"
+)
+
+func (c *ControllerSubscription) SeatUnassign(ctx context.Context, req *subscription.SeatUnassignReq) (res *subscription.SeatUnassignRes, err error) {
+	err = seat.SubscriptionSeatUnassign(ctx, _interface.GetMerchantId(ctx), req.SubscriptionId, req.SeatIds)
+	if err != nil {
+		return nil, err
+	}
+	return &subscription.SeatUnassignRes{}, nil
+}
@@ -0,0 +1,28 @@
+package merchant
+
+import (
+	"context"
+	"unibee/api/bean"
+	"unibee/api/merchant/subscription"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/subscription/config"
+	"unibee/internal/logic/subscription/pause"
+)
+
+func (c *ControllerSubscription) Pause(ctx context.Context, req *subscription.PauseReq) (res *subscription.PauseRes, err error) {
+	var pauseMode = req.PauseMode
+	if len(pauseMode) == 0 {
+		pauseMode = config.GetMerchantSubscriptionConfig(ctx, _interface.GetMerchantId(ctx)).PauseMode
+	}
+	one, err := pause.SubscriptionPause(ctx, &pause.PauseInternalReq{
+		MerchantId:     _interface.GetMerchantId(ctx),
+		SubscriptionId: req.SubscriptionId,
+		PauseMode:      pauseMode,
+		ResumeTime:     req.ResumeTime,
+		Reason:         req.Reason,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &subscription.PauseRes{Subscription: bean.SimplifySubscription(ctx, one)}, nil
+}
@@ -0,0 +1,17 @@
+package merchant
+
+import (
+	"context"
+	"unibee/api/bean"
+	"unibee/api/merchant/subscription"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/subscription/pause"
+)
+
+func (c *ControllerSubscription) Resume(ctx context.Context, req *subscription.ResumeReq) (res *subscription.ResumeRes, err error) {
+	one, err := pause.SubscriptionResume(ctx, _interface.GetMerchantId(ctx), req.SubscriptionId, "Merchant")
+	if err != nil {
+		return nil, err
+	}
+	return &subscription.ResumeRes{Subscription: bean.SimplifySubscription(ctx, one)}, nil
+}
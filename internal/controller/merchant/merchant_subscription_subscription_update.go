@@ -86,6 +86,7 @@ func (c *ControllerSubscription) Update(ctx context.Context, req *subscription.U
 			ProductData:            req.ProductData,
 			ApplyPromoCredit:       req.ApplyPromoCredit,
 			ApplyPromoCreditAmount: req.ApplyPromoCreditAmount,
+			ProrationBehavior:      req.ProrationBehavior,
 		}, memberMemberId)
 		//if update.SubscriptionPendingUpdate != nil {
 		//	operation_log.AppendOptLog(taskCtx, &operation_log.OptLogRequest{
@@ -7,6 +7,10 @@ import (
 )
 
 func (c *ControllerSubscription) UpdatePreview(ctx context.Context, req *subscription.UpdatePreviewReq) (res *subscription.UpdatePreviewRes, err error) {
+	var prorationDate int64 = 0
+	if req.ProrationDate != nil {
+		prorationDate = *req.ProrationDate
+	}
 	update, err := service.SubscriptionUpdatePreview(ctx, &service.UpdatePreviewInternalReq{
 		SubscriptionId:         req.SubscriptionId,
 		NewPlanId:              req.NewPlanId,
@@ -16,7 +20,8 @@ func (c *ControllerSubscription) UpdatePreview(ctx context.Context, req *subscri
 		DiscountCode:           req.DiscountCode,
 		ApplyPromoCredit:       req.ApplyPromoCredit,
 		ApplyPromoCreditAmount: req.ApplyPromoCreditAmount,
-	}, 0, -1)
+		ProrationBehavior:      req.ProrationBehavior,
+	}, prorationDate, -1)
 	if err != nil {
 		return nil, err
 	}
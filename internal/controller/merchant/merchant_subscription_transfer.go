@@ -0,0 +1,27 @@
+package merchant
+
+import (
+	"context"
+	"unibee/api/bean"
+	"unibee/api/merchant/subscription"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/subscription/transfer"
+	"unibee/internal/query"
+)
+
+func (c *ControllerSubscription) Transfer(ctx context.Context, req *subscription.TransferReq) (res *subscription.TransferRes, err error) {
+	one, err := transfer.SubscriptionTransfer(ctx, &transfer.TransferInternalReq{
+		MerchantId:           _interface.GetMerchantId(ctx),
+		SubscriptionId:       req.SubscriptionId,
+		ToUserId:             req.ToUserId,
+		RequirePaymentMethod: req.RequirePaymentMethod,
+		Reason:               req.Reason,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &subscription.TransferRes{
+		Transfer:     bean.SimplifySubscriptionTransfer(one),
+		Subscription: bean.SimplifySubscription(ctx, query.GetSubscriptionBySubscriptionId(ctx, one.SubscriptionId)),
+	}, nil
+}
@@ -0,0 +1,21 @@
+package merchant
+
+import (
+	"context"
+	"unibee/api/bean"
+	"unibee/api/merchant/subscription"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/subscription/transfer"
+)
+
+func (c *ControllerSubscription) TransferList(ctx context.Context, req *subscription.TransferListReq) (res *subscription.TransferListRes, err error) {
+	list, total, err := transfer.GetTransferList(ctx, _interface.GetMerchantId(ctx), req.SubscriptionId, req.UserId, req.Page, req.Count)
+	if err != nil {
+		return nil, err
+	}
+	var transfers = make([]*bean.SubscriptionTransfer, 0)
+	for _, one := range list {
+		transfers = append(transfers, bean.SimplifySubscriptionTransfer(one))
+	}
+	return &subscription.TransferListRes{Transfers: transfers, Total: total}, nil
+}
@@ -0,0 +1,53 @@
+package merchant
+
+import (
+	"context"
+	"unibee/api/bean"
+	"unibee/api/merchant/subscription"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/subscription/trial"
+	user2 "unibee/internal/logic/user"
+	"unibee/internal/query"
+	"unibee/utility"
+)
+
+func (c *ControllerSubscription) TrialCreate(ctx context.Context, req *subscription.TrialCreateReq) (res *subscription.TrialCreateRes, err error) {
+	if req.UserId > 0 {
+		user := query.GetUserAccountById(ctx, req.UserId)
+		utility.Assert(user != nil && user.MerchantId == _interface.GetMerchantId(ctx), "user not found")
+		if len(req.Email) > 0 {
+			utility.Assert(user.Email == req.Email, "invalid email, not match")
+		}
+	} else {
+		utility.Assert(len(req.Email) > 0, "one of userId or email needed")
+		user, err := user2.QueryOrCreateUser(ctx, &user2.NewUserInternalReq{
+			ExternalUserId: req.ExternalUserId,
+			Email:          req.Email,
+			MerchantId:     _interface.GetMerchantId(ctx),
+		})
+		utility.AssertError(err, "Server Error")
+		req.UserId = user.Id
+	}
+	one, sub, err := trial.TrialCreate(ctx, &trial.CreateInternalReq{
+		MerchantId:     _interface.GetMerchantId(ctx),
+		PlanId:         req.PlanId,
+		UserId:         req.UserId,
+		Currency:       req.Currency,
+		Quantity:       req.Quantity,
+		AddonParams:    req.AddonParams,
+		TrialEnd:       req.TrialEnd,
+		VatCountryCode: req.VatCountryCode,
+		VatNumber:      req.VatNumber,
+		TaxPercentage:  req.TaxPercentage,
+		ReturnUrl:      req.ReturnUrl,
+		Metadata:       req.Metadata,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &subscription.TrialCreateRes{
+		Trial:        bean.SimplifySubscriptionTrial(one),
+		Subscription: bean.SimplifySubscription(ctx, sub),
+		Link:         trial.GetTrialLink(one),
+	}, nil
+}
@@ -0,0 +1,18 @@
+package merchant
+
+import (
+	"context"
+	"unibee/api/merchant/subscription"
+	"unibee/internal/consts"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/subscription/trial"
+	"unibee/internal/query"
+	"unibee/utility"
+)
+
+func (c *ControllerSubscription) TrialLink(ctx context.Context, req *subscription.TrialLinkReq) (res *subscription.TrialLinkRes, err error) {
+	one := query.GetSubscriptionTrialByTrialId(ctx, req.TrialId)
+	utility.Assert(one != nil && one.MerchantId == _interface.GetMerchantId(ctx), "trial not found")
+	utility.Assert(one.Status == consts.SubTrialStatusTrialing, "trial already ended")
+	return &subscription.TrialLinkRes{Link: trial.GetTrialLink(one)}, nil
+}
@@ -0,0 +1,21 @@
+package merchant
+
+import (
+	"context"
+	"unibee/api/bean"
+	"unibee/api/merchant/subscription"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/subscription/trial"
+)
+
+func (c *ControllerSubscription) TrialList(ctx context.Context, req *subscription.TrialListReq) (res *subscription.TrialListRes, err error) {
+	list, total, err := trial.GetTrialList(ctx, _interface.GetMerchantId(ctx), req.SubscriptionId, req.UserId, req.Status, req.Page, req.Count)
+	if err != nil {
+		return nil, err
+	}
+	var trials = make([]*bean.SubscriptionTrial, 0)
+	for _, one := range list {
+		trials = append(trials, bean.SimplifySubscriptionTrial(one))
+	}
+	return &subscription.TrialListRes{Trials: trials, Total: total}, nil
+}
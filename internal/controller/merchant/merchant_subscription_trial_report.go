@@ -0,0 +1,18 @@
+package merchant
+
+import (
+	"context"
+	"unibee/api/merchant/subscription"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/subscription/trial"
+	"unibee/utility"
+)
+
+func (c *ControllerSubscription) TrialReport(ctx context.Context, req *subscription.TrialReportReq) (res *subscription.TrialReportRes, err error) {
+	utility.Assert(req.EndTime == 0 || req.EndTime >= req.StartTime, "endTime should not be earlier than startTime")
+	reports, err := trial.TrialReport(ctx, _interface.GetMerchantId(ctx), req.StartTime, req.EndTime)
+	if err != nil {
+		return nil, err
+	}
+	return &subscription.TrialReportRes{Reports: reports}, nil
+}
@@ -0,0 +1,45 @@
+package merchant
+
+import (
+	"context"
+	"fmt"
+
+	"unibee/api/bean"
+	"unibee/api/merchant/subscription"
+	"unibee/internal/consts"
+	dao "unibee/internal/dao/default"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/operation_log"
+	"unibee/internal/query"
+	"unibee/utility"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+func (c *ControllerSubscription) UpdateInvoiceReview(ctx context.Context, req *subscription.UpdateInvoiceReviewReq) (res *subscription.UpdateInvoiceReviewRes, err error) {
+	utility.Assert(len(req.SubscriptionId) > 0, "subscription id should not be empty")
+	utility.Assert(req.InvoiceReviewHours >= -1 && req.InvoiceReviewHours <= consts.MaxInvoiceReviewHours, fmt.Sprintf("invoiceReviewHours should between -1 and %d", consts.MaxInvoiceReviewHours))
+	sub := query.GetSubscriptionBySubscriptionId(ctx, req.SubscriptionId)
+	utility.Assert(sub != nil, "subscription not found")
+	utility.Assert(sub.MerchantId == _interface.GetMerchantId(ctx), "wrong merchant account")
+	_, err = dao.Subscription.Ctx(ctx).Data(g.Map{
+		dao.Subscription.Columns().InvoiceReviewHours: req.InvoiceReviewHours,
+		dao.Subscription.Columns().GmtModify:          gtime.Now(),
+	}).Where(dao.Subscription.Columns().SubscriptionId, sub.SubscriptionId).OmitNil().Update()
+	operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
+		MerchantId:     sub.MerchantId,
+		Target:         fmt.Sprintf("Subscription(%s)", sub.SubscriptionId),
+		Content:        fmt.Sprintf("UpdateInvoiceReview(%d)", req.InvoiceReviewHours),
+		UserId:         sub.UserId,
+		SubscriptionId: sub.SubscriptionId,
+		InvoiceId:      "",
+		PlanId:         0,
+		DiscountCode:   "",
+	}, err)
+	if err != nil {
+		return nil, err
+	}
+	sub.InvoiceReviewHours = req.InvoiceReviewHours
+	return &subscription.UpdateInvoiceReviewRes{Subscription: bean.SimplifySubscription(ctx, sub)}, nil
+}
@@ -0,0 +1,23 @@
+package merchant
+
+import (
+	"context"
+	"unibee/api/bean"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/subscription/consolidation"
+
+	"unibee/api/merchant/user"
+)
+
+func (c *ControllerUser) ConsolidatedBillingSetup(ctx context.Context, req *user.ConsolidatedBillingSetupReq) (res *user.ConsolidatedBillingSetupRes, err error) {
+	one, err := consolidation.SetupUserConsolidatedBilling(ctx, &consolidation.ConsolidatedBillingSetupReq{
+		MerchantId:         _interface.GetMerchantId(ctx),
+		UserId:             req.UserId,
+		Enable:             req.Enable,
+		BillingCycleAnchor: req.BillingCycleAnchor,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &user.ConsolidatedBillingSetupRes{User: bean.SimplifyUserAccount(one)}, nil
+}
@@ -11,6 +11,7 @@ import (
 	"unibee/api/merchant/user"
 	"unibee/internal/cmd/i18n"
 	redismq2 "unibee/internal/cmd/redismq"
+	"unibee/internal/consts"
 	dao "unibee/internal/dao/default"
 	_interface "unibee/internal/interface/context"
 	"unibee/internal/logic/operation_log"
@@ -85,24 +86,47 @@ func (c *ControllerUser) Update(ctx context.Context, req *user.UpdateReq) (res *
 	if req.Type != nil {
 		utility.Assert(*req.Type == 1 || *req.Type == 2, "invalid Type, 1-Individual|2-Business")
 	}
+	if req.TaxStatus != nil {
+		utility.Assert(*req.TaxStatus == consts.UserTaxStatusTaxable || *req.TaxStatus == consts.UserTaxStatusExempt || *req.TaxStatus == consts.UserTaxStatusReverseCharge, "invalid TaxStatus, 0-taxable|1-exempt|2-reverse charge")
+		if *req.TaxStatus == consts.UserTaxStatusExempt {
+			var certificate = one.TaxExemptCertificate
+			if req.TaxExemptCertificate != nil {
+				certificate = *req.TaxExemptCertificate
+			}
+			utility.Assert(len(certificate) > 0, "taxExemptCertificate needed for exempt customer")
+		} else if *req.TaxStatus == consts.UserTaxStatusReverseCharge {
+			utility.Assert(len(vatNumber) > 0, "vat number needed for reverse charge customer")
+		}
+	}
+	if req.TaxExemptExpireTime != nil {
+		utility.Assert(*req.TaxExemptExpireTime >= 0, "invalid TaxExemptExpireTime")
+	}
+	if req.NetTermDays != nil {
+		utility.Assert(*req.NetTermDays == consts.UserNetTermDueOnReceipt || *req.NetTermDays == consts.UserNetTerm30 || *req.NetTermDays == consts.UserNetTerm60 || *req.NetTermDays == consts.UserNetTerm90, "invalid NetTermDays, 0|30|60|90")
+	}
 	_, err = dao.UserAccount.Ctx(ctx).Data(g.Map{
-		dao.UserAccount.Columns().Type:            req.Type,
-		dao.UserAccount.Columns().LastName:        req.LastName,
-		dao.UserAccount.Columns().FirstName:       req.FirstName,
-		dao.UserAccount.Columns().Address:         req.Address,
-		dao.UserAccount.Columns().CompanyName:     req.CompanyName,
-		dao.UserAccount.Columns().VATNumber:       req.VATNumber,
-		dao.UserAccount.Columns().Phone:           req.Phone,
-		dao.UserAccount.Columns().Telegram:        req.Telegram,
-		dao.UserAccount.Columns().WhatsAPP:        req.WhatsApp,
-		dao.UserAccount.Columns().WeChat:          req.WeChat,
-		dao.UserAccount.Columns().LinkedIn:        req.LinkedIn,
-		dao.UserAccount.Columns().Facebook:        req.Facebook,
-		dao.UserAccount.Columns().TikTok:          req.TikTok,
-		dao.UserAccount.Columns().OtherSocialInfo: req.OtherSocialInfo,
-		dao.UserAccount.Columns().City:            req.City,
-		dao.UserAccount.Columns().ZipCode:         req.ZipCode,
-		dao.UserAccount.Columns().Language:        req.Language,
+		dao.UserAccount.Columns().Type:                 req.Type,
+		dao.UserAccount.Columns().LastName:             req.LastName,
+		dao.UserAccount.Columns().FirstName:            req.FirstName,
+		dao.UserAccount.Columns().Address:              req.Address,
+		dao.UserAccount.Columns().CompanyName:          req.CompanyName,
+		dao.UserAccount.Columns().VATNumber:            req.VATNumber,
+		dao.UserAccount.Columns().Phone:                req.Phone,
+		dao.UserAccount.Columns().Telegram:             req.Telegram,
+		dao.UserAccount.Columns().WhatsAPP:             req.WhatsApp,
+		dao.UserAccount.Columns().WeChat:               req.WeChat,
+		dao.UserAccount.Columns().LinkedIn:             req.LinkedIn,
+		dao.UserAccount.Columns().Facebook:             req.Facebook,
+		dao.UserAccount.Columns().TikTok:               req.TikTok,
+		dao.UserAccount.Columns().OtherSocialInfo:      req.OtherSocialInfo,
+		dao.UserAccount.Columns().City:                 req.City,
+		dao.UserAccount.Columns().ZipCode:              req.ZipCode,
+		dao.UserAccount.Columns().RegionCode:           req.RegionCode,
+		dao.UserAccount.Columns().TaxStatus:            req.TaxStatus,
+		dao.UserAccount.Columns().TaxExemptCertificate: req.TaxExemptCertificate,
+		dao.UserAccount.Columns().TaxExemptExpireTime:  req.TaxExemptExpireTime,
+		dao.UserAccount.Columns().NetTermDays:          req.NetTermDays,
+		dao.UserAccount.Columns().Language:             req.Language,
 		//dao.UserAccount.Columns().ReMark:             req.GatewayPaymentType,
 		dao.UserAccount.Columns().RegistrationNumber: req.RegistrationNumber,
 		dao.UserAccount.Columns().GmtModify:          gtime.Now(),
@@ -0,0 +1,40 @@
+package merchant
+
+import (
+	"context"
+	"unibee/api/merchant/vat"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/tax"
+	"unibee/internal/logic/tax/tax_bean"
+	"unibee/internal/query"
+	"unibee/utility"
+)
+
+func (c *ControllerVat) TaxComputePreview(ctx context.Context, req *vat.TaxComputePreviewReq) (res *vat.TaxComputePreviewRes, err error) {
+	computeReq := &tax_bean.ComputeTaxReq{
+		MerchantId:  _interface.GetMerchantId(ctx),
+		UserId:      req.UserId,
+		Currency:    req.Currency,
+		CountryCode: req.CountryCode,
+		RegionCode:  req.RegionCode,
+		City:        req.City,
+		ZipCode:     req.ZipCode,
+		Items:       req.Items,
+	}
+	if req.UserId > 0 {
+		user := query.GetUserAccountById(ctx, req.UserId)
+		utility.Assert(user != nil, "user not found")
+		utility.Assert(user.MerchantId == _interface.GetMerchantId(ctx), "wrong merchant account")
+		computeReq.CountryCode = user.CountryCode
+		computeReq.RegionCode = user.RegionCode
+		computeReq.City = user.City
+		computeReq.ZipCode = user.ZipCode
+		computeReq.VatNumber = user.VATNumber
+	}
+	utility.Assert(len(computeReq.CountryCode) > 0, "countryCode is required")
+	result, err := tax.ComputeTax(ctx, computeReq)
+	if err != nil {
+		return nil, err
+	}
+	return &vat.TaxComputePreviewRes{Items: result.Items}, nil
+}
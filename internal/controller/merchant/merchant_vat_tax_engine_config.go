@@ -0,0 +1,15 @@
+package merchant
+
+import (
+	"context"
+	"unibee/api/merchant/vat"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/tax"
+)
+
+func (c *ControllerVat) TaxEngineConfig(ctx context.Context, req *vat.TaxEngineConfigReq) (res *vat.TaxEngineConfigRes, err error) {
+	return &vat.TaxEngineConfigRes{
+		Config:    tax.GetMerchantTaxEngineConfig(ctx, _interface.GetMerchantId(ctx)),
+		Providers: tax.GetTaxProviderNames(),
+	}, nil
+}
@@ -0,0 +1,20 @@
+package merchant
+
+import (
+	"context"
+	"unibee/api/merchant/vat"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/tax"
+)
+
+func (c *ControllerVat) TaxEngineSetup(ctx context.Context, req *vat.TaxEngineSetupReq) (res *vat.TaxEngineSetupRes, err error) {
+	config := &tax.Config{
+		Enable:   req.Enable,
+		Provider: req.Provider,
+	}
+	err = tax.SetupMerchantTaxEngineConfig(ctx, _interface.GetMerchantId(ctx), config)
+	if err != nil {
+		return nil, err
+	}
+	return &vat.TaxEngineSetupRes{Config: tax.GetMerchantTaxEngineConfig(ctx, _interface.GetMerchantId(ctx))}, nil
+}
@@ -0,0 +1,16 @@
+package merchant
+
+import (
+	"context"
+	"unibee/api/merchant/vat"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/tax/rules"
+)
+
+func (c *ControllerVat) TaxRuleDelete(ctx context.Context, req *vat.TaxRuleDeleteReq) (res *vat.TaxRuleDeleteRes, err error) {
+	err = rules.DeleteTaxRule(ctx, _interface.GetMerchantId(ctx), req.RuleId)
+	if err != nil {
+		return nil, err
+	}
+	return &vat.TaxRuleDeleteRes{}, nil
+}
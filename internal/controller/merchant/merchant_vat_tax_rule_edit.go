@@ -0,0 +1,30 @@
+package merchant
+
+import (
+	"context"
+	"unibee/api/bean"
+	"unibee/api/merchant/vat"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/tax/rules"
+)
+
+func (c *ControllerVat) TaxRuleEdit(ctx context.Context, req *vat.TaxRuleEditReq) (res *vat.TaxRuleEditRes, err error) {
+	one, err := rules.EditTaxRule(ctx, &rules.RuleInternalReq{
+		MerchantId:    _interface.GetMerchantId(ctx),
+		RuleId:        req.RuleId,
+		Name:          req.Name,
+		Jurisdiction:  req.Jurisdiction,
+		CountryCode:   req.CountryCode,
+		RegionCode:    req.RegionCode,
+		City:          req.City,
+		ZipCodePrefix: req.ZipCodePrefix,
+		TaxCategory:   req.TaxCategory,
+		Rate:          req.Rate,
+		Compound:      req.Compound,
+		Priority:      req.Priority,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &vat.TaxRuleEditRes{TaxRule: bean.SimplifyMerchantTaxRule(one)}, nil
+}
@@ -0,0 +1,13 @@
+package merchant
+
+import (
+	"context"
+	"unibee/api/bean"
+	"unibee/api/merchant/vat"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/tax/rules"
+)
+
+func (c *ControllerVat) TaxRuleList(ctx context.Context, req *vat.TaxRuleListReq) (res *vat.TaxRuleListRes, err error) {
+	return &vat.TaxRuleListRes{TaxRules: bean.SimplifyMerchantTaxRuleList(rules.ListTaxRule(ctx, _interface.GetMerchantId(ctx), req.CountryCode))}, nil
+}
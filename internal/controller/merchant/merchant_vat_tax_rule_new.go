@@ -0,0 +1,29 @@
+package merchant
+
+import (
+	"context"
+	"unibee/api/bean"
+	"unibee/api/merchant/vat"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/tax/rules"
+)
+
+func (c *ControllerVat) TaxRuleNew(ctx context.Context, req *vat.TaxRuleNewReq) (res *vat.TaxRuleNewRes, err error) {
+	one, err := rules.NewTaxRule(ctx, &rules.RuleInternalReq{
+		MerchantId:    _interface.GetMerchantId(ctx),
+		Name:          &req.Name,
+		Jurisdiction:  &req.Jurisdiction,
+		CountryCode:   &req.CountryCode,
+		RegionCode:    &req.RegionCode,
+		City:          &req.City,
+		ZipCodePrefix: &req.ZipCodePrefix,
+		TaxCategory:   &req.TaxCategory,
+		Rate:          &req.Rate,
+		Compound:      &req.Compound,
+		Priority:      &req.Priority,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &vat.TaxRuleNewRes{TaxRule: bean.SimplifyMerchantTaxRule(one)}, nil
+}
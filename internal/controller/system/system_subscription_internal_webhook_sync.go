@@ -110,6 +110,8 @@ func syncSubscription(ctx context.Context, req *subscription.InternalWebhookSync
 					event = event2.UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_EXPIRED
 				} else if one.Status == consts.SubStatusFailed {
 					event = event2.UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_FAILED
+				} else if one.Status == consts.SubStatusSuspended {
+					event = event2.UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_PAUSED
 				}
 				subDetailRes, err := detail2.SubscriptionDetail(ctx, one.SubscriptionId)
 				if err != nil {
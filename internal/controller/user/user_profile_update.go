@@ -107,6 +107,7 @@ func (c *ControllerProfile) Update(ctx context.Context, req *profile.UpdateReq)
 		dao.UserAccount.Columns().City:            req.City,
 		dao.UserAccount.Columns().Language:        req.Language,
 		dao.UserAccount.Columns().ZipCode:         req.ZipCode,
+		dao.UserAccount.Columns().RegionCode:      req.RegionCode,
 		//dao.UserAccount.Columns().ReMark:             req.GatewayPaymentType,
 		dao.UserAccount.Columns().RegistrationNumber: req.RegistrationNumber,
 		dao.UserAccount.Columns().GmtModify:          gtime.Now(),
@@ -0,0 +1,28 @@
+package user
+
+import (
+	"context"
+	"unibee/api/bean"
+	"unibee/internal/cmd/config"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/subscription/cancellation"
+	"unibee/internal/query"
+	"unibee/utility"
+
+	"unibee/api/user/subscription"
+)
+
+func (c *ControllerSubscription) CancellationAcceptOffer(ctx context.Context, req *subscription.CancellationAcceptOfferReq) (res *subscription.CancellationAcceptOfferRes, err error) {
+	if !config.GetConfigInstance().IsLocal() {
+		utility.Assert(_interface.Context().Get(ctx).User != nil, "auth failure,not login")
+		utility.Assert(_interface.Context().Get(ctx).User.Id > 0, "userId invalid")
+	}
+	one, err := cancellation.CancellationAcceptOffer(ctx, _interface.Context().Get(ctx).User.Id, req.CancellationId, req.OfferId)
+	if err != nil {
+		return nil, err
+	}
+	return &subscription.CancellationAcceptOfferRes{
+		Cancellation: bean.SimplifySubscriptionCancellation(one),
+		Subscription: bean.SimplifySubscription(ctx, query.GetSubscriptionBySubscriptionId(ctx, one.SubscriptionId)),
+	}, nil
+}
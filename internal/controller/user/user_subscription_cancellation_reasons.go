@@ -0,0 +1,18 @@
+package user
+
+import (
+	"context"
+	"unibee/internal/cmd/config"
+	_interface "unibee/internal/interface/context"
+	subscriptionConfig "unibee/internal/logic/subscription/config"
+	"unibee/utility"
+
+	"unibee/api/user/subscription"
+)
+
+func (c *ControllerSubscription) CancellationReasons(ctx context.Context, req *subscription.CancellationReasonsReq) (res *subscription.CancellationReasonsRes, err error) {
+	if !config.GetConfigInstance().IsLocal() {
+		utility.Assert(_interface.Context().Get(ctx).User != nil, "auth failure,not login")
+	}
+	return &subscription.CancellationReasonsRes{Reasons: subscriptionConfig.GetMerchantSubscriptionConfig(ctx, _interface.GetMerchantId(ctx)).CancellationReasons}, nil
+}
@@ -0,0 +1,33 @@
+package user
+
+import (
+	"context"
+	"unibee/api/bean"
+	"unibee/internal/cmd/config"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/subscription/cancellation"
+	"unibee/utility"
+
+	"unibee/api/user/subscription"
+)
+
+func (c *ControllerSubscription) CancellationStart(ctx context.Context, req *subscription.CancellationStartReq) (res *subscription.CancellationStartRes, err error) {
+	if !config.GetConfigInstance().IsLocal() {
+		utility.Assert(_interface.Context().Get(ctx).User != nil, "auth failure,not login")
+		utility.Assert(_interface.Context().Get(ctx).User.Id > 0, "userId invalid")
+	}
+	one, offers, err := cancellation.CancellationStart(ctx, &cancellation.StartInternalReq{
+		UserId:         _interface.Context().Get(ctx).User.Id,
+		SubscriptionId: req.SubscriptionId,
+		Reason:         req.Reason,
+		ReasonText:     req.ReasonText,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var retentionOffers = make([]*bean.SubscriptionRetentionOffer, 0)
+	for _, offer := range offers {
+		retentionOffers = append(retentionOffers, bean.SimplifySubscriptionRetentionOffer(offer))
+	}
+	return &subscription.CancellationStartRes{Cancellation: bean.SimplifySubscriptionCancellation(one), RetentionOffers: retentionOffers}, nil
+}
@@ -0,0 +1,38 @@
+package user
+
+import (
+	"context"
+	"unibee/api/bean"
+	"unibee/internal/cmd/config"
+	_interface "unibee/internal/interface/context"
+	subscriptionConfig "unibee/internal/logic/subscription/config"
+	"unibee/internal/logic/subscription/pause"
+	"unibee/internal/query"
+	"unibee/utility"
+
+	"unibee/api/user/subscription"
+)
+
+func (c *ControllerSubscription) Pause(ctx context.Context, req *subscription.PauseReq) (res *subscription.PauseRes, err error) {
+	if !config.GetConfigInstance().IsLocal() {
+		utility.Assert(_interface.Context().Get(ctx).User != nil, "auth failure,not login")
+		utility.Assert(_interface.Context().Get(ctx).User.Id > 0, "userId invalid")
+	}
+	sub := query.GetSubscriptionBySubscriptionId(ctx, req.SubscriptionId)
+	utility.Assert(sub != nil, "subscription not found")
+	utility.Assert(sub.UserId == _interface.Context().Get(ctx).User.Id, "no permission")
+	subConfig := subscriptionConfig.GetMerchantSubscriptionConfig(ctx, sub.MerchantId)
+	utility.Assert(subConfig.UserPauseEnable, "pause subscription not available")
+
+	one, err := pause.SubscriptionPause(ctx, &pause.PauseInternalReq{
+		MerchantId:     sub.MerchantId,
+		SubscriptionId: sub.SubscriptionId,
+		PauseMode:      subConfig.PauseMode,
+		ResumeTime:     req.ResumeTime,
+		Reason:         "PausedByUser",
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &subscription.PauseRes{Subscription: bean.SimplifySubscription(ctx, one)}, nil
+}
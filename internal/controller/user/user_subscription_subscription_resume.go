@@ -2,13 +2,28 @@ package user
 
 import (
 	"context"
-
-	"github.com/gogf/gf/v2/errors/gcode"
-	"github.com/gogf/gf/v2/errors/gerror"
+	"unibee/api/bean"
+	"unibee/internal/cmd/config"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/subscription/pause"
+	"unibee/internal/query"
+	"unibee/utility"
 
 	"unibee/api/user/subscription"
 )
 
 func (c *ControllerSubscription) Resume(ctx context.Context, req *subscription.ResumeReq) (res *subscription.ResumeRes, err error) {
-	return nil, gerror.NewCode(gcode.CodeNotImplemented)
+	if !config.GetConfigInstance().IsLocal() {
+		utility.Assert(_interface.Context().Get(ctx).User != nil, "auth failure,not login")
+		utility.Assert(_interface.Context().Get(ctx).User.Id > 0, "userId invalid")
+	}
+	sub := query.GetSubscriptionBySubscriptionId(ctx, req.SubscriptionId)
+	utility.Assert(sub != nil, "subscription not found")
+	utility.Assert(sub.UserId == _interface.Context().Get(ctx).User.Id, "no permission")
+
+	one, err := pause.SubscriptionResume(ctx, sub.MerchantId, sub.SubscriptionId, "User")
+	if err != nil {
+		return nil, err
+	}
+	return &subscription.ResumeRes{Subscription: bean.SimplifySubscription(ctx, one)}, nil
 }
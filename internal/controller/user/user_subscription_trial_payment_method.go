@@ -0,0 +1,28 @@
+package user
+
+import (
+	"context"
+	"unibee/api/bean"
+	"unibee/internal/cmd/config"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/subscription/trial"
+	"unibee/internal/query"
+	"unibee/utility"
+
+	"unibee/api/user/subscription"
+)
+
+func (c *ControllerSubscription) TrialPaymentMethod(ctx context.Context, req *subscription.TrialPaymentMethodReq) (res *subscription.TrialPaymentMethodRes, err error) {
+	if !config.GetConfigInstance().IsLocal() {
+		utility.Assert(_interface.Context().Get(ctx).User != nil, "auth failure,not login")
+		utility.Assert(_interface.Context().Get(ctx).User.Id > 0, "userId invalid")
+	}
+	one := query.GetTrialingSubscriptionTrialBySubscriptionId(ctx, req.SubscriptionId)
+	utility.Assert(one != nil, "trial not found")
+	utility.Assert(one.UserId == _interface.Context().Get(ctx).User.Id, "no permission")
+	link, err := trial.PaymentMethodLink(ctx, one, req.GatewayId, req.ReturnUrl)
+	if err != nil {
+		return nil, err
+	}
+	return &subscription.TrialPaymentMethodRes{Trial: bean.SimplifySubscriptionTrial(one), Link: link}, nil
+}
@@ -10,6 +10,8 @@ import (
 	"unibee/internal/cronjob/discount"
 	"unibee/internal/cronjob/email"
 	"unibee/internal/cronjob/gateway_log"
+	"unibee/internal/cronjob/gateway_reconciliation"
+	"unibee/internal/cronjob/gateway_settlement"
 	"unibee/internal/cronjob/invoice"
 	"unibee/internal/cronjob/multi_currency"
 	"unibee/internal/cronjob/statistics"
@@ -48,6 +50,7 @@ func StartCronJobs() {
 		invoice.TaskForExpireInvoices(ctx)
 		//payment.TaskForCancelExpiredPayment(ctx)
 		batch.TaskForExpireBatchTasks(ctx)
+		sub.TaskForSubscriptionAutoResume(ctx, other1MinTask)
 	}, other1MinTask)
 	if err != nil {
 		g.Log().Errorf(ctx, "StartCronJobs Name:%s Err:%s\n", other1MinTask, err.Error())
@@ -74,6 +77,8 @@ func StartCronJobs() {
 		gateway_log.TaskForDeleteWebhookMessage(ctx)
 		gateway_log.TaskForDeleteWebhookLog(ctx)
 		sub.TaskForUserSubCompensate(ctx, hourTask)
+		sub.TaskForSubscriptionTrialEndReminder(ctx, hourTask)
+		sub.TaskForSubscriptionCommitment(ctx, hourTask)
 		if !config.GetConfigInstance().IsProd() {
 			merchant.ReloadAllMerchantsCacheForSDKAuthBackground()
 			member.ReloadAllMembersCacheForSDKAuthBackground()
@@ -88,6 +93,9 @@ func StartCronJobs() {
 	_, err = gcron.Add(ctx, "@daily", func(ctx context.Context) {
 		invoice.TaskForCompensateSubUpDownInvoices(ctx)
 		multi_currency.TaskForSyncMerchantsMultiCurrencyConfigs(ctx)
+		gateway_reconciliation.TaskForDailyGatewayReconciliation(ctx)
+		gateway_settlement.TaskForDailyGatewaySettlementSync(ctx)
+		invoice.TaskForOverdueInvoiceReminders(ctx)
 		if !config.GetConfigInstance().IsProd() {
 			statistics.TaskForUpdateAllMerchantStatistics(ctx)
 		}
@@ -0,0 +1,55 @@
+package gateway_reconciliation
+
+import (
+	"context"
+
+	"unibee/internal/logic/batch"
+	"unibee/internal/logic/gateway/reconciliation"
+	"unibee/internal/query"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+// TaskForDailyGatewayReconciliation creates one reconciliation export for every gateway of the merchants enabled it,
+// covering the previous utc day
+func TaskForDailyGatewayReconciliation(ctx context.Context) {
+	g.Log().Infof(ctx, "TaskForDailyGatewayReconciliation start")
+	endTime := gtime.Now().UTC().StartOfDay().Timestamp()
+	startTime := endTime - 86400
+	for _, merchant := range query.GetActiveMerchantList(ctx) {
+		config := reconciliation.GetMerchantReconciliationConfig(ctx, merchant.Id)
+		if !config.Enable {
+			continue
+		}
+		owner := query.GetMerchantOwnerMember(ctx, merchant.Id)
+		if owner == nil {
+			g.Log().Errorf(ctx, "TaskForDailyGatewayReconciliation merchant:%d owner not found", merchant.Id)
+			continue
+		}
+		var archive = false
+		for _, gateway := range query.GetMerchantGatewayList(ctx, merchant.Id, &archive) {
+			var err error
+			tryErr := g.Try(ctx, func(ctx context.Context) {
+				err = batch.NewBatchExportTask(ctx, &batch.MerchantBatchExportTaskInternalRequest{
+					MerchantId: merchant.Id,
+					MemberId:   owner.Id,
+					Task:       "GatewayReconciliationExport",
+					Payload: map[string]interface{}{
+						"gatewayId": gateway.Id,
+						"startTime": startTime,
+						"endTime":   endTime,
+						"autoHeal":  config.AutoHeal,
+					},
+				})
+			})
+			if tryErr != nil {
+				err = tryErr
+			}
+			if err != nil {
+				g.Log().Errorf(ctx, "TaskForDailyGatewayReconciliation merchant:%d gateway:%d error:%s", merchant.Id, gateway.Id, err.Error())
+			}
+		}
+	}
+	g.Log().Infof(ctx, "TaskForDailyGatewayReconciliation end")
+}
@@ -0,0 +1,45 @@
+package gateway_settlement
+
+import (
+	"context"
+
+	"unibee/internal/logic/gateway/api"
+	"unibee/internal/logic/settlement"
+	"unibee/internal/query"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+// SyncDays covers the previous days as well, the status of recent payouts may still change
+const SyncDays = 3
+
+// TaskForDailyGatewaySettlementSync ingests the settlements arrived in the last days for every gateway
+// support settlement report, of the merchants enabled it
+func TaskForDailyGatewaySettlementSync(ctx context.Context) {
+	g.Log().Infof(ctx, "TaskForDailyGatewaySettlementSync start")
+	endTime := gtime.Now().UTC().StartOfDay().Timestamp()
+	startTime := endTime - SyncDays*86400
+	for _, merchant := range query.GetActiveMerchantList(ctx) {
+		if !settlement.GetMerchantSettlementConfig(ctx, merchant.Id).Enable {
+			continue
+		}
+		var archive = false
+		for _, gateway := range query.GetMerchantGatewayList(ctx, merchant.Id, &archive) {
+			var err error
+			tryErr := g.Try(ctx, func(ctx context.Context) {
+				if api.GetGatewaySettlementProvider(ctx, gateway.Id) == nil {
+					return
+				}
+				_, err = settlement.Sync(ctx, gateway, startTime, endTime)
+			})
+			if tryErr != nil {
+				err = tryErr
+			}
+			if err != nil {
+				g.Log().Errorf(ctx, "TaskForDailyGatewaySettlementSync merchant:%d gateway:%d error:%s", merchant.Id, gateway.Id, err.Error())
+			}
+		}
+	}
+	g.Log().Infof(ctx, "TaskForDailyGatewaySettlementSync end")
+}
@@ -8,6 +8,7 @@ import (
 	"unibee/internal/consts"
 	dao "unibee/internal/dao/default"
 	"unibee/internal/logic/invoice/detail"
+	"unibee/internal/logic/invoice/receivable"
 	"unibee/internal/logic/invoice/service"
 	entity "unibee/internal/model/entity/default"
 	"unibee/internal/query"
@@ -44,6 +45,9 @@ func TaskForExpireInvoices(ctx context.Context) {
 				if err != nil {
 					g.Log().Errorf(ctx, "TaskForExpireInvoices Update FinishTime error:%s", err.Error())
 				}
+			} else if receivable.IsNetTermInvoice(one) {
+				// the net term invoice stays open as receivable after due, reminded by the overdue reminder
+				continue
 			} else if one.FinishTime+(one.DayUtilDue*86400)+1200 < gtime.Now().Timestamp() { // task delay 20 minutes to expire
 				//Invoice Expire
 				err = service.ProcessingInvoiceFailure(ctx, one.InvoiceId, "TaskForExpireInvoices")
@@ -91,6 +95,8 @@ func ExpireUserSubInvoices(ctx context.Context, sub *entity.Subscription, timeNo
 				if err != nil {
 					g.Log().Errorf(ctx, "ExpireUserSubInvoices Update FinishTime error:%s", err.Error())
 				}
+			} else if receivable.IsNetTermInvoice(one) {
+				continue
 			} else if one.FinishTime+(one.DayUtilDue*86400)+600 < timeNow {
 				//Invoice Expire
 				err = service.ProcessingInvoiceFailure(ctx, one.InvoiceId, "ExpireUserSubInvoices")
@@ -249,3 +255,7 @@ func TaskForCompensateSubUpDownInvoices(ctx context.Context) {
 		}
 	}
 }
+
+func TaskForOverdueInvoiceReminders(ctx context.Context) {
+	receivable.SendOverdueReminders(ctx)
+}
@@ -9,7 +9,9 @@ import (
 	"unibee/internal/consts"
 	dao "unibee/internal/dao/default"
 	"unibee/internal/logic/subscription/billingcycle/cycle"
+	"unibee/internal/logic/subscription/commitment"
 	"unibee/internal/logic/subscription/service"
+	"unibee/internal/logic/subscription/trial"
 	entity "unibee/internal/model/entity/default"
 	"unibee/internal/query"
 	"unibee/utility"
@@ -52,6 +54,52 @@ func TaskForSubscriptionBillingCycleDunningInvoice(ctx context.Context, taskName
 	g.Log().Debug(ctx, taskName, "End......")
 }
 
+func TaskForSubscriptionAutoResume(ctx context.Context, taskName string) {
+	g.Log().Debugf(ctx, "%s:%s", taskName, "TaskForSubscriptionAutoResume Start......")
+	var timeNow = gtime.Now().Timestamp()
+
+	var subs []*entity.Subscription
+	// query paused sub which resume time reached
+	q := dao.Subscription.Ctx(ctx).
+		Where(dao.Subscription.Columns().IsDeleted, 0).
+		Where(dao.Subscription.Columns().Status, consts.SubStatusSuspended).
+		WhereGT(dao.Subscription.Columns().ResumeTime, 0).
+		WhereLTE(dao.Subscription.Columns().ResumeTime, timeNow)
+	if !config.GetConfigInstance().IsProd() {
+		// Test Clock Not Enable For Prod Env
+		q = q.Where(dao.Subscription.Columns().TestClock, 0)
+	}
+	err := q.Limit(0, 100).
+		OrderAsc(dao.Subscription.Columns().ResumeTime).
+		Scan(&subs)
+	if err != nil {
+		g.Log().Errorf(ctx, "%s Error:%s", taskName, err.Error())
+		return
+	}
+
+	for _, sub := range subs {
+		walk, err := cycle.SubPipeBillingCycleWalk(ctx, sub.SubscriptionId, timeNow, taskName)
+		if err != nil {
+			g.Log().Errorf(ctx, "TaskForSubscriptionAutoResume SubPipeBillingCycleWalk SubId:%s error:%s", sub.SubscriptionId, err.Error())
+		}
+		g.Log().Debugf(ctx, "TaskForSubscriptionAutoResume SubPipeBillingCycleWalk SubId:%s WalkResult:%s", sub.SubscriptionId, utility.MarshalToJsonString(walk))
+	}
+
+	g.Log().Debug(ctx, taskName, "TaskForSubscriptionAutoResume End......")
+}
+
+func TaskForSubscriptionTrialEndReminder(ctx context.Context, taskName string) {
+	g.Log().Debugf(ctx, "%s:%s", taskName, "TaskForSubscriptionTrialEndReminder Start......")
+	trial.SendTrialEndReminders(ctx)
+	g.Log().Debug(ctx, taskName, "TaskForSubscriptionTrialEndReminder End......")
+}
+
+func TaskForSubscriptionCommitment(ctx context.Context, taskName string) {
+	g.Log().Debugf(ctx, "%s:%s", taskName, "TaskForSubscriptionCommitment Start......")
+	commitment.WalkCommitments(ctx)
+	g.Log().Debug(ctx, taskName, "TaskForSubscriptionCommitment End......")
+}
+
 func TaskForSubscriptionTrackAfterCancelledOrExpired(ctx context.Context, taskName string) {
 	g.Log().Debugf(ctx, "%s:%s", taskName, "TaskForSubscriptionTrackAfterCancelledOrExpired Start......")
 	var timeNow = gtime.Now().Timestamp()
@@ -110,7 +158,7 @@ func TaskForSubscriptionInitFailed(ctx context.Context, taskName string) {
 	}
 
 	for _, sub := range subs {
-		err = service.SubscriptionCancel(ctx, sub.SubscriptionId, false, false, "CancelledByInitFailure")
+		err = service.SubscriptionCancelBySystem(ctx, sub.SubscriptionId, "CancelledByInitFailure")
 		if err != nil {
 			g.Log().Errorf(ctx, "TaskForSubscriptionInitFailed subId:%s error:%s", sub.SubscriptionId, err.Error())
 		} else {
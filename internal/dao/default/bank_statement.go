@@ -0,0 +1,27 @@
+// =================================================================================
+// This is auto-generated by GoFrame CLI tool only once. Fill this file as you wish.
+// =================================================================================
+
+package dao
+
+import (
+	"unibee/internal/dao/default/internal"
+)
+
+// internalBankStatementDao is internal type for wrapping internal DAO implements.
+type internalBankStatementDao = *internal.BankStatementDao
+
+// bankStatementDao is the data access object for table bank_statement.
+// You can define custom methods on it to extend its functionality as you wish.
+type bankStatementDao struct {
+	internalBankStatementDao
+}
+
+var (
+	// BankStatement is globally public accessible object for table bank_statement operations.
+	BankStatement = bankStatementDao{
+		internal.NewBankStatementDao(),
+	}
+)
+
+// Fill with you ideas below.
@@ -0,0 +1,27 @@
+// =================================================================================
+// This is auto-generated by GoFrame CLI tool only once. Fill this file as you wish.
+// =================================================================================
+
+package dao
+
+import (
+	"unibee/internal/dao/default/internal"
+)
+
+// internalBankStatementLineDao is internal type for wrapping internal DAO implements.
+type internalBankStatementLineDao = *internal.BankStatementLineDao
+
+// bankStatementLineDao is the data access object for table bank_statement_line.
+// You can define custom methods on it to extend its functionality as you wish.
+type bankStatementLineDao struct {
+	internalBankStatementLineDao
+}
+
+var (
+	// BankStatementLine is globally public accessible object for table bank_statement_line operations.
+	BankStatementLine = bankStatementLineDao{
+		internal.NewBankStatementLineDao(),
+	}
+)
+
+// Fill with you ideas below.
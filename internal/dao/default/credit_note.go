@@ -0,0 +1,27 @@
+// =================================================================================
+// This is auto-generated by GoFrame CLI tool only once. Fill this file as you wish.
+// =================================================================================
+
+package dao
+
+import (
+	"unibee/internal/dao/default/internal"
+)
+
+// internalCreditNoteDao is internal type for wrapping internal DAO implements.
+type internalCreditNoteDao = *internal.CreditNoteDao
+
+// creditNoteDao is the data access object for table credit_note.
+// You can define custom methods on it to extend its functionality as you wish.
+type creditNoteDao struct {
+	internalCreditNoteDao
+}
+
+var (
+	// CreditNote is globally public accessible object for table credit_note operations.
+	CreditNote = creditNoteDao{
+		internal.NewCreditNoteDao(),
+	}
+)
+
+// Fill with you ideas below.
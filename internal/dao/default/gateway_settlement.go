@@ -0,0 +1,27 @@
+// =================================================================================
+// This is auto-generated by GoFrame CLI tool only once. Fill this file as you wish.
+// =================================================================================
+
+package dao
+
+import (
+	"unibee/internal/dao/default/internal"
+)
+
+// internalGatewaySettlementDao is internal type for wrapping internal DAO implements.
+type internalGatewaySettlementDao = *internal.GatewaySettlementDao
+
+// gatewaySettlementDao is the data access object for table gateway_settlement.
+// You can define custom methods on it to extend its functionality as you wish.
+type gatewaySettlementDao struct {
+	internalGatewaySettlementDao
+}
+
+var (
+	// GatewaySettlement is globally public accessible object for table gateway_settlement operations.
+	GatewaySettlement = gatewaySettlementDao{
+		internal.NewGatewaySettlementDao(),
+	}
+)
+
+// Fill with you ideas below.
@@ -0,0 +1,27 @@
+// =================================================================================
+// This is auto-generated by GoFrame CLI tool only once. Fill this file as you wish.
+// =================================================================================
+
+package dao
+
+import (
+	"unibee/internal/dao/default/internal"
+)
+
+// internalGatewaySettlementItemDao is internal type for wrapping internal DAO implements.
+type internalGatewaySettlementItemDao = *internal.GatewaySettlementItemDao
+
+// gatewaySettlementItemDao is the data access object for table gateway_settlement_item.
+// You can define custom methods on it to extend its functionality as you wish.
+type gatewaySettlementItemDao struct {
+	internalGatewaySettlementItemDao
+}
+
+var (
+	// GatewaySettlementItem is globally public accessible object for table gateway_settlement_item operations.
+	GatewaySettlementItem = gatewaySettlementItemDao{
+		internal.NewGatewaySettlementItemDao(),
+	}
+)
+
+// Fill with you ideas below.
@@ -79,6 +79,14 @@ type InvoiceColumns struct {
 	PromoCreditDiscountAmount      string // promo credit discount amount
 	PartialCreditPaidAmount        string // partial credit paid amount
 	MetricCharge                   string // invoice metric charge data
+	InvoiceNumber                  string // sequential legal invoice number
+	TaxTreatment                   string // tax treatment, empty-standard，reverse_charge，exempt
+	ReviewFinalizeTime             string // utc time the draft invoice in review is finalised，0-not in review
+	ReviewHold                     string // 0-not held，1-held, the draft invoice in review is not finalised automatically
+	ConsolidatedInvoiceId          string // id of the consolidated invoice this cycle invoice is charged by
+	NetTermDays                    string // payment terms in days of the net term invoice，0-not net term
+	PaidAmount                     string // amount received by partial payments, cent
+	OverdueReminderTime            string // utc time the last overdue reminder is sent
 }
 
 // invoiceColumns holds the columns for table invoice.
@@ -142,6 +150,14 @@ var invoiceColumns = InvoiceColumns{
 	PromoCreditDiscountAmount:      "promo_credit_discount_amount",
 	PartialCreditPaidAmount:        "partial_credit_paid_amount",
 	MetricCharge:                   "metric_charge",
+	InvoiceNumber:                  "invoice_number",
+	TaxTreatment:                   "tax_treatment",
+	ReviewFinalizeTime:             "review_finalize_time",
+	ReviewHold:                     "review_hold",
+	ConsolidatedInvoiceId:          "consolidated_invoice_id",
+	NetTermDays:                    "net_term_days",
+	PaidAmount:                     "paid_amount",
+	OverdueReminderTime:            "overdue_reminder_time",
 }
 
 // NewInvoiceDao creates and returns a new DAO object for table data access.
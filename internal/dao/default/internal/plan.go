@@ -56,6 +56,11 @@ type PlanColumns struct {
 	DisableAutoCharge         string // disable auto-charge, 0-false,1-true
 	MetricCharge              string // metric charge(json)
 	InternalName              string //
+	TrialEndBehavior          string // behaviour at trial end of the subscription without payment method，convert|pause|cancel, default convert
+	TaxCategory               string // tax category of the plan, matched by tax rules
+	InvoiceReviewHours        string // hours the cycle invoices stay in draft for review before finalised，0-review disabled
+	MinSeats                  string // min seats (quantity) of the subscription，0-no limit
+	MaxSeats                  string // max seats (quantity) of the subscription，0-no limit
 }
 
 // planColumns holds the columns for table plan.
@@ -96,6 +101,11 @@ var planColumns = PlanColumns{
 	DisableAutoCharge:         "disable_auto_charge",
 	MetricCharge:              "metric_charge",
 	InternalName:              "internal_name",
+	TrialEndBehavior:          "trial_end_behavior",
+	TaxCategory:               "tax_category",
+	InvoiceReviewHours:        "invoice_review_hours",
+	MinSeats:                  "min_seats",
+	MaxSeats:                  "max_seats",
 }
 
 // NewPlanDao creates and returns a new DAO object for table data access.
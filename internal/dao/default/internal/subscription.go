@@ -72,6 +72,10 @@ type SubscriptionColumns struct {
 	LastTrackTime               string // last subscription track time
 	ExternalSubscriptionId      string // external_subscription_id
 	NextInvoiceData             string // next_invoice_data
+	InvoiceReviewHours          string // hours the cycle invoices stay in draft for review，0-follow plan，-1-review disabled
+	PauseMode                   string // pause billing behaviour，keep_as_draft|void|mark_uncollectible
+	PauseTime                   string // utc time the subscription paused, 0-not paused
+	ResumeTime                  string // utc time the paused subscription resumes automatically, 0-resume manually
 }
 
 // subscriptionColumns holds the columns for table subscription.
@@ -128,6 +132,10 @@ var subscriptionColumns = SubscriptionColumns{
 	LastTrackTime:               "last_track_time",
 	ExternalSubscriptionId:      "external_subscription_id",
 	NextInvoiceData:             "next_invoice_data",
+	InvoiceReviewHours:          "invoice_review_hours",
+	PauseMode:                   "pause_mode",
+	PauseTime:                   "pause_time",
+	ResumeTime:                  "resume_time",
 }
 
 // NewSubscriptionDao creates and returns a new DAO object for table data access.
@@ -0,0 +1,27 @@
+// =================================================================================
+// This is auto-generated by GoFrame CLI tool only once. Fill this file as you wish.
+// =================================================================================
+
+package dao
+
+import (
+	"unibee/internal/dao/default/internal"
+)
+
+// internalInvoicePartialPaymentDao is internal type for wrapping internal DAO implements.
+type internalInvoicePartialPaymentDao = *internal.InvoicePartialPaymentDao
+
+// invoicePartialPaymentDao is the data access object for table invoice_partial_payment.
+// You can define custom methods on it to extend its functionality as you wish.
+type invoicePartialPaymentDao struct {
+	internalInvoicePartialPaymentDao
+}
+
+var (
+	// InvoicePartialPayment is globally public accessible object for table invoice_partial_payment operations.
+	InvoicePartialPayment = invoicePartialPaymentDao{
+		internal.NewInvoicePartialPaymentDao(),
+	}
+)
+
+// Fill with you ideas below.
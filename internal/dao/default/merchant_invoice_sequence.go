@@ -0,0 +1,27 @@
+// =================================================================================
+// This is auto-generated by GoFrame CLI tool only once. Fill this file as you wish.
+// =================================================================================
+
+package dao
+
+import (
+	"unibee/internal/dao/default/internal"
+)
+
+// internalMerchantInvoiceSequenceDao is internal type for wrapping internal DAO implements.
+type internalMerchantInvoiceSequenceDao = *internal.MerchantInvoiceSequenceDao
+
+// merchantInvoiceSequenceDao is the data access object for table merchant_invoice_sequence.
+// You can define custom methods on it to extend its functionality as you wish.
+type merchantInvoiceSequenceDao struct {
+	internalMerchantInvoiceSequenceDao
+}
+
+var (
+	// MerchantInvoiceSequence is globally public accessible object for table merchant_invoice_sequence operations.
+	MerchantInvoiceSequence = merchantInvoiceSequenceDao{
+		internal.NewMerchantInvoiceSequenceDao(),
+	}
+)
+
+// Fill with you ideas below.
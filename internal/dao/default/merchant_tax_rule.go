@@ -0,0 +1,27 @@
+// =================================================================================
+// This is auto-generated by GoFrame CLI tool only once. Fill this file as you wish.
+// =================================================================================
+
+package dao
+
+import (
+	"unibee/internal/dao/default/internal"
+)
+
+// internalMerchantTaxRuleDao is internal type for wrapping internal DAO implements.
+type internalMerchantTaxRuleDao = *internal.MerchantTaxRuleDao
+
+// merchantTaxRuleDao is the data access object for table merchant_tax_rule.
+// You can define custom methods on it to extend its functionality as you wish.
+type merchantTaxRuleDao struct {
+	internalMerchantTaxRuleDao
+}
+
+var (
+	// MerchantTaxRule is globally public accessible object for table merchant_tax_rule operations.
+	MerchantTaxRule = merchantTaxRuleDao{
+		internal.NewMerchantTaxRuleDao(),
+	}
+)
+
+// Fill with you ideas below.
@@ -0,0 +1,27 @@
+// =================================================================================
+// This is auto-generated by GoFrame CLI tool only once. Fill this file as you wish.
+// =================================================================================
+
+package dao
+
+import (
+	"unibee/internal/dao/default/internal"
+)
+
+// internalSubscriptionCancellationDao is internal type for wrapping internal DAO implements.
+type internalSubscriptionCancellationDao = *internal.SubscriptionCancellationDao
+
+// subscriptionCancellationDao is the data access object for table subscription_cancellation.
+// You can define custom methods on it to extend its functionality as you wish.
+type subscriptionCancellationDao struct {
+	internalSubscriptionCancellationDao
+}
+
+var (
+	// SubscriptionCancellation is globally public accessible object for table subscription_cancellation operations.
+	SubscriptionCancellation = subscriptionCancellationDao{
+		internal.NewSubscriptionCancellationDao(),
+	}
+)
+
+// Fill with you ideas below.
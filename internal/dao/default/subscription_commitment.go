@@ -0,0 +1,27 @@
+// =================================================================================
+// This is auto-generated by GoFrame CLI tool only once. Fill this file as you wish.
+// =================================================================================
+
+package dao
+
+import (
+	"unibee/internal/dao/default/internal"
+)
+
+// internalSubscriptionCommitmentDao is internal type for wrapping internal DAO implements.
+type internalSubscriptionCommitmentDao = *internal.SubscriptionCommitmentDao
+
+// subscriptionCommitmentDao is the data access object for table subscription_commitment.
+// You can define custom methods on it to extend its functionality as you wish.
+type subscriptionCommitmentDao struct {
+	internalSubscriptionCommitmentDao
+}
+
+var (
+	// SubscriptionCommitment is globally public accessible object for table subscription_commitment operations.
+	SubscriptionCommitment = subscriptionCommitmentDao{
+		internal.NewSubscriptionCommitmentDao(),
+	}
+)
+
+// Fill with you ideas below.
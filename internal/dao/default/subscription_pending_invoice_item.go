@@ -0,0 +1,27 @@
+// =================================================================================
+// This is auto-generated by GoFrame CLI tool only once. Fill this file as you wish.
+// =================================================================================
+
+package dao
+
+import (
+	"unibee/internal/dao/default/internal"
+)
+
+// internalSubscriptionPendingInvoiceItemDao is internal type for wrapping internal DAO implements.
+type internalSubscriptionPendingInvoiceItemDao = *internal.SubscriptionPendingInvoiceItemDao
+
+// subscriptionPendingInvoiceItemDao is the data access object for table subscription_pending_invoice_item.
+// You can define custom methods on it to extend its functionality as you wish.
+type subscriptionPendingInvoiceItemDao struct {
+	internalSubscriptionPendingInvoiceItemDao
+}
+
+var (
+	// SubscriptionPendingInvoiceItem is globally public accessible object for table subscription_pending_invoice_item operations.
+	SubscriptionPendingInvoiceItem = subscriptionPendingInvoiceItemDao{
+		internal.NewSubscriptionPendingInvoiceItemDao(),
+	}
+)
+
+// Fill with you ideas below.
@@ -0,0 +1,27 @@
+// =================================================================================
+// This is auto-generated by GoFrame CLI tool only once. Fill this file as you wish.
+// =================================================================================
+
+package dao
+
+import (
+	"unibee/internal/dao/default/internal"
+)
+
+// internalSubscriptionRetentionOfferDao is internal type for wrapping internal DAO implements.
+type internalSubscriptionRetentionOfferDao = *internal.SubscriptionRetentionOfferDao
+
+// subscriptionRetentionOfferDao is the data access object for table subscription_retention_offer.
+// You can define custom methods on it to extend its functionality as you wish.
+type subscriptionRetentionOfferDao struct {
+	internalSubscriptionRetentionOfferDao
+}
+
+var (
+	// SubscriptionRetentionOffer is globally public accessible object for table subscription_retention_offer operations.
+	SubscriptionRetentionOffer = subscriptionRetentionOfferDao{
+		internal.NewSubscriptionRetentionOfferDao(),
+	}
+)
+
+// Fill with you ideas below.
@@ -0,0 +1,27 @@
+// =================================================================================
+// This is auto-generated by GoFrame CLI tool only once. Fill this file as you wish.
+// =================================================================================
+
+package dao
+
+import (
+	"unibee/internal/dao/default/internal"
+)
+
+// internalSubscriptionScheduleDao is internal type for wrapping internal DAO implements.
+type internalSubscriptionScheduleDao = *internal.SubscriptionScheduleDao
+
+// subscriptionScheduleDao is the data access object for table subscription_schedule.
+// You can define custom methods on it to extend its functionality as you wish.
+type subscriptionScheduleDao struct {
+	internalSubscriptionScheduleDao
+}
+
+var (
+	// SubscriptionSchedule is globally public accessible object for table subscription_schedule operations.
+	SubscriptionSchedule = subscriptionScheduleDao{
+		internal.NewSubscriptionScheduleDao(),
+	}
+)
+
+// Fill with you ideas below.
@@ -0,0 +1,27 @@
+// =================================================================================
+// This is auto-generated by GoFrame CLI tool only once. Fill this file as you wish.
+// =================================================================================
+
+package dao
+
+import (
+	"unibee/internal/dao/default/internal"
+)
+
+// internalSubscriptionSchedulePhaseDao is internal type for wrapping internal DAO implements.
+type internalSubscriptionSchedulePhaseDao = *internal.SubscriptionSchedulePhaseDao
+
+// subscriptionSchedulePhaseDao is the data access object for table subscription_schedule_phase.
+// You can define custom methods on it to extend its functionality as you wish.
+type subscriptionSchedulePhaseDao struct {
+	internalSubscriptionSchedulePhaseDao
+}
+
+var (
+	// SubscriptionSchedulePhase is globally public accessible object for table subscription_schedule_phase operations.
+	SubscriptionSchedulePhase = subscriptionSchedulePhaseDao{
+		internal.NewSubscriptionSchedulePhaseDao(),
+	}
+)
+
+// Fill with you ideas below.
@@ -0,0 +1,27 @@
+// =================================================================================
+// This is auto-generated by GoFrame CLI tool only once. Fill this file as you wish.
+// =================================================================================
+
+package dao
+
+import (
+	"unibee/internal/dao/default/internal"
+)
+
+// internalSubscriptionSeatDao is internal type for wrapping internal DAO implements.
+type internalSubscriptionSeatDao = *internal.SubscriptionSeatDao
+
+// subscriptionSeatDao is the data access object for table subscription_seat.
+// You can define custom methods on it to extend its functionality as you wish.
+type subscriptionSeatDao struct {
+	internalSubscriptionSeatDao
+}
+
+var (
+	// SubscriptionSeat is globally public accessible object for table subscription_seat operations.
+	SubscriptionSeat = subscriptionSeatDao{
+		internal.NewSubscriptionSeatDao(),
+	}
+)
+
+// Fill with you ideas below.
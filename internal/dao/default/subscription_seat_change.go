@@ -0,0 +1,27 @@
+// =================================================================================
+// This is auto-generated by GoFrame CLI tool only once. Fill this file as you wish.
+// =================================================================================
+
+package dao
+
+import (
+	"unibee/internal/dao/default/internal"
+)
+
+// internalSubscriptionSeatChangeDao is internal type for wrapping internal DAO implements.
+type internalSubscriptionSeatChangeDao = *internal.SubscriptionSeatChangeDao
+
+// subscriptionSeatChangeDao is the data access object for table subscription_seat_change.
+// You can define custom methods on it to extend its functionality as you wish.
+type subscriptionSeatChangeDao struct {
+	internalSubscriptionSeatChangeDao
+}
+
+var (
+	// SubscriptionSeatChange is globally public accessible object for table subscription_seat_change operations.
+	SubscriptionSeatChange = subscriptionSeatChangeDao{
+		internal.NewSubscriptionSeatChangeDao(),
+	}
+)
+
+// Fill with you ideas below.
@@ -0,0 +1,27 @@
+// =================================================================================
+// This is auto-generated by GoFrame CLI tool only once. Fill this file as you wish.
+// =================================================================================
+
+package dao
+
+import (
+	"unibee/internal/dao/default/internal"
+)
+
+// internalSubscriptionTransferDao is internal type for wrapping internal DAO implements.
+type internalSubscriptionTransferDao = *internal.SubscriptionTransferDao
+
+// subscriptionTransferDao is the data access object for table subscription_transfer.
+// You can define custom methods on it to extend its functionality as you wish.
+type subscriptionTransferDao struct {
+	internalSubscriptionTransferDao
+}
+
+var (
+	// SubscriptionTransfer is globally public accessible object for table subscription_transfer operations.
+	SubscriptionTransfer = subscriptionTransferDao{
+		internal.NewSubscriptionTransferDao(),
+	}
+)
+
+// Fill with you ideas below.
@@ -0,0 +1,27 @@
+// =================================================================================
+// This is auto-generated by GoFrame CLI tool only once. Fill this file as you wish.
+// =================================================================================
+
+package dao
+
+import (
+	"unibee/internal/dao/default/internal"
+)
+
+// internalSubscriptionTrialDao is internal type for wrapping internal DAO implements.
+type internalSubscriptionTrialDao = *internal.SubscriptionTrialDao
+
+// subscriptionTrialDao is the data access object for table subscription_trial.
+// You can define custom methods on it to extend its functionality as you wish.
+type subscriptionTrialDao struct {
+	internalSubscriptionTrialDao
+}
+
+var (
+	// SubscriptionTrial is globally public accessible object for table subscription_trial operations.
+	SubscriptionTrial = subscriptionTrialDao{
+		internal.NewSubscriptionTrialDao(),
+	}
+)
+
+// Fill with you ideas below.
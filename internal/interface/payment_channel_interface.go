@@ -35,6 +35,7 @@ type GatewayInfo struct {
 	SubGatewayName                string
 	Host                          string
 	AutoChargeEnabled             bool
+	PaymentListEnabled            bool // lists the payments of the merchant created in a time window, without a user
 }
 
 type GatewayTestReq struct {
@@ -71,6 +72,11 @@ type GatewayInterface interface {
 	GatewayRefundCancel(ctx context.Context, gateway *entity.MerchantGateway, payment *entity.Payment, refund *entity.Refund) (res *gateway_bean.GatewayPaymentRefundResp, err error)
 }
 
+// GatewaySettlementInterface is optional, implemented by gateways able to report payouts and the transactions settled in each
+type GatewaySettlementInterface interface {
+	GatewaySettlementList(ctx context.Context, gateway *entity.MerchantGateway, req *gateway_bean.GatewaySettlementListReq) (res []*gateway_bean.GatewaySettlement, err error)
+}
+
 type GatewayWebhookInterface interface {
 	GatewayCheckAndSetupWebhook(ctx context.Context, gateway *entity.MerchantGateway) (err error)
 	GatewayWebhook(r *ghttp.Request, gateway *entity.MerchantGateway)
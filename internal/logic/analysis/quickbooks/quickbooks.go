@@ -287,7 +287,7 @@ func UploadToSalesReceipt(ctx context.Context, detail *detail.InvoiceDetail, qbC
 			Name:  customer.DisplayName,
 		},
 		TxnDate:              time.Unix(detail.PaidTime, 0).Format("2006-01-02"),
-		DocNumber:            detail.InvoiceId,
+		DocNumber:            docNumber(detail),
 		Line:                 qbLines,
 		GlobalTaxCalculation: "NotApplicable",
 		TotalAmt:             decimal.NewFromInt(detail.TotalAmount).Div(decimal.NewFromInt(100)),
@@ -366,7 +366,7 @@ func UploadToInvoice(ctx context.Context, detail *detail.InvoiceDetail, qbClient
 			Name:  customer.DisplayName,
 		},
 		TxnDate:              time.Unix(detail.PaidTime, 0).Format("2006-01-02"),
-		DocNumber:            detail.InvoiceId,
+		DocNumber:            docNumber(detail),
 		Line:                 qbLines,
 		GlobalTaxCalculation: "NotApplicable",
 		TotalAmt:             decimal.NewFromInt(detail.TotalAmount).Div(decimal.NewFromInt(100)),
@@ -473,7 +473,7 @@ func UploadToRefundReceipt(ctx context.Context, detail *detail.InvoiceDetail, qb
 			Name:  customer.DisplayName,
 		},
 		TxnDate:              time.Unix(detail.PaidTime, 0).Format("2006-01-02"),
-		DocNumber:            detail.InvoiceId,
+		DocNumber:            docNumber(detail),
 		Line:                 qbLines,
 		GlobalTaxCalculation: "NotApplicable",
 		TotalAmt:             decimal.NewFromInt(detail.TotalAmount).Div(decimal.NewFromInt(100)).Abs(),
@@ -493,3 +493,11 @@ func UploadToRefundReceipt(ctx context.Context, detail *detail.InvoiceDetail, qb
 		g.Log().Errorf(ctx, "uploadPaidInvoiceBackground failed to CreateOrUpdateRefundReceiptByDocNumber QuickBooks refund receipt for invoice %s: response is nil", detail.InvoiceId)
 	}
 }
+
+// docNumber prefers the sequential invoice number, invoices issued without numbering keep the invoiceId
+func docNumber(detail *detail.InvoiceDetail) string {
+	if len(detail.InvoiceNumber) > 0 {
+		return detail.InvoiceNumber
+	}
+	return detail.InvoiceId
+}
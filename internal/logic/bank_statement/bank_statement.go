@@ -0,0 +1,353 @@
+package bank_statement
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"unibee/api/bean"
+	"unibee/internal/consts"
+	dao "unibee/internal/dao/default"
+	"unibee/internal/logic/bank_statement/parser"
+	"unibee/internal/logic/invoice/invoice_number"
+	"unibee/internal/logic/invoice/receivable"
+	"unibee/internal/logic/invoice/service"
+	"unibee/internal/logic/operation_log"
+	entity "unibee/internal/model/entity/default"
+	"unibee/internal/query"
+	"unibee/utility"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+type ImportInternalReq struct {
+	MerchantId uint64 `json:"merchantId"`
+	FileName   string `json:"fileName"`
+	Format     string `json:"format"`
+	Data       []byte `json:"-"`
+}
+
+// Import parses the bank statement and matches its credits to the processing invoices of the merchant,
+// the exact matches are marked paid, the others are queued for review, the credits imported before are skipped
+func Import(ctx context.Context, req *ImportInternalReq) (*entity.BankStatement, []*entity.BankStatementLine, error) {
+	utility.Assert(req != nil, "invalid request")
+	utility.Assert(req.MerchantId > 0, "invalid merchantId")
+	utility.Assert(len(req.Data) > 0, "statement file is empty")
+	statement, err := parser.Parse(req.FileName, req.Format, req.Data)
+	if err != nil {
+		return nil, nil, gerror.Newf("parse statement error:%s", err.Error())
+	}
+	lockKey := fmt.Sprintf("bank_statement_import_lock_%d", req.MerchantId)
+	if !utility.TryLock(ctx, lockKey, 300) {
+		return nil, nil, gerror.New("another statement is importing, try later")
+	}
+	defer func() {
+		utility.ReleaseLock(ctx, lockKey)
+	}()
+	one := &entity.BankStatement{
+		MerchantId:      req.MerchantId,
+		Format:          statement.Format,
+		FileName:        req.FileName,
+		BankStatementId: statement.StatementId,
+		Account:         statement.Account,
+		Currency:        statement.Currency,
+		CreateTime:      gtime.Now().Timestamp(),
+	}
+	result, err := dao.BankStatement.Ctx(ctx).Data(one).OmitNil().Insert(one)
+	if err != nil {
+		return nil, nil, gerror.Newf("Import BankStatement error:%s", err.Error())
+	}
+	id, _ := result.LastInsertId()
+	one.Id = uint64(id)
+
+	invoices, err := loadMatchInvoices(ctx, req.MerchantId)
+	if err != nil {
+		return nil, nil, err
+	}
+	var lines = make([]*entity.BankStatementLine, 0)
+	for _, line := range statement.Lines {
+		if query.GetBankStatementLineByKey(ctx, req.MerchantId, line.Key) != nil {
+			one.DuplicateCount = one.DuplicateCount + 1
+			continue
+		}
+		status, candidates := MatchLine(line, invoices)
+		record := &entity.BankStatementLine{
+			MerchantId:          req.MerchantId,
+			StatementId:         one.Id,
+			LineKey:             line.Key,
+			BankReference:       line.BankReference,
+			BookingTime:         line.BookingTime,
+			Currency:            line.Currency,
+			Amount:              line.Amount,
+			CounterpartyName:    line.CounterpartyName,
+			CounterpartyAccount: line.CounterpartyAccount,
+			Remittance:          line.Remittance,
+			Status:              status,
+			Candidates:          utility.MarshalToJsonString(candidates),
+			CreateTime:          gtime.Now().Timestamp(),
+		}
+		if len(candidates) > 0 {
+			record.Confidence = candidates[0].Confidence
+		}
+		result, err = dao.BankStatementLine.Ctx(ctx).Data(record).OmitNil().Insert(record)
+		if err != nil {
+			g.Log().Errorf(ctx, "Import BankStatementLine key:%s error:%s", line.Key, err.Error())
+			continue
+		}
+		lineId, _ := result.LastInsertId()
+		record.Id = uint64(lineId)
+		one.CreditCount = one.CreditCount + 1
+		if status == consts.BankStatementLineAutoMatched {
+			var applyErr error
+			utility.Try(func() {
+				_, applyErr = applyLine(ctx, record, candidates[0].InvoiceId, "BankStatementImport")
+			}, func(exception interface{}) {
+				applyErr = gerror.Newf("%v", exception)
+			})
+			if applyErr != nil {
+				g.Log().Errorf(ctx, "Import BankStatementLine:%d apply invoice:%s error:%s", record.Id, candidates[0].InvoiceId, applyErr.Error())
+				record.Status = consts.BankStatementLineNeedReview
+				record.Note = strings.ReplaceAll(applyErr.Error(), utility.SystemAssertPrefix, "")
+			} else {
+				record.InvoiceId = candidates[0].InvoiceId
+				for _, invoice := range invoices {
+					if invoice.InvoiceId == record.InvoiceId {
+						invoice.OutstandingAmount = invoice.OutstandingAmount - record.Amount
+					}
+				}
+			}
+			_, _ = dao.BankStatementLine.Ctx(ctx).Data(g.Map{
+				dao.BankStatementLine.Columns().Status:    record.Status,
+				dao.BankStatementLine.Columns().InvoiceId: record.InvoiceId,
+				dao.BankStatementLine.Columns().Note:      record.Note,
+				dao.BankStatementLine.Columns().GmtModify: gtime.Now(),
+			}).Where(dao.BankStatementLine.Columns().Id, record.Id).Update()
+		}
+		switch record.Status {
+		case consts.BankStatementLineAutoMatched:
+			one.AutoMatchedCount = one.AutoMatchedCount + 1
+		case consts.BankStatementLineNeedReview:
+			one.ReviewCount = one.ReviewCount + 1
+		default:
+			one.UnmatchedCount = one.UnmatchedCount + 1
+		}
+		lines = append(lines, record)
+	}
+	_, err = dao.BankStatement.Ctx(ctx).Data(g.Map{
+		dao.BankStatement.Columns().CreditCount:      one.CreditCount,
+		dao.BankStatement.Columns().DuplicateCount:   one.DuplicateCount,
+		dao.BankStatement.Columns().AutoMatchedCount: one.AutoMatchedCount,
+		dao.BankStatement.Columns().ReviewCount:      one.ReviewCount,
+		dao.BankStatement.Columns().UnmatchedCount:   one.UnmatchedCount,
+		dao.BankStatement.Columns().GmtModify:        gtime.Now(),
+	}).Where(dao.BankStatement.Columns().Id, one.Id).Update()
+	operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
+		MerchantId: req.MerchantId,
+		Target:     fmt.Sprintf("BankStatement(%v)", one.Id),
+		Content:    fmt.Sprintf("Import(%s)", statement.Format),
+	}, err)
+	if err != nil {
+		return nil, nil, err
+	}
+	return one, lines, nil
+}
+
+// ConfirmLine applies the credit in review to the invoice chosen by the merchant,
+// the invoice is marked paid when the credit covers its outstanding amount, otherwise recorded as partial payment
+func ConfirmLine(ctx context.Context, merchantId uint64, lineId uint64, invoiceId string) (*entity.BankStatementLine, *entity.Invoice, error) {
+	utility.Assert(merchantId > 0, "invalid merchantId")
+	utility.Assert(len(invoiceId) > 0, "invalid invoiceId")
+	line := query.GetBankStatementLineById(ctx, lineId)
+	utility.Assert(line != nil, "bank statement line not found")
+	utility.Assert(line.MerchantId == merchantId, "wrong merchant account")
+	utility.Assert(line.Status == consts.BankStatementLineNeedReview || line.Status == consts.BankStatementLineUnmatched, "bank statement line is "+consts.BankStatementLineStatusEnum(line.Status).Description())
+	lockKey := fmt.Sprintf("bank_statement_line_lock_%d", line.Id)
+	if !utility.TryLock(ctx, lockKey, 30) {
+		return nil, nil, gerror.New("Submit Too Fast")
+	}
+	defer func() {
+		utility.ReleaseLock(ctx, lockKey)
+	}()
+	invoice, err := applyLine(ctx, line, invoiceId, "BankStatementReview")
+	if err != nil {
+		return nil, nil, err
+	}
+	_, err = dao.BankStatementLine.Ctx(ctx).Data(g.Map{
+		dao.BankStatementLine.Columns().Status:    consts.BankStatementLineManualMatched,
+		dao.BankStatementLine.Columns().InvoiceId: invoiceId,
+		dao.BankStatementLine.Columns().GmtModify: gtime.Now(),
+	}).Where(dao.BankStatementLine.Columns().Id, line.Id).Update()
+	operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
+		MerchantId: merchantId,
+		Target:     fmt.Sprintf("BankStatementLine(%v)", line.Id),
+		Content:    "Confirm",
+		UserId:     invoice.UserId,
+		InvoiceId:  invoice.InvoiceId,
+	}, err)
+	if err != nil {
+		return nil, nil, err
+	}
+	return query.GetBankStatementLineById(ctx, line.Id), invoice, nil
+}
+
+// IgnoreLine removes the credit from the review queue, like the credit not paying any invoice
+func IgnoreLine(ctx context.Context, merchantId uint64, lineId uint64, note string) (*entity.BankStatementLine, error) {
+	utility.Assert(merchantId > 0, "invalid merchantId")
+	line := query.GetBankStatementLineById(ctx, lineId)
+	utility.Assert(line != nil, "bank statement line not found")
+	utility.Assert(line.MerchantId == merchantId, "wrong merchant account")
+	utility.Assert(line.Status == consts.BankStatementLineNeedReview || line.Status == consts.BankStatementLineUnmatched, "bank statement line is "+consts.BankStatementLineStatusEnum(line.Status).Description())
+	_, err := dao.BankStatementLine.Ctx(ctx).Data(g.Map{
+		dao.BankStatementLine.Columns().Status:    consts.BankStatementLineIgnored,
+		dao.BankStatementLine.Columns().Note:      note,
+		dao.BankStatementLine.Columns().GmtModify: gtime.Now(),
+	}).Where(dao.BankStatementLine.Columns().Id, line.Id).Update()
+	operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
+		MerchantId: merchantId,
+		Target:     fmt.Sprintf("BankStatementLine(%v)", line.Id),
+		Content:    "Ignore",
+	}, err)
+	if err != nil {
+		return nil, err
+	}
+	return query.GetBankStatementLineById(ctx, line.Id), nil
+}
+
+// applyLine pays the invoice with the credit, the whole invoice by transfer or a partial payment
+func applyLine(ctx context.Context, line *entity.BankStatementLine, invoiceId string, source string) (*entity.Invoice, error) {
+	invoice := query.GetInvoiceByInvoiceId(ctx, invoiceId)
+	utility.Assert(invoice != nil, "invoice not found")
+	utility.Assert(invoice.MerchantId == line.MerchantId, "wrong merchant account")
+	utility.Assert(invoice.Status == consts.InvoiceStatusProcessing, "invoice not process status, InvoiceId:"+invoice.InvoiceId)
+	utility.Assert(strings.EqualFold(invoice.Currency, line.Currency), "currency of the credit not match the invoice's currency")
+	outstanding := receivable.OutstandingAmount(invoice)
+	if line.Amount > outstanding {
+		return nil, gerror.Newf("credit amount %s exceeds the outstanding amount %s of the invoice", utility.ConvertCentToDollarStr(line.Amount, line.Currency), utility.ConvertCentToDollarStr(outstanding, invoice.Currency))
+	}
+	transferNumber := line.BankReference
+	if len(transferNumber) == 0 {
+		transferNumber = fmt.Sprintf("BankStatementLine(%d)", line.Id)
+	}
+	reason := fmt.Sprintf("BankStatement(%d) %s", line.StatementId, line.Remittance)
+	if invoice.PaidAmount == 0 && line.Amount == invoice.TotalAmount {
+		return service.MarkInvoiceAsPaidByTransfer(ctx, invoice, transferNumber, reason, source)
+	}
+	_, invoice, err := receivable.ApplyPartialPayment(ctx, &receivable.PartialPaymentInternalReq{
+		MerchantId:     line.MerchantId,
+		InvoiceId:      invoice.InvoiceId,
+		Amount:         line.Amount,
+		Currency:       line.Currency,
+		TransferNumber: transferNumber,
+		Reason:         reason,
+		PaidTime:       line.BookingTime,
+	})
+	return invoice, err
+}
+
+// loadMatchInvoices loads the open receivable wire transfer and net term invoices of the merchant with the names of their customers,
+// the invoices collected by the card or wallet gateways are never paid by a bank transfer
+func loadMatchInvoices(ctx context.Context, merchantId uint64) ([]*MatchInvoice, error) {
+	list, err := receivable.GetOpenInvoices(ctx, merchantId, "", 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	var gateways = make(map[uint64]*entity.MerchantGateway)
+	var userNames = make(map[uint64][]string)
+	var invoices = make([]*MatchInvoice, 0)
+	for _, one := range list {
+		if !receivable.IsReceivable(one) {
+			continue
+		}
+		gateway, ok := gateways[one.GatewayId]
+		if !ok {
+			gateway = query.GetGatewayById(ctx, one.GatewayId)
+			gateways[one.GatewayId] = gateway
+		}
+		if !receivable.IsPartialPayable(one, gateway) {
+			continue
+		}
+		if _, ok := userNames[one.UserId]; !ok {
+			var names = make([]string, 0)
+			if user := query.GetUserAccountById(ctx, one.UserId); user != nil {
+				names = append(names, user.CompanyName, strings.TrimSpace(fmt.Sprintf("%s %s", user.FirstName, user.LastName)))
+			}
+			userNames[one.UserId] = names
+		}
+		invoices = append(invoices, &MatchInvoice{
+			InvoiceId:         one.InvoiceId,
+			InvoiceNumber:     invoice_number.DisplayNumber(ctx, one),
+			Currency:          one.Currency,
+			OutstandingAmount: receivable.OutstandingAmount(one),
+			CustomerNames:     userNames[one.UserId],
+		})
+	}
+	return invoices, nil
+}
+
+type LineListInternalReq struct {
+	MerchantId  uint64 `json:"merchantId"`
+	StatementId uint64 `json:"statementId"`
+	Status      []int  `json:"status"`
+	Page        int    `json:"page"`
+	Count       int    `json:"count"`
+}
+
+// LineList returns the credit lines of the merchant, the review queue is the lines with status need review
+func LineList(ctx context.Context, req *LineListInternalReq) ([]*bean.BankStatementLine, int, error) {
+	if req.Count <= 0 {
+		req.Count = 20
+	}
+	if req.Page < 0 {
+		req.Page = 0
+	}
+	var list []*entity.BankStatementLine
+	var total = 0
+	q := dao.BankStatementLine.Ctx(ctx).
+		Where(dao.BankStatementLine.Columns().MerchantId, req.MerchantId).
+		Where(dao.BankStatementLine.Columns().IsDeleted, 0)
+	if req.StatementId > 0 {
+		q = q.Where(dao.BankStatementLine.Columns().StatementId, req.StatementId)
+	}
+	if len(req.Status) > 0 {
+		q = q.WhereIn(dao.BankStatementLine.Columns().Status, req.Status)
+	}
+	err := q.OrderDesc(dao.BankStatementLine.Columns().Id).
+		Limit(req.Page*req.Count, req.Count).
+		ScanAndCount(&list, &total, true)
+	if err != nil {
+		return nil, 0, err
+	}
+	var result = make([]*bean.BankStatementLine, 0)
+	for _, one := range list {
+		result = append(result, bean.SimplifyBankStatementLine(one))
+	}
+	return result, total, nil
+}
+
+// List returns the imported bank statements of the merchant
+func List(ctx context.Context, merchantId uint64, page int, count int) ([]*bean.BankStatement, int, error) {
+	if count <= 0 {
+		count = 20
+	}
+	if page < 0 {
+		page = 0
+	}
+	var list []*entity.BankStatement
+	var total = 0
+	err := dao.BankStatement.Ctx(ctx).
+		Where(dao.BankStatement.Columns().MerchantId, merchantId).
+		Where(dao.BankStatement.Columns().IsDeleted, 0).
+		OrderDesc(dao.BankStatement.Columns().Id).
+		Limit(page*count, count).
+		ScanAndCount(&list, &total, true)
+	if err != nil {
+		return nil, 0, err
+	}
+	var result = make([]*bean.BankStatement, 0)
+	for _, one := range list {
+		result = append(result, bean.SimplifyBankStatement(one))
+	}
+	return result, total, nil
+}
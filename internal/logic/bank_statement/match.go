@@ -0,0 +1,173 @@
+package bank_statement
+
+import (
+	"sort"
+	"strings"
+
+	"unibee/api/bean"
+	"unibee/internal/consts"
+	"unibee/internal/logic/bank_statement/parser"
+)
+
+const (
+	matchScoreCurrency      = 10
+	matchScoreReference     = 50
+	matchScoreAmount        = 30
+	matchScorePartialAmount = 15
+	matchScoreName          = 10
+	// the lines with candidates scored below are unmatched, currency and amount only is just enough for review
+	matchReviewThreshold = matchScoreCurrency + matchScoreAmount
+	matchCandidateLimit  = 5
+)
+
+const (
+	MatchReasonReference     = "reference"
+	MatchReasonAmount        = "amount"
+	MatchReasonPartialAmount = "partial_amount"
+	MatchReasonCurrency      = "currency"
+	MatchReasonName          = "name"
+)
+
+// MatchInvoice is the processing invoice the credits are matched against
+type MatchInvoice struct {
+	InvoiceId         string
+	InvoiceNumber     string
+	Currency          string
+	OutstandingAmount int64
+	CustomerNames     []string
+}
+
+// ScoreLine scores how likely the credit pays the invoice, nil when the currency differs
+func ScoreLine(line *parser.Line, invoice *MatchInvoice) *bean.BankStatementMatchCandidate {
+	if line == nil || invoice == nil || invoice.OutstandingAmount <= 0 || !strings.EqualFold(line.Currency, invoice.Currency) {
+		return nil
+	}
+	candidate := &bean.BankStatementMatchCandidate{
+		InvoiceId:         invoice.InvoiceId,
+		InvoiceNumber:     invoice.InvoiceNumber,
+		Currency:          strings.ToUpper(invoice.Currency),
+		OutstandingAmount: invoice.OutstandingAmount,
+		Confidence:        matchScoreCurrency,
+		Reasons:           []string{MatchReasonCurrency},
+	}
+	var referenceMatched = false
+	for _, reference := range []string{invoice.InvoiceId, invoice.InvoiceNumber} {
+		reference = normalizeReference(reference)
+		if len(reference) >= 4 && containsReference(line.Remittance, reference) {
+			referenceMatched = true
+			break
+		}
+	}
+	if referenceMatched {
+		candidate.Confidence = candidate.Confidence + matchScoreReference
+		candidate.Reasons = append(candidate.Reasons, MatchReasonReference)
+	}
+	if line.Amount == invoice.OutstandingAmount {
+		candidate.Confidence = candidate.Confidence + matchScoreAmount
+		candidate.Reasons = append(candidate.Reasons, MatchReasonAmount)
+	} else if referenceMatched && line.Amount < invoice.OutstandingAmount {
+		candidate.Confidence = candidate.Confidence + matchScorePartialAmount
+		candidate.Reasons = append(candidate.Reasons, MatchReasonPartialAmount)
+	}
+	counterparty := normalizeReference(line.CounterpartyName)
+	for _, name := range invoice.CustomerNames {
+		name = normalizeReference(name)
+		if len(name) >= 3 && len(counterparty) >= 3 && (strings.Contains(counterparty, name) || strings.Contains(name, counterparty)) {
+			candidate.Confidence = candidate.Confidence + matchScoreName
+			candidate.Reasons = append(candidate.Reasons, MatchReasonName)
+			break
+		}
+	}
+	return candidate
+}
+
+// IsExactMatch returns true when the credit carries the invoice reference, the outstanding amount and the currency of the invoice
+func IsExactMatch(candidate *bean.BankStatementMatchCandidate) bool {
+	if candidate == nil {
+		return false
+	}
+	var reference, amount = false, false
+	for _, reason := range candidate.Reasons {
+		if reason == MatchReasonReference {
+			reference = true
+		} else if reason == MatchReasonAmount {
+			amount = true
+		}
+	}
+	return reference && amount
+}
+
+// MatchLine matches the credit against the invoices, returns the line status and the candidates sorted by confidence,
+// the credit is auto matched only when exactly one invoice matches exactly
+func MatchLine(line *parser.Line, invoices []*MatchInvoice) (int, []*bean.BankStatementMatchCandidate) {
+	var candidates = make([]*bean.BankStatementMatchCandidate, 0)
+	for _, invoice := range invoices {
+		if candidate := ScoreLine(line, invoice); candidate != nil && candidate.Confidence >= matchReviewThreshold {
+			candidates = append(candidates, candidate)
+		}
+	}
+	if len(candidates) == 0 {
+		return consts.BankStatementLineUnmatched, candidates
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Confidence > candidates[j].Confidence
+	})
+	if len(candidates) > matchCandidateLimit {
+		candidates = candidates[:matchCandidateLimit]
+	}
+	var exactCount = 0
+	for _, candidate := range candidates {
+		if IsExactMatch(candidate) {
+			exactCount++
+		}
+	}
+	if exactCount == 1 && IsExactMatch(candidates[0]) {
+		return consts.BankStatementLineAutoMatched, candidates
+	}
+	return consts.BankStatementLineNeedReview, candidates
+}
+
+// normalizeReference keeps the letters and digits in upper case, references are written with various separators in remittance
+func normalizeReference(value string) string {
+	var builder strings.Builder
+	for _, r := range strings.ToUpper(value) {
+		if isAlphanumeric(r) {
+			builder.WriteRune(r)
+		}
+	}
+	return builder.String()
+}
+
+func isAlphanumeric(r rune) bool {
+	return (r >= 'A' && r <= 'Z') || (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9')
+}
+
+// containsReference returns true when the remittance carries the normalized reference as a whole token,
+// the separators inside the reference are ignored and a non-alphanumeric character or the edge is needed on both sides,
+// INV-0001 is found in "Payment inv 0001" but not in "INV-00012"
+func containsReference(remittance string, reference string) bool {
+	if len(reference) == 0 {
+		return false
+	}
+	runes := []rune(strings.ToUpper(remittance))
+	var normalized []rune
+	var positions []int
+	for i, r := range runes {
+		if isAlphanumeric(r) {
+			normalized = append(normalized, r)
+			positions = append(positions, i)
+		}
+	}
+	target := []rune(reference)
+	for start := 0; start+len(target) <= len(normalized); start++ {
+		if string(normalized[start:start+len(target)]) != reference {
+			continue
+		}
+		first := positions[start]
+		last := positions[start+len(target)-1]
+		if (first == 0 || !isAlphanumeric(runes[first-1])) && (last == len(runes)-1 || !isAlphanumeric(runes[last+1])) {
+			return true
+		}
+	}
+	return false
+}
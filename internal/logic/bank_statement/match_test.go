@@ -0,0 +1,101 @@
+package bank_statement
+
+import (
+	"testing"
+
+	"unibee/internal/consts"
+	"unibee/internal/logic/bank_statement/parser"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testMatchInvoices() []*MatchInvoice {
+	return []*MatchInvoice{
+		{InvoiceId: "81712345678", InvoiceNumber: "INV-0001", Currency: "EUR", OutstandingAmount: 11900, CustomerNames: []string{"ACME GmbH", "John Smith"}},
+		{InvoiceId: "81712345679", InvoiceNumber: "INV-0002", Currency: "EUR", OutstandingAmount: 5000, CustomerNames: []string{"", "Jane Doe"}},
+		{InvoiceId: "81712345680", InvoiceNumber: "INV-0003", Currency: "EUR", OutstandingAmount: 5000, CustomerNames: []string{"Other Ltd"}},
+		{InvoiceId: "81712345681", InvoiceNumber: "INV-0004", Currency: "USD", OutstandingAmount: 11900},
+	}
+}
+
+func TestScoreLine(t *testing.T) {
+	invoices := testMatchInvoices()
+	t.Run("exact by invoice number", func(t *testing.T) {
+		candidate := ScoreLine(&parser.Line{Currency: "eur", Amount: 11900, Remittance: "Payment inv 0001", CounterpartyName: "ACME GMBH"}, invoices[0])
+		require.NotNil(t, candidate)
+		require.Equal(t, 100, candidate.Confidence)
+		require.Equal(t, []string{MatchReasonCurrency, MatchReasonReference, MatchReasonAmount, MatchReasonName}, candidate.Reasons)
+		require.True(t, IsExactMatch(candidate))
+	})
+	t.Run("partial amount by invoice id", func(t *testing.T) {
+		candidate := ScoreLine(&parser.Line{Currency: "EUR", Amount: 5000, Remittance: "817-1234-5678"}, invoices[0])
+		require.Equal(t, matchScoreCurrency+matchScoreReference+matchScorePartialAmount, candidate.Confidence)
+		require.False(t, IsExactMatch(candidate))
+	})
+	t.Run("amount only", func(t *testing.T) {
+		candidate := ScoreLine(&parser.Line{Currency: "EUR", Amount: 5000, Remittance: "thanks"}, invoices[1])
+		require.Equal(t, matchReviewThreshold, candidate.Confidence)
+		require.False(t, IsExactMatch(candidate))
+	})
+	t.Run("reference inside a longer token", func(t *testing.T) {
+		candidate := ScoreLine(&parser.Line{Currency: "EUR", Amount: 5000, Remittance: "INV-00012"}, invoices[0])
+		require.NotNil(t, candidate)
+		require.NotContains(t, candidate.Reasons, MatchReasonReference)
+		candidate = ScoreLine(&parser.Line{Currency: "EUR", Amount: 5000, Remittance: "XINV0001"}, invoices[0])
+		require.NotContains(t, candidate.Reasons, MatchReasonReference)
+		candidate = ScoreLine(&parser.Line{Currency: "EUR", Amount: 5000, Remittance: "ref 9817-1234-5678"}, invoices[0])
+		require.NotContains(t, candidate.Reasons, MatchReasonReference)
+	})
+	t.Run("currency mismatch", func(t *testing.T) {
+		require.Nil(t, ScoreLine(&parser.Line{Currency: "EUR", Amount: 11900, Remittance: "INV-0004"}, invoices[3]))
+	})
+}
+
+func TestContainsReference(t *testing.T) {
+	require.True(t, containsReference("INV-0001", "INV0001"))
+	require.True(t, containsReference("Payment inv 0001, thanks", "INV0001"))
+	require.True(t, containsReference("INV-0002 INV-0003", "INV0003"))
+	require.True(t, containsReference("(INV-0001)", "INV0001"))
+	require.False(t, containsReference("INV-00012", "INV0001"))
+	require.False(t, containsReference("INV-00012 XINV0001", "INV0001"))
+	require.True(t, containsReference("INV-00012 INV-0001", "INV0001"))
+	require.False(t, containsReference("INV-0001", ""))
+}
+
+func TestMatchLine(t *testing.T) {
+	invoices := testMatchInvoices()
+	t.Run("auto matched", func(t *testing.T) {
+		status, candidates := MatchLine(&parser.Line{Currency: "EUR", Amount: 11900, Remittance: "INV-0001"}, invoices)
+		require.Equal(t, consts.BankStatementLineAutoMatched, status)
+		require.Equal(t, "81712345678", candidates[0].InvoiceId)
+	})
+	t.Run("ambiguous amount only", func(t *testing.T) {
+		status, candidates := MatchLine(&parser.Line{Currency: "EUR", Amount: 5000, Remittance: "payment", CounterpartyName: "Jane Doe"}, invoices)
+		require.Equal(t, consts.BankStatementLineNeedReview, status)
+		require.Equal(t, 2, len(candidates))
+		// name breaks the tie
+		require.Equal(t, "81712345679", candidates[0].InvoiceId)
+	})
+	t.Run("reference with wrong amount", func(t *testing.T) {
+		status, candidates := MatchLine(&parser.Line{Currency: "EUR", Amount: 20000, Remittance: "INV-0001"}, invoices)
+		require.Equal(t, consts.BankStatementLineNeedReview, status)
+		require.Equal(t, 1, len(candidates))
+	})
+	t.Run("two exact matches", func(t *testing.T) {
+		status, _ := MatchLine(&parser.Line{Currency: "EUR", Amount: 5000, Remittance: "INV-0002 INV-0003"}, invoices)
+		require.Equal(t, consts.BankStatementLineNeedReview, status)
+	})
+	t.Run("unmatched", func(t *testing.T) {
+		status, candidates := MatchLine(&parser.Line{Currency: "EUR", Amount: 123, Remittance: "rent"}, invoices)
+		require.Equal(t, consts.BankStatementLineUnmatched, status)
+		require.Equal(t, 0, len(candidates))
+		status, _ = MatchLine(&parser.Line{Currency: "GBP", Amount: 11900, Remittance: "INV-0001"}, invoices)
+		require.Equal(t, consts.BankStatementLineUnmatched, status)
+	})
+	t.Run("paid invoice not matched", func(t *testing.T) {
+		paid := testMatchInvoices()
+		paid[0].OutstandingAmount = 0
+		status, _ := MatchLine(&parser.Line{Currency: "EUR", Amount: 11900, Remittance: "INV-0001"}, paid)
+		require.Equal(t, consts.BankStatementLineUnmatched, status)
+	})
+}
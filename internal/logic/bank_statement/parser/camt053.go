@@ -0,0 +1,116 @@
+package parser
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+)
+
+type camtDocument struct {
+	Statements []*camtStatement `xml:"BkToCstmrStmt>Stmt"`
+}
+
+type camtStatement struct {
+	Id      string       `xml:"Id"`
+	Iban    string       `xml:"Acct>Id>IBAN"`
+	Other   string       `xml:"Acct>Id>Othr>Id"`
+	Ccy     string       `xml:"Acct>Ccy"`
+	Entries []*camtEntry `xml:"Ntry"`
+}
+
+type camtAmount struct {
+	Ccy   string `xml:"Ccy,attr"`
+	Value string `xml:",chardata"`
+}
+
+type camtEntry struct {
+	NtryRef     string           `xml:"NtryRef"`
+	Amt         camtAmount       `xml:"Amt"`
+	CdtDbtInd   string           `xml:"CdtDbtInd"`
+	RvslInd     bool             `xml:"RvslInd"`
+	BookingDate string           `xml:"BookgDt>Dt"`
+	BookingTime string           `xml:"BookgDt>DtTm"`
+	AcctSvcrRef string           `xml:"AcctSvcrRef"`
+	Details     []*camtTxDetails `xml:"NtryDtls>TxDtls"`
+	AddtlInf    string           `xml:"AddtlNtryInf"`
+}
+
+type camtTxDetails struct {
+	AcctSvcrRef string      `xml:"Refs>AcctSvcrRef"`
+	EndToEndId  string      `xml:"Refs>EndToEndId"`
+	Amt         *camtAmount `xml:"Amt"`
+	DebtorName  string      `xml:"RltdPties>Dbtr>Nm"`
+	DebtorIban  string      `xml:"RltdPties>DbtrAcct>Id>IBAN"`
+	DebtorOther string      `xml:"RltdPties>DbtrAcct>Id>Othr>Id"`
+	Ustrd       []string    `xml:"RmtInf>Ustrd"`
+	StrdRef     []string    `xml:"RmtInf>Strd>CdtrRefInf>Ref"`
+}
+
+// ParseCamt053 parses the ISO 20022 camt.053 bank to customer statement, one line per credit transaction
+func ParseCamt053(data []byte) (*Statement, error) {
+	var document camtDocument
+	if err := xml.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("invalid camt.053 file:%s", err.Error())
+	}
+	if len(document.Statements) == 0 {
+		return nil, fmt.Errorf("invalid camt.053 file, no statement found")
+	}
+	statement := &Statement{
+		StatementId: strings.TrimSpace(document.Statements[0].Id),
+		Account:     firstNonBlank(document.Statements[0].Iban, document.Statements[0].Other),
+		Currency:    strings.ToUpper(strings.TrimSpace(document.Statements[0].Ccy)),
+		Lines:       make([]*Line, 0),
+	}
+	for _, stmt := range document.Statements {
+		for _, entry := range stmt.Entries {
+			credit := strings.ToUpper(strings.TrimSpace(entry.CdtDbtInd)) == "CRDT"
+			if credit == entry.RvslInd {
+				// debit, or reversal of a credit
+				continue
+			}
+			bookingTime, err := parseDate(firstNonBlank(entry.BookingTime, entry.BookingDate))
+			if err != nil {
+				return nil, err
+			}
+			details := entry.Details
+			if len(details) == 0 {
+				details = []*camtTxDetails{{}}
+			}
+			for _, detail := range details {
+				amount := entry.Amt
+				if len(details) > 1 && detail.Amt != nil {
+					// batch booking, each transaction credited separately
+					amount = *detail.Amt
+				}
+				currency := strings.ToUpper(strings.TrimSpace(firstNonBlank(amount.Ccy, stmt.Ccy)))
+				cent, err := parseAmount(amount.Value, currency)
+				if err != nil {
+					return nil, fmt.Errorf("invalid amount of entry %s:%s", entry.NtryRef, err.Error())
+				}
+				var remittance = make([]string, 0)
+				remittance = append(remittance, detail.StrdRef...)
+				remittance = append(remittance, detail.Ustrd...)
+				if len(remittance) == 0 && len(entry.AddtlInf) > 0 {
+					remittance = []string{entry.AddtlInf}
+				}
+				reference := firstNonBlank(detail.AcctSvcrRef, detail.EndToEndId)
+				if len(details) == 1 {
+					reference = firstNonBlank(entry.AcctSvcrRef, entry.NtryRef, reference)
+				}
+				if strings.ToUpper(reference) == "NOTPROVIDED" {
+					reference = ""
+				}
+				statement.Lines = append(statement.Lines, &Line{
+					BankReference:       reference,
+					BookingTime:         bookingTime,
+					Currency:            currency,
+					Amount:              cent,
+					CounterpartyName:    strings.TrimSpace(detail.DebtorName),
+					CounterpartyAccount: firstNonBlank(detail.DebtorIban, detail.DebtorOther),
+					Remittance:          strings.TrimSpace(strings.Join(remittance, " ")),
+				})
+			}
+		}
+	}
+	return statement, nil
+}
@@ -0,0 +1,119 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strings"
+)
+
+// csvColumnAliases the accepted header names of each column, compared in lower case without blanks
+var csvColumnAliases = map[string][]string{
+	"reference":           {"transactionid", "bankreference", "referencenumber", "id"},
+	"date":                {"bookingdate", "date", "valuedate", "transactiondate", "bookingtime"},
+	"amount":              {"amount", "credit", "creditamount"},
+	"currency":            {"currency", "ccy"},
+	"remittance":          {"remittance", "reference", "description", "details", "purpose", "memo", "remittanceinformation"},
+	"counterpartyName":    {"counterpartyname", "counterparty", "name", "payer", "payername", "debtor", "debtorname"},
+	"counterpartyAccount": {"counterpartyaccount", "iban", "account", "payeraccount", "debtoraccount"},
+	"type":                {"type", "creditdebit", "cdtdbtind", "direction"},
+}
+
+// ParseCsv parses the statement exported as csv, the first row is the header, comma or semicolon separated,
+// the rows with positive amount are credits, the rows typed as debit are skipped
+func ParseCsv(data []byte) (*Statement, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comma = detectCsvSeparator(data)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("invalid csv file:%s", err.Error())
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("invalid csv file, header row needed")
+	}
+	columns := mapCsvColumns(records[0])
+	if _, ok := columns["amount"]; !ok {
+		return nil, fmt.Errorf("invalid csv file, amount column needed")
+	}
+	if _, ok := columns["currency"]; !ok {
+		return nil, fmt.Errorf("invalid csv file, currency column needed")
+	}
+	statement := &Statement{Lines: make([]*Line, 0)}
+	for i, record := range records[1:] {
+		value := func(column string) string {
+			if index, ok := columns[column]; ok && index < len(record) {
+				return strings.TrimSpace(record[index])
+			}
+			return ""
+		}
+		if len(value("amount")) == 0 {
+			continue
+		}
+		direction := strings.ToLower(value("type"))
+		if direction == "d" || direction == "dbit" || direction == "debit" {
+			continue
+		}
+		currency := strings.ToUpper(value("currency"))
+		amount, err := parseAmount(value("amount"), currency)
+		if err != nil {
+			return nil, fmt.Errorf("invalid amount of row %d:%s", i+2, err.Error())
+		}
+		if amount <= 0 {
+			continue
+		}
+		bookingTime, err := parseDate(value("date"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid date of row %d:%s", i+2, err.Error())
+		}
+		if len(statement.Currency) == 0 {
+			statement.Currency = currency
+		}
+		statement.Lines = append(statement.Lines, &Line{
+			BankReference:       value("reference"),
+			BookingTime:         bookingTime,
+			Currency:            currency,
+			Amount:              amount,
+			CounterpartyName:    value("counterpartyName"),
+			CounterpartyAccount: value("counterpartyAccount"),
+			Remittance:          value("remittance"),
+		})
+	}
+	return statement, nil
+}
+
+func detectCsvSeparator(data []byte) rune {
+	header := data
+	if end := bytes.IndexByte(data, '\n'); end >= 0 {
+		header = data[:end]
+	}
+	if bytes.Count(header, []byte(";")) > bytes.Count(header, []byte(",")) {
+		return ';'
+	}
+	return ','
+}
+
+// mapCsvColumns maps the columns to their index, the first matched alias in the alias order wins
+func mapCsvColumns(header []string) map[string]int {
+	var headerIndex = make(map[string]int)
+	for i, name := range header {
+		key := strings.ToLower(strings.NewReplacer(" ", "", "_", "", "-", "", "/", "").Replace(strings.TrimSpace(name)))
+		if _, ok := headerIndex[key]; !ok {
+			headerIndex[key] = i
+		}
+	}
+	var columns = make(map[string]int)
+	var used = make(map[int]bool)
+	for _, column := range []string{"reference", "date", "amount", "currency", "counterpartyName", "counterpartyAccount", "type", "remittance"} {
+		for _, alias := range csvColumnAliases[column] {
+			if index, ok := headerIndex[alias]; ok && !used[index] {
+				columns[column] = index
+				used[index] = true
+				break
+			}
+		}
+	}
+	return columns
+}
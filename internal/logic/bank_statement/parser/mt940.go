@@ -0,0 +1,146 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// mt940StatementLine matches the :61: field, value date, optional entry date, mark, optional funds code, amount, transaction type, customer reference and bank reference
+var mt940StatementLine = regexp.MustCompile(`^(\d{6})(\d{4})?(RC|RD|C|D)([A-Z])?([\d,]+)([NFS][A-Z0-9]{3})([^/]*)(?://(.*))?$`)
+
+// mt940Balance matches the :60F: or :60M: field, mark, date, currency and amount
+var mt940Balance = regexp.MustCompile(`^[CD](\d{6})([A-Z]{3})([\d,]+)$`)
+
+type mt940Field struct {
+	Tag   string
+	Value string
+}
+
+// ParseMT940 parses the SWIFT MT940 customer statement, one line per credit of the :61: fields
+func ParseMT940(data []byte) (*Statement, error) {
+	fields := splitMT940Fields(string(data))
+	statement := &Statement{Lines: make([]*Line, 0)}
+	var current *Line
+	var valid = false
+	for _, field := range fields {
+		switch field.Tag {
+		case "20":
+			if len(statement.StatementId) == 0 {
+				statement.StatementId = strings.TrimSpace(field.Value)
+			}
+		case "25":
+			if len(statement.Account) == 0 {
+				statement.Account = strings.TrimSpace(field.Value)
+			}
+		case "28C":
+			if len(statement.StatementId) == 0 {
+				statement.StatementId = strings.TrimSpace(field.Value)
+			}
+		case "60F", "60M":
+			valid = true
+			if match := mt940Balance.FindStringSubmatch(strings.TrimSpace(field.Value)); match != nil && len(statement.Currency) == 0 {
+				statement.Currency = match[2]
+			}
+		case "61":
+			valid = true
+			current = nil
+			value := strings.SplitN(strings.TrimSpace(field.Value), "\n", 2)
+			match := mt940StatementLine.FindStringSubmatch(strings.TrimSpace(value[0]))
+			if match == nil {
+				return nil, fmt.Errorf("invalid mt940 statement line:%s", value[0])
+			}
+			if match[3] != "C" && match[3] != "RD" {
+				// debit, or reversal of a credit
+				continue
+			}
+			bookingTime, err := parseDate(match[1])
+			if err != nil {
+				return nil, err
+			}
+			amount, err := parseAmount(match[5], statement.Currency)
+			if err != nil {
+				return nil, fmt.Errorf("invalid amount of statement line %s:%s", value[0], err.Error())
+			}
+			reference := strings.TrimSpace(match[8])
+			if len(reference) == 0 && strings.ToUpper(strings.TrimSpace(match[7])) != "NONREF" {
+				reference = strings.TrimSpace(match[7])
+			}
+			current = &Line{
+				BankReference: reference,
+				BookingTime:   bookingTime,
+				Currency:      statement.Currency,
+				Amount:        amount,
+			}
+			if len(value) > 1 {
+				current.Remittance = strings.TrimSpace(value[1])
+			}
+			statement.Lines = append(statement.Lines, current)
+		case "86":
+			if current == nil {
+				continue
+			}
+			name, account, remittance := parseMT940Information(field.Value)
+			current.CounterpartyName = name
+			current.CounterpartyAccount = account
+			current.Remittance = strings.TrimSpace(strings.Join([]string{current.Remittance, remittance}, " "))
+			current = nil
+		}
+	}
+	if !valid {
+		return nil, fmt.Errorf("invalid mt940 file, no balance or statement line found")
+	}
+	return statement, nil
+}
+
+// splitMT940Fields splits the statement into tagged fields, the lines not starting with a tag continue the previous field
+func splitMT940Fields(content string) []*mt940Field {
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	var fields = make([]*mt940Field, 0)
+	var current *mt940Field
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimRight(line, " ")
+		if strings.HasPrefix(trimmed, "{") || trimmed == "-}" || trimmed == "-" {
+			// swift message block header and trailer
+			current = nil
+			continue
+		}
+		if strings.HasPrefix(trimmed, ":") {
+			if end := strings.Index(trimmed[1:], ":"); end > 0 {
+				current = &mt940Field{Tag: trimmed[1 : end+1], Value: trimmed[end+2:]}
+				fields = append(fields, current)
+				continue
+			}
+		}
+		if current != nil {
+			current.Value = current.Value + "\n" + trimmed
+		}
+	}
+	return fields
+}
+
+// parseMT940Information parses the :86: field, the structured ?nn sub fields used by german banks are supported,
+// returns the counterparty name, account and remittance information
+func parseMT940Information(value string) (name string, account string, remittance string) {
+	if !strings.Contains(value, "?") {
+		return "", "", strings.Join(strings.Fields(value), " ")
+	}
+	value = strings.ReplaceAll(value, "\n", "")
+	var names = make([]string, 0)
+	var remittances = make([]string, 0)
+	for _, part := range strings.Split(value, "?")[1:] {
+		if len(part) < 2 {
+			continue
+		}
+		code, text := part[:2], strings.TrimSpace(part[2:])
+		switch {
+		case code >= "20" && code <= "29", code >= "60" && code <= "63":
+			remittances = append(remittances, text)
+		case code == "31":
+			account = text
+		case code == "32" || code == "33":
+			names = append(names, text)
+		}
+	}
+	return strings.TrimSpace(strings.Join(names, "")), account, strings.TrimSpace(strings.Join(remittances, ""))
+}
@@ -0,0 +1,149 @@
+package parser
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"unibee/internal/consts"
+	"unibee/utility"
+)
+
+// Statement is the bank statement parsed from the file, only the credit entries are kept in lines
+type Statement struct {
+	Format      string
+	StatementId string
+	Account     string
+	Currency    string
+	Lines       []*Line
+}
+
+// Line is one incoming credit of the statement, amount in cent
+type Line struct {
+	Key                 string
+	BankReference       string
+	BookingTime         int64
+	Currency            string
+	Amount              int64
+	CounterpartyName    string
+	CounterpartyAccount string
+	Remittance          string
+}
+
+// DetectFormat detects the statement format by the file name and the content, camt053|mt940|csv
+func DetectFormat(fileName string, data []byte) string {
+	name := strings.ToLower(fileName)
+	content := bytes.TrimSpace(data)
+	switch {
+	case strings.HasSuffix(name, ".xml") || bytes.HasPrefix(content, []byte("<")):
+		return consts.BankStatementFormatCamt053
+	case strings.HasSuffix(name, ".sta") || strings.HasSuffix(name, ".mt940") || strings.HasSuffix(name, ".940") ||
+		bytes.Contains(content, []byte(":61:")) || bytes.HasPrefix(content, []byte("{1:")):
+		return consts.BankStatementFormatMT940
+	default:
+		return consts.BankStatementFormatCsv
+	}
+}
+
+// Parse parses the statement file in the format, the format is detected if blank
+func Parse(fileName string, format string, data []byte) (*Statement, error) {
+	format = strings.ToLower(strings.TrimSpace(format))
+	if len(format) == 0 {
+		format = DetectFormat(fileName, data)
+	}
+	var statement *Statement
+	var err error
+	switch format {
+	case consts.BankStatementFormatCamt053:
+		statement, err = ParseCamt053(data)
+	case consts.BankStatementFormatMT940:
+		statement, err = ParseMT940(data)
+	case consts.BankStatementFormatCsv:
+		statement, err = ParseCsv(data)
+	default:
+		return nil, fmt.Errorf("format not supported:%s, camt053|mt940|csv", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	statement.Format = format
+	AssignLineKeys(statement)
+	return statement, nil
+}
+
+// AssignLineKeys gives each credit a key stable across imports of the same statement,
+// the bank reference is used when given, otherwise the content of the credit with its occurrence
+func AssignLineKeys(statement *Statement) {
+	var occurrences = make(map[string]int)
+	for _, line := range statement.Lines {
+		if len(line.BankReference) > 0 {
+			line.Key = utility.MD5(fmt.Sprintf("%s|%s", statement.Account, line.BankReference))
+			continue
+		}
+		content := fmt.Sprintf("%s|%d|%s|%d|%s|%s", statement.Account, line.BookingTime, line.Currency, line.Amount, line.CounterpartyAccount, line.Remittance)
+		occurrences[content] = occurrences[content] + 1
+		line.Key = utility.MD5(fmt.Sprintf("%s#%d", content, occurrences[content]))
+	}
+}
+
+// parseAmount converts the decimal amount to cent, both dot and comma decimal separators accepted
+func parseAmount(value string, currency string) (int64, error) {
+	value = strings.TrimSpace(strings.ReplaceAll(value, " ", ""))
+	if len(value) == 0 {
+		return 0, fmt.Errorf("amount is blank")
+	}
+	// the last separator is the decimal one only when 1 or 2 digits follow it, like 1.234,56 or 1,234.5,
+	// otherwise all separators group thousands, like 1,234 or 1.234.567
+	separators := strings.NewReplacer(".", "", ",", "")
+	last := strings.LastIndexAny(value, ".,")
+	if decimals := len(value) - last - 1; last >= 0 && decimals >= 1 && decimals <= 2 {
+		value = separators.Replace(value[:last]) + "." + value[last+1:]
+	} else {
+		value = separators.Replace(value)
+	}
+	amount, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, err
+	}
+	if utility.IsNoCentCurrency(currency) {
+		return int64(math.Round(amount)), nil
+	}
+	return int64(math.Round(amount * 100)), nil
+}
+
+var dateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+	"02.01.2006",
+	"02/01/2006",
+	"20060102",
+	"060102",
+}
+
+// parseDate converts the date to utc timestamp
+func parseDate(value string) (int64, error) {
+	value = strings.TrimSpace(value)
+	if len(value) == 0 {
+		return 0, nil
+	}
+	for _, layout := range dateLayouts {
+		if one, err := time.Parse(layout, value); err == nil {
+			return one.Unix(), nil
+		}
+	}
+	return 0, fmt.Errorf("invalid date:%s", value)
+}
+
+func firstNonBlank(values ...string) string {
+	for _, value := range values {
+		if value = strings.TrimSpace(value); len(value) > 0 {
+			return value
+		}
+	}
+	return ""
+}
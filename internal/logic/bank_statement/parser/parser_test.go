@@ -0,0 +1,161 @@
+package parser
+
+import (
+	"testing"
+
+	"unibee/internal/consts"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testCamt053 = `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.053.001.02">
+  <BkToCstmrStmt>
+    <Stmt>
+      <Id>STMT-2024-01-02</Id>
+      <Acct><Id><IBAN>DE89370400440532013000</IBAN></Id><Ccy>EUR</Ccy></Acct>
+      <Ntry>
+        <NtryRef>E1</NtryRef>
+        <Amt Ccy="EUR">119.00</Amt>
+        <CdtDbtInd>CRDT</CdtDbtInd>
+        <BookgDt><Dt>2024-01-02</Dt></BookgDt>
+        <AcctSvcrRef>BANK-REF-1</AcctSvcrRef>
+        <NtryDtls><TxDtls>
+          <Refs><EndToEndId>NOTPROVIDED</EndToEndId></Refs>
+          <RltdPties><Dbtr><Nm>ACME GmbH</Nm></Dbtr><DbtrAcct><Id><IBAN>DE02120300000000202051</IBAN></Id></DbtrAcct></RltdPties>
+          <RmtInf><Ustrd>Invoice 81712345678</Ustrd></RmtInf>
+        </TxDtls></NtryDtls>
+      </Ntry>
+      <Ntry>
+        <NtryRef>E2</NtryRef>
+        <Amt Ccy="EUR">50.00</Amt>
+        <CdtDbtInd>DBIT</CdtDbtInd>
+        <BookgDt><Dt>2024-01-02</Dt></BookgDt>
+      </Ntry>
+      <Ntry>
+        <Amt Ccy="EUR">30.00</Amt>
+        <CdtDbtInd>CRDT</CdtDbtInd>
+        <BookgDt><Dt>2024-01-03</Dt></BookgDt>
+        <NtryDtls>
+          <TxDtls><Refs><AcctSvcrRef>B-1</AcctSvcrRef></Refs><Amt Ccy="EUR">10.00</Amt><RmtInf><Strd><CdtrRefInf><Ref>RF18INV1</Ref></CdtrRefInf></Strd></RmtInf></TxDtls>
+          <TxDtls><Refs><AcctSvcrRef>B-2</AcctSvcrRef></Refs><Amt Ccy="EUR">20.00</Amt><RmtInf><Ustrd>INV2</Ustrd></RmtInf></TxDtls>
+        </NtryDtls>
+      </Ntry>
+    </Stmt>
+  </BkToCstmrStmt>
+</Document>`
+
+const testMT940 = `{1:F01BANKDEFFXXXX0000000000}{2:O9401200240102BANKDEFFXXXX00000000002401021200N}{4:
+:20:STARTUMS
+:25:37040044/0532013000
+:28C:00001/001
+:60F:C240101EUR1000,00
+:61:2401020102RD119,00NTRFNONREF//BANK-REF-1
+:86:166?00GUTSCHRIFT?20Invoice 817123?2145678?31DE02120300000000202051?32ACME GmbH
+:61:2401020102DR50,00NTRFNONREF
+:86:Rent January
+:61:2401030103C30,5NTRFCUSTREF
+:86:Payment for INV2
+ second line
+:62F:C240103EUR1099,50
+-}`
+
+func TestParseCamt053(t *testing.T) {
+	statement, err := Parse("statement.xml", "", []byte(testCamt053))
+	require.Nil(t, err)
+	require.Equal(t, consts.BankStatementFormatCamt053, statement.Format)
+	require.Equal(t, "STMT-2024-01-02", statement.StatementId)
+	require.Equal(t, "DE89370400440532013000", statement.Account)
+	require.Equal(t, "EUR", statement.Currency)
+	require.Equal(t, 3, len(statement.Lines))
+	first := statement.Lines[0]
+	require.Equal(t, "BANK-REF-1", first.BankReference)
+	require.Equal(t, int64(11900), first.Amount)
+	require.Equal(t, "EUR", first.Currency)
+	require.Equal(t, int64(1704153600), first.BookingTime)
+	require.Equal(t, "ACME GmbH", first.CounterpartyName)
+	require.Equal(t, "DE02120300000000202051", first.CounterpartyAccount)
+	require.Equal(t, "Invoice 81712345678", first.Remittance)
+	// batch booking split into transactions
+	require.Equal(t, "B-1", statement.Lines[1].BankReference)
+	require.Equal(t, int64(1000), statement.Lines[1].Amount)
+	require.Equal(t, "RF18INV1", statement.Lines[1].Remittance)
+	require.Equal(t, int64(2000), statement.Lines[2].Amount)
+	_, err = ParseCamt053([]byte("<Document></Document>"))
+	require.NotNil(t, err)
+}
+
+func TestParseMT940(t *testing.T) {
+	statement, err := Parse("statement.sta", "", []byte(testMT940))
+	require.Nil(t, err)
+	require.Equal(t, consts.BankStatementFormatMT940, statement.Format)
+	require.Equal(t, "STARTUMS", statement.StatementId)
+	require.Equal(t, "37040044/0532013000", statement.Account)
+	require.Equal(t, "EUR", statement.Currency)
+	require.Equal(t, 2, len(statement.Lines))
+	first := statement.Lines[0]
+	// reversal of debit is a credit
+	require.Equal(t, "BANK-REF-1", first.BankReference)
+	require.Equal(t, int64(11900), first.Amount)
+	require.Equal(t, int64(1704153600), first.BookingTime)
+	require.Equal(t, "ACME GmbH", first.CounterpartyName)
+	require.Equal(t, "DE02120300000000202051", first.CounterpartyAccount)
+	require.Equal(t, "Invoice 81712345678", first.Remittance)
+	second := statement.Lines[1]
+	require.Equal(t, "CUSTREF", second.BankReference)
+	require.Equal(t, int64(3050), second.Amount)
+	require.Equal(t, "Payment for INV2 second line", second.Remittance)
+	_, err = ParseMT940([]byte("hello"))
+	require.NotNil(t, err)
+}
+
+func TestParseCsv(t *testing.T) {
+	content := "\xef\xbb\xbfBooking Date;Amount;Currency;Reference;Name;IBAN;Transaction Id\n" +
+		"2024-01-02;1.190,00;eur;Invoice 817;ACME GmbH;DE02;T1\n" +
+		"02.01.2024;-50,00;EUR;Rent;Landlord;DE03;T2\n" +
+		"2024-01-03;30.5;EUR;INV2;Bob;;\n"
+	statement, err := Parse("statement.csv", "", []byte(content))
+	require.Nil(t, err)
+	require.Equal(t, consts.BankStatementFormatCsv, statement.Format)
+	require.Equal(t, "EUR", statement.Currency)
+	require.Equal(t, 2, len(statement.Lines))
+	require.Equal(t, "T1", statement.Lines[0].BankReference)
+	require.Equal(t, int64(119000), statement.Lines[0].Amount)
+	require.Equal(t, "Invoice 817", statement.Lines[0].Remittance)
+	require.Equal(t, "ACME GmbH", statement.Lines[0].CounterpartyName)
+	require.Equal(t, "DE02", statement.Lines[0].CounterpartyAccount)
+	require.Equal(t, int64(3050), statement.Lines[1].Amount)
+	require.Equal(t, "", statement.Lines[1].BankReference)
+	_, err = ParseCsv([]byte("Date,Reference\n2024-01-02,abc\n"))
+	require.NotNil(t, err)
+	_, err = Parse("statement.txt", "pdf", []byte(content))
+	require.NotNil(t, err)
+}
+
+func TestAssignLineKeys(t *testing.T) {
+	statement := &Statement{Account: "DE89", Lines: []*Line{
+		{BankReference: "R1", Amount: 100, Currency: "EUR"},
+		{Amount: 100, Currency: "EUR", Remittance: "INV1"},
+		{Amount: 100, Currency: "EUR", Remittance: "INV1"},
+	}}
+	AssignLineKeys(statement)
+	require.NotEqual(t, statement.Lines[1].Key, statement.Lines[2].Key)
+	first := []string{statement.Lines[0].Key, statement.Lines[1].Key, statement.Lines[2].Key}
+	// keys are stable across imports of the same statement
+	AssignLineKeys(statement)
+	require.Equal(t, first, []string{statement.Lines[0].Key, statement.Lines[1].Key, statement.Lines[2].Key})
+}
+
+func TestParseAmount(t *testing.T) {
+	for value, expected := range map[string]int64{"19.99": 1999, "19,99": 1999, "1.234,56": 123456, "1,234.56": 123456, "100": 10000, "0,1": 10,
+		"1,234": 123400, "1.234": 123400, "1,234,567": 123456700, "1.234.567,89": 123456789, "1 234,5": 123450, "-1.234,56": -123456} {
+		amount, err := parseAmount(value, "EUR")
+		require.Nil(t, err)
+		require.Equal(t, expected, amount, value)
+	}
+	amount, err := parseAmount("1500", "JPY")
+	require.Nil(t, err)
+	require.Equal(t, int64(1500), amount)
+	_, err = parseAmount("abc", "EUR")
+	require.NotNil(t, err)
+}
@@ -0,0 +1,191 @@
+package settlement
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"unibee/internal/consts"
+	"unibee/internal/logic/gateway/gateway_bean"
+	"unibee/internal/logic/operation_log"
+	"unibee/internal/logic/settlement"
+	entity "unibee/internal/model/entity/default"
+	"unibee/internal/query"
+	"unibee/utility"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+type TaskGatewaySettlementImport struct {
+}
+
+func (t TaskGatewaySettlementImport) TemplateVersion() string {
+	return "v1"
+}
+
+func (t TaskGatewaySettlementImport) TaskName() string {
+	return "GatewaySettlementImport"
+}
+
+func (t TaskGatewaySettlementImport) TemplateHeader() interface{} {
+	return &ImportSettlementEntity{
+		Gateway:              "wire_transfer",
+		GatewaySettlementId:  "payout_20240101",
+		ArrivalTime:          "2024-01-01",
+		GatewayTransactionId: "txn_1",
+		Type:                 "payment",
+		GatewayPaymentId:     "",
+		GatewayRefundId:      "",
+		PaymentId:            "",
+		Currency:             "EUR",
+		GrossAmount:          "10.00",
+		FeeAmount:            "0.30",
+		NetAmount:            "9.70",
+		TransactionTime:      "2024-01-01 10:00:00",
+	}
+}
+
+// ImportRow imports one settled transaction, rows of the same GatewaySettlementId build up one settlement
+func (t TaskGatewaySettlementImport) ImportRow(ctx context.Context, task *entity.MerchantBatchTask, row map[string]string) (interface{}, error) {
+	target := &ImportSettlementEntity{
+		Gateway:              fmt.Sprintf("%s", row["Gateway"]),
+		GatewaySettlementId:  fmt.Sprintf("%s", row["GatewaySettlementId"]),
+		ArrivalTime:          fmt.Sprintf("%s", row["ArrivalTime"]),
+		GatewayTransactionId: fmt.Sprintf("%s", row["GatewayTransactionId"]),
+		Type:                 fmt.Sprintf("%s", row["Type"]),
+		GatewayPaymentId:     fmt.Sprintf("%s", row["GatewayPaymentId"]),
+		GatewayRefundId:      fmt.Sprintf("%s", row["GatewayRefundId"]),
+		PaymentId:            fmt.Sprintf("%s", row["PaymentId"]),
+		Currency:             fmt.Sprintf("%s", row["Currency"]),
+		GrossAmount:          fmt.Sprintf("%s", row["GrossAmount"]),
+		FeeAmount:            fmt.Sprintf("%s", row["FeeAmount"]),
+		NetAmount:            fmt.Sprintf("%s", row["NetAmount"]),
+		TransactionTime:      fmt.Sprintf("%s", row["TransactionTime"]),
+	}
+	if len(target.Gateway) == 0 {
+		return target, gerror.New("Error, Gateway is blank")
+	}
+	if len(target.GatewaySettlementId) == 0 {
+		return target, gerror.New("Error, GatewaySettlementId is blank")
+	}
+	if len(target.GatewayTransactionId) == 0 {
+		return target, gerror.New("Error, GatewayTransactionId is blank")
+	}
+	if len(target.Currency) == 0 {
+		return target, gerror.New("Error, Currency is blank")
+	}
+	gateway := query.GetDefaultGatewayByGatewayName(ctx, task.MerchantId, target.Gateway)
+	if gateway == nil {
+		return target, gerror.New("Error, gateway not found")
+	}
+	currency := strings.TrimSpace(strings.ToUpper(target.Currency))
+	itemType := strings.ToLower(target.Type)
+	if len(itemType) == 0 {
+		itemType = consts.SettlementItemTypePayment
+	}
+	if !utility.StringContainsElement([]string{consts.SettlementItemTypePayment, consts.SettlementItemTypeRefund, consts.SettlementItemTypeFee,
+		consts.SettlementItemTypeDispute, consts.SettlementItemTypeAdjustment, consts.SettlementItemTypeOther}, itemType) {
+		return target, gerror.New("Error, Type should be one of payment|refund|fee|dispute|adjustment|other")
+	}
+	grossAmount, err := parseAmount(target.GrossAmount, currency)
+	if err != nil {
+		return target, gerror.Newf("Invalid GrossAmount,error:%s", err.Error())
+	}
+	feeAmount, err := parseAmount(target.FeeAmount, currency)
+	if err != nil {
+		return target, gerror.Newf("Invalid FeeAmount,error:%s", err.Error())
+	}
+	var netAmount = grossAmount - feeAmount
+	if len(target.NetAmount) > 0 {
+		netAmount, err = parseAmount(target.NetAmount, currency)
+		if err != nil {
+			return target, gerror.Newf("Invalid NetAmount,error:%s", err.Error())
+		}
+	}
+	arrivalTime, err := parseTime(target.ArrivalTime)
+	if err != nil {
+		return target, gerror.Newf("Invalid ArrivalTime,error:%s", err.Error())
+	}
+	transactionTime, err := parseTime(target.TransactionTime)
+	if err != nil {
+		return target, gerror.Newf("Invalid TransactionTime,error:%s", err.Error())
+	}
+	if transactionTime == 0 {
+		transactionTime = arrivalTime
+	}
+	var gatewayRefundId = target.GatewayRefundId
+	if itemType == consts.SettlementItemTypeRefund && len(gatewayRefundId) == 0 {
+		gatewayRefundId = target.GatewayTransactionId
+	}
+	one, err := settlement.Ingest(ctx, gateway, consts.SettlementSourceImport, &gateway_bean.GatewaySettlement{
+		GatewaySettlementId: target.GatewaySettlementId,
+		Currency:            currency,
+		Status:              consts.SettlementPaid,
+		ArrivalTime:         arrivalTime,
+		Items: []*gateway_bean.GatewaySettlementItem{{
+			GatewayTransactionId: target.GatewayTransactionId,
+			Type:                 itemType,
+			GatewayPaymentId:     target.GatewayPaymentId,
+			GatewayRefundId:      gatewayRefundId,
+			PaymentId:            target.PaymentId,
+			Currency:             currency,
+			GrossAmount:          grossAmount,
+			FeeAmount:            feeAmount,
+			NetAmount:            netAmount,
+			TransactionTime:      transactionTime,
+		}},
+	})
+	if err != nil {
+		return target, err
+	}
+	operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
+		MerchantId: task.MerchantId,
+		Target:     fmt.Sprintf("Settlement(%v)", one.Id),
+		Content:    fmt.Sprintf("Import(%s)", target.GatewayTransactionId),
+	}, err)
+	return target, nil
+}
+
+func parseAmount(value string, currency string) (int64, error) {
+	value = strings.ReplaceAll(strings.TrimSpace(value), ",", "")
+	if len(value) == 0 {
+		return 0, nil
+	}
+	if _, err := strconv.ParseFloat(value, 64); err != nil {
+		return 0, err
+	}
+	return utility.ConvertDollarStrToCent(value, currency), nil
+}
+
+func parseTime(value string) (int64, error) {
+	value = strings.TrimSpace(value)
+	if len(value) == 0 {
+		return 0, nil
+	}
+	if timestamp, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return timestamp, nil
+	}
+	one, err := gtime.StrToTime(value)
+	if err != nil {
+		return 0, err
+	}
+	return one.Timestamp(), nil
+}
+
+type ImportSettlementEntity struct {
+	Gateway              string `json:"Gateway"              comment:"Required, the gateway name, like wire_transfer"`
+	GatewaySettlementId  string `json:"GatewaySettlementId"  comment:"Required, the payout or bank deposit reference, rows with the same id are one settlement"`
+	ArrivalTime          string `json:"ArrivalTime"          comment:"The arrival date of the deposit, yyyy-MM-dd or utc timestamp"`
+	GatewayTransactionId string `json:"GatewayTransactionId" comment:"Required, unique transaction id of the gateway, import again to update"`
+	Type                 string `json:"Type"                 comment:"payment|refund|fee|dispute|adjustment|other, payment if blank"`
+	GatewayPaymentId     string `json:"GatewayPaymentId"     comment:"The gateway payment id, used to match the payment"`
+	GatewayRefundId      string `json:"GatewayRefundId"      comment:"The gateway refund id, used to match the refund"`
+	PaymentId            string `json:"PaymentId"            comment:"The UniBee paymentId, used to match the payment"`
+	Currency             string `json:"Currency"             comment:"Required"`
+	GrossAmount          string `json:"GrossAmount"          comment:"Gross amount, negative for refund, like 10.00"`
+	FeeAmount            string `json:"FeeAmount"            comment:"Fee charged by the gateway, like 0.30"`
+	NetAmount            string `json:"NetAmount"            comment:"Net amount, GrossAmount-FeeAmount if blank"`
+	TransactionTime      string `json:"TransactionTime"      comment:"yyyy-MM-dd HH:mm:ss or utc timestamp, ArrivalTime if blank"`
+}
@@ -128,6 +128,7 @@ func (t TaskCreditNoteExport) PageData(ctx context.Context, page int, count int,
 			}
 			mainList = append(mainList, &ExportCreditNoteEntity{
 				CreditNoteId:        one.InvoiceId,
+				CreditNoteNumber:    one.InvoiceNumber,
 				UserId:              fmt.Sprintf("%v", one.UserId),
 				Email:               one.UserSnapshot.Email,
 				FirstName:           one.UserSnapshot.FirstName,
@@ -156,6 +157,7 @@ func (t TaskCreditNoteExport) PageData(ctx context.Context, page int, count int,
 
 type ExportCreditNoteEntity struct {
 	CreditNoteId        string      `json:"CreditNoteId" comment:"The unique id of credit note" group:"Credit Note"`
+	CreditNoteNumber    string      `json:"CreditNoteNumber" comment:"The sequential credit note number, empty when numbering is disabled" group:"Credit Note"`
 	UserId              string      `json:"UserId" comment:"The unique id of user" group:"User Information"`
 	Email               string      `json:"Email" comment:"The email of user" group:"User Information"`
 	FirstName           string      `json:"FirstName" comment:"The first name of user" group:"User Information"`
@@ -163,6 +163,7 @@ func (t TaskCreditNoteV2Export) PageData(ctx context.Context, page int, count in
 			}
 			mainList = append(mainList, &ExportCreditNoteEntity{
 				CreditNoteId:        one.InvoiceId,
+				CreditNoteNumber:    one.InvoiceNumber,
 				UserId:              fmt.Sprintf("%v", one.UserId),
 				Email:               one.UserSnapshot.Email,
 				FirstName:           one.UserSnapshot.FirstName,
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/gogf/gf/v2/frame/g"
 	"github.com/gogf/gf/v2/os/gtime"
+	"strings"
 	"unibee/api/bean"
 	"unibee/internal/consts"
 	"unibee/internal/logic/batch/export"
@@ -115,6 +116,10 @@ func (t TaskInvoiceExport) PageData(ctx context.Context, page int, count int, ta
 			if one.Gateway != nil {
 				invoiceGateway = one.Gateway.GatewayName
 			}
+			var invoiceNumber = one.InvoiceNumber
+			if len(invoiceNumber) == 0 {
+				invoiceNumber = fmt.Sprintf("%s%s", api.GatewayShortNameMapping[invoiceGateway], one.InvoiceId)
+			}
 			if one.UserAccount == nil {
 				one.UserAccount = &bean.UserAccount{}
 			}
@@ -214,7 +219,7 @@ func (t TaskInvoiceExport) PageData(ctx context.Context, page int, count int, ta
 			}
 			mainList = append(mainList, &ExportInvoiceEntity{
 				InvoiceId:                      one.InvoiceId,
-				InvoiceNumber:                  fmt.Sprintf("%s%s", api.GatewayShortNameMapping[invoiceGateway], one.InvoiceId),
+				InvoiceNumber:                  invoiceNumber,
 				UserId:                         fmt.Sprintf("%v", one.UserId),
 				ExternalUserId:                 fmt.Sprintf("%v", one.UserAccount.ExternalUserId),
 				FirstName:                      one.UserSnapshot.FirstName,
@@ -245,6 +250,8 @@ func (t TaskInvoiceExport) PageData(ctx context.Context, page int, count int, ta
 				Currency:                       one.Currency,
 				TaxAmount:                      utility.ConvertCentToDollarStr(one.TaxAmount, one.Currency),
 				TaxPercentage:                  utility.ConvertTaxPercentageToPercentageString(one.TaxPercentage),
+				TaxBreakdown:                   taxBreakdownString(one.TaxLines, one.Currency),
+				TaxTreatment:                   one.TaxTreatment,
 				SubscriptionAmount:             utility.ConvertCentToDollarStr(one.SubscriptionAmount, one.Currency),
 				SubscriptionAmountExcludingTax: utility.ConvertCentToDollarStr(one.SubscriptionAmountExcludingTax, one.Currency),
 				PeriodEnd:                      gtime.NewFromTimeStamp(one.PeriodEnd + timeZone),
@@ -279,7 +286,7 @@ func (t TaskInvoiceExport) PageData(ctx context.Context, page int, count int, ta
 
 type ExportInvoiceEntity struct {
 	InvoiceId                      string      `json:"InvoiceId"  comment:"The unique id of invoice, pure digital" group:"Invoice"`
-	InvoiceNumber                  string      `json:"InvoiceNumber" comment:"The unique number of invoice, format: Gateway+InvoiceId" group:"Invoice"`
+	InvoiceNumber                  string      `json:"InvoiceNumber" comment:"The sequential invoice number, Gateway+InvoiceId for invoices issued without numbering" group:"Invoice"`
 	UserId                         string      `json:"UserId"              comment:"The unique id of user" group:"User Information"`
 	ExternalUserId                 string      `json:"ExternalUserId"      comment:"The external unique id of user" group:"User Information"`
 	FirstName                      string      `json:"FirstName"           comment:"The first name of user" group:"User Information"`
@@ -310,6 +317,8 @@ type ExportInvoiceEntity struct {
 	Currency                       string      `json:"Currency" comment:"The currency of invoice" group:"Transaction"`
 	TaxAmount                      string      `json:"TaxAmount" comment:"The tax amount of invoice" group:"Transaction"`
 	TaxPercentage                  string      `json:"TaxPercentage"            comment:"The tax percentage of invoice applied" group:"Transaction"`
+	TaxBreakdown                   string      `json:"TaxBreakdown"             comment:"The tax lines of invoice from the tax engine, name jurisdiction rate and amount split with ;" group:"Transaction"`
+	TaxTreatment                   string      `json:"TaxTreatment"             comment:"The tax treatment of invoice, empty-standard，reverse_charge，exempt" group:"Transaction"`
 	SubscriptionAmount             string      `json:"SubscriptionAmount" comment:"The amount of subscription if invoice is generated by subscription" group:"Product and Subscription"`
 	SubscriptionAmountExcludingTax string      `json:"SubscriptionAmountExcludingTax" comment:"The amount of subscription which excluded tax amount if invoice is generated by subscription" group:"Product and Subscription"`
 	PeriodEnd                      *gtime.Time `json:"PeriodEnd"  layout:"2006-01-02 15:04:05"   comment:"The end time of period, will apply to subscription if invoice paid" group:"Product and Subscription"`
@@ -338,3 +347,11 @@ type ExportInvoiceEntity struct {
 	PromoCreditChanged             string      `json:"PromoCreditChanged" comment:"The promo credit changed" group:"Transaction"`
 	PromoCreditDiscountAmount      string      `json:"PromoCreditDiscountAmount"     comment:"The promo credit currency discount amount"  group:"Transaction"`
 }
+
+func taxBreakdownString(taxLines []*bean.InvoiceItemTaxLine, currency string) string {
+	var list = make([]string, 0)
+	for _, taxLine := range taxLines {
+		list = append(list, fmt.Sprintf("%s %s %s: %s", taxLine.Name, taxLine.Jurisdiction, utility.ConvertTaxPercentageToPercentageString(taxLine.Rate), utility.ConvertCentToDollarStr(taxLine.Amount, currency)))
+	}
+	return strings.Join(list, "; ")
+}
@@ -121,6 +121,10 @@ func (t TaskInvoiceV2Export) PageData(ctx context.Context, page int, count int,
 		if preload.Gateways[one.GatewayId] != nil {
 			invoiceGateway = preload.Gateways[one.GatewayId].GatewayName
 		}
+		var invoiceNumber = one.InvoiceNumber
+		if len(invoiceNumber) == 0 {
+			invoiceNumber = fmt.Sprintf("%s%s", api.GatewayShortNameMapping[invoiceGateway], one.InvoiceId)
+		}
 		userAccount := &bean.UserAccount{}
 		if preload.Users[one.UserId] != nil {
 			userAccount = bean.SimplifyUserAccount(preload.Users[one.UserId])
@@ -202,7 +206,9 @@ func (t TaskInvoiceV2Export) PageData(ctx context.Context, page int, count int,
 		err = utility.UnmarshalFromJsonString(one.Lines, &lines)
 		for _, line := range lines {
 			line.Currency = one.Currency
-			line.TaxPercentage = one.TaxPercentage
+			if len(line.TaxLines) == 0 {
+				line.TaxPercentage = one.TaxPercentage
+			}
 		}
 		if err != nil {
 			fmt.Printf("ConvertInvoiceLines err:%s", err)
@@ -252,7 +258,7 @@ func (t TaskInvoiceV2Export) PageData(ctx context.Context, page int, count int,
 		}
 		mainList = append(mainList, &ExportInvoiceEntity{
 			InvoiceId:                      one.InvoiceId,
-			InvoiceNumber:                  fmt.Sprintf("%s%s", api.GatewayShortNameMapping[invoiceGateway], one.InvoiceId),
+			InvoiceNumber:                  invoiceNumber,
 			UserId:                         fmt.Sprintf("%v", one.UserId),
 			ExternalUserId:                 fmt.Sprintf("%v", userAccount.ExternalUserId),
 			FirstName:                      userSnapshot.FirstName,
@@ -283,6 +289,8 @@ func (t TaskInvoiceV2Export) PageData(ctx context.Context, page int, count int,
 			Currency:                       one.Currency,
 			TaxAmount:                      utility.ConvertCentToDollarStr(one.TaxAmount, one.Currency),
 			TaxPercentage:                  utility.ConvertTaxPercentageToPercentageString(one.TaxPercentage),
+			TaxBreakdown:                   taxBreakdownString(bean.SummaryInvoiceTaxLines(lines), one.Currency),
+			TaxTreatment:                   one.TaxTreatment,
 			SubscriptionAmount:             utility.ConvertCentToDollarStr(one.SubscriptionAmount, one.Currency),
 			SubscriptionAmountExcludingTax: utility.ConvertCentToDollarStr(one.SubscriptionAmountExcludingTax, one.Currency),
 			PeriodEnd:                      gtime.NewFromTimeStamp(one.PeriodEnd + timeZone),
@@ -0,0 +1,95 @@
+package receivable
+
+import (
+	"context"
+	"fmt"
+
+	"unibee/internal/logic/invoice/invoice_number"
+	"unibee/internal/logic/invoice/receivable"
+	entity "unibee/internal/model/entity/default"
+	"unibee/internal/query"
+	"unibee/utility"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+type TaskReceivableAgeingExport struct {
+}
+
+func (t TaskReceivableAgeingExport) TaskName() string {
+	return "ReceivableAgeingExport"
+}
+
+func (t TaskReceivableAgeingExport) Header() interface{} {
+	return ExportReceivableAgeingEntity{}
+}
+
+// PageData exports one row per open receivable invoice, with its ageing at the asOfTime of the payload
+func (t TaskReceivableAgeingExport) PageData(ctx context.Context, page int, count int, task *entity.MerchantBatchTask) ([]interface{}, error) {
+	var mainList = make([]interface{}, 0)
+	if task == nil || task.MerchantId <= 0 {
+		return mainList, nil
+	}
+	var payload map[string]interface{}
+	err := utility.UnmarshalFromJsonString(task.Payload, &payload)
+	if err != nil {
+		g.Log().Errorf(ctx, "Download PageData error:%s", err.Error())
+		return mainList, nil
+	}
+	var currency = ""
+	var asOf = gtime.Now().Timestamp()
+	if payload != nil {
+		if value, ok := payload["currency"].(string); ok {
+			currency = value
+		}
+		if value, ok := payload["asOfTime"].(float64); ok && value > 0 {
+			asOf = int64(value)
+		}
+	}
+	list, err := receivable.GetOpenInvoices(ctx, task.MerchantId, currency, page, count)
+	if err != nil {
+		return nil, err
+	}
+	for _, one := range list {
+		if !receivable.IsReceivable(one) {
+			continue
+		}
+		var email = ""
+		if user := query.GetUserAccountById(ctx, one.UserId); user != nil {
+			email = user.Email
+		}
+		mainList = append(mainList, &ExportReceivableAgeingEntity{
+			InvoiceId:         one.InvoiceId,
+			InvoiceNumber:     invoice_number.DisplayNumber(ctx, one),
+			UserId:            fmt.Sprintf("%v", one.UserId),
+			Email:             email,
+			Currency:          one.Currency,
+			TotalAmount:       utility.ConvertCentToDollarStr(one.TotalAmount, one.Currency),
+			PaidAmount:        utility.ConvertCentToDollarStr(one.PaidAmount, one.Currency),
+			OutstandingAmount: utility.ConvertCentToDollarStr(receivable.OutstandingAmount(one), one.Currency),
+			NetTermDays:       fmt.Sprintf("%v", one.NetTermDays),
+			IssueTime:         gtime.NewFromTimeStamp(one.FinishTime),
+			DueTime:           gtime.NewFromTimeStamp(receivable.DueTime(one)),
+			DaysPastDue:       fmt.Sprintf("%v", receivable.DaysPastDue(one, asOf)),
+			AgeingBucket:      receivable.AgeingBucket(one, asOf),
+		})
+	}
+	return mainList, nil
+}
+
+type ExportReceivableAgeingEntity struct {
+	InvoiceId         string      `json:"InvoiceId"         comment:""`
+	InvoiceNumber     string      `json:"InvoiceNumber"     comment:""`
+	UserId            string      `json:"UserId"            comment:""`
+	Email             string      `json:"Email"             comment:""`
+	Currency          string      `json:"Currency"          comment:""`
+	TotalAmount       string      `json:"TotalAmount"       comment:""`
+	PaidAmount        string      `json:"PaidAmount"        comment:"The amount received by partial payments"`
+	OutstandingAmount string      `json:"OutstandingAmount" comment:""`
+	NetTermDays       string      `json:"NetTermDays"       comment:"0-due on receipt|30|60|90"`
+	IssueTime         *gtime.Time `json:"IssueTime"         layout:"2006-01-02 15:04:05" comment:""`
+	DueTime           *gtime.Time `json:"DueTime"           layout:"2006-01-02 15:04:05" comment:""`
+	DaysPastDue       string      `json:"DaysPastDue"       comment:""`
+	AgeingBucket      string      `json:"AgeingBucket"      comment:"current|1-30|31-60|61-90|90+"`
+}
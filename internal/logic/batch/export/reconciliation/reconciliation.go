@@ -0,0 +1,132 @@
+package reconciliation
+
+import (
+	"context"
+	"fmt"
+
+	"unibee/internal/consts"
+	"unibee/internal/logic/gateway/reconciliation"
+	entity "unibee/internal/model/entity/default"
+	"unibee/internal/query"
+	"unibee/utility"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+type TaskGatewayReconciliationExport struct {
+}
+
+func (t TaskGatewayReconciliationExport) TaskName() string {
+	return "GatewayReconciliationExport"
+}
+
+func (t TaskGatewayReconciliationExport) Header() interface{} {
+	return ExportReconciliationEntity{}
+}
+
+// PageData runs the whole reconciliation on the first page, the mismatch list of one window is small enough for a single page
+func (t TaskGatewayReconciliationExport) PageData(ctx context.Context, page int, count int, task *entity.MerchantBatchTask) ([]interface{}, error) {
+	var mainList = make([]interface{}, 0)
+	if task == nil || task.MerchantId <= 0 || page > 0 {
+		return mainList, nil
+	}
+	var payload map[string]interface{}
+	err := utility.UnmarshalFromJsonString(task.Payload, &payload)
+	if err != nil {
+		g.Log().Errorf(ctx, "Download PageData error:%s", err.Error())
+		return mainList, nil
+	}
+	req := &reconciliation.Req{
+		EndTime: gtime.Now().Timestamp(),
+	}
+	req.StartTime = req.EndTime - 86400
+	if payload != nil {
+		if value, ok := payload["gatewayId"].(float64); ok {
+			req.Gateway = query.GetGatewayById(ctx, uint64(value))
+		}
+		if value, ok := payload["startTime"].(float64); ok {
+			req.StartTime = int64(value)
+		}
+		if value, ok := payload["endTime"].(float64); ok {
+			req.EndTime = int64(value)
+		}
+		if value, ok := payload["autoHeal"].(bool); ok {
+			req.AutoHeal = value
+		}
+	}
+	utility.Assert(req.Gateway != nil && req.Gateway.MerchantId == task.MerchantId, "gateway not found")
+	list, err := reconciliation.Reconcile(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	for _, one := range list {
+		var localStatus = ""
+		var gatewayStatus = ""
+		if len(one.RefundId) > 0 || one.Type == reconciliation.MismatchOrphanRefund {
+			if one.LocalStatus > 0 {
+				localStatus = consts.RefundStatusEnum(one.LocalStatus).Description()
+			}
+			if one.GatewayStatus > 0 {
+				gatewayStatus = consts.RefundStatusEnum(one.GatewayStatus).Description()
+			}
+		} else {
+			if one.LocalStatus > 0 {
+				localStatus = consts.PaymentStatusEnum(one.LocalStatus).Description()
+			}
+			if one.GatewayStatus > 0 {
+				gatewayStatus = consts.PaymentStatusEnum(one.GatewayStatus).Description()
+			}
+		}
+		var healed = "No"
+		if one.Healed {
+			healed = "Yes"
+		}
+		var healable = "No"
+		if one.Healable {
+			healable = "Yes"
+		}
+		mainList = append(mainList, &ExportReconciliationEntity{
+			Type:             one.Type,
+			Gateway:          req.Gateway.GatewayName,
+			GatewayId:        fmt.Sprintf("%v", one.GatewayId),
+			PaymentId:        one.PaymentId,
+			RefundId:         one.RefundId,
+			GatewayPaymentId: one.GatewayPaymentId,
+			GatewayRefundId:  one.GatewayRefundId,
+			Currency:         one.Currency,
+			LocalStatus:      localStatus,
+			GatewayStatus:    gatewayStatus,
+			LocalAmount:      utility.ConvertCentToDollarStr(one.LocalAmount, one.Currency),
+			GatewayAmount:    utility.ConvertCentToDollarStr(one.GatewayAmount, one.Currency),
+			Healable:         healable,
+			Healed:           healed,
+			HealError:        one.HealError,
+			Message:          one.Message,
+			WindowStart:      gtime.NewFromTimeStamp(req.StartTime),
+			WindowEnd:        gtime.NewFromTimeStamp(req.EndTime),
+		})
+	}
+	return mainList, nil
+}
+
+type ExportReconciliationEntity struct {
+	Type             string      `json:"Type"              comment:"missing_local|status_differs|amount_differs|orphan_refund|payment_list_unsupported|payment_list_failed"`
+	Gateway          string      `json:"Gateway"           comment:""`
+	GatewayId        string      `json:"GatewayId"         comment:""`
+	PaymentId        string      `json:"PaymentId"         comment:""`
+	RefundId         string      `json:"RefundId"          comment:""`
+	GatewayPaymentId string      `json:"GatewayPaymentId"  comment:""`
+	GatewayRefundId  string      `json:"GatewayRefundId"   comment:""`
+	Currency         string      `json:"Currency"          comment:""`
+	LocalStatus      string      `json:"LocalStatus"       comment:""`
+	GatewayStatus    string      `json:"GatewayStatus"     comment:""`
+	LocalAmount      string      `json:"LocalAmount"       comment:""`
+	GatewayAmount    string      `json:"GatewayAmount"     comment:""`
+	Healable         string      `json:"Healable"          comment:"Local row is pending and the gateway reports a final status"`
+	Healed           string      `json:"Healed"            comment:"The gateway status has been applied to the local row"`
+	HealError        string      `json:"HealError"         comment:""`
+	Message          string      `json:"Message"           comment:""`
+	WindowStart      *gtime.Time `json:"WindowStart"       layout:"2006-01-02 15:04:05" comment:""`
+	WindowEnd        *gtime.Time `json:"WindowEnd"         layout:"2006-01-02 15:04:05" comment:""`
+}
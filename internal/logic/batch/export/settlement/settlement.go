@@ -0,0 +1,140 @@
+package settlement
+
+import (
+	"context"
+	"fmt"
+
+	"unibee/internal/consts"
+	dao "unibee/internal/dao/default"
+	entity "unibee/internal/model/entity/default"
+	"unibee/internal/query"
+	"unibee/utility"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+type TaskSettlementExport struct {
+}
+
+func (t TaskSettlementExport) TaskName() string {
+	return "SettlementExport"
+}
+
+func (t TaskSettlementExport) Header() interface{} {
+	return ExportSettlementEntity{}
+}
+
+// PageData exports one row per settled transaction, with the settlement it was paid out in
+func (t TaskSettlementExport) PageData(ctx context.Context, page int, count int, task *entity.MerchantBatchTask) ([]interface{}, error) {
+	var mainList = make([]interface{}, 0)
+	if task == nil || task.MerchantId <= 0 {
+		return mainList, nil
+	}
+	var payload map[string]interface{}
+	err := utility.UnmarshalFromJsonString(task.Payload, &payload)
+	if err != nil {
+		g.Log().Errorf(ctx, "Download PageData error:%s", err.Error())
+		return mainList, nil
+	}
+	q := dao.GatewaySettlement.Ctx(ctx).
+		Where(dao.GatewaySettlement.Columns().MerchantId, task.MerchantId).
+		Where(dao.GatewaySettlement.Columns().IsDeleted, 0)
+	if payload != nil {
+		if value, ok := payload["settlementId"].(float64); ok {
+			q = q.Where(dao.GatewaySettlement.Columns().Id, uint64(value))
+		}
+		if value, ok := payload["gatewayId"].(float64); ok {
+			q = q.Where(dao.GatewaySettlement.Columns().GatewayId, uint64(value))
+		}
+		if value, ok := payload["currency"].(string); ok && len(value) > 0 {
+			q = q.Where(dao.GatewaySettlement.Columns().Currency, value)
+		}
+		if value, ok := payload["arrivalTimeStart"].(float64); ok {
+			q = q.WhereGTE(dao.GatewaySettlement.Columns().ArrivalTime, int64(value))
+		}
+		if value, ok := payload["arrivalTimeEnd"].(float64); ok {
+			q = q.WhereLT(dao.GatewaySettlement.Columns().ArrivalTime, int64(value))
+		}
+	}
+	var settlements []*entity.GatewaySettlement
+	err = q.Scan(&settlements)
+	if err != nil {
+		return nil, err
+	}
+	if len(settlements) == 0 {
+		return mainList, nil
+	}
+	var settlementMap = make(map[uint64]*entity.GatewaySettlement)
+	var settlementIds = make([]uint64, 0)
+	for _, one := range settlements {
+		settlementMap[one.Id] = one
+		settlementIds = append(settlementIds, one.Id)
+	}
+	var items []*entity.GatewaySettlementItem
+	err = dao.GatewaySettlementItem.Ctx(ctx).
+		WhereIn(dao.GatewaySettlementItem.Columns().SettlementId, settlementIds).
+		Where(dao.GatewaySettlementItem.Columns().IsDeleted, 0).
+		Order("settlement_id asc, transaction_time asc, id asc").
+		Limit(page*count, count).
+		Scan(&items)
+	if err != nil {
+		return nil, err
+	}
+	var gatewayNameMap = make(map[uint64]string)
+	for _, one := range items {
+		settlement := settlementMap[one.SettlementId]
+		if settlement == nil {
+			continue
+		}
+		if _, ok := gatewayNameMap[one.GatewayId]; !ok {
+			if gateway := query.GetGatewayById(ctx, one.GatewayId); gateway != nil {
+				gatewayNameMap[one.GatewayId] = gateway.GatewayName
+			}
+		}
+		mainList = append(mainList, &ExportSettlementEntity{
+			Gateway:              gatewayNameMap[one.GatewayId],
+			GatewaySettlementId:  settlement.GatewaySettlementId,
+			SettlementStatus:     consts.SettlementStatusEnum(settlement.Status).Description(),
+			ArrivalTime:          gtime.NewFromTimeStamp(settlement.ArrivalTime),
+			SettlementCurrency:   settlement.Currency,
+			SettlementNetAmount:  utility.ConvertCentToDollarStr(settlement.NetAmount, settlement.Currency),
+			GatewayTransactionId: one.GatewayTransactionId,
+			Type:                 one.Type,
+			PaymentId:            one.PaymentId,
+			RefundId:             one.RefundId,
+			InvoiceId:            one.InvoiceId,
+			GatewayPaymentId:     one.GatewayPaymentId,
+			GatewayRefundId:      one.GatewayRefundId,
+			Currency:             one.Currency,
+			GrossAmount:          utility.ConvertCentToDollarStr(one.GrossAmount, one.Currency),
+			FeeAmount:            utility.ConvertCentToDollarStr(one.FeeAmount, one.Currency),
+			NetAmount:            utility.ConvertCentToDollarStr(one.NetAmount, one.Currency),
+			TransactionTime:      gtime.NewFromTimeStamp(one.TransactionTime),
+			SettlementId:         fmt.Sprintf("%v", settlement.Id),
+		})
+	}
+	return mainList, nil
+}
+
+type ExportSettlementEntity struct {
+	Gateway              string      `json:"Gateway"              comment:""`
+	GatewaySettlementId  string      `json:"GatewaySettlementId"  comment:"The payout id of gateway"`
+	SettlementStatus     string      `json:"SettlementStatus"     comment:"Pending|Paid|Failed|Cancelled"`
+	ArrivalTime          *gtime.Time `json:"ArrivalTime"          layout:"2006-01-02 15:04:05" comment:"The arrival time of bank deposit"`
+	SettlementCurrency   string      `json:"SettlementCurrency"   comment:""`
+	SettlementNetAmount  string      `json:"SettlementNetAmount"  comment:"The amount of bank deposit"`
+	GatewayTransactionId string      `json:"GatewayTransactionId" comment:""`
+	Type                 string      `json:"Type"                 comment:"payment|refund|fee|dispute|adjustment|other"`
+	PaymentId            string      `json:"PaymentId"            comment:""`
+	RefundId             string      `json:"RefundId"             comment:""`
+	InvoiceId            string      `json:"InvoiceId"            comment:""`
+	GatewayPaymentId     string      `json:"GatewayPaymentId"     comment:""`
+	GatewayRefundId      string      `json:"GatewayRefundId"      comment:""`
+	Currency             string      `json:"Currency"             comment:""`
+	GrossAmount          string      `json:"GrossAmount"          comment:""`
+	FeeAmount            string      `json:"FeeAmount"            comment:""`
+	NetAmount            string      `json:"NetAmount"            comment:""`
+	TransactionTime      *gtime.Time `json:"TransactionTime"      layout:"2006-01-02 15:04:05" comment:""`
+	SettlementId         string      `json:"SettlementId"         comment:""`
+}
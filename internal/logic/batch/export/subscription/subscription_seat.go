@@ -0,0 +1,99 @@
+package subscription
+
+import (
+	"context"
+	"fmt"
+
+	"unibee/internal/consts"
+	"unibee/internal/logic/subscription/seat"
+	entity "unibee/internal/model/entity/default"
+	"unibee/internal/query"
+	"unibee/utility"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+type TaskSubscriptionSeatExport struct {
+}
+
+func (t TaskSubscriptionSeatExport) TaskName() string {
+	return "SubscriptionSeatExport"
+}
+
+func (t TaskSubscriptionSeatExport) Header() interface{} {
+	return ExportSubscriptionSeatEntity{}
+}
+
+// PageData exports the seat roster of the merchant, filtered by the subscriptionId and status of the payload
+func (t TaskSubscriptionSeatExport) PageData(ctx context.Context, page int, count int, task *entity.MerchantBatchTask) ([]interface{}, error) {
+	var mainList = make([]interface{}, 0)
+	if task == nil || task.MerchantId <= 0 {
+		return mainList, nil
+	}
+	var payload map[string]interface{}
+	err := utility.UnmarshalFromJsonString(task.Payload, &payload)
+	if err != nil {
+		g.Log().Errorf(ctx, "Download PageData error:%s", err.Error())
+		return mainList, nil
+	}
+	var subscriptionId = ""
+	var status = consts.SubSeatStatusAssigned
+	if payload != nil {
+		if value, ok := payload["subscriptionId"].(string); ok {
+			subscriptionId = value
+		}
+		if value, ok := payload["status"].(float64); ok {
+			status = int(value)
+		}
+	}
+	list, err := seat.GetSeatRoster(ctx, task.MerchantId, subscriptionId, status, page, count)
+	if err != nil {
+		return nil, err
+	}
+	for _, one := range list {
+		var userEmail = ""
+		if user := query.GetUserAccountById(ctx, one.UserId); user != nil {
+			userEmail = user.Email
+		}
+		var removeTime *gtime.Time
+		if one.RemoveTime > 0 {
+			removeTime = gtime.NewFromTimeStamp(one.RemoveTime)
+		}
+		mainList = append(mainList, &ExportSubscriptionSeatEntity{
+			SubscriptionId: one.SubscriptionId,
+			UserId:         fmt.Sprintf("%v", one.UserId),
+			UserEmail:      userEmail,
+			SeatId:         one.SeatId,
+			Email:          one.Email,
+			ExternalId:     one.ExternalId,
+			Name:           one.Name,
+			Status:         seatStatusName(one.Status),
+			AssignTime:     gtime.NewFromTimeStamp(one.AssignTime),
+			RemoveTime:     removeTime,
+		})
+	}
+	return mainList, nil
+}
+
+func seatStatusName(status int) string {
+	if status == consts.SubSeatStatusAssigned {
+		return "Assigned"
+	} else if status == consts.SubSeatStatusRemoved {
+		return "Removed"
+	}
+	return ""
+}
+
+type ExportSubscriptionSeatEntity struct {
+	SubscriptionId string      `json:"SubscriptionId" comment:""`
+	UserId         string      `json:"UserId"         comment:"The owner of subscription"`
+	UserEmail      string      `json:"UserEmail"      comment:"The email of subscription owner"`
+	SeatId         string      `json:"SeatId"         comment:""`
+	Email          string      `json:"Email"          comment:"The email of seat member"`
+	ExternalId     string      `json:"ExternalId"     comment:"The external id of seat member"`
+	Name           string      `json:"Name"           comment:""`
+	Status         string      `json:"Status"         comment:"Assigned|Removed"`
+	AssignTime     *gtime.Time `json:"AssignTime"     layout:"2006-01-02 15:04:05" comment:""`
+	RemoveTime     *gtime.Time `json:"RemoveTime"     layout:"2006-01-02 15:04:05" comment:""`
+}
@@ -15,6 +15,9 @@ import (
 	"unibee/internal/logic/batch/export/discount"
 	"unibee/internal/logic/batch/export/invoice"
 	plan2 "unibee/internal/logic/batch/export/plan"
+	"unibee/internal/logic/batch/export/receivable"
+	"unibee/internal/logic/batch/export/reconciliation"
+	"unibee/internal/logic/batch/export/settlement"
 	"unibee/internal/logic/batch/export/subscription"
 	"unibee/internal/logic/batch/export/transaction"
 	"unibee/internal/logic/batch/export/user"
@@ -31,16 +34,20 @@ import (
 )
 
 var exportTaskMap = map[string]_interface.BatchExportTask{
-	"InvoiceExport":           &invoice.TaskInvoiceV2Export{},
-	"UserExport":              &user.TaskUserExport{},
-	"SubscriptionExport":      &subscription.TaskSubscriptionV2Export{},
-	"TransactionExport":       &transaction.TaskTransactionV2Export{},
-	"DiscountExport":          &discount.TaskDiscountExport{},
-	"PlanExport":              &plan2.TaskPlanExport{},
-	"UserDiscountExport":      &discount.TaskUserDiscountV2Export{},
-	"MultiUserDiscountExport": &discount.TaskMultiUserDiscountV2Export{},
-	"CreditTransactionExport": &credit.TaskCreditTransactionV2Export{},
-	"CreditNoteExport":        &invoice.TaskCreditNoteV2Export{},
+	"InvoiceExport":               &invoice.TaskInvoiceV2Export{},
+	"UserExport":                  &user.TaskUserExport{},
+	"SubscriptionExport":          &subscription.TaskSubscriptionV2Export{},
+	"TransactionExport":           &transaction.TaskTransactionV2Export{},
+	"DiscountExport":              &discount.TaskDiscountExport{},
+	"PlanExport":                  &plan2.TaskPlanExport{},
+	"UserDiscountExport":          &discount.TaskUserDiscountV2Export{},
+	"MultiUserDiscountExport":     &discount.TaskMultiUserDiscountV2Export{},
+	"CreditTransactionExport":     &credit.TaskCreditTransactionV2Export{},
+	"CreditNoteExport":            &invoice.TaskCreditNoteV2Export{},
+	"GatewayReconciliationExport": &reconciliation.TaskGatewayReconciliationExport{},
+	"SettlementExport":            &settlement.TaskSettlementExport{},
+	"ReceivableAgeingExport":      &receivable.TaskReceivableAgeingExport{},
+	"SubscriptionSeatExport":      &subscription.TaskSubscriptionSeatExport{},
 }
 
 func GetExportTaskImpl(task string) _interface.BatchExportTask {
@@ -2,12 +2,14 @@ package batch
 
 import (
 	"context"
+	"encoding/csv"
 	"fmt"
 	"github.com/gogf/gf/v2/errors/gcode"
 	"github.com/gogf/gf/v2/errors/gerror"
 	"github.com/gogf/gf/v2/frame/g"
 	"github.com/gogf/gf/v2/os/gtime"
 	"github.com/xuri/excelize/v2"
+	"os"
 	"strconv"
 	"strings"
 	"unibee/internal/cmd/config"
@@ -15,6 +17,7 @@ import (
 	dao "unibee/internal/dao/default"
 	_interface "unibee/internal/interface"
 	plan2 "unibee/internal/logic/batch/_import/plan"
+	"unibee/internal/logic/batch/_import/settlement"
 	"unibee/internal/logic/batch/_import/subscription"
 	user2 "unibee/internal/logic/batch/_import/user"
 	"unibee/internal/logic/oss"
@@ -27,6 +30,7 @@ var importTaskMap = map[string]_interface.BatchImportTask{
 	"PlanImport":                plan2.TaskPlanImport{},
 	"ActiveSubscriptionImport":  subscription.TaskActiveSubscriptionImport{},
 	"HistorySubscriptionImport": subscription.TaskHistorySubscriptionImport{},
+	"GatewaySettlementImport":   settlement.TaskGatewaySettlementImport{},
 }
 
 func GetImportTaskImpl(task string) _interface.BatchImportTask {
@@ -154,13 +158,7 @@ func startRunImportTaskBackground(task *entity.MerchantBatchTask, taskImpl _inte
 			failureTask(ctx, task.Id, err)
 			return
 		}
-		reader, err := excelize.OpenFile(importFile)
-		if err != nil {
-			g.Log().Errorf(ctx, err.Error())
-			failureTask(ctx, task.Id, err)
-			return
-		}
-		readerRows, err := reader.GetRows(GeneralExportImportSheetName)
+		readerRows, err := readImportRows(importFile)
 		if err != nil {
 			g.Log().Errorf(ctx, err.Error())
 			failureTask(ctx, task.Id, err)
@@ -241,6 +239,31 @@ func startRunImportTaskBackground(task *entity.MerchantBatchTask, taskImpl _inte
 	}()
 }
 
+// readImportRows reads the rows of the uploaded xlsx file, or of a csv file exported by gateways and banks
+func readImportRows(importFile string) ([][]string, error) {
+	if strings.HasSuffix(strings.ToLower(importFile), ".csv") {
+		file, err := os.Open(importFile)
+		if err != nil {
+			return nil, err
+		}
+		defer func() {
+			_ = file.Close()
+		}()
+		reader := csv.NewReader(file)
+		reader.FieldsPerRecord = -1
+		rows, err := reader.ReadAll()
+		if err == nil && len(rows) > 0 && len(rows[0]) > 0 {
+			rows[0][0] = strings.TrimPrefix(rows[0][0], "\uFEFF")
+		}
+		return rows, err
+	}
+	reader, err := excelize.OpenFile(importFile)
+	if err != nil {
+		return nil, err
+	}
+	return reader.GetRows(GeneralExportImportSheetName)
+}
+
 func ProxyImportRow(ctx context.Context, taskImpl _interface.BatchImportTask, task *entity.MerchantBatchTask, target map[string]string) (data interface{}, err error) {
 	defer func() {
 		if exception := recover(); exception != nil {
@@ -38,6 +38,7 @@ const (
 	TemplateNewProcessingInvoiceAfterTrial                  = "NewProcessingInvoiceAfterTrial"
 	TemplateNewProcessingInvoiceForWireTransfer             = "NewProcessingInvoiceForWireTransfer"
 	TemplateInvoiceCancel                                   = "InvoiceCancel"
+	TemplateInvoiceOverdueReminder                          = "InvoiceOverdueReminder"
 	TemplateMerchantRegistrationCodeVerify                  = "MerchantRegistrationCodeVerify"
 	TemplateMerchantOTPLogin                                = "MerchantOTPLogin"
 	TemplateUserRegistrationCodeVerify                      = "UserRegistrationCodeVerify"
@@ -50,6 +51,8 @@ const (
 	TemplateSubscriptionUpdate                              = "SubscriptionUpdate"
 	TemplateSubscriptionNeedAuthorized                      = "SubscriptionNeedAuthorized"
 	TemplateSubscriptionTrialStart                          = "SubscriptionTrialStart"
+	TemplateSubscriptionTrialEndReminder                    = "SubscriptionTrialEndReminder"
+	TemplateSubscriptionCommitmentRenewalReminder           = "SubscriptionCommitmentRenewalReminder"
 	TemplateInvoiceRefundCreated                            = "InvoiceRefundCreated"
 	TemplateInvoiceRefundPaid                               = "InvoiceRefundPaid"
 	TemplateMerchantMemberInvite                            = "MerchantMemberInvite"
@@ -22,7 +22,10 @@ VALUES
     ('3303', 'NewProcessingInvoiceForWireTransfer', 'Email providing wire transfer bank details for invoice payment.', 'Wire Transfer Details from {Merchant Product Name}', '<p>Hi,&nbsp;{User&nbsp;name}!</p>\n\n<p>Thank&nbsp;you&nbsp;for&nbsp;choosing&nbsp;{Merchant&nbsp;Product&nbsp;Name}&nbsp;plan.&nbsp;You&nbsp;can&nbsp;view&nbsp;your&nbsp;invoice&nbsp;in&nbsp;your&nbsp;billing&nbsp;dashboard&nbsp;under&nbsp;the&nbsp;“Invoices”&nbsp;section.</p>\n\n<p>Here&nbsp;are&nbsp;our&nbsp;bank&nbsp;account&nbsp;details&nbsp;for&nbsp;the&nbsp;wire&nbsp;transfer:</p>\n\n<p>Account&nbsp;holder:&nbsp;{Account&nbsp;Holder}</p>\n\n<p>BIC:&nbsp;{BIC}</p>\n\n<p>IBAN:&nbsp;{IBAN}</p>\n\n<p>Wise&#39;s&nbsp;address:&nbsp;{Address}</p>\n\n<p>In&nbsp;case&nbsp;of&nbsp;any&nbsp;questions,&nbsp;do&nbsp;not&nbsp;ever&nbsp;hesitate&nbsp;to&nbsp;email&nbsp;us&nbsp;-&nbsp;{Merchant’s&nbsp;customer&nbsp;support&nbsp;email&nbsp;address}</p>\n\n<p>Important:&nbsp;do&nbsp;NOT&nbsp;reply&nbsp;to&nbsp;this&nbsp;email,&nbsp;use&nbsp;the&nbsp;contacts&nbsp;mentioned&nbsp;above&nbsp;instead.</p>\n\n<p>{Merchant&nbsp;Name}&nbsp;Team</p>', NULL, '2024-01-25 16:20:10', '2025-07-22 07:39:15', '0', NULL),
     ('3304', 'NewProcessingInvoiceForPaidTrial', 'Invoice email for a paid trial subscription, requiring payment.', 'Your Invoice for {Merchant Product Name} Trial', '<p>Hi,&nbsp;{User&nbsp;name}!&nbsp;</p>\n\n<p>Thank&nbsp;you&nbsp;for&nbsp;choosing&nbsp;{Merchant&nbsp;Product&nbsp;Name}&nbsp;Trial.</p>\n\n<p>Please&nbsp;check&nbsp;the&nbsp;attached&nbsp;invoice&nbsp;and&nbsp;send&nbsp;the&nbsp;payment.&nbsp;Once&nbsp;we&nbsp;receive&nbsp;the&nbsp;payment,&nbsp;your&nbsp;trial&nbsp;will&nbsp;be&nbsp;activated.&nbsp;</p>\n\n<p>Please&nbsp;click&nbsp;the&nbsp;following&nbsp;link&nbsp;and&nbsp;process&nbsp;the&nbsp;payment:&nbsp;{Link}</p>\n\n<p>The&nbsp;invoice&nbsp;needs&nbsp;to&nbsp;be&nbsp;paid&nbsp;before&nbsp;the&nbsp;due&nbsp;date&nbsp;{PeriodEnd}&nbsp;to&nbsp;avoid&nbsp;possible&nbsp;interruptions&nbsp;while&nbsp;working&nbsp;with&nbsp;{Merchant&nbsp;Product&nbsp;Name}.&nbsp;</p>\n\n<p>In&nbsp;case&nbsp;of&nbsp;any&nbsp;questions,&nbsp;do&nbsp;not&nbsp;hesitate&nbsp;to&nbsp;contact&nbsp;us&nbsp;-&nbsp;{Merchant’s&nbsp;customer&nbsp;support&nbsp;email&nbsp;address}.</p>\n\n<p>Important:&nbsp;do&nbsp;NOT&nbsp;reply&nbsp;to&nbsp;this&nbsp;email,&nbsp;use&nbsp;the&nbsp;contact&nbsp;mentioned&nbsp;above&nbsp;instead.</p>\n\n<p>Thank&nbsp;you,</p>\n\n<p>{Merchant&nbsp;Name}</p>', NULL, '2024-01-25 16:20:10', '2025-07-22 07:39:16', '0', NULL),
     ('3305', 'SubscriptionTrialStart', 'Confirmation that a trial subscription has been successfully activated.', 'Your {Merchant Product Name} Trial is activated.', '<p>Hi,&nbsp;{User&nbsp;name}!&nbsp;</p>\n\n<p>Your&nbsp;{Merchant&nbsp;Product&nbsp;Name}&nbsp;Trial&nbsp;has&nbsp;been&nbsp;activated.</p>\n\n<p>In&nbsp;case&nbsp;of&nbsp;any&nbsp;questions,&nbsp;do&nbsp;not&nbsp;hesitate&nbsp;to&nbsp;contact&nbsp;us&nbsp;-&nbsp;{Merchant’s&nbsp;customer&nbsp;support&nbsp;email&nbsp;address}.</p>\n\n<p>Important:&nbsp;do&nbsp;NOT&nbsp;reply&nbsp;to&nbsp;this&nbsp;email,&nbsp;use&nbsp;the&nbsp;contact&nbsp;mentioned&nbsp;above&nbsp;instead.</p>\n\n<p>Thank&nbsp;you,</p>\n\n<p>{Merchant&nbsp;Name}</p>', NULL, '2024-01-25 16:20:10', '2025-07-22 07:39:16', '0', NULL),
-    ('3306', 'NewProcessingInvoiceAfterTrial', 'Invoice email sent after a trial period ends, for continuing subscription.', 'Welcome to continue using {Merchant Product Name} ', '<p>Hi,&nbsp;{User&nbsp;name}!&nbsp;</p>\n\n<p>Your&nbsp;{Merchant&nbsp;Product&nbsp;Name}&nbsp;Trial&nbsp;will&nbsp;be&nbsp;ended.&nbsp;</p>\n\n<p>Attached&nbsp;is&nbsp;the&nbsp;invoice&nbsp;for&nbsp;the&nbsp;subscription&nbsp;plan.&nbsp;Once&nbsp;we&nbsp;receive&nbsp;your&nbsp;payment,&nbsp;your&nbsp;subscription&nbsp;will&nbsp;continue&nbsp;activated.&nbsp;</p>\n\n<p>Please&nbsp;click&nbsp;the&nbsp;following&nbsp;link&nbsp;and&nbsp;process&nbsp;the&nbsp;payment:&nbsp;{Link}</p>\n\n<p>The&nbsp;invoice&nbsp;needs&nbsp;to&nbsp;be&nbsp;paid&nbsp;before&nbsp;the&nbsp;due&nbsp;date&nbsp;{PeriodEnd}&nbsp;to&nbsp;avoid&nbsp;possible&nbsp;interruptions&nbsp;while&nbsp;working&nbsp;with&nbsp;{Merchant&nbsp;Product&nbsp;Name}.&nbsp;</p>\n\n<p>In&nbsp;case&nbsp;of&nbsp;any&nbsp;questions,&nbsp;do&nbsp;not&nbsp;hesitate&nbsp;to&nbsp;contact&nbsp;us&nbsp;-&nbsp;{Merchant’s&nbsp;customer&nbsp;support&nbsp;email&nbsp;address}.</p>\n\n<p>Important:&nbsp;do&nbsp;NOT&nbsp;reply&nbsp;to&nbsp;this&nbsp;email,&nbsp;use&nbsp;the&nbsp;contact&nbsp;mentioned&nbsp;above&nbsp;instead.</p>\n\n<p>Thank&nbsp;you,</p>\n\n<p>{Merchant&nbsp;Name}</p>', NULL, '2024-01-25 16:20:10', '2025-07-22 07:39:16', '0', NULL);
+    ('3306', 'NewProcessingInvoiceAfterTrial', 'Invoice email sent after a trial period ends, for continuing subscription.', 'Welcome to continue using {Merchant Product Name} ', '<p>Hi,&nbsp;{User&nbsp;name}!&nbsp;</p>\n\n<p>Your&nbsp;{Merchant&nbsp;Product&nbsp;Name}&nbsp;Trial&nbsp;will&nbsp;be&nbsp;ended.&nbsp;</p>\n\n<p>Attached&nbsp;is&nbsp;the&nbsp;invoice&nbsp;for&nbsp;the&nbsp;subscription&nbsp;plan.&nbsp;Once&nbsp;we&nbsp;receive&nbsp;your&nbsp;payment,&nbsp;your&nbsp;subscription&nbsp;will&nbsp;continue&nbsp;activated.&nbsp;</p>\n\n<p>Please&nbsp;click&nbsp;the&nbsp;following&nbsp;link&nbsp;and&nbsp;process&nbsp;the&nbsp;payment:&nbsp;{Link}</p>\n\n<p>The&nbsp;invoice&nbsp;needs&nbsp;to&nbsp;be&nbsp;paid&nbsp;before&nbsp;the&nbsp;due&nbsp;date&nbsp;{PeriodEnd}&nbsp;to&nbsp;avoid&nbsp;possible&nbsp;interruptions&nbsp;while&nbsp;working&nbsp;with&nbsp;{Merchant&nbsp;Product&nbsp;Name}.&nbsp;</p>\n\n<p>In&nbsp;case&nbsp;of&nbsp;any&nbsp;questions,&nbsp;do&nbsp;not&nbsp;hesitate&nbsp;to&nbsp;contact&nbsp;us&nbsp;-&nbsp;{Merchant’s&nbsp;customer&nbsp;support&nbsp;email&nbsp;address}.</p>\n\n<p>Important:&nbsp;do&nbsp;NOT&nbsp;reply&nbsp;to&nbsp;this&nbsp;email,&nbsp;use&nbsp;the&nbsp;contact&nbsp;mentioned&nbsp;above&nbsp;instead.</p>\n\n<p>Thank&nbsp;you,</p>\n\n<p>{Merchant&nbsp;Name}</p>', NULL, '2024-01-25 16:20:10', '2025-07-22 07:39:16', '0', NULL),
+    ('3307', 'InvoiceOverdueReminder', 'Reminder to user about a net terms invoice past its due date.', 'Reminder - Your Invoice {Invoice Number} is Overdue', '<p>Hi,&nbsp;{User&nbsp;name}!</p>\n\n<p>This&nbsp;is&nbsp;a&nbsp;reminder&nbsp;that&nbsp;your&nbsp;invoice&nbsp;{Invoice&nbsp;Number}&nbsp;for&nbsp;{Merchant&nbsp;Product&nbsp;Name}&nbsp;was&nbsp;due&nbsp;on&nbsp;{PeriodEnd}&nbsp;and&nbsp;is&nbsp;still&nbsp;outstanding.</p>\n\n<p>Attached&nbsp;is&nbsp;the&nbsp;invoice.&nbsp;Please&nbsp;click&nbsp;the&nbsp;following&nbsp;link&nbsp;and&nbsp;process&nbsp;the&nbsp;payment:&nbsp;{Link}</p>\n\n<p>If&nbsp;you&nbsp;have&nbsp;already&nbsp;paid,&nbsp;please&nbsp;ignore&nbsp;this&nbsp;email.&nbsp;In&nbsp;case&nbsp;of&nbsp;any&nbsp;questions,&nbsp;do&nbsp;not&nbsp;hesitate&nbsp;to&nbsp;contact&nbsp;us&nbsp;-&nbsp;{Merchant’s&nbsp;customer&nbsp;support&nbsp;email&nbsp;address}.</p>\n\n<p>Important:&nbsp;do&nbsp;NOT&nbsp;reply&nbsp;to&nbsp;this&nbsp;email,&nbsp;use&nbsp;the&nbsp;contact&nbsp;mentioned&nbsp;above&nbsp;instead.</p>\n\n<p>Thank&nbsp;you,</p>\n\n<p>{Merchant&nbsp;Name}</p>', NULL, '2026-10-19 00:00:00', '2026-10-19 00:00:00', '0', NULL),
+    ('3308', 'SubscriptionTrialEndReminder', 'Reminder to user that the trial without payment method is ending soon.', 'Your {Merchant Product Name} Trial Ends Soon', '<p>Hi,&nbsp;{User&nbsp;name}!</p>\n\n<p>Your&nbsp;{Merchant&nbsp;Product&nbsp;Name}&nbsp;trial&nbsp;ends&nbsp;on&nbsp;{PeriodEnd}&nbsp;and&nbsp;no&nbsp;payment&nbsp;method&nbsp;is&nbsp;attached&nbsp;yet.</p>\n\n<p>To&nbsp;keep&nbsp;using&nbsp;{Merchant&nbsp;Product&nbsp;Name}&nbsp;without&nbsp;interruption,&nbsp;please&nbsp;click&nbsp;the&nbsp;following&nbsp;link&nbsp;and&nbsp;add&nbsp;your&nbsp;payment&nbsp;method:&nbsp;{Link}</p>\n\n<p>In&nbsp;case&nbsp;of&nbsp;any&nbsp;questions,&nbsp;do&nbsp;not&nbsp;hesitate&nbsp;to&nbsp;contact&nbsp;us&nbsp;-&nbsp;{Merchant’s&nbsp;customer&nbsp;support&nbsp;email&nbsp;address}.</p>\n\n<p>Important:&nbsp;do&nbsp;NOT&nbsp;reply&nbsp;to&nbsp;this&nbsp;email,&nbsp;use&nbsp;the&nbsp;contact&nbsp;mentioned&nbsp;above&nbsp;instead.</p>\n\n<p>Thank&nbsp;you,</p>\n\n<p>{Merchant&nbsp;Name}</p>', NULL, '2026-10-19 00:00:00', '2026-10-19 00:00:00', '0', NULL),
+    ('3309', 'SubscriptionCommitmentRenewalReminder', 'Reminder to user that the commitment term of subscription renews soon.', 'Your {Merchant Product Name} Commitment Term Renews Soon', '<p>Hi,&nbsp;{User&nbsp;name}!</p>\n\n<p>Your&nbsp;commitment&nbsp;term&nbsp;of&nbsp;{Merchant&nbsp;Product&nbsp;Name}&nbsp;ends&nbsp;on&nbsp;{PeriodEnd}.</p>\n\n<p>The&nbsp;term&nbsp;renews&nbsp;automatically&nbsp;at&nbsp;its&nbsp;end&nbsp;unless&nbsp;the&nbsp;cancellation&nbsp;is&nbsp;noticed&nbsp;within&nbsp;the&nbsp;notice&nbsp;period&nbsp;of&nbsp;your&nbsp;contract.&nbsp;No&nbsp;action&nbsp;is&nbsp;needed&nbsp;if&nbsp;you&nbsp;would&nbsp;like&nbsp;to&nbsp;continue&nbsp;with&nbsp;{Merchant&nbsp;Product&nbsp;Name}.</p>\n\n<p>In&nbsp;case&nbsp;of&nbsp;any&nbsp;questions,&nbsp;do&nbsp;not&nbsp;hesitate&nbsp;to&nbsp;contact&nbsp;us&nbsp;-&nbsp;{Merchant’s&nbsp;customer&nbsp;support&nbsp;email&nbsp;address}.</p>\n\n<p>Important:&nbsp;do&nbsp;NOT&nbsp;reply&nbsp;to&nbsp;this&nbsp;email,&nbsp;use&nbsp;the&nbsp;contact&nbsp;mentioned&nbsp;above&nbsp;instead.</p>\n\n<p>Thank&nbsp;you,</p>\n\n<p>{Merchant&nbsp;Name}</p>', NULL, '2026-10-19 00:00:00', '2026-10-19 00:00:00', '0', NULL);
 
 
 
@@ -0,0 +1,92 @@
+package adapter
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/stretchr/testify/require"
+	"unibee/internal/consts"
+	"unibee/internal/logic/gateway/gateway_bean"
+	entity "unibee/internal/model/entity/default"
+)
+
+func TestSignature(t *testing.T) {
+	now := gtime.Now().Timestamp()
+	body := []byte(`{"action":"payment.detail"}`)
+	signature := Sign("secret", now, body)
+	require.Nil(t, Verify("secret", gtime.NewFromTimeStamp(now).TimestampStr(), signature, body, now))
+	require.NotNil(t, Verify("other", gtime.NewFromTimeStamp(now).TimestampStr(), signature, body, now))
+	require.NotNil(t, Verify("secret", gtime.NewFromTimeStamp(now).TimestampStr(), signature, []byte(`{}`), now))
+	require.NotNil(t, Verify("secret", gtime.NewFromTimeStamp(now).TimestampStr(), signature, body, now+SignatureTolerance+1))
+	require.NotNil(t, Verify("secret", "", signature, body, now))
+}
+
+func TestStubPaymentFlow(t *testing.T) {
+	ctx := context.Background()
+	stub := NewStub("secret")
+	server := httptest.NewServer(stub)
+	defer server.Close()
+
+	t.Run("Test Reject Invalid Signature", func(t *testing.T) {
+		_, err := Call(ctx, nil, server.URL, "wrong", 1, ActionGatewayTest, &GatewayTestData{}, nil)
+		require.NotNil(t, err)
+	})
+	t.Run("Test Create Capture Refund", func(t *testing.T) {
+		var created *gateway_bean.GatewayNewPaymentResp
+		_, err := Call(ctx, nil, server.URL, "secret", 1, ActionPaymentCreate, &PaymentCreateData{
+			Payment: &entity.Payment{PaymentId: "pay_local", Currency: "EUR", TotalAmount: 1000},
+		}, &created)
+		require.Nil(t, err)
+		require.NotNil(t, created)
+		require.Equal(t, consts.PaymentStatusEnum(consts.PaymentCreated), created.Status)
+
+		var captured *gateway_bean.GatewayPaymentCaptureResp
+		_, err = Call(ctx, nil, server.URL, "secret", 1, ActionPaymentCapture, &PaymentData{GatewayPaymentId: created.GatewayPaymentId}, &captured)
+		require.Nil(t, err)
+		require.Equal(t, int64(1000), captured.Amount)
+
+		var detail *gateway_bean.GatewayPaymentRo
+		_, err = Call(ctx, nil, server.URL, "secret", 1, ActionPaymentDetail, &PaymentData{GatewayPaymentId: created.GatewayPaymentId}, &detail)
+		require.Nil(t, err)
+		require.Equal(t, consts.PaymentSuccess, detail.Status)
+		require.NotNil(t, detail.PaidTime)
+
+		var refund *gateway_bean.GatewayPaymentRefundResp
+		_, err = Call(ctx, nil, server.URL, "secret", 1, ActionRefundCreate, &RefundCreateData{
+			Payment: &entity.Payment{GatewayPaymentId: created.GatewayPaymentId},
+			Refund:  &entity.Refund{RefundAmount: 1500, Currency: "EUR"},
+		}, &refund)
+		require.NotNil(t, err)
+		_, err = Call(ctx, nil, server.URL, "secret", 1, ActionRefundCreate, &RefundCreateData{
+			Payment: &entity.Payment{GatewayPaymentId: created.GatewayPaymentId},
+			Refund:  &entity.Refund{RefundAmount: 400, Currency: "EUR"},
+		}, &refund)
+		require.Nil(t, err)
+		require.Equal(t, consts.RefundStatusEnum(consts.RefundSuccess), refund.Status)
+
+		var refunds []*gateway_bean.GatewayPaymentRefundResp
+		_, err = Call(ctx, nil, server.URL, "secret", 1, ActionRefundList, &RefundData{GatewayPaymentId: created.GatewayPaymentId}, &refunds)
+		require.Nil(t, err)
+		require.Equal(t, 1, len(refunds))
+	})
+	t.Run("Test Webhook Signed", func(t *testing.T) {
+		var received int
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			if Verify("secret", r.Header.Get(HeaderTimestamp), r.Header.Get(HeaderSignature), body, gtime.Now().Timestamp()) == nil {
+				received++
+			}
+		}))
+		defer receiver.Close()
+		_, err := Call(ctx, nil, server.URL, "secret", 1, ActionWebhookSetup, &WebhookSetupData{WebhookUrl: receiver.URL}, nil)
+		require.Nil(t, err)
+		status, err := stub.SendWebhook(ctx, &WebhookEvent{Event: EventPaymentUpdated, GatewayPaymentId: "pay_1"})
+		require.Nil(t, err)
+		require.Equal(t, http.StatusOK, status)
+		require.Equal(t, 1, received)
+	})
+}
@@ -0,0 +1,81 @@
+package adapter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gtime"
+	"unibee/utility"
+)
+
+var httpClient = &http.Client{Timeout: 30 * time.Second}
+
+// Call sends a signed action request to the adapter and decodes the response data into result,
+// the request goes through client when not nil, otherwise through the default client
+func Call(ctx context.Context, client *http.Client, adapterUrl string, secret string, gatewayId uint64, action string, data interface{}, result interface{}) (responseBody string, err error) {
+	if len(adapterUrl) == 0 {
+		return "", gerror.New("adapter url not setup")
+	}
+	if !strings.HasPrefix(adapterUrl, "http://") && !strings.HasPrefix(adapterUrl, "https://") {
+		return "", gerror.Newf("invalid adapter url:%s", adapterUrl)
+	}
+	body, err := json.Marshal(&Request{
+		Version:   ProtocolVersion,
+		Action:    action,
+		GatewayId: gatewayId,
+		RequestId: utility.CreateRequestId(),
+		Data:      data,
+	})
+	if err != nil {
+		return "", err
+	}
+	timestamp := gtime.Now().Timestamp()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, adapterUrl, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderVersion, ProtocolVersion)
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(secret, timestamp, body))
+	if client == nil {
+		client = httpClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", gerror.Newf("adapter read response body error:%v", err)
+	}
+	responseBody = string(respBody)
+	if resp.StatusCode != http.StatusOK {
+		return responseBody, gerror.Newf("adapter http status:%d body:%s", resp.StatusCode, responseBody)
+	}
+	var envelope struct {
+		Success bool            `json:"success"`
+		Error   string          `json:"error"`
+		Data    json.RawMessage `json:"data"`
+	}
+	if err = json.Unmarshal(respBody, &envelope); err != nil {
+		return responseBody, gerror.Newf("adapter invalid response:%s", responseBody)
+	}
+	if !envelope.Success {
+		return responseBody, gerror.Newf("adapter %s failed:%s", action, envelope.Error)
+	}
+	if result != nil && len(envelope.Data) > 0 && string(envelope.Data) != "null" {
+		if err = json.Unmarshal(envelope.Data, result); err != nil {
+			return responseBody, gerror.Newf("adapter %s invalid response data:%v", action, err)
+		}
+	}
+	return responseBody, nil
+}
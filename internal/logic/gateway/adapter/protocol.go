@@ -0,0 +1,174 @@
+package adapter
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"unibee/internal/logic/gateway/gateway_bean"
+	entity "unibee/internal/model/entity/default"
+)
+
+// External gateway adapter protocol, see docs/remote-adapter-protocol.md
+//
+// Every call is a POST of a JSON Request to the adapter url configured on the merchant gateway,
+// signed with the gateway secret, the adapter answers with a JSON Response envelope.
+
+const (
+	ProtocolVersion = "1"
+
+	HeaderVersion   = "X-UniBee-Adapter-Version"
+	HeaderTimestamp = "X-UniBee-Timestamp"
+	HeaderSignature = "X-UniBee-Signature"
+
+	// SignatureTolerance is the max clock skew in seconds accepted for signed requests and webhooks
+	SignatureTolerance = 5 * 60
+)
+
+const (
+	ActionGatewayTest                = "gateway.test"
+	ActionWebhookSetup               = "webhook.setup"
+	ActionUserCreate                 = "user.create"
+	ActionUserDetail                 = "user.detail"
+	ActionMerchantBalances           = "merchant.balances"
+	ActionPaymentMethodAttach        = "payment_method.attach"
+	ActionPaymentMethodDetach        = "payment_method.detach"
+	ActionPaymentMethodList          = "payment_method.list"
+	ActionPaymentMethodCreateAndBind = "payment_method.create_and_bind"
+	ActionPaymentCreate              = "payment.create"
+	ActionPaymentCapture             = "payment.capture"
+	ActionPaymentCancel              = "payment.cancel"
+	ActionPaymentList                = "payment.list"
+	ActionPaymentDetail              = "payment.detail"
+	ActionRefundCreate               = "refund.create"
+	ActionRefundDetail               = "refund.detail"
+	ActionRefundList                 = "refund.list"
+	ActionRefundCancel               = "refund.cancel"
+)
+
+const (
+	EventPaymentUpdated = "payment.updated"
+	EventRefundUpdated  = "refund.updated"
+)
+
+type Request struct {
+	Version   string      `json:"version"`
+	Action    string      `json:"action"`
+	GatewayId uint64      `json:"gatewayId"`
+	RequestId string      `json:"requestId"`
+	Data      interface{} `json:"data"`
+}
+
+type Response struct {
+	Success bool        `json:"success"`
+	Error   string      `json:"error"`
+	Data    interface{} `json:"data"`
+}
+
+type WebhookEvent struct {
+	Event            string `json:"event"`
+	PaymentId        string `json:"paymentId"`
+	GatewayPaymentId string `json:"gatewayPaymentId"`
+	GatewayRefundId  string `json:"gatewayRefundId"`
+}
+
+type GatewayTestData struct {
+	SubGateway string `json:"subGateway"`
+}
+
+type GatewayTestResult struct {
+	Icon        string `json:"icon"`
+	GatewayType int64  `json:"gatewayType"`
+}
+
+type WebhookSetupData struct {
+	WebhookUrl string `json:"webhookUrl"`
+}
+
+type UserData struct {
+	User *entity.UserAccount `json:"user"`
+}
+
+type GatewayUserData struct {
+	GatewayUserId string `json:"gatewayUserId"`
+}
+
+type PaymentMethodData struct {
+	UserId               uint64 `json:"userId"`
+	GatewayPaymentMethod string `json:"gatewayPaymentMethod"`
+}
+
+type PaymentMethodCreateAndBindData struct {
+	UserId   uint64                 `json:"userId"`
+	Currency string                 `json:"currency"`
+	Metadata map[string]interface{} `json:"metadata"`
+}
+
+type PaymentCreateData struct {
+	Payment              *entity.Payment                       `json:"payment"`
+	Invoice              interface{}                           `json:"invoice"`
+	Email                string                                `json:"email"`
+	ExternalUserId       string                                `json:"externalUserId"`
+	GatewayPaymentMethod string                                `json:"gatewayPaymentMethod"`
+	GatewayPaymentType   string                                `json:"gatewayPaymentType"`
+	PayImmediate         bool                                  `json:"payImmediate"`
+	ExchangeAmount       int64                                 `json:"exchangeAmount"`
+	ExchangeCurrency     string                                `json:"exchangeCurrency"`
+	CurrencyExchange     *gateway_bean.GatewayCurrencyExchange `json:"currencyExchange"`
+	Metadata             map[string]interface{}                `json:"metadata"`
+}
+
+type PaymentData struct {
+	GatewayPaymentId string          `json:"gatewayPaymentId"`
+	Payment          *entity.Payment `json:"payment"`
+}
+
+type PaymentListData struct {
+	UserId          uint64 `json:"userId"`
+	CreateTimeStart int64  `json:"createTimeStart"`
+	CreateTimeEnd   int64  `json:"createTimeEnd"`
+}
+
+type RefundCreateData struct {
+	Payment                *entity.Payment `json:"payment"`
+	Refund                 *entity.Refund  `json:"refund"`
+	ExchangeRefundAmount   int64           `json:"exchangeRefundAmount"`
+	ExchangeRefundCurrency string          `json:"exchangeRefundCurrency"`
+}
+
+type RefundData struct {
+	GatewayRefundId  string          `json:"gatewayRefundId"`
+	GatewayPaymentId string          `json:"gatewayPaymentId"`
+	Payment          *entity.Payment `json:"payment"`
+	Refund           *entity.Refund  `json:"refund"`
+}
+
+// Sign returns the hex encoded HMAC-SHA256 of "timestamp.body" with the gateway secret
+func Sign(secret string, timestamp int64, body []byte) string {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	h.Write([]byte("."))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func Verify(secret string, timestampHeader string, signature string, body []byte, now int64) error {
+	if len(secret) == 0 {
+		return fmt.Errorf("adapter secret not setup")
+	}
+	if len(timestampHeader) == 0 || len(signature) == 0 {
+		return fmt.Errorf("signature headers missing")
+	}
+	timestamp, err := strconv.ParseInt(timestampHeader, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid timestamp:%s", timestampHeader)
+	}
+	if now-timestamp > SignatureTolerance || timestamp-now > SignatureTolerance {
+		return fmt.Errorf("timestamp out of tolerance")
+	}
+	if !hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature)) {
+		return fmt.Errorf("signature mismatch")
+	}
+	return nil
+}
@@ -0,0 +1,266 @@
+package adapter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/gogf/gf/v2/os/gtime"
+	"unibee/internal/consts"
+	"unibee/internal/logic/gateway/gateway_bean"
+)
+
+// Stub is a local in-memory adapter implementing the protocol, used by tests and for adapter development.
+// Payments are created in PaymentCreated status and moved by SetPaymentStatus, refunds succeed immediately.
+type Stub struct {
+	Secret     string
+	WebhookUrl string
+	Actions    []string
+
+	lock     sync.Mutex
+	sequence int
+	payments map[string]*gateway_bean.GatewayPaymentRo
+	refunds  map[string]*gateway_bean.GatewayPaymentRefundResp
+}
+
+func NewStub(secret string) *Stub {
+	return &Stub{
+		Secret:   secret,
+		payments: make(map[string]*gateway_bean.GatewayPaymentRo),
+		refunds:  make(map[string]*gateway_bean.GatewayPaymentRefundResp),
+	}
+}
+
+func (s *Stub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	err = Verify(s.Secret, r.Header.Get(HeaderTimestamp), r.Header.Get(HeaderSignature), body, gtime.Now().Timestamp())
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+	var req struct {
+		Action string          `json:"action"`
+		Data   json.RawMessage `json:"data"`
+	}
+	if err = json.Unmarshal(body, &req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	s.lock.Lock()
+	s.Actions = append(s.Actions, req.Action)
+	data, err := s.handle(req.Action, req.Data)
+	s.lock.Unlock()
+	res := &Response{Success: err == nil, Data: data}
+	if err != nil {
+		res.Error = err.Error()
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(res)
+}
+
+func (s *Stub) nextId(prefix string) string {
+	s.sequence++
+	return fmt.Sprintf("%s_%d", prefix, s.sequence)
+}
+
+func (s *Stub) handle(action string, raw json.RawMessage) (interface{}, error) {
+	switch action {
+	case ActionGatewayTest:
+		return &GatewayTestResult{GatewayType: consts.GatewayTypeCard}, nil
+	case ActionWebhookSetup:
+		var data *WebhookSetupData
+		if err := json.Unmarshal(raw, &data); err != nil {
+			return nil, err
+		}
+		s.WebhookUrl = data.WebhookUrl
+		return nil, nil
+	case ActionUserCreate:
+		var data *UserData
+		if err := json.Unmarshal(raw, &data); err != nil || data.User == nil {
+			return nil, fmt.Errorf("invalid user")
+		}
+		return &gateway_bean.GatewayUserCreateResp{GatewayUserId: "cus_" + strconv.FormatUint(data.User.Id, 10)}, nil
+	case ActionUserDetail:
+		var data *GatewayUserData
+		if err := json.Unmarshal(raw, &data); err != nil {
+			return nil, err
+		}
+		return &gateway_bean.GatewayUserDetailQueryResp{GatewayUserId: data.GatewayUserId}, nil
+	case ActionMerchantBalances:
+		return &gateway_bean.GatewayMerchantBalanceQueryResp{}, nil
+	case ActionPaymentMethodAttach, ActionPaymentMethodDetach:
+		return struct{}{}, nil
+	case ActionPaymentMethodList:
+		return &gateway_bean.GatewayUserPaymentMethodListResp{PaymentMethods: []*gateway_bean.PaymentMethod{}}, nil
+	case ActionPaymentMethodCreateAndBind:
+		return nil, fmt.Errorf("not support")
+	case ActionPaymentCreate:
+		var data *PaymentCreateData
+		if err := json.Unmarshal(raw, &data); err != nil || data.Payment == nil {
+			return nil, fmt.Errorf("invalid payment")
+		}
+		one := &gateway_bean.GatewayPaymentRo{
+			Status:           consts.PaymentCreated,
+			Currency:         data.Payment.Currency,
+			TotalAmount:      data.Payment.TotalAmount,
+			PaymentAmount:    data.Payment.TotalAmount,
+			CreateTime:       gtime.Now(),
+			GatewayPaymentId: s.nextId("pay"),
+		}
+		if data.PayImmediate && len(data.GatewayPaymentMethod) > 0 {
+			one.Status = consts.PaymentSuccess
+			one.PaidTime = gtime.Now()
+			one.GatewayPaymentMethod = data.GatewayPaymentMethod
+		}
+		s.payments[one.GatewayPaymentId] = one
+		return &gateway_bean.GatewayNewPaymentResp{
+			Status:           consts.PaymentStatusEnum(one.Status),
+			GatewayPaymentId: one.GatewayPaymentId,
+			Link:             fmt.Sprintf("https://stub.adapter.local/checkout/%s", one.GatewayPaymentId),
+		}, nil
+	case ActionPaymentCapture:
+		one, err := s.payment(raw)
+		if err != nil {
+			return nil, err
+		}
+		one.Status = consts.PaymentSuccess
+		one.PaidTime = gtime.Now()
+		return &gateway_bean.GatewayPaymentCaptureResp{GatewayCaptureId: one.GatewayPaymentId, Amount: one.PaymentAmount, Currency: one.Currency}, nil
+	case ActionPaymentCancel:
+		one, err := s.payment(raw)
+		if err != nil {
+			return nil, err
+		}
+		if one.Status == consts.PaymentSuccess {
+			return nil, fmt.Errorf("payment already paid")
+		}
+		one.Status = consts.PaymentCancelled
+		one.CancelTime = gtime.Now()
+		return &gateway_bean.GatewayPaymentCancelResp{GatewayCancelId: one.GatewayPaymentId, Status: consts.PaymentCancelled}, nil
+	case ActionPaymentDetail:
+		return s.payment(raw)
+	case ActionPaymentList:
+		list := make([]*gateway_bean.GatewayPaymentRo, 0)
+		for _, one := range s.payments {
+			list = append(list, one)
+		}
+		return list, nil
+	case ActionRefundCreate:
+		var data *RefundCreateData
+		if err := json.Unmarshal(raw, &data); err != nil || data.Payment == nil || data.Refund == nil {
+			return nil, fmt.Errorf("invalid refund")
+		}
+		payment, ok := s.payments[data.Payment.GatewayPaymentId]
+		if !ok || payment.Status != consts.PaymentSuccess {
+			return nil, fmt.Errorf("payment not paid")
+		}
+		if payment.RefundAmount+data.Refund.RefundAmount > payment.PaymentAmount {
+			return nil, fmt.Errorf("refund amount exceed")
+		}
+		payment.RefundAmount = payment.RefundAmount + data.Refund.RefundAmount
+		one := &gateway_bean.GatewayPaymentRefundResp{
+			GatewayRefundId:  s.nextId("re"),
+			GatewayPaymentId: payment.GatewayPaymentId,
+			Status:           consts.RefundSuccess,
+			RefundAmount:     data.Refund.RefundAmount,
+			Currency:         data.Refund.Currency,
+			RefundTime:       gtime.Now(),
+			Type:             consts.RefundTypeGateway,
+		}
+		s.refunds[one.GatewayRefundId] = one
+		return one, nil
+	case ActionRefundDetail, ActionRefundCancel:
+		var data *RefundData
+		if err := json.Unmarshal(raw, &data); err != nil {
+			return nil, err
+		}
+		one, ok := s.refunds[data.GatewayRefundId]
+		if !ok {
+			return nil, fmt.Errorf("refund not found")
+		}
+		if action == ActionRefundCancel {
+			if one.Status == consts.RefundSuccess {
+				return nil, fmt.Errorf("refund already succeed")
+			}
+			one.Status = consts.RefundCancelled
+		}
+		return one, nil
+	case ActionRefundList:
+		var data *RefundData
+		if err := json.Unmarshal(raw, &data); err != nil {
+			return nil, err
+		}
+		list := make([]*gateway_bean.GatewayPaymentRefundResp, 0)
+		for _, one := range s.refunds {
+			if one.GatewayPaymentId == data.GatewayPaymentId {
+				list = append(list, one)
+			}
+		}
+		return list, nil
+	default:
+		return nil, fmt.Errorf("action not support:%s", action)
+	}
+}
+
+func (s *Stub) payment(raw json.RawMessage) (*gateway_bean.GatewayPaymentRo, error) {
+	var data *PaymentData
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, err
+	}
+	gatewayPaymentId := data.GatewayPaymentId
+	if len(gatewayPaymentId) == 0 && data.Payment != nil {
+		gatewayPaymentId = data.Payment.GatewayPaymentId
+	}
+	one, ok := s.payments[gatewayPaymentId]
+	if !ok {
+		return nil, fmt.Errorf("payment not found:%s", gatewayPaymentId)
+	}
+	return one, nil
+}
+
+// SetPaymentStatus simulates an asynchronous status change at the provider
+func (s *Stub) SetPaymentStatus(gatewayPaymentId string, status int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if one, ok := s.payments[gatewayPaymentId]; ok {
+		one.Status = status
+		if status == consts.PaymentSuccess {
+			one.PaidTime = gtime.Now()
+		}
+	}
+}
+
+// SendWebhook posts a signed event to the webhook url registered by webhook.setup
+func (s *Stub) SendWebhook(ctx context.Context, event *WebhookEvent) (int, error) {
+	if len(s.WebhookUrl) == 0 {
+		return 0, fmt.Errorf("webhook url not setup")
+	}
+	body, err := json.Marshal(event)
+	if err != nil {
+		return 0, err
+	}
+	timestamp := gtime.Now().Timestamp()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.WebhookUrl, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(s.Secret, timestamp, body))
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	return resp.StatusCode, nil
+}
@@ -21,3 +21,13 @@ func GetGatewayWebhookServiceProviderByGatewayName(ctx context.Context, gatewayN
 	proxy.GatewayName = gatewayName
 	return proxy
 }
+
+// GetGatewaySettlementProvider returns nil if the gateway can not report settlements
+func GetGatewaySettlementProvider(ctx context.Context, gatewayId uint64) (one _interface.GatewaySettlementInterface) {
+	gateway := util.GetGatewayById(ctx, gatewayId)
+	utility.Assert(gateway != nil, fmt.Sprintf("gateway not found %d", gatewayId))
+	if _, ok := GatewayNameMapping[gateway.GatewayName].(_interface.GatewaySettlementInterface); !ok {
+		return nil
+	}
+	return &GatewayProxy{Gateway: gateway, GatewayName: gateway.GatewayName}
+}
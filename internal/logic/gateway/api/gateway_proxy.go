@@ -40,6 +40,7 @@ var GatewayNameMapping = map[string]_interface.GatewayInterface{
 	"mulenpay":        &MulenPay{},
 	"alikassa":        &AliKassa{},
 	"blockonomics":    &Blockonomics{},
+	"remote_adapter":  &RemoteAdapter{},
 	//"airwallex":       &Airwallex{},
 }
 
@@ -65,25 +66,27 @@ var GatewayShortNameMapping = map[string]string{
 	"alikassa":        "AK",
 	"blockonomics":    "BK",
 	"airwallex":       "AW",
+	"remote_adapter":  "RA",
 }
 
 // var ExportGatewaySetupListKeys = []string{"stripe", "changelly", "paypal", "unitpay", "payssion", "wire_transfer"}
 
 var ExportGatewaySetupList = map[string]*_interface.GatewayInfo{
-	"stripe":        Stripe{}.GatewayInfo(context.Background()),
-	"changelly":     Changelly{}.GatewayInfo(context.Background()),
-	"paypal":        Paypal{}.GatewayInfo(context.Background()),
-	"unitpay":       UnitPay{}.GatewayInfo(context.Background()),
-	"payssion":      Payssion{}.GatewayInfo(context.Background()),
-	"wire_transfer": Wire{}.GatewayInfo(context.Background()),
-	"alipay":        Alipay{}.GatewayInfo(context.Background()),
-	"alipay+":       AlipayPlus{}.GatewayInfo(context.Background()),
-	"bank131":       Bank131{}.GatewayInfo(context.Background()),
-	"sberpay":       SberPay{}.GatewayInfo(context.Background()),
-	"firekassa":     FireKassa{}.GatewayInfo(context.Background()),
-	"mulenpay":      MulenPay{}.GatewayInfo(context.Background()),
-	"alikassa":      AliKassa{}.GatewayInfo(context.Background()),
-	"blockonomics":  Blockonomics{}.GatewayInfo(context.Background()),
+	"stripe":         Stripe{}.GatewayInfo(context.Background()),
+	"changelly":      Changelly{}.GatewayInfo(context.Background()),
+	"paypal":         Paypal{}.GatewayInfo(context.Background()),
+	"unitpay":        UnitPay{}.GatewayInfo(context.Background()),
+	"payssion":       Payssion{}.GatewayInfo(context.Background()),
+	"wire_transfer":  Wire{}.GatewayInfo(context.Background()),
+	"alipay":         Alipay{}.GatewayInfo(context.Background()),
+	"alipay+":        AlipayPlus{}.GatewayInfo(context.Background()),
+	"bank131":        Bank131{}.GatewayInfo(context.Background()),
+	"sberpay":        SberPay{}.GatewayInfo(context.Background()),
+	"firekassa":      FireKassa{}.GatewayInfo(context.Background()),
+	"mulenpay":       MulenPay{}.GatewayInfo(context.Background()),
+	"alikassa":       AliKassa{}.GatewayInfo(context.Background()),
+	"blockonomics":   Blockonomics{}.GatewayInfo(context.Background()),
+	"remote_adapter": RemoteAdapter{}.GatewayInfo(context.Background()),
 	//"cryptadium": Cryptadium{}.GatewayInfo(context.Background()),
 }
 
@@ -517,6 +520,29 @@ func (p GatewayProxy) GatewayRefundList(ctx context.Context, gateway *entity.Mer
 	return res, err
 }
 
+func (p GatewayProxy) GatewaySettlementList(ctx context.Context, gateway *entity.MerchantGateway, req *gateway_bean.GatewaySettlementListReq) (res []*gateway_bean.GatewaySettlement, err error) {
+	defer func() {
+		if exception := recover(); exception != nil {
+			if v, ok := exception.(error); ok && gerror.HasStack(v) {
+				err = v
+			} else {
+				err = gerror.NewCodef(gcode.CodeInternalPanic, "%+v", exception)
+			}
+			printChannelPanic(ctx, err)
+			return
+		}
+	}()
+	startTime := time.Now()
+	one, ok := p.getRemoteGateway().(_interface.GatewaySettlementInterface)
+	utility.Assert(ok, "gateway not support settlement:"+p.GatewayName)
+	res, err = one.GatewaySettlementList(ctx, gateway, req)
+	glog.Infof(ctx, "MeasureChannelFunction:GatewaySettlementList cost：%s \n", time.Now().Sub(startTime))
+	if err != nil {
+		err = gerror.NewCode(util.GatewayError, err.Error())
+	}
+	return res, err
+}
+
 func printChannelPanic(ctx context.Context, err error) {
 	var requestId = "init"
 	if context2.Context().Get(ctx) != nil {
@@ -21,7 +21,10 @@ import (
 	"github.com/gogf/gf/v2/os/gtime"
 )
 
-type MulenPay struct{}
+// MulenPay calls the MulenPay api, HttpClient replaces the default client when set
+type MulenPay struct {
+	HttpClient *http.Client
+}
 
 // MulenPay API response structures
 type MulenPayPaymentResponse struct {
@@ -356,7 +359,10 @@ func (m MulenPay) makeAPICall(ctx context.Context, apiKey, apiSecret, method, pa
 	// Add authentication headers
 	req.Header.Set("Authorization", "Bearer "+apiKey)
 	req.Header.Set("X-API-Secret", apiSecret)
-	client := &http.Client{Timeout: 30 * time.Second}
+	client := m.HttpClient
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
 	resp, err := client.Do(req)
 	if err != nil {
 		return "", err
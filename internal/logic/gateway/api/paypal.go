@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 	"unibee/internal/cmd/config"
 	"unibee/internal/consts"
 	_interface "unibee/internal/interface"
@@ -43,6 +44,7 @@ func (p Paypal) GatewayInfo(ctx context.Context) *_interface.GatewayInfo {
 		PublicKeyName:      "Client Id",
 		PrivateSecretName:  "Secret",
 		AutoChargeEnabled:  true,
+		PaymentListEnabled: true,
 	}
 }
 
@@ -121,8 +123,78 @@ func (p Paypal) GatewayUserCreate(ctx context.Context, gateway *entity.MerchantG
 	return nil, gerror.New("not support")
 }
 
+// GatewayPaymentList lists the payment transactions of the account created in the window by the transaction search,
+// the transaction carries the local payment id as its custom field, the list of a user is not supported
 func (p Paypal) GatewayPaymentList(ctx context.Context, gateway *entity.MerchantGateway, listReq *gateway_bean.GatewayPaymentListReq) (res []*gateway_bean.GatewayPaymentRo, err error) {
-	return nil, gerror.New("not support")
+	utility.Assert(gateway != nil, "gateway not found")
+	if listReq.UserId > 0 {
+		return nil, gerror.New("not support")
+	}
+	utility.Assert(listReq.CreateTimeEnd > listReq.CreateTimeStart && listReq.CreateTimeEnd-listReq.CreateTimeStart <= 31*86400, "paypal transaction search window should be within 31 days")
+	c, _ := NewClient(gateway.GatewayKey, gateway.GatewaySecret, p.GetPaypalHost())
+	_, err = c.GetAccessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var pageSize = 500
+	var page = 1
+	for {
+		response, err := c.ListTransactions(ctx, &paypal.TransactionSearchRequest{
+			StartDate: time.Unix(listReq.CreateTimeStart, 0).UTC(),
+			EndDate:   time.Unix(listReq.CreateTimeEnd, 0).UTC(),
+			PageSize:  &pageSize,
+			Page:      &page,
+		})
+		log.SaveChannelHttpLog("GatewayPaymentList", c.RequestBodyStr, c.ResponseStr, err, "", nil, gateway)
+		if err != nil {
+			return nil, err
+		}
+		for _, detail := range response.TransactionDetails {
+			if item := parsePaypalPaymentTransaction(gateway, &detail.TransactionInfo); item != nil {
+				res = append(res, item)
+			}
+		}
+		if page >= response.TotalPages {
+			break
+		}
+		page++
+	}
+	return res, nil
+}
+
+// parsePaypalPaymentTransaction returns the payment of the transaction created by the payments of the gateway, nil for others
+func parsePaypalPaymentTransaction(gateway *entity.MerchantGateway, info *paypal.SearchTransactionInfo) *gateway_bean.GatewayPaymentRo {
+	if info == nil || !strings.HasPrefix(info.TransactionEventCode, "T00") || len(info.CustomField) == 0 || len(info.TransactionAmount.Value) == 0 {
+		return nil
+	}
+	var status = 0
+	switch info.TransactionStatus {
+	case "S":
+		status = consts.PaymentSuccess
+	case "P":
+		status = consts.PaymentCreated
+	case "D":
+		status = consts.PaymentFailed
+	}
+	currency := strings.ToUpper(info.TransactionAmount.Currency)
+	amount := utility.ConvertDollarStrToCent(info.TransactionAmount.Value, currency)
+	createTime := gtime.NewFromTime(time.Time(info.TransactionInitiationDate))
+	var paidTime *gtime.Time
+	if status == consts.PaymentSuccess {
+		paidTime = createTime
+	}
+	return &gateway_bean.GatewayPaymentRo{
+		MerchantId:       gateway.MerchantId,
+		GatewayId:        gateway.Id,
+		GatewayPaymentId: info.TransactionID,
+		PaymentId:        info.CustomField,
+		Status:           status,
+		Currency:         currency,
+		TotalAmount:      amount,
+		PaymentAmount:    amount,
+		CreateTime:       createTime,
+		PaidTime:         paidTime,
+	}
 }
 
 func (p Paypal) GatewayRefundList(ctx context.Context, gateway *entity.MerchantGateway, gatewayPaymentId string) (res []*gateway_bean.GatewayPaymentRefundResp, err error) {
@@ -157,6 +229,107 @@ func (p Paypal) GatewayMerchantBalancesQuery(ctx context.Context, gateway *entit
 	return nil, gerror.New("not support")
 }
 
+// GatewaySettlementList groups the balance affecting transactions by day and currency, paypal settles into the paypal balance
+// instead of payouts, the same grouping as the daily settlement report
+func (p Paypal) GatewaySettlementList(ctx context.Context, gateway *entity.MerchantGateway, req *gateway_bean.GatewaySettlementListReq) (res []*gateway_bean.GatewaySettlement, err error) {
+	utility.Assert(gateway != nil, "gateway not found")
+	utility.Assert(req.ArrivalTimeEnd > req.ArrivalTimeStart && req.ArrivalTimeEnd-req.ArrivalTimeStart <= 31*86400, "paypal transaction search window should be within 31 days")
+	c, _ := NewClient(gateway.GatewayKey, gateway.GatewaySecret, p.GetPaypalHost())
+	_, err = c.GetAccessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var settlementMap = make(map[string]*gateway_bean.GatewaySettlement)
+	var balanceAffectingOnly = "Y"
+	var pageSize = 500
+	var page = 1
+	for {
+		response, err := c.ListTransactions(ctx, &paypal.TransactionSearchRequest{
+			StartDate:                   time.Unix(req.ArrivalTimeStart, 0).UTC(),
+			EndDate:                     time.Unix(req.ArrivalTimeEnd, 0).UTC(),
+			BalanceAffectingRecordsOnly: &balanceAffectingOnly,
+			PageSize:                    &pageSize,
+			Page:                        &page,
+		})
+		log.SaveChannelHttpLog("GatewaySettlementList", c.RequestBodyStr, c.ResponseStr, err, "", nil, gateway)
+		if err != nil {
+			return nil, err
+		}
+		for _, detail := range response.TransactionDetails {
+			item := parsePaypalSettlementTransaction(&detail.TransactionInfo)
+			if item == nil {
+				continue
+			}
+			day := time.Unix(item.TransactionTime, 0).UTC().Format("20060102")
+			key := fmt.Sprintf("PP-%s-%s", day, item.Currency)
+			settlement, ok := settlementMap[key]
+			if !ok {
+				dayStart, _ := time.Parse("20060102", day)
+				settlement = &gateway_bean.GatewaySettlement{
+					GatewaySettlementId: key,
+					Currency:            item.Currency,
+					Status:              consts.SettlementPaid,
+					ArrivalTime:         dayStart.Unix(),
+				}
+				settlementMap[key] = settlement
+				res = append(res, settlement)
+			}
+			settlement.NetAmount = settlement.NetAmount + item.NetAmount
+			settlement.Items = append(settlement.Items, item)
+		}
+		if page >= response.TotalPages {
+			break
+		}
+		page++
+	}
+	return res, nil
+}
+
+func parsePaypalSettlementTransaction(info *paypal.SearchTransactionInfo) *gateway_bean.GatewaySettlementItem {
+	if info == nil || len(info.TransactionEventCode) < 3 || len(info.TransactionAmount.Value) == 0 {
+		return nil
+	}
+	var itemType string
+	switch info.TransactionEventCode[:3] {
+	case "T00":
+		itemType = consts.SettlementItemTypePayment
+	case "T11":
+		itemType = consts.SettlementItemTypeRefund
+	case "T01":
+		itemType = consts.SettlementItemTypeFee
+	case "T12":
+		itemType = consts.SettlementItemTypeAdjustment
+	case "T20", "T15":
+		itemType = consts.SettlementItemTypeDispute
+	case "T03", "T04", "T09":
+		// bank deposits, withdrawals and transfers move the balance itself
+		return nil
+	default:
+		itemType = consts.SettlementItemTypeOther
+	}
+	currency := strings.ToUpper(info.TransactionAmount.Currency)
+	gross := utility.ConvertDollarStrToCent(info.TransactionAmount.Value, currency)
+	var fee int64 = 0
+	if info.FeeAmount != nil && len(info.FeeAmount.Value) > 0 {
+		// paypal reports the charged fee as negative
+		fee = -utility.ConvertDollarStrToCent(info.FeeAmount.Value, currency)
+	}
+	item := &gateway_bean.GatewaySettlementItem{
+		GatewayTransactionId: info.TransactionID,
+		Type:                 itemType,
+		PaymentId:            info.CustomField,
+		Currency:             currency,
+		GrossAmount:          gross,
+		FeeAmount:            fee,
+		NetAmount:            gross - fee,
+		TransactionTime:      time.Time(info.TransactionInitiationDate).Unix(),
+	}
+	if itemType == consts.SettlementItemTypeRefund {
+		item.GatewayRefundId = info.TransactionID
+	}
+	return item
+}
+
 func (p Paypal) GatewayUserDetailQuery(ctx context.Context, gateway *entity.MerchantGateway, gatewayUserId string) (res *gateway_bean.GatewayUserDetailQueryResp, err error) {
 	return nil, gerror.New("not support")
 }
@@ -215,6 +388,8 @@ func (p Paypal) GatewayNewPayment(ctx context.Context, gateway *entity.MerchantG
 					Value:    utility.ConvertCentToDollarStr(createPayContext.Pay.TotalAmount, createPayContext.Pay.Currency),
 					Currency: strings.ToUpper(createPayContext.Pay.Currency),
 				},
+				// carried to the transaction search as custom_field, settlement ingestion matches the payment by it
+				CustomID: createPayContext.Pay.PaymentId,
 			},
 		},
 		&paypal.CreateOrderPayer{},
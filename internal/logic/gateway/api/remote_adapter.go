@@ -0,0 +1,196 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"unibee/internal/consts"
+	_interface "unibee/internal/interface"
+	"unibee/internal/logic/gateway/adapter"
+	"unibee/internal/logic/gateway/api/log"
+	"unibee/internal/logic/gateway/gateway_bean"
+	entity "unibee/internal/model/entity/default"
+	"unibee/utility"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// RemoteAdapter forwards every gateway call to a merchant hosted adapter over the JSON-over-HTTP protocol,
+// GatewayKey holds the adapter url and GatewaySecret the signing secret,
+// HttpClient replaces the default client of the adapter calls when set
+type RemoteAdapter struct {
+	HttpClient *http.Client
+}
+
+func (r RemoteAdapter) GatewayInfo(ctx context.Context) *_interface.GatewayInfo {
+	return &_interface.GatewayInfo{
+		Name:                          "RemoteAdapter",
+		Description:                   "Use a self hosted adapter service to integrate any payment service provider, see docs/remote-adapter-protocol.md",
+		DisplayName:                   "Remote Adapter",
+		GatewayWebsiteLink:            "",
+		GatewayWebhookIntegrationLink: "",
+		GatewayLogo:                   "https://unibee.dev/wp-content/uploads/2024/05/logo-white.svg?ver=1718007070",
+		GatewayIcons:                  []string{"https://unibee.dev/wp-content/uploads/2024/05/logo-white.svg?ver=1718007070"},
+		GatewayType:                   consts.GatewayTypeCard,
+		Sort:                          300,
+		AutoChargeEnabled:             true,
+		PaymentListEnabled:            true,
+		PublicKeyName:                 "Adapter Url",
+		PrivateSecretName:             "Signing Secret",
+		IsStaging:                     true,
+	}
+}
+
+func (r RemoteAdapter) call(ctx context.Context, gateway *entity.MerchantGateway, action string, data interface{}, result interface{}) error {
+	utility.Assert(gateway != nil, "gateway not found")
+	response, err := adapter.Call(ctx, r.HttpClient, gateway.GatewayKey, gateway.GatewaySecret, gateway.Id, action, data, result)
+	log.SaveChannelHttpLog(action, data, response, err, fmt.Sprintf("%s-%d", gateway.GatewayName, gateway.Id), nil, gateway)
+	return err
+}
+
+func (r RemoteAdapter) GatewayTest(ctx context.Context, req *_interface.GatewayTestReq) (icon string, gatewayType int64, err error) {
+	utility.Assert(len(req.Key) > 0, "adapter url is required")
+	utility.Assert(len(req.Secret) > 0, "signing secret is required")
+	var result *adapter.GatewayTestResult
+	_, err = adapter.Call(ctx, r.HttpClient, req.Key, req.Secret, 0, adapter.ActionGatewayTest, &adapter.GatewayTestData{SubGateway: req.SubGateway}, &result)
+	if err != nil {
+		return "", 0, gerror.Newf("remote adapter test failed: %v", err)
+	}
+	gatewayType = consts.GatewayTypeCard
+	if result != nil {
+		icon = result.Icon
+		if result.GatewayType > 0 {
+			gatewayType = result.GatewayType
+		}
+	}
+	return icon, gatewayType, nil
+}
+
+func (r RemoteAdapter) GatewayUserCreate(ctx context.Context, gateway *entity.MerchantGateway, user *entity.UserAccount) (res *gateway_bean.GatewayUserCreateResp, err error) {
+	err = r.call(ctx, gateway, adapter.ActionUserCreate, &adapter.UserData{User: user}, &res)
+	return res, err
+}
+
+func (r RemoteAdapter) GatewayUserDetailQuery(ctx context.Context, gateway *entity.MerchantGateway, gatewayUserId string) (res *gateway_bean.GatewayUserDetailQueryResp, err error) {
+	err = r.call(ctx, gateway, adapter.ActionUserDetail, &adapter.GatewayUserData{GatewayUserId: gatewayUserId}, &res)
+	return res, err
+}
+
+func (r RemoteAdapter) GatewayMerchantBalancesQuery(ctx context.Context, gateway *entity.MerchantGateway) (res *gateway_bean.GatewayMerchantBalanceQueryResp, err error) {
+	err = r.call(ctx, gateway, adapter.ActionMerchantBalances, struct{}{}, &res)
+	return res, err
+}
+
+func (r RemoteAdapter) GatewayUserAttachPaymentMethodQuery(ctx context.Context, gateway *entity.MerchantGateway, userId uint64, gatewayPaymentMethod string) (res *gateway_bean.GatewayUserAttachPaymentMethodResp, err error) {
+	err = r.call(ctx, gateway, adapter.ActionPaymentMethodAttach, &adapter.PaymentMethodData{UserId: userId, GatewayPaymentMethod: gatewayPaymentMethod}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &gateway_bean.GatewayUserAttachPaymentMethodResp{}, nil
+}
+
+func (r RemoteAdapter) GatewayUserDeAttachPaymentMethodQuery(ctx context.Context, gateway *entity.MerchantGateway, userId uint64, gatewayPaymentMethod string) (res *gateway_bean.GatewayUserDeAttachPaymentMethodResp, err error) {
+	err = r.call(ctx, gateway, adapter.ActionPaymentMethodDetach, &adapter.PaymentMethodData{UserId: userId, GatewayPaymentMethod: gatewayPaymentMethod}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &gateway_bean.GatewayUserDeAttachPaymentMethodResp{}, nil
+}
+
+func (r RemoteAdapter) GatewayUserPaymentMethodListQuery(ctx context.Context, gateway *entity.MerchantGateway, req *gateway_bean.GatewayUserPaymentMethodReq) (res *gateway_bean.GatewayUserPaymentMethodListResp, err error) {
+	err = r.call(ctx, gateway, adapter.ActionPaymentMethodList, req, &res)
+	return res, err
+}
+
+func (r RemoteAdapter) GatewayUserCreateAndBindPaymentMethod(ctx context.Context, gateway *entity.MerchantGateway, userId uint64, currency string, metadata map[string]interface{}) (res *gateway_bean.GatewayUserPaymentMethodCreateAndBindResp, err error) {
+	err = r.call(ctx, gateway, adapter.ActionPaymentMethodCreateAndBind, &adapter.PaymentMethodCreateAndBindData{UserId: userId, Currency: currency, Metadata: metadata}, &res)
+	return res, err
+}
+
+func (r RemoteAdapter) GatewayNewPayment(ctx context.Context, gateway *entity.MerchantGateway, createPayContext *gateway_bean.GatewayNewPaymentReq) (res *gateway_bean.GatewayNewPaymentResp, err error) {
+	err = r.call(ctx, gateway, adapter.ActionPaymentCreate, &adapter.PaymentCreateData{
+		Payment:              createPayContext.Pay,
+		Invoice:              createPayContext.Invoice,
+		Email:                createPayContext.Email,
+		ExternalUserId:       createPayContext.ExternalUserId,
+		GatewayPaymentMethod: createPayContext.GatewayPaymentMethod,
+		GatewayPaymentType:   createPayContext.GatewayPaymentType,
+		PayImmediate:         createPayContext.PayImmediate,
+		ExchangeAmount:       createPayContext.ExchangeAmount,
+		ExchangeCurrency:     createPayContext.ExchangeCurrency,
+		CurrencyExchange:     createPayContext.GatewayCurrencyExchange,
+		Metadata:             createPayContext.Metadata,
+	}, &res)
+	if err != nil {
+		return nil, err
+	}
+	utility.Assert(res != nil && len(res.GatewayPaymentId) > 0, "remote adapter return empty gatewayPaymentId")
+	res.Payment = createPayContext.Pay
+	if res.Status == 0 {
+		res.Status = consts.PaymentCreated
+	}
+	return res, nil
+}
+
+func (r RemoteAdapter) GatewayCapture(ctx context.Context, gateway *entity.MerchantGateway, payment *entity.Payment) (res *gateway_bean.GatewayPaymentCaptureResp, err error) {
+	err = r.call(ctx, gateway, adapter.ActionPaymentCapture, &adapter.PaymentData{GatewayPaymentId: payment.GatewayPaymentId, Payment: payment}, &res)
+	return res, err
+}
+
+func (r RemoteAdapter) GatewayCancel(ctx context.Context, gateway *entity.MerchantGateway, payment *entity.Payment) (res *gateway_bean.GatewayPaymentCancelResp, err error) {
+	err = r.call(ctx, gateway, adapter.ActionPaymentCancel, &adapter.PaymentData{GatewayPaymentId: payment.GatewayPaymentId, Payment: payment}, &res)
+	return res, err
+}
+
+func (r RemoteAdapter) GatewayCryptoFiatTrans(ctx context.Context, from *gateway_bean.GatewayCryptoFromCurrencyAmountDetailReq) (to *gateway_bean.GatewayCryptoToCurrencyAmountDetailRes, err error) {
+	return nil, gerror.New("not support")
+}
+
+func (r RemoteAdapter) GatewayPaymentList(ctx context.Context, gateway *entity.MerchantGateway, listReq *gateway_bean.GatewayPaymentListReq) (res []*gateway_bean.GatewayPaymentRo, err error) {
+	err = r.call(ctx, gateway, adapter.ActionPaymentList, &adapter.PaymentListData{UserId: listReq.UserId, CreateTimeStart: listReq.CreateTimeStart, CreateTimeEnd: listReq.CreateTimeEnd}, &res)
+	return res, err
+}
+
+func (r RemoteAdapter) GatewayPaymentDetail(ctx context.Context, gateway *entity.MerchantGateway, gatewayPaymentId string, payment *entity.Payment) (res *gateway_bean.GatewayPaymentRo, err error) {
+	err = r.call(ctx, gateway, adapter.ActionPaymentDetail, &adapter.PaymentData{GatewayPaymentId: gatewayPaymentId, Payment: payment}, &res)
+	if err != nil {
+		return nil, err
+	}
+	utility.Assert(res != nil, "remote adapter return empty payment")
+	res.GatewayId = gateway.Id
+	if len(res.GatewayPaymentId) == 0 {
+		res.GatewayPaymentId = gatewayPaymentId
+	}
+	return res, nil
+}
+
+func (r RemoteAdapter) GatewayRefundList(ctx context.Context, gateway *entity.MerchantGateway, gatewayPaymentId string) (res []*gateway_bean.GatewayPaymentRefundResp, err error) {
+	err = r.call(ctx, gateway, adapter.ActionRefundList, &adapter.RefundData{GatewayPaymentId: gatewayPaymentId}, &res)
+	return res, err
+}
+
+func (r RemoteAdapter) GatewayRefundDetail(ctx context.Context, gateway *entity.MerchantGateway, gatewayRefundId string, refund *entity.Refund) (res *gateway_bean.GatewayPaymentRefundResp, err error) {
+	err = r.call(ctx, gateway, adapter.ActionRefundDetail, &adapter.RefundData{GatewayRefundId: gatewayRefundId, Refund: refund}, &res)
+	return res, err
+}
+
+func (r RemoteAdapter) GatewayRefund(ctx context.Context, gateway *entity.MerchantGateway, createPaymentRefundContext *gateway_bean.GatewayNewPaymentRefundReq) (res *gateway_bean.GatewayPaymentRefundResp, err error) {
+	err = r.call(ctx, gateway, adapter.ActionRefundCreate, &adapter.RefundCreateData{
+		Payment:                createPaymentRefundContext.Payment,
+		Refund:                 createPaymentRefundContext.Refund,
+		ExchangeRefundAmount:   createPaymentRefundContext.ExchangeRefundAmount,
+		ExchangeRefundCurrency: createPaymentRefundContext.ExchangeRefundCurrency,
+	}, &res)
+	if err != nil {
+		return nil, err
+	}
+	utility.Assert(res != nil, "remote adapter return empty refund")
+	res.Type = consts.RefundTypeGateway
+	return res, nil
+}
+
+func (r RemoteAdapter) GatewayRefundCancel(ctx context.Context, gateway *entity.MerchantGateway, payment *entity.Payment, refund *entity.Refund) (res *gateway_bean.GatewayPaymentRefundResp, err error) {
+	err = r.call(ctx, gateway, adapter.ActionRefundCancel, &adapter.RefundData{GatewayRefundId: refund.GatewayRefundId, GatewayPaymentId: payment.GatewayPaymentId, Payment: payment, Refund: refund}, &res)
+	return res, err
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/gogf/gf/v2/os/gtime"
 	"github.com/stripe/stripe-go/v78"
 	"github.com/stripe/stripe-go/v78/balance"
+	"github.com/stripe/stripe-go/v78/balancetransaction"
 	"github.com/stripe/stripe-go/v78/checkout/session"
 	"github.com/stripe/stripe-go/v78/customer"
 	"github.com/stripe/stripe-go/v78/dispute"
@@ -28,6 +29,7 @@ import (
 	"github.com/stripe/stripe-go/v78/invoiceitem"
 	"github.com/stripe/stripe-go/v78/paymentintent"
 	"github.com/stripe/stripe-go/v78/paymentmethod"
+	"github.com/stripe/stripe-go/v78/payout"
 	"github.com/stripe/stripe-go/v78/product"
 	"github.com/stripe/stripe-go/v78/refund"
 	"github.com/stripe/stripe-go/v78/webhookendpoint"
@@ -47,6 +49,7 @@ func (s Stripe) GatewayInfo(ctx context.Context) *_interface.GatewayInfo {
 		GatewayType:        consts.GatewayTypeCard,
 		Sort:               100,
 		AutoChargeEnabled:  true,
+		PaymentListEnabled: true,
 	}
 }
 
@@ -224,6 +227,9 @@ func (s Stripe) GatewayPaymentList(ctx context.Context, gateway *entity.Merchant
 	utility.Assert(gateway != nil, "gateway not found")
 	stripe.Key = gateway.GatewaySecret
 	s.setUnibeeAppInfo()
+	if listReq.UserId == 0 {
+		return s.paymentWindowList(ctx, gateway, listReq)
+	}
 	gatewayUser := QueryAndCreateGatewayUser(ctx, gateway, listReq.UserId)
 
 	params := &stripe.PaymentIntentListParams{}
@@ -239,6 +245,29 @@ func (s Stripe) GatewayPaymentList(ctx context.Context, gateway *entity.Merchant
 	return list, nil
 }
 
+// paymentWindowList lists the payment intents of the account created in the window page by page
+func (s Stripe) paymentWindowList(ctx context.Context, gateway *entity.MerchantGateway, listReq *gateway_bean.GatewayPaymentListReq) (res []*gateway_bean.GatewayPaymentRo, err error) {
+	if listReq.CreateTimeEnd <= listReq.CreateTimeStart {
+		return nil, gerror.New("userId or create time window needed")
+	}
+	params := &stripe.PaymentIntentListParams{}
+	params.CreatedRange = &stripe.RangeQueryParams{
+		GreaterThanOrEqual: listReq.CreateTimeStart,
+		LesserThan:         listReq.CreateTimeEnd,
+	}
+	params.Limit = stripe.Int64(100)
+	paymentList := paymentintent.List(params)
+	for paymentList.Next() {
+		res = append(res, s.parseStripePayment(ctx, gateway, paymentList.PaymentIntent()))
+	}
+	err = paymentList.Err()
+	log.SaveChannelHttpLog("GatewayPaymentList", params, len(res), err, "", nil, gateway)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
 func (s Stripe) GatewayRefundList(ctx context.Context, gateway *entity.MerchantGateway, gatewayPaymentId string) (res []*gateway_bean.GatewayPaymentRefundResp, err error) {
 	utility.Assert(gateway != nil, "gateway not found")
 	stripe.Key = gateway.GatewaySecret
@@ -310,6 +339,106 @@ func (s Stripe) GatewayMerchantBalancesQuery(ctx context.Context, gateway *entit
 	}, nil
 }
 
+// GatewaySettlementList lists the payouts arrived in the window, each with the balance transactions it paid out
+func (s Stripe) GatewaySettlementList(ctx context.Context, gateway *entity.MerchantGateway, req *gateway_bean.GatewaySettlementListReq) (res []*gateway_bean.GatewaySettlement, err error) {
+	utility.Assert(gateway != nil, "gateway not found")
+	stripe.Key = gateway.GatewaySecret
+	s.setUnibeeAppInfo()
+
+	params := &stripe.PayoutListParams{}
+	params.ArrivalDateRange = &stripe.RangeQueryParams{
+		GreaterThanOrEqual: req.ArrivalTimeStart,
+		LesserThan:         req.ArrivalTimeEnd,
+	}
+	params.Limit = stripe.Int64(100)
+	payoutIter := payout.List(params)
+	for payoutIter.Next() {
+		item := payoutIter.Payout()
+		var status = consts.SettlementPending
+		if item.Status == stripe.PayoutStatusPaid {
+			status = consts.SettlementPaid
+		} else if item.Status == stripe.PayoutStatusFailed {
+			status = consts.SettlementFailed
+		} else if item.Status == stripe.PayoutStatusCanceled {
+			status = consts.SettlementCancelled
+		}
+		settlement := &gateway_bean.GatewaySettlement{
+			GatewaySettlementId: item.ID,
+			Currency:            strings.ToUpper(string(item.Currency)),
+			NetAmount:           item.Amount,
+			Status:              status,
+			ArrivalTime:         item.ArrivalDate,
+			Data:                utility.MarshalToJsonString(item),
+		}
+		transactionParams := &stripe.BalanceTransactionListParams{}
+		transactionParams.Payout = stripe.String(item.ID)
+		transactionParams.Limit = stripe.Int64(100)
+		transactionParams.AddExpand("data.source")
+		transactionIter := balancetransaction.List(transactionParams)
+		for transactionIter.Next() {
+			one := parseStripeBalanceTransaction(transactionIter.BalanceTransaction())
+			if one != nil {
+				settlement.Items = append(settlement.Items, one)
+			}
+		}
+		if err = transactionIter.Err(); err != nil {
+			log.SaveChannelHttpLog("GatewaySettlementList", transactionParams, nil, err, "", nil, gateway)
+			return nil, err
+		}
+		res = append(res, settlement)
+	}
+	err = payoutIter.Err()
+	log.SaveChannelHttpLog("GatewaySettlementList", params, res, err, "", nil, gateway)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func parseStripeBalanceTransaction(item *stripe.BalanceTransaction) *gateway_bean.GatewaySettlementItem {
+	if item == nil || item.Type == stripe.BalanceTransactionTypePayout {
+		// the payout transaction itself is the settlement
+		return nil
+	}
+	one := &gateway_bean.GatewaySettlementItem{
+		GatewayTransactionId: item.ID,
+		Type:                 consts.SettlementItemTypeOther,
+		Currency:             strings.ToUpper(string(item.Currency)),
+		GrossAmount:          item.Amount,
+		FeeAmount:            item.Fee,
+		NetAmount:            item.Net,
+		TransactionTime:      item.Created,
+	}
+	switch item.Type {
+	case stripe.BalanceTransactionTypeCharge, stripe.BalanceTransactionTypePayment:
+		one.Type = consts.SettlementItemTypePayment
+	case stripe.BalanceTransactionTypeRefund, stripe.BalanceTransactionTypePaymentRefund, stripe.BalanceTransactionTypeRefundFailure, stripe.BalanceTransactionTypePaymentFailureRefund:
+		one.Type = consts.SettlementItemTypeRefund
+	case stripe.BalanceTransactionTypeStripeFee, stripe.BalanceTransactionTypeStripeFxFee, stripe.BalanceTransactionTypeTaxFee, stripe.BalanceTransactionTypeApplicationFee:
+		one.Type = consts.SettlementItemTypeFee
+	case stripe.BalanceTransactionTypeAdjustment:
+		one.Type = consts.SettlementItemTypeAdjustment
+	}
+	if item.Source != nil {
+		if item.Source.Charge != nil && item.Source.Charge.PaymentIntent != nil {
+			one.GatewayPaymentId = item.Source.Charge.PaymentIntent.ID
+		}
+		if item.Source.Refund != nil {
+			one.GatewayRefundId = item.Source.Refund.ID
+			if item.Source.Refund.PaymentIntent != nil {
+				one.GatewayPaymentId = item.Source.Refund.PaymentIntent.ID
+			}
+		}
+		if item.Source.Dispute != nil {
+			one.Type = consts.SettlementItemTypeDispute
+			if item.Source.Dispute.PaymentIntent != nil {
+				one.GatewayPaymentId = item.Source.Dispute.PaymentIntent.ID
+			}
+		}
+	}
+	return one
+}
+
 func (s Stripe) GatewayUserDetailQuery(ctx context.Context, gateway *entity.MerchantGateway, gatewayUserId string) (res *gateway_bean.GatewayUserDetailQueryResp, err error) {
 	utility.Assert(gateway != nil, "gateway not found")
 	stripe.Key = gateway.GatewaySecret
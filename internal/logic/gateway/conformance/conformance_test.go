@@ -0,0 +1,144 @@
+package conformance
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/stretchr/testify/require"
+	"unibee/internal/consts"
+	_interface "unibee/internal/interface"
+	"unibee/internal/logic/gateway/adapter"
+	"unibee/internal/logic/gateway/api"
+	"unibee/internal/logic/gateway/webhook"
+	entity "unibee/internal/model/entity/default"
+)
+
+func load(t *testing.T, name string) *Recording {
+	recording, err := LoadRecording("testdata/" + name + ".json")
+	require.Nil(t, err)
+	return recording
+}
+
+func remoteAdapter(client *http.Client) _interface.GatewayInterface {
+	return api.RemoteAdapter{HttpClient: client}
+}
+
+func remoteAdapterWebhook(store webhook.Store) _interface.GatewayWebhookInterface {
+	return webhook.RemoteAdapterWebhook{Store: store}
+}
+
+func mulenPay(client *http.Client) _interface.GatewayInterface {
+	return api.MulenPay{HttpClient: client}
+}
+
+func mulenPayWebhook(store webhook.Store) _interface.GatewayWebhookInterface {
+	return webhook.MulenPayWebhook{Store: store}
+}
+
+func TestConformanceRemoteAdapter(t *testing.T) {
+	gateway := &entity.MerchantGateway{
+		Id:            1,
+		MerchantId:    15621,
+		GatewayName:   "remote_adapter",
+		GatewayKey:    "https://adapter.conformance.local/unibee",
+		GatewaySecret: "secret",
+	}
+	sign := func(body []byte) map[string]string {
+		timestamp := gtime.Now().Timestamp()
+		return map[string]string{
+			adapter.HeaderTimestamp: strconv.FormatInt(timestamp, 10),
+			adapter.HeaderSignature: adapter.Sign(gateway.GatewaySecret, timestamp, body),
+		}
+	}
+	t.Run("Test Pay Refund And Webhooks", func(t *testing.T) {
+		Run(t, &Suite{
+			Gateway:            remoteAdapter,
+			Webhook:            remoteAdapterWebhook,
+			MerchantGateway:    gateway,
+			Recording:          load(t, "remote_adapter"),
+			Payment:            &entity.Payment{PaymentId: "pay_local_1", Currency: "EUR", TotalAmount: 1000, PaymentAmount: 1000},
+			PaymentDetailPolls: 2,
+			RefundAmount:       400,
+			RefundDetailPolls:  1,
+			SignWebhook:        sign,
+			ExpectTransitions: []*Transition{
+				{Step: "GatewayNewPayment", Object: ObjectPayment, From: 0, To: consts.PaymentCreated},
+				{Step: "GatewayPaymentDetail", Object: ObjectPayment, From: consts.PaymentCreated, To: consts.PaymentCreated},
+				{Step: "GatewayPaymentDetail", Object: ObjectPayment, From: consts.PaymentCreated, To: consts.PaymentSuccess},
+				{Step: "GatewayRefund", Object: ObjectRefund, From: 0, To: consts.RefundCreated},
+				{Step: "GatewayRefundDetail", Object: ObjectRefund, From: consts.RefundCreated, To: consts.RefundSuccess},
+				{Step: "Webhook:payment updated", Object: ObjectPayment, From: consts.PaymentSuccess, To: consts.PaymentSuccess},
+				{Step: "Webhook:refund updated", Object: ObjectRefund, From: consts.RefundSuccess, To: consts.RefundSuccess},
+			},
+		})
+	})
+	t.Run("Test Capture", func(t *testing.T) {
+		Run(t, &Suite{
+			Gateway:         remoteAdapter,
+			MerchantGateway: gateway,
+			Recording:       load(t, "remote_adapter_capture"),
+			Payment:         &entity.Payment{PaymentId: "pay_local_4", Currency: "EUR", TotalAmount: 1000, PaymentAmount: 1000},
+			Capture:         true,
+			ExpectTransitions: []*Transition{
+				{Step: "GatewayNewPayment", Object: ObjectPayment, From: 0, To: consts.PaymentCreated},
+				{Step: "GatewayCapture", Object: ObjectPayment, From: consts.PaymentCreated, To: consts.PaymentSuccess},
+			},
+		})
+	})
+}
+
+func TestConformanceMulenPay(t *testing.T) {
+	gateway := &entity.MerchantGateway{
+		Id:            2,
+		MerchantId:    15621,
+		GatewayName:   "mulenpay",
+		GatewayKey:    "key",
+		GatewaySecret: "secret",
+		WebhookSecret: "whsec_conformance",
+	}
+	sign := func(body []byte) map[string]string {
+		h := hmac.New(sha256.New, []byte(gateway.WebhookSecret))
+		h.Write(body)
+		return map[string]string{"X-MulenPay-Signature": hex.EncodeToString(h.Sum(nil))}
+	}
+	t.Run("Test Pay Refund And Webhooks", func(t *testing.T) {
+		Run(t, &Suite{
+			Gateway:            mulenPay,
+			Webhook:            mulenPayWebhook,
+			MerchantGateway:    gateway,
+			Recording:          load(t, "mulenpay"),
+			Payment:            &entity.Payment{PaymentId: "pay_local_2", Currency: "RUB", TotalAmount: 1000, PaymentAmount: 1000},
+			PaymentDetailPolls: 1,
+			RefundAmount:       1000,
+			RefundDetailPolls:  1,
+			SignWebhook:        sign,
+			ExpectTransitions: []*Transition{
+				{Step: "GatewayNewPayment", Object: ObjectPayment, From: 0, To: consts.PaymentCreated},
+				{Step: "GatewayPaymentDetail", Object: ObjectPayment, From: consts.PaymentCreated, To: consts.PaymentSuccess},
+				{Step: "GatewayRefund", Object: ObjectRefund, From: 0, To: consts.RefundCreated},
+				{Step: "GatewayRefundDetail", Object: ObjectRefund, From: consts.RefundCreated, To: consts.RefundCreated},
+				{Step: "Webhook:payment finished", Object: ObjectPayment, From: consts.PaymentSuccess, To: consts.PaymentSuccess},
+				{Step: "Webhook:refund finished", Object: ObjectRefund, From: consts.RefundCreated, To: consts.RefundSuccess},
+			},
+		})
+	})
+	t.Run("Test Cancel", func(t *testing.T) {
+		result := Run(t, &Suite{
+			Gateway:         mulenPay,
+			MerchantGateway: gateway,
+			Recording:       load(t, "mulenpay_cancel"),
+			Payment:         &entity.Payment{PaymentId: "pay_local_3", Currency: "RUB", TotalAmount: 1000, PaymentAmount: 1000},
+			Cancel:          true,
+			ExpectTransitions: []*Transition{
+				{Step: "GatewayNewPayment", Object: ObjectPayment, From: 0, To: consts.PaymentCreated},
+				{Step: "GatewayCancel", Object: ObjectPayment, From: consts.PaymentCreated, To: consts.PaymentCancelled},
+			},
+		})
+		require.Nil(t, result.Refund)
+	})
+}
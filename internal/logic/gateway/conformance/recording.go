@@ -0,0 +1,50 @@
+package conformance
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// Interaction is one recorded provider call, matched by method and path, the host is ignored
+type Interaction struct {
+	Method  string            `json:"method"`
+	Path    string            `json:"path"`
+	Status  int               `json:"status"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    json.RawMessage   `json:"body"`
+}
+
+// WebhookCase is one recorded provider notification delivered to GatewayWebhook,
+// the signed cases get the signature headers from Suite.SignWebhook at delivery time
+type WebhookCase struct {
+	Name               string            `json:"name"`
+	Headers            map[string]string `json:"headers,omitempty"`
+	Sign               bool              `json:"sign,omitempty"`
+	Body               json.RawMessage   `json:"body"`
+	ExpectStatus       int               `json:"expectStatus"`
+	ExpectBodyContains string            `json:"expectBodyContains,omitempty"`
+}
+
+// Recording is the fixture file of one gateway, interactions are replayed in order
+type Recording struct {
+	Gateway      string         `json:"gateway"`
+	Interactions []*Interaction `json:"interactions"`
+	Webhooks     []*WebhookCase `json:"webhooks"`
+}
+
+func LoadRecording(path string) (*Recording, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var recording *Recording
+	if err = json.Unmarshal(data, &recording); err != nil {
+		return nil, gerror.Newf("invalid recording %s: %v", path, err)
+	}
+	if recording == nil {
+		return nil, gerror.Newf("empty recording %s", path)
+	}
+	return recording, nil
+}
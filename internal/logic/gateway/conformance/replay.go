@@ -0,0 +1,95 @@
+package conformance
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// Replayer is a httptest server answering provider calls with the recorded interactions.
+// Client redirects the provider host to the server, the suite injects it into the gateway it builds,
+// so nothing global is replaced and suites can run in parallel.
+type Replayer struct {
+	Server    *httptest.Server
+	Unmatched []string
+
+	lock         sync.Mutex
+	interactions []*Interaction
+	used         []bool
+}
+
+func NewReplayer(interactions []*Interaction) *Replayer {
+	r := &Replayer{
+		interactions: interactions,
+		used:         make([]bool, len(interactions)),
+	}
+	r.Server = httptest.NewServer(http.HandlerFunc(r.serve))
+	return r
+}
+
+func (r *Replayer) serve(w http.ResponseWriter, req *http.Request) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for i, one := range r.interactions {
+		if r.used[i] || !strings.EqualFold(one.Method, req.Method) || one.Path != req.URL.Path {
+			continue
+		}
+		r.used[i] = true
+		for key, value := range one.Headers {
+			w.Header().Set(key, value)
+		}
+		if len(w.Header().Get("Content-Type")) == 0 {
+			w.Header().Set("Content-Type", "application/json")
+		}
+		status := one.Status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		w.WriteHeader(status)
+		_, _ = w.Write(one.Body)
+		return
+	}
+	r.Unmatched = append(r.Unmatched, fmt.Sprintf("%s %s", req.Method, req.URL.Path))
+	w.WriteHeader(http.StatusNotImplemented)
+	_, _ = w.Write([]byte(`{"error":"no recorded interaction"}`))
+}
+
+// Client returns a http client sending every request to the replay server whatever the host
+func (r *Replayer) Client() *http.Client {
+	target, _ := url.Parse(r.Server.URL)
+	return &http.Client{Transport: &redirectTransport{target: target, base: r.Server.Client().Transport}}
+}
+
+// Close stops the server
+func (r *Replayer) Close() {
+	r.Server.Close()
+}
+
+// Pending returns the recorded interactions which were never requested
+func (r *Replayer) Pending() []string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	var list []string
+	for i, one := range r.interactions {
+		if !r.used[i] {
+			list = append(list, fmt.Sprintf("%s %s", one.Method, one.Path))
+		}
+	}
+	return list
+}
+
+type redirectTransport struct {
+	target *url.URL
+	base   http.RoundTripper
+}
+
+func (t *redirectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	clone := req.Clone(req.Context())
+	clone.URL.Scheme = t.target.Scheme
+	clone.URL.Host = t.target.Host
+	clone.Host = t.target.Host
+	return t.base.RoundTrip(clone)
+}
@@ -0,0 +1,400 @@
+package conformance
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/stretchr/testify/require"
+	"unibee/api/bean"
+	"unibee/internal/consts"
+	_interface "unibee/internal/interface"
+	"unibee/internal/logic/gateway/gateway_bean"
+	"unibee/internal/logic/gateway/webhook"
+	entity "unibee/internal/model/entity/default"
+)
+
+const (
+	ObjectPayment = "payment"
+	ObjectRefund  = "refund"
+)
+
+// Suite describes one gateway run, the flow is create -> detail polls -> capture or cancel -> refund -> refund detail polls -> webhooks.
+// Only the gateways taking an injected http client can be replayed, which today are api.MulenPay and api.RemoteAdapter,
+// the other gateways call their providers through the provider SDKs and are not covered
+type Suite struct {
+	// Gateway returns the gateway sending its provider calls through the replay client
+	Gateway func(client *http.Client) _interface.GatewayInterface
+	// Webhook returns the webhook processing with the store of the run, nil when no webhook cases recorded
+	Webhook         func(store webhook.Store) _interface.GatewayWebhookInterface
+	MerchantGateway *entity.MerchantGateway
+	Recording       *Recording
+	Payment         *entity.Payment
+	// Invoice is sent with the payment, a single line invoice of the payment amount when nil
+	Invoice *bean.Invoice
+	// PaymentDetailPolls is the number of GatewayPaymentDetail calls after create, each answered by the next recorded interaction
+	PaymentDetailPolls int
+	// Capture calls GatewayCapture then GatewayPaymentDetail to observe the captured state
+	Capture           bool
+	Cancel            bool
+	RefundAmount      int64
+	RefundDetailPolls int
+	// SignWebhook returns the signature headers of the webhook body, applied to the webhook cases with sign set
+	SignWebhook func(body []byte) map[string]string
+	// ExpectTransitions is the status the gateway returned or emitted at every step, webhook steps are named "Webhook:<case>"
+	ExpectTransitions []*Transition
+}
+
+// Transition is the status change of the payment or refund observed from the gateway output at one step
+type Transition struct {
+	Step   string
+	Object string
+	From   int
+	To     int
+}
+
+func (t *Transition) String() string {
+	return fmt.Sprintf("%s %s %d->%d", t.Step, t.Object, t.From, t.To)
+}
+
+// Result is the observed state of a run
+type Result struct {
+	Payment     *entity.Payment
+	Refund      *entity.Refund
+	Transitions []*Transition
+}
+
+var paymentTransitions = map[int][]int{
+	0:                       {consts.PaymentCreated, consts.PaymentSuccess, consts.PaymentFailed, consts.PaymentCancelled},
+	consts.PaymentCreated:   {consts.PaymentCreated, consts.PaymentSuccess, consts.PaymentFailed, consts.PaymentCancelled},
+	consts.PaymentSuccess:   {consts.PaymentSuccess},
+	consts.PaymentFailed:    {consts.PaymentFailed},
+	consts.PaymentCancelled: {consts.PaymentCancelled},
+}
+
+var refundTransitions = map[int][]int{
+	0:                      {consts.RefundCreated, consts.RefundSuccess, consts.RefundFailed, consts.RefundCancelled},
+	consts.RefundCreated:   {consts.RefundCreated, consts.RefundSuccess, consts.RefundFailed, consts.RefundCancelled},
+	consts.RefundSuccess:   {consts.RefundSuccess, consts.RefundReverse},
+	consts.RefundFailed:    {consts.RefundFailed},
+	consts.RefundCancelled: {consts.RefundCancelled},
+	consts.RefundReverse:   {consts.RefundReverse},
+}
+
+func contains(list []int, value int) bool {
+	for _, one := range list {
+		if one == value {
+			return true
+		}
+	}
+	return false
+}
+
+type runner struct {
+	t        *testing.T
+	result   *Result
+	provider _interface.GatewayInterface
+}
+
+func (r *runner) payment(status int, step string) {
+	current := r.result.Payment.Status
+	allowed, ok := paymentTransitions[current]
+	require.True(r.t, ok, "%s: unknown payment status %d", step, current)
+	require.True(r.t, contains(allowed, status), "%s: invalid payment transition %d -> %d", step, current, status)
+	r.result.Transitions = append(r.result.Transitions, &Transition{Step: step, Object: ObjectPayment, From: current, To: status})
+	r.result.Payment.Status = status
+}
+
+func (r *runner) refund(status int, step string) {
+	require.NotNil(r.t, r.result.Refund, "%s: refund update without refund", step)
+	current := r.result.Refund.Status
+	allowed, ok := refundTransitions[current]
+	require.True(r.t, ok, "%s: unknown refund status %d", step, current)
+	require.True(r.t, contains(allowed, status), "%s: invalid refund transition %d -> %d", step, current, status)
+	r.result.Transitions = append(r.result.Transitions, &Transition{Step: step, Object: ObjectRefund, From: current, To: status})
+	r.result.Refund.Status = status
+}
+
+// Run drives the suite against the recorded interactions and asserts state transitions and amounts
+func Run(t *testing.T, suite *Suite) *Result {
+	require.NotNil(t, suite.Gateway)
+	require.NotNil(t, suite.MerchantGateway)
+	require.NotNil(t, suite.Recording)
+	require.NotNil(t, suite.Payment)
+	require.False(t, suite.Capture && suite.Cancel, "capture and cancel are exclusive")
+	gateway := suite.MerchantGateway
+	payment := *suite.Payment
+	if payment.GatewayId == 0 {
+		payment.GatewayId = gateway.Id
+	}
+	if payment.MerchantId == 0 {
+		payment.MerchantId = gateway.MerchantId
+	}
+	payment.Status = 0
+	r := &runner{t: t, result: &Result{Payment: &payment}}
+
+	replayer := NewReplayer(suite.Recording.Interactions)
+	defer replayer.Close()
+	ctx := context.Background()
+	provider := suite.Gateway(replayer.Client())
+	r.provider = provider
+
+	invoice := suite.Invoice
+	if invoice == nil {
+		invoice = &bean.Invoice{
+			InvoiceName: "Conformance",
+			Currency:    payment.Currency,
+			TotalAmount: payment.TotalAmount,
+			Lines: []*bean.InvoiceItemSimplify{{
+				Currency:    payment.Currency,
+				Amount:      payment.TotalAmount,
+				Name:        "Conformance",
+				Description: "Conformance payment",
+				Quantity:    1,
+			}},
+		}
+	}
+	created, err := provider.GatewayNewPayment(ctx, gateway, &gateway_bean.GatewayNewPaymentReq{
+		Pay:      r.paymentCopy(),
+		Gateway:  gateway,
+		Email:    "conformance@unibee.dev",
+		Invoice:  invoice,
+		Metadata: map[string]interface{}{"PaymentId": payment.PaymentId},
+	})
+	require.Nil(t, err, "GatewayNewPayment")
+	require.NotNil(t, created)
+	require.NotEmpty(t, created.GatewayPaymentId, "GatewayNewPayment: empty gatewayPaymentId")
+	payment.GatewayPaymentId = created.GatewayPaymentId
+	r.payment(int(created.Status), "GatewayNewPayment")
+
+	for i := 0; i < suite.PaymentDetailPolls; i++ {
+		r.paymentDetail(ctx, suite, "GatewayPaymentDetail")
+	}
+
+	if suite.Capture {
+		captured, err := provider.GatewayCapture(ctx, gateway, r.paymentCopy())
+		require.Nil(t, err, "GatewayCapture")
+		require.NotNil(t, captured)
+		require.LessOrEqual(t, captured.Amount, payment.TotalAmount, "GatewayCapture: captured more than total")
+		detail := r.paymentDetail(ctx, suite, "GatewayCapture")
+		require.Equal(t, consts.PaymentSuccess, detail.Status, "GatewayCapture: payment not paid after capture")
+		if captured.Amount > 0 && detail.PaymentAmount > 0 {
+			require.Equal(t, captured.Amount, detail.PaymentAmount, "GatewayCapture: captured amount not match the paid amount")
+		}
+	}
+	if suite.Cancel {
+		cancelled, err := provider.GatewayCancel(ctx, gateway, r.paymentCopy())
+		require.Nil(t, err, "GatewayCancel")
+		require.NotNil(t, cancelled)
+		r.payment(int(cancelled.Status), "GatewayCancel")
+	}
+
+	if suite.RefundAmount > 0 {
+		require.Equal(t, consts.PaymentSuccess, payment.Status, "refund requires a paid payment")
+		require.LessOrEqual(t, suite.RefundAmount, payment.TotalAmount, "refund more than total")
+		refund := &entity.Refund{
+			MerchantId:    payment.MerchantId,
+			GatewayId:     payment.GatewayId,
+			RefundId:      fmt.Sprintf("%s_refund", payment.PaymentId),
+			PaymentId:     payment.PaymentId,
+			RefundAmount:  suite.RefundAmount,
+			Currency:      payment.Currency,
+			RefundComment: "conformance",
+		}
+		r.result.Refund = refund
+		resp, err := provider.GatewayRefund(ctx, gateway, &gateway_bean.GatewayNewPaymentRefundReq{
+			Payment: r.paymentCopy(),
+			Refund:  r.refundCopy(),
+		})
+		require.Nil(t, err, "GatewayRefund")
+		require.NotNil(t, resp)
+		require.NotEmpty(t, resp.GatewayRefundId, "GatewayRefund: empty gatewayRefundId")
+		require.Equal(t, consts.RefundTypeGateway, resp.Type, "GatewayRefund: refund type")
+		if resp.RefundAmount > 0 {
+			require.Equal(t, suite.RefundAmount, resp.RefundAmount, "GatewayRefund: refund amount")
+		}
+		refund.GatewayRefundId = resp.GatewayRefundId
+		r.refund(int(resp.Status), "GatewayRefund")
+		for i := 0; i < suite.RefundDetailPolls; i++ {
+			detail, err := provider.GatewayRefundDetail(ctx, gateway, refund.GatewayRefundId, r.refundCopy())
+			require.Nil(t, err, "GatewayRefundDetail")
+			require.NotNil(t, detail)
+			if detail.RefundAmount > 0 {
+				require.Equal(t, suite.RefundAmount, detail.RefundAmount, "GatewayRefundDetail: refund amount")
+			}
+			r.refund(int(detail.Status), "GatewayRefundDetail")
+		}
+	}
+
+	if len(suite.Recording.Webhooks) > 0 {
+		require.NotNil(t, suite.Webhook, "webhook cases recorded without webhook implementation")
+		r.deliverWebhooks(suite)
+	}
+
+	require.Empty(t, replayer.Unmatched, "requests without recorded interaction")
+	require.Empty(t, replayer.Pending(), "recorded interactions never requested")
+
+	if suite.ExpectTransitions != nil {
+		require.Equal(t, transitionStrings(suite.ExpectTransitions), transitionStrings(r.result.Transitions), "transitions")
+	}
+	return r.result
+}
+
+// paymentCopy returns the payment sent to the gateway, the gateway logs it in background while the run goes on
+func (r *runner) paymentCopy() *entity.Payment {
+	one := *r.result.Payment
+	return &one
+}
+
+func (r *runner) refundCopy() *entity.Refund {
+	one := *r.result.Refund
+	return &one
+}
+
+func (r *runner) paymentDetail(ctx context.Context, suite *Suite, step string) *gateway_bean.GatewayPaymentRo {
+	payment := r.result.Payment
+	detail, err := r.provider.GatewayPaymentDetail(ctx, suite.MerchantGateway, payment.GatewayPaymentId, r.paymentCopy())
+	require.Nil(r.t, err, step)
+	require.NotNil(r.t, detail)
+	require.Equal(r.t, payment.GatewayPaymentId, detail.GatewayPaymentId, "%s: gatewayPaymentId changed", step)
+	if detail.PaymentAmount > 0 {
+		require.LessOrEqual(r.t, detail.PaymentAmount, payment.TotalAmount, "%s: paid more than total", step)
+	}
+	r.payment(detail.Status, step)
+	return detail
+}
+
+func transitionStrings(list []*Transition) []string {
+	var result = make([]string, 0)
+	for _, one := range list {
+		result = append(result, one.String())
+	}
+	return result
+}
+
+// store answers the record lookups of the webhook processing with the payment and refund of the run,
+// and keeps the updates the gateway emits instead of handling them
+type store struct {
+	gateway  _interface.GatewayInterface
+	result   *Result
+	lock     sync.Mutex
+	payments []*gateway_bean.GatewayPaymentRo
+	refunds  []*gateway_bean.GatewayPaymentRefundResp
+}
+
+func (o *store) Gateway(ctx context.Context, gateway *entity.MerchantGateway) _interface.GatewayInterface {
+	return o.gateway
+}
+
+func (o *store) Payment(ctx context.Context, paymentId string) *entity.Payment {
+	if o.result.Payment != nil && o.result.Payment.PaymentId == paymentId {
+		one := *o.result.Payment
+		return &one
+	}
+	return nil
+}
+
+func (o *store) PaymentByGatewayPaymentId(ctx context.Context, gatewayId uint64, gatewayPaymentId string) *entity.Payment {
+	if gatewayId > 0 && o.result.Payment != nil && o.result.Payment.GatewayId != gatewayId {
+		return nil
+	}
+	if o.result.Payment != nil && len(gatewayPaymentId) > 0 && o.result.Payment.GatewayPaymentId == gatewayPaymentId {
+		one := *o.result.Payment
+		return &one
+	}
+	return nil
+}
+
+func (o *store) RefundByGatewayRefundId(ctx context.Context, gatewayRefundId string) *entity.Refund {
+	if o.result.Refund != nil && len(gatewayRefundId) > 0 && o.result.Refund.GatewayRefundId == gatewayRefundId {
+		one := *o.result.Refund
+		return &one
+	}
+	return nil
+}
+
+func (o *store) PaymentUpdated(ctx context.Context, paymentId string, ro *gateway_bean.GatewayPaymentRo) error {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	o.payments = append(o.payments, ro)
+	return nil
+}
+
+func (o *store) RefundUpdated(ctx context.Context, ro *gateway_bean.GatewayPaymentRefundResp) error {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	o.refunds = append(o.refunds, ro)
+	return nil
+}
+
+func (o *store) drain() ([]*gateway_bean.GatewayPaymentRo, []*gateway_bean.GatewayPaymentRefundResp) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	payments, refunds := o.payments, o.refunds
+	o.payments, o.refunds = nil, nil
+	return payments, refunds
+}
+
+// deliverWebhooks posts every case to GatewayWebhook through a local ghttp server, checks the response
+// and records the payment and refund updates the gateway emits for the case
+func (r *runner) deliverWebhooks(suite *Suite) {
+	t := r.t
+	gateway := suite.MerchantGateway
+	records := &store{gateway: r.provider, result: r.result}
+	handler := suite.Webhook(records)
+	s := g.Server(fmt.Sprintf("conformance-%s-%d", gateway.GatewayName, gateway.Id))
+	s.SetAddr("127.0.0.1:0")
+	s.SetDumpRouterMap(false)
+	s.BindHandler("POST:/webhook", func(req *ghttp.Request) {
+		handler.GatewayWebhook(req, gateway)
+	})
+	require.Nil(t, s.Start())
+	defer func() {
+		_ = s.Shutdown()
+	}()
+	sender := &http.Client{Transport: &http.Transport{}}
+	url := fmt.Sprintf("http://127.0.0.1:%d/webhook", s.GetListenedPort())
+	for _, one := range suite.Recording.Webhooks {
+		req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(one.Body))
+		require.Nil(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		for key, value := range one.Headers {
+			req.Header.Set(key, value)
+		}
+		if one.Sign {
+			require.NotNil(t, suite.SignWebhook, "webhook %s: signed case without SignWebhook", one.Name)
+			for key, value := range suite.SignWebhook(one.Body) {
+				req.Header.Set(key, value)
+			}
+		}
+		resp, err := sender.Do(req)
+		require.Nil(t, err, "webhook %s", one.Name)
+		body, _ := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		expectStatus := one.ExpectStatus
+		if expectStatus == 0 {
+			expectStatus = http.StatusOK
+		}
+		require.Equal(t, expectStatus, resp.StatusCode, "webhook %s: %s", one.Name, string(body))
+		if len(one.ExpectBodyContains) > 0 {
+			require.True(t, strings.Contains(string(body), one.ExpectBodyContains), "webhook %s: unexpected body %s", one.Name, string(body))
+		}
+		step := fmt.Sprintf("Webhook:%s", one.Name)
+		payments, refunds := records.drain()
+		for _, ro := range payments {
+			require.Equal(t, r.result.Payment.GatewayPaymentId, ro.GatewayPaymentId, "%s: gatewayPaymentId changed", step)
+			r.payment(ro.Status, step)
+		}
+		for _, ro := range refunds {
+			require.Equal(t, r.result.Refund.GatewayRefundId, ro.GatewayRefundId, "%s: gatewayRefundId changed", step)
+			r.refund(int(ro.Status), step)
+		}
+	}
+}
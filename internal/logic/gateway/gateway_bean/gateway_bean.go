@@ -33,6 +33,7 @@ type GatewayNewPaymentReq struct {
 	GatewayCurrencyExchange *GatewayCurrencyExchange `json:"gatewayCurrencyExchange"`
 	ExchangeAmount          int64                    `json:"exchangeAmount"           description:"exchange_amount, cent"`
 	ExchangeCurrency        string                   `json:"exchangeCurrency"         description:"exchange_currency"`
+	Offline                 bool                     `json:"offline"                  description:"the payment received outside the gateway, the gateway is not called"`
 }
 
 func (c *GatewayNewPaymentReq) GetInvoiceSingleProductNameAndDescription() (name string, description string) {
@@ -133,7 +134,9 @@ type GatewayCryptoToCurrencyAmountDetailRes struct {
 }
 
 type GatewayPaymentListReq struct {
-	UserId uint64 `json:"userId"         `
+	UserId          uint64 `json:"userId"         `
+	CreateTimeStart int64  `json:"createTimeStart" description:"utc time, gateways support window query should only return payments created after"`
+	CreateTimeEnd   int64  `json:"createTimeEnd"   description:"utc time, gateways support window query should only return payments created before"`
 }
 
 // GatewayPaymentRo is the golang structure for table oversea_pay.
@@ -163,6 +166,7 @@ type GatewayPaymentRo struct {
 	GatewayPaymentId     string      `json:"gatewayPaymentId"              `
 	GatewayPaymentMethod string      `json:"gatewayPaymentMethod"              `
 	RefundSequence       int64       `json:"refundSequence" `
+	PaymentId            string      `json:"paymentId" description:"the local payment id carried by the provider record, matches the local payment when the listed id differs from its gateway payment id"`
 }
 
 type GatewayCreateSubscriptionResp struct {
@@ -239,3 +243,32 @@ type GatewayRedirectResp struct {
 	Success   bool            `json:"success"`
 	QueryPath string          `json:"queryPath"`
 }
+
+type GatewaySettlementListReq struct {
+	ArrivalTimeStart int64 `json:"arrivalTimeStart" description:"utc time, return settlements arrive after"`
+	ArrivalTimeEnd   int64 `json:"arrivalTimeEnd"   description:"utc time, return settlements arrive before"`
+}
+
+// GatewaySettlement is one provider payout with the balance transactions settled in it
+type GatewaySettlement struct {
+	GatewaySettlementId string                   `json:"gatewaySettlementId"`
+	Currency            string                   `json:"currency"`
+	NetAmount           int64                    `json:"netAmount"`
+	Status              int                      `json:"status"`
+	ArrivalTime         int64                    `json:"arrivalTime"`
+	Data                string                   `json:"data"`
+	Items               []*GatewaySettlementItem `json:"items"`
+}
+
+type GatewaySettlementItem struct {
+	GatewayTransactionId string `json:"gatewayTransactionId"`
+	Type                 string `json:"type"`
+	GatewayPaymentId     string `json:"gatewayPaymentId"`
+	GatewayRefundId      string `json:"gatewayRefundId"`
+	PaymentId            string `json:"paymentId" description:"local paymentId if the gateway carries it"`
+	Currency             string `json:"currency"`
+	GrossAmount          int64  `json:"grossAmount"`
+	FeeAmount            int64  `json:"feeAmount"`
+	NetAmount            int64  `json:"netAmount"`
+	TransactionTime      int64  `json:"transactionTime"`
+}
@@ -0,0 +1,203 @@
+package reconciliation
+
+import (
+	"strings"
+
+	"unibee/internal/consts"
+	"unibee/internal/logic/gateway/gateway_bean"
+	entity "unibee/internal/model/entity/default"
+)
+
+const (
+	MismatchMissingLocal  = "missing_local"
+	MismatchStatusDiffers = "status_differs"
+	MismatchAmountDiffers = "amount_differs"
+	MismatchOrphanRefund  = "orphan_refund"
+	// PaymentListUnsupported and PaymentListFailed mark the window not checked for the provider payments missing locally,
+	// the local payments are still reconciled by detail
+	PaymentListUnsupported = "payment_list_unsupported"
+	PaymentListFailed      = "payment_list_failed"
+)
+
+// Item is one mismatch between a local row and the provider record
+type Item struct {
+	Type             string
+	GatewayId        uint64
+	PaymentId        string
+	RefundId         string
+	GatewayPaymentId string
+	GatewayRefundId  string
+	Currency         string
+	LocalStatus      int
+	GatewayStatus    int
+	LocalAmount      int64
+	GatewayAmount    int64
+	// Healable is true when the local row is still pending and the provider reports a final status,
+	// the same change a delayed webhook would apply
+	Healable  bool
+	Healed    bool
+	HealError string
+	Message   string
+
+	gatewayPayment *gateway_bean.GatewayPaymentRo
+	gatewayRefund  *gateway_bean.GatewayPaymentRefundResp
+}
+
+func finalPaymentStatus(status int) bool {
+	return status == consts.PaymentSuccess || status == consts.PaymentFailed || status == consts.PaymentCancelled
+}
+
+func finalRefundStatus(status int) bool {
+	return status == consts.RefundSuccess || status == consts.RefundFailed || status == consts.RefundCancelled
+}
+
+func sameCurrency(local string, remote string) bool {
+	return len(remote) == 0 || strings.EqualFold(local, remote)
+}
+
+func gatewayPaymentAmount(remote *gateway_bean.GatewayPaymentRo) int64 {
+	if remote.TotalAmount > 0 {
+		return remote.TotalAmount
+	}
+	return remote.PaymentAmount
+}
+
+// PaymentListNotChecked returns the item marking the provider payment list of the window not checked
+func PaymentListNotChecked(gatewayId uint64, listErr error) *Item {
+	if listErr == nil {
+		return &Item{
+			Type:      PaymentListUnsupported,
+			GatewayId: gatewayId,
+			Message:   "gateway does not list payments by time window, payments missing locally not detected",
+		}
+	}
+	return &Item{
+		Type:      PaymentListFailed,
+		GatewayId: gatewayId,
+		Message:   listErr.Error(),
+	}
+}
+
+// ComparePayment returns the mismatches between a local payment and its provider record
+func ComparePayment(local *entity.Payment, remote *gateway_bean.GatewayPaymentRo) []*Item {
+	var list []*Item
+	if local == nil || remote == nil {
+		return list
+	}
+	if remote.Status > 0 && remote.Status != local.Status {
+		list = append(list, &Item{
+			Type:             MismatchStatusDiffers,
+			GatewayId:        local.GatewayId,
+			PaymentId:        local.PaymentId,
+			GatewayPaymentId: local.GatewayPaymentId,
+			Currency:         local.Currency,
+			LocalStatus:      local.Status,
+			GatewayStatus:    remote.Status,
+			LocalAmount:      local.TotalAmount,
+			GatewayAmount:    gatewayPaymentAmount(remote),
+			Healable:         local.Status == consts.PaymentCreated && finalPaymentStatus(remote.Status),
+			gatewayPayment:   remote,
+		})
+	}
+	// the provider may charge in an exchanged currency, amounts are only comparable in the same currency
+	if amount := gatewayPaymentAmount(remote); amount > 0 && amount != local.TotalAmount && sameCurrency(local.Currency, remote.Currency) {
+		list = append(list, &Item{
+			Type:             MismatchAmountDiffers,
+			GatewayId:        local.GatewayId,
+			PaymentId:        local.PaymentId,
+			GatewayPaymentId: local.GatewayPaymentId,
+			Currency:         local.Currency,
+			LocalStatus:      local.Status,
+			GatewayStatus:    remote.Status,
+			LocalAmount:      local.TotalAmount,
+			GatewayAmount:    amount,
+		})
+	}
+	return list
+}
+
+// MissingLocalPayment is reported for a provider record without local payment
+func MissingLocalPayment(gatewayId uint64, remote *gateway_bean.GatewayPaymentRo) *Item {
+	return &Item{
+		Type:             MismatchMissingLocal,
+		GatewayId:        gatewayId,
+		GatewayPaymentId: remote.GatewayPaymentId,
+		Currency:         remote.Currency,
+		GatewayStatus:    remote.Status,
+		GatewayAmount:    gatewayPaymentAmount(remote),
+	}
+}
+
+// CompareRefunds matches the provider refunds of one payment with the local refunds by gatewayRefundId,
+// provider refunds without local row are orphan refunds
+func CompareRefunds(payment *entity.Payment, local []*entity.Refund, remote []*gateway_bean.GatewayPaymentRefundResp) []*Item {
+	var list []*Item
+	localMap := make(map[string]*entity.Refund)
+	for _, one := range local {
+		if one != nil && len(one.GatewayRefundId) > 0 {
+			localMap[one.GatewayRefundId] = one
+		}
+	}
+	for _, one := range remote {
+		if one == nil || len(one.GatewayRefundId) == 0 {
+			continue
+		}
+		refund, ok := localMap[one.GatewayRefundId]
+		if !ok {
+			list = append(list, &Item{
+				Type:             MismatchOrphanRefund,
+				GatewayId:        payment.GatewayId,
+				PaymentId:        payment.PaymentId,
+				GatewayPaymentId: payment.GatewayPaymentId,
+				GatewayRefundId:  one.GatewayRefundId,
+				Currency:         one.Currency,
+				GatewayStatus:    int(one.Status),
+				GatewayAmount:    one.RefundAmount,
+			})
+			continue
+		}
+		list = append(list, CompareRefund(payment, refund, one)...)
+	}
+	return list
+}
+
+// CompareRefund returns the mismatches between a local refund and its provider record
+func CompareRefund(payment *entity.Payment, local *entity.Refund, remote *gateway_bean.GatewayPaymentRefundResp) []*Item {
+	var list []*Item
+	if local == nil || remote == nil {
+		return list
+	}
+	if remote.Status > 0 && int(remote.Status) != local.Status {
+		list = append(list, &Item{
+			Type:             MismatchStatusDiffers,
+			GatewayId:        local.GatewayId,
+			PaymentId:        local.PaymentId,
+			RefundId:         local.RefundId,
+			GatewayPaymentId: payment.GatewayPaymentId,
+			GatewayRefundId:  local.GatewayRefundId,
+			Currency:         local.Currency,
+			LocalStatus:      local.Status,
+			GatewayStatus:    int(remote.Status),
+			LocalAmount:      local.RefundAmount,
+			GatewayAmount:    remote.RefundAmount,
+			Healable:         local.Status == consts.RefundCreated && finalRefundStatus(int(remote.Status)),
+			gatewayRefund:    remote,
+		})
+	}
+	if remote.RefundAmount > 0 && remote.RefundAmount != local.RefundAmount && sameCurrency(local.Currency, remote.Currency) {
+		list = append(list, &Item{
+			Type:             MismatchAmountDiffers,
+			GatewayId:        local.GatewayId,
+			PaymentId:        local.PaymentId,
+			RefundId:         local.RefundId,
+			GatewayPaymentId: payment.GatewayPaymentId,
+			GatewayRefundId:  local.GatewayRefundId,
+			Currency:         local.Currency,
+			LocalStatus:      local.Status,
+			GatewayStatus:    int(remote.Status),
+			LocalAmount:      local.RefundAmount,
+			GatewayAmount:    remote.RefundAmount,
+		})
+	}
+	return list
+}
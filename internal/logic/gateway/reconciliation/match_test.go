@@ -0,0 +1,75 @@
+package reconciliation
+
+import (
+	"testing"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/stretchr/testify/require"
+	"unibee/internal/consts"
+	"unibee/internal/logic/gateway/gateway_bean"
+	entity "unibee/internal/model/entity/default"
+)
+
+func TestComparePayment(t *testing.T) {
+	local := &entity.Payment{PaymentId: "pay_1", GatewayId: 1, GatewayPaymentId: "gp_1", Currency: "EUR", TotalAmount: 1000, Status: consts.PaymentCreated}
+	t.Run("Test Match", func(t *testing.T) {
+		require.Empty(t, ComparePayment(local, &gateway_bean.GatewayPaymentRo{GatewayPaymentId: "gp_1", Currency: "EUR", TotalAmount: 1000, Status: consts.PaymentCreated}))
+	})
+	t.Run("Test Pending Local Healable", func(t *testing.T) {
+		list := ComparePayment(local, &gateway_bean.GatewayPaymentRo{GatewayPaymentId: "gp_1", Currency: "EUR", PaymentAmount: 1000, Status: consts.PaymentSuccess})
+		require.Equal(t, 1, len(list))
+		require.Equal(t, MismatchStatusDiffers, list[0].Type)
+		require.True(t, list[0].Healable)
+	})
+	t.Run("Test Final Local Not Healable", func(t *testing.T) {
+		paid := *local
+		paid.Status = consts.PaymentSuccess
+		list := ComparePayment(&paid, &gateway_bean.GatewayPaymentRo{GatewayPaymentId: "gp_1", Currency: "EUR", TotalAmount: 1000, Status: consts.PaymentFailed})
+		require.Equal(t, 1, len(list))
+		require.False(t, list[0].Healable)
+	})
+	t.Run("Test Amount Differs", func(t *testing.T) {
+		list := ComparePayment(local, &gateway_bean.GatewayPaymentRo{GatewayPaymentId: "gp_1", Currency: "EUR", TotalAmount: 900, Status: consts.PaymentCreated})
+		require.Equal(t, 1, len(list))
+		require.Equal(t, MismatchAmountDiffers, list[0].Type)
+		require.Equal(t, int64(900), list[0].GatewayAmount)
+	})
+	t.Run("Test Exchanged Currency Ignored", func(t *testing.T) {
+		require.Empty(t, ComparePayment(local, &gateway_bean.GatewayPaymentRo{GatewayPaymentId: "gp_1", Currency: "USD", TotalAmount: 1100, Status: consts.PaymentCreated}))
+	})
+}
+
+func TestCompareRefunds(t *testing.T) {
+	payment := &entity.Payment{PaymentId: "pay_1", GatewayId: 1, GatewayPaymentId: "gp_1", Currency: "EUR", TotalAmount: 1000, Status: consts.PaymentSuccess}
+	locals := []*entity.Refund{
+		{RefundId: "re_1", PaymentId: "pay_1", GatewayRefundId: "gr_1", Currency: "EUR", RefundAmount: 300, Status: consts.RefundCreated},
+		{RefundId: "re_2", PaymentId: "pay_1", GatewayRefundId: "gr_2", Currency: "EUR", RefundAmount: 200, Status: consts.RefundSuccess},
+	}
+	remotes := []*gateway_bean.GatewayPaymentRefundResp{
+		{GatewayRefundId: "gr_1", Currency: "EUR", RefundAmount: 300, Status: consts.RefundSuccess},
+		{GatewayRefundId: "gr_2", Currency: "EUR", RefundAmount: 250, Status: consts.RefundSuccess},
+		{GatewayRefundId: "gr_3", Currency: "EUR", RefundAmount: 100, Status: consts.RefundSuccess},
+	}
+	list := CompareRefunds(payment, locals, remotes)
+	require.Equal(t, 3, len(list))
+	require.Equal(t, MismatchStatusDiffers, list[0].Type)
+	require.Equal(t, "re_1", list[0].RefundId)
+	require.True(t, list[0].Healable)
+	require.Equal(t, MismatchAmountDiffers, list[1].Type)
+	require.Equal(t, "re_2", list[1].RefundId)
+	require.False(t, list[1].Healable)
+	require.Equal(t, MismatchOrphanRefund, list[2].Type)
+	require.Equal(t, "gr_3", list[2].GatewayRefundId)
+	require.Equal(t, "pay_1", list[2].PaymentId)
+}
+
+func TestPaymentListNotChecked(t *testing.T) {
+	one := PaymentListNotChecked(1, nil)
+	require.Equal(t, PaymentListUnsupported, one.Type)
+	require.Equal(t, uint64(1), one.GatewayId)
+	require.NotEmpty(t, one.Message)
+	require.False(t, one.Healable)
+	one = PaymentListNotChecked(1, gerror.New("rate limited"))
+	require.Equal(t, PaymentListFailed, one.Type)
+	require.Equal(t, "rate limited", one.Message)
+}
@@ -0,0 +1,253 @@
+package reconciliation
+
+import (
+	"context"
+	"fmt"
+
+	"unibee/internal/consts"
+	dao "unibee/internal/dao/default"
+	_interface "unibee/internal/interface"
+	"unibee/internal/logic/gateway/api"
+	"unibee/internal/logic/gateway/gateway_bean"
+	"unibee/internal/logic/merchant_config"
+	"unibee/internal/logic/operation_log"
+	"unibee/internal/logic/payment/handler"
+	entity "unibee/internal/model/entity/default"
+	"unibee/internal/query"
+	"unibee/utility"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+const (
+	MerchantGatewayReconciliationConfig = "KEY_MERCHANT_GATEWAY_RECONCILIATION"
+	// MaxGatewayQueryCount limits the provider detail and refund list calls of one run
+	MaxGatewayQueryCount = 1000
+)
+
+type Config struct {
+	Enable   bool `json:"enable"   dc:"Run the daily reconciliation for every gateway of the merchant"`
+	AutoHeal bool `json:"autoHeal" dc:"Apply provider final status to local pending payments and refunds"`
+}
+
+func GetMerchantReconciliationConfig(ctx context.Context, merchantId uint64) *Config {
+	config := &Config{}
+	one := merchant_config.GetMerchantConfig(ctx, merchantId, MerchantGatewayReconciliationConfig)
+	if one != nil && len(one.ConfigValue) > 0 {
+		_ = utility.UnmarshalFromJsonString(one.ConfigValue, config)
+	}
+	return config
+}
+
+type Req struct {
+	Gateway   *entity.MerchantGateway
+	StartTime int64
+	EndTime   int64
+	AutoHeal  bool
+}
+
+// Reconcile compares the local payments and refunds of the gateway created in the window with the provider records.
+// Provider records come from GatewayPaymentList for the window and GatewayPaymentDetail/GatewayRefundList for local rows,
+// gateways without window list support are still reconciled by detail and the report marks the list not checked.
+func Reconcile(ctx context.Context, req *Req) (list []*Item, err error) {
+	utility.Assert(req.Gateway != nil, "gateway not found")
+	utility.Assert(req.EndTime > req.StartTime, "invalid reconciliation window")
+	gateway := req.Gateway
+	provider := api.GetGatewayServiceProvider(ctx, gateway.Id)
+	utility.Assert(provider != nil, "gateway not support")
+
+	var locals []*entity.Payment
+	err = dao.Payment.Ctx(ctx).
+		Where(dao.Payment.Columns().MerchantId, gateway.MerchantId).
+		Where(dao.Payment.Columns().GatewayId, gateway.Id).
+		WhereGTE(dao.Payment.Columns().CreateTime, req.StartTime).
+		WhereLT(dao.Payment.Columns().CreateTime, req.EndTime).
+		WhereNot(dao.Payment.Columns().GatewayPaymentId, "").
+		OrderAsc(dao.Payment.Columns().CreateTime).
+		Scan(&locals)
+	if err != nil {
+		return nil, err
+	}
+	localMap := make(map[string]*entity.Payment)
+	for _, one := range locals {
+		localMap[one.GatewayPaymentId] = one
+	}
+
+	remoteMap := make(map[string]*gateway_bean.GatewayPaymentRo)
+	var remotes []*gateway_bean.GatewayPaymentRo
+	if info := provider.GatewayInfo(ctx); info != nil && info.PaymentListEnabled {
+		var listErr error
+		remotes, listErr = safeCall(ctx, "GatewayPaymentList", func() ([]*gateway_bean.GatewayPaymentRo, error) {
+			return provider.GatewayPaymentList(ctx, gateway, &gateway_bean.GatewayPaymentListReq{
+				CreateTimeStart: req.StartTime,
+				CreateTimeEnd:   req.EndTime,
+			})
+		})
+		if listErr != nil {
+			g.Log().Errorf(ctx, "Reconcile gateway:%d payment list error:%s", gateway.Id, listErr.Error())
+			list = append(list, PaymentListNotChecked(gateway.Id, listErr))
+			remotes = nil
+		}
+	} else {
+		list = append(list, PaymentListNotChecked(gateway.Id, nil))
+	}
+	for _, remote := range remotes {
+		if remote == nil || len(remote.GatewayPaymentId) == 0 {
+			continue
+		}
+		if remote.CreateTime != nil && (remote.CreateTime.Timestamp() < req.StartTime || remote.CreateTime.Timestamp() >= req.EndTime) {
+			continue
+		}
+		var local = localMap[remote.GatewayPaymentId]
+		if local == nil && len(remote.PaymentId) > 0 {
+			// the provider lists another id than the gateway payment id, matched by the local payment id it carries
+			if one := query.GetPaymentByPaymentId(ctx, remote.PaymentId); one != nil && one.GatewayId == gateway.Id && len(one.GatewayPaymentId) > 0 {
+				local = one
+				remote.GatewayPaymentId = one.GatewayPaymentId
+			}
+		}
+		remoteMap[remote.GatewayPaymentId] = remote
+		if _, ok := localMap[remote.GatewayPaymentId]; !ok {
+			// created locally outside the window
+			if local == nil {
+				local = query.GetPaymentByGatewayPaymentId(ctx, remote.GatewayPaymentId)
+			}
+			if local == nil || local.GatewayId != gateway.Id {
+				list = append(list, MissingLocalPayment(gateway.Id, remote))
+				continue
+			}
+			list = append(list, ComparePayment(local, remote)...)
+		}
+	}
+
+	var queryCount = 0
+	for _, local := range locals {
+		remote, ok := remoteMap[local.GatewayPaymentId]
+		if !ok && queryCount < MaxGatewayQueryCount {
+			queryCount++
+			remote, err = safeCall(ctx, "GatewayPaymentDetail", func() (*gateway_bean.GatewayPaymentRo, error) {
+				return provider.GatewayPaymentDetail(ctx, gateway, local.GatewayPaymentId, local)
+			})
+			if err != nil {
+				g.Log().Errorf(ctx, "Reconcile gateway:%d payment:%s detail error:%s", gateway.Id, local.PaymentId, err.Error())
+				remote = nil
+			} else if remote != nil && len(remote.GatewayPaymentId) == 0 {
+				remote.GatewayPaymentId = local.GatewayPaymentId
+			}
+		}
+		list = append(list, ComparePayment(local, remote)...)
+		if local.Status != consts.PaymentSuccess && (remote == nil || remote.Status != consts.PaymentSuccess) {
+			continue
+		}
+		if queryCount >= MaxGatewayQueryCount {
+			continue
+		}
+		queryCount++
+		list = append(list, reconcileRefunds(ctx, provider, gateway, local)...)
+	}
+
+	if req.AutoHeal {
+		heal(ctx, gateway, list)
+	}
+	g.Log().Infof(ctx, "Reconcile gateway:%d window:%d-%d local:%d remote:%d mismatch:%d", gateway.Id, req.StartTime, req.EndTime, len(locals), len(remoteMap), len(list))
+	return list, nil
+}
+
+func reconcileRefunds(ctx context.Context, provider _interface.GatewayInterface, gateway *entity.MerchantGateway, payment *entity.Payment) []*Item {
+	var locals []*entity.Refund
+	err := dao.Refund.Ctx(ctx).
+		Where(dao.Refund.Columns().PaymentId, payment.PaymentId).
+		Where(dao.Refund.Columns().Type, consts.RefundTypeGateway).
+		Scan(&locals)
+	if err != nil {
+		g.Log().Errorf(ctx, "Reconcile payment:%s refund query error:%s", payment.PaymentId, err.Error())
+		return nil
+	}
+	remotes, err := safeCall(ctx, "GatewayRefundList", func() ([]*gateway_bean.GatewayPaymentRefundResp, error) {
+		return provider.GatewayRefundList(ctx, gateway, payment.GatewayPaymentId)
+	})
+	if err != nil {
+		g.Log().Errorf(ctx, "Reconcile payment:%s refund list error:%s", payment.PaymentId, err.Error())
+		return nil
+	}
+	list := CompareRefunds(payment, locals, remotes)
+	remoteMap := make(map[string]bool)
+	for _, one := range remotes {
+		if one != nil {
+			remoteMap[one.GatewayRefundId] = true
+		}
+	}
+	// local pending refunds not listed by the provider are checked one by one
+	for _, local := range locals {
+		if len(local.GatewayRefundId) == 0 || remoteMap[local.GatewayRefundId] || local.Status != consts.RefundCreated {
+			continue
+		}
+		remote, err := safeCall(ctx, "GatewayRefundDetail", func() (*gateway_bean.GatewayPaymentRefundResp, error) {
+			return provider.GatewayRefundDetail(ctx, gateway, local.GatewayRefundId, local)
+		})
+		if err != nil {
+			g.Log().Errorf(ctx, "Reconcile refund:%s detail error:%s", local.RefundId, err.Error())
+			continue
+		}
+		if remote != nil && len(remote.GatewayRefundId) == 0 {
+			remote.GatewayRefundId = local.GatewayRefundId
+		}
+		list = append(list, CompareRefund(payment, local, remote)...)
+	}
+	return list
+}
+
+// heal applies the provider final status to pending local rows through the webhook handlers,
+// other mismatches need manual review
+func heal(ctx context.Context, gateway *entity.MerchantGateway, list []*Item) {
+	for _, one := range list {
+		if !one.Healable {
+			continue
+		}
+		var err error
+		if one.gatewayRefund != nil {
+			err = safeHeal(ctx, func() error {
+				return handler.HandleRefundWebhookEvent(ctx, one.gatewayRefund)
+			})
+		} else if one.gatewayPayment != nil {
+			err = safeHeal(ctx, func() error {
+				return handler.HandlePaymentWebhookEvent(ctx, one.PaymentId, one.gatewayPayment)
+			})
+		} else {
+			continue
+		}
+		if err != nil {
+			one.HealError = err.Error()
+			g.Log().Errorf(ctx, "Reconcile heal payment:%s refund:%s error:%s", one.PaymentId, one.RefundId, err.Error())
+		} else {
+			one.Healed = true
+		}
+		operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
+			MerchantId: gateway.MerchantId,
+			Target:     fmt.Sprintf("Payment(%s)", one.PaymentId),
+			Content:    fmt.Sprintf("ReconciliationHeal(%s,%d->%d,Refund:%s)", one.Type, one.LocalStatus, one.GatewayStatus, one.RefundId),
+		}, err)
+	}
+}
+
+func safeCall[T any](ctx context.Context, name string, call func() (T, error)) (res T, err error) {
+	defer func() {
+		if exception := recover(); exception != nil {
+			if v, ok := exception.(error); ok && gerror.HasStack(v) {
+				err = v
+			} else {
+				err = gerror.NewCodef(gcode.CodeInternalPanic, "%s panic:%+v", name, exception)
+			}
+		}
+	}()
+	return call()
+}
+
+func safeHeal(ctx context.Context, call func() error) error {
+	_, err := safeCall(ctx, "Heal", func() (bool, error) {
+		return true, call()
+	})
+	return err
+}
@@ -4,23 +4,24 @@ import (
 	"context"
 	"github.com/gogf/gf/v2/errors/gerror"
 	"github.com/gogf/gf/v2/frame/g"
-	"unibee/internal/logic/gateway/api"
 	"unibee/internal/logic/gateway/gateway_bean"
-	handler2 "unibee/internal/logic/payment/handler"
 	entity "unibee/internal/model/entity/default"
-	"unibee/internal/query"
 )
 
 func ProcessPaymentWebhook(ctx context.Context, metaPaymentId string, gatewayPaymentId string, gateway *entity.MerchantGateway) error {
+	return processPaymentWebhook(ctx, dbStore{}, metaPaymentId, gatewayPaymentId, gateway)
+}
+
+func processPaymentWebhook(ctx context.Context, store Store, metaPaymentId string, gatewayPaymentId string, gateway *entity.MerchantGateway) error {
 	if len(metaPaymentId) > 0 {
 		// PaymentIntent Under UniBee Control
-		payment := query.GetPaymentByPaymentId(ctx, metaPaymentId)
+		payment := store.Payment(ctx, metaPaymentId)
 		if payment != nil {
-			paymentIntentDetail, err := api.GetGatewayServiceProvider(ctx, gateway.Id).GatewayPaymentDetail(ctx, gateway, gatewayPaymentId, payment)
+			paymentIntentDetail, err := store.Gateway(ctx, gateway).GatewayPaymentDetail(ctx, gateway, gatewayPaymentId, payment)
 			if err != nil {
 				return err
 			}
-			err = handler2.HandlePaymentWebhookEvent(ctx, payment.PaymentId, &gateway_bean.GatewayPaymentRo{
+			err = store.PaymentUpdated(ctx, payment.PaymentId, &gateway_bean.GatewayPaymentRo{
 				Status:               paymentIntentDetail.Status,
 				AuthorizeStatus:      paymentIntentDetail.AuthorizeStatus,
 				AuthorizeReason:      paymentIntentDetail.AuthorizeReason,
@@ -57,11 +58,15 @@ func ProcessPaymentWebhook(ctx context.Context, metaPaymentId string, gatewayPay
 }
 
 func ProcessRefundWebhook(ctx context.Context, eventType string, gatewayRefundId string, gateway *entity.MerchantGateway) error {
-	refundDetail, err := api.GetGatewayServiceProvider(ctx, gateway.Id).GatewayRefundDetail(ctx, gateway, gatewayRefundId, nil)
+	return processRefundWebhook(ctx, dbStore{}, eventType, gatewayRefundId, gateway)
+}
+
+func processRefundWebhook(ctx context.Context, store Store, eventType string, gatewayRefundId string, gateway *entity.MerchantGateway) error {
+	refundDetail, err := store.Gateway(ctx, gateway).GatewayRefundDetail(ctx, gateway, gatewayRefundId, nil)
 	if err != nil {
 		return err
 	}
-	err = handler2.HandleRefundWebhookEvent(ctx, refundDetail)
+	err = store.RefundUpdated(ctx, refundDetail)
 	if err != nil {
 		return err
 	}
@@ -35,6 +35,7 @@ var GatewayWebhookNameMapping = map[string]_interface.GatewayWebhookInterface{
 	"mulenpay":        &MulenPayWebhook{},
 	"alikassa":        &AliKassaWebhook{},
 	"blockonomics":    &BlockonomicsWebhook{},
+	"remote_adapter":  &RemoteAdapterWebhook{},
 }
 
 type GatewayWebhookProxy struct {
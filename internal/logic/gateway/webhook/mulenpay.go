@@ -19,7 +19,10 @@ import (
 	"github.com/gogf/gf/v2/net/ghttp"
 )
 
-type MulenPayWebhook struct{}
+// MulenPayWebhook handles the MulenPay notifications, Store replaces the database store when set
+type MulenPayWebhook struct {
+	Store Store
+}
 
 func (m MulenPayWebhook) GatewayCheckAndSetupWebhook(ctx context.Context, gateway *entity.MerchantGateway) (err error) {
 	// MulenPay webhook setup is usually done on the frontend, here we only need to validate the configuration
@@ -125,14 +128,15 @@ func (m MulenPayWebhook) handlePaymentFinished(ctx context.Context, webhookData
 	g.Log().Infof(ctx, "MulenPay payment finished: payment_id=%s, status=%s", paymentId, status)
 
 	// Find payment record
-	payment := query.GetPaymentByGatewayPaymentId(ctx, paymentId)
+	store := storeOrDefault(m.Store)
+	payment := store.PaymentByGatewayPaymentId(ctx, 0, paymentId)
 	if payment == nil {
 		g.Log().Errorf(ctx, "MulenPay webhook: payment not found: %s", paymentId)
 		return
 	}
 
 	// Use existing webhook processing mechanism
-	err := ProcessPaymentWebhook(ctx, payment.PaymentId, paymentId, gateway)
+	err := processPaymentWebhook(ctx, store, payment.PaymentId, paymentId, gateway)
 	if err != nil {
 		g.Log().Errorf(ctx, "MulenPay webhook: failed to process payment: %v", err)
 		return
@@ -146,14 +150,15 @@ func (m MulenPayWebhook) handleRefundFinished(ctx context.Context, webhookData *
 	g.Log().Infof(ctx, "MulenPay refund finished: refund_id=%s, status=%s", refundId, status)
 
 	// Find refund record
-	refund := query.GetRefundByGatewayRefundId(ctx, refundId)
+	store := storeOrDefault(m.Store)
+	refund := store.RefundByGatewayRefundId(ctx, refundId)
 	if refund == nil {
 		g.Log().Errorf(ctx, "MulenPay webhook: refund not found: %s", refundId)
 		return
 	}
 
 	// Use existing webhook processing mechanism
-	err := ProcessRefundWebhook(ctx, "refund.finished", refundId, gateway)
+	err := processRefundWebhook(ctx, store, "refund.finished", refundId, gateway)
 	if err != nil {
 		g.Log().Errorf(ctx, "MulenPay webhook: failed to process refund: %v", err)
 		return
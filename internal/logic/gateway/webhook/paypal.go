@@ -289,7 +289,7 @@ func (p PaypalWebhook) GatewayWebhook(r *ghttp.Request, gateway *entity.Merchant
 				r.Response.WriteHeader(http.StatusBadRequest)
 				responseBack = http.StatusBadRequest
 			} else {
-				g.Log().Infof(r.Context(), "Webhook Gateway:%s-%d, Subscription updated for %d.", gateway.GatewayName, gateway.Id, resource.Get("id").String())
+				g.Log().Infof(r.Context(), "Webhook Gateway:%s-%d, Subscription updated for %s.", gateway.GatewayName, gateway.Id, resource.Get("id").String())
 				// Then define and call a func to handle the successful attachment of a PaymentMethod.
 				gatewayPaymentId := resource.Get("id").String()
 				payment := query.GetPaymentByGatewayPaymentId(r.Context(), gatewayPaymentId)
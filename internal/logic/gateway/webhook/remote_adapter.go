@@ -0,0 +1,151 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"unibee/internal/consts"
+	_gateway "unibee/internal/logic/gateway"
+	"unibee/internal/logic/gateway/adapter"
+	"unibee/internal/logic/gateway/gateway_bean"
+	"unibee/internal/logic/gateway/util"
+	entity "unibee/internal/model/entity/default"
+	"unibee/internal/query"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+// RemoteAdapterWebhook handles the adapter events, Store replaces the database store when set
+type RemoteAdapterWebhook struct {
+	Store Store
+}
+
+func (a RemoteAdapterWebhook) GatewayCheckAndSetupWebhook(ctx context.Context, gateway *entity.MerchantGateway) (err error) {
+	_, err = adapter.Call(ctx, nil, gateway.GatewayKey, gateway.GatewaySecret, gateway.Id, adapter.ActionWebhookSetup, &adapter.WebhookSetupData{
+		WebhookUrl: _gateway.GetPaymentWebhookEntranceUrl(gateway.Id),
+	}, nil)
+	if err != nil {
+		return err
+	}
+	// webhook events from the adapter are signed with the same secret as outgoing calls
+	return query.UpdateGatewayWebhookSecret(ctx, gateway.Id, gateway.GatewaySecret)
+}
+
+func (a RemoteAdapterWebhook) GatewayWebhook(r *ghttp.Request, gateway *entity.MerchantGateway) {
+	ctx := r.Context()
+	body, err := io.ReadAll(r.Body)
+	if err != nil || len(body) == 0 {
+		g.Log().Errorf(ctx, "Webhook Gateway:%s, read body failed", gateway.GatewayName)
+		r.Response.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	err = adapter.Verify(gateway.GatewaySecret, r.Header.Get(adapter.HeaderTimestamp), r.Header.Get(adapter.HeaderSignature), body, gtime.Now().Timestamp())
+	if err != nil {
+		g.Log().Errorf(ctx, "Webhook Gateway:%s, verify signature failed:%s", gateway.GatewayName, err.Error())
+		r.Response.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	var event *adapter.WebhookEvent
+	if err = json.Unmarshal(body, &event); err != nil || event == nil {
+		g.Log().Errorf(ctx, "Webhook Gateway:%s, invalid event:%s", gateway.GatewayName, string(body))
+		r.Response.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	g.Log().Info(ctx, "Receive_Webhook_Channel:", gateway.GatewayName, " hook:", string(body))
+	store := storeOrDefault(a.Store)
+	switch event.Event {
+	case adapter.EventPaymentUpdated:
+		var payment *entity.Payment
+		if len(event.PaymentId) > 0 {
+			payment = store.Payment(ctx, event.PaymentId)
+		} else {
+			payment = store.PaymentByGatewayPaymentId(ctx, gateway.Id, event.GatewayPaymentId)
+		}
+		if payment == nil {
+			err = gerror.Newf("payment not found, paymentId:%s gatewayPaymentId:%s", event.PaymentId, event.GatewayPaymentId)
+			break
+		}
+		if err = checkRemoteAdapterPayment(gateway, payment, event.GatewayPaymentId); err != nil {
+			break
+		}
+		err = processPaymentWebhook(ctx, store, payment.PaymentId, event.GatewayPaymentId, gateway)
+	case adapter.EventRefundUpdated:
+		refund := store.RefundByGatewayRefundId(ctx, event.GatewayRefundId)
+		if refund == nil {
+			err = gerror.Newf("refund not found, gatewayRefundId:%s", event.GatewayRefundId)
+			break
+		}
+		if err = checkGatewayOwner(gateway, refund.GatewayId, refund.MerchantId); err != nil {
+			break
+		}
+		err = processRefundWebhook(ctx, store, event.Event, event.GatewayRefundId, gateway)
+	default:
+		g.Log().Infof(ctx, "Webhook Gateway:%s, unhandled event:%s", gateway.GatewayName, event.Event)
+	}
+	if err != nil {
+		g.Log().Errorf(ctx, "Webhook Gateway:%s, process event:%s error:%s", gateway.GatewayName, event.Event, err.Error())
+		r.Response.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	r.Response.WriteJson(g.Map{"success": true})
+}
+
+// checkGatewayOwner rejects the webhook of the gateway for the records of another gateway or merchant
+func checkGatewayOwner(gateway *entity.MerchantGateway, gatewayId uint64, merchantId uint64) error {
+	if gateway == nil || gatewayId != gateway.Id || merchantId != gateway.MerchantId {
+		return gerror.New("record not belong to the gateway")
+	}
+	return nil
+}
+
+func checkRemoteAdapterPayment(gateway *entity.MerchantGateway, payment *entity.Payment, gatewayPaymentId string) error {
+	if err := checkGatewayOwner(gateway, payment.GatewayId, payment.MerchantId); err != nil {
+		return err
+	}
+	if len(payment.GatewayPaymentId) > 0 && len(gatewayPaymentId) > 0 && payment.GatewayPaymentId != gatewayPaymentId {
+		return gerror.Newf("gatewayPaymentId not match the payment:%s", payment.PaymentId)
+	}
+	return nil
+}
+
+func (a RemoteAdapterWebhook) GatewayRedirect(r *ghttp.Request, gateway *entity.MerchantGateway) (res *gateway_bean.GatewayRedirectResp, err error) {
+	ctx := r.Context()
+	paymentId := r.Get("paymentId").String()
+	payment := query.GetPaymentByPaymentId(ctx, paymentId)
+	if payment == nil {
+		return nil, gerror.Newf("payment not found: %s", paymentId)
+	}
+	if err = checkGatewayOwner(gateway, payment.GatewayId, payment.MerchantId); err != nil {
+		return nil, err
+	}
+	if len(payment.GatewayPaymentId) > 0 && payment.Status == consts.PaymentCreated {
+		err = ProcessPaymentWebhook(ctx, payment.PaymentId, payment.GatewayPaymentId, gateway)
+		if err != nil {
+			g.Log().Errorf(ctx, "GatewayRedirect Gateway:%s, sync payment:%s error:%s", gateway.GatewayName, payment.PaymentId, err.Error())
+		}
+		payment = query.GetPaymentByPaymentId(ctx, paymentId)
+	}
+	success := payment.Status == consts.PaymentSuccess
+	var status = "false"
+	if success {
+		status = "true"
+	}
+	return &gateway_bean.GatewayRedirectResp{
+		Payment:   payment,
+		Status:    success,
+		Success:   success,
+		Message:   fmt.Sprintf("Payment %s", consts.PaymentStatusEnum(payment.Status).Description()),
+		ReturnUrl: util.GetPaymentRedirectUrl(ctx, payment, status),
+		QueryPath: r.URL.RawQuery,
+	}, nil
+}
+
+func (a RemoteAdapterWebhook) GatewayNewPaymentMethodRedirect(r *ghttp.Request, gateway *entity.MerchantGateway) (err error) {
+	return nil
+}
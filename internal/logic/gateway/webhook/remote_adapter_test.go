@@ -0,0 +1,26 @@
+package webhook
+
+import (
+	"testing"
+
+	entity "unibee/internal/model/entity/default"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckRemoteAdapterPayment(t *testing.T) {
+	gateway := &entity.MerchantGateway{Id: 10, MerchantId: 1}
+	t.Run("Same Gateway", func(t *testing.T) {
+		require.Nil(t, checkRemoteAdapterPayment(gateway, &entity.Payment{PaymentId: "pay1", GatewayId: 10, MerchantId: 1, GatewayPaymentId: "gp1"}, "gp1"))
+		require.Nil(t, checkRemoteAdapterPayment(gateway, &entity.Payment{PaymentId: "pay1", GatewayId: 10, MerchantId: 1}, "gp1"))
+	})
+	t.Run("Other Gateway", func(t *testing.T) {
+		require.NotNil(t, checkRemoteAdapterPayment(gateway, &entity.Payment{PaymentId: "pay1", GatewayId: 11, MerchantId: 1, GatewayPaymentId: "gp1"}, "gp1"))
+	})
+	t.Run("Other Merchant", func(t *testing.T) {
+		require.NotNil(t, checkRemoteAdapterPayment(gateway, &entity.Payment{PaymentId: "pay1", GatewayId: 10, MerchantId: 2, GatewayPaymentId: "gp1"}, "gp1"))
+	})
+	t.Run("GatewayPaymentId Mismatch", func(t *testing.T) {
+		require.NotNil(t, checkRemoteAdapterPayment(gateway, &entity.Payment{PaymentId: "pay1", GatewayId: 10, MerchantId: 1, GatewayPaymentId: "gp1"}, "gp2"))
+	})
+}
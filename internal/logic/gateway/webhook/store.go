@@ -0,0 +1,60 @@
+package webhook
+
+import (
+	"context"
+
+	_interface "unibee/internal/interface"
+	"unibee/internal/logic/gateway/api"
+	"unibee/internal/logic/gateway/gateway_bean"
+	handler2 "unibee/internal/logic/payment/handler"
+	entity "unibee/internal/model/entity/default"
+	"unibee/internal/query"
+)
+
+// Store is the gateway provider, the record lookups and the payment handlers used by the webhook processing,
+// the webhooks use the database store unless another one is injected, the conformance suite injects its own
+type Store interface {
+	Gateway(ctx context.Context, gateway *entity.MerchantGateway) _interface.GatewayInterface
+	Payment(ctx context.Context, paymentId string) *entity.Payment
+	// PaymentByGatewayPaymentId finds the payment by the gatewayPaymentId, scoped to the gateway when gatewayId specified
+	PaymentByGatewayPaymentId(ctx context.Context, gatewayId uint64, gatewayPaymentId string) *entity.Payment
+	RefundByGatewayRefundId(ctx context.Context, gatewayRefundId string) *entity.Refund
+	PaymentUpdated(ctx context.Context, paymentId string, ro *gateway_bean.GatewayPaymentRo) error
+	RefundUpdated(ctx context.Context, ro *gateway_bean.GatewayPaymentRefundResp) error
+}
+
+type dbStore struct{}
+
+func (s dbStore) Gateway(ctx context.Context, gateway *entity.MerchantGateway) _interface.GatewayInterface {
+	return api.GetGatewayServiceProvider(ctx, gateway.Id)
+}
+
+func (s dbStore) Payment(ctx context.Context, paymentId string) *entity.Payment {
+	return query.GetPaymentByPaymentId(ctx, paymentId)
+}
+
+func (s dbStore) PaymentByGatewayPaymentId(ctx context.Context, gatewayId uint64, gatewayPaymentId string) *entity.Payment {
+	if gatewayId > 0 {
+		return query.GetPaymentByGatewayIdAndGatewayPaymentId(ctx, gatewayId, gatewayPaymentId)
+	}
+	return query.GetPaymentByGatewayPaymentId(ctx, gatewayPaymentId)
+}
+
+func (s dbStore) RefundByGatewayRefundId(ctx context.Context, gatewayRefundId string) *entity.Refund {
+	return query.GetRefundByGatewayRefundId(ctx, gatewayRefundId)
+}
+
+func (s dbStore) PaymentUpdated(ctx context.Context, paymentId string, ro *gateway_bean.GatewayPaymentRo) error {
+	return handler2.HandlePaymentWebhookEvent(ctx, paymentId, ro)
+}
+
+func (s dbStore) RefundUpdated(ctx context.Context, ro *gateway_bean.GatewayPaymentRefundResp) error {
+	return handler2.HandleRefundWebhookEvent(ctx, ro)
+}
+
+func storeOrDefault(store Store) Store {
+	if store == nil {
+		return dbStore{}
+	}
+	return store
+}
@@ -0,0 +1,146 @@
+package credit_note
+
+import (
+	"math"
+
+	"unibee/api/bean"
+	entity "unibee/internal/model/entity/default"
+	"unibee/utility"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+type CreditLineReq struct {
+	LineIndex int   `json:"lineIndex" dc:"Index of the credited line in the invoice lines, start 0"`
+	Quantity  int64 `json:"quantity"  dc:"Quantity to credit, the amount is prorated from the line, either quantity or amount"`
+	Amount    int64 `json:"amount"    dc:"Amount excluding tax to credit, cent, either quantity or amount"`
+}
+
+// credited is the part of one invoice line already credited by issued credit notes
+type credited struct {
+	quantity int64
+	net      int64
+	tax      int64
+}
+
+func creditedLines(notes []*entity.CreditNote) map[int]*credited {
+	result := make(map[int]*credited)
+	for _, note := range notes {
+		var items []*bean.CreditNoteItem
+		if err := utility.UnmarshalFromJsonString(note.Lines, &items); err != nil {
+			continue
+		}
+		for _, item := range items {
+			one, ok := result[item.LineIndex]
+			if !ok {
+				one = &credited{}
+				result[item.LineIndex] = one
+			}
+			one.quantity = one.quantity + item.CreditQuantity
+			one.net = one.net + item.AmountExcludingTax
+			one.tax = one.tax + item.Tax
+		}
+	}
+	return result
+}
+
+// ComputeCreditItems computes the credited items of the invoice lines, the tax is recomputed per line with the
+// line tax percentage and the last credit of a line takes the remaining tax so that the line never over credits
+func ComputeCreditItems(lines []*bean.InvoiceItemSimplify, taxPercentage int64, creditedMap map[int]*credited, reqs []*CreditLineReq) ([]*bean.CreditNoteItem, error) {
+	if len(reqs) == 0 {
+		return nil, gerror.New("no line to credit")
+	}
+	if creditedMap == nil {
+		creditedMap = make(map[int]*credited)
+	}
+	var items = make([]*bean.CreditNoteItem, 0)
+	var seen = make(map[int]bool)
+	for _, req := range reqs {
+		if req == nil {
+			continue
+		}
+		if req.LineIndex < 0 || req.LineIndex >= len(lines) {
+			return nil, gerror.Newf("invalid lineIndex:%d", req.LineIndex)
+		}
+		if seen[req.LineIndex] {
+			return nil, gerror.Newf("duplicate lineIndex:%d", req.LineIndex)
+		}
+		seen[req.LineIndex] = true
+		if req.Quantity < 0 || req.Amount < 0 || (req.Quantity == 0) == (req.Amount == 0) {
+			return nil, gerror.Newf("line %d should credit either a positive quantity or a positive amount", req.LineIndex)
+		}
+		line := lines[req.LineIndex]
+		done, ok := creditedMap[req.LineIndex]
+		if !ok {
+			done = &credited{}
+		}
+		lineNet := line.Amount - line.Tax
+		remainingNet := lineNet - done.net
+		if remainingNet <= 0 {
+			return nil, gerror.Newf("line %d is fully credited", req.LineIndex)
+		}
+		percentage := line.TaxPercentage
+		if percentage == 0 && line.Tax != 0 {
+			percentage = taxPercentage
+		}
+		item := &bean.CreditNoteItem{
+			LineIndex: req.LineIndex,
+			InvoiceItemSimplify: bean.InvoiceItemSimplify{
+				Currency:      line.Currency,
+				TaxPercentage: percentage,
+				Name:          line.Name,
+				Description:   line.Description,
+				PeriodStart:   line.PeriodStart,
+				PeriodEnd:     line.PeriodEnd,
+				Plan:          line.Plan,
+			},
+		}
+		var net int64
+		if req.Quantity > 0 {
+			if line.Quantity <= 0 {
+				return nil, gerror.Newf("line %d has no quantity, credit by amount", req.LineIndex)
+			}
+			remainingQuantity := line.Quantity - done.quantity
+			if req.Quantity > remainingQuantity {
+				return nil, gerror.Newf("line %d quantity should not greater than %d", req.LineIndex, remainingQuantity)
+			}
+			net = int64(math.Round(float64(lineNet) * float64(req.Quantity) / float64(line.Quantity)))
+			if req.Quantity == remainingQuantity || net > remainingNet {
+				net = remainingNet
+			}
+			item.CreditQuantity = req.Quantity
+			item.Quantity = req.Quantity
+			item.UnitAmountExcludingTax = line.UnitAmountExcludingTax
+			item.DiscountAmount = line.UnitAmountExcludingTax*req.Quantity - net
+		} else {
+			if req.Amount > remainingNet {
+				return nil, gerror.Newf("line %d amount should not greater than %d", req.LineIndex, remainingNet)
+			}
+			net = req.Amount
+			item.Quantity = 1
+			item.UnitAmountExcludingTax = net
+		}
+		tax := int64(math.Round(float64(net) * utility.ConvertTaxPercentageToInternalFloat(percentage)))
+		if net == remainingNet || tax > line.Tax-done.tax {
+			tax = line.Tax - done.tax
+		}
+		item.AmountExcludingTax = net
+		item.Tax = tax
+		item.Amount = net + tax
+		item.OriginAmount = item.Amount + item.DiscountAmount
+		items = append(items, item)
+	}
+	if len(items) == 0 {
+		return nil, gerror.New("no line to credit")
+	}
+	return items, nil
+}
+
+// SumCreditItems returns the total amount excluding tax, tax amount and total amount of the items
+func SumCreditItems(items []*bean.CreditNoteItem) (totalAmountExcludingTax int64, taxAmount int64, totalAmount int64) {
+	for _, item := range items {
+		totalAmountExcludingTax = totalAmountExcludingTax + item.AmountExcludingTax
+		taxAmount = taxAmount + item.Tax
+	}
+	return totalAmountExcludingTax, taxAmount, totalAmountExcludingTax + taxAmount
+}
@@ -0,0 +1,84 @@
+package credit_note
+
+import (
+	"testing"
+
+	"unibee/api/bean"
+	entity "unibee/internal/model/entity/default"
+	"unibee/utility"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testLines() []*bean.InvoiceItemSimplify {
+	return []*bean.InvoiceItemSimplify{
+		{
+			Currency:               "EUR",
+			Amount:                 11900,
+			Tax:                    1900,
+			AmountExcludingTax:     10000,
+			UnitAmountExcludingTax: 1000,
+			Quantity:               10,
+			TaxPercentage:          1900,
+		},
+		{
+			Currency:               "EUR",
+			Amount:                 1070,
+			Tax:                    70,
+			AmountExcludingTax:     1000,
+			UnitAmountExcludingTax: 1000,
+			Quantity:               1,
+			TaxPercentage:          700,
+		},
+	}
+}
+
+func TestComputeCreditItems(t *testing.T) {
+	t.Run("quantity and amount per line", func(t *testing.T) {
+		items, err := ComputeCreditItems(testLines(), 1900, nil, []*CreditLineReq{
+			{LineIndex: 0, Quantity: 3},
+			{LineIndex: 1, Amount: 500},
+		})
+		require.Nil(t, err)
+		require.Equal(t, 2, len(items))
+		require.Equal(t, int64(3000), items[0].AmountExcludingTax)
+		require.Equal(t, int64(570), items[0].Tax)
+		require.Equal(t, int64(3570), items[0].Amount)
+		require.Equal(t, int64(3), items[0].CreditQuantity)
+		require.Equal(t, items[0].AmountExcludingTax, items[0].UnitAmountExcludingTax*items[0].Quantity-items[0].DiscountAmount)
+		require.Equal(t, int64(500), items[1].AmountExcludingTax)
+		require.Equal(t, int64(35), items[1].Tax)
+		require.Equal(t, int64(0), items[1].CreditQuantity)
+		net, tax, total := SumCreditItems(items)
+		require.Equal(t, int64(3500), net)
+		require.Equal(t, int64(605), tax)
+		require.Equal(t, int64(4105), total)
+	})
+	t.Run("remaining credit takes the rounding", func(t *testing.T) {
+		lines := []*bean.InvoiceItemSimplify{{Currency: "EUR", Amount: 1199, Tax: 199, AmountExcludingTax: 1000, UnitAmountExcludingTax: 333, Quantity: 3, DiscountAmount: -1, TaxPercentage: 1990}}
+		first, err := ComputeCreditItems(lines, 0, nil, []*CreditLineReq{{LineIndex: 0, Quantity: 1}})
+		require.Nil(t, err)
+		notes := []*entity.CreditNote{{Lines: utility.MarshalToJsonString(first)}}
+		second, err := ComputeCreditItems(lines, 0, creditedLines(notes), []*CreditLineReq{{LineIndex: 0, Quantity: 2}})
+		require.Nil(t, err)
+		require.Equal(t, int64(1000), first[0].AmountExcludingTax+second[0].AmountExcludingTax)
+		require.Equal(t, int64(199), first[0].Tax+second[0].Tax)
+		notes = append(notes, &entity.CreditNote{Lines: utility.MarshalToJsonString(second)})
+		_, err = ComputeCreditItems(lines, 0, creditedLines(notes), []*CreditLineReq{{LineIndex: 0, Amount: 1}})
+		require.NotNil(t, err)
+	})
+	t.Run("invalid requests", func(t *testing.T) {
+		_, err := ComputeCreditItems(testLines(), 1900, nil, nil)
+		require.NotNil(t, err)
+		_, err = ComputeCreditItems(testLines(), 1900, nil, []*CreditLineReq{{LineIndex: 2, Quantity: 1}})
+		require.NotNil(t, err)
+		_, err = ComputeCreditItems(testLines(), 1900, nil, []*CreditLineReq{{LineIndex: 0, Quantity: 1, Amount: 100}})
+		require.NotNil(t, err)
+		_, err = ComputeCreditItems(testLines(), 1900, nil, []*CreditLineReq{{LineIndex: 0, Quantity: 11}})
+		require.NotNil(t, err)
+		_, err = ComputeCreditItems(testLines(), 1900, nil, []*CreditLineReq{{LineIndex: 1, Amount: 1001}})
+		require.NotNil(t, err)
+		_, err = ComputeCreditItems(testLines(), 1900, nil, []*CreditLineReq{{LineIndex: 0, Quantity: 1}, {LineIndex: 0, Quantity: 1}})
+		require.NotNil(t, err)
+	})
+}
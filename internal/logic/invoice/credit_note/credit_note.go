@@ -0,0 +1,326 @@
+package credit_note
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"unibee/api/bean"
+	"unibee/internal/consts"
+	creditNoteWebhook "unibee/internal/consumer/webhook/credit_note"
+	"unibee/internal/consumer/webhook/event"
+	dao "unibee/internal/dao/default"
+	"unibee/internal/logic/credit/account"
+	"unibee/internal/logic/credit/config"
+	"unibee/internal/logic/invoice/invoice_number"
+	"unibee/internal/logic/payment/service"
+	entity "unibee/internal/model/entity/default"
+	"unibee/internal/query"
+	"unibee/utility"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+type CreateInternalReq struct {
+	MerchantId uint64           `json:"merchantId" dc:"MerchantId" v:"required"`
+	InvoiceId  string           `json:"invoiceId" dc:"The paid invoice to credit" v:"required"`
+	Type       int              `json:"type" dc:"1-refund to the original payment method, 2-credit to customer balance, default 1"`
+	Lines      []*CreditLineReq `json:"lines" dc:"The credited lines" v:"required"`
+	Reason     string           `json:"reason" dc:"Reason"`
+}
+
+type PreviewInternalRes struct {
+	Invoice                 *bean.Invoice          `json:"invoice" dc:"The credited invoice"`
+	Lines                   []*bean.CreditNoteItem `json:"lines" dc:"The credited lines"`
+	TotalAmount             int64                  `json:"totalAmount" dc:"Total amount including tax, cent"`
+	TotalAmountExcludingTax int64                  `json:"totalAmountExcludingTax" dc:"Total amount excluding tax, cent"`
+	TaxAmount               int64                  `json:"taxAmount" dc:"Tax amount, cent"`
+	CreditableAmount        int64                  `json:"creditableAmount" dc:"Amount of the invoice still creditable before this credit note, cent"`
+}
+
+// PreviewCreditNote computes the credit note of the request without issuing it
+func PreviewCreditNote(ctx context.Context, req *CreateInternalReq) (*PreviewInternalRes, error) {
+	invoice, res, err := compute(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	res.Invoice = bean.SimplifyInvoice(invoice)
+	return res, nil
+}
+
+func compute(ctx context.Context, req *CreateInternalReq) (*entity.Invoice, *PreviewInternalRes, error) {
+	utility.Assert(req != nil, "invalid request")
+	utility.Assert(req.MerchantId > 0, "invalid merchantId")
+	if req.Type == 0 {
+		req.Type = consts.CreditNoteTypeRefund
+	}
+	utility.Assert(req.Type == consts.CreditNoteTypeRefund || req.Type == consts.CreditNoteTypeBalance, "type should be 1 or 2")
+	invoice := query.GetInvoiceByInvoiceId(ctx, req.InvoiceId)
+	utility.Assert(invoice != nil, "invoice not found")
+	utility.Assert(invoice.MerchantId == req.MerchantId, "wrong merchant account")
+	utility.Assert(len(invoice.RefundId) == 0 && invoice.TotalAmount > 0, "refund invoice can not be credited")
+	utility.Assert(invoice.Status == consts.InvoiceStatusPaid, "only paid invoice can be credited")
+	if req.Type == consts.CreditNoteTypeRefund {
+		utility.Assert(len(invoice.PaymentId) > 0, "invoice has no payment to refund, credit to customer balance instead")
+	}
+	var lines []*bean.InvoiceItemSimplify
+	err := utility.UnmarshalFromJsonString(invoice.Lines, &lines)
+	if err != nil {
+		return nil, nil, gerror.Newf("invalid invoice lines:%s", err.Error())
+	}
+	issuedNotes := query.GetIssuedCreditNotesByInvoiceId(ctx, invoice.InvoiceId)
+	items, err := ComputeCreditItems(lines, invoice.TaxPercentage, creditedLines(issuedNotes), req.Lines)
+	if err != nil {
+		return nil, nil, err
+	}
+	totalAmountExcludingTax, taxAmount, totalAmount := SumCreditItems(items)
+	creditable := creditableAmount(ctx, invoice, issuedNotes)
+	if totalAmount > creditable {
+		return nil, nil, gerror.Newf("credit amount %s exceeds the creditable amount %s of the invoice", utility.ConvertCentToDollarStr(totalAmount, invoice.Currency), utility.ConvertCentToDollarStr(creditable, invoice.Currency))
+	}
+	return invoice, &PreviewInternalRes{
+		Lines:                   items,
+		TotalAmount:             totalAmount,
+		TotalAmountExcludingTax: totalAmountExcludingTax,
+		TaxAmount:               taxAmount,
+		CreditableAmount:        creditable,
+	}, nil
+}
+
+// creditableAmount is the invoice total less what has been refunded from its payment and credited to balance,
+// refunds made outside credit notes are covered by the payment refund amount
+func creditableAmount(ctx context.Context, invoice *entity.Invoice, issuedNotes []*entity.CreditNote) int64 {
+	var creditable = invoice.TotalAmount
+	var refundedByNotes int64 = 0
+	for _, note := range issuedNotes {
+		if note.Type == consts.CreditNoteTypeBalance {
+			creditable = creditable - note.TotalAmount
+		} else {
+			refundedByNotes = refundedByNotes + note.TotalAmount
+		}
+	}
+	payment := query.GetPaymentByPaymentId(ctx, invoice.PaymentId)
+	if payment != nil {
+		creditable = creditable - utility.MaxInt64(payment.RefundAmount, refundedByNotes)
+	} else {
+		creditable = creditable - refundedByNotes
+	}
+	return utility.MaxInt64(creditable, 0)
+}
+
+// CreateCreditNote issues a credit note against the lines of a paid invoice, the note is either refunded to the original
+// payment method or credited to the customer balance and takes the next number of the merchant credit note series
+// once the refund is accepted or the balance is credited
+func CreateCreditNote(ctx context.Context, req *CreateInternalReq) (*entity.CreditNote, error) {
+	lockKey := fmt.Sprintf("credit_note_invoice_lock_%s", req.InvoiceId)
+	if !utility.TryLock(ctx, lockKey, 30) {
+		return nil, gerror.New("Submit Too Fast")
+	}
+	defer func() {
+		utility.ReleaseLock(ctx, lockKey)
+	}()
+	invoice, res, err := compute(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	var creditAccount *entity.CreditAccount
+	var creditConfig *entity.CreditConfig
+	if req.Type == consts.CreditNoteTypeBalance {
+		err = config.CheckCreditConfig(ctx, invoice.MerchantId, consts.CreditAccountTypeMain, invoice.Currency)
+		if err != nil {
+			return nil, err
+		}
+		creditConfig = query.GetCreditConfig(ctx, invoice.MerchantId, consts.CreditAccountTypeMain, invoice.Currency)
+		creditAccount = account.QueryOrCreateCreditAccount(ctx, invoice.UserId, invoice.Currency, consts.CreditAccountTypeMain)
+		utility.Assert(creditAccount != nil, "Credit account create failed")
+	}
+	one := &entity.CreditNote{
+		MerchantId:              invoice.MerchantId,
+		UserId:                  invoice.UserId,
+		CreditNoteId:            utility.CreateCreditNoteId(),
+		InvoiceId:               invoice.InvoiceId,
+		PaymentId:               invoice.PaymentId,
+		SubscriptionId:          invoice.SubscriptionId,
+		Type:                    req.Type,
+		Status:                  consts.CreditNoteStatusIssued,
+		Currency:                invoice.Currency,
+		TotalAmount:             res.TotalAmount,
+		TotalAmountExcludingTax: res.TotalAmountExcludingTax,
+		TaxAmount:               res.TaxAmount,
+		Lines:                   utility.MarshalToJsonString(res.Lines),
+		Reason:                  req.Reason,
+		LinkToken:               utility.CreateInvoiceSt(),
+		CreateTime:              gtime.Now().Timestamp(),
+	}
+	if one.Type == consts.CreditNoteTypeRefund {
+		// the refund note is numbered once the refund is accepted, a failed refund leaves no number behind
+		err = insertCreditNote(ctx, one)
+		if err != nil {
+			g.Log().Errorf(ctx, "CreateCreditNote invoiceId:%s error:%s", req.InvoiceId, err.Error())
+			return nil, err
+		}
+		var refund *entity.Refund
+		utility.Try(func() {
+			refund, err = service.GatewayPaymentRefundCreate(ctx, &service.NewPaymentRefundInternalReq{
+				PaymentId:        one.PaymentId,
+				ExternalRefundId: one.CreditNoteId,
+				RefundAmount:     one.TotalAmount,
+				Currency:         one.Currency,
+				Reason:           one.Reason,
+				Metadata:         map[string]interface{}{"CreditNoteId": one.CreditNoteId},
+				CreditNote:       one,
+			})
+		}, func(exception interface{}) {
+			err = gerror.Newf("%v", exception)
+		})
+		if err == nil && refund == nil {
+			err = gerror.New("refund not created")
+		}
+		if err == nil && refund.Status == consts.RefundFailed {
+			err = gerror.New("refund failed")
+		}
+		if err != nil {
+			g.Log().Errorf(ctx, "CreateCreditNote creditNoteId:%s refund error:%s", one.CreditNoteId, err.Error())
+			one.Status = consts.CreditNoteStatusFailed
+			_, _ = dao.CreditNote.Ctx(ctx).Data(g.Map{
+				dao.CreditNote.Columns().Status:    consts.CreditNoteStatusFailed,
+				dao.CreditNote.Columns().GmtModify: gtime.Now(),
+			}).Where(dao.CreditNote.Columns().Id, one.Id).Update()
+			return one, err
+		}
+		one.RefundId = refund.RefundId
+		_, err = invoice_number.Allocate(ctx, invoice.MerchantId, invoice_number.SeriesCreditNote, func(ctx context.Context, number string) error {
+			_, err := dao.CreditNote.Ctx(ctx).Data(g.Map{
+				dao.CreditNote.Columns().CreditNoteNumber: number,
+				dao.CreditNote.Columns().RefundId:         refund.RefundId,
+				dao.CreditNote.Columns().GmtModify:        gtime.Now(),
+			}).Where(dao.CreditNote.Columns().Id, one.Id).Update()
+			if err != nil {
+				return err
+			}
+			// the refund invoice carries the credit note number
+			_, err = dao.Invoice.Ctx(ctx).Data(g.Map{
+				dao.Invoice.Columns().InvoiceNumber: number,
+				dao.Invoice.Columns().GmtModify:     gtime.Now(),
+			}).Where(dao.Invoice.Columns().RefundId, refund.RefundId).Update()
+			if err != nil {
+				return err
+			}
+			one.CreditNoteNumber = number
+			return nil
+		})
+		if err != nil {
+			g.Log().Errorf(ctx, "CreateCreditNote creditNoteId:%s number error:%s", one.CreditNoteId, err.Error())
+		}
+	} else {
+		_, err = invoice_number.Allocate(ctx, invoice.MerchantId, invoice_number.SeriesCreditNote, func(ctx context.Context, number string) error {
+			one.CreditNoteNumber = number
+			creditAccount = query.GetCreditAccountById(ctx, creditAccount.Id)
+			if creditAccount == nil {
+				return gerror.New("credit account not found")
+			}
+			one.CreditTransactionId = utility.CreateEventId()
+			trans := &entity.CreditTransaction{
+				UserId:             invoice.UserId,
+				CreditId:           creditAccount.Id,
+				Currency:           strings.ToUpper(invoice.Currency),
+				TransactionId:      one.CreditTransactionId,
+				TransactionType:    consts.CreditTransactionCreditNoteIncome,
+				CreditAmountAfter:  creditAccount.Amount + one.TotalAmount,
+				CreditAmountBefore: creditAccount.Amount,
+				DeltaAmount:        one.TotalAmount,
+				BizId:              one.CreditNoteId,
+				Name:               fmt.Sprintf("Credit Note %s", number),
+				Description:        req.Reason,
+				CreateTime:         gtime.Now().Timestamp(),
+				MerchantId:         invoice.MerchantId,
+				AccountType:        creditAccount.Type,
+				ExchangeRate:       creditConfig.ExchangeRate,
+			}
+			_, err := dao.CreditTransaction.Ctx(ctx).Data(trans).OmitNil().Insert(trans)
+			if err != nil {
+				return err
+			}
+			update, err := dao.CreditAccount.Ctx(ctx).Where(dao.CreditAccount.Columns().Id, creditAccount.Id).Increment(dao.CreditAccount.Columns().Amount, one.TotalAmount)
+			if err != nil {
+				return err
+			}
+			affected, err := update.RowsAffected()
+			if err != nil {
+				return err
+			}
+			if affected != 1 {
+				return gerror.New("update credit amount err")
+			}
+			return insertCreditNote(ctx, one)
+		})
+		if err != nil {
+			g.Log().Errorf(ctx, "CreateCreditNote invoiceId:%s error:%s", req.InvoiceId, err.Error())
+			return nil, err
+		}
+	}
+	creditNoteWebhook.SendMerchantCreditNoteWebhookBackground(one, event.UNIBEE_WEBHOOK_EVENT_CREDIT_NOTE_CREATED, map[string]interface{}{"CreateFrom": utility.ReflectCurrentFunctionName()})
+	return one, nil
+}
+
+func insertCreditNote(ctx context.Context, one *entity.CreditNote) error {
+	result, err := dao.CreditNote.Ctx(ctx).Data(one).OmitNil().Insert(one)
+	if err != nil {
+		return err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	one.Id = uint64(id)
+	return nil
+}
+
+type ListInternalReq struct {
+	MerchantId uint64 `json:"merchantId" dc:"MerchantId" v:"required"`
+	InvoiceId  string `json:"invoiceId" dc:"Filter by the credited invoice"`
+	UserId     uint64 `json:"userId" dc:"Filter by user"`
+	Type       int    `json:"type" dc:"Filter by type, 1-refund, 2-balance"`
+	Status     []int  `json:"status" dc:"Filter by status, 10-issued, 20-failed"`
+	Page       int    `json:"page"  dc:"Page, Start 0" `
+	Count      int    `json:"count"  dc:"Count By Page" `
+}
+
+func List(ctx context.Context, req *ListInternalReq) ([]*bean.CreditNote, int) {
+	utility.Assert(req.MerchantId > 0, "merchantId not found")
+	if req.Count <= 0 {
+		req.Count = 20
+	}
+	if req.Page < 0 {
+		req.Page = 0
+	}
+	q := dao.CreditNote.Ctx(ctx).Where(dao.CreditNote.Columns().MerchantId, req.MerchantId)
+	if len(req.InvoiceId) > 0 {
+		q = q.Where(dao.CreditNote.Columns().InvoiceId, req.InvoiceId)
+	}
+	if req.UserId > 0 {
+		q = q.Where(dao.CreditNote.Columns().UserId, req.UserId)
+	}
+	if req.Type > 0 {
+		q = q.Where(dao.CreditNote.Columns().Type, req.Type)
+	}
+	if len(req.Status) > 0 {
+		q = q.WhereIn(dao.CreditNote.Columns().Status, req.Status)
+	}
+	var mainList []*entity.CreditNote
+	var total = 0
+	err := q.OrderDesc(dao.CreditNote.Columns().Id).
+		Limit(req.Page*req.Count, req.Count).
+		ScanAndCount(&mainList, &total, true)
+	if err != nil {
+		g.Log().Errorf(ctx, "CreditNote List error:%s", err.Error())
+		return make([]*bean.CreditNote, 0), 0
+	}
+	var list = make([]*bean.CreditNote, 0)
+	for _, one := range mainList {
+		list = append(list, bean.SimplifyCreditNote(one))
+	}
+	return list, total
+}
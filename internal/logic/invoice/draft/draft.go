@@ -0,0 +1,149 @@
+package draft
+
+import (
+	"context"
+	"fmt"
+
+	"unibee/api/bean"
+	redismq2 "unibee/internal/cmd/redismq"
+	"unibee/internal/consts"
+	dao "unibee/internal/dao/default"
+	"unibee/internal/logic/invoice/handler"
+	"unibee/internal/logic/invoice/invoice_number"
+	"unibee/internal/logic/operation_log"
+	"unibee/internal/logic/tax"
+	entity "unibee/internal/model/entity/default"
+	"unibee/internal/query"
+	"unibee/utility"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+	redismq "github.com/jackyang-hk/go-redismq"
+)
+
+func getReviewDraft(ctx context.Context, merchantId uint64, invoiceId string) *entity.Invoice {
+	one := query.GetInvoiceByInvoiceId(ctx, invoiceId)
+	utility.Assert(one != nil, fmt.Sprintf("invoice not found:%s", invoiceId))
+	utility.Assert(one.MerchantId == merchantId, "wrong merchant account")
+	utility.Assert(IsReviewDraft(one), "invoice is not a draft in review")
+	return one
+}
+
+// EditDraftInvoice edits the lines of the draft invoice in review, adds the one-off credit and recomputes the totals
+func EditDraftInvoice(ctx context.Context, merchantId uint64, invoiceId string, req *EditReq) (*entity.Invoice, error) {
+	one := getReviewDraft(ctx, merchantId, invoiceId)
+	invoice := bean.SimplifyInvoice(one)
+	// the new lines are taxed as the cycle invoice, the invoice tax percentage may be an effective rate of the tax engine
+	var taxPercentage = one.TaxPercentage
+	if sub := query.GetSubscriptionBySubscriptionId(ctx, one.SubscriptionId); sub != nil {
+		taxPercentage = sub.TaxPercentage
+	}
+	err := ApplyEdit(invoice, req, taxPercentage, func(added *bean.Invoice) {
+		tax.ApplyInvoiceTax(ctx, one.MerchantId, one.UserId, added)
+	})
+	if err != nil {
+		return nil, err
+	}
+	result, err := dao.Invoice.Ctx(ctx).Data(g.Map{
+		dao.Invoice.Columns().TotalAmount:                    invoice.TotalAmount,
+		dao.Invoice.Columns().TotalAmountExcludingTax:        invoice.TotalAmountExcludingTax,
+		dao.Invoice.Columns().TaxAmount:                      invoice.TaxAmount,
+		dao.Invoice.Columns().DiscountAmount:                 invoice.DiscountAmount,
+		dao.Invoice.Columns().SubscriptionAmount:             invoice.SubscriptionAmount,
+		dao.Invoice.Columns().SubscriptionAmountExcludingTax: invoice.SubscriptionAmountExcludingTax,
+		dao.Invoice.Columns().Lines:                          utility.MarshalToJsonString(invoice.Lines),
+		dao.Invoice.Columns().GmtModify:                      gtime.Now(),
+	}).Where(dao.Invoice.Columns().Id, one.Id).Where(dao.Invoice.Columns().Status, consts.InvoiceStatusPending).OmitNil().Update()
+	if err == nil {
+		affected, _ := result.RowsAffected()
+		if affected != 1 {
+			err = fmt.Errorf("draft invoice %s has been finalized", invoiceId)
+		}
+	}
+	operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
+		MerchantId:     one.MerchantId,
+		Target:         fmt.Sprintf("Invoice(%s)", one.InvoiceId),
+		Content:        "EditDraft",
+		UserId:         one.UserId,
+		SubscriptionId: one.SubscriptionId,
+		InvoiceId:      one.InvoiceId,
+		PlanId:         0,
+		DiscountCode:   "",
+	}, err)
+	if err != nil {
+		return nil, err
+	}
+	return query.GetInvoiceByInvoiceId(ctx, one.InvoiceId), nil
+}
+
+// HoldDraftInvoice holds or releases the automatic finalisation of the draft invoice in review
+func HoldDraftInvoice(ctx context.Context, merchantId uint64, invoiceId string, hold bool) (*entity.Invoice, error) {
+	one := getReviewDraft(ctx, merchantId, invoiceId)
+	var reviewHold = 0
+	var content = "ReleaseDraft"
+	if hold {
+		reviewHold = 1
+		content = "HoldDraft"
+	}
+	_, err := dao.Invoice.Ctx(ctx).Data(g.Map{
+		dao.Invoice.Columns().ReviewHold: reviewHold,
+		dao.Invoice.Columns().GmtModify:  gtime.Now(),
+	}).Where(dao.Invoice.Columns().Id, one.Id).OmitNil().Update()
+	operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
+		MerchantId:     one.MerchantId,
+		Target:         fmt.Sprintf("Invoice(%s)", one.InvoiceId),
+		Content:        content,
+		UserId:         one.UserId,
+		SubscriptionId: one.SubscriptionId,
+		InvoiceId:      one.InvoiceId,
+		PlanId:         0,
+		DiscountCode:   "",
+	}, err)
+	if err != nil {
+		return nil, err
+	}
+	one.ReviewHold = reviewHold
+	return one, nil
+}
+
+// FinalizeDraftInvoice turns the draft invoice in review into a processing invoice, the billing cycle charges it afterwards
+func FinalizeDraftInvoice(ctx context.Context, merchantId uint64, invoiceId string, source string) (*entity.Invoice, error) {
+	one := getReviewDraft(ctx, merchantId, invoiceId)
+	g.Log().Infof(ctx, "FinalizeDraftInvoice invoiceId:%s source:%s", invoiceId, source)
+	invoiceStatus := consts.InvoiceStatusProcessing
+	var finishTime = gtime.Now().Timestamp()
+	result, err := dao.Invoice.Ctx(ctx).Data(g.Map{
+		dao.Invoice.Columns().Status:     invoiceStatus,
+		dao.Invoice.Columns().SendStatus: consts.InvoiceSendStatusUnSend,
+		dao.Invoice.Columns().FinishTime: finishTime,
+		dao.Invoice.Columns().GmtModify:  gtime.Now(),
+	}).Where(dao.Invoice.Columns().Id, one.Id).Where(dao.Invoice.Columns().Status, consts.InvoiceStatusPending).OmitNil().Update()
+	if err != nil {
+		return nil, err
+	}
+	affected, _ := result.RowsAffected()
+	if affected != 1 {
+		return nil, fmt.Errorf("draft invoice %s has been finalized", invoiceId)
+	}
+	one.Status = invoiceStatus
+	one.FinishTime = finishTime
+	invoice_number.Assign(ctx, one)
+	_, _ = redismq.Send(&redismq.Message{
+		Topic:      redismq2.TopicInvoiceProcessed.Topic,
+		Tag:        redismq2.TopicInvoiceProcessed.Tag,
+		Body:       one.InvoiceId,
+		CustomData: map[string]interface{}{"CreateFrom": utility.ReflectCurrentFunctionName()},
+	})
+	operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
+		MerchantId:     one.MerchantId,
+		Target:         fmt.Sprintf("Invoice(%s)", one.InvoiceId),
+		Content:        fmt.Sprintf("FinalizeDraft(%s)", source),
+		UserId:         one.UserId,
+		SubscriptionId: one.SubscriptionId,
+		InvoiceId:      one.InvoiceId,
+		PlanId:         0,
+		DiscountCode:   "",
+	}, err)
+	_ = handler.InvoicePdfGenerateAndEmailSendBackground(one.InvoiceId, true, false)
+	return one, nil
+}
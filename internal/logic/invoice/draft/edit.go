@@ -0,0 +1,196 @@
+package draft
+
+import (
+	"fmt"
+	"math"
+
+	"unibee/api/bean"
+	"unibee/internal/consts"
+	entity "unibee/internal/model/entity/default"
+	"unibee/utility"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+type LineReq struct {
+	Index                  *int   `json:"index"                  dc:"Index of the existing draft line to keep, empty to add a new line"`
+	Quantity               int64  `json:"quantity"               dc:"Quantity of the line, the amounts of an existing line are adjusted in proportion"`
+	UnitAmountExcludingTax int64  `json:"unitAmountExcludingTax" dc:"Unit amount excluding tax of the new line, cent"`
+	Name                   string `json:"name"                   dc:"Name of the new line"`
+	Description            string `json:"description"            dc:"Description of the new line"`
+}
+
+type EditReq struct {
+	Lines        []*LineReq `json:"lines"        dc:"Lines of the draft invoice, existing lines not listed are removed"`
+	CreditAmount int64      `json:"creditAmount" dc:"One-off credit excluding tax added to the draft invoice as a negative line, cent"`
+	CreditReason string     `json:"creditReason" dc:"Reason of the one-off credit, printed as the name of the credit line"`
+}
+
+// ReviewHours returns the hours the cycle invoice of the subscription stays in draft, 0 means no review
+func ReviewHours(plan *entity.Plan, sub *entity.Subscription) int64 {
+	if sub != nil && sub.InvoiceReviewHours != 0 {
+		return utility.MaxInt64(int64(sub.InvoiceReviewHours), 0)
+	}
+	if plan != nil && plan.InvoiceReviewHours > 0 {
+		return int64(plan.InvoiceReviewHours)
+	}
+	return 0
+}
+
+// IsReviewDraft returns true when the invoice is a cycle draft waiting for review
+func IsReviewDraft(one *entity.Invoice) bool {
+	return one != nil && one.Status == consts.InvoiceStatusPending && one.ReviewFinalizeTime > 0 && one.IsDeleted == 0
+}
+
+// IsDueForFinalize returns true when the review draft is not held and its review window has passed
+func IsDueForFinalize(one *entity.Invoice, timeNow int64) bool {
+	return IsReviewDraft(one) && one.ReviewHold == 0 && one.ReviewFinalizeTime <= timeNow
+}
+
+// ApplyEdit rebuilds the lines of the draft invoice and moves the invoice totals by the line changes,
+// so the invoice level promo credit stays as computed. The new lines are taxed at the taxPercentage first,
+// then applyTax recomputes their tax the way the cycle invoice is taxed, the kept lines keep their tax
+func ApplyEdit(invoice *bean.Invoice, req *EditReq, taxPercentage int64, applyTax func(added *bean.Invoice)) error {
+	if invoice == nil || req == nil {
+		return gerror.New("invalid draft edit")
+	}
+	if req.CreditAmount < 0 {
+		return gerror.New("creditAmount should not be negative")
+	}
+	var used = make(map[int]bool)
+	var lines = make([]*bean.InvoiceItemSimplify, 0)
+	var added = make([]*bean.InvoiceItemSimplify, 0)
+	for _, lineReq := range req.Lines {
+		if lineReq == nil {
+			continue
+		}
+		if lineReq.Quantity <= 0 {
+			return gerror.New("line quantity should greater than 0")
+		}
+		if lineReq.Index != nil {
+			index := *lineReq.Index
+			if index < 0 || index >= len(invoice.Lines) || invoice.Lines[index] == nil {
+				return gerror.Newf("invalid line index:%d", index)
+			}
+			if used[index] {
+				return gerror.Newf("duplicate line index:%d", index)
+			}
+			used[index] = true
+			line, err := adjustQuantity(invoice.Lines[index], lineReq.Quantity)
+			if err != nil {
+				return err
+			}
+			lines = append(lines, line)
+		} else {
+			if len(lineReq.Name) == 0 {
+				return gerror.New("name of the new line is required")
+			}
+			added = append(added, newLine(invoice, taxPercentage, lineReq.UnitAmountExcludingTax, lineReq.Quantity, lineReq.Name, lineReq.Description))
+		}
+	}
+	if req.CreditAmount > 0 {
+		name := "Credit"
+		if len(req.CreditReason) > 0 {
+			name = fmt.Sprintf("Credit: %s", req.CreditReason)
+		}
+		added = append(added, newLine(invoice, taxPercentage, -req.CreditAmount, 1, name, req.CreditReason))
+	}
+	if len(added) > 0 && applyTax != nil {
+		addedAmount, addedTax, _, addedOrigin := sumLines(added)
+		applyTax(&bean.Invoice{
+			Currency:                invoice.Currency,
+			CountryCode:             invoice.CountryCode,
+			VatNumber:               invoice.VatNumber,
+			TaxPercentage:           taxPercentage,
+			TotalAmount:             addedAmount,
+			TotalAmountExcludingTax: addedAmount - addedTax,
+			TaxAmount:               addedTax,
+			OriginAmount:            addedOrigin,
+			SubscriptionAmount:      addedAmount,
+			Lines:                   added,
+		})
+	}
+	lines = append(lines, added...)
+	if len(lines) == 0 {
+		return gerror.New("draft invoice should have at least one line")
+	}
+
+	oldAmount, oldTax, oldDiscount, oldOrigin := sumLines(invoice.Lines)
+	newAmount, newTax, newDiscount, newOrigin := sumLines(lines)
+	if invoice.TotalAmount+newAmount-oldAmount < 0 {
+		return gerror.New("total amount of the draft invoice should not be negative")
+	}
+	invoice.Lines = lines
+	invoice.TotalAmount = invoice.TotalAmount + newAmount - oldAmount
+	invoice.TaxAmount = invoice.TaxAmount + newTax - oldTax
+	invoice.TotalAmountExcludingTax = invoice.TotalAmountExcludingTax + (newAmount - newTax) - (oldAmount - oldTax)
+	invoice.DiscountAmount = invoice.DiscountAmount + newDiscount - oldDiscount
+	invoice.OriginAmount = invoice.OriginAmount + newOrigin - oldOrigin
+	invoice.SubscriptionAmount = invoice.SubscriptionAmount + newAmount - oldAmount
+	invoice.SubscriptionAmountExcludingTax = invoice.SubscriptionAmountExcludingTax + (newAmount - newTax) - (oldAmount - oldTax)
+	return nil
+}
+
+func adjustQuantity(origin *bean.InvoiceItemSimplify, quantity int64) (*bean.InvoiceItemSimplify, error) {
+	line := *origin
+	if quantity == origin.Quantity {
+		return &line, nil
+	}
+	if origin.MetricCharge != nil || origin.Quantity <= 0 {
+		return nil, gerror.Newf("quantity of line %s can not be changed", origin.Name)
+	}
+	scale := func(value int64) int64 {
+		return int64(math.Round(float64(value) * float64(quantity) / float64(origin.Quantity)))
+	}
+	net := scale(origin.Amount - origin.Tax)
+	line.Quantity = quantity
+	line.AmountExcludingTax = scale(origin.AmountExcludingTax)
+	line.DiscountAmount = scale(origin.DiscountAmount)
+	if len(origin.TaxLines) > 0 {
+		line.TaxLines = make([]*bean.InvoiceItemTaxLine, 0)
+		line.Tax = 0
+		for _, taxLine := range origin.TaxLines {
+			one := *taxLine
+			one.Amount = scale(taxLine.Amount)
+			line.Tax = line.Tax + one.Amount
+			line.TaxLines = append(line.TaxLines, &one)
+		}
+	} else {
+		line.Tax = scale(origin.Tax)
+	}
+	line.Amount = net + line.Tax
+	line.OriginAmount = line.Amount + line.DiscountAmount
+	return &line, nil
+}
+
+func newLine(invoice *bean.Invoice, taxPercentage int64, unitAmountExcludingTax int64, quantity int64, name string, description string) *bean.InvoiceItemSimplify {
+	amountExcludingTax := unitAmountExcludingTax * quantity
+	tax := int64(math.Round(float64(amountExcludingTax) * utility.ConvertTaxPercentageToInternalFloat(taxPercentage)))
+	return &bean.InvoiceItemSimplify{
+		Currency:               invoice.Currency,
+		OriginAmount:           amountExcludingTax + tax,
+		Amount:                 amountExcludingTax + tax,
+		Tax:                    tax,
+		TaxPercentage:          taxPercentage,
+		AmountExcludingTax:     amountExcludingTax,
+		UnitAmountExcludingTax: unitAmountExcludingTax,
+		Quantity:               quantity,
+		Name:                   name,
+		Description:            description,
+		PeriodStart:            invoice.PeriodStart,
+		PeriodEnd:              invoice.PeriodEnd,
+	}
+}
+
+func sumLines(lines []*bean.InvoiceItemSimplify) (amount int64, tax int64, discount int64, origin int64) {
+	for _, line := range lines {
+		if line == nil {
+			continue
+		}
+		amount = amount + line.Amount
+		tax = tax + line.Tax
+		discount = discount + line.DiscountAmount
+		origin = origin + line.OriginAmount
+	}
+	return
+}
@@ -0,0 +1,108 @@
+package draft
+
+import (
+	"testing"
+
+	"unibee/api/bean"
+	"unibee/internal/consts"
+	"unibee/internal/logic/tax"
+	entity "unibee/internal/model/entity/default"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testDraft() *bean.Invoice {
+	return &bean.Invoice{
+		Currency:                       "EUR",
+		TaxPercentage:                  1000,
+		TotalAmount:                    10800,
+		TotalAmountExcludingTax:        9800,
+		TaxAmount:                      1000,
+		OriginAmount:                   11000,
+		SubscriptionAmount:             11000,
+		SubscriptionAmountExcludingTax: 10000,
+		PromoCreditDiscountAmount:      200,
+		Lines: []*bean.InvoiceItemSimplify{
+			{Currency: "EUR", Amount: 8800, Tax: 800, AmountExcludingTax: 8000, UnitAmountExcludingTax: 2000, Quantity: 4, TaxPercentage: 1000, OriginAmount: 8800},
+			{Currency: "EUR", Amount: 2200, Tax: 200, AmountExcludingTax: 2000, UnitAmountExcludingTax: 2000, Quantity: 1, TaxPercentage: 1000, OriginAmount: 2200, MetricCharge: &bean.UserMetricChargeInvoiceItem{}},
+		},
+	}
+}
+
+func index(i int) *int {
+	return &i
+}
+
+func TestApplyEdit(t *testing.T) {
+	t.Run("adjust quantity, remove and add lines", func(t *testing.T) {
+		invoice := testDraft()
+		err := ApplyEdit(invoice, &EditReq{Lines: []*LineReq{
+			{Index: index(0), Quantity: 2},
+			{Quantity: 3, UnitAmountExcludingTax: 500, Name: "Setup"},
+		}}, 1000, nil)
+		require.Nil(t, err)
+		require.Len(t, invoice.Lines, 2)
+		require.Equal(t, int64(4400), invoice.Lines[0].Amount)
+		require.Equal(t, int64(400), invoice.Lines[0].Tax)
+		require.Equal(t, int64(1650), invoice.Lines[1].Amount)
+		require.Equal(t, int64(150), invoice.Lines[1].Tax)
+		require.Equal(t, int64(10800-11000+6050), invoice.TotalAmount)
+		require.Equal(t, int64(550), invoice.TaxAmount)
+		require.Equal(t, int64(9800-10000+5500), invoice.TotalAmountExcludingTax)
+	})
+	t.Run("one-off credit", func(t *testing.T) {
+		invoice := testDraft()
+		err := ApplyEdit(invoice, &EditReq{Lines: []*LineReq{{Index: index(0), Quantity: 4}, {Index: index(1), Quantity: 1}}, CreditAmount: 1000, CreditReason: "Outage"}, 1000, nil)
+		require.Nil(t, err)
+		require.Len(t, invoice.Lines, 3)
+		require.Equal(t, "Credit: Outage", invoice.Lines[2].Name)
+		require.Equal(t, int64(-1100), invoice.Lines[2].Amount)
+		require.Equal(t, int64(9700), invoice.TotalAmount)
+		require.Equal(t, int64(900), invoice.TaxAmount)
+	})
+	t.Run("new lines taxed by the invoice tax", func(t *testing.T) {
+		invoice := testDraft()
+		err := ApplyEdit(invoice, &EditReq{Lines: []*LineReq{
+			{Index: index(0), Quantity: 4},
+			{Quantity: 1, UnitAmountExcludingTax: 1000, Name: "Setup"},
+		}, CreditAmount: 500}, 2000, func(added *bean.Invoice) {
+			require.Len(t, added.Lines, 2)
+			require.Equal(t, int64(2000), added.TaxPercentage)
+			require.Equal(t, int64(100), added.TaxAmount)
+			tax.ApplyTaxTreatment(added, consts.InvoiceTaxTreatmentReverseCharge)
+		})
+		require.Nil(t, err)
+		require.Len(t, invoice.Lines, 3)
+		require.Equal(t, int64(800), invoice.Lines[0].Tax)
+		require.Equal(t, int64(1000), invoice.Lines[1].Amount)
+		require.Equal(t, int64(0), invoice.Lines[1].Tax)
+		require.Equal(t, int64(-500), invoice.Lines[2].Amount)
+		require.Equal(t, int64(800), invoice.TaxAmount)
+		require.Equal(t, int64(10800-11000+8800+500), invoice.TotalAmount)
+	})
+	t.Run("invalid edits", func(t *testing.T) {
+		require.NotNil(t, ApplyEdit(testDraft(), &EditReq{Lines: []*LineReq{{Index: index(2), Quantity: 1}}}, 1000, nil))
+		require.NotNil(t, ApplyEdit(testDraft(), &EditReq{Lines: []*LineReq{{Index: index(0), Quantity: 1}, {Index: index(0), Quantity: 1}}}, 1000, nil))
+		require.NotNil(t, ApplyEdit(testDraft(), &EditReq{Lines: []*LineReq{{Index: index(1), Quantity: 2}}}, 1000, nil))
+		require.NotNil(t, ApplyEdit(testDraft(), &EditReq{Lines: []*LineReq{{Quantity: 1, UnitAmountExcludingTax: 100}}}, 1000, nil))
+		require.NotNil(t, ApplyEdit(testDraft(), &EditReq{Lines: []*LineReq{}}, 1000, nil))
+		require.NotNil(t, ApplyEdit(testDraft(), &EditReq{Lines: []*LineReq{{Index: index(0), Quantity: 1}}, CreditAmount: 100000}, 1000, nil))
+	})
+}
+
+func TestReviewHours(t *testing.T) {
+	require.Equal(t, int64(0), ReviewHours(nil, nil))
+	require.Equal(t, int64(24), ReviewHours(&entity.Plan{InvoiceReviewHours: 24}, &entity.Subscription{}))
+	require.Equal(t, int64(48), ReviewHours(&entity.Plan{InvoiceReviewHours: 24}, &entity.Subscription{InvoiceReviewHours: 48}))
+	require.Equal(t, int64(0), ReviewHours(&entity.Plan{InvoiceReviewHours: 24}, &entity.Subscription{InvoiceReviewHours: -1}))
+}
+
+func TestIsDueForFinalize(t *testing.T) {
+	one := &entity.Invoice{Status: consts.InvoiceStatusPending, ReviewFinalizeTime: 100}
+	require.True(t, IsReviewDraft(one))
+	require.False(t, IsDueForFinalize(one, 99))
+	require.True(t, IsDueForFinalize(one, 100))
+	one.ReviewHold = 1
+	require.False(t, IsDueForFinalize(one, 200))
+	require.False(t, IsReviewDraft(&entity.Invoice{Status: consts.InvoiceStatusProcessing, ReviewFinalizeTime: 100}))
+}
@@ -0,0 +1,351 @@
+package einvoice
+
+import (
+	"encoding/xml"
+)
+
+const (
+	// FacturXGuidelineId is the EN16931 (COMFORT) profile shared by Factur-X 1.0 and ZUGFeRD 2.x
+	FacturXGuidelineId = "urn:cen.eu:en16931:2017"
+	ciiRsmNamespace    = "urn:un:unece:uncefact:data:standard:CrossIndustryInvoice:100"
+	ciiRamNamespace    = "urn:un:unece:uncefact:data:standard:ReusableAggregateBusinessInformationEntity:100"
+	ciiUdtNamespace    = "urn:un:unece:uncefact:data:standard:UnqualifiedDataType:100"
+	ciiQdtNamespace    = "urn:un:unece:uncefact:data:standard:QualifiedDataType:100"
+)
+
+type ciiAmount struct {
+	CurrencyId string `xml:"currencyID,attr,omitempty"`
+	Value      string `xml:",chardata"`
+}
+
+type ciiIdentifier struct {
+	SchemeId string `xml:"schemeID,attr,omitempty"`
+	Value    string `xml:",chardata"`
+}
+
+type ciiQuantity struct {
+	UnitCode string `xml:"unitCode,attr"`
+	Value    int64  `xml:",chardata"`
+}
+
+type ciiDateTime struct {
+	DateTimeString struct {
+		Format string `xml:"format,attr"`
+		Value  string `xml:",chardata"`
+	} `xml:"udt:DateTimeString"`
+}
+
+func newCiiDateTime(timestamp int64) *ciiDateTime {
+	if timestamp <= 0 {
+		return nil
+	}
+	one := &ciiDateTime{}
+	one.DateTimeString.Format = "102"
+	one.DateTimeString.Value = formatDate102(timestamp)
+	return one
+}
+
+type ciiTradeTax struct {
+	CalculatedAmount      *ciiAmount `xml:"ram:CalculatedAmount,omitempty"`
+	TypeCode              string     `xml:"ram:TypeCode"`
+	ExemptionReason       string     `xml:"ram:ExemptionReason,omitempty"`
+	BasisAmount           *ciiAmount `xml:"ram:BasisAmount,omitempty"`
+	CategoryCode          string     `xml:"ram:CategoryCode"`
+	RateApplicablePercent string     `xml:"ram:RateApplicablePercent,omitempty"`
+}
+
+type ciiPeriod struct {
+	StartDateTime *ciiDateTime `xml:"ram:StartDateTime,omitempty"`
+	EndDateTime   *ciiDateTime `xml:"ram:EndDateTime,omitempty"`
+}
+
+func ciiPeriodOf(start int64, end int64) *ciiPeriod {
+	if start <= 0 && end <= 0 {
+		return nil
+	}
+	return &ciiPeriod{StartDateTime: newCiiDateTime(start), EndDateTime: newCiiDateTime(end)}
+}
+
+type ciiLineItem struct {
+	AssociatedDocumentLineDocument struct {
+		LineId string `xml:"ram:LineID"`
+	} `xml:"ram:AssociatedDocumentLineDocument"`
+	SpecifiedTradeProduct struct {
+		Name        string `xml:"ram:Name"`
+		Description string `xml:"ram:Description,omitempty"`
+	} `xml:"ram:SpecifiedTradeProduct"`
+	SpecifiedLineTradeAgreement struct {
+		NetPriceProductTradePrice struct {
+			ChargeAmount ciiAmount `xml:"ram:ChargeAmount"`
+		} `xml:"ram:NetPriceProductTradePrice"`
+	} `xml:"ram:SpecifiedLineTradeAgreement"`
+	SpecifiedLineTradeDelivery struct {
+		BilledQuantity ciiQuantity `xml:"ram:BilledQuantity"`
+	} `xml:"ram:SpecifiedLineTradeDelivery"`
+	SpecifiedLineTradeSettlement struct {
+		ApplicableTradeTax                            ciiTradeTax `xml:"ram:ApplicableTradeTax"`
+		BillingSpecifiedPeriod                        *ciiPeriod  `xml:"ram:BillingSpecifiedPeriod,omitempty"`
+		SpecifiedTradeSettlementLineMonetarySummation struct {
+			LineTotalAmount ciiAmount `xml:"ram:LineTotalAmount"`
+		} `xml:"ram:SpecifiedTradeSettlementLineMonetarySummation"`
+	} `xml:"ram:SpecifiedLineTradeSettlement"`
+}
+
+type ciiAddress struct {
+	PostcodeCode string `xml:"ram:PostcodeCode,omitempty"`
+	LineOne      string `xml:"ram:LineOne,omitempty"`
+	CityName     string `xml:"ram:CityName,omitempty"`
+	CountryId    string `xml:"ram:CountryID"`
+}
+
+type ciiTradeParty struct {
+	Name                       string `xml:"ram:Name"`
+	SpecifiedLegalOrganization *struct {
+		Id string `xml:"ram:ID"`
+	} `xml:"ram:SpecifiedLegalOrganization,omitempty"`
+	PostalTradeAddress        ciiAddress `xml:"ram:PostalTradeAddress"`
+	URIUniversalCommunication *struct {
+		URIID ciiIdentifier `xml:"ram:URIID"`
+	} `xml:"ram:URIUniversalCommunication,omitempty"`
+	SpecifiedTaxRegistration *struct {
+		Id ciiIdentifier `xml:"ram:ID"`
+	} `xml:"ram:SpecifiedTaxRegistration,omitempty"`
+}
+
+type ciiAllowanceCharge struct {
+	ChargeIndicator struct {
+		Indicator bool `xml:"udt:Indicator"`
+	} `xml:"ram:ChargeIndicator"`
+	ActualAmount     ciiAmount   `xml:"ram:ActualAmount"`
+	Reason           string      `xml:"ram:Reason,omitempty"`
+	CategoryTradeTax ciiTradeTax `xml:"ram:CategoryTradeTax"`
+}
+
+type ciiPaymentMeans struct {
+	TypeCode                           string `xml:"ram:TypeCode"`
+	PayeePartyCreditorFinancialAccount *struct {
+		IBANID string `xml:"ram:IBANID"`
+	} `xml:"ram:PayeePartyCreditorFinancialAccount,omitempty"`
+	PayeeSpecifiedCreditorFinancialInstitution *struct {
+		BICID string `xml:"ram:BICID"`
+	} `xml:"ram:PayeeSpecifiedCreditorFinancialInstitution,omitempty"`
+}
+
+type ciiPaymentTerms struct {
+	Description     string       `xml:"ram:Description,omitempty"`
+	DueDateDateTime *ciiDateTime `xml:"ram:DueDateDateTime,omitempty"`
+}
+
+type ciiMonetarySummation struct {
+	LineTotalAmount      ciiAmount  `xml:"ram:LineTotalAmount"`
+	ChargeTotalAmount    *ciiAmount `xml:"ram:ChargeTotalAmount,omitempty"`
+	AllowanceTotalAmount *ciiAmount `xml:"ram:AllowanceTotalAmount,omitempty"`
+	TaxBasisTotalAmount  ciiAmount  `xml:"ram:TaxBasisTotalAmount"`
+	TaxTotalAmount       ciiAmount  `xml:"ram:TaxTotalAmount"`
+	RoundingAmount       *ciiAmount `xml:"ram:RoundingAmount,omitempty"`
+	GrandTotalAmount     ciiAmount  `xml:"ram:GrandTotalAmount"`
+	TotalPrepaidAmount   *ciiAmount `xml:"ram:TotalPrepaidAmount,omitempty"`
+	DuePayableAmount     ciiAmount  `xml:"ram:DuePayableAmount"`
+}
+
+type ciiReferencedDocument struct {
+	IssuerAssignedId       string `xml:"ram:IssuerAssignedID"`
+	FormattedIssueDateTime *struct {
+		DateTimeString struct {
+			Format string `xml:"format,attr"`
+			Value  string `xml:",chardata"`
+		} `xml:"qdt:DateTimeString"`
+	} `xml:"ram:FormattedIssueDateTime,omitempty"`
+}
+
+type ciiDocument struct {
+	XMLName                  xml.Name `xml:"rsm:CrossIndustryInvoice"`
+	XmlnsRsm                 string   `xml:"xmlns:rsm,attr"`
+	XmlnsRam                 string   `xml:"xmlns:ram,attr"`
+	XmlnsUdt                 string   `xml:"xmlns:udt,attr"`
+	XmlnsQdt                 string   `xml:"xmlns:qdt,attr"`
+	ExchangedDocumentContext struct {
+		GuidelineSpecifiedDocumentContextParameter struct {
+			Id string `xml:"ram:ID"`
+		} `xml:"ram:GuidelineSpecifiedDocumentContextParameter"`
+	} `xml:"rsm:ExchangedDocumentContext"`
+	ExchangedDocument struct {
+		Id            string      `xml:"ram:ID"`
+		TypeCode      string      `xml:"ram:TypeCode"`
+		IssueDateTime ciiDateTime `xml:"ram:IssueDateTime"`
+		IncludedNote  *struct {
+			Content string `xml:"ram:Content"`
+		} `xml:"ram:IncludedNote,omitempty"`
+	} `xml:"rsm:ExchangedDocument"`
+	SupplyChainTradeTransaction struct {
+		IncludedSupplyChainTradeLineItem []ciiLineItem `xml:"ram:IncludedSupplyChainTradeLineItem"`
+		ApplicableHeaderTradeAgreement   struct {
+			BuyerReference   string        `xml:"ram:BuyerReference,omitempty"`
+			SellerTradeParty ciiTradeParty `xml:"ram:SellerTradeParty"`
+			BuyerTradeParty  ciiTradeParty `xml:"ram:BuyerTradeParty"`
+		} `xml:"ram:ApplicableHeaderTradeAgreement"`
+		ApplicableHeaderTradeDelivery   struct{} `xml:"ram:ApplicableHeaderTradeDelivery"`
+		ApplicableHeaderTradeSettlement struct {
+			PaymentReference                                string                 `xml:"ram:PaymentReference,omitempty"`
+			InvoiceCurrencyCode                             string                 `xml:"ram:InvoiceCurrencyCode"`
+			SpecifiedTradeSettlementPaymentMeans            *ciiPaymentMeans       `xml:"ram:SpecifiedTradeSettlementPaymentMeans,omitempty"`
+			ApplicableTradeTax                              []ciiTradeTax          `xml:"ram:ApplicableTradeTax"`
+			BillingSpecifiedPeriod                          *ciiPeriod             `xml:"ram:BillingSpecifiedPeriod,omitempty"`
+			SpecifiedTradeAllowanceCharge                   []ciiAllowanceCharge   `xml:"ram:SpecifiedTradeAllowanceCharge,omitempty"`
+			SpecifiedTradePaymentTerms                      *ciiPaymentTerms       `xml:"ram:SpecifiedTradePaymentTerms,omitempty"`
+			SpecifiedTradeSettlementHeaderMonetarySummation ciiMonetarySummation   `xml:"ram:SpecifiedTradeSettlementHeaderMonetarySummation"`
+			InvoiceReferencedDocument                       *ciiReferencedDocument `xml:"ram:InvoiceReferencedDocument,omitempty"`
+		} `xml:"ram:ApplicableHeaderTradeSettlement"`
+	} `xml:"rsm:SupplyChainTradeTransaction"`
+}
+
+func (doc *Document) ciiAmount(cents int64) ciiAmount {
+	return ciiAmount{Value: formatAmount(cents, doc.Currency)}
+}
+
+func (doc *Document) ciiOptionalAmount(cents int64) *ciiAmount {
+	if cents == 0 {
+		return nil
+	}
+	amount := doc.ciiAmount(cents)
+	return &amount
+}
+
+func (doc *Document) ciiTradeTax() ciiTradeTax {
+	return ciiTradeTax{
+		TypeCode:              "VAT",
+		CategoryCode:          doc.VatCategory,
+		RateApplicablePercent: formatPercentage(doc.VatPercentage),
+	}
+}
+
+func (party *Party) cii() ciiTradeParty {
+	one := ciiTradeParty{
+		Name: party.Name,
+		PostalTradeAddress: ciiAddress{
+			PostcodeCode: party.PostalCode,
+			LineOne:      party.Address,
+			CityName:     party.City,
+			CountryId:    party.CountryCode,
+		},
+	}
+	if len(party.RegistrationNumber) > 0 {
+		one.SpecifiedLegalOrganization = &struct {
+			Id string `xml:"ram:ID"`
+		}{Id: party.RegistrationNumber}
+	}
+	if len(party.EndpointId) > 0 {
+		one.URIUniversalCommunication = &struct {
+			URIID ciiIdentifier `xml:"ram:URIID"`
+		}{URIID: ciiIdentifier{SchemeId: party.EndpointScheme, Value: party.EndpointId}}
+	}
+	if len(party.VatNumber) > 0 {
+		one.SpecifiedTaxRegistration = &struct {
+			Id ciiIdentifier `xml:"ram:ID"`
+		}{Id: ciiIdentifier{SchemeId: "VA", Value: party.VatNumber}}
+	}
+	return one
+}
+
+// RenderCII renders the document as UN/CEFACT Cross Industry Invoice in the Factur-X/ZUGFeRD EN16931 profile
+func RenderCII(doc *Document) ([]byte, error) {
+	one := &ciiDocument{
+		XmlnsRsm: ciiRsmNamespace,
+		XmlnsRam: ciiRamNamespace,
+		XmlnsUdt: ciiUdtNamespace,
+		XmlnsQdt: ciiQdtNamespace,
+	}
+	one.ExchangedDocumentContext.GuidelineSpecifiedDocumentContextParameter.Id = FacturXGuidelineId
+	one.ExchangedDocument.Id = doc.Number
+	one.ExchangedDocument.TypeCode = doc.TypeCode
+	if issue := newCiiDateTime(doc.IssueTime); issue != nil {
+		one.ExchangedDocument.IssueDateTime = *issue
+	}
+	if len(doc.Note) > 0 {
+		one.ExchangedDocument.IncludedNote = &struct {
+			Content string `xml:"ram:Content"`
+		}{Content: doc.Note}
+	}
+	transaction := &one.SupplyChainTradeTransaction
+	for _, line := range doc.Lines {
+		item := ciiLineItem{}
+		item.AssociatedDocumentLineDocument.LineId = line.Id
+		item.SpecifiedTradeProduct.Name = line.Name
+		item.SpecifiedTradeProduct.Description = line.Description
+		item.SpecifiedLineTradeAgreement.NetPriceProductTradePrice.ChargeAmount = doc.ciiAmount(line.PriceAmount)
+		item.SpecifiedLineTradeDelivery.BilledQuantity = ciiQuantity{UnitCode: line.UnitCode, Value: line.Quantity}
+		item.SpecifiedLineTradeSettlement.ApplicableTradeTax = doc.ciiTradeTax()
+		item.SpecifiedLineTradeSettlement.BillingSpecifiedPeriod = ciiPeriodOf(line.PeriodStart, line.PeriodEnd)
+		item.SpecifiedLineTradeSettlement.SpecifiedTradeSettlementLineMonetarySummation.LineTotalAmount = doc.ciiAmount(line.NetAmount)
+		transaction.IncludedSupplyChainTradeLineItem = append(transaction.IncludedSupplyChainTradeLineItem, item)
+	}
+	transaction.ApplicableHeaderTradeAgreement.BuyerReference = doc.BuyerReference
+	transaction.ApplicableHeaderTradeAgreement.SellerTradeParty = doc.Seller.cii()
+	transaction.ApplicableHeaderTradeAgreement.BuyerTradeParty = doc.Buyer.cii()
+
+	settlement := &transaction.ApplicableHeaderTradeSettlement
+	settlement.PaymentReference = doc.Number
+	settlement.InvoiceCurrencyCode = doc.Currency
+	if len(doc.PaymentMeansCode) > 0 {
+		settlement.SpecifiedTradeSettlementPaymentMeans = &ciiPaymentMeans{TypeCode: doc.PaymentMeansCode}
+		if len(doc.PayeeIBAN) > 0 {
+			settlement.SpecifiedTradeSettlementPaymentMeans.PayeePartyCreditorFinancialAccount = &struct {
+				IBANID string `xml:"ram:IBANID"`
+			}{IBANID: doc.PayeeIBAN}
+		}
+		if len(doc.PayeeBIC) > 0 {
+			settlement.SpecifiedTradeSettlementPaymentMeans.PayeeSpecifiedCreditorFinancialInstitution = &struct {
+				BICID string `xml:"ram:BICID"`
+			}{BICID: doc.PayeeBIC}
+		}
+	}
+	tax := doc.ciiTradeTax()
+	calculated := doc.ciiAmount(doc.TaxAmount)
+	basis := doc.ciiAmount(doc.TaxExclusiveAmount)
+	tax.CalculatedAmount = &calculated
+	tax.BasisAmount = &basis
+	tax.ExemptionReason = doc.VatExemptionReason
+	settlement.ApplicableTradeTax = []ciiTradeTax{tax}
+	settlement.BillingSpecifiedPeriod = ciiPeriodOf(doc.PeriodStart, doc.PeriodEnd)
+	if doc.ChargeTotalAmount > 0 {
+		charge := ciiAllowanceCharge{ActualAmount: doc.ciiAmount(doc.ChargeTotalAmount), Reason: "Charge", CategoryTradeTax: doc.ciiTradeTax()}
+		charge.ChargeIndicator.Indicator = true
+		settlement.SpecifiedTradeAllowanceCharge = append(settlement.SpecifiedTradeAllowanceCharge, charge)
+	}
+	if doc.AllowanceTotalAmount > 0 {
+		allowance := ciiAllowanceCharge{ActualAmount: doc.ciiAmount(doc.AllowanceTotalAmount), Reason: doc.DocumentAllowanceText, CategoryTradeTax: doc.ciiTradeTax()}
+		settlement.SpecifiedTradeAllowanceCharge = append(settlement.SpecifiedTradeAllowanceCharge, allowance)
+	}
+	if doc.DueTime > 0 || len(doc.PaymentTerms) > 0 {
+		settlement.SpecifiedTradePaymentTerms = &ciiPaymentTerms{Description: doc.PaymentTerms, DueDateDateTime: newCiiDateTime(doc.DueTime)}
+	}
+	settlement.SpecifiedTradeSettlementHeaderMonetarySummation = ciiMonetarySummation{
+		LineTotalAmount:      doc.ciiAmount(doc.LineTotalAmount),
+		ChargeTotalAmount:    doc.ciiOptionalAmount(doc.ChargeTotalAmount),
+		AllowanceTotalAmount: doc.ciiOptionalAmount(doc.AllowanceTotalAmount),
+		TaxBasisTotalAmount:  doc.ciiAmount(doc.TaxExclusiveAmount),
+		TaxTotalAmount:       ciiAmount{CurrencyId: doc.Currency, Value: formatAmount(doc.TaxAmount, doc.Currency)},
+		RoundingAmount:       doc.ciiOptionalAmount(doc.RoundingAmount),
+		GrandTotalAmount:     doc.ciiAmount(doc.TaxInclusiveAmount),
+		TotalPrepaidAmount:   doc.ciiOptionalAmount(doc.PrepaidAmount),
+		DuePayableAmount:     doc.ciiAmount(doc.PayableAmount),
+	}
+	if doc.IsCreditNote() && len(doc.PrecedingNumber) > 0 {
+		settlement.InvoiceReferencedDocument = &ciiReferencedDocument{IssuerAssignedId: doc.PrecedingNumber}
+		if doc.PrecedingIssueTime > 0 {
+			settlement.InvoiceReferencedDocument.FormattedIssueDateTime = &struct {
+				DateTimeString struct {
+					Format string `xml:"format,attr"`
+					Value  string `xml:",chardata"`
+				} `xml:"qdt:DateTimeString"`
+			}{}
+			settlement.InvoiceReferencedDocument.FormattedIssueDateTime.DateTimeString.Format = "102"
+			settlement.InvoiceReferencedDocument.FormattedIssueDateTime.DateTimeString.Value = formatDate102(doc.PrecedingIssueTime)
+		}
+	}
+	content, err := xml.MarshalIndent(one, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), content...), nil
+}
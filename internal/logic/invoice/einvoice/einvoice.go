@@ -0,0 +1,370 @@
+package einvoice
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"unibee/api/bean/detail"
+	"unibee/internal/consts"
+	"unibee/internal/logic/invoice/invoice_number"
+	"unibee/internal/logic/merchant_config"
+	"unibee/internal/logic/merchant_config/update"
+	entity "unibee/internal/model/entity/default"
+	"unibee/internal/query"
+	"unibee/utility"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+const (
+	MerchantEInvoiceConfig = "KEY_MERCHANT_E_INVOICE"
+	FormatUBL              = "ubl"
+	FormatCII              = "cii"
+	TypeCodeInvoice        = "380"
+	TypeCodeCreditNote     = "381"
+	// EndpointSchemeEmail is the EAS code of an email electronic address
+	EndpointSchemeEmail = "EM"
+	UnitCodeOne         = "C62"
+	VatCategoryStandard = "S"
+	VatCategoryZero     = "Z"
+	VatCategoryReverse  = "AE"
+	VatCategoryExempt   = "E"
+)
+
+type Config struct {
+	EmbedFacturX   bool   `json:"embedFacturX"   dc:"Embed the CII xml into invoice PDFs as factur-x.xml (Factur-X/ZUGFeRD EN16931)"`
+	EndpointScheme string `json:"endpointScheme" dc:"EAS scheme of the seller Peppol electronic address, eg 0088 or 9930, default EM with the merchant email"`
+	EndpointId     string `json:"endpointId"     dc:"Seller Peppol electronic address, default the merchant email"`
+}
+
+func GetMerchantEInvoiceConfig(ctx context.Context, merchantId uint64) *Config {
+	config := &Config{}
+	one := merchant_config.GetMerchantConfig(ctx, merchantId, MerchantEInvoiceConfig)
+	if one != nil && len(one.ConfigValue) > 0 {
+		_ = utility.UnmarshalFromJsonString(one.ConfigValue, config)
+	}
+	return config
+}
+
+func SetupMerchantEInvoiceConfig(ctx context.Context, merchantId uint64, config *Config) error {
+	utility.Assert(config != nil, "invalid config")
+	utility.Assert(len(config.EndpointScheme) == 0 || len(config.EndpointId) > 0, "endpointId required when endpointScheme specified")
+	return update.SetMerchantConfig(ctx, merchantId, MerchantEInvoiceConfig, utility.MarshalToJsonString(config))
+}
+
+// Party is the seller or buyer of the document
+type Party struct {
+	Name               string
+	VatNumber          string
+	RegistrationNumber string
+	Address            string
+	City               string
+	PostalCode         string
+	CountryCode        string
+	Email              string
+	EndpointScheme     string
+	EndpointId         string
+}
+
+type Line struct {
+	Id          string
+	Name        string
+	Description string
+	Quantity    int64
+	UnitCode    string
+	// PriceAmount is the net unit price in cents
+	PriceAmount int64
+	// NetAmount is the line net amount in cents
+	NetAmount   int64
+	PeriodStart int64
+	PeriodEnd   int64
+}
+
+// Document is the EN16931 view of one invoice or credit note, amounts in cents,
+// credit notes carry positive amounts
+type Document struct {
+	Number                string
+	TypeCode              string
+	IssueTime             int64
+	DueTime               int64
+	Currency              string
+	Note                  string
+	BuyerReference        string
+	PrecedingNumber       string
+	PrecedingIssueTime    int64
+	PeriodStart           int64
+	PeriodEnd             int64
+	Seller                *Party
+	Buyer                 *Party
+	PaymentMeansCode      string
+	PayeeIBAN             string
+	PayeeBIC              string
+	PaymentTerms          string
+	Lines                 []*Line
+	VatCategory           string
+	VatPercentage         int64
+	VatExemptionReason    string
+	LineTotalAmount       int64
+	AllowanceTotalAmount  int64
+	ChargeTotalAmount     int64
+	TaxExclusiveAmount    int64
+	TaxAmount             int64
+	TaxInclusiveAmount    int64
+	PrepaidAmount         int64
+	RoundingAmount        int64
+	PayableAmount         int64
+	DocumentAllowanceText string
+}
+
+func (doc *Document) IsCreditNote() bool {
+	return doc.TypeCode == TypeCodeCreditNote
+}
+
+func metadataString(metadata map[string]interface{}, key string, defaultValue string) string {
+	if metadata != nil {
+		if value, ok := metadata[key]; ok && value != nil {
+			if str := fmt.Sprintf("%v", value); len(str) > 0 {
+				return str
+			}
+		}
+	}
+	return defaultValue
+}
+
+func documentNumber(one *detail.InvoiceDetail) string {
+	if len(one.InvoiceNumber) > 0 {
+		return one.InvoiceNumber
+	}
+	return one.InvoiceId
+}
+
+// NewDocument maps the invoice detail to the EN16931 document,
+// the seller comes from the merchant profile overridden by the Issue* metadata the PDF uses
+func NewDocument(one *detail.InvoiceDetail, merchant *entity.Merchant, user *entity.UserAccount, config *Config) *Document {
+	utility.Assert(one != nil, "invoice not found")
+	utility.Assert(merchant != nil, "merchant not found")
+	if config == nil {
+		config = &Config{}
+	}
+	doc := &Document{
+		Number:         documentNumber(one),
+		TypeCode:       TypeCodeInvoice,
+		Currency:       strings.ToUpper(one.Currency),
+		Note:           one.InvoiceName,
+		BuyerReference: one.SubscriptionId,
+		PeriodStart:    one.PeriodStart,
+		PeriodEnd:      one.PeriodEnd,
+		VatPercentage:  one.TaxPercentage,
+	}
+	if len(doc.BuyerReference) == 0 {
+		doc.BuyerReference = one.InvoiceId
+	}
+	doc.IssueTime = one.FinishTime
+	if doc.IssueTime <= 0 {
+		doc.IssueTime = one.CreateTime
+	}
+	var sign int64 = 1
+	if len(one.RefundId) > 0 {
+		doc.TypeCode = TypeCodeCreditNote
+		if one.TotalAmount < 0 {
+			sign = -1
+		}
+		if one.OriginalPaymentInvoice != nil {
+			doc.PrecedingNumber = one.OriginalPaymentInvoice.InvoiceNumber
+			if len(doc.PrecedingNumber) == 0 {
+				doc.PrecedingNumber = one.OriginalPaymentInvoice.InvoiceId
+			}
+			doc.PrecedingIssueTime = one.OriginalPaymentInvoice.FinishTime
+		}
+		doc.PaymentTerms = "Refunded to the original payment method"
+	} else {
+		var dayUtilDue = one.DayUtilDue
+		if dayUtilDue <= 0 {
+			dayUtilDue = consts.DEFAULT_DAY_UTIL_DUE
+		}
+		doc.DueTime = doc.IssueTime + dayUtilDue*86400
+	}
+
+	doc.Seller = &Party{
+		Name:               metadataString(one.Metadata, "IssueCompanyName", merchant.CompanyName),
+		VatNumber:          metadataString(one.Metadata, "IssueVatNumber", merchant.BusinessNum),
+		RegistrationNumber: metadataString(one.Metadata, "IssueRegNumber", merchant.Idcard),
+		Address:            metadataString(one.Metadata, "IssueAddress", merchant.Address),
+		CountryCode:        strings.ToUpper(merchant.CountryCode),
+		Email:              merchant.Email,
+		EndpointScheme:     EndpointSchemeEmail,
+		EndpointId:         merchant.Email,
+	}
+	if len(config.EndpointId) > 0 {
+		doc.Seller.EndpointId = config.EndpointId
+		if len(config.EndpointScheme) > 0 {
+			doc.Seller.EndpointScheme = config.EndpointScheme
+		}
+	}
+	doc.Buyer = &Party{EndpointScheme: EndpointSchemeEmail}
+	if user != nil {
+		doc.Buyer.Name = user.CompanyName
+		if user.Type == 1 || len(doc.Buyer.Name) == 0 {
+			doc.Buyer.Name = strings.TrimSpace(user.FirstName + " " + user.LastName)
+		}
+		if len(doc.Buyer.Name) == 0 {
+			doc.Buyer.Name = user.Email
+		}
+		doc.Buyer.Address = user.Address
+		doc.Buyer.City = user.City
+		doc.Buyer.PostalCode = user.ZipCode
+		doc.Buyer.CountryCode = strings.ToUpper(user.CountryCode)
+		doc.Buyer.RegistrationNumber = user.RegistrationNumber
+		doc.Buyer.VatNumber = user.VATNumber
+		doc.Buyer.Email = user.Email
+	}
+	if len(one.CountryCode) > 0 {
+		doc.Buyer.CountryCode = strings.ToUpper(one.CountryCode)
+	}
+	if len(one.VatNumber) > 0 {
+		doc.Buyer.VatNumber = one.VatNumber
+	}
+	if len(doc.Buyer.Email) == 0 {
+		doc.Buyer.Email = one.SendEmail
+	}
+	doc.Buyer.EndpointId = doc.Buyer.Email
+
+	doc.PaymentMeansCode = "68"
+	if one.Gateway != nil && one.Gateway.GatewayType == consts.GatewayTypeWireTransfer {
+		doc.PaymentMeansCode = "30"
+		if one.Gateway.Bank != nil && len(one.Gateway.Bank.IBAN) > 0 {
+			doc.PaymentMeansCode = "58"
+			doc.PayeeIBAN = strings.ReplaceAll(one.Gateway.Bank.IBAN, " ", "")
+			doc.PayeeBIC = one.Gateway.Bank.BIC
+		}
+	}
+
+	for i, item := range one.Lines {
+		if item == nil {
+			continue
+		}
+		line := &Line{
+			Id:          fmt.Sprintf("%d", i+1),
+			Name:        item.Name,
+			Description: item.Description,
+			Quantity:    item.Quantity,
+			UnitCode:    UnitCodeOne,
+			PriceAmount: sign * item.UnitAmountExcludingTax,
+			NetAmount:   sign * item.UnitAmountExcludingTax * item.Quantity,
+			PeriodStart: item.PeriodStart,
+			PeriodEnd:   item.PeriodEnd,
+		}
+		if item.MetricCharge != nil || item.Quantity <= 0 {
+			line.Quantity = 1
+			line.PriceAmount = sign * item.AmountExcludingTax
+			line.NetAmount = sign * item.AmountExcludingTax
+		}
+		if len(line.Name) == 0 {
+			line.Name = item.Description
+		}
+		if len(line.Name) == 0 {
+			line.Name = one.ProductName
+		}
+		doc.Lines = append(doc.Lines, line)
+		doc.LineTotalAmount = doc.LineTotalAmount + line.NetAmount
+	}
+
+	doc.TaxExclusiveAmount = sign * one.TotalAmountExcludingTax
+	doc.TaxAmount = sign * one.TaxAmount
+	// invoice level discounts and promo credits are the gap between line total and taxable amount
+	if diff := doc.LineTotalAmount - doc.TaxExclusiveAmount; diff > 0 {
+		doc.AllowanceTotalAmount = diff
+		doc.DocumentAllowanceText = "Discount"
+		if len(one.DiscountCode) > 0 {
+			doc.DocumentAllowanceText = fmt.Sprintf("Discount %s", one.DiscountCode)
+		}
+	} else if diff < 0 {
+		doc.ChargeTotalAmount = -diff
+	}
+	doc.TaxInclusiveAmount = doc.TaxExclusiveAmount + doc.TaxAmount
+	doc.PrepaidAmount = sign * one.PartialCreditPaidAmount
+	doc.PayableAmount = sign*one.TotalAmount - doc.PrepaidAmount
+	doc.RoundingAmount = doc.PayableAmount + doc.PrepaidAmount - doc.TaxInclusiveAmount
+
+	switch {
+	case one.TaxTreatment == consts.InvoiceTaxTreatmentReverseCharge:
+		doc.VatCategory = VatCategoryReverse
+		doc.VatExemptionReason = "Reverse charge"
+	case one.TaxTreatment == consts.InvoiceTaxTreatmentExempt:
+		doc.VatCategory = VatCategoryExempt
+		doc.VatExemptionReason = "Exempt"
+		if one.UserSnapshot != nil && len(one.UserSnapshot.TaxExemptCertificate) > 0 {
+			doc.VatExemptionReason = fmt.Sprintf("Exempt, certificate %s", one.UserSnapshot.TaxExemptCertificate)
+		} else if user != nil && len(user.TaxExemptCertificate) > 0 {
+			doc.VatExemptionReason = fmt.Sprintf("Exempt, certificate %s", user.TaxExemptCertificate)
+		}
+	case doc.VatPercentage > 0:
+		doc.VatCategory = VatCategoryStandard
+	case len(doc.Buyer.VatNumber) > 0 && len(doc.Buyer.CountryCode) > 0 && doc.Buyer.CountryCode != doc.Seller.CountryCode:
+		doc.VatCategory = VatCategoryReverse
+		doc.VatExemptionReason = "Reverse charge"
+	default:
+		doc.VatCategory = VatCategoryZero
+	}
+	return doc
+}
+
+// formatAmount renders cents as a decimal with the minor units of the currency
+func formatAmount(cents int64, currency string) string {
+	if utility.IsNoCentCurrency(strings.ToUpper(currency)) {
+		return fmt.Sprintf("%d", cents)
+	}
+	var sign = ""
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, cents/100, cents%100)
+}
+
+// formatPercentage renders the internal tax percentage, 1000 = 10%
+func formatPercentage(taxPercentage int64) string {
+	return fmt.Sprintf("%d.%02d", taxPercentage/100, taxPercentage%100)
+}
+
+func formatDate(timestamp int64) string {
+	if timestamp <= 0 {
+		return ""
+	}
+	return gtime.NewFromTimeStamp(timestamp).UTC().Format("Y-m-d")
+}
+
+func formatDate102(timestamp int64) string {
+	if timestamp <= 0 {
+		return ""
+	}
+	return gtime.NewFromTimeStamp(timestamp).UTC().Format("Ymd")
+}
+
+// Render renders the document in the format, ubl or cii
+func Render(doc *Document, format string) ([]byte, error) {
+	switch strings.ToLower(format) {
+	case FormatCII:
+		return RenderCII(doc)
+	case FormatUBL, "":
+		return RenderUBL(doc)
+	default:
+		return nil, gerror.Newf("unsupported e-invoice format:%s", format)
+	}
+}
+
+// Generate renders the e-invoice with the validation results, the invoice is numbered when issued,
+// the one issued before numbering was enabled keeps the number its PDF shows
+func Generate(ctx context.Context, one *entity.Invoice, format string) ([]byte, []*ValidationResult, error) {
+	utility.Assert(one != nil, "invoice not found")
+	invoiceDetail := detail.ConvertInvoiceToDetail(ctx, one)
+	invoiceDetail.InvoiceNumber = invoice_number.DisplayNumber(ctx, one)
+	doc := NewDocument(invoiceDetail, query.GetMerchantById(ctx, one.MerchantId), query.GetUserAccountById(ctx, one.UserId), GetMerchantEInvoiceConfig(ctx, one.MerchantId))
+	content, err := Render(doc, format)
+	if err != nil {
+		return nil, nil, err
+	}
+	return content, Validate(doc), nil
+}
@@ -0,0 +1,178 @@
+package einvoice
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"unibee/api/bean"
+	"unibee/api/bean/detail"
+	"unibee/internal/consts"
+	entity "unibee/internal/model/entity/default"
+
+	"github.com/go-pdf/fpdf"
+	"github.com/stretchr/testify/require"
+)
+
+func testInvoice() *detail.InvoiceDetail {
+	return &detail.InvoiceDetail{
+		InvoiceId:               "81720768257606",
+		InvoiceNumber:           "INV-2024-000001",
+		InvoiceName:             "SubscriptionCreate",
+		Currency:                "EUR",
+		TotalAmount:             2380,
+		TotalAmountExcludingTax: 2000,
+		TaxAmount:               380,
+		TaxPercentage:           1900,
+		CountryCode:             "DE",
+		FinishTime:              1718000000,
+		Lines: []*bean.InvoiceItemSimplify{
+			{Name: "Pro", Description: "Pro Plan", Quantity: 2, UnitAmountExcludingTax: 1000, AmountExcludingTax: 2000},
+		},
+	}
+}
+
+func testMerchant() *entity.Merchant {
+	return &entity.Merchant{CompanyName: "Seller GmbH", BusinessNum: "DE123456789", Address: "Hauptstr. 1, Berlin", CountryCode: "de", Email: "billing@seller.example"}
+}
+
+func testUser() *entity.UserAccount {
+	return &entity.UserAccount{Email: "buyer@example.com", FirstName: "Jane", LastName: "Doe", Type: 1}
+}
+
+func TestNewDocument(t *testing.T) {
+	t.Run("Invoice", func(t *testing.T) {
+		doc := NewDocument(testInvoice(), testMerchant(), testUser(), nil)
+		require.Equal(t, TypeCodeInvoice, doc.TypeCode)
+		require.Equal(t, "INV-2024-000001", doc.Number)
+		require.Equal(t, "DE", doc.Seller.CountryCode)
+		require.Equal(t, "Jane Doe", doc.Buyer.Name)
+		require.Equal(t, VatCategoryStandard, doc.VatCategory)
+		require.Equal(t, int64(2000), doc.LineTotalAmount)
+		require.Equal(t, int64(2380), doc.PayableAmount)
+		require.True(t, doc.DueTime > doc.IssueTime)
+		require.Equal(t, 0, len(Validate(doc)))
+	})
+	t.Run("CreditNote", func(t *testing.T) {
+		one := testInvoice()
+		one.RefundId = "refund"
+		one.TotalAmount = -2380
+		one.TotalAmountExcludingTax = -2000
+		one.TaxAmount = -380
+		one.Lines[0].UnitAmountExcludingTax = -1000
+		one.Lines[0].AmountExcludingTax = -2000
+		one.OriginalPaymentInvoice = &bean.Invoice{InvoiceId: "origin", InvoiceNumber: "INV-2024-000000"}
+		doc := NewDocument(one, testMerchant(), testUser(), nil)
+		require.True(t, doc.IsCreditNote())
+		require.Equal(t, "INV-2024-000000", doc.PrecedingNumber)
+		require.Equal(t, int64(1000), doc.Lines[0].PriceAmount)
+		require.Equal(t, int64(2380), doc.PayableAmount)
+		require.Equal(t, int64(0), doc.DueTime)
+		require.False(t, HasFatal(Validate(doc)))
+	})
+	t.Run("ReverseCharge", func(t *testing.T) {
+		one := testInvoice()
+		one.TaxPercentage = 0
+		one.TaxAmount = 0
+		one.TotalAmount = 2000
+		one.CountryCode = "FR"
+		one.VatNumber = "FR12345678901"
+		doc := NewDocument(one, testMerchant(), testUser(), nil)
+		require.Equal(t, VatCategoryReverse, doc.VatCategory)
+		require.False(t, HasFatal(Validate(doc)))
+	})
+	t.Run("ReverseChargeTreatment", func(t *testing.T) {
+		one := testInvoice()
+		one.TaxPercentage = 0
+		one.TaxAmount = 0
+		one.TotalAmount = 2000
+		one.VatNumber = "DE123456789"
+		one.TaxTreatment = consts.InvoiceTaxTreatmentReverseCharge
+		doc := NewDocument(one, testMerchant(), testUser(), nil)
+		require.Equal(t, VatCategoryReverse, doc.VatCategory)
+		require.False(t, HasFatal(Validate(doc)))
+	})
+	t.Run("Exempt", func(t *testing.T) {
+		one := testInvoice()
+		one.TaxPercentage = 0
+		one.TaxAmount = 0
+		one.TotalAmount = 2000
+		one.TaxTreatment = consts.InvoiceTaxTreatmentExempt
+		one.UserSnapshot = &bean.UserAccount{TaxExemptCertificate: "EX-001"}
+		doc := NewDocument(one, testMerchant(), testUser(), nil)
+		require.Equal(t, VatCategoryExempt, doc.VatCategory)
+		require.Equal(t, "Exempt, certificate EX-001", doc.VatExemptionReason)
+		require.False(t, HasFatal(Validate(doc)))
+		data, err := RenderCII(doc)
+		require.Nil(t, err)
+		require.Contains(t, string(data), "<ram:CategoryCode>E</ram:CategoryCode>")
+	})
+	t.Run("Discount", func(t *testing.T) {
+		one := testInvoice()
+		one.TotalAmountExcludingTax = 1800
+		one.TaxAmount = 342
+		one.TotalAmount = 2142
+		one.DiscountCode = "SAVE10"
+		doc := NewDocument(one, testMerchant(), testUser(), nil)
+		require.Equal(t, int64(200), doc.AllowanceTotalAmount)
+		require.Equal(t, "Discount SAVE10", doc.DocumentAllowanceText)
+		require.Equal(t, 0, len(Validate(doc)))
+	})
+}
+
+func TestValidate(t *testing.T) {
+	doc := NewDocument(testInvoice(), testMerchant(), testUser(), nil)
+	doc.Seller.VatNumber = ""
+	doc.TaxAmount = 100
+	rules := make(map[string]bool)
+	for _, one := range Validate(doc) {
+		rules[one.Rule] = true
+	}
+	require.True(t, rules["BR-S-02"])
+	require.True(t, rules["BR-S-09"])
+	require.True(t, rules["BR-CO-15"])
+	require.False(t, rules["BR-02"])
+}
+
+func TestRender(t *testing.T) {
+	doc := NewDocument(testInvoice(), testMerchant(), testUser(), nil)
+	t.Run("UBL", func(t *testing.T) {
+		content, err := Render(doc, FormatUBL)
+		require.Nil(t, err)
+		require.Nil(t, xml.Unmarshal(content, new(interface{})))
+		require.Contains(t, string(content), PeppolCustomizationId)
+		require.Contains(t, string(content), "<cbc:PayableAmount currencyID=\"EUR\">23.80</cbc:PayableAmount>")
+	})
+	t.Run("CII", func(t *testing.T) {
+		content, err := Render(doc, FormatCII)
+		require.Nil(t, err)
+		require.Nil(t, xml.Unmarshal(content, new(interface{})))
+		require.Contains(t, string(content), FacturXGuidelineId)
+		require.Contains(t, string(content), "<ram:DuePayableAmount>23.80</ram:DuePayableAmount>")
+	})
+	t.Run("Unsupported", func(t *testing.T) {
+		_, err := Render(doc, "pdf")
+		require.NotNil(t, err)
+	})
+}
+
+func TestAppendFacturX(t *testing.T) {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.AddPage()
+	var buffer bytes.Buffer
+	require.Nil(t, pdf.Output(&buffer))
+	doc := NewDocument(testInvoice(), testMerchant(), testUser(), nil)
+	xmlContent, err := RenderCII(doc)
+	require.Nil(t, err)
+	content, err := AppendFacturX(buffer.Bytes(), doc, xmlContent)
+	require.Nil(t, err)
+	require.True(t, bytes.HasPrefix(content, buffer.Bytes()))
+	appended := string(content[buffer.Len():])
+	require.Contains(t, appended, "/AFRelationship /Data")
+	require.Contains(t, appended, "<pdfaid:part>3</pdfaid:part>")
+	require.Contains(t, appended, "/Prev ")
+	require.True(t, strings.HasSuffix(appended, "%%EOF\n"))
+	_, err = AppendFacturX([]byte("not a pdf"), doc, xmlContent)
+	require.NotNil(t, err)
+}
@@ -0,0 +1,153 @@
+package einvoice
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+const (
+	FacturXFilename  = "factur-x.xml"
+	facturXNamespace = "urn:factur-x:pdfa:CrossIndustryDocument:invoice:1p0#"
+)
+
+// facturXMetadata declares PDF/A-3B conformance and the Factur-X extension schema readers use to locate the embedded xml
+func facturXMetadata(doc *Document) []byte {
+	return []byte(fmt.Sprintf(`<?xpacket begin="" id="W5M0MpCehiHzreSzNTczkc9d"?>
+<x:xmpmeta xmlns:x="adobe:ns:meta/">
+<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">
+<rdf:Description rdf:about="" xmlns:pdfaid="http://www.aiim.org/pdfa/ns/id/">
+<pdfaid:part>3</pdfaid:part>
+<pdfaid:conformance>B</pdfaid:conformance>
+</rdf:Description>
+<rdf:Description rdf:about="" xmlns:dc="http://purl.org/dc/elements/1.1/">
+<dc:title><rdf:Alt><rdf:li xml:lang="x-default">%s</rdf:li></rdf:Alt></dc:title>
+</rdf:Description>
+<rdf:Description rdf:about="" xmlns:fx="%s">
+<fx:DocumentType>INVOICE</fx:DocumentType>
+<fx:DocumentFileName>%s</fx:DocumentFileName>
+<fx:Version>1.0</fx:Version>
+<fx:ConformanceLevel>EN 16931</fx:ConformanceLevel>
+</rdf:Description>
+<rdf:Description rdf:about="" xmlns:pdfaExtension="http://www.aiim.org/pdfa/ns/extension/" xmlns:pdfaSchema="http://www.aiim.org/pdfa/ns/schema#" xmlns:pdfaProperty="http://www.aiim.org/pdfa/ns/property#">
+<pdfaExtension:schemas><rdf:Bag><rdf:li rdf:parseType="Resource">
+<pdfaSchema:schema>Factur-X PDFA Extension Schema</pdfaSchema:schema>
+<pdfaSchema:namespaceURI>%s</pdfaSchema:namespaceURI>
+<pdfaSchema:prefix>fx</pdfaSchema:prefix>
+<pdfaSchema:property><rdf:Seq>
+<rdf:li rdf:parseType="Resource"><pdfaProperty:name>DocumentFileName</pdfaProperty:name><pdfaProperty:valueType>Text</pdfaProperty:valueType><pdfaProperty:category>external</pdfaProperty:category><pdfaProperty:description>name of the embedded XML invoice file</pdfaProperty:description></rdf:li>
+<rdf:li rdf:parseType="Resource"><pdfaProperty:name>DocumentType</pdfaProperty:name><pdfaProperty:valueType>Text</pdfaProperty:valueType><pdfaProperty:category>external</pdfaProperty:category><pdfaProperty:description>INVOICE</pdfaProperty:description></rdf:li>
+<rdf:li rdf:parseType="Resource"><pdfaProperty:name>Version</pdfaProperty:name><pdfaProperty:valueType>Text</pdfaProperty:valueType><pdfaProperty:category>external</pdfaProperty:category><pdfaProperty:description>The actual version of the Factur-X XML schema</pdfaProperty:description></rdf:li>
+<rdf:li rdf:parseType="Resource"><pdfaProperty:name>ConformanceLevel</pdfaProperty:name><pdfaProperty:valueType>Text</pdfaProperty:valueType><pdfaProperty:category>external</pdfaProperty:category><pdfaProperty:description>The conformance level of the embedded Factur-X data</pdfaProperty:description></rdf:li>
+</rdf:Seq></pdfaSchema:property>
+</rdf:li></rdf:Bag></pdfaExtension:schemas>
+</rdf:Description>
+</rdf:RDF>
+</x:xmpmeta>
+<?xpacket end="w"?>`, xmlEscape(doc.Number), facturXNamespace, FacturXFilename, facturXNamespace))
+}
+
+func xmlEscape(value string) string {
+	var buffer bytes.Buffer
+	_ = xml.EscapeText(&buffer, []byte(value))
+	return buffer.String()
+}
+
+var (
+	trailerSizeRegex  = regexp.MustCompile(`/Size (\d+)`)
+	trailerRootRegex  = regexp.MustCompile(`/Root (\d+) 0 R`)
+	trailerInfoRegex  = regexp.MustCompile(`/Info (\d+) 0 R`)
+	startXrefRegex    = regexp.MustCompile(`startxref\s+(\d+)\s+%%EOF\s*$`)
+	javascriptRegex   = regexp.MustCompile(`/JavaScript \d+ 0 R`)
+	catalogNamesMarks = []byte("\n/Names <<")
+)
+
+// AppendFacturX embeds the CII xml into the PDF as factur-x.xml with an incremental update, the appended revision
+// carries the associated file (/AF) relation, the PDF/A-3 XMP metadata and the document ID that fpdf does not write.
+// Colour profiles (OutputIntent) are not added, strict PDF/A validators still report them while Factur-X readers accept the file
+func AppendFacturX(content []byte, doc *Document, xmlContent []byte) ([]byte, error) {
+	tail := content
+	if len(tail) > 1024 {
+		tail = content[len(content)-1024:]
+	}
+	startXref := startXrefRegex.FindSubmatch(tail)
+	size := trailerSizeRegex.FindSubmatch(tail)
+	root := trailerRootRegex.FindSubmatch(tail)
+	info := trailerInfoRegex.FindSubmatch(tail)
+	if startXref == nil || size == nil || root == nil || info == nil {
+		return nil, gerror.New("pdf trailer not found")
+	}
+	previous, _ := strconv.Atoi(string(startXref[1]))
+	nextId, _ := strconv.Atoi(string(size[1]))
+	rootId, _ := strconv.Atoi(string(root[1]))
+	catalogStart := bytes.LastIndex(content, []byte(fmt.Sprintf("\n%d 0 obj\n<<\n", rootId)))
+	if catalogStart < 0 {
+		return nil, gerror.New("pdf catalog not found")
+	}
+	catalogBody := content[catalogStart+len(fmt.Sprintf("\n%d 0 obj\n<<\n", rootId)):]
+	if end := bytes.Index(catalogBody, []byte("\nendobj")); end > 0 {
+		catalogBody = catalogBody[:end]
+	}
+	namesIndex := bytes.Index(catalogBody, catalogNamesMarks)
+	if namesIndex < 0 {
+		return nil, gerror.New("pdf catalog names not found")
+	}
+	javascript := javascriptRegex.Find(catalogBody[namesIndex:])
+
+	var (
+		streamId   = nextId
+		filespecId = nextId + 1
+		xmpId      = nextId + 2
+		offsets    = make(map[int]int)
+		buffer     = bytes.NewBuffer(make([]byte, 0, len(content)+len(xmlContent)+8192))
+		modDate    = time.Now().UTC().Format("20060102150405")
+		sum        = md5.Sum(xmlContent)
+	)
+	buffer.Write(content)
+	if !bytes.HasSuffix(content, []byte("\n")) {
+		buffer.WriteString("\n")
+	}
+	offsets[streamId] = buffer.Len()
+	_, _ = fmt.Fprintf(buffer, "%d 0 obj\n<< /Type /EmbeddedFile /Subtype /text#2Fxml /Length %d /Params << /Size %d /ModDate (D:%s) /CheckSum <%s> >> >>\nstream\n", streamId, len(xmlContent), len(xmlContent), modDate, hex.EncodeToString(sum[:]))
+	buffer.Write(xmlContent)
+	buffer.WriteString("\nendstream\nendobj\n")
+
+	offsets[filespecId] = buffer.Len()
+	_, _ = fmt.Fprintf(buffer, "%d 0 obj\n<< /Type /Filespec /F (%s) /UF (%s) /EF << /F %d 0 R /UF %d 0 R >> /AFRelationship /Data /Desc (Factur-X Invoice) >>\nendobj\n", filespecId, FacturXFilename, FacturXFilename, streamId, streamId)
+
+	metadata := facturXMetadata(doc)
+	offsets[xmpId] = buffer.Len()
+	_, _ = fmt.Fprintf(buffer, "%d 0 obj\n<< /Type /Metadata /Subtype /XML /Length %d >>\nstream\n", xmpId, len(metadata))
+	buffer.Write(metadata)
+	buffer.WriteString("\nendstream\nendobj\n")
+
+	offsets[rootId] = buffer.Len()
+	_, _ = fmt.Fprintf(buffer, "%d 0 obj\n<<\n", rootId)
+	buffer.Write(catalogBody[:namesIndex])
+	buffer.WriteString("\n/Names <<")
+	if javascript != nil {
+		buffer.WriteString("\n")
+		buffer.Write(javascript)
+	}
+	_, _ = fmt.Fprintf(buffer, "\n/EmbeddedFiles << /Names [(%s) %d 0 R] >>\n>>\n", FacturXFilename, filespecId)
+	_, _ = fmt.Fprintf(buffer, "/Metadata %d 0 R\n/AF [%d 0 R]\n/Version /1.7\n>>\nendobj\n", xmpId, filespecId)
+
+	xref := buffer.Len()
+	buffer.WriteString("xref\n")
+	_, _ = fmt.Fprintf(buffer, "%d 1\n%010d 00000 n \n", rootId, offsets[rootId])
+	_, _ = fmt.Fprintf(buffer, "%d 3\n", streamId)
+	for _, id := range []int{streamId, filespecId, xmpId} {
+		_, _ = fmt.Fprintf(buffer, "%010d 00000 n \n", offsets[id])
+	}
+	documentId := md5.Sum(content)
+	_, _ = fmt.Fprintf(buffer, "trailer\n<<\n/Size %d\n/Root %d 0 R\n/Info %s 0 R\n/Prev %d\n/ID [<%s> <%s>]\n>>\nstartxref\n%d\n%%%%EOF\n",
+		xmpId+1, rootId, info[1], previous, hex.EncodeToString(documentId[:]), hex.EncodeToString(documentId[:]), xref)
+	return buffer.Bytes(), nil
+}
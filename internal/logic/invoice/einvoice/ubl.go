@@ -0,0 +1,352 @@
+package einvoice
+
+import (
+	"encoding/xml"
+)
+
+const (
+	PeppolCustomizationId  = "urn:cen.eu:en16931:2017#compliant#urn:fdc:peppol.eu:2017:poacc:billing:3.0"
+	PeppolProfileId        = "urn:fdc:peppol.eu:2017:poacc:billing:01:1.0"
+	ublInvoiceNamespace    = "urn:oasis:names:specification:ubl:schema:xsd:Invoice-2"
+	ublCreditNoteNamespace = "urn:oasis:names:specification:ubl:schema:xsd:CreditNote-2"
+	ublCacNamespace        = "urn:oasis:names:specification:ubl:schema:xsd:CommonAggregateComponents-2"
+	ublCbcNamespace        = "urn:oasis:names:specification:ubl:schema:xsd:CommonBasicComponents-2"
+)
+
+// UBL elements are declared with their prefixed names, the namespaces are bound on the root element
+
+type ublAmount struct {
+	CurrencyId string `xml:"currencyID,attr"`
+	Value      string `xml:",chardata"`
+}
+
+type ublIdentifier struct {
+	SchemeId string `xml:"schemeID,attr,omitempty"`
+	Value    string `xml:",chardata"`
+}
+
+type ublQuantity struct {
+	UnitCode string `xml:"unitCode,attr"`
+	Value    int64  `xml:",chardata"`
+}
+
+type ublTaxScheme struct {
+	Id string `xml:"cbc:ID"`
+}
+
+type ublTaxCategory struct {
+	Id                 string       `xml:"cbc:ID"`
+	Percent            string       `xml:"cbc:Percent,omitempty"`
+	TaxExemptionReason string       `xml:"cbc:TaxExemptionReason,omitempty"`
+	TaxScheme          ublTaxScheme `xml:"cac:TaxScheme"`
+}
+
+type ublPeriod struct {
+	StartDate string `xml:"cbc:StartDate,omitempty"`
+	EndDate   string `xml:"cbc:EndDate,omitempty"`
+}
+
+type ublBillingReference struct {
+	InvoiceDocumentReference struct {
+		Id        string `xml:"cbc:ID"`
+		IssueDate string `xml:"cbc:IssueDate,omitempty"`
+	} `xml:"cac:InvoiceDocumentReference"`
+}
+
+type ublCountry struct {
+	IdentificationCode string `xml:"cbc:IdentificationCode"`
+}
+
+type ublPostalAddress struct {
+	StreetName string     `xml:"cbc:StreetName,omitempty"`
+	CityName   string     `xml:"cbc:CityName,omitempty"`
+	PostalZone string     `xml:"cbc:PostalZone,omitempty"`
+	Country    ublCountry `xml:"cac:Country"`
+}
+
+type ublPartyTaxScheme struct {
+	CompanyId string       `xml:"cbc:CompanyID"`
+	TaxScheme ublTaxScheme `xml:"cac:TaxScheme"`
+}
+
+type ublPartyLegalEntity struct {
+	RegistrationName string `xml:"cbc:RegistrationName"`
+	CompanyId        string `xml:"cbc:CompanyID,omitempty"`
+}
+
+type ublContact struct {
+	ElectronicMail string `xml:"cbc:ElectronicMail,omitempty"`
+}
+
+type ublPartyName struct {
+	Name string `xml:"cbc:Name"`
+}
+
+type ublParty struct {
+	EndpointId     ublIdentifier       `xml:"cbc:EndpointID"`
+	PartyName      *ublPartyName       `xml:"cac:PartyName,omitempty"`
+	PostalAddress  ublPostalAddress    `xml:"cac:PostalAddress"`
+	PartyTaxScheme *ublPartyTaxScheme  `xml:"cac:PartyTaxScheme,omitempty"`
+	LegalEntity    ublPartyLegalEntity `xml:"cac:PartyLegalEntity"`
+	Contact        *ublContact         `xml:"cac:Contact,omitempty"`
+}
+
+type ublPartyWrapper struct {
+	Party ublParty `xml:"cac:Party"`
+}
+
+type ublBranch struct {
+	Id string `xml:"cbc:ID"`
+}
+
+type ublFinancialAccount struct {
+	Id                         string     `xml:"cbc:ID"`
+	FinancialInstitutionBranch *ublBranch `xml:"cac:FinancialInstitutionBranch,omitempty"`
+}
+
+type ublPaymentMeans struct {
+	PaymentMeansCode      string               `xml:"cbc:PaymentMeansCode"`
+	PaymentId             string               `xml:"cbc:PaymentID,omitempty"`
+	PayeeFinancialAccount *ublFinancialAccount `xml:"cac:PayeeFinancialAccount,omitempty"`
+}
+
+type ublPaymentTerms struct {
+	Note string `xml:"cbc:Note"`
+}
+
+type ublAllowanceCharge struct {
+	ChargeIndicator       bool           `xml:"cbc:ChargeIndicator"`
+	AllowanceChargeReason string         `xml:"cbc:AllowanceChargeReason,omitempty"`
+	Amount                ublAmount      `xml:"cbc:Amount"`
+	TaxCategory           ublTaxCategory `xml:"cac:TaxCategory"`
+}
+
+type ublTaxSubtotal struct {
+	TaxableAmount ublAmount      `xml:"cbc:TaxableAmount"`
+	TaxAmount     ublAmount      `xml:"cbc:TaxAmount"`
+	TaxCategory   ublTaxCategory `xml:"cac:TaxCategory"`
+}
+
+type ublTaxTotal struct {
+	TaxAmount   ublAmount        `xml:"cbc:TaxAmount"`
+	TaxSubtotal []ublTaxSubtotal `xml:"cac:TaxSubtotal"`
+}
+
+type ublMonetaryTotal struct {
+	LineExtensionAmount   ublAmount  `xml:"cbc:LineExtensionAmount"`
+	TaxExclusiveAmount    ublAmount  `xml:"cbc:TaxExclusiveAmount"`
+	TaxInclusiveAmount    ublAmount  `xml:"cbc:TaxInclusiveAmount"`
+	AllowanceTotalAmount  *ublAmount `xml:"cbc:AllowanceTotalAmount,omitempty"`
+	ChargeTotalAmount     *ublAmount `xml:"cbc:ChargeTotalAmount,omitempty"`
+	PrepaidAmount         *ublAmount `xml:"cbc:PrepaidAmount,omitempty"`
+	PayableRoundingAmount *ublAmount `xml:"cbc:PayableRoundingAmount,omitempty"`
+	PayableAmount         ublAmount  `xml:"cbc:PayableAmount"`
+}
+
+type ublItem struct {
+	Description           string         `xml:"cbc:Description,omitempty"`
+	Name                  string         `xml:"cbc:Name"`
+	ClassifiedTaxCategory ublTaxCategory `xml:"cac:ClassifiedTaxCategory"`
+}
+
+type ublPrice struct {
+	PriceAmount ublAmount `xml:"cbc:PriceAmount"`
+}
+
+type ublLine struct {
+	Id                  string       `xml:"cbc:ID"`
+	InvoicedQuantity    *ublQuantity `xml:"cbc:InvoicedQuantity,omitempty"`
+	CreditedQuantity    *ublQuantity `xml:"cbc:CreditedQuantity,omitempty"`
+	LineExtensionAmount ublAmount    `xml:"cbc:LineExtensionAmount"`
+	InvoicePeriod       *ublPeriod   `xml:"cac:InvoicePeriod,omitempty"`
+	Item                ublItem      `xml:"cac:Item"`
+	Price               ublPrice     `xml:"cac:Price"`
+}
+
+type ublDocument struct {
+	XMLName                 xml.Name
+	Xmlns                   string               `xml:"xmlns,attr"`
+	XmlnsCac                string               `xml:"xmlns:cac,attr"`
+	XmlnsCbc                string               `xml:"xmlns:cbc,attr"`
+	CustomizationId         string               `xml:"cbc:CustomizationID"`
+	ProfileId               string               `xml:"cbc:ProfileID"`
+	Id                      string               `xml:"cbc:ID"`
+	IssueDate               string               `xml:"cbc:IssueDate"`
+	DueDate                 string               `xml:"cbc:DueDate,omitempty"`
+	InvoiceTypeCode         string               `xml:"cbc:InvoiceTypeCode,omitempty"`
+	CreditNoteTypeCode      string               `xml:"cbc:CreditNoteTypeCode,omitempty"`
+	Note                    string               `xml:"cbc:Note,omitempty"`
+	DocumentCurrencyCode    string               `xml:"cbc:DocumentCurrencyCode"`
+	BuyerReference          string               `xml:"cbc:BuyerReference,omitempty"`
+	InvoicePeriod           *ublPeriod           `xml:"cac:InvoicePeriod,omitempty"`
+	BillingReference        *ublBillingReference `xml:"cac:BillingReference,omitempty"`
+	AccountingSupplierParty ublPartyWrapper      `xml:"cac:AccountingSupplierParty"`
+	AccountingCustomerParty ublPartyWrapper      `xml:"cac:AccountingCustomerParty"`
+	PaymentMeans            *ublPaymentMeans     `xml:"cac:PaymentMeans,omitempty"`
+	PaymentTerms            *ublPaymentTerms     `xml:"cac:PaymentTerms,omitempty"`
+	AllowanceCharge         []ublAllowanceCharge `xml:"cac:AllowanceCharge,omitempty"`
+	TaxTotal                ublTaxTotal          `xml:"cac:TaxTotal"`
+	LegalMonetaryTotal      ublMonetaryTotal     `xml:"cac:LegalMonetaryTotal"`
+	InvoiceLine             []ublLine            `xml:"cac:InvoiceLine,omitempty"`
+	CreditNoteLine          []ublLine            `xml:"cac:CreditNoteLine,omitempty"`
+}
+
+func (doc *Document) ublAmount(cents int64) ublAmount {
+	return ublAmount{CurrencyId: doc.Currency, Value: formatAmount(cents, doc.Currency)}
+}
+
+func (doc *Document) ublOptionalAmount(cents int64) *ublAmount {
+	if cents == 0 {
+		return nil
+	}
+	amount := doc.ublAmount(cents)
+	return &amount
+}
+
+func (doc *Document) ublTaxCategory() ublTaxCategory {
+	return ublTaxCategory{
+		Id:                 doc.VatCategory,
+		Percent:            formatPercentage(doc.VatPercentage),
+		TaxExemptionReason: doc.VatExemptionReason,
+		TaxScheme:          ublTaxScheme{Id: "VAT"},
+	}
+}
+
+func ublPeriodOf(start int64, end int64) *ublPeriod {
+	if start <= 0 && end <= 0 {
+		return nil
+	}
+	return &ublPeriod{StartDate: formatDate(start), EndDate: formatDate(end)}
+}
+
+func (party *Party) ubl() ublPartyWrapper {
+	one := ublParty{
+		EndpointId: ublIdentifier{SchemeId: party.EndpointScheme, Value: party.EndpointId},
+		PostalAddress: ublPostalAddress{
+			StreetName: party.Address,
+			CityName:   party.City,
+			PostalZone: party.PostalCode,
+			Country:    ublCountry{IdentificationCode: party.CountryCode},
+		},
+		LegalEntity: ublPartyLegalEntity{
+			RegistrationName: party.Name,
+			CompanyId:        party.RegistrationNumber,
+		},
+	}
+	if len(party.Name) > 0 {
+		one.PartyName = &ublPartyName{Name: party.Name}
+	}
+	if len(party.VatNumber) > 0 {
+		one.PartyTaxScheme = &ublPartyTaxScheme{CompanyId: party.VatNumber, TaxScheme: ublTaxScheme{Id: "VAT"}}
+	}
+	if len(party.Email) > 0 {
+		one.Contact = &ublContact{ElectronicMail: party.Email}
+	}
+	return ublPartyWrapper{Party: one}
+}
+
+// RenderUBL renders the document as UBL 2.1 Invoice or CreditNote following Peppol BIS Billing 3.0
+func RenderUBL(doc *Document) ([]byte, error) {
+	one := &ublDocument{
+		XmlnsCac:                ublCacNamespace,
+		XmlnsCbc:                ublCbcNamespace,
+		CustomizationId:         PeppolCustomizationId,
+		ProfileId:               PeppolProfileId,
+		Id:                      doc.Number,
+		IssueDate:               formatDate(doc.IssueTime),
+		Note:                    doc.Note,
+		DocumentCurrencyCode:    doc.Currency,
+		BuyerReference:          doc.BuyerReference,
+		InvoicePeriod:           ublPeriodOf(doc.PeriodStart, doc.PeriodEnd),
+		AccountingSupplierParty: doc.Seller.ubl(),
+		AccountingCustomerParty: doc.Buyer.ubl(),
+		TaxTotal: ublTaxTotal{
+			TaxAmount: doc.ublAmount(doc.TaxAmount),
+			TaxSubtotal: []ublTaxSubtotal{{
+				TaxableAmount: doc.ublAmount(doc.TaxExclusiveAmount),
+				TaxAmount:     doc.ublAmount(doc.TaxAmount),
+				TaxCategory:   doc.ublTaxCategory(),
+			}},
+		},
+		LegalMonetaryTotal: ublMonetaryTotal{
+			LineExtensionAmount:   doc.ublAmount(doc.LineTotalAmount),
+			TaxExclusiveAmount:    doc.ublAmount(doc.TaxExclusiveAmount),
+			TaxInclusiveAmount:    doc.ublAmount(doc.TaxInclusiveAmount),
+			AllowanceTotalAmount:  doc.ublOptionalAmount(doc.AllowanceTotalAmount),
+			ChargeTotalAmount:     doc.ublOptionalAmount(doc.ChargeTotalAmount),
+			PrepaidAmount:         doc.ublOptionalAmount(doc.PrepaidAmount),
+			PayableRoundingAmount: doc.ublOptionalAmount(doc.RoundingAmount),
+			PayableAmount:         doc.ublAmount(doc.PayableAmount),
+		},
+	}
+	if doc.IsCreditNote() {
+		one.XMLName = xml.Name{Local: "CreditNote"}
+		one.Xmlns = ublCreditNoteNamespace
+		one.CreditNoteTypeCode = doc.TypeCode
+		if len(doc.PrecedingNumber) > 0 {
+			one.BillingReference = &ublBillingReference{}
+			one.BillingReference.InvoiceDocumentReference.Id = doc.PrecedingNumber
+			one.BillingReference.InvoiceDocumentReference.IssueDate = formatDate(doc.PrecedingIssueTime)
+		}
+	} else {
+		one.XMLName = xml.Name{Local: "Invoice"}
+		one.Xmlns = ublInvoiceNamespace
+		one.InvoiceTypeCode = doc.TypeCode
+		one.DueDate = formatDate(doc.DueTime)
+	}
+	if len(doc.PaymentMeansCode) > 0 {
+		one.PaymentMeans = &ublPaymentMeans{PaymentMeansCode: doc.PaymentMeansCode, PaymentId: doc.Number}
+		if len(doc.PayeeIBAN) > 0 {
+			one.PaymentMeans.PayeeFinancialAccount = &ublFinancialAccount{Id: doc.PayeeIBAN}
+			if len(doc.PayeeBIC) > 0 {
+				one.PaymentMeans.PayeeFinancialAccount.FinancialInstitutionBranch = &ublBranch{Id: doc.PayeeBIC}
+			}
+		}
+	}
+	if len(doc.PaymentTerms) > 0 {
+		one.PaymentTerms = &ublPaymentTerms{Note: doc.PaymentTerms}
+	}
+	if doc.AllowanceTotalAmount > 0 {
+		one.AllowanceCharge = append(one.AllowanceCharge, ublAllowanceCharge{
+			ChargeIndicator:       false,
+			AllowanceChargeReason: doc.DocumentAllowanceText,
+			Amount:                doc.ublAmount(doc.AllowanceTotalAmount),
+			TaxCategory:           doc.ublTaxCategory(),
+		})
+	}
+	if doc.ChargeTotalAmount > 0 {
+		one.AllowanceCharge = append(one.AllowanceCharge, ublAllowanceCharge{
+			ChargeIndicator:       true,
+			AllowanceChargeReason: "Charge",
+			Amount:                doc.ublAmount(doc.ChargeTotalAmount),
+			TaxCategory:           doc.ublTaxCategory(),
+		})
+	}
+	for _, line := range doc.Lines {
+		quantity := &ublQuantity{UnitCode: line.UnitCode, Value: line.Quantity}
+		item := ublLine{
+			Id:                  line.Id,
+			LineExtensionAmount: doc.ublAmount(line.NetAmount),
+			InvoicePeriod:       ublPeriodOf(line.PeriodStart, line.PeriodEnd),
+			Item: ublItem{
+				Description:           line.Description,
+				Name:                  line.Name,
+				ClassifiedTaxCategory: doc.ublTaxCategory(),
+			},
+			Price: ublPrice{PriceAmount: doc.ublAmount(line.PriceAmount)},
+		}
+		item.Item.ClassifiedTaxCategory.TaxExemptionReason = ""
+		if doc.IsCreditNote() {
+			item.CreditedQuantity = quantity
+			one.CreditNoteLine = append(one.CreditNoteLine, item)
+		} else {
+			item.InvoicedQuantity = quantity
+			one.InvoiceLine = append(one.InvoiceLine, item)
+		}
+	}
+	content, err := xml.MarshalIndent(one, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), content...), nil
+}
@@ -0,0 +1,107 @@
+package einvoice
+
+import (
+	"fmt"
+)
+
+const (
+	FlagFatal   = "fatal"
+	FlagWarning = "warning"
+)
+
+// ValidationResult is one failed business rule, named after the EN16931 and Peppol BIS 3.0 schematron rule ids
+type ValidationResult struct {
+	Rule    string `json:"rule"    description:"Schematron rule id, eg BR-CO-15"`
+	Flag    string `json:"flag"    description:"fatal|warning"`
+	Message string `json:"message" description:"Message"`
+}
+
+// vatTolerance is the rounding tolerance of VAT amount checks in cents
+const vatTolerance = 1
+
+type validator struct {
+	results []*ValidationResult
+}
+
+func (v *validator) check(ok bool, rule string, flag string, message string) {
+	if !ok {
+		v.results = append(v.results, &ValidationResult{Rule: rule, Flag: flag, Message: message})
+	}
+}
+
+func abs(value int64) int64 {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
+
+// Validate runs the subset of EN16931 and Peppol BIS Billing 3.0 schematron rules the document mapping can break,
+// the document is only valid for exchange when no fatal result is returned
+func Validate(doc *Document) []*ValidationResult {
+	v := &validator{results: make([]*ValidationResult, 0)}
+	if doc == nil {
+		v.check(false, "BR-01", FlagFatal, "document not found")
+		return v.results
+	}
+	v.check(len(doc.Number) > 0, "BR-02", FlagFatal, "An Invoice shall have an Invoice number (BT-1)")
+	v.check(doc.IssueTime > 0, "BR-03", FlagFatal, "An Invoice shall have an Invoice issue date (BT-2)")
+	v.check(doc.TypeCode == TypeCodeInvoice || doc.TypeCode == TypeCodeCreditNote, "BR-04", FlagFatal, "An Invoice shall have an Invoice type code (BT-3)")
+	v.check(len(doc.Currency) == 3, "BR-05", FlagFatal, "An Invoice shall have an Invoice currency code (BT-5)")
+	v.check(doc.Seller != nil && len(doc.Seller.Name) > 0, "BR-06", FlagFatal, "An Invoice shall contain the Seller name (BT-27)")
+	v.check(doc.Buyer != nil && len(doc.Buyer.Name) > 0, "BR-07", FlagFatal, "An Invoice shall contain the Buyer name (BT-44)")
+	v.check(doc.Seller != nil && len(doc.Seller.Address) > 0, "BR-08", FlagFatal, "An Invoice shall contain the Seller postal address (BG-5)")
+	v.check(doc.Seller != nil && len(doc.Seller.CountryCode) == 2, "BR-09", FlagFatal, "The Seller postal address (BG-5) shall contain a Seller country code (BT-40)")
+	v.check(doc.Buyer != nil && len(doc.Buyer.CountryCode) == 2, "BR-11", FlagFatal, "The Buyer postal address shall contain a Buyer country code (BT-55)")
+	v.check(len(doc.Lines) > 0, "BR-16", FlagFatal, "An Invoice shall have at least one Invoice line (BG-25)")
+	for _, line := range doc.Lines {
+		v.check(len(line.Id) > 0, "BR-21", FlagFatal, "Each Invoice line (BG-25) shall have an Invoice line identifier (BT-126)")
+		v.check(line.Quantity != 0, "BR-22", FlagFatal, fmt.Sprintf("Invoice line %s shall have an Invoiced quantity (BT-129)", line.Id))
+		v.check(len(line.UnitCode) > 0, "BR-23", FlagFatal, fmt.Sprintf("Invoice line %s shall have an Invoiced quantity unit of measure code (BT-130)", line.Id))
+		v.check(len(line.Name) > 0, "BR-25", FlagFatal, fmt.Sprintf("Invoice line %s shall contain the Item name (BT-153)", line.Id))
+		v.check(line.PriceAmount >= 0, "BR-27", FlagFatal, fmt.Sprintf("The Item net price (BT-146) of line %s shall NOT be negative", line.Id))
+		v.check(abs(line.PriceAmount*line.Quantity-line.NetAmount) <= vatTolerance*line.Quantity, "PEPPOL-EN16931-R120", FlagFatal, fmt.Sprintf("Invoice line %s net amount shall equal quantity times net price", line.Id))
+	}
+	var lineTotal int64 = 0
+	for _, line := range doc.Lines {
+		lineTotal = lineTotal + line.NetAmount
+	}
+	v.check(lineTotal == doc.LineTotalAmount, "BR-CO-10", FlagFatal, "Sum of Invoice line net amount (BT-106) = Σ Invoice line net amount (BT-131)")
+	v.check(doc.TaxExclusiveAmount == doc.LineTotalAmount-doc.AllowanceTotalAmount+doc.ChargeTotalAmount, "BR-CO-13", FlagFatal, "Invoice total amount without VAT (BT-109) = Σ Invoice line net amount (BT-131) - Sum of allowances on document level (BT-107) + Sum of charges on document level (BT-108)")
+	v.check(doc.TaxInclusiveAmount == doc.TaxExclusiveAmount+doc.TaxAmount, "BR-CO-15", FlagFatal, "Invoice total amount with VAT (BT-112) = Invoice total amount without VAT (BT-109) + Invoice total VAT amount (BT-110)")
+	v.check(doc.PayableAmount == doc.TaxInclusiveAmount-doc.PrepaidAmount+doc.RoundingAmount, "BR-CO-16", FlagFatal, "Amount due for payment (BT-115) = Invoice total amount with VAT (BT-112) - Paid amount (BT-113) + Rounding amount (BT-114)")
+	v.check(doc.DueTime > 0 || len(doc.PaymentTerms) > 0 || doc.PayableAmount <= 0, "BR-CO-25", FlagFatal, "In case the Amount due for payment (BT-115) is positive, either the Payment due date (BT-9) or the Payment terms (BT-20) shall be present")
+	switch doc.VatCategory {
+	case VatCategoryStandard:
+		v.check(doc.Seller != nil && len(doc.Seller.VatNumber) > 0, "BR-S-02", FlagFatal, "An Invoice that contains a VAT breakdown with Standard rated VAT shall contain the Seller VAT Identifier (BT-31)")
+		v.check(doc.VatPercentage > 0, "BR-S-05", FlagFatal, "In an Invoice line where the VAT category code is Standard rated the VAT rate shall be greater than zero")
+		v.check(abs(doc.TaxExclusiveAmount*doc.VatPercentage/10000-doc.TaxAmount) <= vatTolerance, "BR-S-09", FlagFatal, "The VAT category tax amount (BT-117) shall equal the VAT category taxable amount (BT-116) multiplied by the VAT category rate (BT-119)")
+	case VatCategoryReverse:
+		v.check(doc.Seller != nil && len(doc.Seller.VatNumber) > 0, "BR-AE-02", FlagFatal, "An Invoice that contains a Reverse charge VAT breakdown shall contain the Seller VAT Identifier (BT-31)")
+		v.check(doc.Buyer != nil && len(doc.Buyer.VatNumber) > 0, "BR-AE-02", FlagFatal, "An Invoice that contains a Reverse charge VAT breakdown shall contain the Buyer VAT identifier (BT-48)")
+		v.check(doc.TaxAmount == 0, "BR-AE-09", FlagFatal, "The VAT category tax amount (BT-117) in a Reverse charge VAT breakdown shall equal 0")
+	case VatCategoryExempt:
+		v.check(doc.TaxAmount == 0, "BR-E-09", FlagFatal, "The VAT category tax amount (BT-117) In a VAT breakdown where VAT category code (BT-118) is Exempt from VAT shall equal 0")
+		v.check(len(doc.VatExemptionReason) > 0, "BR-E-10", FlagFatal, "A VAT breakdown with VAT Category code (BT-118) Exempt from VAT shall have a VAT exemption reason code (BT-121) or a VAT exemption reason text (BT-120)")
+	case VatCategoryZero:
+		v.check(doc.TaxAmount == 0, "BR-Z-09", FlagFatal, "The VAT category tax amount (BT-117) in a Zero rated VAT breakdown shall equal 0")
+	default:
+		v.check(false, "BR-CO-18", FlagFatal, "An Invoice shall at least have one VAT breakdown group (BG-23)")
+	}
+	v.check(doc.Seller != nil && len(doc.Seller.EndpointId) > 0, "PEPPOL-EN16931-R020", FlagFatal, "Seller electronic address MUST be provided")
+	v.check(doc.Buyer != nil && len(doc.Buyer.EndpointId) > 0, "PEPPOL-EN16931-R010", FlagFatal, "Buyer electronic address MUST be provided")
+	if doc.IsCreditNote() {
+		v.check(len(doc.PrecedingNumber) > 0, "BR-55", FlagWarning, "Each Preceding Invoice reference (BG-3) shall contain a Preceding Invoice reference (BT-25)")
+	}
+	return v.results
+}
+
+// HasFatal reports whether any result blocks the document from exchange
+func HasFatal(results []*ValidationResult) bool {
+	for _, one := range results {
+		if one.Flag == FlagFatal {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+
+	redismq2 "unibee/internal/cmd/redismq"
+	"unibee/internal/consts"
+	dao "unibee/internal/dao/default"
+	entity "unibee/internal/model/entity/default"
+	"unibee/internal/query"
+	"unibee/utility"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+	redismq "github.com/jackyang-hk/go-redismq"
+)
+
+// MarkConsolidatedCycleInvoicesPaid marks the cycle invoices charged by the paid consolidated invoice as paid,
+// the invoice email is sent for the consolidated invoice only
+func MarkConsolidatedCycleInvoicesPaid(ctx context.Context, consolidated *entity.Invoice, payment *entity.Payment) []*entity.Invoice {
+	var list = make([]*entity.Invoice, 0)
+	for _, one := range query.GetInvoicesByConsolidatedInvoiceId(ctx, consolidated.InvoiceId) {
+		if one.Status != consts.InvoiceStatusPaid {
+			_, err := dao.Invoice.Ctx(ctx).Data(g.Map{
+				dao.Invoice.Columns().Status:           consts.InvoiceStatusPaid,
+				dao.Invoice.Columns().GatewayPaymentId: payment.GatewayPaymentId,
+				dao.Invoice.Columns().SendStatus:       consts.InvoiceSendStatusUnnecessary,
+				dao.Invoice.Columns().GmtModify:        gtime.Now(),
+			}).Where(dao.Invoice.Columns().Id, one.Id).OmitNil().Update()
+			if err != nil {
+				g.Log().Errorf(ctx, "MarkConsolidatedCycleInvoicesPaid invoiceId:%s consolidatedInvoiceId:%s err:%s", one.InvoiceId, consolidated.InvoiceId, err.Error())
+				continue
+			}
+			one.Status = consts.InvoiceStatusPaid
+			one.GatewayPaymentId = payment.GatewayPaymentId
+			if utility.TryLock(ctx, fmt.Sprintf("MarkConsolidatedCycleInvoicesPaid_%s", one.InvoiceId), 60) {
+				_, _ = redismq.Send(&redismq.Message{
+					Topic:                     redismq2.TopicInvoicePaid.Topic,
+					Tag:                       redismq2.TopicInvoicePaid.Tag,
+					ConsumerDelayMilliSeconds: 500,
+					Body:                      one.InvoiceId,
+					CustomData:                map[string]interface{}{"CreateFrom": utility.ReflectCurrentFunctionName(), "ConsolidatedInvoiceId": consolidated.InvoiceId},
+				})
+			}
+		}
+		list = append(list, one)
+	}
+	return list
+}
+
+// DetachConsolidatedInvoice releases the unpaid cycle invoices from the closed consolidated invoice, they are charged again by the billing cycle
+func DetachConsolidatedInvoice(ctx context.Context, consolidatedInvoiceId string) {
+	if len(consolidatedInvoiceId) == 0 {
+		return
+	}
+	_, err := dao.Invoice.Ctx(ctx).Data(g.Map{
+		dao.Invoice.Columns().ConsolidatedInvoiceId: "",
+		dao.Invoice.Columns().GmtModify:             gtime.Now(),
+	}).Where(dao.Invoice.Columns().ConsolidatedInvoiceId, consolidatedInvoiceId).
+		WhereNot(dao.Invoice.Columns().Status, consts.InvoiceStatusPaid).
+		Update()
+	if err != nil {
+		g.Log().Errorf(ctx, "DetachConsolidatedInvoice invoiceId:%s err:%s", consolidatedInvoiceId, err.Error())
+	}
+}
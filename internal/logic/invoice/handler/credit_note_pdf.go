@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"unibee/api/bean"
+	"unibee/api/bean/detail"
+	"unibee/internal/consts"
+	entity "unibee/internal/model/entity/default"
+	"unibee/internal/query"
+	"unibee/utility"
+
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+// GenerateCreditNotePdf renders the credit note in the credit layout of the invoice pdf and returns the local file path
+func GenerateCreditNotePdf(ctx context.Context, note *entity.CreditNote) string {
+	utility.Assert(note != nil, "credit note not found")
+	utility.Assert(note.MerchantId > 0, "invalid merchantId")
+	merchantInfo := query.GetMerchantById(ctx, note.MerchantId)
+	utility.Assert(merchantInfo != nil, "merchant not found")
+	user := query.GetUserAccountById(ctx, note.UserId)
+	original := query.GetInvoiceByInvoiceId(ctx, note.InvoiceId)
+	utility.Assert(original != nil, "credited invoice not found")
+	var savePath = fmt.Sprintf("%s.pdf", note.CreditNoteId)
+	err := createInvoicePdf(ctx, convertCreditNoteToInvoiceDetail(note, original), merchantInfo, user, query.GetGatewayById(ctx, original.GatewayId), GetMerchantInvoicePdfTemplate(ctx, note.MerchantId), savePath)
+	utility.AssertError(err, "createCreditNotePdf error:")
+	return savePath
+}
+
+func convertCreditNoteToInvoiceDetail(note *entity.CreditNote, original *entity.Invoice) *detail.InvoiceDetail {
+	var items []*bean.CreditNoteItem
+	_ = utility.UnmarshalFromJsonString(note.Lines, &items)
+	var lines = make([]*bean.InvoiceItemSimplify, 0)
+	var discountAmount int64 = 0
+	for _, creditItem := range items {
+		item := creditItem.InvoiceItemSimplify
+		item.Amount = -item.Amount
+		item.Tax = -item.Tax
+		item.AmountExcludingTax = -item.AmountExcludingTax
+		item.UnitAmountExcludingTax = -item.UnitAmountExcludingTax
+		item.DiscountAmount = -item.DiscountAmount
+		item.OriginAmount = -item.OriginAmount
+		discountAmount = discountAmount + item.DiscountAmount
+		lines = append(lines, &item)
+	}
+	var notes = []string{note.Reason}
+	var sendNote = "Partial Refund"
+	if note.Type == consts.CreditNoteTypeBalance {
+		notes = append(notes, "Credited to customer balance")
+		sendNote = "Balance Credit"
+	} else if note.TotalAmount == original.TotalAmount {
+		sendNote = "Full Refund"
+	}
+	var refundId = note.RefundId
+	if len(refundId) == 0 {
+		refundId = note.CreditNoteId
+	}
+	var metadata = make(map[string]interface{})
+	if len(original.MetaData) > 0 {
+		_ = utility.UnmarshalFromJsonString(original.MetaData, &metadata)
+	}
+	var gmtCreate = note.GmtCreate
+	if gmtCreate == nil {
+		gmtCreate = gtime.NewFromTimeStamp(note.CreateTime)
+	}
+	return &detail.InvoiceDetail{
+		MerchantId:                     note.MerchantId,
+		UserId:                         note.UserId,
+		SubscriptionId:                 note.SubscriptionId,
+		InvoiceName:                    "Credit Note",
+		ProductName:                    original.ProductName,
+		InvoiceId:                      note.CreditNoteId,
+		InvoiceNumber:                  note.CreditNoteNumber,
+		GmtCreate:                      gmtCreate,
+		GmtModify:                      gmtCreate,
+		OriginAmount:                   -note.TotalAmount + discountAmount,
+		TotalAmount:                    -note.TotalAmount,
+		DiscountAmount:                 discountAmount,
+		TaxAmount:                      -note.TaxAmount,
+		SubscriptionAmount:             -note.TotalAmount,
+		Currency:                       note.Currency,
+		Lines:                          lines,
+		GatewayId:                      original.GatewayId,
+		Status:                         consts.InvoiceStatusPaid,
+		TaxPercentage:                  original.TaxPercentage,
+		SendNote:                       fmt.Sprintf("%s (%s)", original.InvoiceId, sendNote),
+		TotalAmountExcludingTax:        -note.TotalAmountExcludingTax,
+		SubscriptionAmountExcludingTax: -note.TotalAmountExcludingTax,
+		PaymentId:                      note.PaymentId,
+		RefundId:                       refundId,
+		CreateFrom:                     strings.TrimSpace(strings.Join(notes, "\n")),
+		Metadata:                       metadata,
+		CountryCode:                    original.CountryCode,
+		VatNumber:                      original.VatNumber,
+		CreateTime:                     note.CreateTime,
+		OriginalPaymentInvoice:         bean.SimplifyInvoice(original),
+	}
+}
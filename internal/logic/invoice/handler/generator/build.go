@@ -14,6 +14,7 @@ import (
 	_ "image/png"
 	"os"
 	"path"
+	"strings"
 )
 
 func printPanic(ctx context.Context, err error) {
@@ -61,6 +62,10 @@ func (doc *Document) LoadSingleFont(familyStr, styleStr, fileStr, fontPath strin
 	_, err := os.Stat(path.Join(fontPath, fileStr))
 	if err == nil {
 		doc.pdf.AddUTF8Font(familyStr, styleStr, fileStr)
+		if doc.fonts == nil {
+			doc.fonts = make(map[string]bool)
+		}
+		doc.fonts[familyStr] = true
 	}
 	return err
 }
@@ -154,6 +159,9 @@ func (doc *Document) Build() (*fpdf.Fpdf, error) {
 	// Append payment term
 	doc.appendPaymentTerm()
 
+	// Append legal notes
+	doc.appendLegalNotes()
+
 	// Append js to auto print if AutoPrint == true
 	if doc.Options.AutoPrint {
 		doc.pdf.SetJavascript("print(true);")
@@ -163,7 +171,7 @@ func (doc *Document) Build() (*fpdf.Fpdf, error) {
 }
 
 func (doc *Document) appendLogo() float64 {
-	if doc.Logo != nil {
+	if doc.Logo != nil && doc.Options.LogoPosition != LogoPositionNone {
 		// Create filename
 		fileName := b64.StdEncoding.EncodeToString([]byte("unibee"))
 
@@ -182,7 +190,11 @@ func (doc *Document) appendLogo() float64 {
 			if imageInfo != nil {
 				var imageOpt fpdf.ImageOptions
 				imageOpt.ImageType = format
-				doc.pdf.ImageOptions(fileName, doc.pdf.GetX()+1, doc.pdf.GetY(), 0, 30, false, imageOpt, 0, "")
+				x := doc.pdf.GetX() + 1
+				if doc.Options.LogoPosition == LogoPositionCenter && imageInfo.Height() > 0 {
+					x = (210 - imageInfo.Width()*30/imageInfo.Height()) / 2
+				}
+				doc.pdf.ImageOptions(fileName, x, doc.pdf.GetY(), 0, 30, false, imageOpt, 0, "")
 				doc.pdf.SetY(doc.pdf.GetY() + 30)
 			}
 			return 30.0
@@ -197,9 +209,9 @@ func (doc *Document) appendTitle() {
 	title := doc.Title
 
 	doc.pdf.SetTextColor(
-		doc.Options.BaseTextColor[0],
-		doc.Options.BaseTextColor[1],
-		doc.Options.BaseTextColor[2],
+		doc.Options.TitleTextColor[0],
+		doc.Options.TitleTextColor[1],
+		doc.Options.TitleTextColor[2],
 	)
 	// Set x y
 	doc.pdf.SetXY(120, BaseMarginTop+3)
@@ -211,6 +223,7 @@ func (doc *Document) appendTitle() {
 	// Draw text
 	doc.pdf.SetFont(doc.Options.BoldFont, "B", 16)
 	doc.pdf.CellFormat(80, 0, doc.encodeString(title), "0", 0, "R", false, 0, "")
+	SetBaseTextColor(doc)
 }
 
 func (doc *Document) appendInvoiceHeader() {
@@ -285,7 +298,7 @@ func (doc *Document) appendInvoiceHeader() {
 			doc.Options.GreyTextColor[1],
 			doc.Options.GreyTextColor[2],
 		)
-		doc.pdf.CellFormat(40, 12, doc.encodeString(fmt.Sprintf("Invoice Type")), "0", 0, "L", false, 0, "")
+		doc.pdf.CellFormat(40, 12, doc.encodeString(doc.Options.TextInvoiceTypeTitle), "0", 0, "L", false, 0, "")
 		doc.pdf.SetXY(x+40, y)
 		doc.pdf.SetTextColor(
 			doc.Options.BaseTextColor[0],
@@ -344,11 +357,11 @@ func (doc *Document) appendInvoiceStatus(y float64) float64 {
 	SetBaseTextColor(doc)
 	doc.pdf.SetXY(110, startY)
 	doc.pdf.SetFont(doc.Options.BoldFont, "B", 10)
-	doc.pdf.CellFormat(80, 8, doc.encodeFitRefundString("Invoice status:"), "0", 0, "L", false, 0, "")
+	doc.pdf.CellFormat(80, 8, doc.encodeFitRefundString(doc.Options.TextInvoiceStatusTitle), "0", 0, "L", false, 0, "")
 
 	doc.pdf.SetXY(110, startY+4)
 	doc.pdf.SetFont(doc.Options.BoldFont, "B", 18)
-	if doc.Status == "Paid" {
+	if doc.Status == doc.Options.TextStatusPaid {
 		doc.pdf.SetTextColor(
 			doc.Options.PaidTextColor[0],
 			doc.Options.PaidTextColor[1],
@@ -413,113 +426,20 @@ func (doc *Document) drawsTableTitles(fontSize float64) {
 		"",
 	)
 
-	// Name
-	doc.pdf.SetX(ItemColNameOffset)
-	doc.pdf.CellFormat(
-		ItemColUnitPriceOffset-ItemColNameOffset,
-		12,
-		doc.encodeString(doc.Options.TextItemsNameTitle),
-		"0",
-		0,
-		"L",
-		false,
-		0,
-		"",
-	)
-
-	// Unit price
-	if ItemColQuantityOffset-ItemColUnitPriceOffset > 0 && doc.ShowDetailItem {
-		doc.pdf.SetX(ItemColUnitPriceOffset)
-		doc.pdf.CellFormat(
-			ItemColQuantityOffset-ItemColUnitPriceOffset,
-			12,
-			doc.encodeString(doc.Options.TextItemsUnitCostTitle),
-			"0",
-			0,
-			"C",
-			false,
-			0,
-			"",
-		)
-	}
-
-	// Quantity
-	if ItemColTotalHTOffset-ItemColQuantityOffset > 0 && doc.ShowDetailItem {
-		doc.pdf.SetX(ItemColQuantityOffset)
-		doc.pdf.CellFormat(
-			ItemColTotalHTOffset-ItemColQuantityOffset,
-			12,
-			doc.encodeString(doc.Options.TextItemsQuantityTitle),
-			"0",
-			0,
-			"C",
-			false,
-			0,
-			"",
-		)
-	}
-
-	// Total NoTax
-	if ItemColDiscountOffset-ItemColTotalHTOffset > 0 && doc.ShowDetailItem {
-		doc.pdf.SetX(ItemColTotalHTOffset)
-		doc.pdf.CellFormat(
-			ItemColDiscountOffset-ItemColTotalHTOffset,
-			12,
-			doc.encodeString(doc.Options.TextItemsTotalHTTitle),
-			"0",
-			0,
-			"",
-			false,
-			0,
-			"",
-		)
-	}
-
-	// Discount
-	if ItemColTaxOffset-ItemColDiscountOffset > 0 && doc.ShowDetailItem {
-		doc.pdf.SetX(ItemColDiscountOffset)
-		doc.pdf.CellFormat(
-			ItemColTaxOffset-ItemColDiscountOffset,
-			12,
-			doc.encodeString(doc.Options.TextItemsDiscountTitle),
-			"0",
-			0,
-			"",
-			false,
-			0,
-			"",
-		)
-	}
-
-	// Tax
-	if ItemColDiscountOffset-ItemColTaxOffset > 0 && doc.ShowDetailItem {
-		doc.pdf.SetX(ItemColTaxOffset)
+	for _, column := range doc.itemColumns() {
+		doc.pdf.SetX(column.offset)
 		doc.pdf.CellFormat(
-			ItemColTotalTTCOffset-ItemColDiscountOffset,
+			column.width,
 			12,
-			doc.encodeString(doc.Options.TextItemsTaxTitle),
+			doc.encodeString(column.title),
 			"0",
 			0,
-			"C",
+			column.align,
 			false,
 			0,
 			"",
 		)
 	}
-
-	// TOTAL TTC
-	doc.pdf.SetX(ItemColTotalTTCOffset)
-	doc.pdf.CellFormat(
-		190-ItemColTotalTTCOffset,
-		12,
-		doc.encodeString(doc.Options.TextItemsTotalTTCTitle),
-		"0",
-		0,
-		"L",
-		false,
-		0,
-		"",
-	)
 }
 
 // appendItems to document
@@ -585,7 +505,7 @@ func (doc *Document) appendNotes() {
 	doc.pdf.SetY(currentY + 20)
 	doc.pdf.SetFont(doc.Options.Font, "", 11)
 	SetGrayTextColor(doc)
-	doc.pdf.MultiCell(70, 5, doc.encodeString("Other details"), "0", "L", false)
+	doc.pdf.MultiCell(70, 5, doc.encodeString(doc.Options.TextOtherDetailsTitle), "0", "L", false)
 	doc.pdf.SetY(doc.pdf.GetY() + 3)
 	SetBaseTextColor(doc)
 	//doc.pdf.SetX(BaseMargin)
@@ -697,7 +617,9 @@ func (doc *Document) appendTotal() {
 	doc.pdf.Rect(120, doc.pdf.GetY(), 40, 10, "F")
 	SetGrayTextColor(doc)
 	if doc.IsRefund {
-		doc.pdf.CellFormat(38, 10, doc.encodeString(fmt.Sprintf("VAT Reverse Charge(%s)", doc.TaxPercentageString)), "0", 0, "R", false, 0, "")
+		doc.pdf.CellFormat(38, 10, doc.encodeString(fmt.Sprintf("%s(%s)", doc.Options.TextVatReverseCharge, doc.TaxPercentageString)), "0", 0, "R", false, 0, "")
+	} else if len(doc.TaxTitle) > 0 {
+		doc.pdf.CellFormat(38, 10, doc.encodeString(fmt.Sprintf("%s(%s)", doc.TaxTitle, doc.TaxPercentageString)), "0", 0, "R", false, 0, "")
 	} else {
 		doc.pdf.CellFormat(38, 10, doc.encodeString(fmt.Sprintf("%s(%s)", doc.Options.TextTotalTax, doc.TaxPercentageString)), "0", 0, "R", false, 0, "")
 	}
@@ -719,6 +641,29 @@ func (doc *Document) appendTotal() {
 		"",
 	)
 
+	// Draw tax breakdown
+	for _, taxLine := range doc.TaxBreakdown {
+		doc.pdf.SetY(doc.pdf.GetY() + lineBreakHeight/2)
+		doc.pdf.SetFont(doc.Options.Font, "", BaseTextFontSize)
+		doc.pdf.SetX(120)
+		SetGrayTextColor(doc)
+		doc.pdf.CellFormat(38, 10, doc.encodeString(taxLine.Title), "0", 0, "R", false, 0, "")
+		doc.pdf.SetX(moneyX)
+		SetBaseTextColor(doc)
+		doc.pdf.CellFormat(
+			40,
+			10,
+			taxLine.AmountString,
+			"0",
+			0,
+			"L",
+			false,
+			0,
+			"",
+		)
+		doc.pdf.SetFont(doc.Options.Font, "", LargeTextFontSize)
+	}
+
 	// Append Exchange Rate
 	if len(doc.OriginalTaxString) > 0 {
 		doc.pdf.SetY(doc.pdf.GetY() + lineBreakHeight/2)
@@ -804,3 +749,23 @@ func (doc *Document) appendPaymentTerm() {
 	}
 
 }
+
+// appendLegalNotes of the tax treatment and the merchant template below the totals
+func (doc *Document) appendLegalNotes() {
+	notes := doc.LegalNotes
+	if len(doc.TaxTreatmentNote) > 0 {
+		notes = strings.TrimSpace(fmt.Sprintf("%s\n%s", doc.TaxTreatmentNote, notes))
+	}
+	if len(notes) == 0 {
+		return
+	}
+	doc.pdf.SetY(doc.pdf.GetY() + 20)
+	if doc.pdf.GetY() > MaxPageHeight {
+		doc.pdf.AddPage()
+	}
+	doc.pdf.SetX(BaseMargin)
+	doc.pdf.SetFont(doc.Options.Font, "", BaseTextFontSize)
+	SetGrayTextColor(doc)
+	doc.pdf.MultiCell(210-2*BaseMargin, 4, doc.encodeString(notes), "0", "L", false)
+	SetBaseTextColor(doc)
+}
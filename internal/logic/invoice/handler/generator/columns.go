@@ -0,0 +1,35 @@
+package generator
+
+type itemColumn struct {
+	key    string
+	title  string
+	offset float64
+	width  float64
+	align  string
+}
+
+// itemColumns returns the visible item columns after the id, the first one is the name, the last one the total
+func (doc *Document) itemColumns() []*itemColumn {
+	columns := []*itemColumn{{key: "name", title: doc.Options.TextItemsNameTitle, offset: ItemColNameOffset, align: "L"}}
+	if doc.ShowDetailItem {
+		unitPriceOffset, quantityOffset := ItemColUnitPriceOffset, ItemColQuantityOffset
+		if doc.Options.ShowLineTax {
+			unitPriceOffset, quantityOffset = ItemColUnitPriceWithTaxOffset, ItemColQuantityWithTaxOffset
+		}
+		if !doc.Options.HideUnitPrice {
+			columns = append(columns, &itemColumn{key: ColumnUnitPrice, title: doc.Options.TextItemsUnitCostTitle, offset: unitPriceOffset, align: "C"})
+		}
+		if !doc.Options.HideQuantity {
+			columns = append(columns, &itemColumn{key: ColumnQuantity, title: doc.Options.TextItemsQuantityTitle, offset: quantityOffset, align: "C"})
+		}
+		if doc.Options.ShowLineTax {
+			columns = append(columns, &itemColumn{key: ColumnTax, title: doc.Options.TextItemsTaxTitle, offset: ItemColLineTaxOffset, align: "C"})
+		}
+	}
+	columns = append(columns, &itemColumn{key: "total", title: doc.Options.TextItemsTotalTTCTitle, offset: ItemColTotalTTCOffset, align: "L"})
+	for i := 0; i < len(columns)-1; i++ {
+		columns[i].width = columns[i+1].offset - columns[i].offset
+	}
+	columns[len(columns)-1].width = ItemColRightOffset - ItemColTotalTTCOffset
+	return columns
+}
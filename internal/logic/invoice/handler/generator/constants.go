@@ -55,6 +55,13 @@ const (
 
 	// ItemColTotalTTCOffset ...
 	ItemColTotalTTCOffset float64 = 165
+
+	// Offsets of the detail columns when the line tax column is shown
+	ItemColUnitPriceWithTaxOffset float64 = 97
+	ItemColQuantityWithTaxOffset  float64 = 117
+	ItemColLineTaxOffset          float64 = 135
+
+	ItemColRightOffset float64 = 190
 )
 
 var (
@@ -86,17 +86,17 @@ func (c *Contact) appendContactTODoc(
 func (c *Contact) appendMerchantContactToDoc(doc *Document, y float64) float64 {
 	x, _, _, _ := doc.pdf.GetMargins()
 	if doc.IsRefund {
-		return c.appendContactTODoc(x, y, true, doc, "From:")
+		return c.appendContactTODoc(x, y, true, doc, doc.Options.TextFromTitle)
 	} else {
-		return c.appendContactTODoc(x, y, true, doc, "Issued by:")
+		return c.appendContactTODoc(x, y, true, doc, doc.Options.TextIssuedByTitle)
 	}
 
 }
 
 func (c *Contact) appendCustomerContactToDoc(doc *Document, y float64) float64 {
 	if doc.IsRefund {
-		return c.appendContactTODoc(110, y, true, doc, "To:")
+		return c.appendContactTODoc(110, y, true, doc, doc.Options.TextToTitle)
 	} else {
-		return c.appendContactTODoc(110, y, true, doc, "Invoice to:")
+		return c.appendContactTODoc(110, y, true, doc, doc.Options.TextInvoiceToTitle)
 	}
 }
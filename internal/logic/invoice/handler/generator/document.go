@@ -8,8 +8,9 @@ import (
 
 // Document define base document
 type Document struct {
-	pdf *fpdf.Fpdf
-	ac  accounting.Accounting
+	pdf   *fpdf.Fpdf
+	ac    accounting.Accounting
+	fonts map[string]bool
 
 	Options             *Options      `json:"options,omitempty"`
 	Header              *HeaderFooter `json:"header,omitempty"`
@@ -40,12 +41,17 @@ type Document struct {
 	TaxString           string        `json:"tax_string,omitempty"`
 	TotalString         string        `json:"total_string,omitempty"`
 	TaxPercentageString string        `json:"tax_percentage_string,omitempty"`
+	TaxBreakdown        []*TaxLine    `json:"tax_breakdown,omitempty"`
+	TaxTitle            string        `json:"tax_title,omitempty"`
+	TaxTreatmentNote    string        `json:"tax_treatment_note,omitempty"`
 	PaidDate            string        `json:"paid_date,omitempty"`
 	ValidityDate        string        `json:"validity_date,omitempty"`
 	PaymentTerm         string        `json:"payment_term,omitempty"`
 	DefaultTax          *Tax          `json:"default_tax,omitempty"`
 	Discount            *Discount     `json:"discount,omitempty"`
 	ShowDetailItem      bool          `json:"showDetailItem,omitempty"`
+	LegalNotes          string        `json:"legal_notes,omitempty"`
+	Template            *Template     `json:"template,omitempty"`
 }
 
 // Pdf returns the underlying *fpdf.Fpdf used to build document
@@ -67,15 +73,12 @@ func (doc *Document) encodeString(str string) string {
 
 // encodeString encodes the string using doc.Options.UnicodeTranslateFunc
 func (doc *Document) encodeFitRefundString(str string) string {
-	if doc.IsRefund {
-		str = strings.ReplaceAll(str, "Invoice", "Credit note")
-	}
-	return doc.Options.UnicodeTranslateFunc(str)
+	return doc.Options.UnicodeTranslateFunc(doc.FitRefundString(str))
 }
 
 func (doc *Document) FitRefundString(str string) string {
 	if doc.IsRefund {
-		str = strings.ReplaceAll(str, "Invoice", "Credit note")
+		str = strings.ReplaceAll(str, doc.Options.TextTypeWordInvoice, doc.Options.TextTypeWordCreditNote)
 	}
 	return str
 }
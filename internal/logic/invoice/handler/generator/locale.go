@@ -0,0 +1,302 @@
+package generator
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+
+	"unibee/utility"
+
+	"github.com/creasty/defaults"
+)
+
+// Locale holds the date and amount formatting and the translated Texts of one language,
+// Texts are keyed by their json name and fall back to the english defaults when missing
+type Locale struct {
+	Code           string
+	DateLayout     string
+	AmountDecimal  string
+	AmountThousand string
+	Texts          map[string]string
+}
+
+const DefaultLocale = "en"
+
+var locales = map[string]*Locale{
+	"en": {
+		Code:          "en",
+		DateLayout:    "2006-01-02",
+		AmountDecimal: ".",
+	},
+	"de": {
+		Code:           "de",
+		DateLayout:     "02.01.2006",
+		AmountDecimal:  ",",
+		AmountThousand: ".",
+		Texts: map[string]string{
+			"invoice_id_title":             "Rechnungs-ID",
+			"invoice_number_title":         "Rechnungsnummer",
+			"text_origin_number_title":     "Ursprüngliche Rechnungsnummer",
+			"text_data_title":              "Rechnungsdatum",
+			"text_paid_date_title":         "Zahlungsdatum der Rechnung",
+			"text_payment_term_title":      "Zahlungsbedingungen",
+			"text_items_name_title":        "Beschreibung",
+			"text_items_unit_cost_title":   "Einzelpreis",
+			"text_items_quantity_title":    "Menge",
+			"text_items_total_ht_title":    "Netto",
+			"text_items_tax_title":         "MwSt.",
+			"text_items_discount_title":    "Rabatt",
+			"text_items_total_ttc_title":   "Gesamt",
+			"text_sub_total":               "ZWISCHENSUMME",
+			"text_total_discounted":        "RABATT",
+			"text_total_tax":               "MwSt.",
+			"text_total_with_tax":          "GESAMT",
+			"text_type_word_invoice":       "Rechnung",
+			"text_type_word_credit_note":   "Gutschrift",
+			"text_title_invoice":           "RECHNUNG",
+			"text_title_credit_note":       "GUTSCHRIFT",
+			"text_invoice_type_title":      "Rechnungsart",
+			"text_invoice_status_title":    "Rechnungsstatus:",
+			"text_issued_by_title":         "Rechnungssteller:",
+			"text_invoice_to_title":        "Rechnungsempfänger:",
+			"text_from_title":              "Von:",
+			"text_to_title":                "An:",
+			"text_other_details_title":     "Weitere Angaben",
+			"text_vat_reverse_charge":      "Steuerschuldnerschaft des Leistungsempfängers",
+			"text_reverse_charge_note":     "Steuerschuldnerschaft des Leistungsempfängers, Art. 196 MwSt-Systemrichtlinie 2006/112/EG",
+			"text_tax_exempt":              "Steuerbefreit",
+			"text_tax_exempt_note":         "Steuerbefreit, Freistellungsbescheinigung:",
+			"text_vat_number":              "USt-IdNr.",
+			"text_promo_credits":           "Guthaben",
+			"text_page":                    "Seite",
+			"text_status_awaiting_payment": "Zahlung ausstehend",
+			"text_status_not_paid":         "Nicht bezahlt",
+			"text_status_paid":             "Bezahlt",
+			"text_status_refunded":         "Erstattet",
+			"text_status_cancelled":        "Storniert",
+			"text_status_failed":           "Fehlgeschlagen",
+			"text_status_reversed":         "Rückgebucht",
+		},
+	},
+	"fr": {
+		Code:           "fr",
+		DateLayout:     "02/01/2006",
+		AmountDecimal:  ",",
+		AmountThousand: " ",
+		Texts: map[string]string{
+			"invoice_id_title":             "ID facture",
+			"invoice_number_title":         "N° facture",
+			"text_origin_number_title":     "N° facture d'origine",
+			"text_data_title":              "Date facture",
+			"text_paid_date_title":         "Date de paiement facture",
+			"text_payment_term_title":      "Conditions de paiement",
+			"text_items_name_title":        "Désignation",
+			"text_items_unit_cost_title":   "Prix unitaire",
+			"text_items_quantity_title":    "Quantité",
+			"text_items_total_ht_title":    "Total HT",
+			"text_items_tax_title":         "TVA",
+			"text_items_discount_title":    "Remise",
+			"text_items_total_ttc_title":   "Total",
+			"text_sub_total":               "SOUS-TOTAL",
+			"text_total_discounted":        "REMISE",
+			"text_total_tax":               "TVA",
+			"text_total_with_tax":          "TOTAL TTC",
+			"text_type_word_invoice":       "facture",
+			"text_type_word_credit_note":   "avoir",
+			"text_title_invoice":           "FACTURE",
+			"text_title_credit_note":       "AVOIR",
+			"text_invoice_type_title":      "Type de facture",
+			"text_invoice_status_title":    "Statut facture :",
+			"text_issued_by_title":         "Émise par :",
+			"text_invoice_to_title":        "Facturé à :",
+			"text_from_title":              "De :",
+			"text_to_title":                "À :",
+			"text_other_details_title":     "Autres informations",
+			"text_vat_reverse_charge":      "Autoliquidation",
+			"text_reverse_charge_note":     "Autoliquidation, article 196 de la directive 2006/112/CE",
+			"text_tax_exempt":              "Exonéré de taxe",
+			"text_tax_exempt_note":         "Exonéré de taxe, attestation d'exonération :",
+			"text_vat_number":              "N° TVA",
+			"text_promo_credits":           "Crédits promotionnels",
+			"text_page":                    "Page",
+			"text_status_awaiting_payment": "En attente de paiement",
+			"text_status_not_paid":         "Non payée",
+			"text_status_paid":             "Payée",
+			"text_status_refunded":         "Remboursée",
+			"text_status_cancelled":        "Annulée",
+			"text_status_failed":           "Échouée",
+			"text_status_reversed":         "Contrepassée",
+		},
+	},
+	"es": {
+		Code:           "es",
+		DateLayout:     "02/01/2006",
+		AmountDecimal:  ",",
+		AmountThousand: ".",
+		Texts: map[string]string{
+			"invoice_id_title":             "ID factura",
+			"invoice_number_title":         "Nº factura",
+			"text_origin_number_title":     "Nº factura original",
+			"text_data_title":              "Fecha factura",
+			"text_paid_date_title":         "Fecha de pago factura",
+			"text_payment_term_title":      "Condiciones de pago",
+			"text_items_name_title":        "Descripción",
+			"text_items_unit_cost_title":   "Precio unitario",
+			"text_items_quantity_title":    "Cantidad",
+			"text_items_total_ht_title":    "Base imponible",
+			"text_items_tax_title":         "IVA",
+			"text_items_discount_title":    "Descuento",
+			"text_items_total_ttc_title":   "Total",
+			"text_sub_total":               "SUBTOTAL",
+			"text_total_discounted":        "DESCUENTO",
+			"text_total_tax":               "IVA",
+			"text_total_with_tax":          "TOTAL",
+			"text_type_word_invoice":       "factura",
+			"text_type_word_credit_note":   "nota de crédito",
+			"text_title_invoice":           "FACTURA",
+			"text_title_credit_note":       "NOTA DE CRÉDITO",
+			"text_invoice_type_title":      "Tipo de factura",
+			"text_invoice_status_title":    "Estado factura:",
+			"text_issued_by_title":         "Emitida por:",
+			"text_invoice_to_title":        "Facturar a:",
+			"text_from_title":              "De:",
+			"text_to_title":                "Para:",
+			"text_other_details_title":     "Otros datos",
+			"text_vat_reverse_charge":      "Inversión del sujeto pasivo",
+			"text_reverse_charge_note":     "Inversión del sujeto pasivo, artículo 196 de la Directiva 2006/112/CE",
+			"text_tax_exempt":              "Exento de impuestos",
+			"text_tax_exempt_note":         "Exento de impuestos, certificado de exención:",
+			"text_vat_number":              "NIF-IVA",
+			"text_promo_credits":           "Créditos promocionales",
+			"text_page":                    "Página",
+			"text_status_awaiting_payment": "Pendiente de pago",
+			"text_status_not_paid":         "No pagada",
+			"text_status_paid":             "Pagada",
+			"text_status_refunded":         "Reembolsada",
+			"text_status_cancelled":        "Cancelada",
+			"text_status_failed":           "Fallida",
+			"text_status_reversed":         "Revertida",
+		},
+	},
+}
+
+// GetLocale returns the locale of the code, the english locale when not supported
+func GetLocale(code string) *Locale {
+	if one, ok := locales[strings.ToLower(code)]; ok {
+		return one
+	}
+	return locales[DefaultLocale]
+}
+
+func IsSupportedLocale(code string) bool {
+	_, ok := locales[strings.ToLower(code)]
+	return ok
+}
+
+// SupportedLocales returns the sorted codes of supported locales
+func SupportedLocales() []string {
+	codes := make([]string, 0, len(locales))
+	for code := range locales {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// TextKeyList returns the sorted json names of Texts
+func TextKeyList() []string {
+	keys := make([]string, 0)
+	for key := range TextKeys() {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// TextKeys returns the json names of Texts, the keys accepted by label overrides
+func TextKeys() map[string]bool {
+	keys := make(map[string]bool)
+	var texts map[string]string
+	_ = json.Unmarshal([]byte(utility.MarshalToJsonString(defaultTexts())), &texts)
+	for key := range texts {
+		keys[key] = true
+	}
+	return keys
+}
+
+func defaultTexts() Texts {
+	options := &Options{}
+	_ = defaults.Set(options)
+	return options.Texts
+}
+
+// overrideTexts replaces the texts found in the map
+func overrideTexts(texts *Texts, override map[string]string) {
+	if len(override) == 0 {
+		return
+	}
+	var current map[string]string
+	if err := json.Unmarshal([]byte(utility.MarshalToJsonString(texts)), &current); err != nil {
+		return
+	}
+	for key, value := range override {
+		if len(strings.TrimSpace(value)) > 0 {
+			current[key] = value
+		}
+	}
+	_ = json.Unmarshal([]byte(utility.MarshalToJsonString(current)), texts)
+}
+
+// FormatDate renders the timestamp in the document date layout
+func (doc *Document) FormatDate(timestamp int64) string {
+	if timestamp <= 0 {
+		return ""
+	}
+	return time.Unix(timestamp, 0).UTC().Format(doc.Options.DateLayout)
+}
+
+// FormatAmount renders the cent amount with the document currency symbol and separators
+func (doc *Document) FormatAmount(cents int64, currency string) string {
+	return doc.FormatAmountWithSymbol(doc.Options.CurrencySymbol, cents, currency)
+}
+
+// FormatNumber renders the cent amount with the document separators and without symbol
+func (doc *Document) FormatNumber(cents int64, currency string) string {
+	return formatAmountString(utility.ConvertCentToDollarStr(cents, currency), doc.Options.AmountDecimal, doc.Options.AmountThousand)
+}
+
+// FormatAmountWithSymbol renders the cent amount with the symbol and the document separators
+func (doc *Document) FormatAmountWithSymbol(symbol string, cents int64, currency string) string {
+	return symbol + formatAmountString(utility.ConvertCentToDollarStr(cents, currency), doc.Options.AmountDecimal, doc.Options.AmountThousand)
+}
+
+func formatAmountString(amount string, decimal string, thousand string) string {
+	if (decimal == "." || decimal == "") && thousand == "" {
+		return amount
+	}
+	var sign = ""
+	if strings.HasPrefix(amount, "-") {
+		sign = "-"
+		amount = amount[1:]
+	}
+	integer, fraction, hasFraction := strings.Cut(amount, ".")
+	if len(thousand) > 0 && len(integer) > 3 {
+		var builder strings.Builder
+		head := len(integer) % 3
+		if head > 0 {
+			builder.WriteString(integer[:head])
+		}
+		for i := head; i < len(integer); i = i + 3 {
+			if builder.Len() > 0 {
+				builder.WriteString(thousand)
+			}
+			builder.WriteString(integer[i : i+3])
+		}
+		integer = builder.String()
+	}
+	if hasFraction {
+		return sign + integer + decimal + fraction
+	}
+	return sign + integer
+}
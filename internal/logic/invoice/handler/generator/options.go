@@ -11,7 +11,41 @@ type Options struct {
 	CurrencyPrecision int    `default:"2" json:"currency_precision,omitempty"`
 	CurrencyDecimal   string `default:"." json:"currency_decimal,omitempty"`
 	CurrencyThousand  string `default:" " json:"currency_thousand,omitempty"`
+	// AmountDecimal and AmountThousand separate the amounts printed by FormatAmount, the thousand separator is optional
+	AmountDecimal  string `default:"." json:"amount_decimal,omitempty"`
+	AmountThousand string `json:"amount_thousand,omitempty"`
 
+	Texts
+
+	LogoPosition  string `default:"left" json:"logo_position,omitempty"`
+	HideUnitPrice bool   `json:"hide_unit_price,omitempty"`
+	HideQuantity  bool   `json:"hide_quantity,omitempty"`
+	ShowLineTax   bool   `json:"show_line_tax,omitempty"`
+	DateLayout    string `default:"2006-01-02" json:"date_layout,omitempty"`
+
+	BaseTextColor  []int `default:"[51,51,51]" json:"base_text_color,omitempty"`
+	TitleTextColor []int `default:"[51,51,51]" json:"title_text_color,omitempty"`
+	PaidTextColor  []int `default:"[73,167,101]" json:"paid_text_color,omitempty"`
+	GreyTextColor  []int `default:"[153,153,153]" json:"grey_text_color,omitempty"`
+	WhiteBgColor   []int `default:"[255,255,255]" json:"grey_bg_color,omitempty"`
+	//WhiteBgColor  []int `default:"[255,255,255]" json:"white_bg_color,omitempty"`
+	//DarkBgColor []int `default:"[247,247,247]" json:"dark_bg_color,omitempty"`
+	DarkBgColor []int `default:"[242,242,242]" json:"dark_bg_color,omitempty"`
+	DeepBgColor []int `default:"[200,200,200]" json:"deep_bg_color,omitempty"`
+
+	//Font     string `default:"Helvetica"`
+	//BoldFont string `default:"Helvetica"`
+	//Font     string `default:"Arial-Unicode"`
+	//BoldFont string `default:"Arial-Unicode"`
+
+	Font     string `default:"dejavu"`
+	BoldFont string `default:"dejavu"`
+
+	UnicodeTranslateFunc UnicodeTranslateFunc
+}
+
+// Texts are the labels printed on the document, see locale.go for the translations
+type Texts struct {
 	TextTypeInvoice      string `default:"INVOICE" json:"text_type_invoice,omitempty"`
 	TextTypeQuotation    string `default:"QUOTATION" json:"text_type_quotation,omitempty"`
 	TextTypeDeliveryNote string `default:"DELIVERY NOTE" json:"text_type_delivery_note,omitempty"`
@@ -36,22 +70,30 @@ type Options struct {
 	TextTotalTax        string `default:"VAT" json:"text_total_tax,omitempty"`
 	TextTotalWithTax    string `default:"TOTAL" json:"text_total_with_tax,omitempty"`
 
-	BaseTextColor []int `default:"[51,51,51]" json:"base_text_color,omitempty"`
-	PaidTextColor []int `default:"[73,167,101]" json:"paid_text_color,omitempty"`
-	GreyTextColor []int `default:"[153,153,153]" json:"grey_text_color,omitempty"`
-	WhiteBgColor  []int `default:"[255,255,255]" json:"grey_bg_color,omitempty"`
-	//WhiteBgColor  []int `default:"[255,255,255]" json:"white_bg_color,omitempty"`
-	//DarkBgColor []int `default:"[247,247,247]" json:"dark_bg_color,omitempty"`
-	DarkBgColor []int `default:"[242,242,242]" json:"dark_bg_color,omitempty"`
-	DeepBgColor []int `default:"[200,200,200]" json:"deep_bg_color,omitempty"`
+	TextTypeWordInvoice    string `default:"Invoice" json:"text_type_word_invoice,omitempty"`
+	TextTypeWordCreditNote string `default:"Credit note" json:"text_type_word_credit_note,omitempty"`
+	TextTitleInvoice       string `default:"TAX INVOICE" json:"text_title_invoice,omitempty"`
+	TextTitleCreditNote    string `default:"TAX CREDIT NOTE" json:"text_title_credit_note,omitempty"`
+	TextInvoiceTypeTitle   string `default:"Invoice Type" json:"text_invoice_type_title,omitempty"`
+	TextInvoiceStatusTitle string `default:"Invoice status:" json:"text_invoice_status_title,omitempty"`
+	TextIssuedByTitle      string `default:"Issued by:" json:"text_issued_by_title,omitempty"`
+	TextInvoiceToTitle     string `default:"Invoice to:" json:"text_invoice_to_title,omitempty"`
+	TextFromTitle          string `default:"From:" json:"text_from_title,omitempty"`
+	TextToTitle            string `default:"To:" json:"text_to_title,omitempty"`
+	TextOtherDetailsTitle  string `default:"Other details" json:"text_other_details_title,omitempty"`
+	TextVatReverseCharge   string `default:"VAT Reverse Charge" json:"text_vat_reverse_charge,omitempty"`
+	TextReverseChargeNote  string `default:"Reverse charge: VAT to be accounted for by the recipient, Article 196 Council Directive 2006/112/EC" json:"text_reverse_charge_note,omitempty"`
+	TextTaxExempt          string `default:"Tax Exempt" json:"text_tax_exempt,omitempty"`
+	TextTaxExemptNote      string `default:"Exempt from tax, exemption certificate:" json:"text_tax_exempt_note,omitempty"`
+	TextVatNumber          string `default:"VAT Number" json:"text_vat_number,omitempty"`
+	TextPromoCredits       string `default:"Promo Credits" json:"text_promo_credits,omitempty"`
+	TextPage               string `default:"Page" json:"text_page,omitempty"`
 
-	//Font     string `default:"Helvetica"`
-	//BoldFont string `default:"Helvetica"`
-	//Font     string `default:"Arial-Unicode"`
-	//BoldFont string `default:"Arial-Unicode"`
-
-	Font     string `default:"dejavu"`
-	BoldFont string `default:"dejavu"`
-
-	UnicodeTranslateFunc UnicodeTranslateFunc
+	TextStatusAwaitingPayment string `default:"Awaiting payment" json:"text_status_awaiting_payment,omitempty"`
+	TextStatusNotPaid         string `default:"Not paid" json:"text_status_not_paid,omitempty"`
+	TextStatusPaid            string `default:"Paid" json:"text_status_paid,omitempty"`
+	TextStatusRefunded        string `default:"Refunded" json:"text_status_refunded,omitempty"`
+	TextStatusCancelled       string `default:"Cancelled" json:"text_status_cancelled,omitempty"`
+	TextStatusFailed          string `default:"Failed" json:"text_status_failed,omitempty"`
+	TextStatusReversed        string `default:"Reversed" json:"text_status_reversed,omitempty"`
 }
@@ -69,3 +69,9 @@ func (t *Tax) getTax() (string, decimal.Decimal) {
 
 	return taxType, decVal
 }
+
+// TaxLine is one row of the tax breakdown under the tax total
+type TaxLine struct {
+	Title        string `json:"title,omitempty"`
+	AmountString string `json:"amount_string,omitempty"`
+}
@@ -0,0 +1,158 @@
+package generator
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	LogoPositionLeft   = "left"
+	LogoPositionCenter = "center"
+	LogoPositionNone   = "none"
+
+	ColumnUnitPrice = "unitPrice"
+	ColumnQuantity  = "quantity"
+	ColumnTax       = "tax"
+
+	FontDejavu       = "dejavu"
+	FontArialUnicode = "Arial-Unicode"
+	FontHelvetica    = "Helvetica"
+
+	MaxFooterTextLength = 256
+	MaxLegalNotesLength = 1024
+)
+
+// Template is the merchant branding of invoice documents, empty fields keep the default layout
+type Template struct {
+	LogoPosition     string            `json:"logoPosition"     dc:"left|center|none, default left"`
+	TitleColor       string            `json:"titleColor"       dc:"Hex colour of the document title, eg #333333"`
+	TextColor        string            `json:"textColor"        dc:"Hex colour of the body text, eg #333333"`
+	TableHeaderColor string            `json:"tableHeaderColor" dc:"Hex background colour of the item table header, eg #F2F2F2"`
+	PaidColor        string            `json:"paidColor"        dc:"Hex colour of the paid status, eg #49A765"`
+	Font             string            `json:"font"             dc:"dejavu|Arial-Unicode|Helvetica, default dejavu, falls back to Helvetica when the font is not installed"`
+	FooterText       string            `json:"footerText"       dc:"Footer text printed on every page, default 'PDF Generated on {date}'"`
+	LegalNotes       string            `json:"legalNotes"       dc:"Legal notes printed below the totals"`
+	Columns          []string          `json:"columns"          dc:"Optional item columns, unitPrice|quantity|tax, default unitPrice and quantity"`
+	Locale           string            `json:"locale"           dc:"Language and formatting of labels, dates and amounts, en|de|fr|es, default en"`
+	DateLayout       string            `json:"dateLayout"       dc:"Go date layout overriding the locale one, eg 02.01.2006"`
+	Labels           map[string]string `json:"labels"           dc:"Label overrides keyed by text name, eg text_title_invoice"`
+}
+
+// DefaultColumns are the item columns of documents without template
+var DefaultColumns = []string{ColumnUnitPrice, ColumnQuantity}
+
+func (t *Template) HasColumn(column string) bool {
+	columns := t.Columns
+	if columns == nil {
+		columns = DefaultColumns
+	}
+	for _, one := range columns {
+		if one == column {
+			return true
+		}
+	}
+	return false
+}
+
+// Check returns the first invalid field of the template
+func (t *Template) Check() error {
+	switch t.LogoPosition {
+	case "", LogoPositionLeft, LogoPositionCenter, LogoPositionNone:
+	default:
+		return fmt.Errorf("invalid logoPosition:%s", t.LogoPosition)
+	}
+	switch t.Font {
+	case "", FontDejavu, FontArialUnicode, FontHelvetica:
+	default:
+		return fmt.Errorf("invalid font:%s", t.Font)
+	}
+	for name, color := range map[string]string{"titleColor": t.TitleColor, "textColor": t.TextColor, "tableHeaderColor": t.TableHeaderColor, "paidColor": t.PaidColor} {
+		if len(color) > 0 && ParseHexColor(color) == nil {
+			return fmt.Errorf("invalid %s:%s", name, color)
+		}
+	}
+	for _, column := range t.Columns {
+		if column != ColumnUnitPrice && column != ColumnQuantity && column != ColumnTax {
+			return fmt.Errorf("invalid column:%s", column)
+		}
+	}
+	if len(t.Locale) > 0 && !IsSupportedLocale(t.Locale) {
+		return fmt.Errorf("unsupported locale:%s", t.Locale)
+	}
+	if len(t.FooterText) > MaxFooterTextLength {
+		return fmt.Errorf("footerText should not longer than %d", MaxFooterTextLength)
+	}
+	if len(t.LegalNotes) > MaxLegalNotesLength {
+		return fmt.Errorf("legalNotes should not longer than %d", MaxLegalNotesLength)
+	}
+	if len(t.Labels) > 0 {
+		keys := TextKeys()
+		for key := range t.Labels {
+			if !keys[key] {
+				return fmt.Errorf("invalid label:%s", key)
+			}
+		}
+	}
+	return nil
+}
+
+// ParseHexColor parses #RRGGBB into rgb, nil if invalid
+func ParseHexColor(color string) []int {
+	color = strings.TrimPrefix(strings.TrimSpace(color), "#")
+	if len(color) != 6 {
+		return nil
+	}
+	value, err := strconv.ParseUint(color, 16, 32)
+	if err != nil {
+		return nil
+	}
+	return []int{int(value >> 16 & 0xFF), int(value >> 8 & 0xFF), int(value & 0xFF)}
+}
+
+// ApplyTemplate applies the locale, colours, font and columns of the template to the document options,
+// call it before setting any content
+func (doc *Document) ApplyTemplate(template *Template) *Document {
+	if template == nil {
+		return doc
+	}
+	doc.Template = template
+	locale := GetLocale(template.Locale)
+	overrideTexts(&doc.Options.Texts, locale.Texts)
+	overrideTexts(&doc.Options.Texts, template.Labels)
+	doc.Options.DateLayout = locale.DateLayout
+	if len(template.DateLayout) > 0 {
+		doc.Options.DateLayout = template.DateLayout
+	}
+	doc.Options.AmountDecimal = locale.AmountDecimal
+	doc.Options.AmountThousand = locale.AmountThousand
+	if len(template.LogoPosition) > 0 {
+		doc.Options.LogoPosition = template.LogoPosition
+	}
+	if color := ParseHexColor(template.TitleColor); color != nil {
+		doc.Options.TitleTextColor = color
+	}
+	if color := ParseHexColor(template.TextColor); color != nil {
+		doc.Options.BaseTextColor = color
+		if len(template.TitleColor) == 0 {
+			doc.Options.TitleTextColor = color
+		}
+	}
+	if color := ParseHexColor(template.TableHeaderColor); color != nil {
+		doc.Options.DarkBgColor = color
+	}
+	if color := ParseHexColor(template.PaidColor); color != nil {
+		doc.Options.PaidTextColor = color
+	}
+	if len(template.Font) > 0 {
+		if template.Font == FontHelvetica || doc.fonts[template.Font] {
+			doc.Options.Font = template.Font
+			doc.Options.BoldFont = template.Font
+		}
+	}
+	doc.Options.HideUnitPrice = !template.HasColumn(ColumnUnitPrice)
+	doc.Options.HideQuantity = !template.HasColumn(ColumnQuantity)
+	doc.Options.ShowLineTax = template.HasColumn(ColumnTax)
+	doc.LegalNotes = template.LegalNotes
+	return doc
+}
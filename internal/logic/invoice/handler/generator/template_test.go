@@ -0,0 +1,52 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/creasty/defaults"
+	"github.com/stretchr/testify/require"
+)
+
+func testDocument() *Document {
+	options := &Options{CurrencySymbol: "€ "}
+	_ = defaults.Set(options)
+	return &Document{Options: options, Type: Invoice, ShowDetailItem: true}
+}
+
+func TestFormatAmountString(t *testing.T) {
+	require.Equal(t, "1234567.89", formatAmountString("1234567.89", ".", ""))
+	require.Equal(t, "1.234.567,89", formatAmountString("1234567.89", ",", "."))
+	require.Equal(t, "-1 234,5", formatAmountString("-1234.5", ",", " "))
+	require.Equal(t, "123", formatAmountString("123", ",", "."))
+}
+
+func TestTemplateCheck(t *testing.T) {
+	require.Nil(t, (&Template{}).Check())
+	require.Nil(t, (&Template{LogoPosition: LogoPositionCenter, TitleColor: "#1A2B3C", Columns: []string{ColumnTax}, Locale: "de", Labels: map[string]string{"text_title_invoice": "RECHNUNG"}}).Check())
+	require.NotNil(t, (&Template{LogoPosition: "right"}).Check())
+	require.NotNil(t, (&Template{TitleColor: "red"}).Check())
+	require.NotNil(t, (&Template{Columns: []string{"discount"}}).Check())
+	require.NotNil(t, (&Template{Locale: "xx"}).Check())
+	require.NotNil(t, (&Template{Labels: map[string]string{"unknown": "x"}}).Check())
+	require.Equal(t, []int{26, 43, 60}, ParseHexColor("#1A2B3C"))
+}
+
+func TestApplyTemplate(t *testing.T) {
+	doc := testDocument()
+	doc.ApplyTemplate(nil)
+	require.Equal(t, "TAX INVOICE", doc.Options.TextTitleInvoice)
+	require.Equal(t, "€ 1234.50", doc.FormatAmount(123450, "EUR"))
+	require.Equal(t, 4, len(doc.itemColumns()))
+
+	doc = testDocument()
+	doc.ApplyTemplate(&Template{Locale: "de", Columns: []string{ColumnQuantity, ColumnTax}, Labels: map[string]string{"text_page": "S."}, LegalNotes: "notes"})
+	require.Equal(t, "RECHNUNG", doc.Options.TextTitleInvoice)
+	require.Equal(t, "S.", doc.Options.TextPage)
+	require.Equal(t, "02.01.2006", doc.Options.DateLayout)
+	require.Equal(t, "€ 1.234,50", doc.FormatAmount(123450, "EUR"))
+	require.True(t, doc.Options.HideUnitPrice)
+	require.True(t, doc.Options.ShowLineTax)
+	require.Equal(t, "notes", doc.LegalNotes)
+	columns := doc.itemColumns()
+	require.Equal(t, []string{"name", ColumnQuantity, ColumnTax, "total"}, []string{columns[0].key, columns[1].key, columns[2].key, columns[3].key})
+}
@@ -21,6 +21,7 @@ import (
 	"unibee/internal/logic/gateway/api"
 	"unibee/internal/logic/gateway/gateway_bean"
 	discount2 "unibee/internal/logic/invoice/discount"
+	"unibee/internal/logic/invoice/invoice_number"
 	"unibee/internal/logic/multi_currencies/currency_exchange"
 	"unibee/internal/logic/subscription/config"
 	"unibee/internal/logic/user/sub_update"
@@ -56,22 +57,25 @@ func CreateProcessInvoiceForNewPayment(ctx context.Context, invoice *bean.Invoic
 	if user != nil {
 		sendEmail = user.Email
 		userSnapshot = &entity.UserAccount{
-			Email:         user.Email,
-			CountryCode:   user.CountryCode,
-			CountryName:   user.CountryName,
-			VATNumber:     user.VATNumber,
-			TaxPercentage: user.TaxPercentage,
-			GatewayId:     user.GatewayId,
-			Type:          user.Type,
-			UserName:      user.UserName,
-			Mobile:        user.Mobile,
-			Phone:         user.Phone,
-			Address:       user.Address,
-			FirstName:     user.FirstName,
-			LastName:      user.LastName,
-			CompanyName:   user.CompanyName,
-			City:          user.City,
-			ZipCode:       user.ZipCode,
+			Email:                user.Email,
+			CountryCode:          user.CountryCode,
+			CountryName:          user.CountryName,
+			VATNumber:            user.VATNumber,
+			TaxPercentage:        user.TaxPercentage,
+			GatewayId:            user.GatewayId,
+			Type:                 user.Type,
+			UserName:             user.UserName,
+			Mobile:               user.Mobile,
+			Phone:                user.Phone,
+			Address:              user.Address,
+			FirstName:            user.FirstName,
+			LastName:             user.LastName,
+			CompanyName:          user.CompanyName,
+			City:                 user.City,
+			ZipCode:              user.ZipCode,
+			TaxStatus:            user.TaxStatus,
+			TaxExemptCertificate: user.TaxExemptCertificate,
+			TaxExemptExpireTime:  user.TaxExemptExpireTime,
 		}
 	}
 	st := utility.CreateInvoiceSt()
@@ -155,6 +159,7 @@ func CreateProcessInvoiceForNewPayment(ctx context.Context, invoice *bean.Invoic
 		CountryCode:                    payment.CountryCode,
 		VatNumber:                      invoice.VatNumber,
 		TaxPercentage:                  invoice.TaxPercentage,
+		TaxTreatment:                   invoice.TaxTreatment,
 		SubscriptionAmount:             invoice.SubscriptionAmount,
 		SubscriptionAmountExcludingTax: invoice.SubscriptionAmountExcludingTax,
 		Lines:                          utility.MarshalToJsonString(invoice.Lines),
@@ -185,6 +190,7 @@ func CreateProcessInvoiceForNewPayment(ctx context.Context, invoice *bean.Invoic
 	}
 	id, _ := result.LastInsertId()
 	one.Id = uint64(uint(id))
+	invoice_number.Assign(ctx, one)
 	if utility.TryLock(ctx, fmt.Sprintf("CreateProcessInvoiceForNewPayment_%s", one.InvoiceId), 60) {
 		_, _ = redismq.Send(&redismq.Message{
 			Topic:      redismq2.TopicInvoiceCreated.Topic,
@@ -240,6 +246,7 @@ func UpdateInvoiceFromPayment(ctx context.Context, payment *entity.Payment) (*en
 				one.Status = consts.InvoiceStatusPaid
 				one.GatewayPaymentId = payment.GatewayPaymentId
 				one.Link = payment.Link
+				invoice_number.Assign(ctx, one)
 				g.Log().Infof(ctx, "UpdateInvoiceFromPayment_Reverse invoiceId:%s paymentId:%s", one.InvoiceId, payment.PaymentId)
 				if utility.TryLock(ctx, fmt.Sprintf("UpdateInvoiceFromPayment_%s", one.InvoiceId), 60) {
 					_, _ = redismq.Send(&redismq.Message{
@@ -279,6 +286,7 @@ func UpdateInvoiceFromPayment(ctx context.Context, payment *entity.Payment) (*en
 		return one, err
 	}
 	if one.Status != status {
+		one.InvoiceNumber = invoice_number.AssignByInvoiceId(ctx, one.InvoiceId)
 		_, _ = dao.Invoice.Ctx(ctx).Data(g.Map{
 			dao.Invoice.Columns().SendPdf: "",
 		}).Where(dao.Invoice.Columns().Id, one.Id).OmitNil().Update()
@@ -337,6 +345,7 @@ func CreateProcessInvoiceForNewPaymentRefund(ctx context.Context, invoice *bean.
 		UserId:                         refund.UserId,
 		MerchantId:                     refund.MerchantId,
 		InvoiceName:                    invoice.InvoiceName,
+		InvoiceNumber:                  invoice.InvoiceNumber,
 		ProductName:                    invoice.ProductName,
 		InvoiceId:                      refund.InvoiceId,
 		UniqueId:                       refund.RefundId,
@@ -362,6 +371,7 @@ func CreateProcessInvoiceForNewPaymentRefund(ctx context.Context, invoice *bean.
 		CountryCode:                    invoice.CountryCode,
 		VatNumber:                      invoice.VatNumber,
 		TaxPercentage:                  invoice.TaxPercentage,
+		TaxTreatment:                   invoice.TaxTreatment,
 		SubscriptionAmount:             invoice.SubscriptionAmount,
 		SubscriptionAmountExcludingTax: invoice.SubscriptionAmountExcludingTax,
 		Lines:                          utility.MarshalToJsonString(invoice.Lines),
@@ -372,6 +382,7 @@ func CreateProcessInvoiceForNewPaymentRefund(ctx context.Context, invoice *bean.
 		DiscountCode:                   invoice.DiscountCode,
 		CreateFrom:                     refund.RefundComment,
 		Data:                           invoice.Data,
+		MetaData:                       utility.MarshalToJsonString(invoice.Metadata),
 	}
 
 	result, err := dao.Invoice.Ctx(ctx).Data(one).OmitNil().Insert(one)
@@ -381,6 +392,8 @@ func CreateProcessInvoiceForNewPaymentRefund(ctx context.Context, invoice *bean.
 	}
 	id, _ := result.LastInsertId()
 	one.Id = uint64(uint(id))
+	// the refund invoice of a credit note takes the credit note number once the refund is accepted
+	invoice_number.Assign(ctx, one)
 	if utility.TryLock(ctx, fmt.Sprintf("CreateProcessInvoiceForNewPaymentRefund_%s", one.InvoiceId), 60) {
 		_, _ = redismq.Send(&redismq.Message{
 			Topic:      redismq2.TopicInvoiceCreated.Topic,
@@ -428,6 +441,7 @@ func UpdateInvoiceFromPaymentRefund(ctx context.Context, refund *entity.Refund)
 		return one, err
 	}
 	if one.Status != status {
+		one.InvoiceNumber = invoice_number.AssignByInvoiceId(ctx, one.InvoiceId)
 		_ = InvoicePdfGenerateAndEmailSendBackground(one.InvoiceId, true, false)
 		if utility.TryLock(ctx, fmt.Sprintf("UpdateInvoiceFromPayment_%s", one.InvoiceId), 60) {
 			if status == consts.InvoiceStatusPaid {
@@ -478,8 +492,9 @@ func MarkInvoiceAsPaidForZeroPayment(ctx context.Context, invoiceId string) (*en
 		return nil, err
 	}
 	sub_update.UpdateUserCountryCode(ctx, one.UserId, one.CountryCode)
-	_ = InvoicePdfGenerateAndEmailSendBackground(one.InvoiceId, true, false)
 	one.Status = consts.InvoiceStatusPaid
+	invoice_number.Assign(ctx, one)
+	_ = InvoicePdfGenerateAndEmailSendBackground(one.InvoiceId, true, false)
 	if utility.TryLock(ctx, fmt.Sprintf("MarkInvoiceAsPaidForZeroPayment_%s", one.InvoiceId), 60) {
 		go func() {
 			time.Sleep(2 * time.Second)
@@ -714,14 +729,20 @@ func SendInvoiceEmailToUser(ctx context.Context, invoiceId string, manualSend bo
 			if len(sendTemplate) > 0 {
 				template = sendTemplate
 			}
+			// the net term invoice is due by the end of its payment terms
+			var dueDate = gtime.Now().AddDate(0, 0, 5)
+			if one.NetTermDays > 0 {
+				dueDate = gtime.NewFromTimeStamp(one.FinishTime + one.DayUtilDue*86400)
+			}
 			err := email.SendTemplateEmail(ctx, merchant.Id, one.SendEmail, user.TimeZone, user.Language, template, pdfFileName, &bean.EmailTemplateVariable{
 				InvoiceId:             one.InvoiceId,
+				InvoiceNumber:         invoice_number.DisplayNumber(ctx, one),
 				UserName:              user.FirstName + " " + user.LastName,
 				MerchantProductName:   one.ProductName,
 				MerchantCustomerEmail: merchant.Email,
 				MerchantName:          query.GetMerchantCountryConfigName(ctx, one.MerchantId, user.CountryCode),
 				DateNow:               gtime.Now(),
-				PeriodEnd:             gtime.Now().AddDate(0, 0, 5),
+				PeriodEnd:             dueDate,
 				PaymentAmount:         strconv.FormatInt(one.TotalAmount, 10),
 				TokenExpireMinute:     strconv.FormatInt(config2.GetConfigInstance().Auth.Login.Expire/60, 10),
 				Link:                  "<a href=\"" + link.GetInvoiceLink(one.InvoiceId, one.SendTerms) + "\">Link</a>",
@@ -764,6 +785,7 @@ func SendInvoiceEmailToUser(ctx context.Context, invoiceId string, manualSend bo
 				}
 				err := email.SendTemplateEmail(ctx, merchant.Id, one.SendEmail, user.TimeZone, user.Language, template, pdfFileName, &bean.EmailTemplateVariable{
 					InvoiceId:             one.InvoiceId,
+					InvoiceNumber:         invoice_number.DisplayNumber(ctx, one),
 					UserName:              user.FirstName + " " + user.LastName,
 					MerchantProductName:   one.ProductName,
 					MerchantCustomerEmail: merchant.Email,
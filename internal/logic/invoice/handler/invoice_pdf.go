@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"os"
@@ -9,12 +10,14 @@ import (
 	"time"
 	"unibee/api/bean/detail"
 	"unibee/internal/consts"
-	"unibee/internal/logic/gateway/api"
+	"unibee/internal/logic/invoice/einvoice"
 	generator2 "unibee/internal/logic/invoice/handler/generator"
+	"unibee/internal/logic/invoice/invoice_number"
 	entity "unibee/internal/model/entity/default"
 	"unibee/internal/query"
 	"unibee/utility"
 
+	"github.com/go-pdf/fpdf"
 	"github.com/gogf/gf/v2/frame/g"
 	"golang.org/x/text/currency"
 	"golang.org/x/text/number"
@@ -27,75 +30,80 @@ func GenerateInvoicePdf(ctx context.Context, one *entity.Invoice) string {
 	user := query.GetUserAccountById(ctx, one.UserId)
 	var savePath = fmt.Sprintf("%s.pdf", one.InvoiceId)
 
-	err := createInvoicePdf(ctx, detail.ConvertInvoiceToDetail(ctx, one), merchantInfo, user, query.GetGatewayById(ctx, one.GatewayId), savePath)
+	err := createInvoicePdf(ctx, detail.ConvertInvoiceToDetail(ctx, one), merchantInfo, user, query.GetGatewayById(ctx, one.GatewayId), GetMerchantInvoicePdfTemplate(ctx, one.MerchantId), savePath)
 	utility.AssertError(err, "createInvoicePdf error:")
 	return savePath
 }
 
-func createInvoicePdf(ctx context.Context, one *detail.InvoiceDetail, merchantInfo *entity.Merchant, user *entity.UserAccount, gateway *entity.MerchantGateway, savePath string) error {
+func createInvoicePdf(ctx context.Context, one *detail.InvoiceDetail, merchantInfo *entity.Merchant, user *entity.UserAccount, gateway *entity.MerchantGateway, template *generator2.Template, savePath string) error {
 	var symbol = fmt.Sprintf("%v ", currency.NarrowSymbol(currency.MustParseISO(strings.ToUpper(one.Currency))))
 	doc, _ := generator2.New(ctx, generator2.Invoice, "/usr/share/fonts", &generator2.Options{
 		AutoPrint:      true,
 		CurrencySymbol: symbol,
 	})
+	doc.ApplyTemplate(template)
+	var footerText = fmt.Sprintf("PDF Generated on %s                                                    -%s-", time.Now().Format(time.RFC850), one.CountryCode)
+	if template != nil && len(template.FooterText) > 0 {
+		footerText = template.FooterText
+	}
 	doc.SetFooter(&generator2.HeaderFooter{
-		Text:       fmt.Sprintf("PDF Generated on %s                                                    -%s-", time.Now().Format(time.RFC850), one.CountryCode),
+		Text:       footerText,
 		Pagination: true,
 	})
 
-	var invoiceGateway = ""
-	if gateway != nil {
-		invoiceGateway = gateway.GatewayName
+	if len(one.InvoiceNumber) > 0 {
+		doc.SetInvoiceNumber(one.InvoiceNumber)
+	} else {
+		doc.SetInvoiceNumber(invoice_number.LegacyNumber(gateway, one.InvoiceId))
 	}
-	doc.SetInvoiceNumber(fmt.Sprintf("%s%s", api.GatewayShortNameMapping[invoiceGateway], one.InvoiceId))
-	doc.SetInvoiceDate(one.GmtCreate.Layout("2006-01-02"))
+	doc.SetInvoiceDate(one.GmtCreate.Layout(doc.Options.DateLayout))
 
 	hideDetailStatus := one.Metadata["hideDetailStatus"]
 	if one.Status == consts.InvoiceStatusProcessing {
-		doc.SetStatus("Awaiting payment")
+		doc.SetStatus(doc.Options.TextStatusAwaitingPayment)
 		if hideDetailStatus != nil {
 			if _hideDetailStatus, ok := hideDetailStatus.(bool); ok {
 				if _hideDetailStatus {
-					doc.SetStatus("Not paid")
+					doc.SetStatus(doc.Options.TextStatusNotPaid)
 				}
 			}
 		}
 	} else if one.Status == consts.InvoiceStatusPaid {
 		if len(one.RefundId) > 0 {
-			doc.SetStatus("Refunded")
+			doc.SetStatus(doc.Options.TextStatusRefunded)
 		} else {
-			doc.SetStatus("Paid")
+			doc.SetStatus(doc.Options.TextStatusPaid)
 		}
 	} else if one.Status == consts.InvoiceStatusCancelled {
-		doc.SetStatus("Cancelled")
+		doc.SetStatus(doc.Options.TextStatusCancelled)
 		if hideDetailStatus != nil {
 			if _hideDetailStatus, ok := hideDetailStatus.(bool); ok {
 				if _hideDetailStatus {
-					doc.SetStatus("Not paid")
+					doc.SetStatus(doc.Options.TextStatusNotPaid)
 				}
 			}
 		}
 	} else if one.Status == consts.InvoiceStatusFailed {
-		doc.SetStatus("Failed")
+		doc.SetStatus(doc.Options.TextStatusFailed)
 		if hideDetailStatus != nil {
 			if _hideDetailStatus, ok := hideDetailStatus.(bool); ok {
 				if _hideDetailStatus {
-					doc.SetStatus("Not paid")
+					doc.SetStatus(doc.Options.TextStatusNotPaid)
 				}
 			}
 		}
 	} else if one.Status == consts.InvoiceStatusReversed {
-		doc.SetStatus("Reversed")
+		doc.SetStatus(doc.Options.TextStatusReversed)
 		if hideDetailStatus != nil {
 			if _hideDetailStatus, ok := hideDetailStatus.(bool); ok {
 				if _hideDetailStatus {
-					doc.SetStatus("Not paid")
+					doc.SetStatus(doc.Options.TextStatusNotPaid)
 				}
 			}
 		}
 	}
 
-	doc.SetPaidDate(one.GmtModify.Layout("2006-01-02"))
+	doc.SetPaidDate(one.GmtModify.Layout(doc.Options.DateLayout))
 
 	var issueLogo = one.Metadata["IssueLogo"]
 	if issueLogo != nil && issueLogo != "" {
@@ -219,8 +227,12 @@ func createInvoicePdf(ctx context.Context, one *detail.InvoiceDetail, merchantIn
 	if len(one.RefundId) > 0 {
 		doc.IsRefund = true
 		//doc.SetOriginInvoiceNumber(one.SendNote)
-		doc.SetOriginInvoiceNumber(one.OriginalPaymentInvoice.InvoiceId)
-		doc.Title = "TAX CREDIT NOTE"
+		if len(one.OriginalPaymentInvoice.InvoiceNumber) > 0 {
+			doc.SetOriginInvoiceNumber(one.OriginalPaymentInvoice.InvoiceNumber)
+		} else {
+			doc.SetOriginInvoiceNumber(one.OriginalPaymentInvoice.InvoiceId)
+		}
+		doc.Title = doc.Options.TextTitleCreditNote
 		refundDesc := ""
 		if strings.Contains(one.SendNote, "Partial Refund") {
 			refundDesc = "Partial Refund"
@@ -230,48 +242,54 @@ func createInvoicePdf(ctx context.Context, one *detail.InvoiceDetail, merchantIn
 		doc.Notes = fmt.Sprintf("%s\n%s", one.CreateFrom, refundDesc)
 		if len(one.VatNumber) > 0 {
 			//doc.Customer.AdditionalInfo = []string{"VAT reverse charge"}
-			doc.Customer.AdditionalInfo = []string{fmt.Sprintf("VAT Number:%s", one.VatNumber)}
+			doc.Customer.AdditionalInfo = []string{fmt.Sprintf("%s:%s", doc.Options.TextVatNumber, one.VatNumber)}
 		}
 		doc.SetIsRefund(true)
 		for i, line := range one.Lines {
 
-			//amountString := fmt.Sprintf("%s%s", symbol, utility.ConvertCentToDollarStr(line.UnitAmountExcludingTax*line.Quantity+line.Tax, one.Currency))
-			amountString := fmt.Sprintf("%s%s", symbol, utility.ConvertCentToDollarStr(line.UnitAmountExcludingTax*line.Quantity, one.Currency)) // remove tax
+			//amountString := fmt.Sprintf("%s%s", symbol, doc.FormatNumber(line.UnitAmountExcludingTax*line.Quantity+line.Tax, one.Currency))
+			amountString := fmt.Sprintf("%s%s", symbol, doc.FormatNumber(line.UnitAmountExcludingTax*line.Quantity, one.Currency)) // remove tax
 			if line.MetricCharge != nil {
-				amountString = fmt.Sprintf("%s%s", symbol, utility.ConvertCentToDollarStr(line.AmountExcludingTax, one.Currency))
+				amountString = fmt.Sprintf("%s%s", symbol, doc.FormatNumber(line.AmountExcludingTax, one.Currency))
 			}
-			//taxString := fmt.Sprintf("%s %s%s", doc.TaxPercentageString, symbol, utility.ConvertCentToDollarStr(line.Tax, one.Currency))
+			//taxString := fmt.Sprintf("%s %s%s", doc.TaxPercentageString, symbol, doc.FormatNumber(line.Tax, one.Currency))
 			taxString := ""
+			if doc.Options.ShowLineTax {
+				taxString = doc.FormatAmount(line.Tax, one.Currency)
+			}
 			description := line.Description
 			if len(line.PdfDescription) > 0 {
 				description = line.PdfDescription
 			}
 			originalUnitAmountString := ""
 			if line.OriginUnitAmountExcludeTax != 0 {
-				originalUnitAmountString = fmt.Sprintf("(%s %s)", symbol, utility.ConvertCentToDollarStr(line.OriginUnitAmountExcludeTax, one.Currency))
+				originalUnitAmountString = fmt.Sprintf("(%s %s)", symbol, doc.FormatNumber(line.OriginUnitAmountExcludeTax, one.Currency))
 			}
 			doc.AppendItem(&generator2.Item{
 				Name:         fmt.Sprintf("%s #%d", description, i),
 				UnitCost:     fmt.Sprintf("%f", float64(line.UnitAmountExcludingTax)/100.0),
-				UnitCostStr:  fmt.Sprintf("%s%s%s", originalUnitAmountString, symbol, utility.ConvertCentToDollarStr(line.UnitAmountExcludingTax, one.Currency)),
+				UnitCostStr:  fmt.Sprintf("%s%s%s", originalUnitAmountString, symbol, doc.FormatNumber(line.UnitAmountExcludingTax, one.Currency)),
 				Quantity:     strconv.FormatInt(line.Quantity, 10),
 				TaxString:    taxString,
 				AmountString: amountString,
 			})
 		}
 	} else {
-		doc.Title = "TAX INVOICE"
+		doc.Title = doc.Options.TextTitleInvoice
 		if len(one.VatNumber) > 0 {
-			doc.Customer.AdditionalInfo = []string{fmt.Sprintf("VAT Number:%s", one.VatNumber)}
+			doc.Customer.AdditionalInfo = []string{fmt.Sprintf("%s:%s", doc.Options.TextVatNumber, one.VatNumber)}
 		}
 		for i, line := range one.Lines {
-			//amountString := fmt.Sprintf("%s%s", symbol, utility.ConvertCentToDollarStr(line.UnitAmountExcludingTax*line.Quantity+line.Tax, one.Currency))
-			amountString := fmt.Sprintf("%s%s", symbol, utility.ConvertCentToDollarStr(line.UnitAmountExcludingTax*line.Quantity, one.Currency)) // remove tax
+			//amountString := fmt.Sprintf("%s%s", symbol, doc.FormatNumber(line.UnitAmountExcludingTax*line.Quantity+line.Tax, one.Currency))
+			amountString := fmt.Sprintf("%s%s", symbol, doc.FormatNumber(line.UnitAmountExcludingTax*line.Quantity, one.Currency)) // remove tax
 			if line.MetricCharge != nil {
-				amountString = fmt.Sprintf("%s%s", symbol, utility.ConvertCentToDollarStr(line.AmountExcludingTax, one.Currency))
+				amountString = fmt.Sprintf("%s%s", symbol, doc.FormatNumber(line.AmountExcludingTax, one.Currency))
 			}
-			//taxString := fmt.Sprintf("%s %s%s", doc.TaxPercentageString, symbol, utility.ConvertCentToDollarStr(line.Tax, one.Currency))
+			//taxString := fmt.Sprintf("%s %s%s", doc.TaxPercentageString, symbol, doc.FormatNumber(line.Tax, one.Currency))
 			taxString := ""
+			if doc.Options.ShowLineTax {
+				taxString = doc.FormatAmount(line.Tax, one.Currency)
+			}
 			description := line.Description
 			if len(line.PdfDescription) > 0 {
 				description = line.PdfDescription
@@ -279,7 +297,7 @@ func createInvoicePdf(ctx context.Context, one *detail.InvoiceDetail, merchantIn
 			doc.AppendItem(&generator2.Item{
 				Name:         fmt.Sprintf("%s #%d", description, i),
 				UnitCost:     fmt.Sprintf("%f", float64(line.UnitAmountExcludingTax)/100.0),
-				UnitCostStr:  fmt.Sprintf("%s%s", symbol, utility.ConvertCentToDollarStr(line.UnitAmountExcludingTax, one.Currency)),
+				UnitCostStr:  fmt.Sprintf("%s%s", symbol, doc.FormatNumber(line.UnitAmountExcludingTax, one.Currency)),
 				Quantity:     strconv.FormatInt(line.Quantity, 10),
 				TaxString:    taxString,
 				AmountString: amountString,
@@ -289,34 +307,55 @@ func createInvoicePdf(ctx context.Context, one *detail.InvoiceDetail, merchantIn
 	doc.SetDefaultTax(&generator2.Tax{
 		Percent: utility.ConvertTaxPercentageToPercentageString(one.TaxPercentage),
 	})
-	doc.SubTotalString = fmt.Sprintf("%s%s", symbol, utility.ConvertCentToDollarStr(one.SubscriptionAmountExcludingTax, one.Currency))
+	doc.SubTotalString = fmt.Sprintf("%s%s", symbol, doc.FormatNumber(one.SubscriptionAmountExcludingTax, one.Currency))
 	if one.DiscountAmount != 0 {
 		if len(one.DiscountCode) > 0 {
-			doc.DiscountTitle = fmt.Sprintf("TOTAL DISCOUNTED(code: %s)", one.DiscountCode)
+			doc.DiscountTitle = fmt.Sprintf("%s(code: %s)", doc.Options.TextTotalDiscounted, one.DiscountCode)
 		}
 		originalDiscountString := ""
 		if len(one.RefundId) > 0 {
-			originalDiscountString = fmt.Sprintf("(%s %s)", symbol, utility.ConvertCentToDollarStr(-one.OriginalPaymentInvoice.DiscountAmount, one.Currency))
+			originalDiscountString = fmt.Sprintf("(%s %s)", symbol, doc.FormatNumber(-one.OriginalPaymentInvoice.DiscountAmount, one.Currency))
 		}
-		doc.DiscountTotalString = fmt.Sprintf("%s %s%s", symbol, utility.ConvertCentToDollarStr(-one.DiscountAmount, one.Currency), originalDiscountString)
+		doc.DiscountTotalString = fmt.Sprintf("%s %s%s", symbol, doc.FormatNumber(-one.DiscountAmount, one.Currency), originalDiscountString)
 	}
 	if one.PromoCreditDiscountAmount != 0 {
 		creditPayment := query.GetCreditPaymentByExternalCreditPaymentId(ctx, one.MerchantId, one.InvoiceId)
 		if creditPayment != nil {
-			doc.PromoCreditTitle = fmt.Sprintf("Promo Credits(%d)", creditPayment.TotalAmount)
+			doc.PromoCreditTitle = fmt.Sprintf("%s(%d)", doc.Options.TextPromoCredits, creditPayment.TotalAmount)
 		} else {
-			doc.PromoCreditTitle = fmt.Sprintf("Promo Credits")
+			doc.PromoCreditTitle = doc.Options.TextPromoCredits
 		}
 		originalPromoCreditString := ""
 		if len(one.RefundId) > 0 {
-			originalPromoCreditString = fmt.Sprintf("(%s %s)", symbol, utility.ConvertCentToDollarStr(-one.OriginalPaymentInvoice.PromoCreditDiscountAmount, one.Currency))
+			originalPromoCreditString = fmt.Sprintf("(%s %s)", symbol, doc.FormatNumber(-one.OriginalPaymentInvoice.PromoCreditDiscountAmount, one.Currency))
 		}
-		doc.PromoCreditString = fmt.Sprintf("%s %s%s", symbol, utility.ConvertCentToDollarStr(-one.PromoCreditDiscountAmount, one.Currency), originalPromoCreditString)
+		doc.PromoCreditString = fmt.Sprintf("%s %s%s", symbol, doc.FormatNumber(-one.PromoCreditDiscountAmount, one.Currency), originalPromoCreditString)
 	}
-	doc.TotalString = fmt.Sprintf("%s%s", symbol, utility.ConvertCentToDollarStr(one.TotalAmount, one.Currency))
-	doc.TaxString = fmt.Sprintf("%s%s", symbol, utility.ConvertCentToDollarStr(one.TaxAmount, one.Currency))
+	doc.TotalString = fmt.Sprintf("%s%s", symbol, doc.FormatNumber(one.TotalAmount, one.Currency))
+	doc.TaxString = fmt.Sprintf("%s%s", symbol, doc.FormatNumber(one.TaxAmount, one.Currency))
 	if len(one.RefundId) > 0 {
-		doc.OriginalTaxString = fmt.Sprintf("(%s %s)", symbol, utility.ConvertCentToDollarStr(one.OriginalPaymentInvoice.TaxAmount, one.Currency))
+		doc.OriginalTaxString = fmt.Sprintf("(%s %s)", symbol, doc.FormatNumber(one.OriginalPaymentInvoice.TaxAmount, one.Currency))
+	}
+	switch one.TaxTreatment {
+	case consts.InvoiceTaxTreatmentReverseCharge:
+		doc.TaxTitle = doc.Options.TextVatReverseCharge
+		doc.TaxTreatmentNote = doc.Options.TextReverseChargeNote
+	case consts.InvoiceTaxTreatmentExempt:
+		doc.TaxTitle = doc.Options.TextTaxExempt
+		doc.TaxTreatmentNote = doc.Options.TextTaxExemptNote
+		if one.UserSnapshot != nil && len(one.UserSnapshot.TaxExemptCertificate) > 0 {
+			doc.TaxTreatmentNote = fmt.Sprintf("%s %s", doc.TaxTreatmentNote, one.UserSnapshot.TaxExemptCertificate)
+		}
+	}
+	for _, taxLine := range one.TaxLines {
+		title := fmt.Sprintf("%s(%s%s)", taxLine.Name, utility.ConvertTaxPercentageToPercentageString(taxLine.Rate), "%")
+		if len(taxLine.Jurisdiction) > 0 {
+			title = fmt.Sprintf("%s %s", taxLine.Jurisdiction, title)
+		}
+		doc.TaxBreakdown = append(doc.TaxBreakdown, &generator2.TaxLine{
+			Title:        title,
+			AmountString: fmt.Sprintf("%s%s", symbol, doc.FormatNumber(taxLine.Amount, one.Currency)),
+		})
 	}
 
 	if localized {
@@ -325,9 +364,9 @@ func createInvoicePdf(ctx context.Context, one *detail.InvoiceDetail, merchantIn
 		} else {
 			doc.ExchangeRateString = fmt.Sprintf("* %s1 = %s%.6f", symbol, localizedSymbol, localizedExchangeRate)
 		}
-		doc.TaxString = fmt.Sprintf("%s | %s%s", doc.TaxString, localizedSymbol, utility.ConvertCentToDollarStr(int64(float64(one.TaxAmount)*localizedExchangeRate), localizedCurrencyStr))
+		doc.TaxString = fmt.Sprintf("%s | %s%s", doc.TaxString, localizedSymbol, doc.FormatNumber(int64(float64(one.TaxAmount)*localizedExchangeRate), localizedCurrencyStr))
 		if len(one.RefundId) > 0 {
-			doc.OriginalTaxString = fmt.Sprintf("(%s %s | %s%s)", symbol, utility.ConvertCentToDollarStr(one.OriginalPaymentInvoice.TaxAmount, one.Currency), localizedSymbol, utility.ConvertCentToDollarStr(int64(float64(one.OriginalPaymentInvoice.TaxAmount)*localizedExchangeRate), localizedCurrencyStr))
+			doc.OriginalTaxString = fmt.Sprintf("(%s %s | %s%s)", symbol, doc.FormatNumber(one.OriginalPaymentInvoice.TaxAmount, one.Currency), localizedSymbol, doc.FormatNumber(int64(float64(one.OriginalPaymentInvoice.TaxAmount)*localizedExchangeRate), localizedCurrencyStr))
 		}
 	}
 
@@ -337,6 +376,10 @@ func createInvoicePdf(ctx context.Context, one *detail.InvoiceDetail, merchantIn
 		return err
 	}
 
+	if eInvoiceConfig := einvoice.GetMerchantEInvoiceConfig(ctx, merchantInfo.Id); eInvoiceConfig.EmbedFacturX {
+		return createFacturXPdf(ctx, pdf, einvoice.NewDocument(one, merchantInfo, user, eInvoiceConfig), savePath)
+	}
+
 	err = pdf.OutputFileAndClose(savePath)
 
 	if err != nil {
@@ -346,6 +389,37 @@ func createInvoicePdf(ctx context.Context, one *detail.InvoiceDetail, merchantIn
 	return nil
 }
 
+// createFacturXPdf embeds the CII xml into the rendered PDF, the plain PDF is kept when the xml can't be rendered
+func createFacturXPdf(ctx context.Context, pdf *fpdf.Fpdf, doc *einvoice.Document, savePath string) error {
+	var buffer bytes.Buffer
+	err := pdf.Output(&buffer)
+	if err != nil {
+		g.Log().Errorf(ctx, "createFacturXPdf Output error:%s", err.Error())
+		return err
+	}
+	content := buffer.Bytes()
+	xmlContent, err := einvoice.RenderCII(doc)
+	if err == nil {
+		if results := einvoice.Validate(doc); einvoice.HasFatal(results) {
+			g.Log().Infof(ctx, "createFacturXPdf invoice:%s validation:%s", doc.Number, utility.MarshalToJsonString(results))
+		}
+		var facturX []byte
+		facturX, err = einvoice.AppendFacturX(content, doc, xmlContent)
+		if err == nil {
+			content = facturX
+		}
+	}
+	if err != nil {
+		g.Log().Errorf(ctx, "createFacturXPdf invoice:%s embed error:%s", doc.Number, err.Error())
+	}
+	err = os.WriteFile(savePath, content, 0644)
+	if err != nil {
+		g.Log().Errorf(ctx, "createFacturXPdf WriteFile error:%s", err.Error())
+		return err
+	}
+	return nil
+}
+
 func MustParseCurrencySymbolValue(currencyCode string, centAmount int64) string {
 	cur := currency.MustParseISO(strings.ToUpper(currencyCode))
 	amountInYuan := float64(centAmount) / 100.0
@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	"unibee/api/bean"
+	"unibee/api/bean/detail"
+	"unibee/internal/consts"
+	generator2 "unibee/internal/logic/invoice/handler/generator"
+	"unibee/internal/logic/merchant_config"
+	"unibee/internal/logic/merchant_config/update"
+	"unibee/internal/logic/oss"
+	entity "unibee/internal/model/entity/default"
+	"unibee/internal/query"
+	"unibee/utility"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+const MerchantInvoicePdfTemplateConfig = "KEY_MERCHANT_INVOICE_PDF_TEMPLATE"
+
+// GetMerchantInvoicePdfTemplate returns the saved template of merchant, nil keeps the default layout
+func GetMerchantInvoicePdfTemplate(ctx context.Context, merchantId uint64) *generator2.Template {
+	one := merchant_config.GetMerchantConfig(ctx, merchantId, MerchantInvoicePdfTemplateConfig)
+	if one == nil || len(one.ConfigValue) == 0 {
+		return nil
+	}
+	template := &generator2.Template{}
+	if err := utility.UnmarshalFromJsonString(one.ConfigValue, template); err != nil {
+		g.Log().Errorf(ctx, "GetMerchantInvoicePdfTemplate merchantId:%d error:%s", merchantId, err.Error())
+		return nil
+	}
+	return template
+}
+
+func SetupMerchantInvoicePdfTemplate(ctx context.Context, merchantId uint64, template *generator2.Template) error {
+	utility.Assert(template != nil, "invalid template")
+	utility.AssertError(template.Check(), "invalid template")
+	return update.SetMerchantConfig(ctx, merchantId, MerchantInvoicePdfTemplateConfig, utility.MarshalToJsonString(template))
+}
+
+// GenerateInvoicePdfPreview renders a sample invoice of merchant with the template and returns the uploaded file url
+func GenerateInvoicePdfPreview(ctx context.Context, merchantId uint64, template *generator2.Template) (string, error) {
+	merchantInfo := query.GetMerchantById(ctx, merchantId)
+	utility.Assert(merchantInfo != nil, "merchant not found")
+	if template != nil {
+		utility.AssertError(template.Check(), "invalid template")
+	}
+	var savePath = fmt.Sprintf("preview_%d_%d.pdf", merchantId, gtime.Now().TimestampMilli())
+	err := createInvoicePdf(ctx, samplePreviewInvoice(merchantInfo), merchantInfo, samplePreviewUser(), nil, template, savePath)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = os.Remove(savePath)
+	}()
+	upload, err := oss.UploadLocalFile(ctx, savePath, "invoice_preview", savePath, strconv.FormatUint(merchantId, 10))
+	if err != nil {
+		return "", err
+	}
+	return upload.Url, nil
+}
+
+func samplePreviewInvoice(merchantInfo *entity.Merchant) *detail.InvoiceDetail {
+	var now = gtime.Now()
+	return &detail.InvoiceDetail{
+		InvoiceId:                      "PREVIEW",
+		InvoiceNumber:                  "PREVIEW-000001",
+		GmtCreate:                      now,
+		GmtModify:                      now,
+		Currency:                       "EUR",
+		TotalAmount:                    146370,
+		TaxAmount:                      23370,
+		TaxPercentage:                  1900,
+		SubscriptionAmountExcludingTax: 123000,
+		CountryCode:                    merchantInfo.CountryCode,
+		Status:                         consts.InvoiceStatusPaid,
+		Lines: []*bean.InvoiceItemSimplify{
+			{
+				Currency:               "EUR",
+				Amount:                 119000,
+				AmountExcludingTax:     100000,
+				Tax:                    19000,
+				UnitAmountExcludingTax: 10000,
+				Description:            "10 * Pro Plan (Monthly)",
+				Quantity:               10,
+				PeriodStart:            now.Timestamp(),
+				PeriodEnd:              now.AddDate(0, 1, 0).Timestamp(),
+			},
+			{
+				Currency:               "EUR",
+				Amount:                 27370,
+				AmountExcludingTax:     23000,
+				Tax:                    4370,
+				UnitAmountExcludingTax: 2300,
+				Description:            "10 * Storage Addon (Monthly)",
+				Quantity:               10,
+				PeriodStart:            now.Timestamp(),
+				PeriodEnd:              now.AddDate(0, 1, 0).Timestamp(),
+			},
+		},
+		Metadata: map[string]interface{}{"ShowDetailItem": true},
+	}
+}
+
+func samplePreviewUser() *entity.UserAccount {
+	return &entity.UserAccount{
+		Email:       "customer@example.com",
+		FirstName:   "Jane",
+		LastName:    "Doe",
+		Address:     "1 Sample Street",
+		City:        "Berlin",
+		ZipCode:     "10115",
+		CountryName: "Germany",
+		VATNumber:   "DE123456789",
+	}
+}
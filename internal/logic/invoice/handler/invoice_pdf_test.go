@@ -104,7 +104,7 @@ func TestGenerate(t *testing.T) {
 		City:               "Hangzhou",
 		RegistrationNumber: "Regxxxddd",
 		VATNumber:          "EE101775690",
-	}, nil, savePath)
+	}, nil, nil, savePath)
 	if err != nil {
 		fmt.Printf("err :%s", err.Error())
 	}
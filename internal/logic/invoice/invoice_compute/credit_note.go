@@ -0,0 +1,78 @@
+package invoice_compute
+
+import (
+	"context"
+	"fmt"
+
+	"unibee/api/bean"
+	"unibee/internal/consts"
+	entity "unibee/internal/model/entity/default"
+	"unibee/internal/query"
+	"unibee/utility"
+
+	"github.com/gogf/gf/v2/encoding/gjson"
+)
+
+// CreateInvoiceSimplifyForCreditNote builds the refund invoice of the credit note from its credited lines,
+// the refund invoice takes the credit note number once the refund is accepted, so the credit note series is not consumed twice
+func CreateInvoiceSimplifyForCreditNote(ctx context.Context, payment *entity.Payment, refund *entity.Refund, creditNote *entity.CreditNote) *bean.Invoice {
+	originalInvoice := query.GetInvoiceByInvoiceId(ctx, creditNote.InvoiceId)
+	utility.Assert(originalInvoice != nil, "Credit Note Invoice Not found")
+	utility.Assert(refund.RefundAmount == creditNote.TotalAmount, "refund amount not match the credit note")
+	var creditItems []*bean.CreditNoteItem
+	err := utility.UnmarshalFromJsonString(creditNote.Lines, &creditItems)
+	utility.AssertError(err, "invalid credit note lines")
+	var items = make([]*bean.InvoiceItemSimplify, 0)
+	var totalDiscountAmount int64 = 0
+	for _, creditItem := range creditItems {
+		item := creditItem.InvoiceItemSimplify
+		item.OriginUnitAmountExcludeTax = item.UnitAmountExcludingTax
+		item.Amount = -item.Amount
+		item.Tax = -item.Tax
+		item.AmountExcludingTax = -item.AmountExcludingTax
+		item.UnitAmountExcludingTax = -item.UnitAmountExcludingTax
+		item.DiscountAmount = -item.DiscountAmount
+		item.OriginAmount = -item.OriginAmount
+		totalDiscountAmount = totalDiscountAmount + item.DiscountAmount
+		items = append(items, &item)
+	}
+	var refundType = "Partial Refund"
+	if payment.TotalAmount == refund.RefundAmount {
+		refundType = "Full Refund"
+	}
+	var metadata = make(map[string]interface{})
+	if len(originalInvoice.MetaData) > 0 {
+		err = gjson.Unmarshal([]byte(originalInvoice.MetaData), &metadata)
+		if err != nil {
+			fmt.Printf("Unmarshal Metadata error:%s", err.Error())
+		}
+	}
+	metadata["CreditNoteId"] = creditNote.CreditNoteId
+
+	return &bean.Invoice{
+		InvoiceName:                    "Credit Note",
+		InvoiceNumber:                  creditNote.CreditNoteNumber,
+		ProductName:                    originalInvoice.ProductName,
+		BizType:                        originalInvoice.BizType,
+		Currency:                       originalInvoice.Currency,
+		OriginAmount:                   -creditNote.TotalAmount + totalDiscountAmount,
+		TaxAmount:                      -creditNote.TaxAmount,
+		TotalAmount:                    -creditNote.TotalAmount,
+		TotalAmountExcludingTax:        -creditNote.TotalAmountExcludingTax,
+		SubscriptionAmount:             -creditNote.TotalAmount,
+		SubscriptionAmountExcludingTax: -creditNote.TotalAmountExcludingTax,
+		CountryCode:                    originalInvoice.CountryCode,
+		VatNumber:                      originalInvoice.VatNumber,
+		TaxPercentage:                  originalInvoice.TaxPercentage,
+		DiscountAmount:                 totalDiscountAmount,
+		DiscountCode:                   originalInvoice.DiscountCode,
+		SendStatus:                     consts.InvoiceSendStatusUnSend,
+		DayUtilDue:                     consts.DEFAULT_DAY_UTIL_DUE,
+		Lines:                          items,
+		SendNote:                       fmt.Sprintf("%s (%s)", originalInvoice.InvoiceId, refundType),
+		PaymentId:                      payment.PaymentId,
+		RefundId:                       refund.RefundId,
+		Data:                           originalInvoice.Data,
+		Metadata:                       metadata,
+	}
+}
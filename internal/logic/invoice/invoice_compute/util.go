@@ -11,6 +11,7 @@ import (
 	"unibee/internal/logic/discount"
 	"unibee/internal/logic/plan/period"
 	addon2 "unibee/internal/logic/subscription/addon"
+	"unibee/internal/logic/tax"
 	entity "unibee/internal/model/entity/default"
 	"unibee/internal/query"
 	"unibee/utility"
@@ -41,6 +42,54 @@ type CalculateInvoiceReq struct {
 	ApplyPromoCredit           bool                                    `json:"applyPromoCredit" dc:"apply promo credit or not"`
 	ApplyPromoCreditAmount     *int64                                  `json:"applyPromoCreditAmount"  dc:"apply promo credit amount, auto compute if not specified"`
 	UserMetricChargeForInvoice *bean.UserMetricChargeInvoiceItemEntity `json:"userMetricChargeForInvoice"`
+	ProrationScale             int64                                   `json:"prorationScale" dc:"proration scale of the plan and addons for a shortened period，10000 = 100%, 0-no proration"`
+	AdditionalItems            []*AdditionalInvoiceItem                `json:"additionalItems" dc:"additional items charged with the cycle invoice, like the true-up of seats"`
+}
+
+type AdditionalInvoiceItem struct {
+	Name                   string `json:"name"`
+	Description            string `json:"description"`
+	UnitAmountExcludingTax int64  `json:"unitAmountExcludingTax"`
+	Quantity               int64  `json:"quantity"`
+	Proration              bool   `json:"proration"`
+	PlanId                 uint64 `json:"planId" dc:"plan of the item, 0 if not plan-based"`
+	TaxPercentage          *int64 `json:"taxPercentage" dc:"TaxPercentage override of the item，1000 = 10%, follow the invoice if not specified"`
+	CarryForwardAmount     int64  `json:"carryForwardAmount" dc:"the credit of the item over the invoice amount, excluding tax, carried forward to the next invoice"`
+}
+
+// CreditAmount returns the credit of the item before capped, excluding tax, 0 if the item is a charge
+func (item *AdditionalInvoiceItem) CreditAmount() int64 {
+	var amount = item.UnitAmountExcludingTax*item.Quantity + item.CarryForwardAmount
+	if amount >= 0 {
+		return 0
+	}
+	return -amount
+}
+
+// CapCreditItems caps the credit items in order so the credits applied do not exceed the charge amount of the invoice,
+// a capped item charges the credit applied as a single unit and keeps the rest as CarryForwardAmount,
+// capping the items capped before starts over from their credit before capped
+func CapCreditItems(items []*AdditionalInvoiceItem, chargeAmount int64) {
+	var remaining = utility.MaxInt64(chargeAmount, 0)
+	for _, item := range items {
+		credit := item.CreditAmount()
+		if credit == 0 {
+			continue
+		}
+		applied := utility.MinInt64(credit, remaining)
+		remaining = remaining - applied
+		if applied == credit {
+			if item.CarryForwardAmount != 0 {
+				item.UnitAmountExcludingTax = -credit
+				item.Quantity = 1
+				item.CarryForwardAmount = 0
+			}
+			continue
+		}
+		item.UnitAmountExcludingTax = -applied
+		item.Quantity = 1
+		item.CarryForwardAmount = applied - credit
+	}
 }
 
 func VerifyInvoiceSimplify(one *bean.Invoice) {
@@ -53,7 +102,7 @@ func VerifyInvoiceSimplify(one *bean.Invoice) {
 		totalOriginAmount = totalOriginAmount - item.OriginAmount
 		totalTax = totalTax - item.Tax
 		totalDiscountAmount = totalDiscountAmount - item.DiscountAmount
-		utility.Assert(one.TaxPercentage == item.TaxPercentage, "taxPercentage is not match")
+		utility.Assert(len(item.TaxLines) > 0 || one.TaxPercentage == item.TaxPercentage, "taxPercentage is not match")
 		utility.Assert(item.AmountExcludingTax == item.UnitAmountExcludingTax*item.Quantity-item.DiscountAmount, "item AmountExcludingTax not match unit*quantity-discount")
 		utility.Assert(one.Currency == item.Currency, "currency not match")
 	}
@@ -79,7 +128,7 @@ func VerifyInvoice(one *entity.Invoice) {
 		totalOriginAmount = totalOriginAmount - item.OriginAmount
 		totalTax = totalTax - item.Tax
 		totalDiscountAmount = totalDiscountAmount - item.DiscountAmount
-		utility.Assert(one.TaxPercentage == item.TaxPercentage, "taxPercentage is not match")
+		utility.Assert(len(item.TaxLines) > 0 || one.TaxPercentage == item.TaxPercentage, "taxPercentage is not match")
 		utility.Assert(item.AmountExcludingTax == item.UnitAmountExcludingTax*item.Quantity-item.DiscountAmount, "item AmountExcludingTax not match unit*quantity-discount")
 		utility.Assert(one.Currency == item.Currency, "currency not match")
 	}
@@ -246,9 +295,15 @@ func ComputeSubscriptionBillingCycleInvoiceDetailSimplify(ctx context.Context, r
 	plan := query.GetPlanById(ctx, req.PlanId)
 	utility.Assert(plan != nil, fmt.Sprintf("plan not found:%d", req.PlanId))
 	addons := addon2.GetSubscriptionAddonsByAddonJson(ctx, req.AddonJsonData)
-	var totalAmountExcludingTax = plan.CurrencyAmount(ctx, req.Currency) * req.Quantity
+	var isProration = req.ProrationScale > 0 && req.ProrationScale < 10000
+	var totalAmountExcludingTax = ProrateUnitAmount(plan.CurrencyAmount(ctx, req.Currency), req.ProrationScale) * req.Quantity
 	for _, addon := range addons {
-		totalAmountExcludingTax = totalAmountExcludingTax + addon.AddonPlan.CurrencyAmount(ctx, req.Currency)*addon.Quantity
+		totalAmountExcludingTax = totalAmountExcludingTax + ProrateUnitAmount(addon.AddonPlan.CurrencyAmount(ctx, req.Currency), req.ProrationScale)*addon.Quantity
+	}
+	for _, item := range req.AdditionalItems {
+		if item.CreditAmount() == 0 {
+			totalAmountExcludingTax = totalAmountExcludingTax + item.UnitAmountExcludingTax*item.Quantity
+		}
 	}
 	if req.UserMetricChargeForInvoice != nil && len(req.UserMetricChargeForInvoice.MeteredChargeStats) > 0 {
 		for _, metricCharge := range req.UserMetricChargeForInvoice.MeteredChargeStats {
@@ -260,6 +315,13 @@ func ComputeSubscriptionBillingCycleInvoiceDetailSimplify(ctx context.Context, r
 			totalAmountExcludingTax = totalAmountExcludingTax + metricCharge.TotalChargeAmount
 		}
 	}
+	// the credit items apply up to the charges, the rest carries forward to the next invoice
+	CapCreditItems(req.AdditionalItems, totalAmountExcludingTax)
+	for _, item := range req.AdditionalItems {
+		if item.CreditAmount() > 0 {
+			totalAmountExcludingTax = totalAmountExcludingTax + item.UnitAmountExcludingTax*item.Quantity
+		}
+	}
 
 	var period = ""
 	if req.PeriodStart > 0 && req.PeriodEnd > req.PeriodStart {
@@ -267,7 +329,7 @@ func ComputeSubscriptionBillingCycleInvoiceDetailSimplify(ctx context.Context, r
 	}
 
 	var invoiceItems []*bean.InvoiceItemSimplify
-	var planAmountExcludingTax = req.Quantity * plan.CurrencyAmount(ctx, req.Currency)
+	var planAmountExcludingTax = req.Quantity * ProrateUnitAmount(plan.CurrencyAmount(ctx, req.Currency), req.ProrationScale)
 	var planTaxAmount = int64(math.Round(float64(planAmountExcludingTax) * utility.ConvertTaxPercentageToInternalFloat(req.TaxPercentage)))
 	var name = plan.PlanName
 	var description = fmt.Sprintf("%d * %s %s", req.Quantity, plan.PlanName, period)
@@ -284,15 +346,16 @@ func ComputeSubscriptionBillingCycleInvoiceDetailSimplify(ctx context.Context, r
 		Tax:                    planTaxAmount,
 		TaxPercentage:          req.TaxPercentage,
 		AmountExcludingTax:     planAmountExcludingTax,
-		UnitAmountExcludingTax: plan.CurrencyAmount(ctx, req.Currency),
+		UnitAmountExcludingTax: ProrateUnitAmount(plan.CurrencyAmount(ctx, req.Currency), req.ProrationScale),
 		Quantity:               req.Quantity,
 		Name:                   name,
 		Description:            description,
 		PdfDescription:         fmt.Sprintf("%d * %s %s", req.Quantity, plan.PlanName, period),
+		Proration:              isProration,
 		Plan:                   bean.SimplifyPlan(plan),
 	})
 	for _, addon := range addons {
-		var addonAmountExcludingTax = addon.Quantity * addon.AddonPlan.CurrencyAmount(ctx, req.Currency)
+		var addonAmountExcludingTax = addon.Quantity * ProrateUnitAmount(addon.AddonPlan.CurrencyAmount(ctx, req.Currency), req.ProrationScale)
 		var addonTaxAmount = int64(math.Round(float64(addonAmountExcludingTax) * utility.ConvertTaxPercentageToInternalFloat(req.TaxPercentage)))
 		invoiceItems = append(invoiceItems, &bean.InvoiceItemSimplify{
 			Currency:               req.Currency,
@@ -301,13 +364,44 @@ func ComputeSubscriptionBillingCycleInvoiceDetailSimplify(ctx context.Context, r
 			Tax:                    addonTaxAmount,
 			TaxPercentage:          req.TaxPercentage,
 			AmountExcludingTax:     addonAmountExcludingTax,
-			UnitAmountExcludingTax: addon.AddonPlan.CurrencyAmount(ctx, req.Currency),
+			UnitAmountExcludingTax: ProrateUnitAmount(addon.AddonPlan.CurrencyAmount(ctx, req.Currency), req.ProrationScale),
 			Quantity:               addon.Quantity,
 			Name:                   addon.AddonPlan.PlanName,
 			Description:            fmt.Sprintf("%d * %s %s", addon.Quantity, addon.AddonPlan.PlanName, period),
+			Proration:              isProration,
 			Plan:                   addon.AddonPlan,
 		})
 	}
+	var taxOverrides = make(map[int]int64)
+	for _, item := range req.AdditionalItems {
+		var itemAmountExcludingTax = item.UnitAmountExcludingTax * item.Quantity
+		if itemAmountExcludingTax == 0 && item.CarryForwardAmount != 0 {
+			// the credit carried forward entirely
+			continue
+		}
+		var itemTaxAmount = int64(math.Round(float64(itemAmountExcludingTax) * utility.ConvertTaxPercentageToInternalFloat(req.TaxPercentage)))
+		var itemPlan *bean.Plan
+		if item.PlanId > 0 {
+			itemPlan = bean.SimplifyPlan(query.GetPlanById(ctx, item.PlanId))
+		}
+		if item.TaxPercentage != nil {
+			taxOverrides[len(invoiceItems)] = *item.TaxPercentage
+		}
+		invoiceItems = append(invoiceItems, &bean.InvoiceItemSimplify{
+			Currency:               req.Currency,
+			OriginAmount:           itemAmountExcludingTax + itemTaxAmount,
+			Amount:                 itemAmountExcludingTax + itemTaxAmount,
+			Tax:                    itemTaxAmount,
+			TaxPercentage:          req.TaxPercentage,
+			AmountExcludingTax:     itemAmountExcludingTax,
+			UnitAmountExcludingTax: item.UnitAmountExcludingTax,
+			Quantity:               item.Quantity,
+			Name:                   item.Name,
+			Description:            item.Description,
+			Proration:              item.Proration,
+			Plan:                   itemPlan,
+		})
+	}
 
 	if req.UserMetricChargeForInvoice != nil && len(req.UserMetricChargeForInvoice.MeteredChargeStats) > 0 {
 		for _, metricCharge := range req.UserMetricChargeForInvoice.MeteredChargeStats {
@@ -407,14 +501,14 @@ func ComputeSubscriptionBillingCycleInvoiceDetailSimplify(ctx context.Context, r
 		}
 	}
 
-	discountAmount := utility.MinInt64(discount.ComputeDiscountAmount(ctx, query.GetDiscountByCode(ctx, plan.MerchantId, req.DiscountCode), totalAmountExcludingTax, req.Currency, req.TimeNow), totalAmountExcludingTax)
+	discountAmount := utility.MaxInt64(utility.MinInt64(discount.ComputeDiscountAmount(ctx, query.GetDiscountByCode(ctx, plan.MerchantId, req.DiscountCode), totalAmountExcludingTax, req.Currency, req.TimeNow), totalAmountExcludingTax), 0)
 	totalAmountExcludingTax = totalAmountExcludingTax - discountAmount
 	totalDiscountAmount += discountAmount
 
 	var taxAmount = int64(math.Round(float64(totalAmountExcludingTax) * utility.ConvertTaxPercentageToInternalFloat(req.TaxPercentage)))
 	prorationCompensateTotalToItems(totalDiscountAmount, taxAmount, invoiceItems)
 
-	return &bean.Invoice{
+	invoice := &bean.Invoice{
 		BizType:                        consts.BizTypeSubscription,
 		InvoiceName:                    req.InvoiceName,
 		ProductName:                    plan.PlanName,
@@ -434,6 +528,7 @@ func ComputeSubscriptionBillingCycleInvoiceDetailSimplify(ctx context.Context, r
 		SubscriptionAmount:             totalAmountExcludingTax + discountAmount + promoCreditDiscountAmount + taxAmount,
 		SubscriptionAmountExcludingTax: totalAmountExcludingTax + discountAmount + promoCreditDiscountAmount,
 		Lines:                          invoiceItems,
+		ProrationScale:                 req.ProrationScale,
 		PeriodStart:                    req.PeriodStart,
 		PeriodEnd:                      req.PeriodEnd,
 		FinishTime:                     req.FinishTime,
@@ -444,6 +539,53 @@ func ComputeSubscriptionBillingCycleInvoiceDetailSimplify(ctx context.Context, r
 		CreateFrom:                     req.CreateFrom,
 		UserMetricChargeForInvoice:     req.UserMetricChargeForInvoice,
 	}
+	if len(taxOverrides) > 0 {
+		// the tax override of the items wins the tax engine, the reverse charge or exemption still applies
+		tax.ApplyTaxEngine(ctx, plan.MerchantId, req.UserId, invoice)
+		ApplyLineTaxOverrides(invoice, taxOverrides)
+		tax.ApplyTaxTreatment(invoice, tax.ResolveTaxTreatment(ctx, plan.MerchantId, req.UserId, invoice.VatNumber))
+	} else {
+		tax.ApplyInvoiceTax(ctx, plan.MerchantId, req.UserId, invoice)
+	}
+	return invoice
+}
+
+// ApplyLineTaxOverrides recomputes the tax of the invoice lines by index with their tax percentage override,
+// the override shows as the tax line of the line and the invoice totals move by the tax difference
+func ApplyLineTaxOverrides(invoice *bean.Invoice, overrides map[int]int64) {
+	if invoice == nil {
+		return
+	}
+	for index, taxPercentage := range overrides {
+		if index < 0 || index >= len(invoice.Lines) {
+			continue
+		}
+		line := invoice.Lines[index]
+		net := line.Amount - line.Tax
+		lineTax := int64(math.Round(float64(net) * utility.ConvertTaxPercentageToInternalFloat(taxPercentage)))
+		delta := lineTax - line.Tax
+		line.Tax = lineTax
+		line.Amount = net + lineTax
+		line.OriginAmount = line.Amount + line.DiscountAmount
+		line.TaxPercentage = taxPercentage
+		line.TaxLines = []*bean.InvoiceItemTaxLine{{
+			Name:   "Tax",
+			Rate:   taxPercentage,
+			Amount: lineTax,
+		}}
+		invoice.TaxAmount = invoice.TaxAmount + delta
+		invoice.TotalAmount = invoice.TotalAmount + delta
+		invoice.OriginAmount = invoice.OriginAmount + delta
+		invoice.SubscriptionAmount = invoice.SubscriptionAmount + delta
+	}
+}
+
+// ProrateUnitAmount scales the unit amount by the proration scale, 10000 = 100%
+func ProrateUnitAmount(unitAmount int64, prorationScale int64) int64 {
+	if prorationScale <= 0 || prorationScale >= 10000 {
+		return unitAmount
+	}
+	return int64(math.Round(float64(unitAmount) * utility.ConvertTaxPercentageToInternalFloat(prorationScale)))
 }
 
 type ProrationPlanParam struct {
@@ -612,7 +754,7 @@ func ComputeSubscriptionProrationToFixedEndInvoiceDetailSimplify(ctx context.Con
 	totalDiscountAmount += discountAmount
 	var taxAmount = int64(math.Round(float64(totalAmountExcludingTax) * utility.ConvertTaxPercentageToInternalFloat(req.TaxPercentage)))
 	prorationCompensateTotalToItems(totalDiscountAmount, taxAmount, invoiceItems)
-	return &bean.Invoice{
+	invoice := &bean.Invoice{
 		BizType:                        consts.BizTypeSubscription,
 		InvoiceName:                    req.InvoiceName,
 		ProductName:                    req.ProductName,
@@ -642,6 +784,8 @@ func ComputeSubscriptionProrationToFixedEndInvoiceDetailSimplify(ctx context.Con
 		BillingCycleAnchor:             req.BillingCycleAnchor,
 		Metadata:                       req.Metadata,
 	}
+	tax.ApplyInvoiceTax(ctx, merchantId, req.UserId, invoice)
+	return invoice
 }
 
 func ComputeSubscriptionProrationToDifferentIntervalInvoiceDetailSimplify(ctx context.Context, req *CalculateProrationInvoiceReq) *bean.Invoice {
@@ -748,7 +892,7 @@ func ComputeSubscriptionProrationToDifferentIntervalInvoiceDetailSimplify(ctx co
 	totalDiscountAmount += discountAmount
 	var taxAmount = int64(math.Round(float64(totalAmountExcludingTax) * utility.ConvertTaxPercentageToInternalFloat(req.TaxPercentage)))
 	prorationCompensateTotalToItems(totalDiscountAmount, taxAmount, invoiceItems)
-	return &bean.Invoice{
+	invoice := &bean.Invoice{
 		BizType:                        consts.BizTypeSubscription,
 		InvoiceName:                    req.InvoiceName,
 		ProductName:                    req.ProductName,
@@ -778,6 +922,8 @@ func ComputeSubscriptionProrationToDifferentIntervalInvoiceDetailSimplify(ctx co
 		BillingCycleAnchor:             req.BillingCycleAnchor,
 		Metadata:                       req.Metadata,
 	}
+	tax.ApplyInvoiceTax(ctx, merchantId, req.UserId, invoice)
+	return invoice
 }
 
 func prorationCompensateTotalToItems(totalDiscountAmount int64, totalTaxAmount int64, items []*bean.InvoiceItemSimplify) {
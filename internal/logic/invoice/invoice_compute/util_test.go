@@ -5,11 +5,14 @@ import (
 	"math"
 	"testing"
 	"unibee/api/bean"
+	"unibee/internal/consts"
+	"unibee/internal/logic/tax"
 	"unibee/test"
 	"unibee/utility"
 
 	"github.com/gogf/gf/v2/frame/g"
 	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/stretchr/testify/require"
 )
 
 func TestInvoiceSimplifyCreation(t *testing.T) {
@@ -52,3 +55,131 @@ func TestInvoiceCalculate(t *testing.T) {
 		g.Log().Infof(ctx, "Total Tax %d", taxAmount)
 	})
 }
+
+func TestCapCreditItems(t *testing.T) {
+	t.Run("credits within the charges apply in full", func(t *testing.T) {
+		items := []*AdditionalInvoiceItem{
+			{UnitAmountExcludingTax: 500, Quantity: 2},
+			{UnitAmountExcludingTax: -300, Quantity: 2},
+		}
+		CapCreditItems(items, 2000)
+		require.Equal(t, int64(500), items[0].UnitAmountExcludingTax)
+		require.Equal(t, int64(-300), items[1].UnitAmountExcludingTax)
+		require.Equal(t, int64(2), items[1].Quantity)
+		require.Equal(t, int64(0), items[1].CarryForwardAmount)
+	})
+	t.Run("credit over the charges carries forward", func(t *testing.T) {
+		items := []*AdditionalInvoiceItem{
+			{UnitAmountExcludingTax: -400, Quantity: 1},
+			{UnitAmountExcludingTax: -1000, Quantity: 2},
+			{UnitAmountExcludingTax: -100, Quantity: 1},
+		}
+		CapCreditItems(items, 1000)
+		require.Equal(t, int64(-400), items[0].UnitAmountExcludingTax)
+		require.Equal(t, int64(0), items[0].CarryForwardAmount)
+		require.Equal(t, int64(-600), items[1].UnitAmountExcludingTax)
+		require.Equal(t, int64(1), items[1].Quantity)
+		require.Equal(t, int64(-1400), items[1].CarryForwardAmount)
+		// no charge left, carries forward entirely
+		require.Equal(t, int64(0), items[2].UnitAmountExcludingTax)
+		require.Equal(t, int64(-100), items[2].CarryForwardAmount)
+		var applied int64
+		for _, item := range items {
+			applied = applied + item.UnitAmountExcludingTax*item.Quantity
+		}
+		require.Equal(t, int64(-1000), applied)
+	})
+	t.Run("capping again starts over from the credit before capped", func(t *testing.T) {
+		items := []*AdditionalInvoiceItem{{UnitAmountExcludingTax: -1000, Quantity: 1}}
+		CapCreditItems(items, 300)
+		require.Equal(t, int64(-300), items[0].UnitAmountExcludingTax)
+		require.Equal(t, int64(-700), items[0].CarryForwardAmount)
+		CapCreditItems(items, 800)
+		require.Equal(t, int64(-800), items[0].UnitAmountExcludingTax)
+		require.Equal(t, int64(-200), items[0].CarryForwardAmount)
+		CapCreditItems(items, 5000)
+		require.Equal(t, int64(-1000), items[0].UnitAmountExcludingTax)
+		require.Equal(t, int64(0), items[0].CarryForwardAmount)
+	})
+	t.Run("negative charge amount applies no credit", func(t *testing.T) {
+		items := []*AdditionalInvoiceItem{{UnitAmountExcludingTax: -100, Quantity: 1}}
+		CapCreditItems(items, -50)
+		require.Equal(t, int64(0), items[0].UnitAmountExcludingTax)
+		require.Equal(t, int64(-100), items[0].CarryForwardAmount)
+	})
+}
+
+func overrideTestInvoice() *bean.Invoice {
+	// two lines at 10%, 10000 and 2000 excluding tax
+	return &bean.Invoice{
+		OriginAmount:            13200,
+		TotalAmount:             13200,
+		TotalAmountExcludingTax: 12000,
+		TaxAmount:               1200,
+		TaxPercentage:           1000,
+		SubscriptionAmount:      13200,
+		Lines: []*bean.InvoiceItemSimplify{
+			{Amount: 11000, Tax: 1000, OriginAmount: 11000, AmountExcludingTax: 10000, TaxPercentage: 1000},
+			{Amount: 2200, Tax: 200, OriginAmount: 2200, AmountExcludingTax: 2000, TaxPercentage: 1000},
+		},
+	}
+}
+
+func TestApplyLineTaxOverrides(t *testing.T) {
+	t.Run("override moves the line and the totals by the tax difference", func(t *testing.T) {
+		invoice := overrideTestInvoice()
+		ApplyLineTaxOverrides(invoice, map[int]int64{1: 0})
+		line := invoice.Lines[1]
+		require.Equal(t, int64(0), line.Tax)
+		require.Equal(t, int64(2000), line.Amount)
+		require.Equal(t, int64(2000), line.OriginAmount)
+		require.Equal(t, int64(0), line.TaxPercentage)
+		require.Len(t, line.TaxLines, 1)
+		require.Equal(t, int64(0), line.TaxLines[0].Amount)
+		// the other line untouched
+		require.Equal(t, int64(1000), invoice.Lines[0].Tax)
+		require.Equal(t, int64(1000), invoice.TaxAmount)
+		require.Equal(t, int64(13000), invoice.TotalAmount)
+		require.Equal(t, int64(13000), invoice.OriginAmount)
+		require.Equal(t, int64(13000), invoice.SubscriptionAmount)
+		require.Equal(t, int64(12000), invoice.TotalAmountExcludingTax)
+	})
+	t.Run("override of a credit line", func(t *testing.T) {
+		invoice := overrideTestInvoice()
+		invoice.Lines[1] = &bean.InvoiceItemSimplify{Amount: -2200, Tax: -200, OriginAmount: -2200, AmountExcludingTax: -2000, TaxPercentage: 1000}
+		invoice.TotalAmountExcludingTax = 8000
+		invoice.TaxAmount = 800
+		invoice.TotalAmount = 8800
+		invoice.OriginAmount = 8800
+		invoice.SubscriptionAmount = 8800
+		ApplyLineTaxOverrides(invoice, map[int]int64{1: 2000})
+		require.Equal(t, int64(-400), invoice.Lines[1].Tax)
+		require.Equal(t, int64(-2400), invoice.Lines[1].Amount)
+		require.Equal(t, int64(600), invoice.TaxAmount)
+		require.Equal(t, int64(8600), invoice.TotalAmount)
+	})
+	t.Run("index out of lines ignored", func(t *testing.T) {
+		invoice := overrideTestInvoice()
+		ApplyLineTaxOverrides(invoice, map[int]int64{-1: 0, 2: 0})
+		require.Equal(t, int64(1200), invoice.TaxAmount)
+		require.Equal(t, int64(13200), invoice.TotalAmount)
+		ApplyLineTaxOverrides(nil, map[int]int64{0: 0})
+	})
+	t.Run("reverse charge removes the tax of the override", func(t *testing.T) {
+		invoice := overrideTestInvoice()
+		ApplyLineTaxOverrides(invoice, map[int]int64{1: 2000})
+		require.Equal(t, int64(1400), invoice.TaxAmount)
+		require.Equal(t, int64(13400), invoice.TotalAmount)
+		tax.ApplyTaxTreatment(invoice, consts.InvoiceTaxTreatmentReverseCharge)
+		require.Equal(t, consts.InvoiceTaxTreatmentReverseCharge, invoice.TaxTreatment)
+		require.Equal(t, int64(0), invoice.TaxAmount)
+		require.Equal(t, int64(12000), invoice.TotalAmount)
+		require.Equal(t, int64(12000), invoice.OriginAmount)
+		require.Equal(t, int64(12000), invoice.SubscriptionAmount)
+		for _, line := range invoice.Lines {
+			require.Equal(t, int64(0), line.Tax)
+			require.Equal(t, line.AmountExcludingTax, line.Amount)
+			require.Nil(t, line.TaxLines)
+		}
+	})
+}
@@ -0,0 +1,254 @@
+package invoice_number
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"unibee/internal/consts"
+	dao "unibee/internal/dao/default"
+	"unibee/internal/logic/gateway/api"
+	"unibee/internal/logic/merchant_config"
+	"unibee/internal/logic/merchant_config/update"
+	entity "unibee/internal/model/entity/default"
+	"unibee/internal/query"
+	"unibee/utility"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+const (
+	MerchantInvoiceNumberingConfig = "KEY_MERCHANT_INVOICE_NUMBERING"
+	SeriesInvoice                  = "invoice"
+	SeriesCreditNote               = "credit_note"
+	DefaultPattern                 = "{PREFIX}{YYYY}-{SEQ}"
+	DefaultPrefix                  = "INV-"
+	DefaultCreditNotePrefix        = "CN-"
+	DefaultPadding                 = 6
+	MaxPadding                     = 12
+)
+
+type Config struct {
+	Enable           bool   `json:"enable"           dc:"Allocate sequential invoice numbers when an invoice leaves pending"`
+	Pattern          string `json:"pattern"          dc:"Number pattern, placeholders {PREFIX} {YYYY} {YY} {MM} {SEQ}, {PREFIX} and {SEQ} are required, the counter restarts every year when {YYYY} or {YY} is used and every month when {MM} is used as well, default {PREFIX}{YYYY}-{SEQ}"`
+	Prefix           string `json:"prefix"           dc:"Prefix of the invoice series, default INV-"`
+	CreditNotePrefix string `json:"creditNotePrefix" dc:"Prefix of the credit note series, should differ from the invoice prefix, default CN-"`
+	Padding          int    `json:"padding"          dc:"Zero padded width of the counter, default 6"`
+}
+
+func GetMerchantInvoiceNumberingConfig(ctx context.Context, merchantId uint64) *Config {
+	config := &Config{}
+	one := merchant_config.GetMerchantConfig(ctx, merchantId, MerchantInvoiceNumberingConfig)
+	if one != nil && len(one.ConfigValue) > 0 {
+		_ = utility.UnmarshalFromJsonString(one.ConfigValue, config)
+	}
+	config.fillDefault()
+	return config
+}
+
+func SetupMerchantInvoiceNumberingConfig(ctx context.Context, merchantId uint64, config *Config) error {
+	utility.Assert(config != nil, "invalid config")
+	config.fillDefault()
+	utility.AssertError(config.validate(), "invalid config")
+	utility.Assert(len(Format(config.Pattern, config.Prefix, gtime.Now(), 1, config.Padding)) <= 64, "pattern too long")
+	return update.SetMerchantConfig(ctx, merchantId, MerchantInvoiceNumberingConfig, utility.MarshalToJsonString(config))
+}
+
+// validate returns error when the invoice and credit note series could render the same number
+func (config *Config) validate() error {
+	if !strings.Contains(config.Pattern, "{SEQ}") {
+		return gerror.New("pattern should contain {SEQ}")
+	}
+	if !strings.Contains(config.Pattern, "{PREFIX}") {
+		return gerror.New("pattern should contain {PREFIX}")
+	}
+	if config.Padding > MaxPadding {
+		return gerror.Newf("padding should not greater than %d", MaxPadding)
+	}
+	if strings.EqualFold(config.Prefix, config.CreditNotePrefix) {
+		return gerror.New("credit note prefix should differ from invoice prefix")
+	}
+	return nil
+}
+
+func (config *Config) fillDefault() {
+	if len(config.Pattern) == 0 {
+		config.Pattern = DefaultPattern
+	}
+	if len(config.Prefix) == 0 {
+		config.Prefix = DefaultPrefix
+	}
+	if len(config.CreditNotePrefix) == 0 {
+		config.CreditNotePrefix = DefaultCreditNotePrefix
+	}
+	if config.Padding <= 0 {
+		config.Padding = DefaultPadding
+	}
+}
+
+// Series returns the number series of the invoice, refund invoices are numbered as credit notes
+func Series(one *entity.Invoice) string {
+	if len(one.RefundId) > 0 {
+		return SeriesCreditNote
+	}
+	return SeriesInvoice
+}
+
+// Period returns the counter period of the pattern at the time, the counter restarts when the period changes
+func Period(pattern string, time *gtime.Time) string {
+	upper := strings.ToUpper(pattern)
+	if strings.Contains(upper, "{YYYY}") || strings.Contains(upper, "{YY}") {
+		if strings.Contains(upper, "{MM}") {
+			return time.Format("Ym")
+		}
+		return time.Format("Y")
+	}
+	return ""
+}
+
+// Format renders the pattern with the prefix, time and counter value
+func Format(pattern string, prefix string, time *gtime.Time, seq int64, padding int) string {
+	replacer := strings.NewReplacer(
+		"{PREFIX}", prefix,
+		"{YYYY}", time.Format("Y"),
+		"{YY}", time.Format("y"),
+		"{MM}", time.Format("m"),
+		"{SEQ}", fmt.Sprintf("%0*d", padding, seq),
+	)
+	return replacer.Replace(pattern)
+}
+
+func issued(one *entity.Invoice) bool {
+	return one.Status == consts.InvoiceStatusProcessing || one.Status == consts.InvoiceStatusPaid
+}
+
+// IsCreditNoteRefund returns true when the refund invoice is created by a credit note,
+// the credit note allocates the number and hands it to the refund invoice
+func IsCreditNoteRefund(one *entity.Invoice) bool {
+	if one == nil || len(one.RefundId) == 0 || len(one.MetaData) == 0 {
+		return false
+	}
+	var metadata = make(map[string]interface{})
+	_ = utility.UnmarshalFromJsonString(one.MetaData, &metadata)
+	_, ok := metadata["CreditNoteId"]
+	return ok
+}
+
+// Assign allocates the next number of the merchant series to the invoice once it has left pending,
+// the counter increment and the invoice update share one transaction so that no number is skipped or reused.
+// The cycle invoice charged by a consolidated invoice is not a fiscal document, only the consolidated invoice is numbered
+func Assign(ctx context.Context, one *entity.Invoice) string {
+	if one == nil || one.Id <= 0 || len(one.InvoiceNumber) > 0 || !issued(one) || len(one.ConsolidatedInvoiceId) > 0 || IsCreditNoteRefund(one) {
+		if one != nil {
+			return one.InvoiceNumber
+		}
+		return ""
+	}
+	config := GetMerchantInvoiceNumberingConfig(ctx, one.MerchantId)
+	if !config.Enable {
+		return ""
+	}
+	number, err := Allocate(ctx, one.MerchantId, Series(one), func(ctx context.Context, number string) error {
+		result, err := dao.Invoice.Ctx(ctx).Data(g.Map{
+			dao.Invoice.Columns().InvoiceNumber: number,
+		}).Where(dao.Invoice.Columns().Id, one.Id).
+			Where("(" + dao.Invoice.Columns().InvoiceNumber + " IS NULL OR " + dao.Invoice.Columns().InvoiceNumber + " = '')").
+			Update()
+		if err != nil {
+			return err
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected != 1 {
+			// numbered concurrently, roll back the increment
+			return gerror.New("invoice already numbered")
+		}
+		return nil
+	})
+	if err != nil {
+		g.Log().Infof(ctx, "InvoiceNumber Assign invoiceId:%s not assigned:%s", one.InvoiceId, err.Error())
+		latest := query.GetInvoiceByInvoiceId(ctx, one.InvoiceId)
+		if latest != nil {
+			one.InvoiceNumber = latest.InvoiceNumber
+		}
+		return one.InvoiceNumber
+	}
+	one.InvoiceNumber = number
+	g.Log().Infof(ctx, "InvoiceNumber Assign invoiceId:%s number:%s", one.InvoiceId, number)
+	return number
+}
+
+// Allocate increments the counter of the merchant series and hands the rendered number to apply in the same transaction,
+// the increment is rolled back when apply fails. Credit notes are always numbered, whether invoice numbering is enabled or not
+func Allocate(ctx context.Context, merchantId uint64, series string, apply func(ctx context.Context, number string) error) (string, error) {
+	config := GetMerchantInvoiceNumberingConfig(ctx, merchantId)
+	var prefix = config.Prefix
+	if series == SeriesCreditNote {
+		prefix = config.CreditNotePrefix
+	}
+	now := gtime.Now()
+	period := Period(config.Pattern, now)
+	_, err := dao.MerchantInvoiceSequence.Ctx(ctx).Data(&entity.MerchantInvoiceSequence{
+		MerchantId: merchantId,
+		Series:     series,
+		Period:     period,
+		CreateTime: now.Timestamp(),
+	}).InsertIgnore()
+	if err != nil {
+		return "", err
+	}
+	var number string
+	err = dao.MerchantInvoiceSequence.DB().Transaction(ctx, func(ctx context.Context, transaction gdb.TX) error {
+		sequenceQuery := dao.MerchantInvoiceSequence.Ctx(ctx).
+			Where(dao.MerchantInvoiceSequence.Columns().MerchantId, merchantId).
+			Where(dao.MerchantInvoiceSequence.Columns().Series, series).
+			Where(dao.MerchantInvoiceSequence.Columns().Period, period)
+		_, err = sequenceQuery.Increment(dao.MerchantInvoiceSequence.Columns().CurrentValue, 1)
+		if err != nil {
+			return err
+		}
+		var sequence *entity.MerchantInvoiceSequence
+		err = sequenceQuery.LockUpdate().Scan(&sequence)
+		if err != nil {
+			return err
+		}
+		if sequence == nil {
+			return gerror.New("sequence not found")
+		}
+		number = Format(config.Pattern, prefix, now, sequence.CurrentValue, config.Padding)
+		return apply(ctx, number)
+	})
+	if err != nil {
+		return "", err
+	}
+	return number, nil
+}
+
+// AssignByInvoiceId reloads the invoice and allocates its number
+func AssignByInvoiceId(ctx context.Context, invoiceId string) string {
+	return Assign(ctx, query.GetInvoiceByInvoiceId(ctx, invoiceId))
+}
+
+// DisplayNumber is the number printed on documents, invoices issued before numbering was enabled keep the gateway prefixed invoiceId
+func DisplayNumber(ctx context.Context, one *entity.Invoice) string {
+	if one == nil {
+		return ""
+	}
+	if len(one.InvoiceNumber) > 0 {
+		return one.InvoiceNumber
+	}
+	return LegacyNumber(query.GetGatewayById(ctx, one.GatewayId), one.InvoiceId)
+}
+
+func LegacyNumber(gateway *entity.MerchantGateway, invoiceId string) string {
+	var invoiceGateway = ""
+	if gateway != nil {
+		invoiceGateway = gateway.GatewayName
+	}
+	return fmt.Sprintf("%s%s", api.GatewayShortNameMapping[invoiceGateway], invoiceId)
+}
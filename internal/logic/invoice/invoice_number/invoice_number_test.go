@@ -0,0 +1,59 @@
+package invoice_number
+
+import (
+	"testing"
+
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/stretchr/testify/require"
+	entity "unibee/internal/model/entity/default"
+)
+
+func TestFormat(t *testing.T) {
+	now := gtime.NewFromStr("2026-03-05 10:00:00")
+	t.Run("Test Default Pattern", func(t *testing.T) {
+		require.Equal(t, "INV-2026-000042", Format(DefaultPattern, DefaultPrefix, now, 42, DefaultPadding))
+	})
+	t.Run("Test Monthly Pattern", func(t *testing.T) {
+		require.Equal(t, "CN26/03/0007", Format("{PREFIX}{YY}/{MM}/{SEQ}", "CN", now, 7, 4))
+	})
+	t.Run("Test Counter Wider Than Padding", func(t *testing.T) {
+		require.Equal(t, "1234567", Format("{SEQ}", "", now, 1234567, 3))
+	})
+}
+
+func TestPeriod(t *testing.T) {
+	now := gtime.NewFromStr("2026-03-05 10:00:00")
+	require.Equal(t, "2026", Period(DefaultPattern, now))
+	require.Equal(t, "202603", Period("{PREFIX}{YY}{MM}-{SEQ}", now))
+	require.Equal(t, "", Period("{PREFIX}{SEQ}", now))
+	require.Equal(t, "", Period("{PREFIX}{MM}{SEQ}", now))
+}
+
+func TestSeries(t *testing.T) {
+	require.Equal(t, SeriesInvoice, Series(&entity.Invoice{InvoiceId: "81234"}))
+	require.Equal(t, SeriesCreditNote, Series(&entity.Invoice{InvoiceId: "81235", RefundId: "re_1"}))
+}
+
+func TestIsCreditNoteRefund(t *testing.T) {
+	require.False(t, IsCreditNoteRefund(&entity.Invoice{InvoiceId: "81234"}))
+	require.False(t, IsCreditNoteRefund(&entity.Invoice{InvoiceId: "81235", RefundId: "re_1", MetaData: `{"Reason":"duplicate"}`}))
+	require.True(t, IsCreditNoteRefund(&entity.Invoice{InvoiceId: "81236", RefundId: "re_2", MetaData: `{"CreditNoteId":"cn_1"}`}))
+	require.False(t, IsCreditNoteRefund(&entity.Invoice{InvoiceId: "81237", MetaData: `{"CreditNoteId":"cn_1"}`}))
+}
+
+func TestConfigValidate(t *testing.T) {
+	newConfig := func(pattern string, prefix string, creditNotePrefix string) *Config {
+		config := &Config{Pattern: pattern, Prefix: prefix, CreditNotePrefix: creditNotePrefix}
+		config.fillDefault()
+		return config
+	}
+	require.Nil(t, newConfig("", "", "").validate())
+	require.Nil(t, newConfig("{PREFIX}{YY}{MM}-{SEQ}", "F", "G").validate())
+	require.NotNil(t, newConfig("{YYYY}-{SEQ}", "INV-", "CN-").validate())
+	require.NotNil(t, newConfig("{PREFIX}{YYYY}", "INV-", "CN-").validate())
+	require.NotNil(t, newConfig("", "DOC-", "DOC-").validate())
+	require.NotNil(t, newConfig("", "doc-", "DOC-").validate())
+	config := newConfig("", "", "")
+	config.Padding = MaxPadding + 1
+	require.NotNil(t, config.validate())
+}
@@ -0,0 +1,124 @@
+package receivable
+
+import (
+	"sort"
+	"strings"
+
+	"unibee/api/bean"
+	"unibee/internal/consts"
+	entity "unibee/internal/model/entity/default"
+)
+
+// DueTime returns the utc time the invoice needs to be paid by
+func DueTime(one *entity.Invoice) int64 {
+	if one == nil {
+		return 0
+	}
+	return one.FinishTime + one.DayUtilDue*86400
+}
+
+// OutstandingAmount returns the amount of the invoice not yet received
+func OutstandingAmount(one *entity.Invoice) int64 {
+	if one == nil || one.Status != consts.InvoiceStatusProcessing {
+		return 0
+	}
+	if one.PaidAmount >= one.TotalAmount {
+		return 0
+	}
+	return one.TotalAmount - one.PaidAmount
+}
+
+// IsNetTermInvoice returns true when the invoice is paid by the customer within payment terms instead of charged automatically
+func IsNetTermInvoice(one *entity.Invoice) bool {
+	return one != nil && one.NetTermDays > 0 && one.TotalAmount > 0
+}
+
+// IsPartialPayable returns true when the invoice is paid by the customer outside the gateway, the net term and wire transfer invoices,
+// the invoices charged by the gateway never receive partial payments
+func IsPartialPayable(one *entity.Invoice, gateway *entity.MerchantGateway) bool {
+	if one == nil || len(one.RefundId) > 0 || one.TotalAmount <= 0 {
+		return false
+	}
+	return IsNetTermInvoice(one) || (gateway != nil && gateway.GatewayType == consts.GatewayTypeWireTransfer)
+}
+
+// IsWithinNetTerm returns true when the net term invoice is open and not yet due
+func IsWithinNetTerm(one *entity.Invoice, timeNow int64) bool {
+	return IsNetTermInvoice(one) && one.Status == consts.InvoiceStatusProcessing && timeNow <= DueTime(one)
+}
+
+// DaysPastDue returns the whole days the invoice is past its due time, 0 when not yet due
+func DaysPastDue(one *entity.Invoice, asOf int64) int64 {
+	dueTime := DueTime(one)
+	if asOf <= dueTime {
+		return 0
+	}
+	return (asOf-dueTime-1)/86400 + 1
+}
+
+// AgeingBucket returns the ageing bucket of the invoice at the time, current|1-30|31-60|61-90|90+
+func AgeingBucket(one *entity.Invoice, asOf int64) string {
+	days := DaysPastDue(one, asOf)
+	switch {
+	case days <= 0:
+		return consts.InvoiceAgeingBucketCurrent
+	case days <= 30:
+		return consts.InvoiceAgeingBucket1To30
+	case days <= 60:
+		return consts.InvoiceAgeingBucket31To60
+	case days <= 90:
+		return consts.InvoiceAgeingBucket61To90
+	default:
+		return consts.InvoiceAgeingBucketOver90
+	}
+}
+
+// IsReceivable returns true when the invoice counts into the accounts receivable,
+// the cycle invoices charged by a consolidated invoice are counted by the consolidated invoice
+func IsReceivable(one *entity.Invoice) bool {
+	return one != nil &&
+		one.Status == consts.InvoiceStatusProcessing &&
+		one.TotalAmount > 0 &&
+		len(one.RefundId) == 0 &&
+		len(one.ConsolidatedInvoiceId) == 0 &&
+		OutstandingAmount(one) > 0
+}
+
+// SummaryAgeing buckets the outstanding amounts of the invoices per currency, sorted by currency
+func SummaryAgeing(list []*entity.Invoice, asOf int64) []*bean.ReceivableAgeing {
+	var currencyMap = make(map[string]*bean.ReceivableAgeing)
+	for _, one := range list {
+		if !IsReceivable(one) {
+			continue
+		}
+		currency := strings.ToUpper(one.Currency)
+		ageing, ok := currencyMap[currency]
+		if !ok {
+			ageing = &bean.ReceivableAgeing{Currency: currency}
+			currencyMap[currency] = ageing
+		}
+		amount := OutstandingAmount(one)
+		switch AgeingBucket(one, asOf) {
+		case consts.InvoiceAgeingBucketCurrent:
+			ageing.Current = ageing.Current + amount
+		case consts.InvoiceAgeingBucket1To30:
+			ageing.Days1To30 = ageing.Days1To30 + amount
+		case consts.InvoiceAgeingBucket31To60:
+			ageing.Days31To60 = ageing.Days31To60 + amount
+		case consts.InvoiceAgeingBucket61To90:
+			ageing.Days61To90 = ageing.Days61To90 + amount
+		default:
+			ageing.DaysOver90 = ageing.DaysOver90 + amount
+		}
+		ageing.Total = ageing.Total + amount
+		ageing.InvoiceCount = ageing.InvoiceCount + 1
+	}
+	var result = make([]*bean.ReceivableAgeing, 0)
+	for _, ageing := range currencyMap {
+		result = append(result, ageing)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Currency < result[j].Currency
+	})
+	return result
+}
@@ -0,0 +1,104 @@
+package receivable
+
+import (
+	"testing"
+
+	"unibee/internal/consts"
+	entity "unibee/internal/model/entity/default"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testIssueTime int64 = 1700000000
+
+func testNetTermInvoice(invoiceId string, currency string, total int64, paid int64, netTermDays int) *entity.Invoice {
+	return &entity.Invoice{
+		InvoiceId:   invoiceId,
+		Currency:    currency,
+		TotalAmount: total,
+		PaidAmount:  paid,
+		Status:      consts.InvoiceStatusProcessing,
+		FinishTime:  testIssueTime,
+		DayUtilDue:  int64(netTermDays),
+		NetTermDays: netTermDays,
+	}
+}
+
+func TestAgeing(t *testing.T) {
+	one := testNetTermInvoice("in_1", "EUR", 10000, 0, 30)
+	dueTime := testIssueTime + 30*86400
+	t.Run("due time and outstanding", func(t *testing.T) {
+		require.Equal(t, dueTime, DueTime(one))
+		require.Equal(t, int64(10000), OutstandingAmount(one))
+		require.Equal(t, int64(6000), OutstandingAmount(testNetTermInvoice("in_2", "EUR", 10000, 4000, 30)))
+		paid := testNetTermInvoice("in_3", "EUR", 10000, 0, 30)
+		paid.Status = consts.InvoiceStatusPaid
+		require.Equal(t, int64(0), OutstandingAmount(paid))
+	})
+	t.Run("within net term", func(t *testing.T) {
+		require.True(t, IsWithinNetTerm(one, dueTime))
+		require.False(t, IsWithinNetTerm(one, dueTime+1))
+		require.False(t, IsWithinNetTerm(testNetTermInvoice("in_4", "EUR", 10000, 0, 0), testIssueTime))
+	})
+	t.Run("days past due and buckets", func(t *testing.T) {
+		require.Equal(t, int64(0), DaysPastDue(one, dueTime))
+		require.Equal(t, int64(1), DaysPastDue(one, dueTime+1))
+		require.Equal(t, int64(1), DaysPastDue(one, dueTime+86400))
+		require.Equal(t, int64(2), DaysPastDue(one, dueTime+86401))
+		require.Equal(t, consts.InvoiceAgeingBucketCurrent, AgeingBucket(one, testIssueTime))
+		require.Equal(t, consts.InvoiceAgeingBucket1To30, AgeingBucket(one, dueTime+30*86400))
+		require.Equal(t, consts.InvoiceAgeingBucket31To60, AgeingBucket(one, dueTime+30*86400+1))
+		require.Equal(t, consts.InvoiceAgeingBucket61To90, AgeingBucket(one, dueTime+90*86400))
+		require.Equal(t, consts.InvoiceAgeingBucketOver90, AgeingBucket(one, dueTime+90*86400+1))
+	})
+}
+
+func TestSummaryAgeing(t *testing.T) {
+	asOf := testIssueTime + 75*86400
+	refund := testNetTermInvoice("in_refund", "EUR", 5000, 0, 30)
+	refund.RefundId = "re_1"
+	consolidated := testNetTermInvoice("in_child", "EUR", 5000, 0, 30)
+	consolidated.ConsolidatedInvoiceId = "in_parent"
+	list := []*entity.Invoice{
+		testNetTermInvoice("in_1", "usd", 1000, 0, 90),
+		testNetTermInvoice("in_2", "EUR", 10000, 4000, 30),
+		testNetTermInvoice("in_3", "EUR", 3000, 0, 60),
+		refund,
+		consolidated,
+	}
+	result := SummaryAgeing(list, asOf)
+	require.Equal(t, 2, len(result))
+	require.Equal(t, "EUR", result[0].Currency)
+	require.Equal(t, int64(6000), result[0].Days31To60)
+	require.Equal(t, int64(3000), result[0].Days1To30)
+	require.Equal(t, int64(9000), result[0].Total)
+	require.Equal(t, 2, int(result[0].InvoiceCount))
+	require.Equal(t, "USD", result[1].Currency)
+	require.Equal(t, int64(1000), result[1].Current)
+	require.Equal(t, int64(1000), result[1].Total)
+	require.Equal(t, 0, len(SummaryAgeing(nil, asOf)))
+}
+
+func TestNeedOverdueReminder(t *testing.T) {
+	one := testNetTermInvoice("in_1", "EUR", 10000, 0, 30)
+	dueTime := DueTime(one)
+	require.False(t, NeedOverdueReminder(one, dueTime))
+	require.True(t, NeedOverdueReminder(one, dueTime+1))
+	one.OverdueReminderTime = dueTime + 1
+	require.False(t, NeedOverdueReminder(one, dueTime+overdueReminderInterval))
+	require.True(t, NeedOverdueReminder(one, dueTime+1+overdueReminderInterval))
+	require.False(t, NeedOverdueReminder(testNetTermInvoice("in_2", "EUR", 10000, 0, 0), dueTime+1))
+}
+
+func TestIsPartialPayable(t *testing.T) {
+	card := &entity.MerchantGateway{GatewayType: consts.GatewayTypeCard}
+	wire := &entity.MerchantGateway{GatewayType: consts.GatewayTypeWireTransfer}
+	require.True(t, IsPartialPayable(testNetTermInvoice("in_1", "EUR", 10000, 0, 30), card))
+	require.True(t, IsPartialPayable(testNetTermInvoice("in_2", "EUR", 10000, 0, 0), wire))
+	require.False(t, IsPartialPayable(testNetTermInvoice("in_3", "EUR", 10000, 0, 0), card))
+	require.False(t, IsPartialPayable(testNetTermInvoice("in_4", "EUR", 10000, 0, 0), nil))
+	refund := testNetTermInvoice("in_5", "EUR", -10000, 0, 30)
+	refund.RefundId = "re_1"
+	require.False(t, IsPartialPayable(refund, wire))
+	require.False(t, IsPartialPayable(nil, wire))
+}
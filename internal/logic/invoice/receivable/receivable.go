@@ -0,0 +1,182 @@
+package receivable
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"unibee/api/bean"
+	"unibee/internal/consts"
+	dao "unibee/internal/dao/default"
+	"unibee/internal/logic/invoice/service"
+	"unibee/internal/logic/operation_log"
+	service2 "unibee/internal/logic/payment/service"
+	entity "unibee/internal/model/entity/default"
+	"unibee/internal/query"
+	"unibee/utility"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+type PartialPaymentInternalReq struct {
+	MerchantId     uint64 `json:"merchantId"`
+	InvoiceId      string `json:"invoiceId"`
+	Amount         int64  `json:"amount"`
+	Currency       string `json:"currency"`
+	TransferNumber string `json:"transferNumber"`
+	Reason         string `json:"reason"`
+	PaidTime       int64  `json:"paidTime"`
+}
+
+// ApplyPartialPayment applies a payment received outside the gateway to the processing net term or wire transfer invoice,
+// the invoice is marked as paid once the received amount reaches its total amount
+func ApplyPartialPayment(ctx context.Context, req *PartialPaymentInternalReq) (*entity.InvoicePartialPayment, *entity.Invoice, error) {
+	utility.Assert(req != nil, "invalid request")
+	utility.Assert(req.MerchantId > 0, "invalid merchantId")
+	utility.Assert(len(req.InvoiceId) > 0, "invalid invoiceId")
+	utility.Assert(req.Amount > 0, "amount should greater than 0")
+	utility.Assert(len(req.TransferNumber) > 0, "invalid transferNumber")
+	lockKey := fmt.Sprintf("invoice_partial_payment_lock_%s", req.InvoiceId)
+	if !utility.TryLock(ctx, lockKey, 30) {
+		return nil, nil, gerror.New("Submit Too Fast")
+	}
+	defer func() {
+		utility.ReleaseLock(ctx, lockKey)
+	}()
+	invoice := query.GetInvoiceByInvoiceId(ctx, req.InvoiceId)
+	utility.Assert(invoice != nil, "invoice not found")
+	utility.Assert(invoice.MerchantId == req.MerchantId, "wrong merchant account")
+	utility.Assert(invoice.Status == consts.InvoiceStatusProcessing, "invoice not process status, InvoiceId:"+invoice.InvoiceId)
+	utility.Assert(len(invoice.RefundId) == 0 && invoice.TotalAmount > 0, "refund invoice can not be paid")
+	utility.Assert(len(invoice.ConsolidatedInvoiceId) == 0, "invoice is charged by consolidated invoice:"+invoice.ConsolidatedInvoiceId)
+	utility.Assert(IsPartialPayable(invoice, query.GetGatewayById(ctx, invoice.GatewayId)), "only net term or wire transfer invoice accepts partial payment")
+	if len(req.Currency) > 0 {
+		utility.Assert(strings.ToUpper(req.Currency) == strings.ToUpper(invoice.Currency), "currency not match the invoice's currency")
+	}
+	outstanding := OutstandingAmount(invoice)
+	if req.Amount > outstanding {
+		return nil, nil, gerror.Newf("amount %s exceeds the outstanding amount %s of the invoice", utility.ConvertCentToDollarStr(req.Amount, invoice.Currency), utility.ConvertCentToDollarStr(outstanding, invoice.Currency))
+	}
+	var paidTime = req.PaidTime
+	if paidTime <= 0 {
+		paidTime = gtime.Now().Timestamp()
+	}
+	one := &entity.InvoicePartialPayment{
+		MerchantId:     invoice.MerchantId,
+		UserId:         invoice.UserId,
+		InvoiceId:      invoice.InvoiceId,
+		Currency:       strings.ToUpper(invoice.Currency),
+		Amount:         req.Amount,
+		TransferNumber: req.TransferNumber,
+		Reason:         req.Reason,
+		PaidTime:       paidTime,
+		CreateTime:     gtime.Now().Timestamp(),
+	}
+	err := dao.InvoicePartialPayment.DB().Transaction(ctx, func(ctx context.Context, transaction gdb.TX) error {
+		result, err := dao.InvoicePartialPayment.Ctx(ctx).Data(one).OmitNil().Insert(one)
+		if err != nil {
+			return gerror.Newf(`ApplyPartialPayment record insert failure %s`, err.Error())
+		}
+		id, _ := result.LastInsertId()
+		one.Id = uint64(id)
+		update, err := dao.Invoice.Ctx(ctx).
+			Where(dao.Invoice.Columns().Id, invoice.Id).
+			Where(dao.Invoice.Columns().Status, consts.InvoiceStatusProcessing).
+			Where(dao.Invoice.Columns().PaidAmount, invoice.PaidAmount).
+			Increment(dao.Invoice.Columns().PaidAmount, req.Amount)
+		if err != nil {
+			return err
+		}
+		affected, err := update.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected != 1 {
+			return gerror.New("invoice changed, try again later")
+		}
+		return nil
+	})
+	operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
+		MerchantId:     invoice.MerchantId,
+		Target:         fmt.Sprintf("Invoice(%s)", invoice.InvoiceId),
+		Content:        fmt.Sprintf("PartialPayment(%s %s)", utility.ConvertCentToDollarStr(req.Amount, invoice.Currency), strings.ToUpper(invoice.Currency)),
+		UserId:         invoice.UserId,
+		SubscriptionId: invoice.SubscriptionId,
+		InvoiceId:      invoice.InvoiceId,
+		PlanId:         0,
+		DiscountCode:   "",
+	}, err)
+	if err != nil {
+		return nil, nil, err
+	}
+	invoice.PaidAmount = invoice.PaidAmount + req.Amount
+	if OutstandingAmount(invoice) > 0 && len(invoice.PaymentId) > 0 {
+		// the payment opened for the total amount is cancelled, the outstanding amount is settled by transfer
+		lastPayment, clearErr := service2.ClearInvoicePayment(ctx, invoice)
+		if clearErr != nil {
+			g.Log().Errorf(ctx, "ApplyPartialPayment clear payment invoiceId:%s err:%s", invoice.InvoiceId, clearErr.Error())
+		} else if lastPayment != nil {
+			clearErr = service2.PaymentGatewayCancel(ctx, lastPayment)
+			if clearErr != nil {
+				g.Log().Errorf(ctx, "ApplyPartialPayment cancel payment paymentId:%s err:%s", lastPayment.PaymentId, clearErr.Error())
+			}
+		}
+	}
+	if OutstandingAmount(invoice) == 0 {
+		invoice, err = service.MarkInvoiceAsPaidByTransfer(ctx, invoice, req.TransferNumber, req.Reason, "PartialPayment")
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return one, invoice, nil
+}
+
+// GetPartialPaymentList returns the partial payments applied to the invoice of the merchant
+func GetPartialPaymentList(ctx context.Context, merchantId uint64, invoiceId string) []*bean.InvoicePartialPayment {
+	invoice := query.GetInvoiceByInvoiceId(ctx, invoiceId)
+	utility.Assert(invoice != nil, "invoice not found")
+	utility.Assert(invoice.MerchantId == merchantId, "wrong merchant account")
+	var list = make([]*bean.InvoicePartialPayment, 0)
+	for _, one := range query.GetInvoicePartialPaymentsByInvoiceId(ctx, invoiceId) {
+		list = append(list, bean.SimplifyInvoicePartialPayment(one))
+	}
+	return list
+}
+
+// GetOpenInvoices returns the processing invoices of the merchant, filtered by currency if specified
+func GetOpenInvoices(ctx context.Context, merchantId uint64, currency string, page int, count int) ([]*entity.Invoice, error) {
+	var list []*entity.Invoice
+	q := dao.Invoice.Ctx(ctx).
+		Where(dao.Invoice.Columns().MerchantId, merchantId).
+		Where(dao.Invoice.Columns().Status, consts.InvoiceStatusProcessing).
+		Where(dao.Invoice.Columns().IsDeleted, 0).
+		WhereGT(dao.Invoice.Columns().TotalAmount, 0)
+	if len(currency) > 0 {
+		q = q.Where(dao.Invoice.Columns().Currency, strings.ToUpper(currency))
+	}
+	if count > 0 {
+		q = q.Limit(page*count, count)
+	}
+	err := q.OrderAsc(dao.Invoice.Columns().FinishTime).Scan(&list)
+	if err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// GetAgeingReport returns the accounts receivable ageing of the merchant per currency at the time, default now
+func GetAgeingReport(ctx context.Context, merchantId uint64, currency string, asOf int64) ([]*bean.ReceivableAgeing, error) {
+	utility.Assert(merchantId > 0, "invalid merchantId")
+	if asOf <= 0 {
+		asOf = gtime.Now().Timestamp()
+	}
+	list, err := GetOpenInvoices(ctx, merchantId, currency, 0, 0)
+	if err != nil {
+		g.Log().Errorf(ctx, "GetAgeingReport merchantId:%d err:%s", merchantId, err.Error())
+		return nil, err
+	}
+	return SummaryAgeing(list, asOf), nil
+}
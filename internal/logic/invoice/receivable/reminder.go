@@ -0,0 +1,75 @@
+package receivable
+
+import (
+	"context"
+	"fmt"
+
+	"unibee/internal/consts"
+	dao "unibee/internal/dao/default"
+	"unibee/internal/logic/email"
+	"unibee/internal/logic/invoice/handler"
+	entity "unibee/internal/model/entity/default"
+	"unibee/utility"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+// overdueReminderInterval the overdue reminder of an invoice is sent once a week until paid
+const overdueReminderInterval int64 = 7 * 86400
+
+// NeedOverdueReminder returns true when the net term invoice is past due and no reminder was sent within the interval
+func NeedOverdueReminder(one *entity.Invoice, timeNow int64) bool {
+	if !IsNetTermInvoice(one) || !IsReceivable(one) || timeNow <= DueTime(one) {
+		return false
+	}
+	return one.OverdueReminderTime <= 0 || timeNow-one.OverdueReminderTime >= overdueReminderInterval
+}
+
+// SendOverdueReminders sends the reminder email of the overdue net term invoices
+func SendOverdueReminders(ctx context.Context) {
+	var timeNow = gtime.Now().Timestamp()
+	var count = 100
+	var lastId uint64 = 0
+	for {
+		var list []*entity.Invoice
+		err := dao.Invoice.Ctx(ctx).
+			Where(dao.Invoice.Columns().Status, consts.InvoiceStatusProcessing).
+			WhereGT(dao.Invoice.Columns().NetTermDays, 0).
+			Where(dao.Invoice.Columns().IsDeleted, 0).
+			WhereGT(dao.Invoice.Columns().Id, lastId).
+			OrderAsc(dao.Invoice.Columns().Id).
+			Limit(count).
+			Scan(&list)
+		if err != nil {
+			g.Log().Errorf(ctx, "SendOverdueReminders error:%s", err.Error())
+			return
+		}
+		for _, one := range list {
+			lastId = one.Id
+			if !NeedOverdueReminder(one, timeNow) {
+				continue
+			}
+			key := fmt.Sprintf("SendOverdueReminders-%v", one.Id)
+			if !utility.TryLock(ctx, key, 60) {
+				continue
+			}
+			err = handler.SendInvoiceEmailToUser(ctx, one.InvoiceId, false, email.TemplateInvoiceOverdueReminder)
+			if err != nil {
+				g.Log().Errorf(ctx, "SendOverdueReminders invoiceId:%s err:%s", one.InvoiceId, err.Error())
+			} else {
+				_, err = dao.Invoice.Ctx(ctx).Data(g.Map{
+					dao.Invoice.Columns().OverdueReminderTime: timeNow,
+					dao.Invoice.Columns().GmtModify:           gtime.Now(),
+				}).Where(dao.Invoice.Columns().Id, one.Id).OmitNil().Update()
+				if err != nil {
+					g.Log().Errorf(ctx, "SendOverdueReminders update invoiceId:%s err:%s", one.InvoiceId, err.Error())
+				}
+			}
+			utility.ReleaseLock(ctx, key)
+		}
+		if len(list) < count {
+			break
+		}
+	}
+}
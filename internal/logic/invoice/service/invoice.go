@@ -16,9 +16,11 @@ import (
 	dao "unibee/internal/dao/default"
 	_interface "unibee/internal/interface/context"
 	"unibee/internal/logic/invoice/handler"
+	"unibee/internal/logic/invoice/invoice_number"
 	"unibee/internal/logic/operation_log"
 	handler2 "unibee/internal/logic/payment/handler"
 	"unibee/internal/logic/payment/service"
+	"unibee/internal/logic/tax"
 	entity "unibee/internal/model/entity/default"
 	"unibee/internal/query"
 	"unibee/utility"
@@ -56,62 +58,92 @@ func TryCancelSubscriptionLatestInvoice(ctx context.Context, subscription *entit
 func checkInvoice(one *detail.InvoiceDetail) {
 	var totalAmountExcludingTax int64 = 0
 	var totalTax int64 = 0
+	var taxEngine = false
 	for _, line := range one.Lines {
 		amountExcludingTax := line.UnitAmountExcludingTax * line.Quantity
 		tax := int64(float64(amountExcludingTax) * utility.ConvertTaxPercentageToInternalFloat(one.TaxPercentage))
+		if len(line.TaxLines) > 0 {
+			// the line tax of the tax engine is the sum of its tax lines
+			taxEngine = true
+			tax = 0
+			for _, taxLine := range line.TaxLines {
+				tax = tax + taxLine.Amount
+			}
+			utility.Assert(line.Tax == tax, "line tax mistake")
+		}
 		utility.Assert(line.AmountExcludingTax == amountExcludingTax, "line amountExcludingTax mistake")
 		utility.Assert(strings.Compare(line.Currency, one.Currency) == 0, "line currency not match invoice currency")
 		utility.Assert(line.Amount == amountExcludingTax+tax, "line amount mistake")
 		totalTax = totalTax + tax
 		totalAmountExcludingTax = totalAmountExcludingTax + amountExcludingTax
 	}
-	totalTax = int64(math.Round(float64(totalAmountExcludingTax) * utility.ConvertTaxPercentageToInternalFloat(one.TaxPercentage)))
+	if !taxEngine {
+		totalTax = int64(math.Round(float64(totalAmountExcludingTax) * utility.ConvertTaxPercentageToInternalFloat(one.TaxPercentage)))
+	}
 	var totalAmount = totalTax + totalAmountExcludingTax
 	utility.Assert(one.TaxAmount == totalTax, "invoice taxAmount mistake")
 	utility.Assert(one.TotalAmountExcludingTax == totalAmountExcludingTax, "invoice totalAmountExcludingTax mistake")
 	utility.Assert(one.TotalAmount == totalAmount, "line totalAmount mistake")
 }
 
-func CreateInvoice(ctx context.Context, merchantId uint64, req *invoice.NewReq) (res *invoice.NewRes, err error) {
-	user := query.GetUserAccountById(ctx, req.UserId)
-	utility.Assert(user != nil, fmt.Sprintf("send user not found:%d", req.UserId))
-	utility.Assert(len(user.Email) > 0, fmt.Sprintf("send user email not found:%d", req.UserId))
-	if req.GatewayId <= 0 {
-		gatewayId, _ := strconv.ParseUint(user.GatewayId, 10, 64)
-		if gatewayId > 0 {
-			req.GatewayId = gatewayId
-		}
-	}
-	utility.Assert(req.GatewayId > 0, "invalid gatewayId")
-	gateway := query.GetGatewayById(ctx, req.GatewayId)
-	utility.Assert(gateway != nil, "gateway not found")
-	metadata := map[string]interface{}{}
-	detail.CopyGatewayCompanyIssuer(gateway, metadata)
-
+// computeManualInvoice computes the lines of the manual invoice with the tax percentage of the request,
+// then the merchant tax engine and the customer tax treatment apply as they do to the subscription invoices
+func computeManualInvoice(ctx context.Context, merchantId uint64, userId uint64, currency string, countryCode string, taxPercentage int64, lines []*invoice.NewInvoiceItemParam) *bean.Invoice {
 	var invoiceItems []*bean.InvoiceItemSimplify
 	var totalAmountExcludingTax int64 = 0
-	var totalTax int64 = 0
-	for _, line := range req.Lines {
+	for _, line := range lines {
 		amountExcludingTax := line.UnitAmountExcludingTax * line.Quantity
-		tax := int64(float64(amountExcludingTax) * utility.ConvertTaxPercentageToInternalFloat(req.TaxPercentage))
+		tax := int64(float64(amountExcludingTax) * utility.ConvertTaxPercentageToInternalFloat(taxPercentage))
 		invoiceItems = append(invoiceItems, &bean.InvoiceItemSimplify{
-			Currency:               req.Currency,
+			Currency:               currency,
 			OriginAmount:           amountExcludingTax + tax,
 			Amount:                 amountExcludingTax + tax,
 			DiscountAmount:         0,
 			Tax:                    tax,
-			TaxPercentage:          req.TaxPercentage,
+			TaxPercentage:          taxPercentage,
 			AmountExcludingTax:     amountExcludingTax,
 			UnitAmountExcludingTax: line.UnitAmountExcludingTax,
 			Quantity:               line.Quantity,
 			Name:                   line.Name,
 			Description:            line.Description,
 		})
-		totalTax = totalTax + tax
 		totalAmountExcludingTax = totalAmountExcludingTax + amountExcludingTax
 	}
-	totalTax = int64(math.Round(float64(totalAmountExcludingTax) * utility.ConvertTaxPercentageToInternalFloat(req.TaxPercentage)))
+	totalTax := int64(math.Round(float64(totalAmountExcludingTax) * utility.ConvertTaxPercentageToInternalFloat(taxPercentage)))
 	var totalAmount = totalTax + totalAmountExcludingTax
+	simplify := &bean.Invoice{
+		Currency:                       currency,
+		CountryCode:                    countryCode,
+		OriginAmount:                   totalAmount,
+		TotalAmount:                    totalAmount,
+		TotalAmountExcludingTax:        totalAmountExcludingTax,
+		TaxAmount:                      totalTax,
+		TaxPercentage:                  taxPercentage,
+		SubscriptionAmount:             totalAmount,
+		SubscriptionAmountExcludingTax: totalAmountExcludingTax,
+		Lines:                          invoiceItems,
+	}
+	tax.ApplyInvoiceTax(ctx, merchantId, userId, simplify)
+	return simplify
+}
+
+func CreateInvoice(ctx context.Context, merchantId uint64, req *invoice.NewReq) (res *invoice.NewRes, err error) {
+	user := query.GetUserAccountById(ctx, req.UserId)
+	utility.Assert(user != nil, fmt.Sprintf("send user not found:%d", req.UserId))
+	utility.Assert(len(user.Email) > 0, fmt.Sprintf("send user email not found:%d", req.UserId))
+	if req.GatewayId <= 0 {
+		gatewayId, _ := strconv.ParseUint(user.GatewayId, 10, 64)
+		if gatewayId > 0 {
+			req.GatewayId = gatewayId
+		}
+	}
+	utility.Assert(req.GatewayId > 0, "invalid gatewayId")
+	gateway := query.GetGatewayById(ctx, req.GatewayId)
+	utility.Assert(gateway != nil, "gateway not found")
+	metadata := map[string]interface{}{}
+	detail.CopyGatewayCompanyIssuer(gateway, metadata)
+
+	simplify := computeManualInvoice(ctx, merchantId, req.UserId, req.Currency, user.CountryCode, req.TaxPercentage, req.Lines)
 
 	invoiceId := utility.CreateInvoiceId()
 	one := &entity.Invoice{
@@ -121,14 +153,15 @@ func CreateInvoice(ctx context.Context, merchantId uint64, req *invoice.NewReq)
 		InvoiceName:                    req.Name,
 		ProductName:                    req.Name,
 		UniqueId:                       invoiceId,
-		TotalAmount:                    totalAmount,
-		TotalAmountExcludingTax:        totalAmountExcludingTax,
-		TaxAmount:                      totalTax,
-		TaxPercentage:                  req.TaxPercentage,
-		SubscriptionAmount:             totalAmount,
-		SubscriptionAmountExcludingTax: totalAmountExcludingTax,
+		TotalAmount:                    simplify.TotalAmount,
+		TotalAmountExcludingTax:        simplify.TotalAmountExcludingTax,
+		TaxAmount:                      simplify.TaxAmount,
+		TaxPercentage:                  simplify.TaxPercentage,
+		TaxTreatment:                   simplify.TaxTreatment,
+		SubscriptionAmount:             simplify.TotalAmount,
+		SubscriptionAmountExcludingTax: simplify.TotalAmountExcludingTax,
 		Currency:                       strings.ToUpper(req.Currency),
-		Lines:                          utility.MarshalToJsonString(invoiceItems),
+		Lines:                          utility.MarshalToJsonString(simplify.Lines),
 		GatewayId:                      req.GatewayId,
 		Status:                         consts.InvoiceStatusPending,
 		SendStatus:                     consts.InvoiceSendStatusUnSend,
@@ -147,7 +180,7 @@ func CreateInvoice(ctx context.Context, merchantId uint64, req *invoice.NewReq)
 	id, _ := result.LastInsertId()
 	one.Id = uint64(uint(id))
 
-	one.Lines = utility.MarshalToJsonString(invoiceItems)
+	one.Lines = utility.MarshalToJsonString(simplify.Lines)
 	_, _ = redismq.Send(&redismq.Message{
 		Topic:      redismq2.TopicInvoiceCreated.Topic,
 		Tag:        redismq2.TopicInvoiceCreated.Tag,
@@ -196,53 +229,32 @@ func EditInvoice(ctx context.Context, req *invoice.EditReq) (res *invoice.EditRe
 		req.Currency = one.Currency
 	}
 
-	var invoiceItems []*bean.InvoiceItemSimplify
-	var totalAmountExcludingTax int64 = 0
-	var totalTax int64 = 0
-	for _, line := range req.Lines {
-		amountExcludingTax := line.UnitAmountExcludingTax * line.Quantity
-		tax := int64(float64(amountExcludingTax) * utility.ConvertTaxPercentageToInternalFloat(req.TaxPercentage))
-		invoiceItems = append(invoiceItems, &bean.InvoiceItemSimplify{
-			Currency:               req.Currency,
-			OriginAmount:           amountExcludingTax + tax,
-			Amount:                 amountExcludingTax + tax,
-			DiscountAmount:         0,
-			Tax:                    tax,
-			TaxPercentage:          req.TaxPercentage,
-			AmountExcludingTax:     amountExcludingTax,
-			UnitAmountExcludingTax: line.UnitAmountExcludingTax,
-			Quantity:               line.Quantity,
-			Name:                   line.Name,
-			Description:            line.Description,
-		})
-		totalTax = totalTax + tax
-		totalAmountExcludingTax = totalAmountExcludingTax + amountExcludingTax
-	}
-	totalTax = int64(math.Round(float64(totalAmountExcludingTax) * utility.ConvertTaxPercentageToInternalFloat(req.TaxPercentage)))
-	var totalAmount = totalTax + totalAmountExcludingTax
+	simplify := computeManualInvoice(ctx, one.MerchantId, one.UserId, req.Currency, one.CountryCode, req.TaxPercentage, req.Lines)
 
 	_, err = dao.Invoice.Ctx(ctx).Data(g.Map{
 		dao.Invoice.Columns().BizType:                        consts.BizTypeSubscription,
 		dao.Invoice.Columns().InvoiceName:                    req.Name,
-		dao.Invoice.Columns().TotalAmount:                    totalAmount,
-		dao.Invoice.Columns().TotalAmountExcludingTax:        totalAmountExcludingTax,
-		dao.Invoice.Columns().TaxAmount:                      totalTax,
-		dao.Invoice.Columns().SubscriptionAmount:             totalAmount,
-		dao.Invoice.Columns().SubscriptionAmountExcludingTax: totalAmountExcludingTax,
+		dao.Invoice.Columns().TotalAmount:                    simplify.TotalAmount,
+		dao.Invoice.Columns().TotalAmountExcludingTax:        simplify.TotalAmountExcludingTax,
+		dao.Invoice.Columns().TaxAmount:                      simplify.TaxAmount,
+		dao.Invoice.Columns().SubscriptionAmount:             simplify.TotalAmount,
+		dao.Invoice.Columns().SubscriptionAmountExcludingTax: simplify.TotalAmountExcludingTax,
 		dao.Invoice.Columns().Currency:                       strings.ToUpper(req.Currency),
 		dao.Invoice.Columns().Currency:                       req.Currency,
-		dao.Invoice.Columns().TaxPercentage:                  req.TaxPercentage,
+		dao.Invoice.Columns().TaxPercentage:                  simplify.TaxPercentage,
+		dao.Invoice.Columns().TaxTreatment:                   simplify.TaxTreatment,
 		dao.Invoice.Columns().GatewayId:                      req.GatewayId,
-		dao.Invoice.Columns().Lines:                          utility.MarshalToJsonString(invoiceItems),
+		dao.Invoice.Columns().Lines:                          utility.MarshalToJsonString(simplify.Lines),
 		dao.Invoice.Columns().GmtModify:                      gtime.Now(),
 	}).Where(dao.Subscription.Columns().Id, one.Id).OmitNil().Update()
 	if err != nil {
 		return nil, err
 	}
 	one.Currency = req.Currency
-	one.TaxPercentage = req.TaxPercentage
+	one.TaxPercentage = simplify.TaxPercentage
+	one.TaxTreatment = simplify.TaxTreatment
 	one.GatewayId = req.GatewayId
-	one.Lines = utility.MarshalToJsonString(invoiceItems)
+	one.Lines = utility.MarshalToJsonString(simplify.Lines)
 	operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
 		MerchantId:     one.MerchantId,
 		Target:         fmt.Sprintf("Invoice(%s)", one.InvoiceId),
@@ -443,6 +455,7 @@ func FinishInvoice(ctx context.Context, req *invoice.FinishReq) (*invoice.Finish
 	one.Status = invoiceStatus
 	one.Link = invoiceLink
 	one.SendTerms = st
+	invoice_number.Assign(ctx, one)
 	_ = handler.InvoicePdfGenerateAndEmailSendBackground(one.InvoiceId, true, false)
 	_, _ = redismq.Send(&redismq.Message{
 		Topic:      redismq2.TopicInvoiceProcessed.Topic,
@@ -613,15 +626,27 @@ func MarkWireTransferInvoiceAsPaid(ctx context.Context, invoiceId string, transf
 	utility.Assert(gateway.GatewayType == consts.GatewayTypeWireTransfer, "invoice not wire transfer type")
 	utility.Assert(one.TotalAmount >= gateway.MinimumAmount, "Total Amount not reach the gateway's minimum amount")
 	utility.Assert(strings.ToUpper(one.Currency) == strings.ToUpper(gateway.Currency), "Invoice currency not match the gateway's currency")
+	return MarkInvoiceAsPaidByTransfer(ctx, one, transferNumber, reason, "WireTransfer")
+}
+
+// MarkInvoiceAsPaidByTransfer finishes the processing invoice with a manual payment received outside the gateway,
+// the payment records the amount outstanding after the partial payments and the gateway of the invoice is never called
+func MarkInvoiceAsPaidByTransfer(ctx context.Context, one *entity.Invoice, transferNumber string, reason string, source string) (*entity.Invoice, error) {
+	utility.Assert(one != nil, "invoice not found")
+	utility.Assert(one.Status == consts.InvoiceStatusProcessing, "invoice not process status, InvoiceId:"+one.InvoiceId)
+	utility.Assert(one.TotalAmount > one.PaidAmount, "invoice paid already, InvoiceId:"+one.InvoiceId)
 	payment := query.GetPaymentByPaymentId(ctx, one.PaymentId)
-	if payment == nil {
+	gateway := query.GetGatewayById(ctx, one.GatewayId)
+	if payment == nil || payment.Status != consts.PaymentCreated || payment.TotalAmount != one.TotalAmount-one.PaidAmount ||
+		gateway == nil || gateway.GatewayType != consts.GatewayTypeWireTransfer {
 		res, err := service.CreateSubInvoicePaymentDefaultAutomatic(ctx, &service.CreateSubInvoicePaymentDefaultAutomaticReq{
 			Invoice:       one,
 			ManualPayment: true,
 			ReturnUrl:     "",
 			CancelUrl:     "",
-			Source:        "MarkWireTransferInvoiceAsPaid",
+			Source:        fmt.Sprintf("MarkInvoiceAsPaid(%s)", source),
 			TimeNow:       0,
+			Offline:       true,
 		})
 		utility.AssertError(err, "Mark as success error")
 		payment = res.Payment
@@ -637,12 +662,12 @@ func MarkWireTransferInvoiceAsPaid(ctx context.Context, invoiceId string, transf
 		PaymentAmount:          payment.TotalAmount,
 		Reason:                 reason,
 	})
-	utility.AssertError(err, "MarkWireTransferInvoiceAsPaid")
-	one = query.GetInvoiceByInvoiceId(ctx, invoiceId)
+	utility.AssertError(err, "MarkInvoiceAsPaidByTransfer")
+	one = query.GetInvoiceByInvoiceId(ctx, one.InvoiceId)
 	operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
 		MerchantId:     one.MerchantId,
 		Target:         fmt.Sprintf("Invoice(%s)", one.InvoiceId),
-		Content:        "MarkInvoiceAsPaid(WireTransfer)",
+		Content:        fmt.Sprintf("MarkInvoiceAsPaid(%s)", source),
 		UserId:         one.UserId,
 		SubscriptionId: one.SubscriptionId,
 		InvoiceId:      one.InvoiceId,
@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"math"
 	"testing"
+	"unibee/api/bean"
 	"unibee/api/bean/detail"
 	"unibee/api/merchant/invoice"
 	"unibee/internal/consts"
@@ -151,3 +152,28 @@ func TestFloatToInt(t *testing.T) {
 	fmt.Printf(fmt.Sprintf("%d\n", int64(math.Round(float64(int64(a)+int64(b))*utility.ConvertTaxPercentageToInternalFloat(2900)))))
 	fmt.Printf(fmt.Sprintf("%d\n", int64(math.Round(58.33))))
 }
+
+func TestCheckInvoiceWithTaxLines(t *testing.T) {
+	one := &detail.InvoiceDetail{
+		Currency:                "USD",
+		TaxPercentage:           1300,
+		TaxAmount:               130,
+		TotalAmount:             1130,
+		TotalAmountExcludingTax: 1000,
+		Lines: []*bean.InvoiceItemSimplify{{
+			Currency:               "USD",
+			Amount:                 1130,
+			AmountExcludingTax:     1000,
+			UnitAmountExcludingTax: 1000,
+			Quantity:               1,
+			Tax:                    130,
+			TaxLines: []*bean.InvoiceItemTaxLine{
+				{Name: "GST", Rate: 500, Amount: 50},
+				{Name: "PST", Rate: 800, Amount: 80},
+			},
+		}},
+	}
+	require.NotPanics(t, func() { checkInvoice(one) })
+	one.Lines[0].Tax = 120
+	require.Panics(t, func() { checkInvoice(one) })
+}
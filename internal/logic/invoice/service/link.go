@@ -100,6 +100,9 @@ func LinkCheck(ctx context.Context, invoiceId string, time int64) *LinkCheckRes
 		if payment != nil && payment.Status == consts.PaymentSuccess {
 			// status haven't sync completely
 			res.Link = link.GetInvoicePdfLink(one.InvoiceId, one.SendTerms)
+		} else if one.PaidAmount > 0 {
+			// the partial paid invoice is settled by transfer only
+			res.Message = "Invoice Partially Paid, Please Transfer The Outstanding Amount"
 		} else if gateway.GatewayType != consts.GatewayTypeCrypto ||
 			payment == nil ||
 			//(gateway.GatewayType == consts.GatewayTypeCrypto && (gtime.Now().Timestamp()-payment.CreateTime) > 60*60) ||
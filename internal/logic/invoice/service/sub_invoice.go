@@ -14,6 +14,7 @@ import (
 	"unibee/internal/logic/discount"
 	discount2 "unibee/internal/logic/invoice/discount"
 	"unibee/internal/logic/invoice/handler"
+	"unibee/internal/logic/invoice/invoice_number"
 	"unibee/internal/logic/operation_log"
 	entity "unibee/internal/model/entity/default"
 	"unibee/internal/query"
@@ -34,6 +35,7 @@ type CreateProcessingInvoiceForSubReq struct {
 	PaymentMethodId    string
 	IsSubLatestInvoice bool
 	TimeNow            int64
+	ReviewHours        int64 // create the invoice as a draft for review, finalised after the hours unless held
 }
 
 func CreateProcessingInvoiceForSub(ctx context.Context, req *CreateProcessingInvoiceForSubReq) (*entity.Invoice, error) {
@@ -56,22 +58,25 @@ func CreateProcessingInvoiceForSub(ctx context.Context, req *CreateProcessingInv
 	if user != nil {
 		sendEmail = user.Email
 		userSnapshot = &entity.UserAccount{
-			Email:         user.Email,
-			CountryCode:   user.CountryCode,
-			CountryName:   user.CountryName,
-			VATNumber:     user.VATNumber,
-			TaxPercentage: user.TaxPercentage,
-			GatewayId:     user.GatewayId,
-			Type:          user.Type,
-			UserName:      user.UserName,
-			Mobile:        user.Mobile,
-			Phone:         user.Phone,
-			Address:       user.Address,
-			FirstName:     user.FirstName,
-			LastName:      user.LastName,
-			CompanyName:   user.CompanyName,
-			City:          user.City,
-			ZipCode:       user.ZipCode,
+			Email:                user.Email,
+			CountryCode:          user.CountryCode,
+			CountryName:          user.CountryName,
+			VATNumber:            user.VATNumber,
+			TaxPercentage:        user.TaxPercentage,
+			GatewayId:            user.GatewayId,
+			Type:                 user.Type,
+			UserName:             user.UserName,
+			Mobile:               user.Mobile,
+			Phone:                user.Phone,
+			Address:              user.Address,
+			FirstName:            user.FirstName,
+			LastName:             user.LastName,
+			CompanyName:          user.CompanyName,
+			City:                 user.City,
+			ZipCode:              user.ZipCode,
+			TaxStatus:            user.TaxStatus,
+			TaxExemptCertificate: user.TaxExemptCertificate,
+			TaxExemptExpireTime:  user.TaxExemptExpireTime,
 		}
 	}
 	var currentTime = gtime.Now().Timestamp()
@@ -122,6 +127,19 @@ func CreateProcessingInvoiceForSub(ctx context.Context, req *CreateProcessingInv
 	}
 
 	status := consts.InvoiceStatusProcessing
+	var reviewFinalizeTime int64 = 0
+	if req.ReviewHours > 0 {
+		status = consts.InvoiceStatusPending
+		// the review ends by the period end at the latest, so the invoice is charged before the subscription runs out of period
+		reviewFinalizeTime = utility.MinInt64(currentTime+req.ReviewHours*3600, utility.MaxInt64(req.Sub.CurrentPeriodEnd, req.Sub.TrialEnd))
+	}
+	// the cycle invoice of a net terms customer is due within the terms instead of charged automatically
+	var dayUtilDue = req.Simplify.DayUtilDue
+	var netTermDays = 0
+	if user != nil && user.NetTermDays > 0 && strings.Compare(req.Simplify.CreateFrom, consts.InvoiceAutoChargeFlag) == 0 {
+		netTermDays = user.NetTermDays
+		dayUtilDue = int64(user.NetTermDays)
+	}
 	st := utility.CreateInvoiceSt()
 	one := &entity.Invoice{
 		SubscriptionId:                 req.Sub.SubscriptionId,
@@ -151,13 +169,16 @@ func CreateProcessingInvoiceForSub(ctx context.Context, req *CreateProcessingInv
 		CountryCode:                    req.Simplify.CountryCode,
 		VatNumber:                      req.Simplify.VatNumber,
 		TaxPercentage:                  req.Simplify.TaxPercentage,
+		TaxTreatment:                   req.Simplify.TaxTreatment,
+		ReviewFinalizeTime:             reviewFinalizeTime,
 		SubscriptionAmount:             req.Simplify.SubscriptionAmount,
 		SubscriptionAmountExcludingTax: req.Simplify.SubscriptionAmountExcludingTax,
 		Lines:                          utility.MarshalToJsonString(req.Simplify.Lines),
 		Link:                           link.GetInvoiceLink(invoiceId, st),
 		CreateTime:                     gtime.Now().Timestamp(),
 		FinishTime:                     currentTime,
-		DayUtilDue:                     req.Simplify.DayUtilDue,
+		DayUtilDue:                     dayUtilDue,
+		NetTermDays:                    netTermDays,
 		DiscountAmount:                 req.Simplify.DiscountAmount,
 		DiscountCode:                   req.Simplify.DiscountCode,
 		TrialEnd:                       req.Simplify.TrialEnd,
@@ -183,6 +204,13 @@ func CreateProcessingInvoiceForSub(ctx context.Context, req *CreateProcessingInv
 	}
 	id, _ := result.LastInsertId()
 	one.Id = uint64(uint(id))
+	// the auto charge cycle invoice of the consolidated user waits for the consolidated invoice,
+	// it is numbered and sent only when the billing cycle charges it on its own
+	var consolidatedCycle = user != nil && user.ConsolidatedBilling == 1 && req.IsSubLatestInvoice && netTermDays == 0 &&
+		one.TotalAmount > 0 && strings.Compare(one.CreateFrom, consts.InvoiceAutoChargeFlag) == 0
+	if status == consts.InvoiceStatusProcessing && !consolidatedCycle {
+		invoice_number.Assign(ctx, one)
+	}
 	if req.IsSubLatestInvoice {
 		_, err = dao.Subscription.Ctx(ctx).Data(g.Map{
 			dao.Subscription.Columns().LatestInvoiceId: invoiceId,
@@ -198,12 +226,14 @@ func CreateProcessingInvoiceForSub(ctx context.Context, req *CreateProcessingInv
 			Body:       one.InvoiceId,
 			CustomData: map[string]interface{}{"CreateFrom": utility.ReflectCurrentFunctionName()},
 		})
-		_, _ = redismq.Send(&redismq.Message{
-			Topic:      redismq2.TopicInvoiceProcessed.Topic,
-			Tag:        redismq2.TopicInvoiceProcessed.Tag,
-			Body:       one.InvoiceId,
-			CustomData: map[string]interface{}{"CreateFrom": utility.ReflectCurrentFunctionName()},
-		})
+		if status == consts.InvoiceStatusProcessing {
+			_, _ = redismq.Send(&redismq.Message{
+				Topic:      redismq2.TopicInvoiceProcessed.Topic,
+				Tag:        redismq2.TopicInvoiceProcessed.Tag,
+				Body:       one.InvoiceId,
+				CustomData: map[string]interface{}{"CreateFrom": utility.ReflectCurrentFunctionName()},
+			})
+		}
 	}
 	operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
 		MerchantId:     one.MerchantId,
@@ -215,8 +245,10 @@ func CreateProcessingInvoiceForSub(ctx context.Context, req *CreateProcessingInv
 		PlanId:         0,
 		DiscountCode:   "",
 	}, err)
-	//New Invoice Send Email
-	_ = handler.InvoicePdfGenerateAndEmailSendBackground(one.InvoiceId, true, false)
+	//New Invoice Send Email, the draft in review is sent when finalised, the consolidated cycle invoice is sent when charged on its own
+	if status == consts.InvoiceStatusProcessing && !consolidatedCycle {
+		_ = handler.InvoicePdfGenerateAndEmailSendBackground(one.InvoiceId, true, false)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -1 +1,46 @@
 package permission
+
+import (
+	"context"
+
+	"unibee/internal/consts"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/member"
+	"unibee/internal/query"
+	"unibee/utility"
+)
+
+// HasMemberPermission returns true when the owner or one role of the member grants the permission of the group
+func HasMemberPermission(ctx context.Context, memberId uint64, group consts.PermissionTypeGroup, permission consts.PermissionType) bool {
+	one := query.GetMerchantMemberById(ctx, memberId)
+	if one == nil || one.IsDeleted > 0 {
+		return false
+	}
+	isOwner, permissions := member.ConvertMemberPermissions(ctx, &member.MerchantMember{Id: one.Id, MerchantId: one.MerchantId, Role: one.Role})
+	return isOwner || IsPermissionGranted(permissions, group, permission)
+}
+
+// IsPermissionGranted returns true when one of the role permissions grants the permission of the group
+func IsPermissionGranted(permissions []*member.MerchantRolePermission, group consts.PermissionTypeGroup, permission consts.PermissionType) bool {
+	for _, one := range permissions {
+		if one == nil || one.Group != string(group) {
+			continue
+		}
+		if utility.IsStringInArray(one.Permissions, string(permission)) {
+			return true
+		}
+	}
+	return false
+}
+
+// AssertContextMemberPermission asserts the portal member of the request has the permission, open api calls with the merchant key pass
+func AssertContextMemberPermission(ctx context.Context, group consts.PermissionTypeGroup, permission consts.PermissionType) {
+	if _interface.Context() == nil || _interface.Context().Get(ctx) == nil {
+		return
+	}
+	contextMember := _interface.Context().Get(ctx).MerchantMember
+	if contextMember == nil || contextMember.IsOwner {
+		return
+	}
+	utility.Assert(HasMemberPermission(ctx, contextMember.Id, group, permission), "No Permission")
+}
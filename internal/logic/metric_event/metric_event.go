@@ -91,6 +91,10 @@ func NewMerchantMetricEvent(ctx context.Context, req *MerchantMetricEventInterna
 	utility.Assert(met.MerchantId == req.MerchantId, "code not match")
 	// check the only active subscription
 	sub := query.GetLatestActiveOrIncompleteSubscriptionByUserId(ctx, user.Id, req.MerchantId, req.ProductId)
+	if sub == nil {
+		// usage is suppressed while the subscription paused
+		utility.Assert(query.GetLatestSuspendedSubscriptionByUserId(ctx, user.Id, req.MerchantId, req.ProductId) == nil, fmt.Sprintf("The user's subscription is paused, event of metric(code:%s) not accepted until resumed", met.Code))
+	}
 	utility.Assert(sub != nil, fmt.Sprintf("The user should subscribe the plan bind metric(code:%s) first", met.Code))
 	planMetricBindingEntity := bean.ConvertMetricPlanBindingEntityFromPlan(query.GetPlanById(ctx, sub.PlanId))
 	if met.Type == metric.MetricTypeChargeMetered {
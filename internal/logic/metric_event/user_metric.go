@@ -378,3 +378,59 @@ func metricUserCacheKey(merchantId uint64, userId uint64, met *entity.MerchantMe
 	cacheKey := fmt.Sprintf("%s_%d_%d_%d_%s_%d", UserMetricCacheKeyPrefix, merchantId, userId, met.Id, sub.SubscriptionId, sub.CurrentPeriodStart)
 	return cacheKey
 }
+
+// TransferSubscriptionMetricEvents moves the usage of the current period and the unbilled charge usage of the subscription
+// to the new owner, the used values cached under the previous owner are dropped and reloaded under the new owner,
+// returns the count of events moved
+func TransferSubscriptionMetricEvents(ctx context.Context, sub *entity.Subscription, fromUserId uint64, toUser *entity.UserAccount) (int64, error) {
+	utility.Assert(sub != nil, "subscription not found")
+	utility.Assert(toUser != nil, "user not found")
+	var metricIds = make(map[uint64]bool)
+	for metricId := range GetUserMetricTotalLimits(ctx, sub.MerchantId, fromUserId, sub, false) {
+		metricIds[metricId] = true
+	}
+	var chargeMetricIds = make([]uint64, 0)
+	plan := query.GetPlanById(ctx, sub.PlanId)
+	if plan != nil {
+		planMetricBindingEntity := bean.ConvertMetricPlanBindingEntityFromPlan(plan)
+		for _, one := range planMetricBindingEntity.MetricMeteredCharge {
+			chargeMetricIds = append(chargeMetricIds, one.MetricId)
+		}
+		for _, one := range planMetricBindingEntity.MetricRecurringCharge {
+			chargeMetricIds = append(chargeMetricIds, one.MetricId)
+		}
+	}
+	for _, metricId := range chargeMetricIds {
+		metricIds[metricId] = true
+	}
+	q := dao.MerchantMetricEvent.Ctx(ctx).
+		Where(dao.MerchantMetricEvent.Columns().MerchantId, sub.MerchantId).
+		Where(dao.MerchantMetricEvent.Columns().UserId, fromUserId).
+		Where(dao.MerchantMetricEvent.Columns().SubscriptionIds, sub.SubscriptionId).
+		Where(dao.MerchantMetricEvent.Columns().IsDeleted, 0)
+	periodWhere := q.Builder().Where(dao.MerchantMetricEvent.Columns().SubscriptionPeriodStart, sub.CurrentPeriodStart)
+	if len(chargeMetricIds) > 0 {
+		periodWhere = periodWhere.WhereOr(q.Builder().
+			Where(dao.MerchantMetricEvent.Columns().ChargeStatus, 0).
+			WhereIn(dao.MerchantMetricEvent.Columns().MetricId, chargeMetricIds))
+	}
+	result, err := q.Where(periodWhere).Data(g.Map{
+		dao.MerchantMetricEvent.Columns().UserId:    toUser.Id,
+		dao.MerchantMetricEvent.Columns().GmtModify: gtime.Now(),
+	}).Update()
+	if err != nil {
+		return 0, err
+	}
+	count, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	for metricId := range metricIds {
+		met := query.GetMerchantMetric(ctx, metricId)
+		if met != nil {
+			_, _ = g.Redis().Del(ctx, metricUserCacheKey(sub.MerchantId, fromUserId, met, sub))
+		}
+	}
+	GetUserSubscriptionMetricStat(ctx, sub.MerchantId, toUser, sub, true)
+	return count, nil
+}
@@ -0,0 +1,124 @@
+package idempotency
+
+import (
+	"context"
+	"fmt"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+	"unibee/utility"
+)
+
+const (
+	HeaderKey        = "Idempotency-Key"
+	MaxKeyLength     = 255
+	RecordExpireTime = 24 * 60 * 60 // 24h
+	// ProcessingExpireTime limits how long a crashed request keeps its key locked
+	ProcessingExpireTime = 5 * 60
+)
+
+const (
+	RecordStatusProcessing = 1
+	RecordStatusCompleted  = 2
+)
+
+const (
+	CheckResultNew        = 1
+	CheckResultReplay     = 2
+	CheckResultMismatch   = 3
+	CheckResultInProgress = 4
+)
+
+type Record struct {
+	RequestHash string      `json:"requestHash"`
+	Method      string      `json:"method"`
+	Url         string      `json:"url"`
+	Status      int         `json:"status"`
+	HttpStatus  int         `json:"httpStatus"`
+	Code        int         `json:"code"`
+	Message     string      `json:"message"`
+	Data        interface{} `json:"data"`
+	CreateTime  int64       `json:"createTime"`
+}
+
+func cacheKey(merchantId uint64, key string) string {
+	return fmt.Sprintf("UniBee#Merchant#Idempotency#%d#%s", merchantId, key)
+}
+
+func RequestHash(method string, url string, body string) string {
+	return utility.MD5(fmt.Sprintf("%s%s%s", method, url, body))
+}
+
+func GetRecord(ctx context.Context, merchantId uint64, key string) *Record {
+	result, err := g.Redis().Get(ctx, cacheKey(merchantId, key))
+	if err != nil || result == nil || result.IsNil() || len(result.String()) == 0 {
+		return nil
+	}
+	var one *Record
+	err = utility.UnmarshalFromJsonString(result.String(), &one)
+	if err != nil {
+		g.Log().Errorf(ctx, "Idempotency GetRecord merchantId:%d key:%s error:%s", merchantId, key, err.Error())
+		return nil
+	}
+	return one
+}
+
+// Begin locks the key for the current request, or returns the stored record when the key has been used before
+func Begin(ctx context.Context, merchantId uint64, key string, method string, url string, requestHash string) (int, *Record) {
+	one := &Record{
+		RequestHash: requestHash,
+		Method:      method,
+		Url:         url,
+		Status:      RecordStatusProcessing,
+		CreateTime:  gtime.Now().Timestamp(),
+	}
+	result, err := g.Redis().Do(ctx, "SET", cacheKey(merchantId, key), utility.MarshalToJsonString(one), "NX", "EX", ProcessingExpireTime)
+	if err != nil {
+		// fail open, idempotency should never block the api when redis not available
+		g.Log().Errorf(ctx, "Idempotency Begin merchantId:%d key:%s error:%s", merchantId, key, err.Error())
+		return CheckResultNew, nil
+	}
+	if result != nil && !result.IsNil() {
+		return CheckResultNew, one
+	}
+	exist := GetRecord(ctx, merchantId, key)
+	return CheckRecord(exist, requestHash), exist
+}
+
+// CheckRecord compares the stored record of the key with the current request
+func CheckRecord(exist *Record, requestHash string) int {
+	if exist == nil {
+		return CheckResultNew
+	}
+	if exist.RequestHash != requestHash {
+		return CheckResultMismatch
+	}
+	if exist.Status != RecordStatusCompleted {
+		return CheckResultInProgress
+	}
+	return CheckResultReplay
+}
+
+func Complete(ctx context.Context, merchantId uint64, key string, requestHash string, httpStatus int, code int, message string, data interface{}) {
+	exist := GetRecord(ctx, merchantId, key)
+	if exist == nil || exist.RequestHash != requestHash {
+		return
+	}
+	exist.Status = RecordStatusCompleted
+	exist.HttpStatus = httpStatus
+	exist.Code = code
+	exist.Message = message
+	exist.Data = data
+	// set with the expiry in one command, the key never stays without expiry
+	err := g.Redis().SetEX(ctx, cacheKey(merchantId, key), utility.MarshalToJsonString(exist), RecordExpireTime)
+	if err != nil {
+		g.Log().Errorf(ctx, "Idempotency Complete merchantId:%d key:%s error:%s", merchantId, key, err.Error())
+	}
+}
+
+// Release drops the key so that the client can retry, used when the request failed with server error
+func Release(ctx context.Context, merchantId uint64, key string) {
+	_, err := g.Redis().Del(ctx, cacheKey(merchantId, key))
+	if err != nil {
+		g.Log().Errorf(ctx, "Idempotency Release merchantId:%d key:%s error:%s", merchantId, key, err.Error())
+	}
+}
@@ -0,0 +1,40 @@
+package idempotency
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckRecord(t *testing.T) {
+	hash := RequestHash("POST", "/merchant/subscription/create", `{"planId":1}`)
+	t.Run("New Key", func(t *testing.T) {
+		require.Equal(t, CheckResultNew, CheckRecord(nil, hash))
+	})
+	t.Run("Replay Completed Request", func(t *testing.T) {
+		record := &Record{RequestHash: hash, Status: RecordStatusCompleted, HttpStatus: 200, Code: 0, Data: map[string]interface{}{"id": 1}}
+		require.Equal(t, CheckResultReplay, CheckRecord(record, hash))
+	})
+	t.Run("Replay Completed Failure", func(t *testing.T) {
+		record := &Record{RequestHash: hash, Status: RecordStatusCompleted, HttpStatus: 400, Code: 51, Message: "plan not found"}
+		require.Equal(t, CheckResultReplay, CheckRecord(record, hash))
+	})
+	t.Run("Conflict With Different Body", func(t *testing.T) {
+		record := &Record{RequestHash: hash, Status: RecordStatusCompleted}
+		require.Equal(t, CheckResultMismatch, CheckRecord(record, RequestHash("POST", "/merchant/subscription/create", `{"planId":2}`)))
+	})
+	t.Run("Conflict With Different Path", func(t *testing.T) {
+		record := &Record{RequestHash: hash, Status: RecordStatusProcessing}
+		require.Equal(t, CheckResultMismatch, CheckRecord(record, RequestHash("POST", "/merchant/subscription/cancel", `{"planId":1}`)))
+	})
+	t.Run("Conflict In Progress", func(t *testing.T) {
+		record := &Record{RequestHash: hash, Status: RecordStatusProcessing}
+		require.Equal(t, CheckResultInProgress, CheckRecord(record, hash))
+	})
+}
+
+func TestRequestHash(t *testing.T) {
+	require.Equal(t, RequestHash("POST", "/a", "{}"), RequestHash("POST", "/a", "{}"))
+	require.NotEqual(t, RequestHash("POST", "/a", "{}"), RequestHash("PUT", "/a", "{}"))
+	require.NotEqual(t, RequestHash("POST", "/a", "{}"), RequestHash("POST", "/a", `{"a":1}`))
+}
@@ -17,6 +17,7 @@ import (
 	"unibee/internal/logic/analysis/segment"
 	"unibee/internal/logic/jwt"
 	"unibee/internal/logic/merchant"
+	"unibee/internal/logic/middleware/idempotency"
 	"unibee/internal/logic/middleware/license"
 	"unibee/internal/logic/middleware/rate_limit"
 	"unibee/internal/logic/totp/client_activity"
@@ -117,6 +118,8 @@ func (s *SMiddleware) ResponseHandler(r *ghttp.Request) {
 	)
 
 	if err == nil && r.Response.BufferLength() > 0 {
+		// raw response like file download can not be replayed
+		idempotencyRelease(r, customCtx)
 		return
 	}
 
@@ -133,6 +136,7 @@ func (s *SMiddleware) ResponseHandler(r *ghttp.Request) {
 		if strings.Contains(message, "Session Expired") {
 			if customCtx.IsOpenApiCall {
 				r.Response.Status = 400
+				idempotencyComplete(r, customCtx, gcode.CodeValidationFailed.Code(), "Session Expired", nil)
 				_interface.OpenApiJsonExit(r, gcode.CodeValidationFailed.Code(), "Session Expired")
 			} else {
 				r.Response.Status = 200 // error reply in json code, http code always 200
@@ -145,6 +149,7 @@ func (s *SMiddleware) ResponseHandler(r *ghttp.Request) {
 			}
 			if customCtx.IsOpenApiCall {
 				r.Response.Status = 400
+				idempotencyComplete(r, customCtx, errorCode, strings.Replace(message, "exception recovered: "+utility.SystemAssertPrefix, "", 1), nil)
 				_interface.OpenApiJsonExit(r, errorCode, strings.Replace(message, "exception recovered: "+utility.SystemAssertPrefix, "", 1))
 			} else {
 				r.Response.Status = 200 // error reply in json code, http code always 200
@@ -153,6 +158,7 @@ func (s *SMiddleware) ResponseHandler(r *ghttp.Request) {
 		} else {
 			if customCtx.IsOpenApiCall {
 				r.Response.Status = 400
+				idempotencyRelease(r, customCtx)
 				_interface.OpenApiJsonExit(r, code.Code(), fmt.Sprintf("Server Error-%s-%d", context.Context().Get(r.Context()).RequestId, code.Code()))
 			} else {
 				r.Response.Status = 200 // error reply in json code, http code always 200
@@ -162,6 +168,7 @@ func (s *SMiddleware) ResponseHandler(r *ghttp.Request) {
 	} else {
 		r.Response.Status = 200
 		if customCtx.IsOpenApiCall {
+			idempotencyComplete(r, customCtx, code.Code(), "", res)
 			_interface.OpenApiJsonExit(r, code.Code(), "", res)
 		} else {
 			_interface.JsonExit(r, code.Code(), "", res)
@@ -254,6 +261,7 @@ func (s *SMiddleware) MerchantHandler(r *ghttp.Request) {
 			customCtx.OpenApiKey = customCtx.TokenString
 		}
 		merchantQPSLimit(customCtx.MerchantId, r)
+		idempotencyCheck(customCtx, r)
 		lang := ""
 		if r.Get("lang") != nil {
 			lang = r.Get("lang").String()
@@ -421,6 +429,63 @@ func doubleRequestLimit(id string, r *ghttp.Request) {
 	}
 }
 
+func idempotencyCheck(customCtx *model.Context, r *ghttp.Request) {
+	key := strings.TrimSpace(r.GetHeader(idempotency.HeaderKey))
+	if len(key) == 0 || customCtx.MerchantId <= 0 {
+		return
+	}
+	if r.Method != "POST" && r.Method != "PUT" && r.Method != "DELETE" {
+		return
+	}
+	if strings.HasSuffix(r.URL.Path, "detail") || strings.HasSuffix(r.URL.Path, "list") || strings.HasSuffix(r.URL.Path, "get") {
+		return
+	}
+	if len(key) > idempotency.MaxKeyLength {
+		r.Response.Status = 400
+		_interface.OpenApiJsonExit(r, gcode.CodeValidationFailed.Code(), fmt.Sprintf("%s should not be longer than %d characters", idempotency.HeaderKey, idempotency.MaxKeyLength))
+	}
+	requestHash := idempotency.RequestHash(r.Method, r.URL.Path, r.GetBodyString())
+	result, record := idempotency.Begin(r.Context(), customCtx.MerchantId, key, r.Method, r.URL.Path, requestHash)
+	switch result {
+	case idempotency.CheckResultMismatch:
+		g.Log().Infof(r.Context(), "IdempotencyCheck merchantId:%d key:%s reused with different request", customCtx.MerchantId, key)
+		r.Response.Status = http.StatusConflict
+		_interface.OpenApiJsonExit(r, http.StatusConflict, fmt.Sprintf("%s has already been used with a different request", idempotency.HeaderKey))
+	case idempotency.CheckResultInProgress:
+		g.Log().Infof(r.Context(), "IdempotencyCheck merchantId:%d key:%s request in progress", customCtx.MerchantId, key)
+		r.Response.Status = http.StatusConflict
+		_interface.OpenApiJsonExit(r, http.StatusConflict, fmt.Sprintf("A request with the same %s is in progress", idempotency.HeaderKey))
+	case idempotency.CheckResultReplay:
+		g.Log().Infof(r.Context(), "IdempotencyCheck merchantId:%d key:%s replay response", customCtx.MerchantId, key)
+		r.Response.Header().Set("Idempotent-Replayed", "true")
+		r.Response.Status = record.HttpStatus
+		_interface.OpenApiJsonExit(r, record.Code, record.Message, record.Data)
+	default:
+		if record != nil {
+			customCtx.IdempotencyKey = key
+			customCtx.IdempotencyHash = requestHash
+		}
+	}
+}
+
+func idempotencyComplete(r *ghttp.Request, customCtx *model.Context, code int, message string, data interface{}) {
+	if len(customCtx.IdempotencyKey) == 0 {
+		return
+	}
+	httpStatus := r.Response.Status
+	if httpStatus == 0 {
+		httpStatus = http.StatusOK
+	}
+	idempotency.Complete(r.Context(), customCtx.MerchantId, customCtx.IdempotencyKey, customCtx.IdempotencyHash, httpStatus, code, message, data)
+}
+
+func idempotencyRelease(r *ghttp.Request, customCtx *model.Context) {
+	if len(customCtx.IdempotencyKey) == 0 {
+		return
+	}
+	idempotency.Release(r.Context(), customCtx.MerchantId, customCtx.IdempotencyKey)
+}
+
 func merchantQPSLimit(merchantId uint64, r *ghttp.Request) {
 	if config.GetConfigInstance().Mode == "cloud" {
 		maxQps := license.GetMerchantAPIRateLimit(r.Context(), merchantId)
@@ -278,6 +278,8 @@ func (p proxy) GetCallbackImpl() (one _interface.PaymentBizCallbackInterface) {
 		return &callback.SubscriptionPaymentCallback{}
 	} else if p.BizType == consts.BizTypeCreditRecharge {
 		return &callback2.CreditRechargeCallback{}
+	} else if p.BizType == consts.BizTypeConsolidated {
+		return &callback.ConsolidatedPaymentCallback{}
 	} else {
 		return &Invalid{}
 	}
@@ -245,9 +245,14 @@ func GatewayPaymentCreate(ctx context.Context, createPayContext *gateway_bean.Ga
 		return nil, err
 	}
 
-	gatewayInternalPayResult, err = api.GetGatewayServiceProvider(ctx, createPayContext.Pay.GatewayId).GatewayNewPayment(ctx, createPayContext.Gateway, createPayContext)
-	if err != nil {
-		return nil, err
+	if createPayContext.Offline {
+		// the payment received outside the gateway is recorded only
+		gatewayInternalPayResult = &gateway_bean.GatewayNewPaymentResp{Status: consts.PaymentCreated}
+	} else {
+		gatewayInternalPayResult, err = api.GetGatewayServiceProvider(ctx, createPayContext.Pay.GatewayId).GatewayNewPayment(ctx, createPayContext.Gateway, createPayContext)
+		if err != nil {
+			return nil, err
+		}
 	}
 	jsonData, err := gjson.Marshal(gatewayInternalPayResult)
 	if err != nil {
@@ -285,13 +290,15 @@ func GatewayPaymentCreate(ctx context.Context, createPayContext *gateway_bean.Ga
 			return nil, err
 		}
 	}
-	// send the payment status checker mq
-	_, _ = redismq.Send(&redismq.Message{
-		Topic:      redismqcmd.TopicPaymentChecker.Topic,
-		Tag:        redismqcmd.TopicPaymentChecker.Tag,
-		Body:       createPayContext.Pay.PaymentId,
-		CustomData: map[string]interface{}{"CreateFrom": utility.ReflectCurrentFunctionName()},
-	})
+	if !createPayContext.Offline {
+		// send the payment status checker mq
+		_, _ = redismq.Send(&redismq.Message{
+			Topic:      redismqcmd.TopicPaymentChecker.Topic,
+			Tag:        redismqcmd.TopicPaymentChecker.Tag,
+			Body:       createPayContext.Pay.PaymentId,
+			CustomData: map[string]interface{}{"CreateFrom": utility.ReflectCurrentFunctionName()},
+		})
+	}
 
 	_, _ = dao.Invoice.Ctx(ctx).Data(g.Map{
 		dao.Invoice.Columns().PaymentLink: paymentLink,
@@ -379,10 +386,15 @@ type CreateSubInvoicePaymentDefaultAutomaticReq struct {
 	CancelUrl     string
 	Source        string
 	TimeNow       int64
+	Offline       bool // records the payment received outside the gateway for the outstanding amount of the invoice
 }
 
 func CreateSubInvoicePaymentDefaultAutomatic(ctx context.Context, req *CreateSubInvoicePaymentDefaultAutomaticReq) (gatewayInternalPayResult *gateway_bean.GatewayNewPaymentResp, err error) {
 	g.Log().Infof(ctx, "CreateSubInvoicePaymentDefaultAutomatic invoiceId:%s", req.Invoice.InvoiceId)
+	if req.Invoice.PaidAmount > 0 && !req.Offline {
+		// the partial paid invoice is settled by transfer only, the gateway never charges its total amount again
+		return nil, gerror.Newf("invoice %s partially paid, the outstanding amount should be received by transfer", req.Invoice.InvoiceId)
+	}
 	lastPayment, err := ClearInvoicePayment(ctx, req.Invoice)
 	if err != nil {
 		g.Log().Infof(ctx, "CreateSubInvoicePaymentDefaultAutomatic ClearInvoicePayment invoiceId:%s err:%s", req.Invoice.InvoiceId, err.Error())
@@ -425,7 +437,7 @@ func CreateSubInvoicePaymentDefaultAutomatic(ctx context.Context, req *CreateSub
 			AuthorizeStatus:   consts.Authorized,
 			UserId:            req.Invoice.UserId,
 			GatewayId:         gateway.Id,
-			TotalAmount:       req.Invoice.TotalAmount,
+			TotalAmount:       req.Invoice.TotalAmount - req.Invoice.PaidAmount,
 			Currency:          strings.ToUpper(req.Invoice.Currency),
 			CryptoAmount:      req.Invoice.CryptoAmount,
 			CryptoCurrency:    req.Invoice.CryptoCurrency,
@@ -444,6 +456,7 @@ func CreateSubInvoicePaymentDefaultAutomatic(ctx context.Context, req *CreateSub
 		Metadata:             map[string]interface{}{"BillingReason": req.Invoice.InvoiceName, "Source": req.Source, "manualPayment": req.ManualPayment, "CancelUrl": req.CancelUrl},
 		GatewayPaymentMethod: req.Invoice.GatewayPaymentMethod,
 		GatewayPaymentType:   req.Invoice.GatewayInvoiceId,
+		Offline:              req.Offline,
 	})
 
 	if err == nil && res.Payment != nil {
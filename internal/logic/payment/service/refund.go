@@ -11,6 +11,7 @@ import (
 	"golang.org/x/text/currency"
 	"strconv"
 	"strings"
+	"unibee/api/bean"
 	redismqcmd "unibee/internal/cmd/redismq"
 	"unibee/internal/consts"
 	dao "unibee/internal/dao/default"
@@ -33,6 +34,7 @@ type NewPaymentRefundInternalReq struct {
 	Currency         string                 `json:"currency" dc:"Currency"  v:"required"`
 	Reason           string                 `json:"reason" dc:"Reason"`
 	Metadata         map[string]interface{} `json:"metadata" dc:"Metadata，Map"`
+	CreditNote       *entity.CreditNote     `json:"-"`
 }
 
 func GatewayPaymentRefundCreate(ctx context.Context, req *NewPaymentRefundInternalReq) (refund *entity.Refund, err error) {
@@ -154,7 +156,13 @@ func GatewayPaymentRefundCreate(ctx context.Context, req *NewPaymentRefundIntern
 			if err != nil {
 				return err
 			}
-			_, err = handler2.CreateProcessInvoiceForNewPaymentRefund(ctx, invoice_compute.CreateInvoiceSimplifyForRefund(ctx, payment, one), one)
+			var refundInvoice *bean.Invoice
+			if req.CreditNote != nil {
+				refundInvoice = invoice_compute.CreateInvoiceSimplifyForCreditNote(ctx, payment, one, req.CreditNote)
+			} else {
+				refundInvoice = invoice_compute.CreateInvoiceSimplifyForRefund(ctx, payment, one)
+			}
+			_, err = handler2.CreateProcessInvoiceForNewPaymentRefund(ctx, refundInvoice, one)
 			if err != nil {
 				return err
 			}
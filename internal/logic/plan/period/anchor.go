@@ -0,0 +1,62 @@
+package period
+
+import (
+	entity "unibee/internal/model/entity/default"
+
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+// MaxBackdatedPeriods caps the periods walked from a backdated start
+const MaxBackdatedPeriods = 400
+
+type Period struct {
+	Start          int64
+	End            int64
+	ProrationScale int64 // 10000 = 100%, 0-no proration
+}
+
+// IsValidBillingCycleAnchorDay returns true when the day of month is available as billing cycle anchor, 1-28 to exist in every month
+func IsValidBillingCycleAnchorDay(day int) bool {
+	return day >= 1 && day <= 28
+}
+
+// BillingCycleAnchorFromDay returns the first time after the start on the day of month, at the clock of the start
+func BillingCycleAnchorFromDay(start int64, anchorDay int) int64 {
+	startTime := gtime.NewFromTimeStamp(start)
+	candidate := startTime.AddDate(0, 0, anchorDay-startTime.Day())
+	if !candidate.After(startTime) {
+		candidate = candidate.AddDate(0, 1, 0)
+	}
+	return candidate.Timestamp()
+}
+
+// PeriodsFromStart returns the periods of the plan from the start to the one covering the time,
+// the first period ends on the billing cycle anchor and is prorated when the anchor is before the period end of the plan,
+// returns false when the periods exceed MaxBackdatedPeriods
+func PeriodsFromStart(plan *entity.Plan, start int64, billingCycleAnchor int64, timeNow int64) ([]*Period, bool) {
+	var periods = make([]*Period, 0)
+	if billingCycleAnchor <= start {
+		billingCycleAnchor = start
+	}
+	var periodEnd = PlanPeriodEndFromStart(plan, start, start)
+	if periodEnd <= start {
+		return periods, false
+	}
+	if billingCycleAnchor > start && billingCycleAnchor < periodEnd {
+		var prorationScale = (billingCycleAnchor - start) * 10000 / (periodEnd - start)
+		if prorationScale < 1 {
+			prorationScale = 1
+		}
+		periods = append(periods, &Period{Start: start, End: billingCycleAnchor, ProrationScale: prorationScale})
+	} else {
+		periods = append(periods, &Period{Start: start, End: periodEnd})
+	}
+	for periods[len(periods)-1].End <= timeNow {
+		if len(periods) >= MaxBackdatedPeriods {
+			return periods, false
+		}
+		var nextStart = periods[len(periods)-1].End
+		periods = append(periods, &Period{Start: nextStart, End: PlanPeriodEndFromStart(plan, nextStart, billingCycleAnchor)})
+	}
+	return periods, true
+}
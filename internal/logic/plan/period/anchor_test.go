@@ -0,0 +1,53 @@
+package period
+
+import (
+	"testing"
+	"time"
+
+	entity "unibee/internal/model/entity/default"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testTime(month time.Month, day int) int64 {
+	return time.Date(2024, month, day, 10, 0, 0, 0, time.Local).Unix()
+}
+
+func TestBillingCycleAnchorFromDay(t *testing.T) {
+	require.True(t, IsValidBillingCycleAnchorDay(1))
+	require.True(t, IsValidBillingCycleAnchorDay(28))
+	require.False(t, IsValidBillingCycleAnchorDay(0))
+	require.False(t, IsValidBillingCycleAnchorDay(31))
+	start := testTime(time.January, 15)
+	require.Equal(t, testTime(time.February, 1), BillingCycleAnchorFromDay(start, 1))
+	require.Equal(t, testTime(time.January, 20), BillingCycleAnchorFromDay(start, 20))
+	require.Equal(t, testTime(time.February, 15), BillingCycleAnchorFromDay(start, 15))
+}
+
+func TestPeriodsFromStart(t *testing.T) {
+	plan := &entity.Plan{IntervalUnit: "month", IntervalCount: 1}
+	start := testTime(time.January, 15)
+	// anchored, prorated first period
+	periods, ok := PeriodsFromStart(plan, start, testTime(time.February, 1), testTime(time.January, 20))
+	require.True(t, ok)
+	require.Equal(t, 1, len(periods))
+	require.Equal(t, testTime(time.February, 1), periods[0].End)
+	require.Equal(t, int64(17*10000/31), periods[0].ProrationScale)
+	// backdated
+	periods, ok = PeriodsFromStart(plan, start, start, testTime(time.April, 20))
+	require.True(t, ok)
+	require.Equal(t, 4, len(periods))
+	require.Equal(t, testTime(time.April, 15), periods[3].Start)
+	require.Equal(t, testTime(time.May, 15), periods[3].End)
+	require.Equal(t, int64(0), periods[3].ProrationScale)
+	// backdated and anchored
+	periods, ok = PeriodsFromStart(plan, start, testTime(time.February, 1), testTime(time.March, 10))
+	require.True(t, ok)
+	require.Equal(t, 3, len(periods))
+	require.True(t, periods[0].ProrationScale > 0)
+	require.Equal(t, testTime(time.March, 1), periods[2].Start)
+	require.Equal(t, testTime(time.April, 1), periods[2].End)
+	// too many periods to catch up
+	_, ok = PeriodsFromStart(&entity.Plan{IntervalUnit: "day", IntervalCount: 1}, start, start, start+500*86400)
+	require.False(t, ok)
+}
@@ -22,15 +22,20 @@ func GetPlanById(ctx context.Context, id uint64) (one *entity.Plan) {
 }
 
 func GetPeriodEndFromStart(ctx context.Context, start int64, billingCycleAnchor int64, planId uint64) int64 {
-	if billingCycleAnchor == 0 {
-		billingCycleAnchor = start
-	}
 	plan := GetPlanById(ctx, planId)
 	//utility.Assert(plan != nil, "GetPeriod Plan Not Found")
 	if plan == nil {
 		g.Log().Errorf(ctx, "GetPeriodEndFromStart planId %d not found", planId)
 		return start
 	}
+	return PlanPeriodEndFromStart(plan, start, billingCycleAnchor)
+}
+
+// PlanPeriodEndFromStart returns the period end of the plan from the start, the day of month and year intervals follows the billing cycle anchor
+func PlanPeriodEndFromStart(plan *entity.Plan, start int64, billingCycleAnchor int64) int64 {
+	if billingCycleAnchor == 0 {
+		billingCycleAnchor = start
+	}
 	var periodEnd = gtime.NewFromTimeStamp(start)
 	if strings.Compare(strings.ToLower(plan.IntervalUnit), "day") == 0 {
 		periodEnd = periodEnd.AddDate(0, 0, plan.IntervalCount)
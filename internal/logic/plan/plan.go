@@ -108,8 +108,13 @@ type PlanInternalReq struct {
 	TrialDurationTime     int64                                `json:"trialDurationTime"         description:"duration of trial"`      // duration of trial
 	TrialDemand           string                               `json:"trialDemand"               description:"demand of trial, example, paymentMethod, payment method will ask for subscription trial start"`
 	CancelAtTrialEnd      int                                  `json:"cancelAtTrialEnd"          description:"whether cancel at subscription first trial end，0-false | 1-true, will pass to cancelAtPeriodEnd of subscription"` // whether cancel at subscripiton first trial end，0-false | 1-true, will pass to cancelAtPeriodEnd of subscription
+	TrialEndBehavior      string                               `json:"trialEndBehavior"          description:"behaviour at trial end of the subscription without payment method，convert|pause|cancel, default convert"`
 	ProductId             int64                                `json:"productId"   dc:"Id of product which plan to linked" `
 	MultiCurrencies       []*bean.PlanMultiCurrency            `json:"multiCurrencies"  dc:"Plan's MultiCurrencies" `
+	TaxCategory           string                               `json:"taxCategory"  dc:"Tax category of plan, matched by the tax rules of tax engine" `
+	InvoiceReviewHours    int                                  `json:"invoiceReviewHours"  dc:"Hours the cycle invoices stay in draft for review before finalised automatically, 0-review disabled" `
+	MinSeats              int                                  `json:"minSeats"  dc:"Min seats (quantity) of the subscription, 0-no limit" `
+	MaxSeats              int                                  `json:"maxSeats"  dc:"Max seats (quantity) of the subscription, 0-no limit" `
 }
 
 func MetricPlanChargeValidation(metricPlanCharges []*bean.PlanMetricMeteredChargeParam) error {
@@ -177,6 +182,7 @@ func PlanCreate(ctx context.Context, req *PlanInternalReq) (one *entity.Plan, er
 			utility.Assert(req.TrialDurationTime == 0, "Trial not available for addon and one-time plan")
 			utility.Assert(req.TrialAmount == 0, "Trial not available for addon and one-time plan")
 			utility.Assert(req.TrialDemand == "", "Trial not available for addon and one-time plan")
+			utility.Assert(req.TrialEndBehavior == "", "Trial not available for addon and one-time plan")
 		}
 		utility.Assert(len(req.MetricMeteredCharge) == 0, "Metric metered charge not available for addon")
 		utility.Assert(len(req.MetricRecurringCharge) == 0, "Metric recurring charge not available for addon")
@@ -256,6 +262,9 @@ func PlanCreate(ctx context.Context, req *PlanInternalReq) (one *entity.Plan, er
 	}
 
 	utility.Assert(req.TrialDemand == "" || req.TrialDemand == "paymentMethod", "Demand of trial should be paymentMethod or not")
+	utility.Assert(IsValidTrialEndBehavior(req.TrialEndBehavior), "trialEndBehavior should one of convert|pause|cancel")
+	utility.Assert(req.InvoiceReviewHours >= 0 && req.InvoiceReviewHours <= consts.MaxInvoiceReviewHours, fmt.Sprintf("invoiceReviewHours should between 0 and %d", consts.MaxInvoiceReviewHours))
+	utility.AssertError(CheckSeatLimitParam(req.MinSeats, req.MaxSeats), "invalid seats")
 
 	var targetMultiCurrencies = make([]*bean.PlanMultiCurrency, 0)
 	if req.MultiCurrencies != nil {
@@ -289,8 +298,13 @@ func PlanCreate(ctx context.Context, req *PlanInternalReq) (one *entity.Plan, er
 		TrialAmount:            req.TrialAmount,
 		TrialDemand:            req.TrialDemand,
 		CancelAtTrialEnd:       req.CancelAtTrialEnd,
+		TrialEndBehavior:       req.TrialEndBehavior,
 		PublishStatus:          consts.PlanPublishStatusUnPublished,
 		ProductId:              req.ProductId,
+		TaxCategory:            req.TaxCategory,
+		InvoiceReviewHours:     req.InvoiceReviewHours,
+		MinSeats:               req.MinSeats,
+		MaxSeats:               req.MaxSeats,
 		MetricCharge: utility.MarshalToJsonString(&bean.MetricPlanBindingEntity{
 			MetricLimits:          req.MetricLimits,
 			MetricMeteredCharge:   req.MetricMeteredCharge,
@@ -374,8 +388,13 @@ type EditInternalReq struct {
 	TrialDurationTime     *int64                                `json:"trialDurationTime"         description:"duration of trial"`      // duration of trial
 	TrialDemand           *string                               `json:"trialDemand"               description:"demand of trial, example, paymentMethod, payment method will ask for subscription trial start"`
 	CancelAtTrialEnd      *int                                  `json:"cancelAtTrialEnd"          description:"whether cancel at subscripiton first trial end，0-false | 1-true, will pass to cancelAtPeriodEnd of subscription"` // whether cancel at subscripiton first trial end，0-false | 1-true, will pass to cancelAtPeriodEnd of subscription
+	TrialEndBehavior      *string                               `json:"trialEndBehavior"          description:"behaviour at trial end of the subscription without payment method，convert|pause|cancel, default convert"`
 	ProductId             *int64                                `json:"productId"   dc:"Id of product which plan to linked" `
 	MultiCurrencies       *[]*bean.PlanMultiCurrency            `json:"multiCurrencies"  dc:"Plan's MultiCurrencies" `
+	TaxCategory           *string                               `json:"taxCategory"  dc:"Tax category of plan, matched by the tax rules of tax engine" `
+	InvoiceReviewHours    *int                                  `json:"invoiceReviewHours"  dc:"Hours the cycle invoices stay in draft for review before finalised automatically, 0-review disabled" `
+	MinSeats              *int                                  `json:"minSeats"  dc:"Min seats (quantity) of the subscription, 0-no limit" `
+	MaxSeats              *int                                  `json:"maxSeats"  dc:"Max seats (quantity) of the subscription, 0-no limit" `
 }
 
 func PlanEdit(ctx context.Context, req *EditInternalReq) (one *entity.Plan, err error) {
@@ -384,6 +403,20 @@ func PlanEdit(ctx context.Context, req *EditInternalReq) (one *entity.Plan, err
 	one = query.GetPlanById(ctx, req.PlanId)
 	utility.Assert(one != nil, fmt.Sprintf("plan not found, id:%d", req.PlanId))
 	utility.Assert(one.MerchantId == req.MerchantId, "Merchant not match")
+	if req.InvoiceReviewHours != nil {
+		utility.Assert(*req.InvoiceReviewHours >= 0 && *req.InvoiceReviewHours <= consts.MaxInvoiceReviewHours, fmt.Sprintf("invoiceReviewHours should between 0 and %d", consts.MaxInvoiceReviewHours))
+	}
+	if req.MinSeats != nil || req.MaxSeats != nil {
+		var minSeats = one.MinSeats
+		var maxSeats = one.MaxSeats
+		if req.MinSeats != nil {
+			minSeats = *req.MinSeats
+		}
+		if req.MaxSeats != nil {
+			maxSeats = *req.MaxSeats
+		}
+		utility.AssertError(CheckSeatLimitParam(minSeats, maxSeats), "invalid seats")
+	}
 
 	var metricPlanCharge = &bean.MetricPlanBindingEntity{}
 	if len(one.MetricCharge) > 0 {
@@ -444,6 +477,7 @@ func PlanEdit(ctx context.Context, req *EditInternalReq) (one *entity.Plan, err
 			utility.Assert(req.TrialDurationTime == nil || *req.TrialDurationTime == 0, "Trial not available for addon and one-time plan")
 			utility.Assert(req.TrialAmount == nil || *req.TrialAmount == 0, "Trial not available for addon and one-time plan")
 			utility.Assert(req.TrialDemand == nil || *req.TrialDemand == "", "Trial not available for addon and one-time plan")
+			utility.Assert(req.TrialEndBehavior == nil || *req.TrialEndBehavior == "", "Trial not available for addon and one-time plan")
 		}
 		utility.Assert(req.MetricMeteredCharge == nil || len(*req.MetricMeteredCharge) == 0, "Metric metered charge not available for addon")
 		utility.Assert(req.MetricRecurringCharge == nil || len(*req.MetricRecurringCharge) == 0, "Metric recurring charge not available for addon")
@@ -539,6 +573,7 @@ func PlanEdit(ctx context.Context, req *EditInternalReq) (one *entity.Plan, err
 	}
 
 	utility.Assert(req.TrialDemand == nil || *req.TrialDemand == "" || *req.TrialDemand == "paymentMethod", "Demand of trial should be paymentMethod or not")
+	utility.Assert(req.TrialEndBehavior == nil || IsValidTrialEndBehavior(*req.TrialEndBehavior), "trialEndBehavior should one of convert|pause|cancel")
 
 	_, err = dao.Plan.Ctx(ctx).Data(g.Map{
 		dao.Plan.Columns().ExternalPlanId:            req.ExternalPlanId,
@@ -559,7 +594,12 @@ func PlanEdit(ctx context.Context, req *EditInternalReq) (one *entity.Plan, err
 		dao.Plan.Columns().TrialDurationTime:         req.TrialDurationTime,
 		dao.Plan.Columns().TrialAmount:               req.TrialAmount,
 		dao.Plan.Columns().CancelAtTrialEnd:          req.CancelAtTrialEnd,
+		dao.Plan.Columns().TrialEndBehavior:          req.TrialEndBehavior,
 		dao.Plan.Columns().ProductId:                 req.ProductId,
+		dao.Plan.Columns().TaxCategory:               req.TaxCategory,
+		dao.Plan.Columns().InvoiceReviewHours:        req.InvoiceReviewHours,
+		dao.Plan.Columns().MinSeats:                  req.MinSeats,
+		dao.Plan.Columns().MaxSeats:                  req.MaxSeats,
 		dao.Plan.Columns().MetricCharge:              utility.MarshalToJsonString(metricPlanCharge),
 		dao.Plan.Columns().GatewayProductDescription: utility.MarshalToJsonString(multiCurrencies),
 	}).Where(dao.Plan.Columns().Id, req.PlanId).OmitNil().Update()
@@ -645,7 +685,12 @@ func PlanCopy(ctx context.Context, planId uint64) (one *entity.Plan, err error)
 		TrialAmount:               one.TrialAmount,
 		TrialDemand:               one.TrialDemand,
 		CancelAtTrialEnd:          one.CancelAtTrialEnd,
+		TrialEndBehavior:          one.TrialEndBehavior,
 		ProductId:                 one.ProductId,
+		TaxCategory:               one.TaxCategory,
+		InvoiceReviewHours:        one.InvoiceReviewHours,
+		MinSeats:                  one.MinSeats,
+		MaxSeats:                  one.MaxSeats,
 		MetricCharge:              one.MetricCharge,
 		GatewayProductDescription: one.GatewayProductDescription,
 	}
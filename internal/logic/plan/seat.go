@@ -0,0 +1,32 @@
+package plan
+
+import (
+	"fmt"
+
+	entity "unibee/internal/model/entity/default"
+)
+
+// CheckSeatLimitParam checks the min and max seats of plan, 0 means no limit
+func CheckSeatLimitParam(minSeats int, maxSeats int) error {
+	if minSeats < 0 || maxSeats < 0 {
+		return fmt.Errorf("minSeats and maxSeats should not be negative")
+	}
+	if maxSeats > 0 && minSeats > maxSeats {
+		return fmt.Errorf("minSeats should not be greater than maxSeats")
+	}
+	return nil
+}
+
+// CheckPlanSeatLimit checks the seats (quantity) of subscription within the min and max seats of plan
+func CheckPlanSeatLimit(one *entity.Plan, quantity int64) error {
+	if one == nil {
+		return nil
+	}
+	if one.MinSeats > 0 && quantity < int64(one.MinSeats) {
+		return fmt.Errorf("seats of plan %s should be at least %d", one.PlanName, one.MinSeats)
+	}
+	if one.MaxSeats > 0 && quantity > int64(one.MaxSeats) {
+		return fmt.Errorf("seats of plan %s should be at most %d", one.PlanName, one.MaxSeats)
+	}
+	return nil
+}
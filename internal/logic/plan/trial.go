@@ -0,0 +1,22 @@
+package plan
+
+import (
+	"unibee/internal/consts"
+	entity "unibee/internal/model/entity/default"
+)
+
+// IsValidTrialEndBehavior checks the trial end behavior of plan, empty means convert
+func IsValidTrialEndBehavior(behavior string) bool {
+	return behavior == "" ||
+		behavior == consts.TrialEndBehaviorConvert ||
+		behavior == consts.TrialEndBehaviorPause ||
+		behavior == consts.TrialEndBehaviorCancel
+}
+
+// GetTrialEndBehavior returns the behavior at trial end of the subscription without payment method
+func GetTrialEndBehavior(one *entity.Plan) string {
+	if one == nil || len(one.TrialEndBehavior) == 0 {
+		return consts.TrialEndBehaviorConvert
+	}
+	return one.TrialEndBehavior
+}
@@ -0,0 +1,252 @@
+package settlement
+
+import (
+	"context"
+
+	"unibee/internal/consts"
+	dao "unibee/internal/dao/default"
+	"unibee/internal/logic/gateway/api"
+	"unibee/internal/logic/gateway/gateway_bean"
+	"unibee/internal/logic/merchant_config"
+	entity "unibee/internal/model/entity/default"
+	"unibee/internal/query"
+	"unibee/utility"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+const MerchantGatewaySettlementConfig = "KEY_MERCHANT_GATEWAY_SETTLEMENT"
+
+type Config struct {
+	Enable bool `json:"enable" dc:"Ingest the settlement reports of the previous day for every gateway support it"`
+}
+
+func GetMerchantSettlementConfig(ctx context.Context, merchantId uint64) *Config {
+	config := &Config{}
+	one := merchant_config.GetMerchantConfig(ctx, merchantId, MerchantGatewaySettlementConfig)
+	if one != nil && len(one.ConfigValue) > 0 {
+		_ = utility.UnmarshalFromJsonString(one.ConfigValue, config)
+	}
+	return config
+}
+
+// Sync fetches the settlements arrived in the window from the gateway and ingests them
+func Sync(ctx context.Context, gateway *entity.MerchantGateway, arrivalTimeStart int64, arrivalTimeEnd int64) (list []*entity.GatewaySettlement, err error) {
+	utility.Assert(gateway != nil, "gateway not found")
+	utility.Assert(arrivalTimeEnd > arrivalTimeStart, "invalid settlement window")
+	provider := api.GetGatewaySettlementProvider(ctx, gateway.Id)
+	utility.Assert(provider != nil, "gateway not support settlement report")
+	remotes, err := provider.GatewaySettlementList(ctx, gateway, &gateway_bean.GatewaySettlementListReq{
+		ArrivalTimeStart: arrivalTimeStart,
+		ArrivalTimeEnd:   arrivalTimeEnd,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, remote := range remotes {
+		one, err := Ingest(ctx, gateway, consts.SettlementSourceGateway, remote)
+		if err != nil {
+			return list, err
+		}
+		list = append(list, one)
+	}
+	g.Log().Infof(ctx, "Settlement Sync gateway:%d window:%d-%d count:%d", gateway.Id, arrivalTimeStart, arrivalTimeEnd, len(list))
+	return list, nil
+}
+
+// Ingest saves the settlement and its items, ingesting the same settlement again updates it
+func Ingest(ctx context.Context, gateway *entity.MerchantGateway, source string, req *gateway_bean.GatewaySettlement) (one *entity.GatewaySettlement, err error) {
+	utility.Assert(gateway != nil, "gateway not found")
+	utility.Assert(req != nil && len(req.GatewaySettlementId) > 0, "invalid settlement")
+	one = query.GetSettlementByGatewaySettlementId(ctx, gateway.Id, req.GatewaySettlementId)
+	if one == nil {
+		one = &entity.GatewaySettlement{
+			MerchantId:          gateway.MerchantId,
+			GatewayId:           gateway.Id,
+			GatewaySettlementId: req.GatewaySettlementId,
+			Source:              source,
+			Currency:            req.Currency,
+			NetAmount:           req.NetAmount,
+			Status:              req.Status,
+			ArrivalTime:         req.ArrivalTime,
+			Data:                req.Data,
+			CreateTime:          gtime.Now().Timestamp(),
+		}
+		result, err := dao.GatewaySettlement.Ctx(ctx).Data(one).OmitNil().Insert(one)
+		if err != nil {
+			return nil, gerror.Newf(`settlement record insert failure %s`, err.Error())
+		}
+		id, _ := result.LastInsertId()
+		one.Id = uint64(id)
+	} else {
+		_, err = dao.GatewaySettlement.Ctx(ctx).Data(g.Map{
+			dao.GatewaySettlement.Columns().NetAmount:   req.NetAmount,
+			dao.GatewaySettlement.Columns().Status:      req.Status,
+			dao.GatewaySettlement.Columns().ArrivalTime: req.ArrivalTime,
+			dao.GatewaySettlement.Columns().Data:        req.Data,
+			dao.GatewaySettlement.Columns().GmtModify:   gtime.Now(),
+		}).Where(dao.GatewaySettlement.Columns().Id, one.Id).OmitEmpty().Update()
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, item := range req.Items {
+		if item == nil || len(item.GatewayTransactionId) == 0 {
+			continue
+		}
+		if err = ingestItem(ctx, gateway, one, item); err != nil {
+			return nil, err
+		}
+	}
+	if err = Refresh(ctx, one.Id); err != nil {
+		return nil, err
+	}
+	return query.GetSettlementById(ctx, one.Id), nil
+}
+
+func ingestItem(ctx context.Context, gateway *entity.MerchantGateway, settlement *entity.GatewaySettlement, item *gateway_bean.GatewaySettlementItem) error {
+	match := MatchItem(ctx, gateway, item)
+	exist := query.GetSettlementItemByGatewayTransactionId(ctx, gateway.Id, item.GatewayTransactionId)
+	if exist != nil {
+		_, err := dao.GatewaySettlementItem.Ctx(ctx).Data(g.Map{
+			dao.GatewaySettlementItem.Columns().SettlementId:        settlement.Id,
+			dao.GatewaySettlementItem.Columns().GatewaySettlementId: settlement.GatewaySettlementId,
+			dao.GatewaySettlementItem.Columns().Type:                item.Type,
+			dao.GatewaySettlementItem.Columns().GatewayPaymentId:    match.GatewayPaymentId,
+			dao.GatewaySettlementItem.Columns().GatewayRefundId:     item.GatewayRefundId,
+			dao.GatewaySettlementItem.Columns().PaymentId:           match.PaymentId,
+			dao.GatewaySettlementItem.Columns().RefundId:            match.RefundId,
+			dao.GatewaySettlementItem.Columns().InvoiceId:           match.InvoiceId,
+			dao.GatewaySettlementItem.Columns().Currency:            item.Currency,
+			dao.GatewaySettlementItem.Columns().GrossAmount:         item.GrossAmount,
+			dao.GatewaySettlementItem.Columns().FeeAmount:           item.FeeAmount,
+			dao.GatewaySettlementItem.Columns().NetAmount:           item.NetAmount,
+			dao.GatewaySettlementItem.Columns().TransactionTime:     item.TransactionTime,
+			dao.GatewaySettlementItem.Columns().IsDeleted:           0,
+			dao.GatewaySettlementItem.Columns().GmtModify:           gtime.Now(),
+		}).Where(dao.GatewaySettlementItem.Columns().Id, exist.Id).Update()
+		if exist.SettlementId != settlement.Id {
+			// moved from another settlement, the totals of it change as well
+			_ = Refresh(ctx, exist.SettlementId)
+		}
+		return err
+	}
+	_, err := dao.GatewaySettlementItem.Ctx(ctx).Data(&entity.GatewaySettlementItem{
+		MerchantId:           gateway.MerchantId,
+		GatewayId:            gateway.Id,
+		SettlementId:         settlement.Id,
+		GatewaySettlementId:  settlement.GatewaySettlementId,
+		GatewayTransactionId: item.GatewayTransactionId,
+		Type:                 item.Type,
+		GatewayPaymentId:     match.GatewayPaymentId,
+		GatewayRefundId:      item.GatewayRefundId,
+		PaymentId:            match.PaymentId,
+		RefundId:             match.RefundId,
+		InvoiceId:            match.InvoiceId,
+		Currency:             item.Currency,
+		GrossAmount:          item.GrossAmount,
+		FeeAmount:            item.FeeAmount,
+		NetAmount:            item.NetAmount,
+		TransactionTime:      item.TransactionTime,
+		CreateTime:           gtime.Now().Timestamp(),
+	}).OmitNil().Insert()
+	if err != nil {
+		return gerror.Newf(`settlement item insert failure %s`, err.Error())
+	}
+	return nil
+}
+
+type Match struct {
+	GatewayPaymentId string
+	PaymentId        string
+	RefundId         string
+	InvoiceId        string
+}
+
+// MatchItem links a settlement transaction to the local refund or payment of the same gateway,
+// by gatewayRefundId first, then by the local paymentId carried by the gateway, then by gatewayPaymentId
+func MatchItem(ctx context.Context, gateway *entity.MerchantGateway, item *gateway_bean.GatewaySettlementItem) *Match {
+	match := &Match{GatewayPaymentId: item.GatewayPaymentId}
+	if len(item.GatewayRefundId) > 0 {
+		refund := query.GetRefundByGatewayRefundId(ctx, item.GatewayRefundId)
+		if refund != nil && refund.GatewayId == gateway.Id {
+			match.RefundId = refund.RefundId
+			match.PaymentId = refund.PaymentId
+			match.InvoiceId = refund.InvoiceId
+			if len(match.GatewayPaymentId) == 0 {
+				if payment := query.GetPaymentByPaymentId(ctx, refund.PaymentId); payment != nil {
+					match.GatewayPaymentId = payment.GatewayPaymentId
+				}
+			}
+			return match
+		}
+	}
+	var payment *entity.Payment
+	if len(item.PaymentId) > 0 {
+		payment = query.GetPaymentByPaymentId(ctx, item.PaymentId)
+	}
+	if payment == nil && len(item.GatewayPaymentId) > 0 {
+		payment = query.GetPaymentByGatewayPaymentId(ctx, item.GatewayPaymentId)
+	}
+	if payment != nil && payment.GatewayId == gateway.Id {
+		match.PaymentId = payment.PaymentId
+		match.InvoiceId = payment.InvoiceId
+		if len(match.GatewayPaymentId) == 0 {
+			match.GatewayPaymentId = payment.GatewayPaymentId
+		}
+	}
+	return match
+}
+
+// Refresh recalculates the totals of the settlement from its items, the net amount reported by the gateway is kept
+func Refresh(ctx context.Context, settlementId uint64) error {
+	one := query.GetSettlementById(ctx, settlementId)
+	if one == nil {
+		return nil
+	}
+	summary := Summarize(query.GetSettlementItemList(ctx, settlementId))
+	var netAmount = one.NetAmount
+	if one.Source == consts.SettlementSourceImport {
+		netAmount = summary.NetAmount
+	}
+	_, err := dao.GatewaySettlement.Ctx(ctx).Data(g.Map{
+		dao.GatewaySettlement.Columns().GrossAmount:  summary.GrossAmount,
+		dao.GatewaySettlement.Columns().FeeAmount:    summary.FeeAmount,
+		dao.GatewaySettlement.Columns().NetAmount:    netAmount,
+		dao.GatewaySettlement.Columns().ItemCount:    summary.ItemCount,
+		dao.GatewaySettlement.Columns().MatchedCount: summary.MatchedCount,
+		dao.GatewaySettlement.Columns().GmtModify:    gtime.Now(),
+	}).Where(dao.GatewaySettlement.Columns().Id, settlementId).Update()
+	if err != nil {
+		g.Log().Errorf(ctx, "Settlement Refresh settlement:%d error:%s", settlementId, err.Error())
+	}
+	return err
+}
+
+type Summary struct {
+	GrossAmount  int64
+	FeeAmount    int64
+	NetAmount    int64
+	ItemCount    int
+	MatchedCount int
+}
+
+// Summarize counts the transactions of one settlement, transactions matched with a payment or refund are matched
+func Summarize(items []*entity.GatewaySettlementItem) *Summary {
+	summary := &Summary{}
+	for _, one := range items {
+		if one == nil {
+			continue
+		}
+		summary.GrossAmount = summary.GrossAmount + one.GrossAmount
+		summary.FeeAmount = summary.FeeAmount + one.FeeAmount
+		summary.NetAmount = summary.NetAmount + one.NetAmount
+		summary.ItemCount++
+		if len(one.PaymentId) > 0 || len(one.RefundId) > 0 {
+			summary.MatchedCount++
+		}
+	}
+	return summary
+}
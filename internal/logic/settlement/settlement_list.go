@@ -0,0 +1,111 @@
+package settlement
+
+import (
+	"context"
+	"strings"
+
+	"unibee/api/bean"
+	dao "unibee/internal/dao/default"
+	entity "unibee/internal/model/entity/default"
+	"unibee/utility"
+)
+
+type ListInternalReq struct {
+	MerchantId       uint64 `json:"merchantId"       dc:"MerchantId"`
+	GatewayId        uint64 `json:"gatewayId"        dc:"GatewayId"`
+	Currency         string `json:"currency"         dc:"Currency"`
+	Status           int    `json:"status"           dc:"Status, 1-Pending|2-Paid|3-Failed|4-Cancelled"`
+	ArrivalTimeStart int64  `json:"arrivalTimeStart" dc:"ArrivalTimeStart, utc time"`
+	ArrivalTimeEnd   int64  `json:"arrivalTimeEnd"   dc:"ArrivalTimeEnd, utc time"`
+	Page             int    `json:"page"             dc:"Page, Start With 0"`
+	Count            int    `json:"count"            dc:"Count Of Page"`
+}
+
+func List(ctx context.Context, req *ListInternalReq) (list []*bean.GatewaySettlement, total int, err error) {
+	utility.Assert(req.MerchantId > 0, "merchantId not found")
+	if req.Count <= 0 {
+		req.Count = 20
+	}
+	if req.Page < 0 {
+		req.Page = 0
+	}
+	q := dao.GatewaySettlement.Ctx(ctx).
+		Where(dao.GatewaySettlement.Columns().MerchantId, req.MerchantId).
+		Where(dao.GatewaySettlement.Columns().IsDeleted, 0)
+	if req.GatewayId > 0 {
+		q = q.Where(dao.GatewaySettlement.Columns().GatewayId, req.GatewayId)
+	}
+	if len(req.Currency) > 0 {
+		q = q.Where(dao.GatewaySettlement.Columns().Currency, strings.ToUpper(req.Currency))
+	}
+	if req.Status > 0 {
+		q = q.Where(dao.GatewaySettlement.Columns().Status, req.Status)
+	}
+	if req.ArrivalTimeStart > 0 {
+		q = q.WhereGTE(dao.GatewaySettlement.Columns().ArrivalTime, req.ArrivalTimeStart)
+	}
+	if req.ArrivalTimeEnd > 0 {
+		q = q.WhereLT(dao.GatewaySettlement.Columns().ArrivalTime, req.ArrivalTimeEnd)
+	}
+	var entities []*entity.GatewaySettlement
+	err = q.Order("arrival_time desc, id desc").
+		Limit(req.Page*req.Count, req.Count).
+		ScanAndCount(&entities, &total, true)
+	if err != nil {
+		return nil, 0, err
+	}
+	list = make([]*bean.GatewaySettlement, 0)
+	for _, one := range entities {
+		list = append(list, bean.SimplifyGatewaySettlement(one))
+	}
+	return list, total, nil
+}
+
+type ItemListInternalReq struct {
+	MerchantId   uint64 `json:"merchantId"   dc:"MerchantId"`
+	SettlementId uint64 `json:"settlementId" dc:"SettlementId"`
+	PaymentId    string `json:"paymentId"    dc:"PaymentId"`
+	InvoiceId    string `json:"invoiceId"    dc:"InvoiceId"`
+	Type         string `json:"type"         dc:"Type, payment|refund|fee|dispute|adjustment|other"`
+	Page         int    `json:"page"         dc:"Page, Start With 0"`
+	Count        int    `json:"count"        dc:"Count Of Page"`
+}
+
+// ItemList lists the settled transactions of a settlement, or of a payment or invoice across settlements
+func ItemList(ctx context.Context, req *ItemListInternalReq) (list []*bean.GatewaySettlementItem, total int, err error) {
+	utility.Assert(req.MerchantId > 0, "merchantId not found")
+	utility.Assert(req.SettlementId > 0 || len(req.PaymentId) > 0 || len(req.InvoiceId) > 0, "settlementId, paymentId or invoiceId is required")
+	if req.Count <= 0 {
+		req.Count = 100
+	}
+	if req.Page < 0 {
+		req.Page = 0
+	}
+	q := dao.GatewaySettlementItem.Ctx(ctx).
+		Where(dao.GatewaySettlementItem.Columns().MerchantId, req.MerchantId).
+		Where(dao.GatewaySettlementItem.Columns().IsDeleted, 0)
+	if req.SettlementId > 0 {
+		q = q.Where(dao.GatewaySettlementItem.Columns().SettlementId, req.SettlementId)
+	}
+	if len(req.PaymentId) > 0 {
+		q = q.Where(dao.GatewaySettlementItem.Columns().PaymentId, req.PaymentId)
+	}
+	if len(req.InvoiceId) > 0 {
+		q = q.Where(dao.GatewaySettlementItem.Columns().InvoiceId, req.InvoiceId)
+	}
+	if len(req.Type) > 0 {
+		q = q.Where(dao.GatewaySettlementItem.Columns().Type, req.Type)
+	}
+	var entities []*entity.GatewaySettlementItem
+	err = q.OrderAsc(dao.GatewaySettlementItem.Columns().TransactionTime).
+		Limit(req.Page*req.Count, req.Count).
+		ScanAndCount(&entities, &total, true)
+	if err != nil {
+		return nil, 0, err
+	}
+	list = make([]*bean.GatewaySettlementItem, 0)
+	for _, one := range entities {
+		list = append(list, bean.SimplifyGatewaySettlementItem(one))
+	}
+	return list, total, nil
+}
@@ -0,0 +1,22 @@
+package settlement
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	entity "unibee/internal/model/entity/default"
+)
+
+func TestSummarize(t *testing.T) {
+	summary := Summarize([]*entity.GatewaySettlementItem{
+		{Type: "payment", PaymentId: "pay_1", GrossAmount: 1000, FeeAmount: 59, NetAmount: 941},
+		{Type: "refund", PaymentId: "pay_1", RefundId: "re_1", GrossAmount: -300, FeeAmount: 0, NetAmount: -300},
+		{Type: "fee", GrossAmount: -100, FeeAmount: 0, NetAmount: -100},
+		nil,
+	})
+	require.Equal(t, int64(600), summary.GrossAmount)
+	require.Equal(t, int64(59), summary.FeeAmount)
+	require.Equal(t, int64(541), summary.NetAmount)
+	require.Equal(t, 3, summary.ItemCount)
+	require.Equal(t, 2, summary.MatchedCount)
+}
@@ -15,18 +15,26 @@ import (
 	dao "unibee/internal/dao/default"
 	config3 "unibee/internal/logic/credit/config"
 	"unibee/internal/logic/discount"
+	"unibee/internal/logic/invoice/draft"
 	handler3 "unibee/internal/logic/invoice/handler"
 	"unibee/internal/logic/invoice/invoice_compute"
+	"unibee/internal/logic/invoice/receivable"
 	handler2 "unibee/internal/logic/invoice/service"
 	"unibee/internal/logic/metric_event"
 	"unibee/internal/logic/payment/service"
 	"unibee/internal/logic/plan/period"
 	"unibee/internal/logic/subscription/billingcycle/expire"
 	"unibee/internal/logic/subscription/config"
+	"unibee/internal/logic/subscription/consolidation"
 	"unibee/internal/logic/subscription/handler"
+	"unibee/internal/logic/subscription/pause"
+	"unibee/internal/logic/subscription/pending_item"
 	"unibee/internal/logic/subscription/pending_update_cancel"
+	"unibee/internal/logic/subscription/schedule"
+	"unibee/internal/logic/subscription/seat"
 	service2 "unibee/internal/logic/subscription/service"
 	"unibee/internal/logic/subscription/service/next"
+	"unibee/internal/logic/subscription/trial"
 	"unibee/internal/logic/user/sub_update"
 	"unibee/internal/logic/user/vat"
 	entity "unibee/internal/model/entity/default"
@@ -73,6 +81,26 @@ func SubPipeBillingCycleWalk(ctx context.Context, subId string, timeNow int64, s
 			g.Log().Errorf(ctx, source, "SubscriptionBillingCycleDunningInvoice Update TaskTime err:", err.Error())
 		}
 
+		if sub.Status == consts.SubStatusSuspended {
+			if pause.IsDueForResume(sub, timeNow) {
+				_, err = pause.ResumeAt(ctx, sub, timeNow, "AutoResume")
+				if err != nil {
+					g.Log().Errorf(ctx, source, "SubscriptionBillingCycleDunningInvoice ResumeAt err:", err.Error())
+					return nil, err
+				}
+				return &BillingCycleWalkRes{WalkUnfinished: true, Message: "Subscription Resumed As Resume Time Reached"}, nil
+			}
+			return &BillingCycleWalkRes{WalkUnfinished: false, Message: "Nothing Todo As Sub Paused"}, nil
+		}
+
+		trialHandled, trialMessage, err := trial.HandleTrialEnd(ctx, sub, timeNow)
+		if err != nil {
+			g.Log().Errorf(ctx, source, "SubscriptionBillingCycleDunningInvoice HandleTrialEnd err:", err.Error())
+			return nil, err
+		} else if trialHandled {
+			return &BillingCycleWalkRes{WalkUnfinished: false, Message: trialMessage}, nil
+		}
+
 		if (utility.MaxInt64(sub.CurrentPeriodEnd, sub.TrialEnd) - timeNow) < 3600*6 {
 			// last 6 hours
 			if len(sub.PendingUpdateId) > 0 {
@@ -113,6 +141,11 @@ func SubPipeBillingCycleWalk(ctx context.Context, subId string, timeNow int64, s
 		} else if timeNow < sub.DunningTime {
 			needInvoiceGenerate = false
 		}
+		reviewDraftOpen := draft.IsReviewDraft(latestInvoice)
+		if reviewDraftOpen {
+			// the draft in review is the invoice of this cycle, it is finalized on schedule instead of generated again
+			needInvoiceGenerate = false
+		}
 		// lock the invoice and payment creation half an hour before period end
 		if timeNow > utility.MaxInt64(sub.CurrentPeriodEnd, sub.TrialEnd)-27*60 && timeNow < utility.MaxInt64(sub.CurrentPeriodEnd, sub.TrialEnd) {
 			needInvoiceGenerate = false
@@ -130,6 +163,11 @@ func SubPipeBillingCycleWalk(ctx context.Context, subId string, timeNow int64, s
 			// pay invoice immediate if amount is zero
 			needTryInvoiceAutomaticPayment = true
 		}
+		if latestInvoice != nil && receivable.IsNetTermInvoice(latestInvoice) && latestInvoice.TotalAmount > 0 {
+			// net term invoice waits for customer transfer, no automatic charge
+			needTryInvoiceAutomaticPayment = false
+		}
+		netTermOutstanding := receivable.IsWithinNetTerm(latestInvoice, timeNow)
 
 		if sub.Status == consts.SubStatusExpired || sub.Status == consts.SubStatusFailed || sub.Status == consts.SubStatusCancelled {
 			return &BillingCycleWalkRes{WalkUnfinished: false, Message: "Nothing Todo As Sub Cancelled Or Expired Or Failed"}, nil
@@ -166,14 +204,14 @@ func SubPipeBillingCycleWalk(ctx context.Context, subId string, timeNow int64, s
 			//}
 			// sub set cancelAtPeriodEnd, need cancel by system
 			needInvoiceGenerate = false
-			err = service2.SubscriptionCancel(ctx, sub.SubscriptionId, false, false, "CancelAtPeriodEndBySystem")
+			err = service2.SubscriptionCancelBySystem(ctx, sub.SubscriptionId, "CancelAtPeriodEndBySystem")
 			if err != nil {
 				g.Log().Errorf(ctx, source, "SubscriptionBillingCycleDunningInvoice SubscriptionCancel err:", err.Error())
 				return nil, err
 			} else {
 				return &BillingCycleWalkRes{WalkUnfinished: true, Message: "SubscriptionCancel At Billing Cycle End By CurrentPeriodEnd Set"}, nil
 			}
-		} else if !needInvoiceGenerate && !needTryInvoiceAutomaticPayment && isSubscriptionExpireExcludePending(ctx, sub, timeNow) {
+		} else if !needInvoiceGenerate && !needTryInvoiceAutomaticPayment && !netTermOutstanding && !reviewDraftOpen && isSubscriptionExpireExcludePending(ctx, sub, timeNow) {
 			// invoice not generate and sub out of time, need expired by system
 			err = expire.SubscriptionExpire(ctx, sub, "AutoRenewFailure")
 			if err != nil {
@@ -209,8 +247,8 @@ func SubPipeBillingCycleWalk(ctx context.Context, subId string, timeNow int64, s
 				//}
 				return &BillingCycleWalkRes{WalkUnfinished: false, Message: "Nothing Todo As CancelPeriodEnd Set"}, nil
 			}
-			// Unpaid after period end or trial end
-			if utility.MaxInt64(sub.CurrentPeriodEnd, sub.TrialEnd) < timeNow && sub.Status != consts.SubStatusIncomplete && config.GetMerchantSubscriptionConfig(ctx, sub.MerchantId).IncompleteExpireTime > 30 {
+			// Unpaid after period end or trial end, the draft in review is finalized first
+			if utility.MaxInt64(sub.CurrentPeriodEnd, sub.TrialEnd) < timeNow && sub.Status != consts.SubStatusIncomplete && !netTermOutstanding && !reviewDraftOpen && config.GetMerchantSubscriptionConfig(ctx, sub.MerchantId).IncompleteExpireTime > 30 {
 				err = handler.HandleSubscriptionIncomplete(ctx, sub.SubscriptionId, timeNow, "OutOfPeriod")
 				if err != nil {
 					g.Log().Errorf(ctx, source, "SubscriptionBillingCycleDunningInvoice HandleSubscriptionIncomplete err:", err.Error())
@@ -221,8 +259,17 @@ func SubPipeBillingCycleWalk(ctx context.Context, subId string, timeNow int64, s
 			}
 
 			if needInvoiceGenerate {
+				var generate bool
+				sub, generate, err = schedule.PrepareNextCycle(ctx, sub, utility.MaxInt64(sub.CurrentPeriodEnd, sub.TrialEnd))
+				if err != nil {
+					g.Log().Errorf(ctx, source, "SubscriptionBillingCycleDunningInvoice PrepareNextCycle err:%s", err.Error())
+					return nil, err
+				}
+				if !generate {
+					return &BillingCycleWalkRes{WalkUnfinished: true, Message: "Subscription Schedule Completed, CancelAtPeriodEnd Set"}, nil
+				}
 				nextApplyData := next.GetSubscriptionNextInvoiceData(ctx, sub.SubscriptionId)
-				invoice, pendingUpdate := PreviewSubscriptionNextInvoice(ctx, sub, nextApplyData, timeNow)
+				invoice, pendingUpdate, billedItems := previewSubscriptionNextInvoice(ctx, sub, nextApplyData, timeNow)
 				gatewayId, paymentType, paymentMethodId := sub_update.VerifyPaymentGatewayMethod(ctx, sub.UserId, nil, "", "", sub.SubscriptionId)
 				if gatewayId > 0 && (gatewayId != sub.GatewayId || paymentMethodId != sub.GatewayDefaultPaymentMethod) {
 					_, _ = dao.Subscription.Ctx(ctx).Data(g.Map{
@@ -242,11 +289,14 @@ func SubPipeBillingCycleWalk(ctx context.Context, subId string, timeNow int64, s
 					PaymentMethodId:    sub.GatewayDefaultPaymentMethod,
 					IsSubLatestInvoice: true,
 					TimeNow:            timeNow,
+					ReviewHours:        draft.ReviewHours(plan, sub),
 				})
 				if err != nil {
 					g.Log().Errorf(ctx, source, "SubscriptionBillingCycleDunningInvoice CreateProcessingInvoiceForSub err:%s", err.Error())
 					return nil, err
 				}
+				seat.MarkTrueUpBilled(ctx, sub, one.InvoiceId, billedItems.SeatChangeIds)
+				pending_item.MarkInvoiced(ctx, sub, one.InvoiceId, billedItems.PendingItemIds, billedItems.PendingItems)
 				if pendingUpdate != nil {
 					_, err = dao.SubscriptionPendingUpdate.Ctx(ctx).Data(g.Map{
 						dao.SubscriptionPendingUpdate.Columns().GmtModify: gtime.Now(),
@@ -269,6 +319,17 @@ func SubPipeBillingCycleWalk(ctx context.Context, subId string, timeNow int64, s
 				if latestInvoice == nil {
 					return &BillingCycleWalkRes{WalkUnfinished: false, Message: "Nothing Todo As invalid latestInvoice"}, nil
 				}
+				if draft.IsReviewDraft(latestInvoice) {
+					if draft.IsDueForFinalize(latestInvoice, timeNow) {
+						one, err := draft.FinalizeDraftInvoice(ctx, sub.MerchantId, latestInvoice.InvoiceId, "SubscriptionBillingCycle")
+						if err != nil {
+							g.Log().Errorf(ctx, source, "SubscriptionBillingCycleDunningInvoice FinalizeDraftInvoice err:%s", err.Error())
+							return nil, err
+						}
+						return &BillingCycleWalkRes{WalkUnfinished: true, Message: fmt.Sprintf("Subscription Finalize Draft Invoice Result:%s", utility.MarshalToJsonString(one))}, nil
+					}
+					return &BillingCycleWalkRes{WalkUnfinished: false, Message: "Nothing Todo As Draft Invoice In Review"}, nil
+				}
 				if latestInvoice.Status == consts.InvoiceStatusProcessing {
 					trackForSubscriptionLatestProcessInvoice(ctx, sub, timeNow)
 				}
@@ -299,6 +360,14 @@ func SubPipeBillingCycleWalk(ctx context.Context, subId string, timeNow int64, s
 						}
 						return &BillingCycleWalkRes{WalkUnfinished: true, Message: fmt.Sprintf("Subscription Finish Zero Invoice Payment Result:%s", utility.MarshalToJsonString(paidInvoice))}, nil
 					} else {
+						consolidatedRes, err := consolidation.TryConsolidatedAutomaticPayment(ctx, sub, latestInvoice, timeNow, "SubscriptionBillingCycle")
+						if err != nil {
+							g.Log().Errorf(ctx, "AutomaticPaymentByCycle TryConsolidatedAutomaticPayment err:%s", err.Error())
+							return nil, err
+						}
+						if consolidatedRes.Handled {
+							return &BillingCycleWalkRes{WalkUnfinished: consolidatedRes.Charged, Message: consolidatedRes.Message}, nil
+						}
 						// gatewayId, paymentMethodId := user.VerifyPaymentGatewayMethod(ctx, sub.UserId, nil, "", sub.SubscriptionId)
 						createRes, err := service.CreateSubInvoicePaymentDefaultAutomatic(ctx, &service.CreateSubInvoicePaymentDefaultAutomaticReq{
 							Invoice:       latestInvoice,
@@ -343,6 +412,18 @@ func SubPipeBillingCycleWalk(ctx context.Context, subId string, timeNow int64, s
 }
 
 func PreviewSubscriptionNextInvoice(ctx context.Context, sub *entity.Subscription, nextApplyData *bean.SubscriptionNextInvoiceData, timeNow int64) (*bean.Invoice, *entity.SubscriptionPendingUpdate) {
+	invoice, pendingUpdate, _ := previewSubscriptionNextInvoice(ctx, sub, nextApplyData, timeNow)
+	return invoice, pendingUpdate
+}
+
+// nextInvoiceBilledItems are the seat changes and the pending invoice items merged into the next cycle invoice
+type nextInvoiceBilledItems struct {
+	SeatChangeIds  []uint64
+	PendingItemIds []string
+	PendingItems   []*invoice_compute.AdditionalInvoiceItem // capped by the invoice computed, by index of PendingItemIds
+}
+
+func previewSubscriptionNextInvoice(ctx context.Context, sub *entity.Subscription, nextApplyData *bean.SubscriptionNextInvoiceData, timeNow int64) (*bean.Invoice, *entity.SubscriptionPendingUpdate, *nextInvoiceBilledItems) {
 	user := query.GetUserAccountById(ctx, sub.UserId)
 	utility.Assert(user != nil, "user not found")
 	plan := query.GetPlanById(ctx, sub.PlanId)
@@ -352,6 +433,17 @@ func PreviewSubscriptionNextInvoice(ctx context.Context, sub *entity.Subscriptio
 	if err == nil {
 		taxPercentage = percentage
 	}
+	pendingUpdate := query.GetUnfinishedSubscriptionPendingUpdateByPendingUpdateId(ctx, sub.PendingUpdateId)
+	if pendingUpdate != nil && (pendingUpdate.EffectImmediate == 1 || pending_update_cancel.IsWaitingForCommitmentTermEnd(pendingUpdate, utility.MaxInt64(sub.CurrentPeriodEnd, sub.TrialEnd))) {
+		pendingUpdate = nil
+	}
+	var discountPlanId = sub.PlanId
+	var isSchedulePhase = schedule.IsSchedulePendingUpdate(pendingUpdate)
+	if isSchedulePhase {
+		// the phase of subscription schedule brings its own discount
+		sub.DiscountCode = pendingUpdate.DiscountCode
+		discountPlanId = pendingUpdate.UpdatePlanId
+	}
 	if nextApplyData != nil && nextApplyData.DiscountCode != "" {
 		sub.DiscountCode = nextApplyData.DiscountCode
 	}
@@ -366,7 +458,7 @@ func PreviewSubscriptionNextInvoice(ctx context.Context, sub *entity.Subscriptio
 		DiscountCode:       sub.DiscountCode,
 		Currency:           sub.Currency,
 		SubscriptionId:     sub.SubscriptionId,
-		PLanId:             sub.PlanId,
+		PLanId:             discountPlanId,
 		TimeNow:            timeNow,
 		IsRecurringApply:   true,
 		IsUpgrade:          false,
@@ -377,10 +469,6 @@ func PreviewSubscriptionNextInvoice(ctx context.Context, sub *entity.Subscriptio
 	if canApply && isRecurring {
 		discountCode = sub.DiscountCode
 	}
-	pendingUpdate := query.GetUnfinishedSubscriptionPendingUpdateByPendingUpdateId(ctx, sub.PendingUpdateId)
-	if pendingUpdate != nil && pendingUpdate.EffectImmediate == 1 {
-		pendingUpdate = nil
-	}
 	applyPromoCredit := config3.CheckCreditConfigRecurring(ctx, sub.MerchantId, consts.CreditAccountTypePromo, sub.Currency)
 	if config3.CheckCreditConfigDiscountCodeExclusive(ctx, sub.MerchantId, consts.CreditAccountTypePromo, sub.Currency) && len(discountCode) > 0 {
 		applyPromoCredit = false
@@ -390,11 +478,23 @@ func PreviewSubscriptionNextInvoice(ctx context.Context, sub *entity.Subscriptio
 		*applyPromoCreditAmount = nextApplyData.ApplyPromoCreditAmount
 		applyPromoCredit = true
 	}
+	// the seats true-up and the unbilled pending invoice items charged with the cycle invoice
+	additionalItems, seatChangeIds := seat.TrueUpInvoiceItems(ctx, sub)
+	pendingItems, pendingItemIds := pending_item.NextInvoiceItems(ctx, sub)
+	additionalItems = append(additionalItems, pendingItems...)
+	billedItems := &nextInvoiceBilledItems{SeatChangeIds: seatChangeIds, PendingItemIds: pendingItemIds, PendingItems: pendingItems}
 	if pendingUpdate != nil {
 		//generate PendingUpdate cycle invoice
 		updatePlan := query.GetPlanById(ctx, pendingUpdate.UpdatePlanId)
 		var nextPeriodStart = utility.MaxInt64(sub.CurrentPeriodEnd, sub.TrialEnd)
 		var nextPeriodEnd = period.GetPeriodEndFromStart(ctx, nextPeriodStart, sub.BillingCycleAnchor, updatePlan.Id)
+		var invoiceName = "SubscriptionDowngrade"
+		var metadata = map[string]interface{}{"SubscriptionUpdate": true, "IsUpgrade": false}
+		if isSchedulePhase {
+			invoiceName = "SubscriptionCycle"
+			_ = utility.UnmarshalFromJsonString(pendingUpdate.MetaData, &metadata)
+			metadata["SubscriptionUpdate"] = true
+		}
 
 		invoice = invoice_compute.ComputeSubscriptionBillingCycleInvoiceDetailSimplify(ctx, &invoice_compute.CalculateInvoiceReq{
 			UserId:                     sub.UserId,
@@ -409,13 +509,14 @@ func PreviewSubscriptionNextInvoice(ctx context.Context, sub *entity.Subscriptio
 			TaxPercentage:              taxPercentage,
 			PeriodStart:                nextPeriodStart,
 			PeriodEnd:                  nextPeriodEnd,
-			InvoiceName:                "SubscriptionDowngrade",
+			InvoiceName:                invoiceName,
 			FinishTime:                 timeNow,
 			CreateFrom:                 consts.InvoiceAutoChargeFlag,
-			Metadata:                   map[string]interface{}{"SubscriptionUpdate": true, "IsUpgrade": false},
+			Metadata:                   metadata,
 			ApplyPromoCredit:           applyPromoCredit,
 			ApplyPromoCreditAmount:     applyPromoCreditAmount,
 			UserMetricChargeForInvoice: metric_event.GetUserMetricStatForAutoChargeInvoice(ctx, sub.MerchantId, user, sub, true),
+			AdditionalItems:            additionalItems,
 		})
 	} else {
 		//generate cycle invoice from sub
@@ -423,6 +524,13 @@ func PreviewSubscriptionNextInvoice(ctx context.Context, sub *entity.Subscriptio
 
 		var nextPeriodStart = utility.MaxInt64(sub.CurrentPeriodEnd, sub.TrialEnd)
 		var nextPeriodEnd = period.GetPeriodEndFromStart(ctx, nextPeriodStart, sub.BillingCycleAnchor, plan.Id)
+		// align the period of a consolidated user's subscription to the consolidation anchor
+		var billingCycleAnchor int64 = 0
+		alignedPeriodEnd, prorationScale := consolidation.AlignCyclePeriod(user, plan, nextPeriodStart, nextPeriodEnd)
+		if prorationScale > 0 {
+			nextPeriodEnd = alignedPeriodEnd
+			billingCycleAnchor = user.ConsolidatedBillingAnchor
+		}
 
 		invoice = invoice_compute.ComputeSubscriptionBillingCycleInvoiceDetailSimplify(ctx, &invoice_compute.CalculateInvoiceReq{
 			UserId:                     sub.UserId,
@@ -439,11 +547,14 @@ func PreviewSubscriptionNextInvoice(ctx context.Context, sub *entity.Subscriptio
 			PeriodEnd:                  nextPeriodEnd,
 			InvoiceName:                "SubscriptionCycle",
 			FinishTime:                 timeNow,
+			BillingCycleAnchor:         billingCycleAnchor,
 			CreateFrom:                 consts.InvoiceAutoChargeFlag,
 			Metadata:                   map[string]interface{}{},
 			ApplyPromoCredit:           applyPromoCredit,
 			ApplyPromoCreditAmount:     applyPromoCreditAmount,
 			UserMetricChargeForInvoice: metric_event.GetUserMetricStatForAutoChargeInvoice(ctx, sub.MerchantId, user, sub, true),
+			ProrationScale:             prorationScale,
+			AdditionalItems:            additionalItems,
 		})
 	}
 	if sub.TrialEnd > 0 && sub.TrialEnd == sub.CurrentPeriodEnd {
@@ -452,7 +563,7 @@ func PreviewSubscriptionNextInvoice(ctx context.Context, sub *entity.Subscriptio
 	if nextApplyData != nil && invoice.Metadata != nil {
 		invoice.Metadata["NextApplyData"] = nextApplyData
 	}
-	return invoice, pendingUpdate
+	return invoice, pendingUpdate, billedItems
 }
 
 func trackForSubscriptionLatestProcessInvoice(ctx context.Context, sub *entity.Subscription, timeNow int64) {
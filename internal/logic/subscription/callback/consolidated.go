@@ -0,0 +1,110 @@
+package callback
+
+import (
+	"context"
+	"fmt"
+	"github.com/gogf/gf/v2/frame/g"
+	redismq "github.com/jackyang-hk/go-redismq"
+	redismq2 "unibee/internal/cmd/redismq"
+	"unibee/internal/consts"
+	handler2 "unibee/internal/logic/invoice/handler"
+	"unibee/internal/logic/subscription/handler"
+	entity "unibee/internal/model/entity/default"
+	"unibee/internal/query"
+	"unibee/utility"
+)
+
+// ConsolidatedPaymentCallback moves the subscriptions of the cycle invoices merged into one consolidated invoice
+type ConsolidatedPaymentCallback struct {
+}
+
+func (s ConsolidatedPaymentCallback) PaymentCreateCallback(ctx context.Context, payment *entity.Payment, invoice *entity.Invoice) {
+}
+
+func (s ConsolidatedPaymentCallback) PaymentSuccessCallback(ctx context.Context, payment *entity.Payment, invoice *entity.Invoice) {
+	if invoice == nil {
+		g.Log().Errorf(ctx, "ConsolidatedPaymentSuccessCallback error invoice is nil paymentId:%s", payment.PaymentId)
+		return
+	}
+	for _, child := range handler2.MarkConsolidatedCycleInvoicesPaid(ctx, invoice, payment) {
+		if child.Status != consts.InvoiceStatusPaid {
+			continue
+		}
+		sub := query.GetSubscriptionBySubscriptionId(ctx, child.SubscriptionId)
+		if sub == nil {
+			g.Log().Errorf(ctx, "ConsolidatedPaymentSuccessCallback sub not found:%s invoiceId:%s", child.SubscriptionId, child.InvoiceId)
+			continue
+		}
+		_ = handler.UpdateSubscriptionDefaultPaymentMethod(ctx, sub.SubscriptionId, payment.GatewayPaymentMethod)
+		pendingUpdate := query.GetSubscriptionPendingUpdateByInvoiceId(ctx, child.InvoiceId)
+		if pendingUpdate != nil {
+			_, err := handler.HandlePendingUpdatePaymentSuccess(ctx, sub, pendingUpdate.PendingUpdateId, child)
+			if err != nil {
+				g.Log().Errorf(ctx, "ConsolidatedPaymentSuccessCallback_Finish_Update subId:%s error:%s", sub.SubscriptionId, err.Error())
+			}
+		} else if sub.LatestInvoiceId == child.InvoiceId {
+			err := handler.HandleSubscriptionNextBillingCyclePaymentSuccess(ctx, sub, child)
+			if err != nil {
+				g.Log().Errorf(ctx, "ConsolidatedPaymentSuccessCallback_Finish_SubscriptionCycle subId:%s error:%s", sub.SubscriptionId, err.Error())
+			}
+		} else {
+			g.Log().Infof(ctx, "ConsolidatedPaymentSuccessCallback Miss Match Subscription Action subId:%s invoiceId:%s", sub.SubscriptionId, child.InvoiceId)
+		}
+		if utility.TryLock(ctx, fmt.Sprintf("ConsolidatedPaymentSuccessCallback_%s", child.InvoiceId), 60) {
+			_, _ = redismq.Send(&redismq.Message{
+				Topic:      redismq2.TopicSubscriptionAutoRenewSuccess.Topic,
+				Tag:        redismq2.TopicSubscriptionAutoRenewSuccess.Tag,
+				Body:       sub.SubscriptionId,
+				CustomData: map[string]interface{}{"CreateFrom": utility.ReflectCurrentFunctionName()},
+			})
+		}
+	}
+}
+
+func (s ConsolidatedPaymentCallback) PaymentFailureCallback(ctx context.Context, payment *entity.Payment, invoice *entity.Invoice) {
+	if invoice == nil {
+		return
+	}
+	// the consolidated invoice stays in processing and is charged again by the billing cycle
+	s.failPendingUpdates(ctx, invoice)
+}
+
+func (s ConsolidatedPaymentCallback) PaymentCancelCallback(ctx context.Context, payment *entity.Payment, invoice *entity.Invoice) {
+	if invoice == nil {
+		return
+	}
+	s.failPendingUpdates(ctx, invoice)
+	if invoice.Status == consts.InvoiceStatusCancelled || invoice.Status == consts.InvoiceStatusFailed {
+		handler2.DetachConsolidatedInvoice(ctx, invoice.InvoiceId)
+	}
+}
+
+func (s ConsolidatedPaymentCallback) failPendingUpdates(ctx context.Context, invoice *entity.Invoice) {
+	for _, child := range query.GetInvoicesByConsolidatedInvoiceId(ctx, invoice.InvoiceId) {
+		pendingSubUpdate := query.GetUnfinishedSubscriptionPendingUpdateByInvoiceId(ctx, child.InvoiceId)
+		if pendingSubUpdate != nil {
+			_, err := handler.HandlePendingUpdatePaymentFailure(ctx, pendingSubUpdate.PendingUpdateId)
+			if err != nil {
+				g.Log().Errorf(ctx, "ConsolidatedPaymentCallback HandlePendingUpdatePaymentFailure pendingUpdateId:%s error:%s", pendingSubUpdate.PendingUpdateId, err.Error())
+			}
+		}
+	}
+}
+
+func (s ConsolidatedPaymentCallback) PaymentNeedAuthorisedCallback(ctx context.Context, payment *entity.Payment, invoice *entity.Invoice) {
+}
+
+func (s ConsolidatedPaymentCallback) PaymentRefundCreateCallback(ctx context.Context, payment *entity.Payment, refund *entity.Refund) {
+}
+
+func (s ConsolidatedPaymentCallback) PaymentRefundSuccessCallback(ctx context.Context, payment *entity.Payment, refund *entity.Refund) {
+}
+
+func (s ConsolidatedPaymentCallback) PaymentRefundCancelCallback(ctx context.Context, payment *entity.Payment, refund *entity.Refund) {
+}
+
+func (s ConsolidatedPaymentCallback) PaymentRefundFailureCallback(ctx context.Context, payment *entity.Payment, refund *entity.Refund) {
+}
+
+func (s ConsolidatedPaymentCallback) PaymentRefundReverseCallback(ctx context.Context, payment *entity.Payment, refund *entity.Refund) {
+}
@@ -0,0 +1,50 @@
+package cancellation
+
+import (
+	"testing"
+
+	"unibee/internal/consts"
+	entity "unibee/internal/model/entity/default"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchRetentionOffers(t *testing.T) {
+	offers := []*entity.SubscriptionRetentionOffer{
+		{Id: 1, OfferType: consts.RetentionOfferTypeDiscount, Reasons: `["Too expensive"]`, Priority: 1, Status: consts.RetentionOfferStatusActive},
+		{Id: 2, OfferType: consts.RetentionOfferTypePause, Reasons: "[]", PlanIds: "[]", Priority: 5, Status: consts.RetentionOfferStatusActive},
+		{Id: 3, OfferType: consts.RetentionOfferTypeDowngrade, PlanIds: "[10,11]", Priority: 1, Status: consts.RetentionOfferStatusActive},
+		{Id: 4, OfferType: consts.RetentionOfferTypeDiscount, Priority: 9, Status: consts.RetentionOfferStatusArchived},
+	}
+	list := MatchRetentionOffers(offers, "Too expensive", 10)
+	require.Equal(t, 3, len(list))
+	require.Equal(t, uint64(2), list[0].Id)
+	require.Equal(t, uint64(1), list[1].Id)
+	require.Equal(t, uint64(3), list[2].Id)
+
+	list = MatchRetentionOffers(offers, "Missing features", 12)
+	require.Equal(t, 1, len(list))
+	require.Equal(t, uint64(2), list[0].Id)
+}
+
+func TestSummarizeCancellations(t *testing.T) {
+	reports := SummarizeCancellations([]*entity.SubscriptionCancellation{
+		{PlanId: 2, Reason: "Too expensive", Status: consts.SubCancellationStatusSaved, AcceptedOfferType: consts.RetentionOfferTypeDiscount},
+		{PlanId: 2, Reason: "Too expensive", Status: consts.SubCancellationStatusCancelled},
+		{PlanId: 2, Reason: "Other", Status: consts.SubCancellationStatusCancelled},
+		{PlanId: 2, Reason: "Other", Status: consts.SubCancellationStatusStarted},
+		{PlanId: 1, Reason: "Other", Status: consts.SubCancellationStatusStarted},
+	})
+	require.Equal(t, 2, len(reports))
+	require.Equal(t, uint64(1), reports[0].PlanId)
+	require.Equal(t, int64(1), reports[0].Started)
+	require.Equal(t, int64(0), reports[0].SaveRate)
+
+	require.Equal(t, int64(4), reports[1].Started)
+	require.Equal(t, int64(1), reports[1].Saved)
+	require.Equal(t, int64(2), reports[1].Cancelled)
+	require.Equal(t, int64(3333), reports[1].SaveRate)
+	require.Equal(t, int64(2), reports[1].Reasons["Too expensive"])
+	require.Equal(t, int64(2), reports[1].Reasons["Other"])
+	require.Equal(t, int64(1), reports[1].AcceptedOffers[consts.RetentionOfferTypeDiscount])
+}
@@ -0,0 +1,246 @@
+package cancellation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"unibee/api/bean"
+	"unibee/internal/consts"
+	dao "unibee/internal/dao/default"
+	"unibee/internal/logic/discount"
+	service3 "unibee/internal/logic/invoice/service"
+	"unibee/internal/logic/operation_log"
+	"unibee/internal/logic/subscription/config"
+	"unibee/internal/logic/subscription/pause"
+	"unibee/internal/logic/subscription/service"
+	"unibee/internal/logic/subscription/service/next"
+	entity "unibee/internal/model/entity/default"
+	"unibee/internal/query"
+	"unibee/utility"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+type StartInternalReq struct {
+	UserId         uint64
+	SubscriptionId string
+	Reason         string
+	ReasonText     string
+}
+
+func checkCancellationSubscription(ctx context.Context, userId uint64, subscriptionId string) *entity.Subscription {
+	utility.Assert(len(subscriptionId) > 0, "subscriptionId not found")
+	sub := query.GetSubscriptionBySubscriptionId(ctx, subscriptionId)
+	utility.Assert(sub != nil, "subscription not found")
+	utility.Assert(sub.UserId == userId, "no permission")
+	utility.Assert(sub.Status == consts.SubStatusActive, "subscription not in active status")
+	utility.Assert(sub.CancelAtPeriodEnd == 0, "subscription already cancelled at period end")
+	return sub
+}
+
+func checkStartedCancellation(ctx context.Context, userId uint64, cancellationId string) *entity.SubscriptionCancellation {
+	utility.Assert(len(cancellationId) > 0, "cancellationId not found")
+	one := query.GetSubscriptionCancellationByCancellationId(ctx, cancellationId)
+	utility.Assert(one != nil, "cancellation not found")
+	utility.Assert(one.UserId == userId, "no permission")
+	utility.Assert(one.Status == consts.SubCancellationStatusStarted, "cancellation already finished")
+	return one
+}
+
+// CancellationStart records the cancellation reason of subscription and returns the retention offers presented to the user,
+// the flow started before and not finished is taken over
+func CancellationStart(ctx context.Context, req *StartInternalReq) (*entity.SubscriptionCancellation, []*entity.SubscriptionRetentionOffer, error) {
+	sub := checkCancellationSubscription(ctx, req.UserId, req.SubscriptionId)
+	utility.Assert(len(req.Reason) > 0, "reason is required")
+	utility.Assert(containsString(config.GetMerchantSubscriptionConfig(ctx, sub.MerchantId).CancellationReasons, req.Reason), "invalid reason, should be one of the cancellation reasons")
+	var offers = make([]*entity.SubscriptionRetentionOffer, 0)
+	var offerIds = make([]uint64, 0)
+	for _, offer := range MatchRetentionOffers(query.GetActiveSubscriptionRetentionOffers(ctx, sub.MerchantId), req.Reason, sub.PlanId) {
+		if isRetentionOfferAvailable(ctx, sub, offer) {
+			offers = append(offers, offer)
+			offerIds = append(offerIds, offer.Id)
+		}
+	}
+	one := query.GetStartedSubscriptionCancellationBySubscriptionId(ctx, sub.SubscriptionId)
+	if one != nil {
+		_, err := dao.SubscriptionCancellation.Ctx(ctx).Data(g.Map{
+			dao.SubscriptionCancellation.Columns().PlanId:     sub.PlanId,
+			dao.SubscriptionCancellation.Columns().Reason:     req.Reason,
+			dao.SubscriptionCancellation.Columns().ReasonText: req.ReasonText,
+			dao.SubscriptionCancellation.Columns().OfferIds:   utility.MarshalToJsonString(offerIds),
+			dao.SubscriptionCancellation.Columns().GmtModify:  gtime.Now(),
+		}).Where(dao.SubscriptionCancellation.Columns().Id, one.Id).OmitNil().Update()
+		if err != nil {
+			return nil, nil, err
+		}
+		return query.GetSubscriptionCancellationByCancellationId(ctx, one.CancellationId), offers, nil
+	}
+	one = &entity.SubscriptionCancellation{
+		MerchantId:     sub.MerchantId,
+		UserId:         sub.UserId,
+		SubscriptionId: sub.SubscriptionId,
+		PlanId:         sub.PlanId,
+		CancellationId: utility.CreateSubscriptionCancellationId(),
+		Reason:         req.Reason,
+		ReasonText:     req.ReasonText,
+		OfferIds:       utility.MarshalToJsonString(offerIds),
+		Status:         consts.SubCancellationStatusStarted,
+		CreateTime:     gtime.Now().Timestamp(),
+	}
+	_, err := dao.SubscriptionCancellation.Ctx(ctx).Data(one).OmitNil().Insert(one)
+	if err != nil {
+		return nil, nil, err
+	}
+	return one, offers, nil
+}
+
+// isRetentionOfferAvailable returns true when the offer can be accepted by the subscription
+func isRetentionOfferAvailable(ctx context.Context, sub *entity.Subscription, offer *entity.SubscriptionRetentionOffer) (available bool) {
+	switch offer.OfferType {
+	case consts.RetentionOfferTypeDiscount:
+		available, _, _ = discount.UserDiscountApplyPreview(ctx, &discount.UserDiscountApplyReq{
+			MerchantId:     sub.MerchantId,
+			UserId:         sub.UserId,
+			DiscountCode:   offer.DiscountCode,
+			Currency:       sub.Currency,
+			SubscriptionId: sub.SubscriptionId,
+			PLanId:         sub.PlanId,
+			TimeNow:        utility.MaxInt64(gtime.Now().Timestamp(), sub.TestClock),
+			IsRenew:        true,
+		})
+		return available
+	case consts.RetentionOfferTypePause:
+		return sub.Type == consts.SubTypeUniBeeControl
+	case consts.RetentionOfferTypeDowngrade:
+		plan := query.GetPlanById(ctx, sub.PlanId)
+		target := query.GetPlanById(ctx, offer.TargetPlanId)
+		if plan == nil || target == nil || target.Id == plan.Id || target.ProductId != plan.ProductId || target.Status != consts.PlanStatusActive {
+			return false
+		}
+		utility.Try(func() {
+			available = target.CurrencyAmount(ctx, sub.Currency) < plan.CurrencyAmount(ctx, sub.Currency)
+		}, func(err interface{}) {
+			available = false
+		})
+		return available
+	}
+	return false
+}
+
+// CancellationAcceptOffer applies the retention offer presented to the subscription and finishes the flow as saved
+func CancellationAcceptOffer(ctx context.Context, userId uint64, cancellationId string, offerId uint64) (*entity.SubscriptionCancellation, error) {
+	one := checkStartedCancellation(ctx, userId, cancellationId)
+	var offerIds = make([]uint64, 0)
+	_ = utility.UnmarshalFromJsonString(one.OfferIds, &offerIds)
+	utility.Assert(containsUint64(offerIds, offerId), "retention offer not presented")
+	offer := query.GetSubscriptionRetentionOfferById(ctx, offerId)
+	utility.Assert(offer != nil, "retention offer not found")
+	sub := checkCancellationSubscription(ctx, userId, one.SubscriptionId)
+	utility.Assert(isRetentionOfferAvailable(ctx, sub, offer), "retention offer not available")
+
+	switch offer.OfferType {
+	case consts.RetentionOfferTypeDiscount:
+		next.SaveSubscriptionNextInvoiceData(ctx, sub.SubscriptionId, &bean.SubscriptionNextInvoiceData{
+			DiscountCode: offer.DiscountCode,
+		})
+		service3.TryCancelSubscriptionLatestAutoChargeInvoice(ctx, sub)
+	case consts.RetentionOfferTypePause:
+		var resumeTime int64 = 0
+		if offer.PauseDays > 0 {
+			resumeTime = utility.MaxInt64(gtime.Now().Timestamp(), sub.TestClock) + offer.PauseDays*86400
+		}
+		_, err := pause.SubscriptionPause(ctx, &pause.PauseInternalReq{
+			MerchantId:     sub.MerchantId,
+			SubscriptionId: sub.SubscriptionId,
+			PauseMode:      config.GetMerchantSubscriptionConfig(ctx, sub.MerchantId).PauseMode,
+			ResumeTime:     resumeTime,
+			Reason:         "RetentionOffer",
+		})
+		if err != nil {
+			return nil, err
+		}
+	case consts.RetentionOfferTypeDowngrade:
+		var addonParams = make([]*bean.PlanAddonParam, 0)
+		if len(sub.AddonData) > 0 {
+			_ = utility.UnmarshalFromJsonString(sub.AddonData, &addonParams)
+		}
+		_, err := service.SubscriptionUpdate(ctx, &service.UpdateInternalReq{
+			SubscriptionId:  sub.SubscriptionId,
+			NewPlanId:       offer.TargetPlanId,
+			Quantity:        sub.Quantity,
+			AddonParams:     addonParams,
+			EffectImmediate: 2,
+			Metadata:        map[string]interface{}{"RetentionOffer": offer.Id},
+		}, 0)
+		if err != nil {
+			return nil, err
+		}
+	}
+	err := finishCancellation(ctx, one, consts.SubCancellationStatusSaved, offer)
+	operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
+		MerchantId:     sub.MerchantId,
+		Target:         fmt.Sprintf("Subscription(%s)", sub.SubscriptionId),
+		Content:        fmt.Sprintf("AcceptRetentionOffer(%d,%s)", offer.Id, offer.OfferType),
+		UserId:         sub.UserId,
+		SubscriptionId: sub.SubscriptionId,
+		InvoiceId:      "",
+		PlanId:         offer.TargetPlanId,
+		DiscountCode:   offer.DiscountCode,
+	}, err)
+	if err != nil {
+		return nil, err
+	}
+	return query.GetSubscriptionCancellationByCancellationId(ctx, one.CancellationId), nil
+}
+
+// CancellationConfirm cancels the subscription at period end with the reason captured and finishes the flow as cancelled
+func CancellationConfirm(ctx context.Context, userId uint64, cancellationId string) (*entity.SubscriptionCancellation, error) {
+	one := checkStartedCancellation(ctx, userId, cancellationId)
+	sub := checkCancellationSubscription(ctx, userId, one.SubscriptionId)
+	err := service.SubscriptionCancelAtPeriodEnd(ctx, sub.SubscriptionId, false, 0)
+	if err != nil {
+		return nil, err
+	}
+	var cancelReason = one.Reason
+	if len(strings.TrimSpace(one.ReasonText)) > 0 {
+		cancelReason = fmt.Sprintf("%s: %s", one.Reason, strings.TrimSpace(one.ReasonText))
+	}
+	_, err = dao.Subscription.Ctx(ctx).Data(g.Map{
+		dao.Subscription.Columns().CancelReason: cancelReason,
+		dao.Subscription.Columns().GmtModify:    gtime.Now(),
+	}).Where(dao.Subscription.Columns().Id, sub.Id).OmitNil().Update()
+	if err != nil {
+		return nil, err
+	}
+	err = finishCancellation(ctx, one, consts.SubCancellationStatusCancelled, nil)
+	if err != nil {
+		return nil, err
+	}
+	return query.GetSubscriptionCancellationByCancellationId(ctx, one.CancellationId), nil
+}
+
+func finishCancellation(ctx context.Context, one *entity.SubscriptionCancellation, status int, offer *entity.SubscriptionRetentionOffer) error {
+	var data = g.Map{
+		dao.SubscriptionCancellation.Columns().Status:     status,
+		dao.SubscriptionCancellation.Columns().FinishTime: gtime.Now().Timestamp(),
+		dao.SubscriptionCancellation.Columns().GmtModify:  gtime.Now(),
+	}
+	if offer != nil {
+		data[dao.SubscriptionCancellation.Columns().AcceptedOfferId] = offer.Id
+		data[dao.SubscriptionCancellation.Columns().AcceptedOfferType] = offer.OfferType
+	}
+	result, err := dao.SubscriptionCancellation.Ctx(ctx).Data(data).
+		Where(dao.SubscriptionCancellation.Columns().Id, one.Id).
+		Where(dao.SubscriptionCancellation.Columns().Status, consts.SubCancellationStatusStarted).
+		OmitNil().Update()
+	if err != nil {
+		return err
+	}
+	affected, _ := result.RowsAffected()
+	if affected != 1 {
+		return fmt.Errorf("cancellation %s already finished", one.CancellationId)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package cancellation
+
+import (
+	"sort"
+
+	"unibee/internal/consts"
+	entity "unibee/internal/model/entity/default"
+	"unibee/utility"
+)
+
+func IsValidRetentionOfferType(offerType string) bool {
+	return offerType == consts.RetentionOfferTypeDiscount || offerType == consts.RetentionOfferTypePause || offerType == consts.RetentionOfferTypeDowngrade
+}
+
+// MatchRetentionOffers returns the active offers presented for the cancellation reason and plan ordered by priority desc,
+// an offer without reasons or plans configured matches all of them
+func MatchRetentionOffers(offers []*entity.SubscriptionRetentionOffer, reason string, planId uint64) []*entity.SubscriptionRetentionOffer {
+	var list = make([]*entity.SubscriptionRetentionOffer, 0)
+	for _, one := range offers {
+		if one == nil || one.Status != consts.RetentionOfferStatusActive || one.IsDeleted > 0 {
+			continue
+		}
+		var reasons = make([]string, 0)
+		if len(one.Reasons) > 0 {
+			_ = utility.UnmarshalFromJsonString(one.Reasons, &reasons)
+		}
+		if len(reasons) > 0 && !containsString(reasons, reason) {
+			continue
+		}
+		var planIds = make([]uint64, 0)
+		if len(one.PlanIds) > 0 {
+			_ = utility.UnmarshalFromJsonString(one.PlanIds, &planIds)
+		}
+		if len(planIds) > 0 && !containsUint64(planIds, planId) {
+			continue
+		}
+		list = append(list, one)
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		if list[i].Priority != list[j].Priority {
+			return list[i].Priority > list[j].Priority
+		}
+		return list[i].Id < list[j].Id
+	})
+	return list
+}
+
+func containsString(list []string, target string) bool {
+	for _, one := range list {
+		if one == target {
+			return true
+		}
+	}
+	return false
+}
+
+func containsUint64(list []uint64, target uint64) bool {
+	for _, one := range list {
+		if one == target {
+			return true
+		}
+	}
+	return false
+}
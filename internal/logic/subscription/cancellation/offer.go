@@ -0,0 +1,185 @@
+package cancellation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"unibee/internal/consts"
+	dao "unibee/internal/dao/default"
+	"unibee/internal/logic/operation_log"
+	"unibee/internal/logic/subscription/config"
+	entity "unibee/internal/model/entity/default"
+	"unibee/internal/query"
+	"unibee/utility"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+type RetentionOfferInternalReq struct {
+	MerchantId   uint64
+	OfferId      uint64
+	Name         string
+	Description  string
+	OfferType    string
+	DiscountCode string
+	PauseDays    int64
+	TargetPlanId uint64
+	Reasons      []string
+	PlanIds      []uint64
+	Priority     int
+}
+
+func checkRetentionOffer(ctx context.Context, req *RetentionOfferInternalReq) {
+	utility.Assert(len(strings.TrimSpace(req.Name)) > 0, "name is required")
+	utility.Assert(IsValidRetentionOfferType(req.OfferType), fmt.Sprintf("invalid offerType, should be one of %s|%s|%s", consts.RetentionOfferTypeDiscount, consts.RetentionOfferTypePause, consts.RetentionOfferTypeDowngrade))
+	switch req.OfferType {
+	case consts.RetentionOfferTypeDiscount:
+		utility.Assert(len(req.DiscountCode) > 0, "discountCode is required for discount offer")
+		discountCode := query.GetDiscountByCode(ctx, req.MerchantId, req.DiscountCode)
+		utility.Assert(discountCode != nil && discountCode.IsDeleted == 0, fmt.Sprintf("discount code not found:%s", req.DiscountCode))
+	case consts.RetentionOfferTypePause:
+		utility.Assert(req.PauseDays >= 0, "pauseDays should not be negative")
+	case consts.RetentionOfferTypeDowngrade:
+		utility.Assert(req.TargetPlanId > 0, "targetPlanId is required for downgrade offer")
+		plan := query.GetPlanById(ctx, req.TargetPlanId)
+		utility.Assert(plan != nil && plan.MerchantId == req.MerchantId, fmt.Sprintf("plan not found:%d", req.TargetPlanId))
+		utility.Assert(plan.Type == consts.PlanTypeMain, "targetPlan should be a main plan")
+		utility.Assert(plan.Status == consts.PlanStatusActive, "targetPlan not in active status")
+	}
+	var configReasons = config.GetMerchantSubscriptionConfig(ctx, req.MerchantId).CancellationReasons
+	for _, reason := range req.Reasons {
+		utility.Assert(containsString(configReasons, reason), fmt.Sprintf("reason not in the cancellation reasons of subscription config:%s", reason))
+	}
+	for _, planId := range req.PlanIds {
+		plan := query.GetPlanById(ctx, planId)
+		utility.Assert(plan != nil && plan.MerchantId == req.MerchantId, fmt.Sprintf("plan not found:%d", planId))
+	}
+}
+
+func offerData(req *RetentionOfferInternalReq) g.Map {
+	var data = g.Map{
+		dao.SubscriptionRetentionOffer.Columns().Name:         strings.TrimSpace(req.Name),
+		dao.SubscriptionRetentionOffer.Columns().Description:  req.Description,
+		dao.SubscriptionRetentionOffer.Columns().OfferType:    req.OfferType,
+		dao.SubscriptionRetentionOffer.Columns().DiscountCode: "",
+		dao.SubscriptionRetentionOffer.Columns().PauseDays:    0,
+		dao.SubscriptionRetentionOffer.Columns().TargetPlanId: 0,
+		dao.SubscriptionRetentionOffer.Columns().Reasons:      utility.MarshalToJsonString(req.Reasons),
+		dao.SubscriptionRetentionOffer.Columns().PlanIds:      utility.MarshalToJsonString(req.PlanIds),
+		dao.SubscriptionRetentionOffer.Columns().Priority:     req.Priority,
+	}
+	if req.Reasons == nil {
+		data[dao.SubscriptionRetentionOffer.Columns().Reasons] = "[]"
+	}
+	if req.PlanIds == nil {
+		data[dao.SubscriptionRetentionOffer.Columns().PlanIds] = "[]"
+	}
+	switch req.OfferType {
+	case consts.RetentionOfferTypeDiscount:
+		data[dao.SubscriptionRetentionOffer.Columns().DiscountCode] = req.DiscountCode
+	case consts.RetentionOfferTypePause:
+		data[dao.SubscriptionRetentionOffer.Columns().PauseDays] = req.PauseDays
+	case consts.RetentionOfferTypeDowngrade:
+		data[dao.SubscriptionRetentionOffer.Columns().TargetPlanId] = req.TargetPlanId
+	}
+	return data
+}
+
+// RetentionOfferNew creates the retention offer presented to the users cancelling their subscription
+func RetentionOfferNew(ctx context.Context, req *RetentionOfferInternalReq) (*entity.SubscriptionRetentionOffer, error) {
+	checkRetentionOffer(ctx, req)
+	data := offerData(req)
+	data[dao.SubscriptionRetentionOffer.Columns().MerchantId] = req.MerchantId
+	data[dao.SubscriptionRetentionOffer.Columns().Status] = consts.RetentionOfferStatusActive
+	data[dao.SubscriptionRetentionOffer.Columns().CreateTime] = gtime.Now().Timestamp()
+	id, err := dao.SubscriptionRetentionOffer.Ctx(ctx).Data(data).OmitNil().InsertAndGetId()
+	operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
+		MerchantId:     req.MerchantId,
+		Target:         fmt.Sprintf("RetentionOffer(%d)", id),
+		Content:        fmt.Sprintf("New(%s)", req.OfferType),
+		UserId:         0,
+		SubscriptionId: "",
+		InvoiceId:      "",
+		PlanId:         req.TargetPlanId,
+		DiscountCode:   req.DiscountCode,
+	}, err)
+	if err != nil {
+		return nil, err
+	}
+	return query.GetSubscriptionRetentionOfferById(ctx, uint64(id)), nil
+}
+
+// RetentionOfferEdit edits the retention offer, the cancellation flows started keep the offers presented
+func RetentionOfferEdit(ctx context.Context, req *RetentionOfferInternalReq) (*entity.SubscriptionRetentionOffer, error) {
+	one := query.GetSubscriptionRetentionOfferById(ctx, req.OfferId)
+	utility.Assert(one != nil && one.MerchantId == req.MerchantId, "retention offer not found")
+	utility.Assert(one.Status == consts.RetentionOfferStatusActive, "retention offer archived")
+	checkRetentionOffer(ctx, req)
+	data := offerData(req)
+	data[dao.SubscriptionRetentionOffer.Columns().GmtModify] = gtime.Now()
+	_, err := dao.SubscriptionRetentionOffer.Ctx(ctx).Data(data).
+		Where(dao.SubscriptionRetentionOffer.Columns().Id, one.Id).
+		OmitNil().Update()
+	operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
+		MerchantId:     req.MerchantId,
+		Target:         fmt.Sprintf("RetentionOffer(%d)", one.Id),
+		Content:        fmt.Sprintf("Edit(%s)", req.OfferType),
+		UserId:         0,
+		SubscriptionId: "",
+		InvoiceId:      "",
+		PlanId:         req.TargetPlanId,
+		DiscountCode:   req.DiscountCode,
+	}, err)
+	if err != nil {
+		return nil, err
+	}
+	return query.GetSubscriptionRetentionOfferById(ctx, one.Id), nil
+}
+
+// RetentionOfferArchive stops presenting the retention offer, the offers presented before can still be accepted
+func RetentionOfferArchive(ctx context.Context, merchantId uint64, offerId uint64) error {
+	one := query.GetSubscriptionRetentionOfferById(ctx, offerId)
+	utility.Assert(one != nil && one.MerchantId == merchantId, "retention offer not found")
+	if one.Status == consts.RetentionOfferStatusArchived {
+		return nil
+	}
+	_, err := dao.SubscriptionRetentionOffer.Ctx(ctx).Data(g.Map{
+		dao.SubscriptionRetentionOffer.Columns().Status:    consts.RetentionOfferStatusArchived,
+		dao.SubscriptionRetentionOffer.Columns().GmtModify: gtime.Now(),
+	}).Where(dao.SubscriptionRetentionOffer.Columns().Id, one.Id).OmitNil().Update()
+	operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
+		MerchantId:     merchantId,
+		Target:         fmt.Sprintf("RetentionOffer(%d)", one.Id),
+		Content:        "Archive",
+		UserId:         0,
+		SubscriptionId: "",
+		InvoiceId:      "",
+		PlanId:         0,
+		DiscountCode:   "",
+	}, err)
+	return err
+}
+
+// GetRetentionOfferList returns the retention offers of the merchant, filtered by status if specified
+func GetRetentionOfferList(ctx context.Context, merchantId uint64, status int, page int, count int) ([]*entity.SubscriptionRetentionOffer, int, error) {
+	var list []*entity.SubscriptionRetentionOffer
+	var total = 0
+	q := dao.SubscriptionRetentionOffer.Ctx(ctx).
+		Where(dao.SubscriptionRetentionOffer.Columns().MerchantId, merchantId).
+		Where(dao.SubscriptionRetentionOffer.Columns().IsDeleted, 0)
+	if status > 0 {
+		q = q.Where(dao.SubscriptionRetentionOffer.Columns().Status, status)
+	}
+	if count > 0 {
+		q = q.Limit(page*count, count)
+	}
+	err := q.OrderDesc(dao.SubscriptionRetentionOffer.Columns().Priority).
+		OrderAsc(dao.SubscriptionRetentionOffer.Columns().Id).
+		ScanAndCount(&list, &total, true)
+	if err != nil {
+		return nil, 0, err
+	}
+	return list, total, nil
+}
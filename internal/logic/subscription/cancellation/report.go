@@ -0,0 +1,106 @@
+package cancellation
+
+import (
+	"context"
+	"sort"
+
+	"unibee/api/bean"
+	"unibee/internal/consts"
+	dao "unibee/internal/dao/default"
+	entity "unibee/internal/model/entity/default"
+	"unibee/internal/query"
+)
+
+// SummarizeCancellations aggregates the cancellation flows by plan, the save rate counts the finished flows only
+func SummarizeCancellations(list []*entity.SubscriptionCancellation) []*bean.SubscriptionCancellationReport {
+	var reports = make([]*bean.SubscriptionCancellationReport, 0)
+	var planReports = make(map[uint64]*bean.SubscriptionCancellationReport)
+	for _, one := range list {
+		if one == nil {
+			continue
+		}
+		report, ok := planReports[one.PlanId]
+		if !ok {
+			report = &bean.SubscriptionCancellationReport{
+				PlanId:         one.PlanId,
+				Reasons:        make(map[string]int64),
+				AcceptedOffers: make(map[string]int64),
+			}
+			planReports[one.PlanId] = report
+			reports = append(reports, report)
+		}
+		report.Started = report.Started + 1
+		if len(one.Reason) > 0 {
+			report.Reasons[one.Reason] = report.Reasons[one.Reason] + 1
+		}
+		if one.Status == consts.SubCancellationStatusSaved {
+			report.Saved = report.Saved + 1
+			if len(one.AcceptedOfferType) > 0 {
+				report.AcceptedOffers[one.AcceptedOfferType] = report.AcceptedOffers[one.AcceptedOfferType] + 1
+			}
+		} else if one.Status == consts.SubCancellationStatusCancelled {
+			report.Cancelled = report.Cancelled + 1
+		}
+	}
+	for _, report := range reports {
+		if report.Saved+report.Cancelled > 0 {
+			report.SaveRate = report.Saved * 10000 / (report.Saved + report.Cancelled)
+		}
+	}
+	sort.SliceStable(reports, func(i, j int) bool {
+		return reports[i].PlanId < reports[j].PlanId
+	})
+	return reports
+}
+
+// CancellationReport returns the cancellation reasons and save rates per plan of the flows started between startTime and endTime
+func CancellationReport(ctx context.Context, merchantId uint64, startTime int64, endTime int64) ([]*bean.SubscriptionCancellationReport, error) {
+	var list []*entity.SubscriptionCancellation
+	q := dao.SubscriptionCancellation.Ctx(ctx).
+		Where(dao.SubscriptionCancellation.Columns().MerchantId, merchantId).
+		Where(dao.SubscriptionCancellation.Columns().IsDeleted, 0)
+	if startTime > 0 {
+		q = q.WhereGTE(dao.SubscriptionCancellation.Columns().CreateTime, startTime)
+	}
+	if endTime > 0 {
+		q = q.WhereLTE(dao.SubscriptionCancellation.Columns().CreateTime, endTime)
+	}
+	err := q.Scan(&list)
+	if err != nil {
+		return nil, err
+	}
+	reports := SummarizeCancellations(list)
+	for _, report := range reports {
+		plan := query.GetPlanById(ctx, report.PlanId)
+		if plan != nil {
+			report.PlanName = plan.PlanName
+		}
+	}
+	return reports, nil
+}
+
+// GetCancellationList returns the cancellation flows of the merchant, filtered by subscription, user and status if specified
+func GetCancellationList(ctx context.Context, merchantId uint64, subscriptionId string, userId uint64, status int, page int, count int) ([]*entity.SubscriptionCancellation, int, error) {
+	var list []*entity.SubscriptionCancellation
+	var total = 0
+	q := dao.SubscriptionCancellation.Ctx(ctx).
+		Where(dao.SubscriptionCancellation.Columns().MerchantId, merchantId).
+		Where(dao.SubscriptionCancellation.Columns().IsDeleted, 0)
+	if len(subscriptionId) > 0 {
+		q = q.Where(dao.SubscriptionCancellation.Columns().SubscriptionId, subscriptionId)
+	}
+	if userId > 0 {
+		q = q.Where(dao.SubscriptionCancellation.Columns().UserId, userId)
+	}
+	if status > 0 {
+		q = q.Where(dao.SubscriptionCancellation.Columns().Status, status)
+	}
+	if count > 0 {
+		q = q.Limit(page*count, count)
+	}
+	err := q.OrderDesc(dao.SubscriptionCancellation.Columns().Id).ScanAndCount(&list, &total, true)
+	if err != nil {
+		return nil, 0, err
+	}
+	return list, total, nil
+}
@@ -0,0 +1,169 @@
+package commitment
+
+import (
+	"context"
+	"fmt"
+
+	"unibee/internal/consts"
+	"unibee/internal/consumer/webhook/event"
+	subscription3 "unibee/internal/consumer/webhook/subscription"
+	dao "unibee/internal/dao/default"
+	"unibee/internal/logic/operation_log"
+	"unibee/internal/logic/subscription/commitment/term"
+	"unibee/internal/logic/subscription/schedule"
+	entity "unibee/internal/model/entity/default"
+	"unibee/internal/query"
+	"unibee/utility"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+type CreateInternalReq struct {
+	MerchantId                    uint64 `json:"merchantId" dc:"MerchantId"`
+	SubscriptionId                string `json:"subscriptionId" dc:"SubscriptionId"`
+	TermMonths                    int    `json:"termMonths" dc:"TermMonths, length of the minimum commitment term in months"`
+	AutoRenew                     bool   `json:"autoRenew" dc:"AutoRenew, whether the term renews at term end"`
+	RenewTermMonths               int    `json:"renewTermMonths" dc:"RenewTermMonths, length of the term renewed automatically in months, default the termMonths"`
+	NoticeDays                    int    `json:"noticeDays" dc:"NoticeDays, days before term end the cancellation should be noticed"`
+	EarlyTerminationFeePercentage *int64 `json:"earlyTerminationFeePercentage" dc:"EarlyTerminationFeePercentage, percentage of the remaining commitment charged at early termination，10000 = 100%, default 10000"`
+	TermStart                     int64  `json:"termStart" dc:"TermStart, utc time, default the current period start of subscription"`
+}
+
+type UpdateInternalReq struct {
+	MerchantId                    uint64 `json:"merchantId" dc:"MerchantId"`
+	CommitmentId                  string `json:"commitmentId" dc:"CommitmentId"`
+	AutoRenew                     *bool  `json:"autoRenew" dc:"AutoRenew"`
+	RenewTermMonths               *int   `json:"renewTermMonths" dc:"RenewTermMonths"`
+	NoticeDays                    *int   `json:"noticeDays" dc:"NoticeDays"`
+	EarlyTerminationFeePercentage *int64 `json:"earlyTerminationFeePercentage" dc:"EarlyTerminationFeePercentage"`
+}
+
+func checkTerms(termMonths int, noticeDays int, earlyTerminationFeePercentage int64) {
+	utility.Assert(termMonths > 0 && termMonths <= term.MaxTermMonths, fmt.Sprintf("termMonths should between 1 and %d", term.MaxTermMonths))
+	utility.Assert(noticeDays >= 0 && noticeDays <= term.MaxNoticeDays, fmt.Sprintf("noticeDays should between 0 and %d", term.MaxNoticeDays))
+	utility.Assert(earlyTerminationFeePercentage >= 0 && earlyTerminationFeePercentage <= 10000, "earlyTerminationFeePercentage should between 0 and 10000")
+}
+
+func autoRenewValue(autoRenew bool) int {
+	if autoRenew {
+		return 1
+	}
+	return 0
+}
+
+// CommitmentCreate commits the subscription to the minimum term, the subscription can not be cancelled or downgraded before the term end
+// except the early termination with fee
+func CommitmentCreate(ctx context.Context, req *CreateInternalReq) (*entity.SubscriptionCommitment, error) {
+	utility.Assert(req != nil, "req not found")
+	sub := query.GetSubscriptionBySubscriptionId(ctx, req.SubscriptionId)
+	utility.Assert(sub != nil, "subscription not found")
+	utility.Assert(sub.MerchantId == req.MerchantId, "merchant not match")
+	utility.Assert(sub.Status == consts.SubStatusActive || sub.Status == consts.SubStatusIncomplete || sub.Status == consts.SubStatusPending || sub.Status == consts.SubStatusProcessing, "subscription not in active status")
+	utility.Assert(query.GetActiveSubscriptionCommitmentBySubscriptionId(ctx, sub.SubscriptionId) == nil, "subscription has active commitment already")
+	if req.RenewTermMonths == 0 {
+		req.RenewTermMonths = req.TermMonths
+	}
+	var feePercentage int64 = term.DefaultEarlyTerminationPercentage
+	if req.EarlyTerminationFeePercentage != nil {
+		feePercentage = *req.EarlyTerminationFeePercentage
+	}
+	checkTerms(req.TermMonths, req.NoticeDays, feePercentage)
+	utility.Assert(req.RenewTermMonths > 0 && req.RenewTermMonths <= term.MaxTermMonths, fmt.Sprintf("renewTermMonths should between 1 and %d", term.MaxTermMonths))
+	if req.TermStart <= 0 {
+		req.TermStart = sub.CurrentPeriodStart
+	}
+	termEnd := term.TermEndFromStart(req.TermStart, req.TermMonths)
+	utility.Assert(termEnd > utility.MaxInt64(gtime.Now().Timestamp(), sub.TestClock), "the term ended already")
+	utility.Assert(int64(req.NoticeDays)*86400 < termEnd-req.TermStart, "noticeDays should be shorter than the term")
+	one := &entity.SubscriptionCommitment{
+		MerchantId:                    sub.MerchantId,
+		UserId:                        sub.UserId,
+		SubscriptionId:                sub.SubscriptionId,
+		CommitmentId:                  utility.CreateSubscriptionCommitmentId(),
+		TermMonths:                    req.TermMonths,
+		RenewTermMonths:               req.RenewTermMonths,
+		AutoRenew:                     autoRenewValue(req.AutoRenew),
+		NoticeDays:                    req.NoticeDays,
+		EarlyTerminationFeePercentage: feePercentage,
+		TermStart:                     req.TermStart,
+		TermEnd:                       termEnd,
+		TermCount:                     1,
+		Status:                        consts.SubCommitmentStatusActive,
+		CreateTime:                    gtime.Now().Timestamp(),
+	}
+	utility.AssertError(schedule.CheckScheduleCommitment(ctx, sub, one), "schedule of subscription conflicts with the commitment term")
+	_, err := dao.SubscriptionCommitment.Ctx(ctx).Data(one).OmitNil().Insert(one)
+	operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
+		MerchantId:     sub.MerchantId,
+		Target:         fmt.Sprintf("SubscriptionCommitment(%s)", one.CommitmentId),
+		Content:        fmt.Sprintf("New(%dMonths)", one.TermMonths),
+		UserId:         sub.UserId,
+		SubscriptionId: sub.SubscriptionId,
+		InvoiceId:      "",
+		PlanId:         sub.PlanId,
+		DiscountCode:   "",
+	}, err)
+	if err != nil {
+		return nil, err
+	}
+	subscription3.SendMerchantSubscriptionWebhookBackground(sub, -10000, event.UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_COMMITMENT_CREATED, map[string]interface{}{"CommitmentId": one.CommitmentId, "TermEnd": one.TermEnd})
+	return one, nil
+}
+
+// CommitmentUpdate changes the renewal, notice and early termination terms of the active commitment, the current term stays
+func CommitmentUpdate(ctx context.Context, req *UpdateInternalReq) (*entity.SubscriptionCommitment, error) {
+	utility.Assert(req != nil, "req not found")
+	one := query.GetSubscriptionCommitmentByCommitmentId(ctx, req.CommitmentId)
+	utility.Assert(one != nil, "commitment not found")
+	utility.Assert(one.MerchantId == req.MerchantId, "merchant not match")
+	utility.Assert(one.Status == consts.SubCommitmentStatusActive, "commitment not active")
+	if req.AutoRenew != nil {
+		one.AutoRenew = autoRenewValue(*req.AutoRenew)
+	}
+	if req.RenewTermMonths != nil {
+		utility.Assert(*req.RenewTermMonths > 0 && *req.RenewTermMonths <= term.MaxTermMonths, fmt.Sprintf("renewTermMonths should between 1 and %d", term.MaxTermMonths))
+		one.RenewTermMonths = *req.RenewTermMonths
+	}
+	if req.NoticeDays != nil {
+		one.NoticeDays = *req.NoticeDays
+	}
+	if req.EarlyTerminationFeePercentage != nil {
+		one.EarlyTerminationFeePercentage = *req.EarlyTerminationFeePercentage
+	}
+	checkTerms(one.TermMonths, one.NoticeDays, one.EarlyTerminationFeePercentage)
+	utility.Assert(int64(one.NoticeDays)*86400 < one.TermEnd-one.TermStart, "noticeDays should be shorter than the term")
+	sub := query.GetSubscriptionBySubscriptionId(ctx, one.SubscriptionId)
+	utility.Assert(sub != nil, "subscription not found")
+	utility.AssertError(schedule.CheckScheduleCommitment(ctx, sub, one), "schedule of subscription conflicts with the commitment term")
+	result, err := dao.SubscriptionCommitment.Ctx(ctx).Data(g.Map{
+		dao.SubscriptionCommitment.Columns().AutoRenew:                     one.AutoRenew,
+		dao.SubscriptionCommitment.Columns().RenewTermMonths:               one.RenewTermMonths,
+		dao.SubscriptionCommitment.Columns().NoticeDays:                    one.NoticeDays,
+		dao.SubscriptionCommitment.Columns().EarlyTerminationFeePercentage: one.EarlyTerminationFeePercentage,
+		dao.SubscriptionCommitment.Columns().GmtModify:                     gtime.Now(),
+	}).Where(dao.SubscriptionCommitment.Columns().Id, one.Id).
+		Where(dao.SubscriptionCommitment.Columns().Status, consts.SubCommitmentStatusActive).
+		OmitNil().Update()
+	if err == nil {
+		var affected int64
+		affected, err = result.RowsAffected()
+		if err == nil && affected != 1 {
+			err = fmt.Errorf("commitment not active")
+		}
+	}
+	operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
+		MerchantId:     one.MerchantId,
+		Target:         fmt.Sprintf("SubscriptionCommitment(%s)", one.CommitmentId),
+		Content:        fmt.Sprintf("Update(AutoRenew:%d,NoticeDays:%d)", one.AutoRenew, one.NoticeDays),
+		UserId:         one.UserId,
+		SubscriptionId: one.SubscriptionId,
+		InvoiceId:      "",
+		PlanId:         0,
+		DiscountCode:   "",
+	}, err)
+	if err != nil {
+		return nil, err
+	}
+	return one, nil
+}
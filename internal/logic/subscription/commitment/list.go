@@ -0,0 +1,34 @@
+package commitment
+
+import (
+	"context"
+
+	dao "unibee/internal/dao/default"
+	entity "unibee/internal/model/entity/default"
+)
+
+// GetCommitmentList returns the commitments of the merchant, filtered by subscription, user and status if specified
+func GetCommitmentList(ctx context.Context, merchantId uint64, subscriptionId string, userId uint64, status int, page int, count int) ([]*entity.SubscriptionCommitment, int, error) {
+	var list []*entity.SubscriptionCommitment
+	var total = 0
+	q := dao.SubscriptionCommitment.Ctx(ctx).
+		Where(dao.SubscriptionCommitment.Columns().MerchantId, merchantId).
+		Where(dao.SubscriptionCommitment.Columns().IsDeleted, 0)
+	if len(subscriptionId) > 0 {
+		q = q.Where(dao.SubscriptionCommitment.Columns().SubscriptionId, subscriptionId)
+	}
+	if userId > 0 {
+		q = q.Where(dao.SubscriptionCommitment.Columns().UserId, userId)
+	}
+	if status > 0 {
+		q = q.Where(dao.SubscriptionCommitment.Columns().Status, status)
+	}
+	if count > 0 {
+		q = q.Limit(page*count, count)
+	}
+	err := q.OrderDesc(dao.SubscriptionCommitment.Columns().Id).ScanAndCount(&list, &total, true)
+	if err != nil {
+		return nil, 0, err
+	}
+	return list, total, nil
+}
@@ -0,0 +1,212 @@
+package commitment
+
+import (
+	"context"
+	"fmt"
+
+	"unibee/api/bean"
+	"unibee/internal/consts"
+	"unibee/internal/consumer/webhook/event"
+	subscription3 "unibee/internal/consumer/webhook/subscription"
+	dao "unibee/internal/dao/default"
+	"unibee/internal/logic/email"
+	"unibee/internal/logic/operation_log"
+	"unibee/internal/logic/subscription/commitment/term"
+	"unibee/internal/logic/subscription/config"
+	"unibee/internal/logic/subscription/service"
+	entity "unibee/internal/model/entity/default"
+	"unibee/internal/query"
+	"unibee/utility"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+func finishCommitment(ctx context.Context, one *entity.SubscriptionCommitment, status int, timeNow int64) error {
+	result, err := dao.SubscriptionCommitment.Ctx(ctx).Data(g.Map{
+		dao.SubscriptionCommitment.Columns().Status:     status,
+		dao.SubscriptionCommitment.Columns().FinishTime: timeNow,
+		dao.SubscriptionCommitment.Columns().GmtModify:  gtime.Now(),
+	}).Where(dao.SubscriptionCommitment.Columns().Id, one.Id).
+		Where(dao.SubscriptionCommitment.Columns().Status, consts.SubCommitmentStatusActive).
+		OmitNil().Update()
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected != 1 {
+		return fmt.Errorf("commitment already finished")
+	}
+	one.Status = status
+	one.FinishTime = timeNow
+	return nil
+}
+
+func renewCommitment(ctx context.Context, one *entity.SubscriptionCommitment) error {
+	var termStart = one.TermEnd
+	var termEnd = term.TermEndFromStart(termStart, one.RenewTermMonths)
+	result, err := dao.SubscriptionCommitment.Ctx(ctx).Data(g.Map{
+		dao.SubscriptionCommitment.Columns().TermStart:    termStart,
+		dao.SubscriptionCommitment.Columns().TermEnd:      termEnd,
+		dao.SubscriptionCommitment.Columns().TermCount:    one.TermCount + 1,
+		dao.SubscriptionCommitment.Columns().ReminderTime: 0,
+		dao.SubscriptionCommitment.Columns().GmtModify:    gtime.Now(),
+	}).Where(dao.SubscriptionCommitment.Columns().Id, one.Id).
+		Where(dao.SubscriptionCommitment.Columns().Status, consts.SubCommitmentStatusActive).
+		Where(dao.SubscriptionCommitment.Columns().TermEnd, one.TermEnd).
+		OmitNil().Update()
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected != 1 {
+		return fmt.Errorf("commitment already renewed")
+	}
+	one.TermStart = termStart
+	one.TermEnd = termEnd
+	one.TermCount = one.TermCount + 1
+	one.ReminderTime = 0
+	return nil
+}
+
+// WalkCommitments renews or completes the commitments reaching the term end, reminds the users of the terms ending soon,
+// the commitments of the subscriptions ended are cancelled
+func WalkCommitments(ctx context.Context) {
+	var count = 100
+	var lastId uint64 = 0
+	for {
+		var list []*entity.SubscriptionCommitment
+		err := dao.SubscriptionCommitment.Ctx(ctx).
+			Where(dao.SubscriptionCommitment.Columns().Status, consts.SubCommitmentStatusActive).
+			Where(dao.SubscriptionCommitment.Columns().IsDeleted, 0).
+			WhereGT(dao.SubscriptionCommitment.Columns().Id, lastId).
+			OrderAsc(dao.SubscriptionCommitment.Columns().Id).
+			Limit(count).
+			Scan(&list)
+		if err != nil {
+			g.Log().Errorf(ctx, "WalkCommitments error:%s", err.Error())
+			return
+		}
+		for _, one := range list {
+			lastId = one.Id
+			key := fmt.Sprintf("WalkCommitments-%v", one.Id)
+			if !utility.TryLock(ctx, key, 60) {
+				continue
+			}
+			err = walkCommitment(ctx, one)
+			if err != nil {
+				g.Log().Errorf(ctx, "WalkCommitments commitmentId:%s err:%s", one.CommitmentId, err.Error())
+			}
+			utility.ReleaseLock(ctx, key)
+		}
+		if len(list) < count {
+			break
+		}
+	}
+}
+
+func walkCommitment(ctx context.Context, one *entity.SubscriptionCommitment) error {
+	sub := query.GetSubscriptionBySubscriptionId(ctx, one.SubscriptionId)
+	var timeNow = gtime.Now().Timestamp()
+	if sub == nil || sub.Status == consts.SubStatusCancelled || sub.Status == consts.SubStatusExpired || sub.Status == consts.SubStatusFailed {
+		var status = consts.SubCommitmentStatusCancelled
+		if sub != nil && utility.MaxInt64(timeNow, sub.TestClock) >= one.TermEnd {
+			status = consts.SubCommitmentStatusCompleted
+		}
+		return finishCommitment(ctx, one, status, timeNow)
+	}
+	var subTimeNow = utility.MaxInt64(timeNow, sub.TestClock)
+	if one.CancelAtTermEnd == 1 && subTimeNow >= one.TermEnd {
+		// the cancellation noticed during the term takes effect at the term end, whenever the period ends
+		err := service.SubscriptionCancelBySystem(ctx, sub.SubscriptionId, "CommitmentCancelAtTermEnd")
+		if err != nil {
+			return err
+		}
+		err = finishCommitment(ctx, one, consts.SubCommitmentStatusCompleted, timeNow)
+		if err != nil {
+			return err
+		}
+		subscription3.SendMerchantSubscriptionWebhookBackground(sub, -10000, event.UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_COMMITMENT_COMPLETED, map[string]interface{}{"CommitmentId": one.CommitmentId, "TermEnd": one.TermEnd})
+		return nil
+	}
+	if one.CancelAtTermEnd == 1 && sub.Status == consts.SubStatusActive && sub.CancelAtPeriodEnd == 0 && utility.MaxInt64(sub.CurrentPeriodEnd, sub.TrialEnd) >= one.TermEnd {
+		// the last period of the term, the cycle walk should not invoice the period after the term end
+		err := service.SubscriptionCancelAtPeriodEndBySystem(ctx, sub.SubscriptionId, 0)
+		if err != nil {
+			return err
+		}
+	}
+	if subTimeNow >= one.TermEnd {
+		if one.AutoRenew == 1 && sub.CancelAtPeriodEnd == 0 {
+			var previousTermEnd = one.TermEnd
+			err := renewCommitment(ctx, one)
+			operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
+				MerchantId:     one.MerchantId,
+				Target:         fmt.Sprintf("SubscriptionCommitment(%s)", one.CommitmentId),
+				Content:        fmt.Sprintf("Renew(Term:%d)", one.TermCount),
+				UserId:         one.UserId,
+				SubscriptionId: one.SubscriptionId,
+				InvoiceId:      "",
+				PlanId:         sub.PlanId,
+				DiscountCode:   "",
+			}, err)
+			if err != nil {
+				return err
+			}
+			subscription3.SendMerchantSubscriptionWebhookBackground(sub, -10000, event.UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_COMMITMENT_RENEWED, map[string]interface{}{"CommitmentId": one.CommitmentId, "PreviousTermEnd": previousTermEnd, "TermEnd": one.TermEnd})
+			return nil
+		}
+		err := finishCommitment(ctx, one, consts.SubCommitmentStatusCompleted, timeNow)
+		if err != nil {
+			return err
+		}
+		subscription3.SendMerchantSubscriptionWebhookBackground(sub, -10000, event.UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_COMMITMENT_COMPLETED, map[string]interface{}{"CommitmentId": one.CommitmentId, "TermEnd": one.TermEnd})
+		return nil
+	}
+	if term.NeedRenewalReminder(one, config.GetMerchantSubscriptionConfig(ctx, one.MerchantId).CommitmentReminderDays, subTimeNow) {
+		sendRenewalReminder(ctx, one, sub, subTimeNow)
+	}
+	return nil
+}
+
+func sendRenewalReminder(ctx context.Context, one *entity.SubscriptionCommitment, sub *entity.Subscription, timeNow int64) {
+	if one.AutoRenew == 1 && one.CancelAtTermEnd == 0 {
+		user := query.GetUserAccountById(ctx, sub.UserId)
+		plan := query.GetPlanById(ctx, sub.PlanId)
+		merchant := query.GetMerchantById(ctx, sub.MerchantId)
+		if user == nil || plan == nil || merchant == nil {
+			return
+		}
+		err := email.SendTemplateEmail(ctx, merchant.Id, user.Email, user.TimeZone, user.Language, email.TemplateSubscriptionCommitmentRenewalReminder, "", &bean.EmailTemplateVariable{
+			UserName:              user.FirstName + " " + user.LastName,
+			MerchantProductName:   plan.PlanName,
+			MerchantCustomerEmail: merchant.Email,
+			MerchantName:          query.GetMerchantCountryConfigName(ctx, merchant.Id, user.CountryCode),
+			PeriodEnd:             gtime.NewFromTimeStamp(one.TermEnd),
+		})
+		if err != nil {
+			g.Log().Errorf(ctx, "SendRenewalReminder commitmentId:%s err:%s", one.CommitmentId, err.Error())
+			return
+		}
+	}
+	subscription3.SendMerchantSubscriptionWebhookBackground(sub, int((one.TermEnd-timeNow)/86400), event.UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_COMMITMENT_TERM_WILL_END, map[string]interface{}{
+		"CommitmentId":    one.CommitmentId,
+		"TermEnd":         one.TermEnd,
+		"NoticeDeadline":  term.NoticeDeadline(one),
+		"AutoRenew":       one.AutoRenew == 1 && one.CancelAtTermEnd == 0,
+		"CancelAtTermEnd": one.CancelAtTermEnd == 1,
+	})
+	_, err := dao.SubscriptionCommitment.Ctx(ctx).Data(g.Map{
+		dao.SubscriptionCommitment.Columns().ReminderTime: timeNow,
+		dao.SubscriptionCommitment.Columns().GmtModify:    gtime.Now(),
+	}).Where(dao.SubscriptionCommitment.Columns().Id, one.Id).OmitNil().Update()
+	if err != nil {
+		g.Log().Errorf(ctx, "SendRenewalReminder update commitmentId:%s err:%s", one.CommitmentId, err.Error())
+	}
+}
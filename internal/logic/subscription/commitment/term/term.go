@@ -0,0 +1,130 @@
+package term
+
+import (
+	"fmt"
+
+	"unibee/internal/consts"
+	"unibee/internal/logic/plan/period"
+	entity "unibee/internal/model/entity/default"
+
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+const (
+	MaxTermMonths                     = 120
+	MaxNoticeDays                     = 365
+	DefaultEarlyTerminationPercentage = 10000
+	maxRemainingPeriods               = 5000
+)
+
+// TermEndFromStart returns the end of the term the months after the start
+func TermEndFromStart(start int64, months int) int64 {
+	return gtime.NewFromTimeStamp(start).AddDate(0, months, 0).Timestamp()
+}
+
+// NoticeDeadline returns the latest time the user notices the cancellation before the term end
+func NoticeDeadline(one *entity.SubscriptionCommitment) int64 {
+	return one.TermEnd - int64(one.NoticeDays)*86400
+}
+
+// IsInTerm returns true when the commitment is active and the term not ended yet
+func IsInTerm(one *entity.SubscriptionCommitment, timeNow int64) bool {
+	return one != nil && one.Status == consts.SubCommitmentStatusActive && timeNow < one.TermEnd
+}
+
+func termEndDate(one *entity.SubscriptionCommitment) string {
+	return gtime.NewFromTimeStamp(one.TermEnd).Format("Y-m-d")
+}
+
+// CheckCancel returns error when the subscription is cancelled immediately during the commitment term,
+// the commitment should be terminated with the early termination fee instead
+func CheckCancel(one *entity.SubscriptionCommitment, timeNow int64) error {
+	if !IsInTerm(one, timeNow) {
+		return nil
+	}
+	return fmt.Errorf("subscription committed until %s, terminate the commitment to cancel before the term end", termEndDate(one))
+}
+
+// CheckNotice returns error when the term renews automatically and its notice deadline passed,
+// the notice is given again before the deadline of the renewed term
+func CheckNotice(one *entity.SubscriptionCommitment, timeNow int64) error {
+	if !IsInTerm(one, timeNow) {
+		return nil
+	}
+	if one.AutoRenew == 1 && timeNow > NoticeDeadline(one) {
+		return fmt.Errorf("the notice period of %d days before the term end %s passed, the term renews automatically", one.NoticeDays, termEndDate(one))
+	}
+	return nil
+}
+
+// CheckCancelAtPeriodEnd returns true when the cancellation noticed during the commitment term waits for the term end,
+// or error when the notice deadline passed
+func CheckCancelAtPeriodEnd(one *entity.SubscriptionCommitment, periodEnd int64, timeNow int64) (bool, error) {
+	if err := CheckNotice(one, timeNow); err != nil {
+		return false, err
+	}
+	return IsInTerm(one, timeNow) && periodEnd < one.TermEnd, nil
+}
+
+// CheckDowngrade returns the time the downgrade noticed during the commitment term takes effect, the later of the period end and the term end,
+// 0 when no term applies, or error when the downgrade is immediate or the notice deadline passed
+func CheckDowngrade(one *entity.SubscriptionCommitment, periodEnd int64, effectImmediate bool, timeNow int64) (int64, error) {
+	if !IsInTerm(one, timeNow) {
+		return 0, nil
+	}
+	if effectImmediate {
+		return 0, fmt.Errorf("downgrade not available during the commitment term until %s, downgrade at the term end instead", termEndDate(one))
+	}
+	if err := CheckNotice(one, timeNow); err != nil {
+		return 0, err
+	}
+	if periodEnd > one.TermEnd {
+		return periodEnd, nil
+	}
+	return one.TermEnd, nil
+}
+
+// RemainingPeriods returns the count of the billing periods from the period end to the term end
+func RemainingPeriods(plan *entity.Plan, periodEnd int64, billingCycleAnchor int64, termEnd int64) int64 {
+	var count int64 = 0
+	if plan == nil {
+		return count
+	}
+	var start = periodEnd
+	for start < termEnd && count < maxRemainingPeriods {
+		end := period.PlanPeriodEndFromStart(plan, start, billingCycleAnchor)
+		if end <= start {
+			break
+		}
+		count = count + 1
+		start = end
+	}
+	return count
+}
+
+// EarlyTerminationFee returns the fee excluding tax charged for the remaining commitment, 10000 = 100%
+func EarlyTerminationFee(periodAmountExcludingTax int64, remainingPeriods int64, feePercentage int64) int64 {
+	if periodAmountExcludingTax <= 0 || remainingPeriods <= 0 || feePercentage <= 0 {
+		return 0
+	}
+	return periodAmountExcludingTax * remainingPeriods * feePercentage / 10000
+}
+
+// PeriodAmountExcludingTax returns the recurring amount of the subscription excluding tax
+func PeriodAmountExcludingTax(amount int64, taxPercentage int64) int64 {
+	if taxPercentage <= 0 {
+		return amount
+	}
+	return amount * 10000 / (10000 + taxPercentage)
+}
+
+// NeedRenewalReminder returns true when the term reaches the reminder days before the notice deadline and not reminded yet
+func NeedRenewalReminder(one *entity.SubscriptionCommitment, reminderDays int64, timeNow int64) bool {
+	if one == nil || one.Status != consts.SubCommitmentStatusActive || one.ReminderTime > 0 {
+		return false
+	}
+	if reminderDays <= 0 || timeNow >= one.TermEnd {
+		return false
+	}
+	return timeNow >= NoticeDeadline(one)-reminderDays*86400
+}
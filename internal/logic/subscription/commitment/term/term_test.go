@@ -0,0 +1,109 @@
+package term
+
+import (
+	"testing"
+	"time"
+
+	"unibee/internal/consts"
+	entity "unibee/internal/model/entity/default"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testTime(year int, month time.Month, day int) int64 {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.Local).Unix()
+}
+
+func TestTermEndFromStart(t *testing.T) {
+	require.Equal(t, testTime(2025, time.January, 15), TermEndFromStart(testTime(2024, time.January, 15), 12))
+	require.Equal(t, testTime(2024, time.July, 15), TermEndFromStart(testTime(2024, time.January, 15), 6))
+}
+
+func TestCheckCancel(t *testing.T) {
+	termEnd := testTime(2025, time.January, 15)
+	one := &entity.SubscriptionCommitment{TermEnd: termEnd, NoticeDays: 30, AutoRenew: 1, Status: consts.SubCommitmentStatusActive}
+	require.NotNil(t, CheckCancel(one, termEnd-1))
+	require.Nil(t, CheckCancel(one, termEnd))
+	require.Nil(t, CheckCancel(nil, termEnd-1))
+
+	// noticed before the deadline in a monthly period before the last one, cancelled at the term end
+	atTermEnd, err := CheckCancelAtPeriodEnd(one, termEnd-60*86400, termEnd-80*86400)
+	require.Nil(t, err)
+	require.True(t, atTermEnd)
+	// the last period of the term after the notice deadline
+	_, err = CheckCancelAtPeriodEnd(one, termEnd, termEnd-25*86400)
+	require.NotNil(t, err)
+	// the last period of the term before the notice deadline, cancelled at the period end
+	one.NoticeDays = 20
+	atTermEnd, err = CheckCancelAtPeriodEnd(one, termEnd, termEnd-25*86400)
+	require.Nil(t, err)
+	require.False(t, atTermEnd)
+	one.NoticeDays = 30
+	// the notice deadline passed before the last period
+	_, err = CheckCancelAtPeriodEnd(one, termEnd-10*86400, termEnd-20*86400)
+	require.NotNil(t, err)
+	one.AutoRenew = 0
+	atTermEnd, err = CheckCancelAtPeriodEnd(one, termEnd-10*86400, termEnd-20*86400)
+	require.Nil(t, err)
+	require.True(t, atTermEnd)
+
+	one.Status = consts.SubCommitmentStatusTerminated
+	require.Nil(t, CheckCancel(one, termEnd-1))
+	atTermEnd, err = CheckCancelAtPeriodEnd(one, termEnd-86400, termEnd-40*86400)
+	require.Nil(t, err)
+	require.False(t, atTermEnd)
+}
+
+func TestCheckDowngrade(t *testing.T) {
+	termEnd := testTime(2025, time.January, 15)
+	one := &entity.SubscriptionCommitment{TermEnd: termEnd, NoticeDays: 30, AutoRenew: 1, Status: consts.SubCommitmentStatusActive}
+	// noticed before the deadline, takes effect at the term end instead of the period end
+	effectTime, err := CheckDowngrade(one, termEnd-60*86400, false, termEnd-80*86400)
+	require.Nil(t, err)
+	require.Equal(t, termEnd, effectTime)
+	_, err = CheckDowngrade(one, termEnd-60*86400, true, termEnd-80*86400)
+	require.NotNil(t, err)
+	// the notice deadline passed
+	_, err = CheckDowngrade(one, termEnd, false, termEnd-20*86400)
+	require.NotNil(t, err)
+	one.AutoRenew = 0
+	effectTime, err = CheckDowngrade(one, termEnd, false, termEnd-20*86400)
+	require.Nil(t, err)
+	require.Equal(t, termEnd, effectTime)
+	// the period ends after the term end
+	effectTime, err = CheckDowngrade(one, termEnd+10*86400, false, termEnd-20*86400)
+	require.Nil(t, err)
+	require.Equal(t, termEnd+10*86400, effectTime)
+	// no term applies at the term end
+	effectTime, err = CheckDowngrade(one, termEnd+30*86400, true, termEnd)
+	require.Nil(t, err)
+	require.Equal(t, int64(0), effectTime)
+}
+
+func TestEarlyTerminationFee(t *testing.T) {
+	plan := &entity.Plan{IntervalUnit: "month", IntervalCount: 1}
+	start := testTime(2024, time.January, 15)
+	termEnd := TermEndFromStart(start, 12)
+	require.Equal(t, int64(12), RemainingPeriods(plan, start, start, termEnd))
+	require.Equal(t, int64(9), RemainingPeriods(plan, testTime(2024, time.April, 15), start, termEnd))
+	require.Equal(t, int64(0), RemainingPeriods(plan, termEnd, start, termEnd))
+	require.Equal(t, int64(0), RemainingPeriods(nil, start, start, termEnd))
+
+	require.Equal(t, int64(9000), EarlyTerminationFee(1000, 9, 10000))
+	require.Equal(t, int64(4500), EarlyTerminationFee(1000, 9, 5000))
+	require.Equal(t, int64(0), EarlyTerminationFee(1000, 0, 10000))
+	require.Equal(t, int64(1000), PeriodAmountExcludingTax(1100, 1000))
+	require.Equal(t, int64(1000), PeriodAmountExcludingTax(1000, 0))
+}
+
+func TestNeedRenewalReminder(t *testing.T) {
+	termEnd := testTime(2025, time.January, 15)
+	one := &entity.SubscriptionCommitment{TermEnd: termEnd, NoticeDays: 30, Status: consts.SubCommitmentStatusActive}
+	require.False(t, NeedRenewalReminder(one, 30, termEnd-60*86400-1))
+	require.True(t, NeedRenewalReminder(one, 30, termEnd-60*86400))
+	require.True(t, NeedRenewalReminder(one, 30, termEnd-1))
+	require.False(t, NeedRenewalReminder(one, 30, termEnd))
+	require.False(t, NeedRenewalReminder(one, 0, termEnd-1))
+	one.ReminderTime = termEnd - 50*86400
+	require.False(t, NeedRenewalReminder(one, 30, termEnd-1))
+}
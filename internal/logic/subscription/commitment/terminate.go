@@ -0,0 +1,153 @@
+package commitment
+
+import (
+	"context"
+	"fmt"
+
+	"unibee/api/bean"
+	"unibee/api/merchant/invoice"
+	"unibee/internal/consts"
+	"unibee/internal/consumer/webhook/event"
+	subscription3 "unibee/internal/consumer/webhook/subscription"
+	dao "unibee/internal/dao/default"
+	service3 "unibee/internal/logic/invoice/service"
+	"unibee/internal/logic/operation_log"
+	"unibee/internal/logic/subscription/commitment/term"
+	"unibee/internal/logic/subscription/service"
+	entity "unibee/internal/model/entity/default"
+	"unibee/internal/query"
+	"unibee/utility"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+// TerminationPreviewForCommitment computes the early termination fee of the remaining commitment if terminated now
+func TerminationPreviewForCommitment(ctx context.Context, one *entity.SubscriptionCommitment, sub *entity.Subscription) *bean.SubscriptionCommitmentTerminationPreview {
+	if one == nil || sub == nil {
+		return &bean.SubscriptionCommitmentTerminationPreview{}
+	}
+	var preview = &bean.SubscriptionCommitmentTerminationPreview{
+		PeriodAmountExcludingTax: term.PeriodAmountExcludingTax(sub.Amount, sub.TaxPercentage),
+		TaxPercentage:            sub.TaxPercentage,
+		Currency:                 sub.Currency,
+	}
+	if !term.IsInTerm(one, utility.MaxInt64(gtime.Now().Timestamp(), sub.TestClock)) {
+		return preview
+	}
+	preview.RemainingPeriods = term.RemainingPeriods(query.GetPlanById(ctx, sub.PlanId), sub.CurrentPeriodEnd, sub.BillingCycleAnchor, one.TermEnd)
+	preview.TerminationFee = term.EarlyTerminationFee(preview.PeriodAmountExcludingTax, preview.RemainingPeriods, one.EarlyTerminationFeePercentage)
+	return preview
+}
+
+type TerminateInternalReq struct {
+	MerchantId   uint64 `json:"merchantId" dc:"MerchantId"`
+	CommitmentId string `json:"commitmentId" dc:"CommitmentId"`
+	WaiveFee     bool   `json:"waiveFee" dc:"WaiveFee, terminate without the early termination fee invoice"`
+	Reason       string `json:"reason" dc:"Reason"`
+}
+
+// CommitmentTerminate ends the commitment before the term end and cancels the subscription immediately,
+// the invoice of the early termination fee computed from the remaining commitment is created and sent to the user
+func CommitmentTerminate(ctx context.Context, req *TerminateInternalReq) (*entity.SubscriptionCommitment, error) {
+	utility.Assert(req != nil, "req not found")
+	one := query.GetSubscriptionCommitmentByCommitmentId(ctx, req.CommitmentId)
+	utility.Assert(one != nil, "commitment not found")
+	utility.Assert(one.MerchantId == req.MerchantId, "merchant not match")
+	utility.Assert(one.Status == consts.SubCommitmentStatusActive, "commitment not active")
+	sub := query.GetSubscriptionBySubscriptionId(ctx, one.SubscriptionId)
+	utility.Assert(sub != nil, "subscription not found")
+	plan := query.GetPlanById(ctx, sub.PlanId)
+	utility.Assert(plan != nil, "plan not found")
+	var fee int64 = 0
+	var remainingPeriods int64 = 0
+	if !req.WaiveFee {
+		preview := TerminationPreviewForCommitment(ctx, one, sub)
+		fee = preview.TerminationFee
+		remainingPeriods = preview.RemainingPeriods
+	}
+	if fee > 0 && sub.GatewayId == 0 {
+		user := query.GetUserAccountById(ctx, sub.UserId)
+		utility.Assert(user != nil && len(user.GatewayId) > 0, "no gateway to charge the early termination fee, waive the fee or attach payment method first")
+	}
+	key := fmt.Sprintf("CommitmentTerminate-%s", one.CommitmentId)
+	utility.Assert(utility.TryLock(ctx, key, 60), "commitment terminate in progress, please retry later")
+	defer utility.ReleaseLock(ctx, key)
+
+	// the fee invoice is created before the commitment terminated, the termination aborts if the fee can not be invoiced
+	var terminationInvoiceId = ""
+	if fee > 0 {
+		res, err := service3.CreateInvoice(ctx, sub.MerchantId, &invoice.NewReq{
+			UserId:        sub.UserId,
+			TaxPercentage: sub.TaxPercentage,
+			GatewayId:     sub.GatewayId,
+			Currency:      sub.Currency,
+			Name:          fmt.Sprintf("Early Termination Fee of %s", plan.PlanName),
+			Lines: []*invoice.NewInvoiceItemParam{{
+				UnitAmountExcludingTax: fee,
+				Name:                   fmt.Sprintf("Early Termination Fee of %s", plan.PlanName),
+				Description:            fmt.Sprintf("%d remaining billing periods of the commitment term until %s", remainingPeriods, gtime.NewFromTimeStamp(one.TermEnd).Format("Y-m-d")),
+				Quantity:               1,
+			}},
+			Finish: true,
+		})
+		if err != nil {
+			g.Log().Errorf(ctx, "CommitmentTerminate create fee invoice commitmentId:%s err:%s", one.CommitmentId, err.Error())
+			return nil, err
+		}
+		if res == nil || res.Invoice == nil {
+			return nil, fmt.Errorf("early termination fee invoice not created")
+		}
+		terminationInvoiceId = res.Invoice.InvoiceId
+	}
+	var timeNow = gtime.Now().Timestamp()
+	result, err := dao.SubscriptionCommitment.Ctx(ctx).Data(g.Map{
+		dao.SubscriptionCommitment.Columns().Status:               consts.SubCommitmentStatusTerminated,
+		dao.SubscriptionCommitment.Columns().TerminationFee:       fee,
+		dao.SubscriptionCommitment.Columns().TerminationInvoiceId: terminationInvoiceId,
+		dao.SubscriptionCommitment.Columns().FinishTime:           timeNow,
+		dao.SubscriptionCommitment.Columns().GmtModify:            gtime.Now(),
+	}).Where(dao.SubscriptionCommitment.Columns().Id, one.Id).
+		Where(dao.SubscriptionCommitment.Columns().Status, consts.SubCommitmentStatusActive).
+		OmitNil().Update()
+	if err == nil {
+		var affected int64
+		affected, err = result.RowsAffected()
+		if err == nil && affected != 1 {
+			err = fmt.Errorf("commitment not active")
+		}
+	}
+	if err != nil {
+		if len(terminationInvoiceId) > 0 {
+			cancelErr := service3.CancelProcessingInvoice(ctx, terminationInvoiceId, "CommitmentTerminateFailed")
+			if cancelErr != nil {
+				g.Log().Errorf(ctx, "CommitmentTerminate cancel fee invoice:%s err:%s", terminationInvoiceId, cancelErr.Error())
+			}
+		}
+		return nil, err
+	}
+	one.Status = consts.SubCommitmentStatusTerminated
+	one.TerminationFee = fee
+	one.TerminationInvoiceId = terminationInvoiceId
+	one.FinishTime = timeNow
+	err = service.SubscriptionCancelBySystem(ctx, sub.SubscriptionId, "CommitmentEarlyTermination")
+	operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
+		MerchantId:     one.MerchantId,
+		Target:         fmt.Sprintf("SubscriptionCommitment(%s)", one.CommitmentId),
+		Content:        fmt.Sprintf("Terminate(Fee:%d,%s)", fee, req.Reason),
+		UserId:         one.UserId,
+		SubscriptionId: one.SubscriptionId,
+		InvoiceId:      one.TerminationInvoiceId,
+		PlanId:         sub.PlanId,
+		DiscountCode:   "",
+	}, err)
+	if err != nil {
+		return nil, err
+	}
+	subscription3.SendMerchantSubscriptionWebhookBackground(sub, -10000, event.UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_COMMITMENT_TERMINATED, map[string]interface{}{
+		"CommitmentId":         one.CommitmentId,
+		"TerminationFee":       one.TerminationFee,
+		"TerminationInvoiceId": one.TerminationInvoiceId,
+	})
+	return one, nil
+}
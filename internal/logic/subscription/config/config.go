@@ -4,7 +4,9 @@ import (
 	"context"
 	"strconv"
 	"unibee/api/bean"
+	"unibee/internal/consts"
 	"unibee/internal/logic/merchant_config"
+	"unibee/utility"
 )
 
 const (
@@ -15,8 +17,16 @@ const (
 	TryAutomaticPaymentBeforePeriodEnd = "TryAutomaticPaymentBeforePeriodEnd"
 	GatewayVATRule                     = "GatewayVATRule"
 	ShowZeroInvoice                    = "ShowZeroInvoice"
+	PauseMode                          = "PauseMode"
+	UserPauseEnable                    = "UserPauseEnable"
+	SeatProrationMode                  = "SeatProrationMode"
+	CancellationReasons                = "CancellationReasons"
+	TrialReminderDays                  = "TrialReminderDays"
+	CommitmentReminderDays             = "CommitmentReminderDays"
 )
 
+var DefaultCancellationReasons = []string{"Too expensive", "Missing features", "Switching to another product", "Not using it enough", "Technical issues", "Other"}
+
 func GetMerchantSubscriptionConfig(ctx context.Context, merchantId uint64) (config *bean.SubscriptionConfig) {
 	// default config
 	config = &bean.SubscriptionConfig{
@@ -27,6 +37,12 @@ func GetMerchantSubscriptionConfig(ctx context.Context, merchantId uint64) (conf
 		TryAutomaticPaymentBeforePeriodEnd: 2 * 60 * 60, // default 2 hours before period
 		GatewayVATRule:                     "",
 		ShowZeroInvoice:                    true, // default false
+		PauseMode:                          consts.SubPauseModeKeepAsDraft,
+		UserPauseEnable:                    false,
+		SeatProrationMode:                  consts.SeatProrationModeImmediate,
+		CancellationReasons:                DefaultCancellationReasons,
+		TrialReminderDays:                  3,  // default 3 days before trial end
+		CommitmentReminderDays:             30, // default 30 days before the notice deadline of term end
 	}
 	downgradeEffectImmediatelyConfig := merchant_config.GetMerchantConfig(ctx, merchantId, DowngradeEffectImmediately)
 	if downgradeEffectImmediatelyConfig != nil && downgradeEffectImmediatelyConfig.ConfigValue == "true" {
@@ -62,5 +78,39 @@ func GetMerchantSubscriptionConfig(ctx context.Context, merchantId uint64) (conf
 	if showZeroInvoice != nil && showZeroInvoice.ConfigValue == "false" {
 		config.ShowZeroInvoice = false
 	}
+	pauseMode := merchant_config.GetMerchantConfig(ctx, merchantId, PauseMode)
+	if pauseMode != nil && len(pauseMode.ConfigValue) > 0 {
+		config.PauseMode = pauseMode.ConfigValue
+	}
+	userPauseEnable := merchant_config.GetMerchantConfig(ctx, merchantId, UserPauseEnable)
+	if userPauseEnable != nil && userPauseEnable.ConfigValue == "true" {
+		config.UserPauseEnable = true
+	}
+	seatProrationMode := merchant_config.GetMerchantConfig(ctx, merchantId, SeatProrationMode)
+	if seatProrationMode != nil && len(seatProrationMode.ConfigValue) > 0 {
+		config.SeatProrationMode = seatProrationMode.ConfigValue
+	}
+	cancellationReasons := merchant_config.GetMerchantConfig(ctx, merchantId, CancellationReasons)
+	if cancellationReasons != nil && len(cancellationReasons.ConfigValue) > 0 {
+		var reasons []string
+		err := utility.UnmarshalFromJsonString(cancellationReasons.ConfigValue, &reasons)
+		if err == nil && len(reasons) > 0 {
+			config.CancellationReasons = reasons
+		}
+	}
+	trialReminderDays := merchant_config.GetMerchantConfig(ctx, merchantId, TrialReminderDays)
+	if trialReminderDays != nil && len(trialReminderDays.ConfigValue) > 0 {
+		value, err := strconv.ParseInt(trialReminderDays.ConfigValue, 10, 64)
+		if err == nil {
+			config.TrialReminderDays = value
+		}
+	}
+	commitmentReminderDays := merchant_config.GetMerchantConfig(ctx, merchantId, CommitmentReminderDays)
+	if commitmentReminderDays != nil && len(commitmentReminderDays.ConfigValue) > 0 {
+		value, err := strconv.ParseInt(commitmentReminderDays.ConfigValue, 10, 64)
+		if err == nil {
+			config.CommitmentReminderDays = value
+		}
+	}
 	return config
 }
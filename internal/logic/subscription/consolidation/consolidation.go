@@ -0,0 +1,280 @@
+package consolidation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"unibee/api/bean"
+	"unibee/api/bean/detail"
+	redismq2 "unibee/internal/cmd/redismq"
+	"unibee/internal/consts"
+	"unibee/internal/controller/link"
+	dao "unibee/internal/dao/default"
+	"unibee/internal/logic/invoice/draft"
+	"unibee/internal/logic/invoice/handler"
+	"unibee/internal/logic/invoice/invoice_number"
+	service2 "unibee/internal/logic/invoice/service"
+	"unibee/internal/logic/operation_log"
+	"unibee/internal/logic/payment/service"
+	entity "unibee/internal/model/entity/default"
+	"unibee/internal/query"
+	"unibee/utility"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+	redismq "github.com/jackyang-hk/go-redismq"
+)
+
+type AutomaticPaymentRes struct {
+	Handled bool   // the cycle invoice is charged by the consolidated invoice, the caller should not charge it on its own
+	Charged bool   // a payment of the consolidated invoice is created
+	Message string // message of the billing cycle walk
+}
+
+// TryConsolidatedAutomaticPayment charges the cycle invoice of a consolidated user together with the cycle invoices
+// of the user's other subscriptions in the same cycle, the cycle invoice is charged on its own when the result is not handled
+func TryConsolidatedAutomaticPayment(ctx context.Context, sub *entity.Subscription, latestInvoice *entity.Invoice, timeNow int64, source string) (*AutomaticPaymentRes, error) {
+	if sub == nil || latestInvoice == nil || latestInvoice.Status != consts.InvoiceStatusProcessing || latestInvoice.TotalAmount <= 0 {
+		return &AutomaticPaymentRes{Handled: false}, nil
+	}
+	if len(latestInvoice.ConsolidatedInvoiceId) > 0 {
+		consolidated := query.GetInvoiceByInvoiceId(ctx, latestInvoice.ConsolidatedInvoiceId)
+		if consolidated != nil && consolidated.Status == consts.InvoiceStatusProcessing {
+			return retryConsolidatedInvoicePayment(ctx, consolidated, timeNow, source)
+		}
+		// the consolidated invoice is closed without payment, the cycle invoices are consolidated again
+		handler.DetachConsolidatedInvoice(ctx, latestInvoice.ConsolidatedInvoiceId)
+		latestInvoice.ConsolidatedInvoiceId = ""
+	}
+	user := query.GetUserAccountById(ctx, sub.UserId)
+	if !IsUserConsolidated(user) {
+		return &AutomaticPaymentRes{Handled: false}, nil
+	}
+	key := fmt.Sprintf("ConsolidatedCycleInvoice-%d", sub.UserId)
+	if !utility.TryLock(ctx, key, 60) {
+		return &AutomaticPaymentRes{Handled: true, Message: "Consolidated Invoice Get Lock Failure"}, nil
+	}
+	defer func() {
+		utility.ReleaseLock(ctx, key)
+	}()
+	children, waiting := collectCycleInvoices(ctx, sub, latestInvoice)
+	if waiting && timeNow < utility.MaxInt64(sub.CurrentPeriodEnd, sub.TrialEnd)-3600 {
+		return &AutomaticPaymentRes{Handled: true, Message: "Nothing Todo, Waiting For The Cycle Invoices Of Other Subscriptions To Consolidate"}, nil
+	}
+	if len(children) < 2 {
+		issueUnconsolidatedInvoice(ctx, latestInvoice)
+		return &AutomaticPaymentRes{Handled: false}, nil
+	}
+	consolidated, err := createConsolidatedInvoice(ctx, latestInvoice, children, timeNow)
+	if err != nil {
+		return nil, err
+	}
+	createRes, err := service.CreateSubInvoicePaymentDefaultAutomatic(ctx, &service.CreateSubInvoicePaymentDefaultAutomaticReq{
+		Invoice:       consolidated,
+		ManualPayment: false,
+		ReturnUrl:     "",
+		CancelUrl:     "",
+		Source:        source,
+		TimeNow:       timeNow,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &AutomaticPaymentRes{Handled: true, Charged: true, Message: fmt.Sprintf("Consolidated Invoice Payment Result:%s", utility.MarshalToJsonString(createRes))}, nil
+}
+
+// issueUnconsolidatedInvoice numbers the cycle invoice charged on its own and sends the invoice email held back for the consolidated invoice
+func issueUnconsolidatedInvoice(ctx context.Context, latestInvoice *entity.Invoice) {
+	invoice_number.Assign(ctx, latestInvoice)
+	if latestInvoice.SendStatus == consts.InvoiceSendStatusUnSend && utility.TryLock(ctx, fmt.Sprintf("issueUnconsolidatedInvoice_%s", latestInvoice.InvoiceId), 43200) {
+		_ = handler.InvoicePdfGenerateAndEmailSendBackground(latestInvoice.InvoiceId, true, false)
+	}
+}
+
+// collectCycleInvoices returns the processing cycle invoices of the user's subscriptions in the same cycle and currency,
+// waiting is true when a subscription of the cycle has not issued its cycle invoice yet
+func collectCycleInvoices(ctx context.Context, sub *entity.Subscription, latestInvoice *entity.Invoice) (children []*entity.Invoice, waiting bool) {
+	children = make([]*entity.Invoice, 0)
+	subPeriodEnd := utility.MaxInt64(sub.CurrentPeriodEnd, sub.TrialEnd)
+	for _, one := range query.GetUserAllActiveOrIncompleteSubscriptions(ctx, sub.UserId, sub.MerchantId) {
+		if one.SubscriptionId == sub.SubscriptionId {
+			children = append(children, latestInvoice)
+			continue
+		}
+		if strings.ToUpper(one.Currency) != strings.ToUpper(sub.Currency) ||
+			one.CancelAtPeriodEnd == 1 ||
+			!IsSameCycle(utility.MaxInt64(one.CurrentPeriodEnd, one.TrialEnd), subPeriodEnd) {
+			continue
+		}
+		plan := query.GetPlanById(ctx, one.PlanId)
+		if plan == nil || plan.DisableAutoCharge > 0 || plan.Type != consts.PlanTypeMain || plan.Amount == 0 {
+			continue
+		}
+		invoice := query.GetInvoiceByInvoiceId(ctx, one.LatestInvoiceId)
+		if invoice == nil || !IsSameCycle(invoice.PeriodStart, latestInvoice.PeriodStart) || draft.IsReviewDraft(invoice) {
+			waiting = true
+			continue
+		}
+		if invoice.Status != consts.InvoiceStatusProcessing || invoice.TotalAmount <= 0 || len(invoice.ConsolidatedInvoiceId) > 0 ||
+			strings.ToUpper(invoice.Currency) != strings.ToUpper(latestInvoice.Currency) {
+			continue
+		}
+		children = append(children, invoice)
+	}
+	return children, waiting
+}
+
+func createConsolidatedInvoice(ctx context.Context, trigger *entity.Invoice, children []*entity.Invoice, timeNow int64) (*entity.Invoice, error) {
+	var simplifies = make([]*bean.Invoice, 0)
+	var childInvoiceIds = make([]string, 0)
+	for _, child := range children {
+		simplifies = append(simplifies, bean.SimplifyInvoice(child))
+		childInvoiceIds = append(childInvoiceIds, child.InvoiceId)
+	}
+	merged := MergeCycleInvoices(simplifies)
+	gateway := query.GetGatewayById(ctx, trigger.GatewayId)
+	if gateway != nil {
+		detail.CopyGatewayCompanyIssuer(gateway, merged.Metadata)
+	}
+	var currentTime = gtime.Now().Timestamp()
+	if timeNow > currentTime {
+		currentTime = timeNow
+	}
+	invoiceId := utility.CreateInvoiceId()
+	st := utility.CreateInvoiceSt()
+	one := &entity.Invoice{
+		BizType:                        consts.BizTypeConsolidated,
+		UserId:                         trigger.UserId,
+		MerchantId:                     trigger.MerchantId,
+		InvoiceName:                    merged.InvoiceName,
+		ProductName:                    merged.ProductName,
+		InvoiceId:                      invoiceId,
+		PeriodStart:                    merged.PeriodStart,
+		PeriodEnd:                      merged.PeriodEnd,
+		PeriodStartTime:                gtime.NewFromTimeStamp(merged.PeriodStart),
+		PeriodEndTime:                  gtime.NewFromTimeStamp(merged.PeriodEnd),
+		Currency:                       trigger.Currency,
+		GatewayId:                      trigger.GatewayId,
+		GatewayInvoiceId:               trigger.GatewayInvoiceId,
+		GatewayPaymentMethod:           trigger.GatewayPaymentMethod,
+		Status:                         consts.InvoiceStatusProcessing,
+		SendStatus:                     merged.SendStatus,
+		SendEmail:                      trigger.SendEmail,
+		UniqueId:                       invoiceId,
+		SendTerms:                      st,
+		TotalAmount:                    merged.TotalAmount,
+		TotalAmountExcludingTax:        merged.TotalAmountExcludingTax,
+		TaxAmount:                      merged.TaxAmount,
+		CountryCode:                    merged.CountryCode,
+		VatNumber:                      merged.VatNumber,
+		TaxPercentage:                  merged.TaxPercentage,
+		TaxTreatment:                   merged.TaxTreatment,
+		SubscriptionAmount:             merged.SubscriptionAmount,
+		SubscriptionAmountExcludingTax: merged.SubscriptionAmountExcludingTax,
+		Lines:                          utility.MarshalToJsonString(merged.Lines),
+		Link:                           link.GetInvoiceLink(invoiceId, st),
+		CreateTime:                     gtime.Now().Timestamp(),
+		FinishTime:                     currentTime,
+		DayUtilDue:                     merged.DayUtilDue,
+		DiscountAmount:                 merged.DiscountAmount,
+		Data:                           trigger.Data,
+		MetaData:                       utility.MarshalToJsonString(merged.Metadata),
+		CreateFrom:                     merged.CreateFrom,
+		PromoCreditDiscountAmount:      merged.PromoCreditDiscountAmount,
+	}
+	err := dao.Invoice.DB().Transaction(ctx, func(ctx context.Context, transaction gdb.TX) error {
+		result, err := dao.Invoice.Ctx(ctx).Data(one).OmitNil().Insert(one)
+		if err != nil {
+			return gerror.Newf(`createConsolidatedInvoice record insert failure %s`, err.Error())
+		}
+		id, _ := result.LastInsertId()
+		one.Id = uint64(uint(id))
+		update, err := dao.Invoice.Ctx(ctx).Data(g.Map{
+			dao.Invoice.Columns().ConsolidatedInvoiceId: invoiceId,
+			dao.Invoice.Columns().GmtModify:             gtime.Now(),
+		}).WhereIn(dao.Invoice.Columns().InvoiceId, childInvoiceIds).
+			Where(dao.Invoice.Columns().Status, consts.InvoiceStatusProcessing).
+			Where(dao.Invoice.Columns().ConsolidatedInvoiceId, "").
+			Update()
+		if err != nil {
+			return gerror.Newf(`createConsolidatedInvoice attach cycle invoices failure %s`, err.Error())
+		}
+		affected, err := update.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected != int64(len(childInvoiceIds)) {
+			return gerror.New("createConsolidatedInvoice cycle invoices changed, try again later")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	invoice_number.Assign(ctx, one)
+	if utility.TryLock(ctx, fmt.Sprintf("createConsolidatedInvoice_%s", one.InvoiceId), 60) {
+		_, _ = redismq.Send(&redismq.Message{
+			Topic:      redismq2.TopicInvoiceCreated.Topic,
+			Tag:        redismq2.TopicInvoiceCreated.Tag,
+			Body:       one.InvoiceId,
+			CustomData: map[string]interface{}{"CreateFrom": utility.ReflectCurrentFunctionName()},
+		})
+		_, _ = redismq.Send(&redismq.Message{
+			Topic:      redismq2.TopicInvoiceProcessed.Topic,
+			Tag:        redismq2.TopicInvoiceProcessed.Tag,
+			Body:       one.InvoiceId,
+			CustomData: map[string]interface{}{"CreateFrom": utility.ReflectCurrentFunctionName()},
+		})
+	}
+	operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
+		MerchantId:     one.MerchantId,
+		Target:         fmt.Sprintf("Invoice(%s)", one.InvoiceId),
+		Content:        fmt.Sprintf("NewConsolidated(%s)", strings.Join(childInvoiceIds, ",")),
+		UserId:         one.UserId,
+		SubscriptionId: "",
+		InvoiceId:      one.InvoiceId,
+		PlanId:         0,
+		DiscountCode:   "",
+	}, err)
+	_ = handler.InvoicePdfGenerateAndEmailSendBackground(one.InvoiceId, true, false)
+	return one, nil
+}
+
+func retryConsolidatedInvoicePayment(ctx context.Context, consolidated *entity.Invoice, timeNow int64, source string) (*AutomaticPaymentRes, error) {
+	for _, child := range query.GetInvoicesByConsolidatedInvoiceId(ctx, consolidated.InvoiceId) {
+		if child.Status != consts.InvoiceStatusProcessing {
+			// a cycle invoice is closed on its own, cancel the consolidated invoice, the others are consolidated again
+			err := service2.CancelProcessingInvoice(ctx, consolidated.InvoiceId, "ConsolidatedCycleInvoiceClosed")
+			if err != nil {
+				g.Log().Errorf(ctx, "retryConsolidatedInvoicePayment CancelProcessingInvoice invoiceId:%s err:%s", consolidated.InvoiceId, err.Error())
+			}
+			handler.DetachConsolidatedInvoice(ctx, consolidated.InvoiceId)
+			return &AutomaticPaymentRes{Handled: true, Charged: false, Message: "Consolidated Invoice Cancelled As Cycle Invoice Closed"}, nil
+		}
+	}
+	var lastAutomaticTryTime int64 = 0
+	if len(consolidated.PaymentId) > 0 {
+		lastPayment := query.GetPaymentByPaymentId(ctx, consolidated.PaymentId)
+		if lastPayment != nil {
+			lastAutomaticTryTime = lastPayment.CreateTime
+		}
+	}
+	if (timeNow - lastAutomaticTryTime) <= 43200 {
+		return &AutomaticPaymentRes{Handled: true, Charged: false, Message: "Nothing Todo, Cycle Invoice Charged By Consolidated Invoice"}, nil
+	}
+	createRes, err := service.CreateSubInvoicePaymentDefaultAutomatic(ctx, &service.CreateSubInvoicePaymentDefaultAutomaticReq{
+		Invoice:       consolidated,
+		ManualPayment: false,
+		ReturnUrl:     "",
+		CancelUrl:     "",
+		Source:        source,
+		TimeNow:       timeNow,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &AutomaticPaymentRes{Handled: true, Charged: true, Message: fmt.Sprintf("Consolidated Invoice Payment Result:%s", utility.MarshalToJsonString(createRes))}, nil
+}
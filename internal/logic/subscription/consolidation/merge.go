@@ -0,0 +1,154 @@
+package consolidation
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"unibee/api/bean"
+	"unibee/internal/consts"
+	entity "unibee/internal/model/entity/default"
+
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+const (
+	ConsolidatedInvoiceName = "SubscriptionConsolidatedCycle"
+	// cycleTolerance cycle invoices starting within one day are charged together
+	cycleTolerance int64 = 86400
+)
+
+// IsUserConsolidated returns true when the cycle invoices of the user are merged into one consolidated invoice
+func IsUserConsolidated(user *entity.UserAccount) bool {
+	return user != nil && user.ConsolidatedBilling == 1
+}
+
+// IsSameCycle returns true when the two period boundaries belong to the same consolidated cycle
+func IsSameCycle(a int64, b int64) bool {
+	diff := a - b
+	if diff < 0 {
+		diff = -diff
+	}
+	return diff < cycleTolerance
+}
+
+// AlignedPeriodEnd returns the first time after the period start on the day and clock of the anchor,
+// the day is capped to the last day of the month
+func AlignedPeriodEnd(periodStart int64, anchor int64) int64 {
+	start := time.Unix(periodStart, 0)
+	anchorTime := time.Unix(anchor, 0)
+	candidate := anchorDayOfMonth(start.Year(), start.Month(), anchorTime)
+	if !candidate.After(start) {
+		candidate = anchorDayOfMonth(start.Year(), start.Month()+1, anchorTime)
+	}
+	return candidate.Unix()
+}
+
+func anchorDayOfMonth(year int, month time.Month, anchorTime time.Time) time.Time {
+	lastDay := time.Date(year, month+1, 0, 0, 0, 0, 0, anchorTime.Location()).Day()
+	day := anchorTime.Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return time.Date(year, month, day, anchorTime.Hour(), anchorTime.Minute(), anchorTime.Second(), 0, anchorTime.Location())
+}
+
+// AlignCyclePeriod shortens the next monthly period of a consolidated user's subscription to end on the consolidation anchor,
+// returns the period end and the proration scale of the period, 10000 = 100%, the scale is 0 when the period is kept
+func AlignCyclePeriod(user *entity.UserAccount, plan *entity.Plan, periodStart int64, periodEnd int64) (int64, int64) {
+	if !IsUserConsolidated(user) || user.ConsolidatedBillingAnchor <= 0 || plan == nil || periodEnd <= periodStart {
+		return periodEnd, 0
+	}
+	if strings.Compare(strings.ToLower(plan.IntervalUnit), "month") != 0 {
+		return periodEnd, 0
+	}
+	alignedEnd := AlignedPeriodEnd(periodStart, user.ConsolidatedBillingAnchor)
+	if alignedEnd >= periodEnd {
+		return periodEnd, 0
+	}
+	prorationScale := (alignedEnd - periodStart) * 10000 / (periodEnd - periodStart)
+	if prorationScale < 1 {
+		prorationScale = 1
+	}
+	return alignedEnd, prorationScale
+}
+
+// MergeCycleInvoices merges the cycle invoices of the subscriptions into one consolidated invoice with a line per subscription
+func MergeCycleInvoices(children []*bean.Invoice) *bean.Invoice {
+	if len(children) == 0 {
+		return nil
+	}
+	first := children[0]
+	one := &bean.Invoice{
+		BizType:       consts.BizTypeConsolidated,
+		UserId:        first.UserId,
+		InvoiceName:   ConsolidatedInvoiceName,
+		ProductName:   fmt.Sprintf("%d Subscriptions", len(children)),
+		Currency:      strings.ToUpper(first.Currency),
+		CountryCode:   first.CountryCode,
+		VatNumber:     first.VatNumber,
+		TaxPercentage: first.TaxPercentage,
+		TaxTreatment:  first.TaxTreatment,
+		PeriodStart:   first.PeriodStart,
+		PeriodEnd:     first.PeriodEnd,
+		SendStatus:    consts.InvoiceSendStatusUnSend,
+		DayUtilDue:    first.DayUtilDue,
+		CreateFrom:    consts.InvoiceAutoChargeFlag,
+		Lines:         make([]*bean.InvoiceItemSimplify, 0),
+	}
+	var invoiceIds = make([]string, 0)
+	for _, child := range children {
+		if child.TaxPercentage != one.TaxPercentage {
+			one.TaxPercentage = 0
+		}
+		if child.PeriodStart < one.PeriodStart {
+			one.PeriodStart = child.PeriodStart
+		}
+		if child.PeriodEnd > one.PeriodEnd {
+			one.PeriodEnd = child.PeriodEnd
+		}
+		var period = ""
+		if child.PeriodStart > 0 && child.PeriodEnd > child.PeriodStart {
+			period = fmt.Sprintf("(%s-%s)", gtime.NewFromTimeStamp(child.PeriodStart).Layout("2006-01-02"), gtime.NewFromTimeStamp(child.PeriodEnd).Layout("2006-01-02"))
+		}
+		discountAmount := child.DiscountAmount + child.PromoCreditDiscountAmount
+		one.Lines = append(one.Lines, &bean.InvoiceItemSimplify{
+			Currency:               one.Currency,
+			OriginAmount:           child.TotalAmount + discountAmount,
+			DiscountAmount:         discountAmount,
+			Amount:                 child.TotalAmount,
+			Tax:                    child.TaxAmount,
+			AmountExcludingTax:     child.TotalAmountExcludingTax,
+			TaxPercentage:          child.TaxPercentage,
+			UnitAmountExcludingTax: child.TotalAmountExcludingTax,
+			Quantity:               1,
+			Name:                   child.ProductName,
+			Description:            fmt.Sprintf("%s %s", child.ProductName, period),
+			PdfDescription:         fmt.Sprintf("%s %s, Subscription %s", child.ProductName, period, child.SubscriptionId),
+			Proration:              isProrationLines(child.Lines),
+			PeriodStart:            child.PeriodStart,
+			PeriodEnd:              child.PeriodEnd,
+			TaxLines:               bean.SummaryInvoiceTaxLines(child.Lines),
+		})
+		one.TotalAmount = one.TotalAmount + child.TotalAmount
+		one.TotalAmountExcludingTax = one.TotalAmountExcludingTax + child.TotalAmountExcludingTax
+		one.TaxAmount = one.TaxAmount + child.TaxAmount
+		one.DiscountAmount = one.DiscountAmount + child.DiscountAmount
+		one.PromoCreditDiscountAmount = one.PromoCreditDiscountAmount + child.PromoCreditDiscountAmount
+		one.SubscriptionAmount = one.SubscriptionAmount + child.SubscriptionAmount
+		one.SubscriptionAmountExcludingTax = one.SubscriptionAmountExcludingTax + child.SubscriptionAmountExcludingTax
+		invoiceIds = append(invoiceIds, child.InvoiceId)
+	}
+	one.OriginAmount = one.TotalAmount + one.DiscountAmount + one.PromoCreditDiscountAmount
+	one.Metadata = map[string]interface{}{"ConsolidatedInvoiceIds": invoiceIds}
+	return one
+}
+
+func isProrationLines(lines []*bean.InvoiceItemSimplify) bool {
+	for _, line := range lines {
+		if line != nil && line.Proration {
+			return true
+		}
+	}
+	return false
+}
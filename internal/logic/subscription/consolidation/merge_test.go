@@ -0,0 +1,125 @@
+package consolidation
+
+import (
+	"testing"
+	"time"
+
+	"unibee/api/bean"
+	"unibee/internal/consts"
+	entity "unibee/internal/model/entity/default"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testCycleInvoice(invoiceId string, subId string, totalExcludingTax int64, tax int64, discount int64) *bean.Invoice {
+	return &bean.Invoice{
+		InvoiceId:                      invoiceId,
+		SubscriptionId:                 subId,
+		UserId:                         1,
+		ProductName:                    "Plan " + subId,
+		Currency:                       "eur",
+		TaxPercentage:                  1000,
+		TotalAmount:                    totalExcludingTax + tax,
+		TotalAmountExcludingTax:        totalExcludingTax,
+		TaxAmount:                      tax,
+		DiscountAmount:                 discount,
+		SubscriptionAmount:             totalExcludingTax + tax + discount,
+		SubscriptionAmountExcludingTax: totalExcludingTax + discount,
+		PeriodStart:                    1000,
+		PeriodEnd:                      2000,
+		DayUtilDue:                     3,
+		Lines: []*bean.InvoiceItemSimplify{
+			{Amount: totalExcludingTax + tax, Tax: tax, AmountExcludingTax: totalExcludingTax, TaxLines: []*bean.InvoiceItemTaxLine{{Name: "VAT", Rate: 1000, Amount: tax}}},
+		},
+	}
+}
+
+func TestMergeCycleInvoices(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		require.Nil(t, MergeCycleInvoices(nil))
+	})
+	t.Run("line per subscription", func(t *testing.T) {
+		second := testCycleInvoice("iv2", "sub2", 2000, 200, 0)
+		second.PeriodEnd = 2500
+		second.Lines[0].Proration = true
+		one := MergeCycleInvoices([]*bean.Invoice{testCycleInvoice("iv1", "sub1", 1000, 100, 500), second})
+		require.NotNil(t, one)
+		require.Equal(t, consts.BizTypeConsolidated, one.BizType)
+		require.Equal(t, ConsolidatedInvoiceName, one.InvoiceName)
+		require.Equal(t, "EUR", one.Currency)
+		require.Equal(t, 2, len(one.Lines))
+		require.Equal(t, int64(3300), one.TotalAmount)
+		require.Equal(t, int64(3000), one.TotalAmountExcludingTax)
+		require.Equal(t, int64(300), one.TaxAmount)
+		require.Equal(t, int64(500), one.DiscountAmount)
+		require.Equal(t, int64(3800), one.OriginAmount)
+		require.Equal(t, int64(1000), one.TaxPercentage)
+		require.Equal(t, int64(1000), one.PeriodStart)
+		require.Equal(t, int64(2500), one.PeriodEnd)
+		require.Equal(t, int64(1100), one.Lines[0].Amount)
+		require.Equal(t, int64(1600), one.Lines[0].OriginAmount)
+		require.Equal(t, int64(100), one.Lines[0].TaxLines[0].Amount)
+		require.False(t, one.Lines[0].Proration)
+		require.True(t, one.Lines[1].Proration)
+		require.Equal(t, []string{"iv1", "iv2"}, one.Metadata["ConsolidatedInvoiceIds"])
+	})
+	t.Run("mixed tax percentage", func(t *testing.T) {
+		second := testCycleInvoice("iv2", "sub2", 2000, 0, 0)
+		second.TaxPercentage = 0
+		one := MergeCycleInvoices([]*bean.Invoice{testCycleInvoice("iv1", "sub1", 1000, 100, 0), second})
+		require.Equal(t, int64(0), one.TaxPercentage)
+		require.Equal(t, int64(3100), one.TotalAmount)
+	})
+}
+
+func TestAlignedPeriodEnd(t *testing.T) {
+	anchor := time.Date(2026, 1, 5, 8, 0, 0, 0, time.Local).Unix()
+	t.Run("later in the same month", func(t *testing.T) {
+		start := time.Date(2026, 3, 2, 12, 0, 0, 0, time.Local).Unix()
+		require.Equal(t, time.Date(2026, 3, 5, 8, 0, 0, 0, time.Local).Unix(), AlignedPeriodEnd(start, anchor))
+	})
+	t.Run("next month", func(t *testing.T) {
+		start := time.Date(2026, 3, 20, 12, 0, 0, 0, time.Local).Unix()
+		require.Equal(t, time.Date(2026, 4, 5, 8, 0, 0, 0, time.Local).Unix(), AlignedPeriodEnd(start, anchor))
+	})
+	t.Run("day capped to the end of month", func(t *testing.T) {
+		endOfMonthAnchor := time.Date(2026, 1, 31, 0, 0, 0, 0, time.Local).Unix()
+		start := time.Date(2026, 2, 10, 0, 0, 0, 0, time.Local).Unix()
+		require.Equal(t, time.Date(2026, 2, 28, 0, 0, 0, 0, time.Local).Unix(), AlignedPeriodEnd(start, endOfMonthAnchor))
+	})
+}
+
+func TestAlignCyclePeriod(t *testing.T) {
+	anchor := time.Date(2026, 1, 5, 0, 0, 0, 0, time.Local).Unix()
+	user := &entity.UserAccount{ConsolidatedBilling: 1, ConsolidatedBillingAnchor: anchor}
+	monthly := &entity.Plan{IntervalUnit: "month", IntervalCount: 1}
+	start := time.Date(2026, 3, 20, 0, 0, 0, 0, time.Local).Unix()
+	end := time.Date(2026, 4, 20, 0, 0, 0, 0, time.Local).Unix()
+	t.Run("shortened and prorated", func(t *testing.T) {
+		alignedEnd, scale := AlignCyclePeriod(user, monthly, start, end)
+		require.Equal(t, time.Date(2026, 4, 5, 0, 0, 0, 0, time.Local).Unix(), alignedEnd)
+		require.Equal(t, (alignedEnd-start)*10000/(end-start), scale)
+		require.True(t, scale > 0 && scale < 10000)
+	})
+	t.Run("already aligned", func(t *testing.T) {
+		alignedStart := time.Date(2026, 3, 5, 0, 0, 0, 0, time.Local).Unix()
+		alignedEnd := time.Date(2026, 4, 5, 0, 0, 0, 0, time.Local).Unix()
+		periodEnd, scale := AlignCyclePeriod(user, monthly, alignedStart, alignedEnd)
+		require.Equal(t, alignedEnd, periodEnd)
+		require.Equal(t, int64(0), scale)
+	})
+	t.Run("not consolidated or not monthly", func(t *testing.T) {
+		periodEnd, scale := AlignCyclePeriod(&entity.UserAccount{ConsolidatedBillingAnchor: anchor}, monthly, start, end)
+		require.Equal(t, end, periodEnd)
+		require.Equal(t, int64(0), scale)
+		periodEnd, scale = AlignCyclePeriod(user, &entity.Plan{IntervalUnit: "year", IntervalCount: 1}, start, end)
+		require.Equal(t, end, periodEnd)
+		require.Equal(t, int64(0), scale)
+	})
+}
+
+func TestIsSameCycle(t *testing.T) {
+	require.True(t, IsSameCycle(1000, 1000))
+	require.True(t, IsSameCycle(1000, 1000+86399))
+	require.False(t, IsSameCycle(1000+86400, 1000))
+}
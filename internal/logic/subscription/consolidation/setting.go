@@ -0,0 +1,72 @@
+package consolidation
+
+import (
+	"context"
+	"fmt"
+
+	dao "unibee/internal/dao/default"
+	"unibee/internal/logic/operation_log"
+	entity "unibee/internal/model/entity/default"
+	"unibee/internal/query"
+	"unibee/utility"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+type ConsolidatedBillingSetupReq struct {
+	MerchantId         uint64
+	UserId             uint64
+	Enable             bool
+	BillingCycleAnchor int64 // the consolidated subscriptions are aligned to the day and clock of the anchor, default the earliest period end of the user's subscriptions
+}
+
+// SetupUserConsolidatedBilling turns the consolidated billing of the user on or off, the monthly subscriptions of the user
+// are aligned to the anchor from their next cycle with a prorated shortened period
+func SetupUserConsolidatedBilling(ctx context.Context, req *ConsolidatedBillingSetupReq) (*entity.UserAccount, error) {
+	utility.Assert(req.UserId > 0, "invalid userId")
+	user := query.GetUserAccountById(ctx, req.UserId)
+	utility.Assert(user != nil, "user not found")
+	utility.Assert(user.MerchantId == req.MerchantId, "wrong merchant account")
+	var consolidatedBilling = 0
+	var anchor int64 = 0
+	if req.Enable {
+		consolidatedBilling = 1
+		anchor = req.BillingCycleAnchor
+		if anchor <= 0 {
+			anchor = user.ConsolidatedBillingAnchor
+		}
+		if anchor <= 0 {
+			for _, sub := range query.GetUserAllActiveOrIncompleteSubscriptions(ctx, user.Id, user.MerchantId) {
+				periodEnd := utility.MaxInt64(sub.CurrentPeriodEnd, sub.TrialEnd)
+				if periodEnd > 0 && (anchor <= 0 || periodEnd < anchor) {
+					anchor = periodEnd
+				}
+			}
+		}
+		if anchor <= 0 {
+			anchor = gtime.Now().Timestamp()
+		}
+	}
+	_, err := dao.UserAccount.Ctx(ctx).Data(g.Map{
+		dao.UserAccount.Columns().ConsolidatedBilling:       consolidatedBilling,
+		dao.UserAccount.Columns().ConsolidatedBillingAnchor: anchor,
+		dao.UserAccount.Columns().GmtModify:                 gtime.Now(),
+	}).Where(dao.UserAccount.Columns().Id, user.Id).Update()
+	operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
+		MerchantId:     user.MerchantId,
+		Target:         fmt.Sprintf("User(%v)", user.Id),
+		Content:        fmt.Sprintf("ConsolidatedBilling(%d->%d)", user.ConsolidatedBilling, consolidatedBilling),
+		UserId:         user.Id,
+		SubscriptionId: "",
+		InvoiceId:      "",
+		PlanId:         0,
+		DiscountCode:   "",
+	}, err)
+	if err != nil {
+		return nil, err
+	}
+	user.ConsolidatedBilling = consolidatedBilling
+	user.ConsolidatedBillingAnchor = anchor
+	return user, nil
+}
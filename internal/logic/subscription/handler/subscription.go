@@ -67,10 +67,15 @@ func HandleSubscriptionFirstInvoicePaid(ctx context.Context, sub *entity.Subscri
 		return nil
 	}
 	var dunningTime = period.GetDunningTimeFromEnd(ctx, utility.MaxInt64(invoice.PeriodEnd, invoice.TrialEnd), sub.PlanId)
+	// the backdated or anchored creation keeps its billing cycle anchor
+	var billingCycleAnchor = invoice.PeriodStart
+	if invoice.BillingCycleAnchor > 0 {
+		billingCycleAnchor = invoice.BillingCycleAnchor
+	}
 	_, err := dao.Subscription.Ctx(ctx).Data(g.Map{
 		dao.Subscription.Columns().Status:                 consts.SubStatusActive,
 		dao.Subscription.Columns().CurrentPeriodPaid:      1,
-		dao.Subscription.Columns().BillingCycleAnchor:     invoice.PeriodStart,
+		dao.Subscription.Columns().BillingCycleAnchor:     billingCycleAnchor,
 		dao.Subscription.Columns().CurrentPeriodStart:     invoice.PeriodStart,
 		dao.Subscription.Columns().CurrentPeriodEnd:       invoice.PeriodEnd,
 		dao.Subscription.Columns().CurrentPeriodStartTime: gtime.NewFromTimeStamp(invoice.PeriodStart),
@@ -21,6 +21,7 @@ import (
 	"unibee/internal/logic/payment/service"
 	"unibee/internal/logic/plan/period"
 	service2 "unibee/internal/logic/subscription/service"
+	"unibee/internal/logic/tax"
 	"unibee/internal/logic/user/sub_update"
 	"unibee/internal/logic/user/vat"
 	entity "unibee/internal/model/entity/default"
@@ -236,6 +237,12 @@ func CreateSubOneTimeAddonPreview(ctx context.Context, req *SubscriptionCreateOn
 			Plan:                   bean.SimplifyPlan(addon),
 		}},
 	}
+	if req.TaxPercentage != nil {
+		// the tax percentage of the api call wins the tax engine, the reverse charge or exemption still applies
+		tax.ApplyTaxTreatment(invoice, tax.ResolveTaxTreatment(ctx, sub.MerchantId, sub.UserId, invoice.VatNumber))
+	} else {
+		tax.ApplyInvoiceTax(ctx, sub.MerchantId, sub.UserId, invoice)
+	}
 	return &SubscriptionCreateOnetimeAddonPreviewInternalRes{
 		MerchantId:           req.MerchantId,
 		Subscription:         sub,
@@ -0,0 +1,272 @@
+package pause
+
+import (
+	"context"
+	"fmt"
+
+	config2 "unibee/internal/cmd/config"
+	redismq2 "unibee/internal/cmd/redismq"
+	"unibee/internal/consts"
+	dao "unibee/internal/dao/default"
+	"unibee/internal/logic/invoice/draft"
+	"unibee/internal/logic/invoice/receivable"
+	service3 "unibee/internal/logic/invoice/service"
+	metric2 "unibee/internal/logic/metric"
+	"unibee/internal/logic/operation_log"
+	"unibee/internal/logic/payment/service"
+	"unibee/internal/logic/plan/period"
+	"unibee/internal/logic/subscription/pending_update_cancel"
+	entity "unibee/internal/model/entity/default"
+	"unibee/internal/query"
+	"unibee/utility"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+	redismq "github.com/jackyang-hk/go-redismq"
+)
+
+type PauseInternalReq struct {
+	MerchantId     uint64
+	SubscriptionId string
+	PauseMode      string
+	ResumeTime     int64 // the utc time the subscription resumes automatically, 0 to resume manually
+	Reason         string
+}
+
+// subscriptionTimeNow returns the time now of the subscription, the test clock is followed outside prod env
+func subscriptionTimeNow(sub *entity.Subscription) int64 {
+	timeNow := gtime.Now().Timestamp()
+	if sub.TestClock > timeNow && !config2.GetConfigInstance().IsProd() {
+		timeNow = sub.TestClock
+	}
+	return timeNow
+}
+
+// SubscriptionPause pauses the active subscription, the billing cycle stops until resumed,
+// the open cycle invoice is kept as draft, voided or closed as uncollectible by the pause mode
+func SubscriptionPause(ctx context.Context, req *PauseInternalReq) (*entity.Subscription, error) {
+	utility.Assert(len(req.SubscriptionId) > 0, "subscriptionId not found")
+	utility.Assert(IsValidPauseMode(req.PauseMode), fmt.Sprintf("invalid pauseMode, should be one of %s|%s|%s", consts.SubPauseModeKeepAsDraft, consts.SubPauseModeVoid, consts.SubPauseModeMarkUncollectible))
+	sub := query.GetSubscriptionBySubscriptionId(ctx, req.SubscriptionId)
+	utility.Assert(sub != nil, "subscription not found")
+	utility.Assert(sub.MerchantId == req.MerchantId, "wrong merchant account")
+	utility.Assert(sub.Type == consts.SubTypeUniBeeControl, "subscription billing cycle not controlled by UniBee")
+	utility.Assert(sub.Status == consts.SubStatusActive, "subscription not in active status")
+	utility.Assert(sub.CancelAtPeriodEnd == 0, "subscription will cancel at period end, resume it first")
+	timeNow := subscriptionTimeNow(sub)
+	utility.Assert(req.ResumeTime == 0 || req.ResumeTime > timeNow, "resumeTime should be later than now")
+
+	if len(sub.PendingUpdateId) > 0 {
+		pendingUpdate := query.GetUnfinishedSubscriptionPendingUpdateByPendingUpdateId(ctx, sub.PendingUpdateId)
+		if pendingUpdate != nil {
+			err := pending_update_cancel.SubscriptionPendingUpdateCancel(ctx, pendingUpdate.PendingUpdateId, "SubscriptionPaused")
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	result, err := dao.Subscription.Ctx(ctx).Data(g.Map{
+		dao.Subscription.Columns().Status:         consts.SubStatusSuspended,
+		dao.Subscription.Columns().PauseMode:      req.PauseMode,
+		dao.Subscription.Columns().PauseTime:      timeNow,
+		dao.Subscription.Columns().ResumeTime:     req.ResumeTime,
+		dao.Subscription.Columns().GmtModify:      gtime.Now(),
+		dao.Subscription.Columns().LastUpdateTime: gtime.Now().Timestamp(),
+	}).Where(dao.Subscription.Columns().Id, sub.Id).Where(dao.Subscription.Columns().Status, consts.SubStatusActive).OmitNil().Update()
+	if err != nil {
+		return nil, err
+	}
+	affected, _ := result.RowsAffected()
+	if affected != 1 {
+		return nil, fmt.Errorf("subscription %s status changed, try again", sub.SubscriptionId)
+	}
+	closeOpenInvoice(ctx, sub, req.PauseMode)
+	operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
+		MerchantId:     sub.MerchantId,
+		Target:         fmt.Sprintf("Subscription(%s)", sub.SubscriptionId),
+		Content:        fmt.Sprintf("Pause(%s,ResumeTime:%d,%s)", req.PauseMode, req.ResumeTime, req.Reason),
+		UserId:         sub.UserId,
+		SubscriptionId: sub.SubscriptionId,
+		InvoiceId:      sub.LatestInvoiceId,
+		PlanId:         0,
+		DiscountCode:   "",
+	}, err)
+	_, _ = redismq.Send(&redismq.Message{
+		Topic:      redismq2.TopicSubscriptionPaused.Topic,
+		Tag:        redismq2.TopicSubscriptionPaused.Tag,
+		Body:       sub.SubscriptionId,
+		CustomData: map[string]interface{}{"CreateFrom": utility.ReflectCurrentFunctionName(), "PauseMode": req.PauseMode, "ResumeTime": req.ResumeTime},
+	})
+	_, _ = redismq.Send(&redismq.Message{
+		Topic: redismq2.TopicUserMetricUpdate.Topic,
+		Tag:   redismq2.TopicUserMetricUpdate.Tag,
+		Body: utility.MarshalToJsonString(&metric2.UserMetricUpdateMessage{
+			UserId:         sub.UserId,
+			SubscriptionId: sub.SubscriptionId,
+			Description:    "SubscriptionPaused",
+		}),
+		CustomData: map[string]interface{}{"CreateFrom": utility.ReflectCurrentFunctionName()},
+	})
+	return query.GetSubscriptionBySubscriptionId(ctx, sub.SubscriptionId), nil
+}
+
+// closeOpenInvoice applies the pause mode to the latest cycle invoice not paid yet,
+// the net term invoice already sent to the customer stays open as receivable
+func closeOpenInvoice(ctx context.Context, sub *entity.Subscription, pauseMode string) {
+	one := query.GetInvoiceByInvoiceId(ctx, sub.LatestInvoiceId)
+	if one == nil || one.IsDeleted != 0 || receivable.IsNetTermInvoice(one) {
+		return
+	}
+	var err error
+	if draft.IsReviewDraft(one) {
+		if pauseMode == consts.SubPauseModeKeepAsDraft {
+			_, err = draft.HoldDraftInvoice(ctx, one.MerchantId, one.InvoiceId, true)
+		} else {
+			// nothing issued yet, voided and uncollectible drafts are both dropped
+			err = service3.DeletePendingInvoice(ctx, one.InvoiceId)
+		}
+	} else if one.Status == consts.InvoiceStatusProcessing {
+		if pauseMode == consts.SubPauseModeKeepAsDraft {
+			err = keepInvoiceAsDraft(ctx, one)
+		} else if pauseMode == consts.SubPauseModeVoid {
+			err = service3.CancelProcessingInvoice(ctx, one.InvoiceId, "SubscriptionPaused")
+		} else {
+			err = service3.ProcessingInvoiceFailure(ctx, one.InvoiceId, "SubscriptionPausedUncollectible")
+		}
+	}
+	if err != nil {
+		g.Log().Errorf(ctx, "SubscriptionPause closeOpenInvoice subscriptionId:%s invoiceId:%s mode:%s err:%s", sub.SubscriptionId, one.InvoiceId, pauseMode, err.Error())
+	}
+}
+
+// keepInvoiceAsDraft moves the processing invoice back to a held draft, the payment in progress is cancelled
+func keepInvoiceAsDraft(ctx context.Context, one *entity.Invoice) error {
+	var finalizeTime = one.FinishTime
+	if finalizeTime <= 0 {
+		finalizeTime = gtime.Now().Timestamp()
+	}
+	result, err := dao.Invoice.Ctx(ctx).Data(g.Map{
+		dao.Invoice.Columns().Status:             consts.InvoiceStatusPending,
+		dao.Invoice.Columns().PaymentId:          "",
+		dao.Invoice.Columns().ReviewFinalizeTime: finalizeTime,
+		dao.Invoice.Columns().ReviewHold:         1,
+		dao.Invoice.Columns().GmtModify:          gtime.Now(),
+	}).Where(dao.Invoice.Columns().Id, one.Id).Where(dao.Invoice.Columns().Status, consts.InvoiceStatusProcessing).OmitNil().Update()
+	if err != nil {
+		return err
+	}
+	affected, _ := result.RowsAffected()
+	if affected != 1 {
+		return fmt.Errorf("invoice %s is not processing", one.InvoiceId)
+	}
+	operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
+		MerchantId:     one.MerchantId,
+		Target:         fmt.Sprintf("Invoice(%s)", one.InvoiceId),
+		Content:        "KeepAsDraftBySubscriptionPaused",
+		UserId:         one.UserId,
+		SubscriptionId: one.SubscriptionId,
+		InvoiceId:      one.InvoiceId,
+		PlanId:         0,
+		DiscountCode:   "",
+	}, err)
+	if len(one.PaymentId) > 0 {
+		payment := query.GetPaymentByPaymentId(ctx, one.PaymentId)
+		if payment != nil && payment.Status == consts.PaymentCreated {
+			err = service.PaymentGatewayCancel(ctx, payment)
+			if err != nil {
+				g.Log().Errorf(ctx, "keepInvoiceAsDraft PaymentGatewayCancel paymentId:%s err:%s", payment.PaymentId, err.Error())
+			}
+		}
+	}
+	return nil
+}
+
+// SubscriptionResume resumes the paused subscription, the period end is extended by the time paused
+// and the invoice kept as draft moves with the period and is finalised again by the billing cycle
+func SubscriptionResume(ctx context.Context, merchantId uint64, subscriptionId string, source string) (*entity.Subscription, error) {
+	sub := query.GetSubscriptionBySubscriptionId(ctx, subscriptionId)
+	utility.Assert(sub != nil, "subscription not found")
+	utility.Assert(sub.MerchantId == merchantId, "wrong merchant account")
+	return ResumeAt(ctx, sub, subscriptionTimeNow(sub), source)
+}
+
+// ResumeAt resumes the paused subscription at the time, the billing cycle walk resumes the subscription due with its time
+func ResumeAt(ctx context.Context, sub *entity.Subscription, timeNow int64, source string) (*entity.Subscription, error) {
+	utility.Assert(sub != nil, "subscription not found")
+	utility.Assert(sub.Status == consts.SubStatusSuspended, "subscription not paused")
+	resumeTime := EffectiveResumeTime(sub, timeNow)
+	periodEnd, trialEnd := ResumedPeriod(sub, resumeTime)
+	shift := periodEnd - sub.CurrentPeriodEnd
+	result, err := dao.Subscription.Ctx(ctx).Data(g.Map{
+		dao.Subscription.Columns().Status:               consts.SubStatusActive,
+		dao.Subscription.Columns().CurrentPeriodEnd:     periodEnd,
+		dao.Subscription.Columns().CurrentPeriodEndTime: gtime.NewFromTimeStamp(periodEnd),
+		dao.Subscription.Columns().TrialEnd:             trialEnd,
+		dao.Subscription.Columns().DunningTime:          period.GetDunningTimeFromEnd(ctx, utility.MaxInt64(periodEnd, trialEnd), sub.PlanId),
+		dao.Subscription.Columns().PauseMode:            "",
+		dao.Subscription.Columns().PauseTime:            0,
+		dao.Subscription.Columns().ResumeTime:           0,
+		dao.Subscription.Columns().GmtModify:            gtime.Now(),
+		dao.Subscription.Columns().LastUpdateTime:       gtime.Now().Timestamp(),
+	}).Where(dao.Subscription.Columns().Id, sub.Id).Where(dao.Subscription.Columns().Status, consts.SubStatusSuspended).OmitNil().Update()
+	if err != nil {
+		return nil, err
+	}
+	affected, _ := result.RowsAffected()
+	if affected != 1 {
+		return nil, fmt.Errorf("subscription %s not paused", sub.SubscriptionId)
+	}
+	if sub.PauseMode == consts.SubPauseModeKeepAsDraft {
+		releaseKeptDraft(ctx, sub, shift)
+	}
+	operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
+		MerchantId:     sub.MerchantId,
+		Target:         fmt.Sprintf("Subscription(%s)", sub.SubscriptionId),
+		Content:        fmt.Sprintf("Resume(%s,PeriodEnd:%d->%d)", source, sub.CurrentPeriodEnd, periodEnd),
+		UserId:         sub.UserId,
+		SubscriptionId: sub.SubscriptionId,
+		InvoiceId:      "",
+		PlanId:         0,
+		DiscountCode:   "",
+	}, err)
+	_, _ = redismq.Send(&redismq.Message{
+		Topic:      redismq2.TopicSubscriptionResumed.Topic,
+		Tag:        redismq2.TopicSubscriptionResumed.Tag,
+		Body:       sub.SubscriptionId,
+		CustomData: map[string]interface{}{"CreateFrom": utility.ReflectCurrentFunctionName(), "Source": source},
+	})
+	_, _ = redismq.Send(&redismq.Message{
+		Topic: redismq2.TopicUserMetricUpdate.Topic,
+		Tag:   redismq2.TopicUserMetricUpdate.Tag,
+		Body: utility.MarshalToJsonString(&metric2.UserMetricUpdateMessage{
+			UserId:         sub.UserId,
+			SubscriptionId: sub.SubscriptionId,
+			Description:    "SubscriptionResumed",
+		}),
+		CustomData: map[string]interface{}{"CreateFrom": utility.ReflectCurrentFunctionName()},
+	})
+	return query.GetSubscriptionBySubscriptionId(ctx, sub.SubscriptionId), nil
+}
+
+// releaseKeptDraft moves the draft kept for the next period with the extended period and releases its hold
+func releaseKeptDraft(ctx context.Context, sub *entity.Subscription, shift int64) {
+	one := query.GetInvoiceByInvoiceId(ctx, sub.LatestInvoiceId)
+	if !draft.IsReviewDraft(one) {
+		return
+	}
+	var data = g.Map{
+		dao.Invoice.Columns().ReviewHold: 0,
+		dao.Invoice.Columns().GmtModify:  gtime.Now(),
+	}
+	if shift > 0 && one.PeriodStart >= sub.CurrentPeriodEnd {
+		data[dao.Invoice.Columns().PeriodStart] = one.PeriodStart + shift
+		data[dao.Invoice.Columns().PeriodEnd] = one.PeriodEnd + shift
+		data[dao.Invoice.Columns().PeriodStartTime] = gtime.NewFromTimeStamp(one.PeriodStart + shift)
+		data[dao.Invoice.Columns().PeriodEndTime] = gtime.NewFromTimeStamp(one.PeriodEnd + shift)
+		data[dao.Invoice.Columns().ReviewFinalizeTime] = one.ReviewFinalizeTime + shift
+	}
+	_, err := dao.Invoice.Ctx(ctx).Data(data).Where(dao.Invoice.Columns().Id, one.Id).Where(dao.Invoice.Columns().Status, consts.InvoiceStatusPending).OmitNil().Update()
+	if err != nil {
+		g.Log().Errorf(ctx, "SubscriptionResume releaseKeptDraft invoiceId:%s err:%s", one.InvoiceId, err.Error())
+	}
+}
@@ -0,0 +1,46 @@
+package pause
+
+import (
+	"unibee/internal/consts"
+	entity "unibee/internal/model/entity/default"
+)
+
+// IsValidPauseMode returns true when the mode is one of the pause billing behaviours
+func IsValidPauseMode(mode string) bool {
+	return mode == consts.SubPauseModeKeepAsDraft || mode == consts.SubPauseModeVoid || mode == consts.SubPauseModeMarkUncollectible
+}
+
+// IsDueForResume returns true when the paused subscription reaches its automatic resume time
+func IsDueForResume(sub *entity.Subscription, timeNow int64) bool {
+	return sub != nil && sub.Status == consts.SubStatusSuspended && sub.ResumeTime > 0 && sub.ResumeTime <= timeNow
+}
+
+// EffectiveResumeTime returns the time the paused subscription continues from, the scheduled resume time when due,
+// so the test clock walking over the resume time resumes the subscription as scheduled
+func EffectiveResumeTime(sub *entity.Subscription, timeNow int64) int64 {
+	if IsDueForResume(sub, timeNow) && sub.ResumeTime >= sub.PauseTime {
+		return sub.ResumeTime
+	}
+	return timeNow
+}
+
+// ResumedPeriod returns the period end and trial end of the paused subscription resumed at the time,
+// the period and trial left when paused are not consumed by the pause and continue from the resume time
+func ResumedPeriod(sub *entity.Subscription, resumeTime int64) (periodEnd int64, trialEnd int64) {
+	if sub == nil {
+		return 0, 0
+	}
+	periodEnd = sub.CurrentPeriodEnd
+	trialEnd = sub.TrialEnd
+	if sub.PauseTime <= 0 || resumeTime <= sub.PauseTime {
+		return
+	}
+	paused := resumeTime - sub.PauseTime
+	if sub.CurrentPeriodEnd > sub.PauseTime {
+		periodEnd = sub.CurrentPeriodEnd + paused
+	}
+	if sub.TrialEnd > sub.PauseTime {
+		trialEnd = sub.TrialEnd + paused
+	}
+	return
+}
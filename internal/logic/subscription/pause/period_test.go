@@ -0,0 +1,58 @@
+package pause
+
+import (
+	"testing"
+
+	"unibee/internal/consts"
+	entity "unibee/internal/model/entity/default"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsValidPauseMode(t *testing.T) {
+	require.True(t, IsValidPauseMode(consts.SubPauseModeKeepAsDraft))
+	require.True(t, IsValidPauseMode(consts.SubPauseModeVoid))
+	require.True(t, IsValidPauseMode(consts.SubPauseModeMarkUncollectible))
+	require.False(t, IsValidPauseMode(""))
+	require.False(t, IsValidPauseMode("cancel"))
+}
+
+func TestIsDueForResume(t *testing.T) {
+	sub := &entity.Subscription{Status: consts.SubStatusSuspended, PauseTime: 1000, ResumeTime: 5000}
+	require.False(t, IsDueForResume(sub, 4999))
+	require.True(t, IsDueForResume(sub, 5000))
+	require.Equal(t, int64(5000), EffectiveResumeTime(sub, 9000))
+	require.Equal(t, int64(3000), EffectiveResumeTime(sub, 3000))
+	// resume manually
+	sub.ResumeTime = 0
+	require.False(t, IsDueForResume(sub, 9000))
+	require.Equal(t, int64(9000), EffectiveResumeTime(sub, 9000))
+	sub.ResumeTime = 5000
+	sub.Status = consts.SubStatusActive
+	require.False(t, IsDueForResume(sub, 9000))
+	require.False(t, IsDueForResume(nil, 9000))
+}
+
+func TestResumedPeriod(t *testing.T) {
+	sub := &entity.Subscription{CurrentPeriodStart: 0, CurrentPeriodEnd: 10000, TrialEnd: 0, PauseTime: 4000}
+	periodEnd, trialEnd := ResumedPeriod(sub, 7000)
+	require.Equal(t, int64(13000), periodEnd)
+	require.Equal(t, int64(0), trialEnd)
+	// the trial left continues after resume as well
+	sub.TrialEnd = 10000
+	periodEnd, trialEnd = ResumedPeriod(sub, 7000)
+	require.Equal(t, int64(13000), periodEnd)
+	require.Equal(t, int64(13000), trialEnd)
+	// paused after the period end, nothing left to extend
+	sub = &entity.Subscription{CurrentPeriodEnd: 10000, TrialEnd: 9999, PauseTime: 12000}
+	periodEnd, trialEnd = ResumedPeriod(sub, 20000)
+	require.Equal(t, int64(10000), periodEnd)
+	require.Equal(t, int64(9999), trialEnd)
+	// resumed before pause time or never paused
+	sub = &entity.Subscription{CurrentPeriodEnd: 10000, PauseTime: 4000}
+	periodEnd, _ = ResumedPeriod(sub, 3000)
+	require.Equal(t, int64(10000), periodEnd)
+	sub.PauseTime = 0
+	periodEnd, _ = ResumedPeriod(sub, 7000)
+	require.Equal(t, int64(10000), periodEnd)
+}
@@ -0,0 +1,149 @@
+package pending_item
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"unibee/internal/consts"
+	"unibee/internal/consumer/webhook/event"
+	webhook "unibee/internal/consumer/webhook/subscription_pending_invoice_item"
+	dao "unibee/internal/dao/default"
+	"unibee/internal/logic/operation_log"
+	entity "unibee/internal/model/entity/default"
+	"unibee/internal/query"
+	"unibee/utility"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+type NewInternalReq struct {
+	MerchantId     uint64
+	SubscriptionId string
+	PlanId         uint64 // plan-based item charged with the plan amount in the subscription currency
+	UnitAmount     *int64 // amount-based item, excluding tax, negative for credit
+	Quantity       int64
+	Name           string
+	Description    string
+	TaxPercentage  *int64 // follow the invoice if not specified
+}
+
+// SubscriptionPendingInvoiceItemNew adds a one-off charge or credit to the next cycle invoice of subscription,
+// either planId or unitAmount needed
+func SubscriptionPendingInvoiceItemNew(ctx context.Context, req *NewInternalReq) (*entity.SubscriptionPendingInvoiceItem, error) {
+	utility.Assert(len(req.SubscriptionId) > 0, "subscriptionId not found")
+	sub := query.GetSubscriptionBySubscriptionId(ctx, req.SubscriptionId)
+	utility.Assert(sub != nil, "subscription not found")
+	utility.Assert(sub.MerchantId == req.MerchantId, "wrong merchant account")
+	utility.Assert(sub.Status != consts.SubStatusCancelled && sub.Status != consts.SubStatusExpired && sub.Status != consts.SubStatusFailed, "subscription already ended")
+	utility.Assert((req.PlanId > 0) != (req.UnitAmount != nil), "either planId or unitAmount needed")
+	if req.Quantity <= 0 {
+		req.Quantity = 1
+	}
+	if req.TaxPercentage != nil {
+		utility.Assert(*req.TaxPercentage >= 0 && *req.TaxPercentage <= 10000, "taxPercentage should between 0 and 10000")
+	}
+	var unitAmount int64
+	var name = strings.TrimSpace(req.Name)
+	if req.PlanId > 0 {
+		plan := query.GetPlanById(ctx, req.PlanId)
+		utility.Assert(plan != nil, "plan not found")
+		utility.Assert(plan.MerchantId == sub.MerchantId, "wrong merchant account")
+		utility.Assert(plan.Status == consts.PlanStatusActive, fmt.Sprintf("Plan Id:%v Not Active", plan.Id))
+		unitAmount = plan.CurrencyAmount(ctx, sub.Currency)
+		if len(name) == 0 {
+			name = plan.PlanName
+		}
+	} else {
+		utility.Assert(*req.UnitAmount != 0, "unitAmount should not be 0")
+		utility.Assert(len(name) > 0, "name needed for the amount-based item")
+		unitAmount = *req.UnitAmount
+	}
+	var description = req.Description
+	if len(description) == 0 {
+		description = fmt.Sprintf("%d * %s", req.Quantity, name)
+	}
+	one, err := CreatePendingInvoiceItem(ctx, &CreateInternalReq{
+		Subscription:  sub,
+		PlanId:        req.PlanId,
+		Name:          name,
+		Description:   description,
+		UnitAmount:    unitAmount,
+		Quantity:      req.Quantity,
+		TaxPercentage: req.TaxPercentage,
+		Source:        consts.PendingInvoiceItemSourceMerchant,
+	})
+	var itemId = ""
+	if one != nil {
+		itemId = one.ItemId
+	}
+	operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
+		MerchantId:     sub.MerchantId,
+		Target:         fmt.Sprintf("Subscription(%s)", sub.SubscriptionId),
+		Content:        fmt.Sprintf("NewPendingInvoiceItem(%s,%d*%d)", itemId, req.Quantity, unitAmount),
+		UserId:         sub.UserId,
+		SubscriptionId: sub.SubscriptionId,
+		InvoiceId:      "",
+		PlanId:         req.PlanId,
+		DiscountCode:   "",
+	}, err)
+	return one, err
+}
+
+// SubscriptionPendingInvoiceItemDelete removes the item not charged yet, the item merged into an invoice waiting for payment can not be removed
+func SubscriptionPendingInvoiceItemDelete(ctx context.Context, merchantId uint64, itemId string) error {
+	one := query.GetSubscriptionPendingInvoiceItemByItemId(ctx, itemId)
+	utility.Assert(one != nil, "pending invoice item not found")
+	utility.Assert(one.MerchantId == merchantId, "wrong merchant account")
+	var invoiceStatus = 0
+	if len(one.InvoiceId) > 0 {
+		if invoice := query.GetInvoiceByInvoiceId(ctx, one.InvoiceId); invoice != nil {
+			invoiceStatus = invoice.Status
+		}
+	}
+	utility.Assert(IsUnbilled(one.Status, invoiceStatus), "pending invoice item already invoiced")
+	_, err := dao.SubscriptionPendingInvoiceItem.Ctx(ctx).Data(g.Map{
+		dao.SubscriptionPendingInvoiceItem.Columns().IsDeleted: 1,
+		dao.SubscriptionPendingInvoiceItem.Columns().GmtModify: gtime.Now(),
+	}).Where(dao.SubscriptionPendingInvoiceItem.Columns().Id, one.Id).
+		Where(dao.SubscriptionPendingInvoiceItem.Columns().Status, one.Status).
+		OmitNil().Update()
+	operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
+		MerchantId:     one.MerchantId,
+		Target:         fmt.Sprintf("Subscription(%s)", one.SubscriptionId),
+		Content:        fmt.Sprintf("DeletePendingInvoiceItem(%s)", one.ItemId),
+		UserId:         one.UserId,
+		SubscriptionId: one.SubscriptionId,
+		InvoiceId:      "",
+		PlanId:         one.PlanId,
+		DiscountCode:   "",
+	}, err)
+	if err != nil {
+		return err
+	}
+	one.IsDeleted = 1
+	webhook.SendMerchantSubscriptionPendingInvoiceItemWebhookBackground(one, event.UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_PENDING_INVOICE_ITEM_DELETED)
+	return nil
+}
+
+// SubscriptionPendingInvoiceItemList returns the pending invoice items of subscription, filtered by status if specified
+func SubscriptionPendingInvoiceItemList(ctx context.Context, merchantId uint64, subscriptionId string, status []int, page int, count int) ([]*entity.SubscriptionPendingInvoiceItem, int, error) {
+	var list []*entity.SubscriptionPendingInvoiceItem
+	var total = 0
+	q := dao.SubscriptionPendingInvoiceItem.Ctx(ctx).
+		Where(dao.SubscriptionPendingInvoiceItem.Columns().MerchantId, merchantId).
+		Where(dao.SubscriptionPendingInvoiceItem.Columns().SubscriptionId, subscriptionId).
+		Where(dao.SubscriptionPendingInvoiceItem.Columns().IsDeleted, 0)
+	if len(status) > 0 {
+		q = q.WhereIn(dao.SubscriptionPendingInvoiceItem.Columns().Status, status)
+	}
+	if count > 0 {
+		q = q.Limit(page*count, count)
+	}
+	err := q.OrderDesc(dao.SubscriptionPendingInvoiceItem.Columns().Id).ScanAndCount(&list, &total, true)
+	if err != nil {
+		return nil, 0, err
+	}
+	return list, total, nil
+}
@@ -0,0 +1,109 @@
+package pending_item
+
+import (
+	"context"
+	"testing"
+
+	"unibee/internal/consts"
+	"unibee/internal/query"
+	"unibee/test"
+	"unibee/utility/unibee"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubscriptionPendingInvoiceItem(t *testing.T) {
+	if !test.EnableAutomaticTest {
+		t.Skip("Skipping pending invoice item test - requires the test database")
+	}
+	ctx := context.Background()
+	sub := query.GetLatestActiveOrIncompleteOrCreateSubscriptionByUserId(ctx, test.TestUser.Id, test.TestMerchant.Id, 0)
+	if sub == nil {
+		t.Skip("Skipping pending invoice item test - requires a subscription of the test user")
+	}
+	var creditItemId string
+	var planItemId string
+	t.Run("Test for pending invoice item new", func(t *testing.T) {
+		require.Panics(t, func() {
+			_, _ = SubscriptionPendingInvoiceItemNew(ctx, &NewInternalReq{
+				MerchantId:     sub.MerchantId,
+				SubscriptionId: sub.SubscriptionId,
+				PlanId:         test.TestPlan.Id,
+				UnitAmount:     unibee.Int64(100),
+			})
+		})
+		require.Panics(t, func() {
+			_, _ = SubscriptionPendingInvoiceItemNew(ctx, &NewInternalReq{
+				MerchantId:     sub.MerchantId,
+				SubscriptionId: sub.SubscriptionId,
+				UnitAmount:     unibee.Int64(-100),
+			})
+		})
+		require.Panics(t, func() {
+			_, _ = SubscriptionPendingInvoiceItemNew(ctx, &NewInternalReq{
+				MerchantId:     sub.MerchantId + 1,
+				SubscriptionId: sub.SubscriptionId,
+				UnitAmount:     unibee.Int64(-100),
+				Name:           "Credit",
+			})
+		})
+		credit, err := SubscriptionPendingInvoiceItemNew(ctx, &NewInternalReq{
+			MerchantId:     sub.MerchantId,
+			SubscriptionId: sub.SubscriptionId,
+			UnitAmount:     unibee.Int64(-500),
+			Name:           "Credit",
+		})
+		require.Nil(t, err)
+		require.Equal(t, int64(-500), credit.Amount)
+		require.Equal(t, int64(1), credit.Quantity)
+		require.Equal(t, int64(-1), credit.TaxPercentage)
+		require.Equal(t, consts.PendingInvoiceItemStatusPending, credit.Status)
+		require.Equal(t, consts.PendingInvoiceItemSourceMerchant, credit.Source)
+		creditItemId = credit.ItemId
+
+		planItem, err := SubscriptionPendingInvoiceItemNew(ctx, &NewInternalReq{
+			MerchantId:     sub.MerchantId,
+			SubscriptionId: sub.SubscriptionId,
+			PlanId:         test.TestPlan.Id,
+			Quantity:       2,
+			TaxPercentage:  unibee.Int64(0),
+		})
+		require.Nil(t, err)
+		require.Equal(t, test.TestPlan.PlanName, planItem.Name)
+		require.Equal(t, planItem.UnitAmount*2, planItem.Amount)
+		require.Equal(t, int64(0), planItem.TaxPercentage)
+		planItemId = planItem.ItemId
+	})
+	t.Run("Test for pending invoice item list", func(t *testing.T) {
+		list, total, err := SubscriptionPendingInvoiceItemList(ctx, sub.MerchantId, sub.SubscriptionId, []int{consts.PendingInvoiceItemStatusPending}, 0, 100)
+		require.Nil(t, err)
+		require.True(t, total >= 2)
+		var itemIds = make(map[string]bool)
+		for _, one := range list {
+			require.Equal(t, consts.PendingInvoiceItemStatusPending, one.Status)
+			itemIds[one.ItemId] = true
+		}
+		require.True(t, itemIds[creditItemId])
+		require.True(t, itemIds[planItemId])
+		list, _, err = SubscriptionPendingInvoiceItemList(ctx, sub.MerchantId+1, sub.SubscriptionId, nil, 0, 100)
+		require.Nil(t, err)
+		require.Len(t, list, 0)
+	})
+	t.Run("Test for pending invoice item delete", func(t *testing.T) {
+		require.Panics(t, func() {
+			_ = SubscriptionPendingInvoiceItemDelete(ctx, sub.MerchantId+1, creditItemId)
+		})
+		require.Nil(t, SubscriptionPendingInvoiceItemDelete(ctx, sub.MerchantId, creditItemId))
+		require.Nil(t, SubscriptionPendingInvoiceItemDelete(ctx, sub.MerchantId, planItemId))
+		require.Nil(t, query.GetSubscriptionPendingInvoiceItemByItemId(ctx, creditItemId))
+		require.Panics(t, func() {
+			_ = SubscriptionPendingInvoiceItemDelete(ctx, sub.MerchantId, creditItemId)
+		})
+		list, _, err := SubscriptionPendingInvoiceItemList(ctx, sub.MerchantId, sub.SubscriptionId, nil, 0, 100)
+		require.Nil(t, err)
+		for _, one := range list {
+			require.NotEqual(t, creditItemId, one.ItemId)
+			require.NotEqual(t, planItemId, one.ItemId)
+		}
+	})
+}
@@ -0,0 +1,222 @@
+package pending_item
+
+import (
+	"context"
+
+	"unibee/internal/consts"
+	"unibee/internal/consumer/webhook/event"
+	webhook "unibee/internal/consumer/webhook/subscription_pending_invoice_item"
+	dao "unibee/internal/dao/default"
+	"unibee/internal/logic/invoice/invoice_compute"
+	entity "unibee/internal/model/entity/default"
+	"unibee/internal/query"
+	"unibee/utility"
+	"unibee/utility/unibee"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+type CreateInternalReq struct {
+	Subscription    *entity.Subscription
+	PlanId          uint64
+	Name            string
+	Description     string
+	UnitAmount      int64 // excluding tax, negative for credit
+	Quantity        int64
+	Proration       bool
+	TaxPercentage   *int64 // follow the invoice if not specified
+	Source          string
+	PendingUpdateId string
+}
+
+// CreatePendingInvoiceItem adds the item to the next cycle invoice of subscription
+func CreatePendingInvoiceItem(ctx context.Context, req *CreateInternalReq) (*entity.SubscriptionPendingInvoiceItem, error) {
+	utility.Assert(req.Subscription != nil, "subscription not found")
+	utility.Assert(req.Quantity > 0, "quantity should be greater than 0")
+	var proration = 0
+	if req.Proration {
+		proration = 1
+	}
+	var taxPercentage int64 = -1
+	if req.TaxPercentage != nil {
+		taxPercentage = *req.TaxPercentage
+	}
+	one := &entity.SubscriptionPendingInvoiceItem{
+		MerchantId:      req.Subscription.MerchantId,
+		UserId:          req.Subscription.UserId,
+		SubscriptionId:  req.Subscription.SubscriptionId,
+		ItemId:          utility.CreatePendingInvoiceItemId(),
+		PlanId:          req.PlanId,
+		Name:            req.Name,
+		Description:     req.Description,
+		Currency:        req.Subscription.Currency,
+		UnitAmount:      req.UnitAmount,
+		Quantity:        req.Quantity,
+		Amount:          req.UnitAmount * req.Quantity,
+		Proration:       proration,
+		TaxPercentage:   taxPercentage,
+		Source:          req.Source,
+		PendingUpdateId: req.PendingUpdateId,
+		Status:          consts.PendingInvoiceItemStatusPending,
+		CreateTime:      gtime.Now().Timestamp(),
+	}
+	_, err := dao.SubscriptionPendingInvoiceItem.Ctx(ctx).Data(one).OmitNil().Insert(one)
+	if err != nil {
+		return nil, err
+	}
+	webhook.SendMerchantSubscriptionPendingInvoiceItemWebhookBackground(one, event.UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_PENDING_INVOICE_ITEM_CREATED)
+	return one, nil
+}
+
+// IsUnbilled returns true when the item is not merged into any invoice, or the invoice merged into is closed without paid,
+// the unbilled items roll into the next cycle invoice
+func IsUnbilled(itemStatus int, invoiceStatus int) bool {
+	if itemStatus == consts.PendingInvoiceItemStatusPending {
+		return true
+	}
+	return itemStatus == consts.PendingInvoiceItemStatusInvoiced &&
+		(invoiceStatus == consts.InvoiceStatusCancelled || invoiceStatus == consts.InvoiceStatusFailed)
+}
+
+// GetUnbilledItems returns the pending invoice items of subscription to merge into its next cycle invoice
+func GetUnbilledItems(ctx context.Context, sub *entity.Subscription) []*entity.SubscriptionPendingInvoiceItem {
+	var list = make([]*entity.SubscriptionPendingInvoiceItem, 0)
+	if sub == nil {
+		return list
+	}
+	var invoiceStatus = make(map[string]int)
+	for _, one := range query.GetSubscriptionPendingInvoiceItemsByStatus(ctx, sub.SubscriptionId, []int{consts.PendingInvoiceItemStatusPending, consts.PendingInvoiceItemStatusInvoiced}) {
+		if one.Currency != sub.Currency {
+			continue
+		}
+		if one.Status == consts.PendingInvoiceItemStatusInvoiced {
+			if _, ok := invoiceStatus[one.InvoiceId]; !ok {
+				invoiceStatus[one.InvoiceId] = consts.InvoiceStatusCancelled
+				if invoice := query.GetInvoiceByInvoiceId(ctx, one.InvoiceId); invoice != nil {
+					invoiceStatus[one.InvoiceId] = invoice.Status
+				}
+			}
+		}
+		if IsUnbilled(one.Status, invoiceStatus[one.InvoiceId]) {
+			list = append(list, one)
+		}
+	}
+	return list
+}
+
+// NextInvoiceItems returns the unbilled pending invoice items of subscription, charged with its next cycle invoice,
+// and the ids of the items
+func NextInvoiceItems(ctx context.Context, sub *entity.Subscription) ([]*invoice_compute.AdditionalInvoiceItem, []string) {
+	var list = make([]*invoice_compute.AdditionalInvoiceItem, 0)
+	var itemIds = make([]string, 0)
+	for _, one := range GetUnbilledItems(ctx, sub) {
+		var taxPercentage *int64
+		if one.TaxPercentage >= 0 {
+			taxPercentage = unibee.Int64(one.TaxPercentage)
+		}
+		list = append(list, &invoice_compute.AdditionalInvoiceItem{
+			Name:                   one.Name,
+			Description:            one.Description,
+			UnitAmountExcludingTax: one.UnitAmount,
+			Quantity:               one.Quantity,
+			Proration:              one.Proration == 1,
+			PlanId:                 one.PlanId,
+			TaxPercentage:          taxPercentage,
+		})
+		itemIds = append(itemIds, one.ItemId)
+	}
+	return list, itemIds
+}
+
+// MarkInvoiced links the pending invoice items merged into the cycle invoice to it,
+// the items roll into the next cycle invoice again if the invoice closed without paid,
+// items are the invoice items of itemIds by index, the credit over the invoice amount carries forward
+func MarkInvoiced(ctx context.Context, sub *entity.Subscription, invoiceId string, itemIds []string, items []*invoice_compute.AdditionalInvoiceItem) {
+	if len(itemIds) == 0 {
+		return
+	}
+	var invoiceTime = gtime.Now().Timestamp()
+	_, err := dao.SubscriptionPendingInvoiceItem.Ctx(ctx).Data(g.Map{
+		dao.SubscriptionPendingInvoiceItem.Columns().Status:      consts.PendingInvoiceItemStatusInvoiced,
+		dao.SubscriptionPendingInvoiceItem.Columns().InvoiceId:   invoiceId,
+		dao.SubscriptionPendingInvoiceItem.Columns().InvoiceTime: invoiceTime,
+		dao.SubscriptionPendingInvoiceItem.Columns().GmtModify:   gtime.Now(),
+	}).Where(dao.SubscriptionPendingInvoiceItem.Columns().SubscriptionId, sub.SubscriptionId).
+		WhereIn(dao.SubscriptionPendingInvoiceItem.Columns().ItemId, itemIds).
+		WhereIn(dao.SubscriptionPendingInvoiceItem.Columns().Status, []int{consts.PendingInvoiceItemStatusPending, consts.PendingInvoiceItemStatusInvoiced}).
+		OmitNil().Update()
+	if err != nil {
+		g.Log().Errorf(ctx, "MarkInvoiced subscriptionId:%s invoiceId:%s err:%s", sub.SubscriptionId, invoiceId, err.Error())
+		return
+	}
+	for i, item := range items {
+		if i < len(itemIds) && item.CarryForwardAmount < 0 {
+			carryForwardCredit(ctx, sub, invoiceId, itemIds[i], item)
+		}
+	}
+	var list []*entity.SubscriptionPendingInvoiceItem
+	err = dao.SubscriptionPendingInvoiceItem.Ctx(ctx).
+		Where(dao.SubscriptionPendingInvoiceItem.Columns().InvoiceId, invoiceId).
+		WhereIn(dao.SubscriptionPendingInvoiceItem.Columns().ItemId, itemIds).
+		Scan(&list)
+	if err != nil {
+		g.Log().Errorf(ctx, "MarkInvoiced subscriptionId:%s invoiceId:%s query err:%s", sub.SubscriptionId, invoiceId, err.Error())
+		return
+	}
+	for _, one := range list {
+		webhook.SendMerchantSubscriptionPendingInvoiceItemWebhookBackground(one, event.UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_PENDING_INVOICE_ITEM_INVOICED)
+	}
+}
+
+// carryForwardCredit splits the credit item capped by the cycle invoice, the item merged into the invoice keeps the credit applied
+// and the rest adds to the next cycle invoice as a new pending invoice item, both roll back on their own if their invoice closed without paid
+func carryForwardCredit(ctx context.Context, sub *entity.Subscription, invoiceId string, itemId string, item *invoice_compute.AdditionalInvoiceItem) {
+	one := query.GetSubscriptionPendingInvoiceItemByItemId(ctx, itemId)
+	if one == nil || one.InvoiceId != invoiceId {
+		return
+	}
+	result, err := dao.SubscriptionPendingInvoiceItem.Ctx(ctx).Data(g.Map{
+		dao.SubscriptionPendingInvoiceItem.Columns().UnitAmount: item.UnitAmountExcludingTax,
+		dao.SubscriptionPendingInvoiceItem.Columns().Quantity:   item.Quantity,
+		dao.SubscriptionPendingInvoiceItem.Columns().Amount:     item.UnitAmountExcludingTax * item.Quantity,
+		dao.SubscriptionPendingInvoiceItem.Columns().GmtModify:  gtime.Now(),
+	}).Where(dao.SubscriptionPendingInvoiceItem.Columns().Id, one.Id).
+		Where(dao.SubscriptionPendingInvoiceItem.Columns().InvoiceId, invoiceId).
+		Where(dao.SubscriptionPendingInvoiceItem.Columns().Amount, one.Amount).
+		OmitNil().Update()
+	if err != nil {
+		g.Log().Errorf(ctx, "carryForwardCredit itemId:%s invoiceId:%s err:%s", itemId, invoiceId, err.Error())
+		return
+	}
+	if affected, _ := result.RowsAffected(); affected != 1 {
+		return
+	}
+	var taxPercentage *int64
+	if one.TaxPercentage >= 0 {
+		taxPercentage = unibee.Int64(one.TaxPercentage)
+	}
+	_, err = CreatePendingInvoiceItem(ctx, &CreateInternalReq{
+		Subscription:    sub,
+		PlanId:          one.PlanId,
+		Name:            one.Name,
+		Description:     one.Description,
+		UnitAmount:      item.CarryForwardAmount,
+		Quantity:        1,
+		Proration:       one.Proration == 1,
+		TaxPercentage:   taxPercentage,
+		Source:          one.Source,
+		PendingUpdateId: one.PendingUpdateId,
+	})
+	if err != nil {
+		g.Log().Errorf(ctx, "carryForwardCredit itemId:%s invoiceId:%s create err:%s", itemId, invoiceId, err.Error())
+	}
+}
+
+// IsValidProrationBehavior returns true when the proration behavior is one of create_prorations|prorate_to_next_invoice|none|always_invoice
+func IsValidProrationBehavior(behavior string) bool {
+	return behavior == consts.ProrationBehaviorCreateProrations ||
+		behavior == consts.ProrationBehaviorProrateToNextInvoice ||
+		behavior == consts.ProrationBehaviorNone ||
+		behavior == consts.ProrationBehaviorAlwaysInvoice
+}
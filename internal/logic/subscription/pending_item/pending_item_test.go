@@ -0,0 +1,28 @@
+package pending_item
+
+import (
+	"testing"
+
+	"unibee/internal/consts"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsValidProrationBehavior(t *testing.T) {
+	require.True(t, IsValidProrationBehavior(consts.ProrationBehaviorCreateProrations))
+	require.True(t, IsValidProrationBehavior(consts.ProrationBehaviorProrateToNextInvoice))
+	require.True(t, IsValidProrationBehavior(consts.ProrationBehaviorNone))
+	require.True(t, IsValidProrationBehavior(consts.ProrationBehaviorAlwaysInvoice))
+	require.False(t, IsValidProrationBehavior(""))
+	require.False(t, IsValidProrationBehavior("immediate"))
+}
+
+func TestIsUnbilled(t *testing.T) {
+	require.True(t, IsUnbilled(consts.PendingInvoiceItemStatusPending, 0))
+	// merged into the invoice waiting for payment or paid
+	require.False(t, IsUnbilled(consts.PendingInvoiceItemStatusInvoiced, consts.InvoiceStatusProcessing))
+	require.False(t, IsUnbilled(consts.PendingInvoiceItemStatusInvoiced, consts.InvoiceStatusPaid))
+	// the invoice closed without paid, roll into the next cycle invoice
+	require.True(t, IsUnbilled(consts.PendingInvoiceItemStatusInvoiced, consts.InvoiceStatusCancelled))
+	require.True(t, IsUnbilled(consts.PendingInvoiceItemStatusInvoiced, consts.InvoiceStatusFailed))
+}
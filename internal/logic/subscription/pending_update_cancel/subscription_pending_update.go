@@ -16,6 +16,20 @@ import (
 	"unibee/utility"
 )
 
+// IsWaitingForCommitmentTermEnd returns true when the pending update is the downgrade noticed during the commitment term
+// and takes effect later than the cycle starting at the periodStart
+func IsWaitingForCommitmentTermEnd(one *entity.SubscriptionPendingUpdate, periodStart int64) bool {
+	if one == nil || one.EffectImmediate == 1 || len(one.MetaData) == 0 {
+		return false
+	}
+	var metadata = make(map[string]interface{})
+	_ = utility.UnmarshalFromJsonString(one.MetaData, &metadata)
+	if _, ok := metadata["CommitmentEffectTime"]; !ok {
+		return false
+	}
+	return one.EffectTime > periodStart
+}
+
 func SubscriptionPendingUpdateCancel(ctx context.Context, pendingUpdateId string, reason string) error {
 	one := query.GetSubscriptionPendingUpdateByPendingUpdateId(ctx, pendingUpdateId)
 	if one != nil {
@@ -0,0 +1,94 @@
+package schedule
+
+import (
+	"context"
+	"fmt"
+
+	"unibee/api/bean"
+	"unibee/internal/consts"
+	"unibee/internal/logic/plan/period"
+	"unibee/internal/logic/subscription/commitment/term"
+	"unibee/internal/logic/subscription/service"
+	entity "unibee/internal/model/entity/default"
+	"unibee/internal/query"
+	"unibee/utility"
+
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+// CheckScheduleCommitment returns error when the active schedule of the subscription downgrades or cancels it during the commitment term,
+// the schedule applies its phases and end behavior by the billing cycle walk without the commitment checked again
+func CheckScheduleCommitment(ctx context.Context, sub *entity.Subscription, commitment *entity.SubscriptionCommitment) error {
+	one := query.GetActiveSubscriptionScheduleBySubscriptionId(ctx, sub.SubscriptionId)
+	if one == nil {
+		return nil
+	}
+	phases := query.GetSubscriptionSchedulePhases(ctx, one.ScheduleId)
+	var params = make([]*bean.SubscriptionSchedulePhaseParam, 0)
+	for _, phase := range phases {
+		if phase.PhaseIndex > one.CurrentPhase && phase.Status == consts.SubSchedulePhaseStatusPending {
+			params = append(params, phaseParam(phase))
+		}
+	}
+	return checkCommitment(ctx, sub, commitment, params, one.CurrentPhase+1, nextPhaseStart(ctx, sub, one, findPhase(phases, one.CurrentPhase)), one.EndBehavior)
+}
+
+// nextPhaseStart returns the start of the billing cycle the phase following the current phase starts with
+func nextPhaseStart(ctx context.Context, sub *entity.Subscription, one *entity.SubscriptionSchedule, current *entity.SubscriptionSchedulePhase) int64 {
+	var periodStart = utility.MaxInt64(sub.CurrentPeriodEnd, sub.TrialEnd)
+	if one == nil || one.CurrentPhase == 0 || current == nil {
+		return periodStart
+	}
+	if one.LastPeriodStart >= periodStart {
+		// the upcoming billing cycle walked already
+		periodStart = period.GetPeriodEndFromStart(ctx, one.LastPeriodStart, sub.BillingCycleAnchor, current.PlanId)
+	}
+	for count := one.PhaseCycleCount; count < current.Cycles; count++ {
+		periodStart = period.GetPeriodEndFromStart(ctx, periodStart, sub.BillingCycleAnchor, current.PlanId)
+	}
+	return periodStart
+}
+
+// checkCommitment returns error when a phase downgrades the subscription or the schedule cancels it during the commitment term,
+// the phases start one after another from periodStart by their cycles
+func checkCommitment(ctx context.Context, sub *entity.Subscription, commitment *entity.SubscriptionCommitment, params []*bean.SubscriptionSchedulePhaseParam, startIndex int, periodStart int64, endBehavior string) error {
+	var timeNow = utility.MaxInt64(gtime.Now().Timestamp(), sub.TestClock)
+	if !term.IsInTerm(commitment, timeNow) {
+		return nil
+	}
+	for i, param := range params {
+		plan := query.GetPlanById(ctx, param.PlanId)
+		var quantity = param.Quantity
+		if quantity <= 0 {
+			quantity = 1
+		}
+		if plan != nil && service.IsDowngradeForSubscription(ctx, sub, plan, quantity, param.AddonParams) {
+			effectTime, err := term.CheckDowngrade(commitment, periodStart, false, timeNow)
+			if err != nil {
+				return fmt.Errorf("phase %d: %s", startIndex+i, err.Error())
+			}
+			if effectTime > periodStart {
+				// the phase starts on schedule, it can not wait for the term end
+				return fmt.Errorf("phase %d: downgrade not available during the commitment term until %s", startIndex+i, gtime.NewFromTimeStamp(commitment.TermEnd).Format("Y-m-d"))
+			}
+		}
+		if param.Cycles == 0 {
+			// the last phase renews without end
+			return nil
+		}
+		// the checks only differ before the term end
+		for count := 0; count < param.Cycles && periodStart < commitment.TermEnd; count++ {
+			periodStart = period.GetPeriodEndFromStart(ctx, periodStart, sub.BillingCycleAnchor, param.PlanId)
+		}
+	}
+	if endBehavior == consts.SubScheduleEndBehaviorCancel {
+		atTermEnd, err := term.CheckCancelAtPeriodEnd(commitment, periodStart, timeNow)
+		if err != nil {
+			return err
+		}
+		if atTermEnd {
+			return fmt.Errorf("subscription committed until %s, the schedule can not cancel it before the term end", gtime.NewFromTimeStamp(commitment.TermEnd).Format("Y-m-d"))
+		}
+	}
+	return nil
+}
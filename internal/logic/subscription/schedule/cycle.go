@@ -0,0 +1,214 @@
+package schedule
+
+import (
+	"context"
+	"fmt"
+
+	redismq2 "unibee/internal/cmd/redismq"
+	"unibee/internal/consts"
+	dao "unibee/internal/dao/default"
+	"unibee/internal/logic/operation_log"
+	"unibee/internal/logic/subscription/pending_update_cancel"
+	"unibee/internal/logic/subscription/service"
+	entity "unibee/internal/model/entity/default"
+	"unibee/internal/query"
+	"unibee/utility"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+	redismq "github.com/jackyang-hk/go-redismq"
+)
+
+// PrepareNextCycle walks the active schedule of the subscription to the billing cycle starting at periodStart before its invoice generated,
+// the phase starting with the cycle is applied as a pending update effect at period end,
+// returns false when the schedule ends with the subscription cancelled at period end and no invoice need generate
+func PrepareNextCycle(ctx context.Context, sub *entity.Subscription, periodStart int64) (*entity.Subscription, bool, error) {
+	one := query.GetActiveSubscriptionScheduleBySubscriptionId(ctx, sub.SubscriptionId)
+	if one == nil {
+		return sub, true, nil
+	}
+	phases := query.GetSubscriptionSchedulePhases(ctx, one.ScheduleId)
+	action, target := NextCycle(one, phases, periodStart)
+	switch action {
+	case CycleActionPrepared:
+		if target != nil && target.StartTime == periodStart && (len(target.PendingUpdateId) == 0 || isPendingUpdateCancelled(ctx, target.PendingUpdateId)) {
+			// the pending update of the phase not applied, the regenerated invoice applies the phase again
+			err := applyPhase(ctx, sub, one, target, periodStart)
+			if err != nil {
+				return sub, true, err
+			}
+		}
+		return query.GetSubscriptionBySubscriptionId(ctx, sub.SubscriptionId), true, nil
+	case CycleActionContinue:
+		ApplyCycle(one, phases, action, target, periodStart)
+		err := saveScheduleCycle(ctx, one, periodStart)
+		return sub, true, err
+	case CycleActionEnterPhase:
+		var previous = findPhase(phases, one.CurrentPhase)
+		var fromPlanId = sub.PlanId
+		ApplyCycle(one, phases, action, target, periodStart)
+		err := saveScheduleCycle(ctx, one, periodStart)
+		if err != nil {
+			return sub, true, err
+		}
+		err = completePhase(ctx, previous, periodStart)
+		if err != nil {
+			return sub, true, err
+		}
+		_, err = dao.SubscriptionSchedulePhase.Ctx(ctx).Data(g.Map{
+			dao.SubscriptionSchedulePhase.Columns().Status:    consts.SubSchedulePhaseStatusActive,
+			dao.SubscriptionSchedulePhase.Columns().StartTime: periodStart,
+			dao.SubscriptionSchedulePhase.Columns().GmtModify: gtime.Now(),
+		}).Where(dao.SubscriptionSchedulePhase.Columns().Id, target.Id).OmitNil().Update()
+		if err != nil {
+			return sub, true, err
+		}
+		err = applyPhase(ctx, sub, one, target, periodStart)
+		if err != nil {
+			return sub, true, err
+		}
+		operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
+			MerchantId:     one.MerchantId,
+			Target:         fmt.Sprintf("SubscriptionSchedule(%s)", one.ScheduleId),
+			Content:        fmt.Sprintf("StartPhase(%d)(%d->%d)", target.PhaseIndex, fromPlanId, target.PlanId),
+			UserId:         one.UserId,
+			SubscriptionId: one.SubscriptionId,
+			InvoiceId:      "",
+			PlanId:         target.PlanId,
+			DiscountCode:   target.DiscountCode,
+		}, err)
+		return query.GetSubscriptionBySubscriptionId(ctx, sub.SubscriptionId), true, nil
+	default:
+		var previous = findPhase(phases, one.CurrentPhase)
+		ApplyCycle(one, phases, action, target, periodStart)
+		err := saveScheduleCycle(ctx, one, periodStart)
+		if err != nil {
+			return sub, true, err
+		}
+		err = completePhase(ctx, previous, periodStart)
+		if err != nil {
+			return sub, true, err
+		}
+		operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
+			MerchantId:     one.MerchantId,
+			Target:         fmt.Sprintf("SubscriptionSchedule(%s)", one.ScheduleId),
+			Content:        fmt.Sprintf("Complete(%s)", one.EndBehavior),
+			UserId:         one.UserId,
+			SubscriptionId: one.SubscriptionId,
+			InvoiceId:      "",
+			PlanId:         0,
+			DiscountCode:   "",
+		}, err)
+		if one.EndBehavior == consts.SubScheduleEndBehaviorCancel {
+			err = service.SubscriptionCancelAtPeriodEndBySystem(ctx, sub.SubscriptionId, one.MerchantMemberId)
+			if err != nil {
+				return sub, true, err
+			}
+			return query.GetSubscriptionBySubscriptionId(ctx, sub.SubscriptionId), false, nil
+		}
+		return sub, true, nil
+	}
+}
+
+func completePhase(ctx context.Context, phase *entity.SubscriptionSchedulePhase, periodStart int64) error {
+	if phase == nil {
+		return nil
+	}
+	_, err := dao.SubscriptionSchedulePhase.Ctx(ctx).Data(g.Map{
+		dao.SubscriptionSchedulePhase.Columns().Status:    consts.SubSchedulePhaseStatusCompleted,
+		dao.SubscriptionSchedulePhase.Columns().EndTime:   periodStart,
+		dao.SubscriptionSchedulePhase.Columns().GmtModify: gtime.Now(),
+	}).Where(dao.SubscriptionSchedulePhase.Columns().Id, phase.Id).OmitNil().Update()
+	return err
+}
+
+// applyPhase binds the pending update applying the phase to the subscription
+func applyPhase(ctx context.Context, sub *entity.Subscription, one *entity.SubscriptionSchedule, phase *entity.SubscriptionSchedulePhase, periodStart int64) error {
+	pendingUpdate, err := createPhasePendingUpdate(ctx, sub, one, phase, periodStart)
+	if err != nil {
+		return err
+	}
+	_, err = dao.SubscriptionSchedulePhase.Ctx(ctx).Data(g.Map{
+		dao.SubscriptionSchedulePhase.Columns().PendingUpdateId: pendingUpdate.PendingUpdateId,
+		dao.SubscriptionSchedulePhase.Columns().GmtModify:       gtime.Now(),
+	}).Where(dao.SubscriptionSchedulePhase.Columns().Id, phase.Id).OmitNil().Update()
+	return err
+}
+
+func isPendingUpdateCancelled(ctx context.Context, pendingUpdateId string) bool {
+	pendingUpdate := query.GetSubscriptionPendingUpdateByPendingUpdateId(ctx, pendingUpdateId)
+	return pendingUpdate != nil && pendingUpdate.Status == consts.PendingSubStatusCancelled
+}
+
+// saveScheduleCycle stores the schedule state walked to the billing cycle, guarded by the last period start walked
+func saveScheduleCycle(ctx context.Context, one *entity.SubscriptionSchedule, periodStart int64) error {
+	result, err := dao.SubscriptionSchedule.Ctx(ctx).Data(g.Map{
+		dao.SubscriptionSchedule.Columns().Status:          one.Status,
+		dao.SubscriptionSchedule.Columns().CurrentPhase:    one.CurrentPhase,
+		dao.SubscriptionSchedule.Columns().PhaseCycleCount: one.PhaseCycleCount,
+		dao.SubscriptionSchedule.Columns().LastPeriodStart: one.LastPeriodStart,
+		dao.SubscriptionSchedule.Columns().StartTime:       one.StartTime,
+		dao.SubscriptionSchedule.Columns().EndTime:         one.EndTime,
+		dao.SubscriptionSchedule.Columns().GmtModify:       gtime.Now(),
+	}).Where(dao.SubscriptionSchedule.Columns().Id, one.Id).
+		Where(dao.SubscriptionSchedule.Columns().Status, consts.SubScheduleStatusActive).
+		WhereLT(dao.SubscriptionSchedule.Columns().LastPeriodStart, periodStart).
+		OmitNil().Update()
+	if err != nil {
+		return err
+	}
+	affected, _ := result.RowsAffected()
+	if affected != 1 {
+		return fmt.Errorf("schedule %s walked by others, try again", one.ScheduleId)
+	}
+	return nil
+}
+
+// createPhasePendingUpdate applies the phase to the subscription as a pending update effect at period end,
+// the cycle invoice of the subscription is generated from the pending update
+func createPhasePendingUpdate(ctx context.Context, sub *entity.Subscription, one *entity.SubscriptionSchedule, phase *entity.SubscriptionSchedulePhase, periodStart int64) (*entity.SubscriptionPendingUpdate, error) {
+	pendingUpdate := &entity.SubscriptionPendingUpdate{
+		MerchantId:       sub.MerchantId,
+		GatewayId:        sub.GatewayId,
+		UserId:           sub.UserId,
+		SubscriptionId:   sub.SubscriptionId,
+		PendingUpdateId:  utility.CreatePendingUpdateId(),
+		Amount:           sub.Amount,
+		Currency:         sub.Currency,
+		PlanId:           sub.PlanId,
+		Quantity:         sub.Quantity,
+		AddonData:        sub.AddonData,
+		UpdateCurrency:   sub.Currency,
+		UpdatePlanId:     phase.PlanId,
+		UpdateQuantity:   phase.Quantity,
+		UpdateAddonData:  phase.AddonData,
+		Status:           consts.PendingSubStatusCreate,
+		MerchantMemberId: one.MerchantMemberId,
+		EffectImmediate:  0,
+		EffectTime:       periodStart,
+		TaxPercentage:    sub.TaxPercentage,
+		DiscountCode:     phase.DiscountCode,
+		Note:             fmt.Sprintf("Schedule Phase %d", phase.PhaseIndex),
+		CreateTime:       gtime.Now().Timestamp(),
+		MetaData:         utility.MarshalToJsonString(map[string]interface{}{MetaScheduleId: one.ScheduleId, MetaSchedulePhase: phase.PhaseIndex}),
+	}
+	_, err := dao.SubscriptionPendingUpdate.Ctx(ctx).Data(pendingUpdate).OmitNil().Insert(pendingUpdate)
+	if err != nil {
+		return nil, err
+	}
+	_, err = dao.Subscription.Ctx(ctx).Data(g.Map{
+		dao.Subscription.Columns().PendingUpdateId: pendingUpdate.PendingUpdateId,
+		dao.Subscription.Columns().GmtModify:       gtime.Now(),
+	}).Where(dao.Subscription.Columns().SubscriptionId, sub.SubscriptionId).OmitNil().Update()
+	if err != nil {
+		return nil, err
+	}
+	pending_update_cancel.CancelOtherUnfinishedPendingUpdatesBackground(sub.SubscriptionId, pendingUpdate.PendingUpdateId, "CancelBySchedule-"+one.ScheduleId)
+	_, _ = redismq.Send(&redismq.Message{
+		Topic:      redismq2.TopicSubscriptionPendingUpdateCreate.Topic,
+		Tag:        redismq2.TopicSubscriptionPendingUpdateCreate.Tag,
+		Body:       pendingUpdate.PendingUpdateId,
+		CustomData: map[string]interface{}{"CreateFrom": utility.ReflectCurrentFunctionName()},
+	})
+	return pendingUpdate, nil
+}
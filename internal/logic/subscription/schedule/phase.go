@@ -0,0 +1,131 @@
+package schedule
+
+import (
+	"fmt"
+	"unibee/api/bean"
+	"unibee/internal/consts"
+	entity "unibee/internal/model/entity/default"
+	"unibee/utility"
+)
+
+const (
+	MetaScheduleId    = "SubscriptionScheduleId"
+	MetaSchedulePhase = "SubscriptionSchedulePhase"
+)
+
+type CycleAction int
+
+const (
+	// CycleActionPrepared the billing cycle has been walked by the schedule before
+	CycleActionPrepared CycleAction = iota
+	// CycleActionContinue the current phase continues with one more billing cycle
+	CycleActionContinue
+	// CycleActionEnterPhase the billing cycle starts the target phase
+	CycleActionEnterPhase
+	// CycleActionEnd all phases finished, the schedule ends with its end behavior
+	CycleActionEnd
+)
+
+// ValidatePhaseParams checks the phases of a schedule, only the last phase can renew without end,
+// a schedule cancels the subscription at its end needs the cycles of the last phase
+func ValidatePhaseParams(phases []*bean.SubscriptionSchedulePhaseParam, endBehavior string) error {
+	if len(phases) == 0 {
+		return fmt.Errorf("phases of schedule not found")
+	}
+	if endBehavior != consts.SubScheduleEndBehaviorRelease && endBehavior != consts.SubScheduleEndBehaviorCancel {
+		return fmt.Errorf("endBehavior should be one of release|cancel")
+	}
+	for i, phase := range phases {
+		if phase == nil || phase.PlanId <= 0 {
+			return fmt.Errorf("planId of phase %d invalid", i+1)
+		}
+		if phase.Quantity < 0 {
+			return fmt.Errorf("quantity of phase %d invalid", i+1)
+		}
+		if phase.Cycles < 0 {
+			return fmt.Errorf("cycles of phase %d invalid", i+1)
+		}
+		if phase.Cycles == 0 && i < len(phases)-1 {
+			return fmt.Errorf("cycles of phase %d should be greater than 0, only the last phase can renew without end", i+1)
+		}
+	}
+	if endBehavior == consts.SubScheduleEndBehaviorCancel && phases[len(phases)-1].Cycles == 0 {
+		return fmt.Errorf("cycles of the last phase should be greater than 0 to cancel the subscription at the end")
+	}
+	return nil
+}
+
+func findPhase(phases []*entity.SubscriptionSchedulePhase, phaseIndex int) *entity.SubscriptionSchedulePhase {
+	for _, phase := range phases {
+		if phase.PhaseIndex == phaseIndex && phase.Status != consts.SubSchedulePhaseStatusCancelled {
+			return phase
+		}
+	}
+	return nil
+}
+
+// IsLastPhase returns true when no phase follows the phase
+func IsLastPhase(phases []*entity.SubscriptionSchedulePhase, phaseIndex int) bool {
+	return findPhase(phases, phaseIndex+1) == nil
+}
+
+// NextCycle decides what the schedule does for the billing cycle starting at periodStart,
+// the first billing cycle walked by the schedule starts the first phase
+func NextCycle(one *entity.SubscriptionSchedule, phases []*entity.SubscriptionSchedulePhase, periodStart int64) (CycleAction, *entity.SubscriptionSchedulePhase) {
+	current := findPhase(phases, one.CurrentPhase)
+	if one.CurrentPhase > 0 && periodStart <= one.LastPeriodStart {
+		return CycleActionPrepared, current
+	}
+	if current == nil {
+		if next := findPhase(phases, one.CurrentPhase+1); next != nil {
+			return CycleActionEnterPhase, next
+		}
+		return CycleActionEnd, nil
+	}
+	if current.Cycles == 0 || one.PhaseCycleCount < current.Cycles {
+		return CycleActionContinue, current
+	}
+	if next := findPhase(phases, current.PhaseIndex+1); next != nil {
+		return CycleActionEnterPhase, next
+	}
+	return CycleActionEnd, nil
+}
+
+// ApplyCycle moves the schedule state to the billing cycle starting at periodStart
+func ApplyCycle(one *entity.SubscriptionSchedule, phases []*entity.SubscriptionSchedulePhase, action CycleAction, target *entity.SubscriptionSchedulePhase, periodStart int64) {
+	switch action {
+	case CycleActionContinue:
+		one.PhaseCycleCount = one.PhaseCycleCount + 1
+		one.LastPeriodStart = periodStart
+	case CycleActionEnterPhase:
+		if one.StartTime == 0 {
+			one.StartTime = periodStart
+		}
+		one.CurrentPhase = target.PhaseIndex
+		one.PhaseCycleCount = 1
+		one.LastPeriodStart = periodStart
+		if target.Cycles == 0 && IsLastPhase(phases, target.PhaseIndex) {
+			// renew on the last phase, nothing left to schedule
+			one.Status = consts.SubScheduleStatusCompleted
+			one.EndTime = periodStart
+		}
+	case CycleActionEnd:
+		one.LastPeriodStart = periodStart
+		one.Status = consts.SubScheduleStatusCompleted
+		one.EndTime = periodStart
+	}
+}
+
+// IsSchedulePendingUpdate returns true when the pending update applies a phase of subscription schedule
+func IsSchedulePendingUpdate(one *entity.SubscriptionPendingUpdate) bool {
+	if one == nil || len(one.MetaData) == 0 {
+		return false
+	}
+	var metadata map[string]interface{}
+	_ = utility.UnmarshalFromJsonString(one.MetaData, &metadata)
+	if metadata == nil {
+		return false
+	}
+	scheduleId, ok := metadata[MetaScheduleId].(string)
+	return ok && len(scheduleId) > 0
+}
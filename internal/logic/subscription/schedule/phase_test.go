@@ -0,0 +1,85 @@
+package schedule
+
+import (
+	"testing"
+
+	"unibee/api/bean"
+	"unibee/internal/consts"
+	entity "unibee/internal/model/entity/default"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidatePhaseParams(t *testing.T) {
+	require.NotNil(t, ValidatePhaseParams(nil, consts.SubScheduleEndBehaviorRelease))
+	require.NotNil(t, ValidatePhaseParams([]*bean.SubscriptionSchedulePhaseParam{{PlanId: 1, Cycles: 1}}, "other"))
+	require.NotNil(t, ValidatePhaseParams([]*bean.SubscriptionSchedulePhaseParam{{PlanId: 0, Cycles: 1}}, consts.SubScheduleEndBehaviorRelease))
+	require.NotNil(t, ValidatePhaseParams([]*bean.SubscriptionSchedulePhaseParam{{PlanId: 1, Cycles: -1}}, consts.SubScheduleEndBehaviorRelease))
+	require.NotNil(t, ValidatePhaseParams([]*bean.SubscriptionSchedulePhaseParam{{PlanId: 1, Quantity: -1, Cycles: 1}}, consts.SubScheduleEndBehaviorRelease))
+	// only the last phase renews without end
+	require.NotNil(t, ValidatePhaseParams([]*bean.SubscriptionSchedulePhaseParam{{PlanId: 1, Cycles: 0}, {PlanId: 2, Cycles: 1}}, consts.SubScheduleEndBehaviorRelease))
+	require.Nil(t, ValidatePhaseParams([]*bean.SubscriptionSchedulePhaseParam{{PlanId: 1, Cycles: 3}, {PlanId: 2, Cycles: 0}}, consts.SubScheduleEndBehaviorRelease))
+	require.NotNil(t, ValidatePhaseParams([]*bean.SubscriptionSchedulePhaseParam{{PlanId: 1, Cycles: 3}, {PlanId: 2, Cycles: 0}}, consts.SubScheduleEndBehaviorCancel))
+	require.Nil(t, ValidatePhaseParams([]*bean.SubscriptionSchedulePhaseParam{{PlanId: 1, Cycles: 3}, {PlanId: 2, Cycles: 2}}, consts.SubScheduleEndBehaviorCancel))
+}
+
+func TestNextCycle(t *testing.T) {
+	one := &entity.SubscriptionSchedule{Status: consts.SubScheduleStatusActive, EndBehavior: consts.SubScheduleEndBehaviorCancel}
+	phases := []*entity.SubscriptionSchedulePhase{
+		{PhaseIndex: 1, PlanId: 11, Cycles: 2},
+		{PhaseIndex: 2, PlanId: 12, Cycles: 1},
+	}
+	walk := func(periodStart int64) (CycleAction, *entity.SubscriptionSchedulePhase) {
+		action, target := NextCycle(one, phases, periodStart)
+		ApplyCycle(one, phases, action, target, periodStart)
+		return action, target
+	}
+	action, target := walk(100)
+	require.Equal(t, CycleActionEnterPhase, action)
+	require.Equal(t, uint64(11), target.PlanId)
+	require.Equal(t, int64(100), one.StartTime)
+	// the billing cycle walked again
+	action, _ = NextCycle(one, phases, 100)
+	require.Equal(t, CycleActionPrepared, action)
+	action, target = walk(200)
+	require.Equal(t, CycleActionContinue, action)
+	require.Equal(t, 1, target.PhaseIndex)
+	require.Equal(t, 2, one.PhaseCycleCount)
+	action, target = walk(300)
+	require.Equal(t, CycleActionEnterPhase, action)
+	require.Equal(t, uint64(12), target.PlanId)
+	require.Equal(t, 1, one.PhaseCycleCount)
+	require.Equal(t, consts.SubScheduleStatusActive, one.Status)
+	action, target = walk(400)
+	require.Equal(t, CycleActionEnd, action)
+	require.Nil(t, target)
+	require.Equal(t, consts.SubScheduleStatusCompleted, one.Status)
+	require.Equal(t, int64(400), one.EndTime)
+}
+
+func TestNextCycleRenewLastPhase(t *testing.T) {
+	one := &entity.SubscriptionSchedule{Status: consts.SubScheduleStatusActive, EndBehavior: consts.SubScheduleEndBehaviorRelease}
+	phases := []*entity.SubscriptionSchedulePhase{
+		{PhaseIndex: 1, PlanId: 11, Cycles: 1},
+		{PhaseIndex: 2, PlanId: 12, Cycles: 0, Status: consts.SubSchedulePhaseStatusCancelled},
+		{PhaseIndex: 2, PlanId: 13, Cycles: 0},
+	}
+	action, target := NextCycle(one, phases, 100)
+	ApplyCycle(one, phases, action, target, 100)
+	require.Equal(t, CycleActionEnterPhase, action)
+	action, target = NextCycle(one, phases, 200)
+	require.Equal(t, CycleActionEnterPhase, action)
+	// the cancelled phase skipped
+	require.Equal(t, uint64(13), target.PlanId)
+	require.True(t, IsLastPhase(phases, target.PhaseIndex))
+	ApplyCycle(one, phases, action, target, 200)
+	require.Equal(t, consts.SubScheduleStatusCompleted, one.Status)
+	require.Equal(t, 2, one.CurrentPhase)
+}
+
+func TestIsSchedulePendingUpdate(t *testing.T) {
+	require.False(t, IsSchedulePendingUpdate(nil))
+	require.False(t, IsSchedulePendingUpdate(&entity.SubscriptionPendingUpdate{MetaData: ""}))
+	require.False(t, IsSchedulePendingUpdate(&entity.SubscriptionPendingUpdate{MetaData: `{"Other":"x"}`}))
+	require.True(t, IsSchedulePendingUpdate(&entity.SubscriptionPendingUpdate{MetaData: `{"SubscriptionScheduleId":"subsch1","SubscriptionSchedulePhase":1}`}))
+}
@@ -0,0 +1,104 @@
+package schedule
+
+import (
+	"context"
+
+	"unibee/api/bean"
+	"unibee/internal/consts"
+	"unibee/internal/logic/invoice/invoice_compute"
+	"unibee/internal/logic/plan/period"
+	"unibee/internal/logic/user/vat"
+	entity "unibee/internal/model/entity/default"
+	"unibee/utility"
+
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+const previewCycleLimit = 36
+
+// PreviewSubscriptionSchedule previews the cycle invoices the subscription will be charged by the schedule,
+// the preview stops at the end of the schedule or the first cycle renewing without end
+func PreviewSubscriptionSchedule(ctx context.Context, sub *entity.Subscription, one *entity.SubscriptionSchedule, phases []*entity.SubscriptionSchedulePhase) []*bean.Invoice {
+	utility.Assert(sub != nil, "subscription not found")
+	utility.Assert(one != nil, "schedule not found")
+	var state = *one
+	var taxPercentage = sub.TaxPercentage
+	percentage, countryCode, vatNumber, err := vat.GetUserTaxPercentage(ctx, sub.UserId)
+	if err == nil {
+		taxPercentage = percentage
+	}
+	var timeNow = gtime.Now().Timestamp()
+	var periodStart = utility.MaxInt64(sub.CurrentPeriodEnd, sub.TrialEnd)
+	var invoices = make([]*bean.Invoice, 0)
+	for i := 0; i < previewCycleLimit && state.Status == consts.SubScheduleStatusActive; i++ {
+		action, target := NextCycle(&state, phases, periodStart)
+		var planId = sub.PlanId
+		var quantity = sub.Quantity
+		var addonData = sub.AddonData
+		var discountCode = sub.DiscountCode
+		var phaseIndex = 0
+		if action == CycleActionEnd && state.EndBehavior == consts.SubScheduleEndBehaviorCancel {
+			break
+		}
+		if action != CycleActionPrepared {
+			ApplyCycle(&state, phases, action, target, periodStart)
+		}
+		if action == CycleActionEnd {
+			// released, the subscription renews on the last phase
+			target = findPhase(phases, state.CurrentPhase)
+		}
+		if target != nil {
+			planId = target.PlanId
+			quantity = target.Quantity
+			addonData = target.AddonData
+			discountCode = target.DiscountCode
+			phaseIndex = target.PhaseIndex
+		}
+		var periodEnd = period.GetPeriodEndFromStart(ctx, periodStart, sub.BillingCycleAnchor, planId)
+		invoice := invoice_compute.ComputeSubscriptionBillingCycleInvoiceDetailSimplify(ctx, &invoice_compute.CalculateInvoiceReq{
+			UserId:        sub.UserId,
+			Currency:      sub.Currency,
+			DiscountCode:  discountCode,
+			TimeNow:       timeNow,
+			PlanId:        planId,
+			Quantity:      quantity,
+			AddonJsonData: addonData,
+			VatNumber:     vatNumber,
+			CountryCode:   countryCode,
+			TaxPercentage: taxPercentage,
+			PeriodStart:   periodStart,
+			PeriodEnd:     periodEnd,
+			InvoiceName:   "SubscriptionCycle",
+			FinishTime:    timeNow,
+			CreateFrom:    consts.InvoiceAutoChargeFlag,
+			Metadata:      map[string]interface{}{MetaScheduleId: state.ScheduleId, MetaSchedulePhase: phaseIndex},
+		})
+		invoices = append(invoices, invoice)
+		periodStart = periodEnd
+	}
+	return invoices
+}
+
+// PreviewSubscriptionSchedulePhases previews the cycle invoices of the phases not saved yet
+func PreviewSubscriptionSchedulePhases(ctx context.Context, sub *entity.Subscription, params []*bean.SubscriptionSchedulePhaseParam, endBehavior string) []*bean.Invoice {
+	utility.Assert(sub != nil, "subscription not found")
+	if len(endBehavior) == 0 {
+		endBehavior = consts.SubScheduleEndBehaviorRelease
+	}
+	utility.AssertError(ValidatePhaseParams(params, endBehavior), "invalid phases")
+	for i, param := range params {
+		checkPhaseParam(ctx, sub, i+1, param)
+	}
+	one := &entity.SubscriptionSchedule{
+		MerchantId:     sub.MerchantId,
+		UserId:         sub.UserId,
+		SubscriptionId: sub.SubscriptionId,
+		Status:         consts.SubScheduleStatusActive,
+		EndBehavior:    endBehavior,
+	}
+	var phases = make([]*entity.SubscriptionSchedulePhase, 0)
+	for i, param := range params {
+		phases = append(phases, buildPhase(one, param, i+1))
+	}
+	return PreviewSubscriptionSchedule(ctx, sub, one, phases)
+}
@@ -0,0 +1,307 @@
+package schedule
+
+import (
+	"context"
+	"fmt"
+
+	"unibee/api/bean"
+	"unibee/internal/consts"
+	dao "unibee/internal/dao/default"
+	"unibee/internal/logic/operation_log"
+	entity "unibee/internal/model/entity/default"
+	"unibee/internal/query"
+	"unibee/utility"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+type CreateInternalReq struct {
+	MerchantId       uint64
+	SubscriptionId   string
+	Phases           []*bean.SubscriptionSchedulePhaseParam
+	EndBehavior      string
+	MerchantMemberId int64
+}
+
+type UpdateInternalReq struct {
+	MerchantId  uint64
+	ScheduleId  string
+	Phases      []*bean.SubscriptionSchedulePhaseParam // replace the phases not started
+	EndBehavior *string
+}
+
+// SubscriptionScheduleCreate attaches the phases to the active subscription, the first phase starts from the next billing cycle,
+// each phase lasts its billing cycles and the billing cycle walk applies the next phase at period end
+func SubscriptionScheduleCreate(ctx context.Context, req *CreateInternalReq) (*entity.SubscriptionSchedule, []*entity.SubscriptionSchedulePhase, error) {
+	utility.Assert(len(req.SubscriptionId) > 0, "subscriptionId not found")
+	if len(req.EndBehavior) == 0 {
+		req.EndBehavior = consts.SubScheduleEndBehaviorRelease
+	}
+	utility.AssertError(ValidatePhaseParams(req.Phases, req.EndBehavior), "invalid phases")
+	sub := query.GetSubscriptionBySubscriptionId(ctx, req.SubscriptionId)
+	utility.Assert(sub != nil, "subscription not found")
+	utility.Assert(sub.MerchantId == req.MerchantId, "wrong merchant account")
+	utility.Assert(sub.Type == consts.SubTypeUniBeeControl, "subscription billing cycle not controlled by UniBee")
+	utility.Assert(sub.Status == consts.SubStatusActive, "subscription not in active status")
+	utility.Assert(sub.CancelAtPeriodEnd == 0, "subscription will cancel at period end, resume it first")
+	utility.Assert(query.GetActiveSubscriptionScheduleBySubscriptionId(ctx, sub.SubscriptionId) == nil, "subscription already has an active schedule, update or release it")
+	utility.Assert(query.GetUnfinishedSubscriptionPendingUpdateByPendingUpdateId(ctx, sub.PendingUpdateId) == nil, "subscription has a pending update, cancel it first")
+	for i, phase := range req.Phases {
+		checkPhaseParam(ctx, sub, i+1, phase)
+	}
+	utility.AssertError(checkCommitment(ctx, sub, query.GetActiveSubscriptionCommitmentBySubscriptionId(ctx, sub.SubscriptionId), req.Phases, 1, nextPhaseStart(ctx, sub, nil, nil), req.EndBehavior), "commitment term")
+
+	one := &entity.SubscriptionSchedule{
+		MerchantId:       sub.MerchantId,
+		UserId:           sub.UserId,
+		SubscriptionId:   sub.SubscriptionId,
+		ScheduleId:       utility.CreateSubscriptionScheduleId(),
+		Status:           consts.SubScheduleStatusActive,
+		EndBehavior:      req.EndBehavior,
+		MerchantMemberId: req.MerchantMemberId,
+		CreateTime:       gtime.Now().Timestamp(),
+	}
+	result, err := dao.SubscriptionSchedule.Ctx(ctx).Data(one).OmitNil().Insert(one)
+	if err != nil {
+		return nil, nil, err
+	}
+	id, _ := result.LastInsertId()
+	one.Id = uint64(id)
+	err = insertPhases(ctx, one, req.Phases, 1)
+	if err != nil {
+		return nil, nil, err
+	}
+	operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
+		MerchantId:     sub.MerchantId,
+		Target:         fmt.Sprintf("SubscriptionSchedule(%s)", one.ScheduleId),
+		Content:        fmt.Sprintf("New(%d phases,%s)", len(req.Phases), req.EndBehavior),
+		UserId:         sub.UserId,
+		SubscriptionId: sub.SubscriptionId,
+		InvoiceId:      "",
+		PlanId:         0,
+		DiscountCode:   "",
+	}, err)
+	return one, query.GetSubscriptionSchedulePhases(ctx, one.ScheduleId), nil
+}
+
+// SubscriptionScheduleUpdate replaces the phases not started yet, the started phases stay as they are
+func SubscriptionScheduleUpdate(ctx context.Context, req *UpdateInternalReq) (*entity.SubscriptionSchedule, []*entity.SubscriptionSchedulePhase, error) {
+	one := query.GetSubscriptionScheduleByScheduleId(ctx, req.ScheduleId)
+	utility.Assert(one != nil, "schedule not found")
+	utility.Assert(one.MerchantId == req.MerchantId, "wrong merchant account")
+	utility.Assert(one.Status == consts.SubScheduleStatusActive, "schedule not in active status")
+	sub := query.GetSubscriptionBySubscriptionId(ctx, one.SubscriptionId)
+	utility.Assert(sub != nil, "subscription not found")
+	var endBehavior = one.EndBehavior
+	if req.EndBehavior != nil {
+		endBehavior = *req.EndBehavior
+	}
+
+	phases := query.GetSubscriptionSchedulePhases(ctx, one.ScheduleId)
+	// the phases started are validated together with the new ones
+	var params = make([]*bean.SubscriptionSchedulePhaseParam, 0)
+	for _, phase := range phases {
+		if phase.PhaseIndex <= one.CurrentPhase && phase.Status != consts.SubSchedulePhaseStatusCancelled {
+			params = append(params, phaseParam(phase))
+		}
+	}
+	var started = len(params)
+	if req.Phases != nil {
+		params = append(params, req.Phases...)
+	} else {
+		for _, phase := range phases {
+			if phase.PhaseIndex > one.CurrentPhase && phase.Status == consts.SubSchedulePhaseStatusPending {
+				params = append(params, phaseParam(phase))
+			}
+		}
+	}
+	utility.AssertError(ValidatePhaseParams(params, endBehavior), "invalid phases")
+	current := findPhase(phases, one.CurrentPhase)
+	if current != nil && current.Cycles > 0 {
+		utility.Assert(one.PhaseCycleCount <= current.Cycles, "cycles of the current phase exceeded")
+	}
+
+	if req.Phases != nil {
+		for i, phase := range req.Phases {
+			checkPhaseParam(ctx, sub, one.CurrentPhase+i+1, phase)
+		}
+	}
+	utility.AssertError(checkCommitment(ctx, sub, query.GetActiveSubscriptionCommitmentBySubscriptionId(ctx, sub.SubscriptionId), params[started:], one.CurrentPhase+1, nextPhaseStart(ctx, sub, one, current), endBehavior), "commitment term")
+
+	if req.Phases != nil {
+		_, err := dao.SubscriptionSchedulePhase.Ctx(ctx).Data(g.Map{
+			dao.SubscriptionSchedulePhase.Columns().Status:    consts.SubSchedulePhaseStatusCancelled,
+			dao.SubscriptionSchedulePhase.Columns().IsDeleted: 1,
+			dao.SubscriptionSchedulePhase.Columns().GmtModify: gtime.Now(),
+		}).Where(dao.SubscriptionSchedulePhase.Columns().ScheduleId, one.ScheduleId).
+			WhereGT(dao.SubscriptionSchedulePhase.Columns().PhaseIndex, one.CurrentPhase).
+			Where(dao.SubscriptionSchedulePhase.Columns().Status, consts.SubSchedulePhaseStatusPending).
+			OmitNil().Update()
+		if err != nil {
+			return nil, nil, err
+		}
+		err = insertPhases(ctx, one, req.Phases, one.CurrentPhase+1)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	_, err := dao.SubscriptionSchedule.Ctx(ctx).Data(g.Map{
+		dao.SubscriptionSchedule.Columns().EndBehavior: endBehavior,
+		dao.SubscriptionSchedule.Columns().GmtModify:   gtime.Now(),
+	}).Where(dao.SubscriptionSchedule.Columns().Id, one.Id).OmitNil().Update()
+	if err != nil {
+		return nil, nil, err
+	}
+	operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
+		MerchantId:     one.MerchantId,
+		Target:         fmt.Sprintf("SubscriptionSchedule(%s)", one.ScheduleId),
+		Content:        fmt.Sprintf("Update(%d phases,%s)", len(params), endBehavior),
+		UserId:         one.UserId,
+		SubscriptionId: one.SubscriptionId,
+		InvoiceId:      "",
+		PlanId:         0,
+		DiscountCode:   "",
+	}, err)
+	return query.GetSubscriptionScheduleByScheduleId(ctx, one.ScheduleId), query.GetSubscriptionSchedulePhases(ctx, one.ScheduleId), nil
+}
+
+// SubscriptionScheduleRelease stops the schedule, the subscription keeps its current plan and renews on it,
+// the phase already applied to the upcoming billing cycle is kept
+func SubscriptionScheduleRelease(ctx context.Context, merchantId uint64, scheduleId string, reason string) (*entity.SubscriptionSchedule, error) {
+	one := query.GetSubscriptionScheduleByScheduleId(ctx, scheduleId)
+	utility.Assert(one != nil, "schedule not found")
+	utility.Assert(one.MerchantId == merchantId, "wrong merchant account")
+	utility.Assert(one.Status == consts.SubScheduleStatusActive, "schedule not in active status")
+	err := releaseSchedule(ctx, one, reason)
+	if err != nil {
+		return nil, err
+	}
+	return query.GetSubscriptionScheduleByScheduleId(ctx, one.ScheduleId), nil
+}
+
+// ReleaseSubscriptionScheduleBySubEnd releases the active schedule of the subscription cancelled or expired
+func ReleaseSubscriptionScheduleBySubEnd(ctx context.Context, subscriptionId string, reason string) {
+	one := query.GetActiveSubscriptionScheduleBySubscriptionId(ctx, subscriptionId)
+	if one == nil {
+		return
+	}
+	err := releaseSchedule(ctx, one, reason)
+	if err != nil {
+		g.Log().Errorf(ctx, "ReleaseSubscriptionScheduleBySubEnd scheduleId:%s err:%s", one.ScheduleId, err.Error())
+	}
+}
+
+func releaseSchedule(ctx context.Context, one *entity.SubscriptionSchedule, reason string) error {
+	timeNow := gtime.Now().Timestamp()
+	result, err := dao.SubscriptionSchedule.Ctx(ctx).Data(g.Map{
+		dao.SubscriptionSchedule.Columns().Status:    consts.SubScheduleStatusReleased,
+		dao.SubscriptionSchedule.Columns().EndTime:   timeNow,
+		dao.SubscriptionSchedule.Columns().GmtModify: gtime.Now(),
+	}).Where(dao.SubscriptionSchedule.Columns().Id, one.Id).Where(dao.SubscriptionSchedule.Columns().Status, consts.SubScheduleStatusActive).OmitNil().Update()
+	if err != nil {
+		return err
+	}
+	affected, _ := result.RowsAffected()
+	if affected != 1 {
+		return fmt.Errorf("schedule %s status changed, try again", one.ScheduleId)
+	}
+	_, err = dao.SubscriptionSchedulePhase.Ctx(ctx).Data(g.Map{
+		dao.SubscriptionSchedulePhase.Columns().Status:    consts.SubSchedulePhaseStatusCancelled,
+		dao.SubscriptionSchedulePhase.Columns().GmtModify: gtime.Now(),
+	}).Where(dao.SubscriptionSchedulePhase.Columns().ScheduleId, one.ScheduleId).
+		Where(dao.SubscriptionSchedulePhase.Columns().Status, consts.SubSchedulePhaseStatusPending).
+		OmitNil().Update()
+	if err != nil {
+		return err
+	}
+	_, err = dao.SubscriptionSchedulePhase.Ctx(ctx).Data(g.Map{
+		dao.SubscriptionSchedulePhase.Columns().Status:    consts.SubSchedulePhaseStatusCompleted,
+		dao.SubscriptionSchedulePhase.Columns().EndTime:   timeNow,
+		dao.SubscriptionSchedulePhase.Columns().GmtModify: gtime.Now(),
+	}).Where(dao.SubscriptionSchedulePhase.Columns().ScheduleId, one.ScheduleId).
+		Where(dao.SubscriptionSchedulePhase.Columns().Status, consts.SubSchedulePhaseStatusActive).
+		OmitNil().Update()
+	operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
+		MerchantId:     one.MerchantId,
+		Target:         fmt.Sprintf("SubscriptionSchedule(%s)", one.ScheduleId),
+		Content:        fmt.Sprintf("Release(%s)", reason),
+		UserId:         one.UserId,
+		SubscriptionId: one.SubscriptionId,
+		InvoiceId:      "",
+		PlanId:         0,
+		DiscountCode:   "",
+	}, err)
+	return err
+}
+
+func phaseParam(phase *entity.SubscriptionSchedulePhase) *bean.SubscriptionSchedulePhaseParam {
+	simplify := bean.SimplifySubscriptionSchedulePhase(phase)
+	return &bean.SubscriptionSchedulePhaseParam{
+		PlanId:       simplify.PlanId,
+		Quantity:     simplify.Quantity,
+		AddonParams:  simplify.AddonParams,
+		DiscountCode: simplify.DiscountCode,
+		Cycles:       simplify.Cycles,
+	}
+}
+
+func insertPhases(ctx context.Context, one *entity.SubscriptionSchedule, params []*bean.SubscriptionSchedulePhaseParam, startIndex int) error {
+	for i, param := range params {
+		phase := buildPhase(one, param, startIndex+i)
+		_, err := dao.SubscriptionSchedulePhase.Ctx(ctx).Data(phase).OmitNil().Insert(phase)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func buildPhase(one *entity.SubscriptionSchedule, param *bean.SubscriptionSchedulePhaseParam, phaseIndex int) *entity.SubscriptionSchedulePhase {
+	var quantity = param.Quantity
+	if quantity <= 0 {
+		quantity = 1
+	}
+	var addonData = ""
+	if len(param.AddonParams) > 0 {
+		addonData = utility.MarshalToJsonString(param.AddonParams)
+	}
+	return &entity.SubscriptionSchedulePhase{
+		MerchantId:     one.MerchantId,
+		SubscriptionId: one.SubscriptionId,
+		ScheduleId:     one.ScheduleId,
+		PhaseIndex:     phaseIndex,
+		PlanId:         param.PlanId,
+		Quantity:       quantity,
+		AddonData:      addonData,
+		DiscountCode:   param.DiscountCode,
+		Cycles:         param.Cycles,
+		Status:         consts.SubSchedulePhaseStatusPending,
+		CreateTime:     gtime.Now().Timestamp(),
+	}
+}
+
+// checkPhaseParam checks the plan, addons and discount code of the phase are available for the subscription
+func checkPhaseParam(ctx context.Context, sub *entity.Subscription, phaseIndex int, param *bean.SubscriptionSchedulePhaseParam) {
+	plan := query.GetPlanById(ctx, param.PlanId)
+	utility.Assert(plan != nil, fmt.Sprintf("plan of phase %d not found", phaseIndex))
+	utility.Assert(plan.MerchantId == sub.MerchantId, fmt.Sprintf("plan of phase %d merchant not match", phaseIndex))
+	utility.Assert(plan.Status == consts.PlanStatusActive, fmt.Sprintf("plan of phase %d not active", phaseIndex))
+	utility.Assert(plan.Type == consts.PlanTypeMain, fmt.Sprintf("plan of phase %d not main type", phaseIndex))
+	oldPlan := query.GetPlanById(ctx, sub.PlanId)
+	utility.Assert(oldPlan != nil, "plan of subscription not found")
+	utility.Assert(plan.ProductId == oldPlan.ProductId, fmt.Sprintf("plan of phase %d product not equal to sub's product", phaseIndex))
+	for _, addonParam := range param.AddonParams {
+		utility.Assert(addonParam != nil && addonParam.AddonPlanId > 0, fmt.Sprintf("addon of phase %d invalid", phaseIndex))
+		addon := query.GetPlanById(ctx, addonParam.AddonPlanId)
+		utility.Assert(addon != nil, fmt.Sprintf("addon %d of phase %d not found", addonParam.AddonPlanId, phaseIndex))
+		utility.Assert(addon.MerchantId == sub.MerchantId, fmt.Sprintf("addon %d of phase %d merchant not match", addon.Id, phaseIndex))
+		utility.Assert(addon.Status == consts.PlanStatusActive, fmt.Sprintf("addon %d of phase %d not active", addon.Id, phaseIndex))
+		utility.Assert(addon.Type == consts.PlanTypeRecurringAddon, fmt.Sprintf("addon %d of phase %d not recurring type", addon.Id, phaseIndex))
+		utility.Assert(addon.IntervalUnit == plan.IntervalUnit && addon.IntervalCount == plan.IntervalCount, fmt.Sprintf("addon %d of phase %d must have same recurring interval to plan", addon.Id, phaseIndex))
+	}
+	if len(param.DiscountCode) > 0 {
+		code := query.GetDiscountByCode(ctx, sub.MerchantId, param.DiscountCode)
+		utility.Assert(code != nil && code.IsDeleted == 0, fmt.Sprintf("discount code of phase %d not found", phaseIndex))
+	}
+}
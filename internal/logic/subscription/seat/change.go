@@ -0,0 +1,164 @@
+package seat
+
+import (
+	"context"
+	"fmt"
+
+	"unibee/api/bean"
+	"unibee/internal/consts"
+	dao "unibee/internal/dao/default"
+	"unibee/internal/logic/invoice/invoice_compute"
+	"unibee/internal/logic/operation_log"
+	plan2 "unibee/internal/logic/plan"
+	"unibee/internal/logic/subscription/config"
+	"unibee/internal/logic/subscription/service"
+	entity "unibee/internal/model/entity/default"
+	"unibee/internal/query"
+	"unibee/utility"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+type ChangeInternalReq struct {
+	MerchantId       uint64
+	SubscriptionId   string
+	Quantity         int64
+	ProrationMode    string // immediate|true_up, follow the subscription config if not specified
+	MerchantMemberId int64
+}
+
+type ChangeInternalRes struct {
+	Subscription *entity.Subscription
+	SeatChange   *entity.SubscriptionSeatChange
+	Update       *service.UpdateInternalRes
+}
+
+// SubscriptionSeatChange adds or removes the seats (quantity) of the active subscription,
+// in immediate mode the seats added are charged with proration at once and the seats removed follow the downgrade config,
+// in true_up mode the seats change at once and the seats added are charged with proration in the next cycle invoice
+func SubscriptionSeatChange(ctx context.Context, req *ChangeInternalReq) (*ChangeInternalRes, error) {
+	sub := checkSeatSubscription(ctx, req.MerchantId, req.SubscriptionId)
+	utility.Assert(sub.Status == consts.SubStatusActive, "subscription not in active status")
+	utility.Assert(req.Quantity > 0, "seats should be greater than 0")
+	utility.Assert(req.Quantity != sub.Quantity, "seats not changed")
+	plan := query.GetPlanById(ctx, sub.PlanId)
+	utility.Assert(plan != nil, "plan not found")
+	utility.AssertError(plan2.CheckPlanSeatLimit(plan, req.Quantity), "invalid seats")
+	var assigned = query.CountAssignedSubscriptionSeats(ctx, sub.SubscriptionId)
+	utility.Assert(req.Quantity >= assigned, fmt.Sprintf("%d seats assigned, unassign seats first", assigned))
+	var mode = req.ProrationMode
+	if len(mode) == 0 {
+		mode = config.GetMerchantSubscriptionConfig(ctx, sub.MerchantId).SeatProrationMode
+	}
+	utility.Assert(IsValidSeatProrationMode(mode), "prorationMode should be one of immediate|true_up")
+
+	var timeNow = utility.MaxInt64(gtime.Now().Timestamp(), sub.TestClock)
+	change := &entity.SubscriptionSeatChange{
+		MerchantId:       sub.MerchantId,
+		UserId:           sub.UserId,
+		SubscriptionId:   sub.SubscriptionId,
+		FromQuantity:     sub.Quantity,
+		ToQuantity:       req.Quantity,
+		ProrationMode:    mode,
+		ChangeTime:       timeNow,
+		PeriodStart:      sub.CurrentPeriodStart,
+		PeriodEnd:        sub.CurrentPeriodEnd,
+		Status:           consts.SubSeatChangeStatusApplied,
+		MerchantMemberId: req.MerchantMemberId,
+		CreateTime:       gtime.Now().Timestamp(),
+	}
+	var updateRes *service.UpdateInternalRes
+	if mode == consts.SeatProrationModeImmediate {
+		var addonParams = make([]*bean.PlanAddonParam, 0)
+		if len(sub.AddonData) > 0 {
+			_ = utility.UnmarshalFromJsonString(sub.AddonData, &addonParams)
+		}
+		var effectImmediate = 0
+		if req.Quantity > sub.Quantity {
+			effectImmediate = 1
+		}
+		var err error
+		updateRes, err = service.SubscriptionUpdate(ctx, &service.UpdateInternalReq{
+			SubscriptionId:  sub.SubscriptionId,
+			NewPlanId:       sub.PlanId,
+			Quantity:        req.Quantity,
+			AddonParams:     addonParams,
+			EffectImmediate: effectImmediate,
+			Metadata:        map[string]interface{}{"SeatChange": true},
+		}, req.MerchantMemberId)
+		if err != nil {
+			return nil, err
+		}
+		if updateRes.SubscriptionPendingUpdate != nil {
+			change.PendingUpdateId = updateRes.SubscriptionPendingUpdate.PendingUpdateId
+		}
+	} else {
+		utility.Assert(query.GetActiveSubscriptionScheduleBySubscriptionId(ctx, sub.SubscriptionId) == nil, "subscription is managed by schedule, release the schedule first")
+		utility.Assert(query.GetUnfinishedSubscriptionPendingUpdateByPendingUpdateId(ctx, sub.PendingUpdateId) == nil, "subscription has a pending update, cancel it first")
+		if req.Quantity < sub.Quantity {
+			// the seats removed in true_up mode take effect at once
+			_, err := service.CheckCommitmentDowngrade(ctx, sub, sub.CurrentPeriodEnd, true)
+			utility.AssertError(err, "commitment term")
+		}
+		if req.Quantity > sub.Quantity && !IsTrialPeriod(sub) {
+			change.Status = consts.SubSeatChangeStatusPending
+		}
+		result, err := dao.Subscription.Ctx(ctx).Data(g.Map{
+			dao.Subscription.Columns().Quantity:  req.Quantity,
+			dao.Subscription.Columns().Amount:    cycleAmount(ctx, sub, req.Quantity, timeNow),
+			dao.Subscription.Columns().GmtModify: gtime.Now(),
+		}).Where(dao.Subscription.Columns().Id, sub.Id).
+			Where(dao.Subscription.Columns().Quantity, sub.Quantity).
+			OmitNil().Update()
+		if err != nil {
+			return nil, err
+		}
+		affected, _ := result.RowsAffected()
+		if affected != 1 {
+			return nil, fmt.Errorf("seats of subscription changed by others, try again")
+		}
+	}
+	_, err := dao.SubscriptionSeatChange.Ctx(ctx).Data(change).OmitNil().Insert(change)
+	operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
+		MerchantId:     sub.MerchantId,
+		Target:         fmt.Sprintf("Subscription(%s)", sub.SubscriptionId),
+		Content:        fmt.Sprintf("ChangeSeats(%d->%d,%s)", sub.Quantity, req.Quantity, mode),
+		UserId:         sub.UserId,
+		SubscriptionId: sub.SubscriptionId,
+		InvoiceId:      "",
+		PlanId:         sub.PlanId,
+		DiscountCode:   "",
+	}, err)
+	if err != nil {
+		return nil, err
+	}
+	return &ChangeInternalRes{
+		Subscription: query.GetSubscriptionBySubscriptionId(ctx, sub.SubscriptionId),
+		SeatChange:   change,
+		Update:       updateRes,
+	}, nil
+}
+
+// IsTrialPeriod returns true when the current period of subscription is in trial, the seats added in trial are not charged
+func IsTrialPeriod(sub *entity.Subscription) bool {
+	return sub.TrialEnd > 0 && sub.TrialEnd >= sub.CurrentPeriodEnd
+}
+
+// cycleAmount returns the amount of the cycle invoice with the seats
+func cycleAmount(ctx context.Context, sub *entity.Subscription, quantity int64, timeNow int64) int64 {
+	invoice := invoice_compute.ComputeSubscriptionBillingCycleInvoiceDetailSimplify(ctx, &invoice_compute.CalculateInvoiceReq{
+		UserId:        sub.UserId,
+		Currency:      sub.Currency,
+		DiscountCode:  sub.DiscountCode,
+		TimeNow:       timeNow,
+		PlanId:        sub.PlanId,
+		Quantity:      quantity,
+		AddonJsonData: sub.AddonData,
+		TaxPercentage: sub.TaxPercentage,
+		InvoiceName:   "SubscriptionCycle",
+		FinishTime:    timeNow,
+		Metadata:      map[string]interface{}{},
+	})
+	return invoice.TotalAmount
+}
@@ -0,0 +1,69 @@
+package seat
+
+import (
+	"context"
+	"fmt"
+
+	"unibee/internal/consts"
+	dao "unibee/internal/dao/default"
+	"unibee/internal/logic/invoice/invoice_compute"
+	entity "unibee/internal/model/entity/default"
+	"unibee/internal/query"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+// TrueUpInvoiceItems returns the true-up of the seats added in the current period of subscription, charged with its next cycle invoice,
+// and the ids of the seat changes it covers
+func TrueUpInvoiceItems(ctx context.Context, sub *entity.Subscription) ([]*invoice_compute.AdditionalInvoiceItem, []uint64) {
+	var list = make([]*invoice_compute.AdditionalInvoiceItem, 0)
+	var changeIds = make([]uint64, 0)
+	if sub == nil || IsTrialPeriod(sub) {
+		return list, changeIds
+	}
+	changes := query.GetSubscriptionSeatChangesByPeriodStart(ctx, sub.SubscriptionId, sub.CurrentPeriodStart)
+	items := TrueUpSeats(sub.CurrentPeriodStart, sub.CurrentPeriodEnd, changes)
+	if len(items) == 0 {
+		return list, changeIds
+	}
+	plan := query.GetPlanById(ctx, sub.PlanId)
+	if plan == nil {
+		return list, changeIds
+	}
+	for _, change := range changes {
+		if change.Status == consts.SubSeatChangeStatusPending || change.Status == consts.SubSeatChangeStatusBilled {
+			changeIds = append(changeIds, change.Id)
+		}
+	}
+	for _, item := range items {
+		list = append(list, &invoice_compute.AdditionalInvoiceItem{
+			PlanId:                 sub.PlanId,
+			Name:                   fmt.Sprintf("%s Seats True-up", plan.PlanName),
+			Description:            fmt.Sprintf("%d * %s seats added (%s-%s)", item.Quantity, plan.PlanName, gtime.NewFromTimeStamp(item.ChangeTime).Layout("2006-01-02"), gtime.NewFromTimeStamp(sub.CurrentPeriodEnd).Layout("2006-01-02")),
+			UnitAmountExcludingTax: ProratedUnitAmount(plan.CurrencyAmount(ctx, sub.Currency), item.ProrationScale),
+			Quantity:               item.Quantity,
+			Proration:              item.ProrationScale < 10000,
+		})
+	}
+	return list, changeIds
+}
+
+// MarkTrueUpBilled links the seat changes billed by the true-up of the cycle invoice to it,
+// the invoice regenerated for the same period takes them over
+func MarkTrueUpBilled(ctx context.Context, sub *entity.Subscription, invoiceId string, changeIds []uint64) {
+	if len(changeIds) == 0 {
+		return
+	}
+	_, err := dao.SubscriptionSeatChange.Ctx(ctx).Data(g.Map{
+		dao.SubscriptionSeatChange.Columns().Status:    consts.SubSeatChangeStatusBilled,
+		dao.SubscriptionSeatChange.Columns().InvoiceId: invoiceId,
+		dao.SubscriptionSeatChange.Columns().GmtModify: gtime.Now(),
+	}).Where(dao.SubscriptionSeatChange.Columns().SubscriptionId, sub.SubscriptionId).
+		WhereIn(dao.SubscriptionSeatChange.Columns().Id, changeIds).
+		WhereIn(dao.SubscriptionSeatChange.Columns().Status, []int{consts.SubSeatChangeStatusPending, consts.SubSeatChangeStatusBilled}).
+		OmitNil().Update()
+	if err != nil {
+		g.Log().Errorf(ctx, "MarkTrueUpBilled subscriptionId:%s err:%s", sub.SubscriptionId, err.Error())
+	}
+}
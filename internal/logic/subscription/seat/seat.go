@@ -0,0 +1,141 @@
+package seat
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"unibee/api/bean"
+	"unibee/internal/consts"
+	dao "unibee/internal/dao/default"
+	"unibee/internal/logic/operation_log"
+	entity "unibee/internal/model/entity/default"
+	"unibee/internal/query"
+	"unibee/utility"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+type AssignInternalReq struct {
+	MerchantId     uint64
+	SubscriptionId string
+	Seats          []*bean.SubscriptionSeatParam
+}
+
+func checkSeatSubscription(ctx context.Context, merchantId uint64, subscriptionId string) *entity.Subscription {
+	utility.Assert(len(subscriptionId) > 0, "subscriptionId not found")
+	sub := query.GetSubscriptionBySubscriptionId(ctx, subscriptionId)
+	utility.Assert(sub != nil, "subscription not found")
+	utility.Assert(sub.MerchantId == merchantId, "wrong merchant account")
+	utility.Assert(sub.Status != consts.SubStatusCancelled && sub.Status != consts.SubStatusExpired && sub.Status != consts.SubStatusFailed, "subscription already ended")
+	return sub
+}
+
+// SubscriptionSeatAssign assigns the named members to the free seats of subscription, the seats (quantity) of subscription are not changed
+func SubscriptionSeatAssign(ctx context.Context, req *AssignInternalReq) ([]*entity.SubscriptionSeat, error) {
+	utility.Assert(len(req.Seats) > 0, "seats not found")
+	sub := checkSeatSubscription(ctx, req.MerchantId, req.SubscriptionId)
+	var assigned = query.CountAssignedSubscriptionSeats(ctx, sub.SubscriptionId)
+	utility.Assert(assigned+int64(len(req.Seats)) <= sub.Quantity, fmt.Sprintf("no enough free seats, %d of %d seats assigned, add seats first", assigned, sub.Quantity))
+	var members = make(map[string]bool)
+	for _, param := range req.Seats {
+		utility.Assert(param != nil && (len(param.Email) > 0 || len(param.ExternalId) > 0), "either email or externalId of seat needed")
+		param.Email = strings.ToLower(strings.TrimSpace(param.Email))
+		param.ExternalId = strings.TrimSpace(param.ExternalId)
+		if len(param.Email) > 0 {
+			utility.Assert(utility.IsEmailValid(param.Email), fmt.Sprintf("invalid email:%s", param.Email))
+			utility.Assert(!members["email:"+param.Email], fmt.Sprintf("duplicate seat member:%s", param.Email))
+			members["email:"+param.Email] = true
+		}
+		if len(param.ExternalId) > 0 {
+			utility.Assert(!members["external:"+param.ExternalId], fmt.Sprintf("duplicate seat member:%s", param.ExternalId))
+			members["external:"+param.ExternalId] = true
+		}
+		utility.Assert(query.GetAssignedSubscriptionSeatByMember(ctx, sub.SubscriptionId, param.Email, param.ExternalId) == nil, fmt.Sprintf("seat member already assigned:%s%s", param.Email, param.ExternalId))
+	}
+	var list = make([]*entity.SubscriptionSeat, 0)
+	for _, param := range req.Seats {
+		one := &entity.SubscriptionSeat{
+			MerchantId:     sub.MerchantId,
+			UserId:         sub.UserId,
+			SubscriptionId: sub.SubscriptionId,
+			SeatId:         utility.CreateSubscriptionSeatId(),
+			Email:          param.Email,
+			ExternalId:     param.ExternalId,
+			Name:           param.Name,
+			Status:         consts.SubSeatStatusAssigned,
+			AssignTime:     gtime.Now().Timestamp(),
+			CreateTime:     gtime.Now().Timestamp(),
+		}
+		_, err := dao.SubscriptionSeat.Ctx(ctx).Data(one).OmitNil().Insert(one)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, one)
+	}
+	operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
+		MerchantId:     sub.MerchantId,
+		Target:         fmt.Sprintf("Subscription(%s)", sub.SubscriptionId),
+		Content:        fmt.Sprintf("AssignSeats(%d)", len(list)),
+		UserId:         sub.UserId,
+		SubscriptionId: sub.SubscriptionId,
+		InvoiceId:      "",
+		PlanId:         0,
+		DiscountCode:   "",
+	}, nil)
+	return list, nil
+}
+
+// SubscriptionSeatUnassign frees the seats assigned, the seats (quantity) of subscription are not changed
+func SubscriptionSeatUnassign(ctx context.Context, merchantId uint64, subscriptionId string, seatIds []string) error {
+	utility.Assert(len(seatIds) > 0, "seatIds not found")
+	sub := checkSeatSubscription(ctx, merchantId, subscriptionId)
+	for _, seatId := range seatIds {
+		one := query.GetSubscriptionSeatBySeatId(ctx, seatId)
+		utility.Assert(one != nil, fmt.Sprintf("seat not found:%s", seatId))
+		utility.Assert(one.SubscriptionId == sub.SubscriptionId, fmt.Sprintf("seat not belong to subscription:%s", seatId))
+		utility.Assert(one.Status == consts.SubSeatStatusAssigned, fmt.Sprintf("seat already removed:%s", seatId))
+	}
+	_, err := dao.SubscriptionSeat.Ctx(ctx).Data(g.Map{
+		dao.SubscriptionSeat.Columns().Status:     consts.SubSeatStatusRemoved,
+		dao.SubscriptionSeat.Columns().RemoveTime: gtime.Now().Timestamp(),
+		dao.SubscriptionSeat.Columns().GmtModify:  gtime.Now(),
+	}).Where(dao.SubscriptionSeat.Columns().SubscriptionId, sub.SubscriptionId).
+		WhereIn(dao.SubscriptionSeat.Columns().SeatId, seatIds).
+		Where(dao.SubscriptionSeat.Columns().Status, consts.SubSeatStatusAssigned).
+		OmitNil().Update()
+	operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
+		MerchantId:     sub.MerchantId,
+		Target:         fmt.Sprintf("Subscription(%s)", sub.SubscriptionId),
+		Content:        fmt.Sprintf("UnassignSeats(%d)", len(seatIds)),
+		UserId:         sub.UserId,
+		SubscriptionId: sub.SubscriptionId,
+		InvoiceId:      "",
+		PlanId:         0,
+		DiscountCode:   "",
+	}, err)
+	return err
+}
+
+// GetSeatRoster returns the seats of the merchant ordered by subscription, filtered by subscription and status if specified
+func GetSeatRoster(ctx context.Context, merchantId uint64, subscriptionId string, status int, page int, count int) ([]*entity.SubscriptionSeat, error) {
+	var list []*entity.SubscriptionSeat
+	q := dao.SubscriptionSeat.Ctx(ctx).
+		Where(dao.SubscriptionSeat.Columns().MerchantId, merchantId).
+		Where(dao.SubscriptionSeat.Columns().IsDeleted, 0)
+	if len(subscriptionId) > 0 {
+		q = q.Where(dao.SubscriptionSeat.Columns().SubscriptionId, subscriptionId)
+	}
+	if status > 0 {
+		q = q.Where(dao.SubscriptionSeat.Columns().Status, status)
+	}
+	if count > 0 {
+		q = q.Limit(page*count, count)
+	}
+	err := q.OrderAsc(dao.SubscriptionSeat.Columns().SubscriptionId).OrderAsc(dao.SubscriptionSeat.Columns().Id).Scan(&list)
+	if err != nil {
+		return nil, err
+	}
+	return list, nil
+}
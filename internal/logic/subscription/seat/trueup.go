@@ -0,0 +1,59 @@
+package seat
+
+import (
+	"math"
+
+	"unibee/internal/consts"
+	entity "unibee/internal/model/entity/default"
+	"unibee/utility"
+)
+
+// IsValidSeatProrationMode returns true when the mode is one of the seat proration modes
+func IsValidSeatProrationMode(mode string) bool {
+	return mode == consts.SeatProrationModeImmediate || mode == consts.SeatProrationModeTrueUp
+}
+
+type TrueUpItem struct {
+	ChangeTime     int64
+	Quantity       int64
+	ProrationScale int64 // 10000 = 100%
+}
+
+// TrueUpSeats returns the seats added in the period to charge with the next cycle invoice, prorated from the time added to the period end,
+// only the seats above the highest seats reached before are charged, the seats removed are not credited
+// and the changes applied without true-up raise the seats without charge
+func TrueUpSeats(periodStart int64, periodEnd int64, changes []*entity.SubscriptionSeatChange) []*TrueUpItem {
+	var items = make([]*TrueUpItem, 0)
+	if periodEnd <= periodStart || len(changes) == 0 {
+		return items
+	}
+	var highest = changes[0].FromQuantity
+	for _, change := range changes {
+		if change.ToQuantity <= highest {
+			continue
+		}
+		if change.Status == consts.SubSeatChangeStatusApplied {
+			highest = change.ToQuantity
+			continue
+		}
+		var changeTime = utility.MinInt64(utility.MaxInt64(change.ChangeTime, periodStart), periodEnd)
+		var scale = (periodEnd - changeTime) * 10000 / (periodEnd - periodStart)
+		if scale > 0 {
+			items = append(items, &TrueUpItem{
+				ChangeTime:     changeTime,
+				Quantity:       change.ToQuantity - highest,
+				ProrationScale: scale,
+			})
+		}
+		highest = change.ToQuantity
+	}
+	return items
+}
+
+// ProratedUnitAmount returns the unit amount of the seat scaled by the proration
+func ProratedUnitAmount(unitAmount int64, prorationScale int64) int64 {
+	if prorationScale >= 10000 {
+		return unitAmount
+	}
+	return int64(math.Round(float64(unitAmount) * utility.ConvertTaxPercentageToInternalFloat(prorationScale)))
+}
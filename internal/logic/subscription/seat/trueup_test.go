@@ -0,0 +1,54 @@
+package seat
+
+import (
+	"testing"
+
+	"unibee/internal/consts"
+	entity "unibee/internal/model/entity/default"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsValidSeatProrationMode(t *testing.T) {
+	require.True(t, IsValidSeatProrationMode(consts.SeatProrationModeImmediate))
+	require.True(t, IsValidSeatProrationMode(consts.SeatProrationModeTrueUp))
+	require.False(t, IsValidSeatProrationMode(""))
+	require.False(t, IsValidSeatProrationMode("period_end"))
+}
+
+func TestTrueUpSeats(t *testing.T) {
+	require.Equal(t, 0, len(TrueUpSeats(0, 10000, nil)))
+	// 5->8 at half period, 8->6, 6->9 at 80%, only one seat above the highest charged
+	items := TrueUpSeats(0, 10000, []*entity.SubscriptionSeatChange{
+		{FromQuantity: 5, ToQuantity: 8, ChangeTime: 5000, Status: consts.SubSeatChangeStatusPending},
+		{FromQuantity: 8, ToQuantity: 6, ChangeTime: 6000, Status: consts.SubSeatChangeStatusApplied},
+		{FromQuantity: 6, ToQuantity: 9, ChangeTime: 8000, Status: consts.SubSeatChangeStatusPending},
+	})
+	require.Equal(t, 2, len(items))
+	require.Equal(t, int64(3), items[0].Quantity)
+	require.Equal(t, int64(5000), items[0].ProrationScale)
+	require.Equal(t, int64(1), items[1].Quantity)
+	require.Equal(t, int64(2000), items[1].ProrationScale)
+	// the seats added with the immediate mode raise the highest without charge
+	items = TrueUpSeats(0, 10000, []*entity.SubscriptionSeatChange{
+		{FromQuantity: 2, ToQuantity: 4, ChangeTime: 1000, Status: consts.SubSeatChangeStatusApplied},
+		{FromQuantity: 4, ToQuantity: 5, ChangeTime: 2000, Status: consts.SubSeatChangeStatusBilled},
+	})
+	require.Equal(t, 1, len(items))
+	require.Equal(t, int64(1), items[0].Quantity)
+	require.Equal(t, int64(8000), items[0].ProrationScale)
+	// the change before the period start is charged for the whole period, at the period end not charged
+	items = TrueUpSeats(1000, 11000, []*entity.SubscriptionSeatChange{
+		{FromQuantity: 1, ToQuantity: 2, ChangeTime: 500, Status: consts.SubSeatChangeStatusPending},
+		{FromQuantity: 2, ToQuantity: 3, ChangeTime: 12000, Status: consts.SubSeatChangeStatusPending},
+	})
+	require.Equal(t, 1, len(items))
+	require.Equal(t, int64(10000), items[0].ProrationScale)
+}
+
+func TestProratedUnitAmount(t *testing.T) {
+	require.Equal(t, int64(1000), ProratedUnitAmount(1000, 10000))
+	require.Equal(t, int64(500), ProratedUnitAmount(1000, 5000))
+	require.Equal(t, int64(333), ProratedUnitAmount(1000, 3333))
+	require.Equal(t, int64(0), ProratedUnitAmount(1000, 0))
+}
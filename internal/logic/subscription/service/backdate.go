@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"unibee/api/bean"
+	"unibee/internal/logic/invoice/invoice_compute"
+	"unibee/internal/logic/plan/period"
+	entity "unibee/internal/model/entity/default"
+	"unibee/utility"
+
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+// creationPeriods checks the backdated start and the billing cycle anchor day of the creation,
+// returns the periods from the start to the current one and the billing cycle anchor of the subscription
+func creationPeriods(plan *entity.Plan, backdateStartTime int64, billingCycleAnchorDay int, timeNow int64) ([]*period.Period, int64) {
+	var start = timeNow
+	if backdateStartTime > 0 {
+		utility.Assert(backdateStartTime < timeNow, "backdateStartTime should be earlier than now")
+		start = backdateStartTime
+	}
+	var billingCycleAnchor = start
+	if billingCycleAnchorDay > 0 {
+		utility.Assert(period.IsValidBillingCycleAnchorDay(billingCycleAnchorDay), "billingCycleAnchorDay should be between 1 and 28")
+		var unit = strings.ToLower(plan.IntervalUnit)
+		utility.Assert(unit == "month" || unit == "year", "billingCycleAnchorDay only available for month or year plan")
+		billingCycleAnchor = period.BillingCycleAnchorFromDay(start, billingCycleAnchorDay)
+	}
+	periods, ok := period.PeriodsFromStart(plan, start, billingCycleAnchor, timeNow)
+	utility.Assert(ok, fmt.Sprintf("backdateStartTime too early, more than %d periods to catch up", period.MaxBackdatedPeriods))
+	return periods, billingCycleAnchor
+}
+
+// catchUpInvoiceItems returns the plan and addons of the elapsed periods of a backdated creation, charged with the first invoice
+func catchUpInvoiceItems(ctx context.Context, plan *entity.Plan, addons []*bean.PlanAddonDetail, quantity int64, currency string, periods []*period.Period) []*invoice_compute.AdditionalInvoiceItem {
+	var list = make([]*invoice_compute.AdditionalInvoiceItem, 0)
+	for _, one := range periods {
+		var isProration = one.ProrationScale > 0 && one.ProrationScale < 10000
+		var periodName = fmt.Sprintf("(%s-%s)", gtime.NewFromTimeStamp(one.Start).Layout("2006-01-02"), gtime.NewFromTimeStamp(one.End).Layout("2006-01-02"))
+		list = append(list, &invoice_compute.AdditionalInvoiceItem{
+			Name:                   fmt.Sprintf("%s Catch-up", plan.PlanName),
+			Description:            fmt.Sprintf("%d * %s %s", quantity, plan.PlanName, periodName),
+			UnitAmountExcludingTax: invoice_compute.ProrateUnitAmount(plan.CurrencyAmount(ctx, currency), one.ProrationScale),
+			Quantity:               quantity,
+			Proration:              isProration,
+		})
+		for _, addon := range addons {
+			list = append(list, &invoice_compute.AdditionalInvoiceItem{
+				Name:                   fmt.Sprintf("%s Catch-up", addon.AddonPlan.PlanName),
+				Description:            fmt.Sprintf("%d * %s %s", addon.Quantity, addon.AddonPlan.PlanName, periodName),
+				UnitAmountExcludingTax: invoice_compute.ProrateUnitAmount(addon.AddonPlan.CurrencyAmount(ctx, currency), one.ProrationScale),
+				Quantity:               addon.Quantity,
+				Proration:              isProration,
+			})
+		}
+	}
+	return list
+}
@@ -23,6 +23,7 @@ import (
 	"unibee/internal/logic/operation_log"
 	"unibee/internal/logic/payment/method"
 	"unibee/internal/logic/payment/service"
+	plan2 "unibee/internal/logic/plan"
 	"unibee/internal/logic/plan/period"
 	"unibee/internal/logic/subscription/handler"
 	"unibee/internal/logic/subscription/timeline"
@@ -61,6 +62,10 @@ type CreatePreviewInternalReq struct {
 	Metadata               map[string]interface{} `json:"metadata" dc:"Metadata，Map"`
 	ApplyPromoCredit       *bool                  `json:"applyPromoCredit" `
 	ApplyPromoCreditAmount *int64                 `json:"applyPromoCreditAmount"  dc:"apply promo credit amount, auto compute if not specified"`
+	BackdateStartTime      int64                  `json:"backdateStartTime" dc:"The start time of subscription in the past, utc time"`
+	BackdateCatchUp        bool                   `json:"backdateCatchUp" dc:"Charge the elapsed periods of the backdated start with the first invoice or skip them"`
+	BillingCycleAnchorDay  int                    `json:"billingCycleAnchorDay" dc:"The day of month the billing cycle anchors to, 1-28, the first period is prorated to the anchor"`
+	CardLessTrial          bool                   `json:"cardLessTrial" dc:"Start the trial without gateway and payment method"`
 }
 
 type CreatePreviewInternalRes struct {
@@ -125,6 +130,10 @@ type CreateInternalReq struct {
 	ProductData            *bean.PlanProductParam      `json:"productData"  dc:"ProductData"  `
 	ApplyPromoCredit       bool                        `json:"applyPromoCredit" `
 	ApplyPromoCreditAmount *int64                      `json:"applyPromoCreditAmount"  dc:"apply promo credit amount, auto compute if not specified"`
+	BackdateStartTime      int64                       `json:"backdateStartTime" dc:"The start time of subscription in the past, utc time"`
+	BackdateCatchUp        bool                        `json:"backdateCatchUp" dc:"Charge the elapsed periods of the backdated start with the first invoice or skip them"`
+	BillingCycleAnchorDay  int                         `json:"billingCycleAnchorDay" dc:"The day of month the billing cycle anchors to, 1-28, the first period is prorated to the anchor"`
+	CardLessTrial          bool                        `json:"cardLessTrial" dc:"Start the trial without gateway and payment method"`
 }
 
 type CreateInternalRes struct {
@@ -143,7 +152,7 @@ func SubscriptionCreatePreview(ctx context.Context, req *CreatePreviewInternalRe
 	utility.Assert(req.PlanId > 0, "PlanId invalid")
 	if req.IsSubmit {
 		utility.Assert(req.UserId > 0, "UserId invalid")
-		utility.Assert(req.GatewayId != nil, "Gateway invalid")
+		utility.Assert(req.GatewayId != nil || req.CardLessTrial, "Gateway invalid")
 	}
 	plan := query.GetPlanById(ctx, req.PlanId)
 	utility.Assert(plan != nil, "invalid planId")
@@ -169,7 +178,7 @@ func SubscriptionCreatePreview(ctx context.Context, req *CreatePreviewInternalRe
 		gatewayId, paymentType, paymentMethodId = sub_update.VerifyPaymentGatewayMethod(ctx, user.Id, req.GatewayId, req.GatewayPaymentType, req.PaymentMethodId, "")
 	}
 	var gateway *entity.MerchantGateway
-	if gatewayId > 0 || req.IsSubmit {
+	if gatewayId > 0 || (req.IsSubmit && !req.CardLessTrial) {
 		utility.Assert(gatewayId > 0, "gateway need specified")
 		gateway = query.GetGatewayById(ctx, gatewayId)
 		utility.Assert(gateway != nil, "gateway not found")
@@ -182,6 +191,7 @@ func SubscriptionCreatePreview(ctx context.Context, req *CreatePreviewInternalRe
 	utility.Assert(merchantInfo != nil, "merchant not found")
 
 	req.Quantity = utility.MaxInt64(1, req.Quantity)
+	utility.AssertError(plan2.CheckPlanSeatLimit(plan, req.Quantity), "invalid quantity")
 	userEmail := ""
 	if user != nil {
 		userEmail = user.Email
@@ -198,6 +208,8 @@ func SubscriptionCreatePreview(ctx context.Context, req *CreatePreviewInternalRe
 		}
 		if req.IsSubmit {
 			utility.Assert(otherSameProductActiveSubscription == nil, i18n.LocalizationFormat(ctx, "{#SubDuplicateCreation}"))
+			// the paused subscription resumes instead of a new one
+			utility.Assert(query.GetLatestSuspendedSubscriptionByUserId(ctx, user.Id, merchantInfo.Id, plan.ProductId) == nil, i18n.LocalizationFormat(ctx, "{#SubDuplicateCreation}"))
 		}
 	}
 
@@ -318,6 +330,7 @@ func SubscriptionCreatePreview(ctx context.Context, req *CreatePreviewInternalRe
 	utility.Assert(len(plan.IntervalUnit) > 0, "Invalid plan billing period")
 	if plan.TrialDurationTime > 0 || req.TrialEnd > 0 {
 		//trial period
+		utility.Assert(req.BackdateStartTime == 0 && req.BillingCycleAnchorDay == 0, "backdateStartTime or billingCycleAnchorDay not available for trial")
 		if plan.TrialAmount > 0 {
 			utility.Assert(len(addons) == 0, "addon is not available for charge trial plan")
 		}
@@ -430,6 +443,22 @@ func SubscriptionCreatePreview(ctx context.Context, req *CreatePreviewInternalRe
 		}, nil
 	} else {
 		//var currentTimeEnd = subscription2.GetPeriodEndFromStart(ctx, currentTimeStart.Timestamp(), currentTimeStart.Timestamp(), req.PlanId)
+		var currentPeriodStart = currentTimeStart.Timestamp()
+		var billingCycleAnchor = currentTimeStart.Timestamp()
+		var prorationScale int64 = 0
+		var catchUpItems []*invoice_compute.AdditionalInvoiceItem
+		if req.BackdateStartTime > 0 || req.BillingCycleAnchorDay > 0 {
+			// backdated or anchored creation, charge the current period, the elapsed periods as catch-up if needed
+			var periods []*period.Period
+			periods, billingCycleAnchor = creationPeriods(plan, req.BackdateStartTime, req.BillingCycleAnchorDay, currentTimeStart.Timestamp())
+			current := periods[len(periods)-1]
+			currentPeriodStart = current.Start
+			currentTimeEnd = current.End
+			prorationScale = current.ProrationScale
+			if req.BackdateCatchUp {
+				catchUpItems = catchUpInvoiceItems(ctx, plan, addons, req.Quantity, currency, periods[:len(periods)-1])
+			}
+		}
 		invoice := invoice_compute.ComputeSubscriptionBillingCycleInvoiceDetailSimplify(ctx, &invoice_compute.CalculateInvoiceReq{
 			UserId:                 req.UserId,
 			InvoiceName:            "SubscriptionCreate",
@@ -442,14 +471,16 @@ func SubscriptionCreatePreview(ctx context.Context, req *CreatePreviewInternalRe
 			CountryCode:            vatCountryCode,
 			VatNumber:              validVatNumber,
 			TaxPercentage:          subscriptionTaxPercentage,
-			PeriodStart:            currentTimeStart.Timestamp(),
+			PeriodStart:            currentPeriodStart,
 			PeriodEnd:              currentTimeEnd,
 			FinishTime:             currentTimeStart.Timestamp(),
 			ProductData:            req.ProductData,
-			BillingCycleAnchor:     currentTimeStart.Timestamp(),
+			BillingCycleAnchor:     billingCycleAnchor,
 			Metadata:               req.Metadata,
 			ApplyPromoCredit:       *req.ApplyPromoCredit,
 			ApplyPromoCreditAmount: req.ApplyPromoCreditAmount,
+			ProrationScale:         prorationScale,
+			AdditionalItems:        catchUpItems,
 		})
 
 		return &CreatePreviewInternalRes{
@@ -513,7 +544,7 @@ func SubscriptionCreate(ctx context.Context, req *CreateInternalReq) (*CreateInt
 	utility.Assert(plan != nil, "invalid planId")
 	existOne := query.GetLatestCreateOrProcessingSubscriptionByUserId(ctx, req.UserId, req.MerchantId, plan.ProductId)
 	if existOne != nil {
-		err := SubscriptionCancel(ctx, existOne.SubscriptionId, false, false, "CancelledByAnotherCreation")
+		err := SubscriptionCancelBySystem(ctx, existOne.SubscriptionId, "CancelledByAnotherCreation")
 		utility.AssertError(err, "Subscription cancel error")
 	}
 
@@ -538,10 +569,18 @@ func SubscriptionCreate(ctx context.Context, req *CreateInternalReq) (*CreateInt
 		Metadata:               req.Metadata,
 		ApplyPromoCredit:       unibee.Bool(req.ApplyPromoCredit),
 		ApplyPromoCreditAmount: req.ApplyPromoCreditAmount,
+		BackdateStartTime:      req.BackdateStartTime,
+		BackdateCatchUp:        req.BackdateCatchUp,
+		BillingCycleAnchorDay:  req.BillingCycleAnchorDay,
+		CardLessTrial:          req.CardLessTrial,
 	})
 	if err != nil {
 		return nil, err
 	}
+	if req.CardLessTrial {
+		utility.Assert(prepare.Invoice.TotalAmount == 0, "Card-less trial should start with zero amount")
+		utility.Assert(!strings.Contains(prepare.Plan.TrialDemand, "paymentMethod"), "Card-less trial not available for plan with payment method demand")
+	}
 	// todo mark countryCode is required or node
 	// utility.Assert(len(prepare.VatCountryCode) > 0, "CountryCode Needed")
 	if req.ConfirmTotalAmount > 0 {
@@ -565,13 +604,17 @@ func SubscriptionCreate(ctx context.Context, req *CreateInternalReq) (*CreateInt
 	}
 
 	var dunningTime = period.GetDunningTimeFromEnd(ctx, utility.MaxInt64(prepare.Invoice.PeriodEnd, prepare.TrialEnd), prepare.Plan.Id)
+	var gatewayId uint64 = 0
+	if prepare.Gateway != nil {
+		gatewayId = prepare.Gateway.Id
+	}
 
 	one := &entity.Subscription{
 		MerchantId:                  prepare.Merchant.Id,
 		Type:                        subType,
 		PlanId:                      prepare.Plan.Id,
 		TrialEnd:                    prepare.TrialEnd,
-		GatewayId:                   prepare.Gateway.Id,
+		GatewayId:                   gatewayId,
 		UserId:                      prepare.UserId,
 		Quantity:                    prepare.Quantity,
 		Amount:                      prepare.TotalAmount, // todo mark should use originAmount
@@ -21,6 +21,7 @@ import (
 	"unibee/internal/logic/operation_log"
 	"unibee/internal/logic/payment/service"
 	"unibee/internal/logic/plan/period"
+	"unibee/internal/logic/subscription/commitment/term"
 	"unibee/internal/logic/subscription/handler"
 	"unibee/internal/logic/user/sub_update"
 	"unibee/internal/logic/vat_gateway"
@@ -92,7 +93,17 @@ func GetSubscriptionZeroPaymentLink(invoice *entity.Invoice, returnUrl string, s
 	}
 }
 
+// SubscriptionCancel cancels the subscription immediately, refused during the commitment term of the subscription
 func SubscriptionCancel(ctx context.Context, subscriptionId string, proration bool, invoiceNow bool, reason string) error {
+	return subscriptionCancel(ctx, subscriptionId, proration, invoiceNow, reason, true)
+}
+
+// SubscriptionCancelBySystem cancels the subscription immediately for the system reasons, the commitment term is not checked
+func SubscriptionCancelBySystem(ctx context.Context, subscriptionId string, reason string) error {
+	return subscriptionCancel(ctx, subscriptionId, false, false, reason, false)
+}
+
+func subscriptionCancel(ctx context.Context, subscriptionId string, proration bool, invoiceNow bool, reason string, checkCommitment bool) error {
 	utility.Assert(len(subscriptionId) > 0, "subscriptionId not found")
 	sub := query.GetSubscriptionBySubscriptionId(ctx, subscriptionId)
 	utility.Assert(sub != nil, "subscription not found")
@@ -100,6 +111,12 @@ func SubscriptionCancel(ctx context.Context, subscriptionId string, proration bo
 		g.Log().Infof(ctx, "SubscriptionCancel, subscription already cancelled or expired")
 		return nil
 	}
+	if checkCommitment {
+		err := term.CheckCancel(query.GetActiveSubscriptionCommitmentBySubscriptionId(ctx, sub.SubscriptionId), utility.MaxInt64(gtime.Now().Timestamp(), sub.TestClock))
+		if err != nil {
+			return err
+		}
+	}
 	plan := query.GetPlanById(ctx, sub.PlanId)
 	gateway := query.GetGatewayById(ctx, sub.GatewayId)
 	utility.Assert(gateway != nil, "gateway not found")
@@ -172,7 +189,19 @@ func SubscriptionCancel(ctx context.Context, subscriptionId string, proration bo
 	return nil
 }
 
+// SubscriptionCancelAtPeriodEnd cancels the subscription at period end, the cancellation noticed during the commitment term
+// waits for the term end, refused when the notice deadline of the term renewed automatically passed
 func SubscriptionCancelAtPeriodEnd(ctx context.Context, subscriptionId string, proration bool, merchantMemberId int64) error {
+	return subscriptionCancelAtPeriodEnd(ctx, subscriptionId, proration, merchantMemberId, true)
+}
+
+// SubscriptionCancelAtPeriodEndBySystem cancels the subscription at period end for the system, like the schedule ending with cancel,
+// the commitment term is checked where the system action is configured
+func SubscriptionCancelAtPeriodEndBySystem(ctx context.Context, subscriptionId string, merchantMemberId int64) error {
+	return subscriptionCancelAtPeriodEnd(ctx, subscriptionId, false, merchantMemberId, false)
+}
+
+func subscriptionCancelAtPeriodEnd(ctx context.Context, subscriptionId string, proration bool, merchantMemberId int64, checkCommitment bool) error {
 	utility.Assert(len(subscriptionId) > 0, "subscriptionId not found")
 	sub := query.GetSubscriptionBySubscriptionId(ctx, subscriptionId)
 	utility.Assert(sub != nil, "subscription not found")
@@ -180,13 +209,25 @@ func SubscriptionCancelAtPeriodEnd(ctx context.Context, subscriptionId string, p
 	if sub.CancelAtPeriodEnd == 1 {
 		return nil
 	}
+	var err error
+	if checkCommitment {
+		commitment := query.GetActiveSubscriptionCommitmentBySubscriptionId(ctx, sub.SubscriptionId)
+		atTermEnd, err := term.CheckCancelAtPeriodEnd(commitment, sub.CurrentPeriodEnd, utility.MaxInt64(gtime.Now().Timestamp(), sub.TestClock))
+		if err != nil {
+			return err
+		}
+		if atTermEnd {
+			// noticed before the deadline, the commitment walk cancels the subscription at the term end
+			return setCommitmentCancelAtTermEnd(ctx, sub, commitment, true)
+		}
+	}
 
 	plan := query.GetPlanById(ctx, sub.PlanId)
 	gateway := query.GetGatewayById(ctx, sub.GatewayId)
 	utility.Assert(gateway != nil, "gateway not found")
 	merchantInfo := query.GetMerchantById(ctx, plan.MerchantId)
 	utility.Assert(merchantInfo != nil, "merchant not found")
-	_, err := dao.Subscription.Ctx(ctx).Data(g.Map{
+	_, err = dao.Subscription.Ctx(ctx).Data(g.Map{
 		dao.Subscription.Columns().CancelAtPeriodEnd: 1,
 		dao.Subscription.Columns().GmtModify:         gtime.Now(),
 	}).Where(dao.Subscription.Columns().SubscriptionId, subscriptionId).OmitNil().Update()
@@ -246,11 +287,61 @@ func SubscriptionCancelAtPeriodEnd(ctx context.Context, subscriptionId string, p
 	return nil
 }
 
+// setCommitmentCancelAtTermEnd records or withdraws the cancellation at the term end of the commitment
+func setCommitmentCancelAtTermEnd(ctx context.Context, sub *entity.Subscription, commitment *entity.SubscriptionCommitment, cancel bool) error {
+	var cancelAtTermEnd = 0
+	var noticeTime int64 = 0
+	var content = "WithdrawCancelAtTermEnd"
+	if cancel {
+		cancelAtTermEnd = 1
+		noticeTime = utility.MaxInt64(gtime.Now().Timestamp(), sub.TestClock)
+		content = "CancelAtTermEnd"
+	}
+	if commitment.CancelAtTermEnd == cancelAtTermEnd {
+		return nil
+	}
+	result, err := dao.SubscriptionCommitment.Ctx(ctx).Data(g.Map{
+		dao.SubscriptionCommitment.Columns().CancelAtTermEnd: cancelAtTermEnd,
+		dao.SubscriptionCommitment.Columns().NoticeTime:      noticeTime,
+		dao.SubscriptionCommitment.Columns().GmtModify:       gtime.Now(),
+	}).Where(dao.SubscriptionCommitment.Columns().Id, commitment.Id).
+		Where(dao.SubscriptionCommitment.Columns().Status, consts.SubCommitmentStatusActive).
+		OmitNil().Update()
+	if err == nil {
+		affected, _ := result.RowsAffected()
+		if affected != 1 {
+			err = fmt.Errorf("commitment already finished")
+		}
+	}
+	operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
+		MerchantId:     sub.MerchantId,
+		Target:         fmt.Sprintf("SubscriptionCommitment(%s)", commitment.CommitmentId),
+		Content:        content,
+		UserId:         sub.UserId,
+		SubscriptionId: sub.SubscriptionId,
+		InvoiceId:      "",
+		PlanId:         sub.PlanId,
+		DiscountCode:   "",
+	}, err)
+	if err != nil {
+		return err
+	}
+	commitment.CancelAtTermEnd = cancelAtTermEnd
+	commitment.NoticeTime = noticeTime
+	return nil
+}
+
 func SubscriptionCancelLastCancelAtPeriodEnd(ctx context.Context, subscriptionId string, proration bool) error {
 	utility.Assert(len(subscriptionId) > 0, "subscriptionId not found")
 	sub := query.GetSubscriptionBySubscriptionId(ctx, subscriptionId)
 	utility.Assert(sub != nil, "subscription not found")
 	utility.Assert(sub.Status == consts.SubStatusActive, "subscription not in active status")
+	if commitment := query.GetActiveSubscriptionCommitmentBySubscriptionId(ctx, sub.SubscriptionId); commitment != nil && commitment.CancelAtTermEnd == 1 {
+		err := setCommitmentCancelAtTermEnd(ctx, sub, commitment, false)
+		if err != nil {
+			return err
+		}
+	}
 	if sub.CancelAtPeriodEnd == 0 {
 		return nil
 	}
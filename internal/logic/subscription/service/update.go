@@ -26,8 +26,10 @@ import (
 	plan2 "unibee/internal/logic/plan"
 	"unibee/internal/logic/plan/period"
 	addon2 "unibee/internal/logic/subscription/addon"
+	"unibee/internal/logic/subscription/commitment/term"
 	"unibee/internal/logic/subscription/config"
 	"unibee/internal/logic/subscription/handler"
+	"unibee/internal/logic/subscription/pending_item"
 	"unibee/internal/logic/subscription/pending_update_cancel"
 	"unibee/internal/logic/subscription/service/next"
 	"unibee/internal/logic/user/sub_update"
@@ -125,6 +127,18 @@ func isUpgradeForSubscription(ctx context.Context, sub *entity.Subscription, pla
 	return
 }
 
+// IsDowngradeForSubscription returns true when the subscription changed to the plan, quantity and addons is a downgrade
+func IsDowngradeForSubscription(ctx context.Context, sub *entity.Subscription, plan *entity.Plan, quantity int64, addonParams []*bean.PlanAddonParam) bool {
+	isUpgrade, _, changed := isUpgradeForSubscription(ctx, sub, plan, quantity, addonParams)
+	return changed && !isUpgrade
+}
+
+// CheckCommitmentDowngrade returns the time the downgrade of the subscription noticed during its commitment term takes effect, 0 without term,
+// or error when the downgrade is immediate or noticed too late, the downgrade not effect immediately takes effect at the periodEnd otherwise
+func CheckCommitmentDowngrade(ctx context.Context, sub *entity.Subscription, periodEnd int64, effectImmediate bool) (int64, error) {
+	return term.CheckDowngrade(query.GetActiveSubscriptionCommitmentBySubscriptionId(ctx, sub.SubscriptionId), periodEnd, effectImmediate, utility.MaxInt64(gtime.Now().Timestamp(), sub.TestClock))
+}
+
 type UpdatePreviewInternalReq struct {
 	SubscriptionId         string                 `json:"subscriptionId" dc:"SubscriptionId" v:"required"`
 	NewPlanId              uint64                 `json:"newPlanId" dc:"NewPlanId" v:"required"`
@@ -141,37 +155,41 @@ type UpdatePreviewInternalReq struct {
 	Metadata               map[string]interface{} `json:"metadata" dc:"Metadata，Map"`
 	ApplyPromoCredit       *bool                  `json:"applyPromoCredit" dc:"apply promo credit or not"`
 	ApplyPromoCreditAmount *int64                 `json:"applyPromoCreditAmount"  dc:"apply promo credit amount, auto compute if not specified"`
+	ProrationBehavior      string                 `json:"prorationBehavior" dc:"ProrationBehavior, create_prorations|prorate_to_next_invoice|none|always_invoice, follow the subscription config if not specified"`
 }
 
 type UpdatePreviewInternalRes struct {
-	Subscription          *entity.Subscription       `json:"subscription"`
-	Plan                  *entity.Plan               `json:"plan"`
-	Quantity              int64                      `json:"quantity"`
-	Gateway               *entity.MerchantGateway    `json:"gateway"`
-	MerchantInfo          *entity.Merchant           `json:"merchantInfo"`
-	AddonParams           []*bean.PlanAddonParam     `json:"addonParams"`
-	Addons                []*bean.PlanAddonDetail    `json:"addons"`
-	OriginAmount          int64                      `json:"originAmount"                `
-	TotalAmount           int64                      `json:"totalAmount"`
-	DiscountAmount        int64                      `json:"discountAmount"`
-	Currency              string                     `json:"currency"`
-	UserId                uint64                     `json:"userId"`
-	OldPlan               *entity.Plan               `json:"oldPlan"`
-	Invoice               *bean.Invoice              `json:"invoice"`
-	NextPeriodInvoice     *bean.Invoice              `json:"nextPeriodInvoice"`
-	ProrationDate         int64                      `json:"prorationDate"`
-	EffectImmediate       bool                       `json:"EffectImmediate"`
-	Gateways              []*detail.Gateway          `json:"gateways"`
-	Changed               bool                       `json:"changed"`
-	IsUpgrade             bool                       `json:"isUpgrade"`
-	TaxPercentage         int64                      `json:"taxPercentage" `
-	RecurringDiscountCode string                     `json:"recurringDiscountCode" `
-	Discount              *bean.MerchantDiscountCode `json:"discount" `
-	DiscountMessage       string                     `json:"discountMessage" `
-	PaymentMethodId       string                     `json:"paymentMethodId" `
-	GatewayPaymentType    string                     `json:"gatewayPaymentType" `
-	ApplyPromoCredit      bool                       `json:"applyPromoCredit" dc:"apply promo credit or not"`
-	ProrationAmount       int64                      `json:"prorationAmount" `
+	Subscription          *entity.Subscription                   `json:"subscription"`
+	Plan                  *entity.Plan                           `json:"plan"`
+	Quantity              int64                                  `json:"quantity"`
+	Gateway               *entity.MerchantGateway                `json:"gateway"`
+	MerchantInfo          *entity.Merchant                       `json:"merchantInfo"`
+	AddonParams           []*bean.PlanAddonParam                 `json:"addonParams"`
+	Addons                []*bean.PlanAddonDetail                `json:"addons"`
+	OriginAmount          int64                                  `json:"originAmount"                `
+	TotalAmount           int64                                  `json:"totalAmount"`
+	DiscountAmount        int64                                  `json:"discountAmount"`
+	Currency              string                                 `json:"currency"`
+	UserId                uint64                                 `json:"userId"`
+	OldPlan               *entity.Plan                           `json:"oldPlan"`
+	Invoice               *bean.Invoice                          `json:"invoice"`
+	NextPeriodInvoice     *bean.Invoice                          `json:"nextPeriodInvoice"`
+	ProrationDate         int64                                  `json:"prorationDate"`
+	EffectImmediate       bool                                   `json:"EffectImmediate"`
+	Gateways              []*detail.Gateway                      `json:"gateways"`
+	Changed               bool                                   `json:"changed"`
+	IsUpgrade             bool                                   `json:"isUpgrade"`
+	TaxPercentage         int64                                  `json:"taxPercentage" `
+	RecurringDiscountCode string                                 `json:"recurringDiscountCode" `
+	Discount              *bean.MerchantDiscountCode             `json:"discount" `
+	DiscountMessage       string                                 `json:"discountMessage" `
+	PaymentMethodId       string                                 `json:"paymentMethodId" `
+	GatewayPaymentType    string                                 `json:"gatewayPaymentType" `
+	ApplyPromoCredit      bool                                   `json:"applyPromoCredit" dc:"apply promo credit or not"`
+	ProrationAmount       int64                                  `json:"prorationAmount" `
+	ProrationBehavior     string                                 `json:"prorationBehavior" `
+	NextInvoiceItem       *invoice_compute.AdditionalInvoiceItem `json:"nextInvoiceItem" dc:"the proration added to the next cycle invoice as pending invoice item"`
+	CommitmentEffectTime  int64                                  `json:"commitmentEffectTime" dc:"the time the downgrade noticed during the commitment term takes effect, 0 without term"`
 }
 
 func SubscriptionUpdatePreview(ctx context.Context, req *UpdatePreviewInternalReq, prorationDate int64, merchantMemberId int64) (res *UpdatePreviewInternalRes, err error) {
@@ -180,6 +198,7 @@ func SubscriptionUpdatePreview(ctx context.Context, req *UpdatePreviewInternalRe
 	utility.Assert(len(req.SubscriptionId) > 0, "SubscriptionId invalid")
 	sub := query.GetSubscriptionBySubscriptionId(ctx, req.SubscriptionId)
 	utility.Assert(sub != nil, "subscription not found")
+	utility.Assert(query.GetActiveSubscriptionScheduleBySubscriptionId(ctx, sub.SubscriptionId) == nil, "subscription is managed by schedule, release the schedule first")
 	var currency = sub.Currency
 
 	plan := query.GetPlanById(ctx, req.NewPlanId)
@@ -203,6 +222,8 @@ func SubscriptionUpdatePreview(ctx context.Context, req *UpdatePreviewInternalRe
 	if req.Quantity <= 0 {
 		req.Quantity = 1
 	}
+	utility.AssertError(plan2.CheckPlanSeatLimit(plan, req.Quantity), "invalid quantity")
+	utility.Assert(req.Quantity >= query.CountAssignedSubscriptionSeats(ctx, sub.SubscriptionId), "quantity less than the seats assigned, unassign seats first")
 	addons := checkAndListAddonsFromParams(ctx, req.AddonParams)
 	var subscriptionTaxPercentage = sub.TaxPercentage
 	percentage, countryCode, vatNumber, err := vat.GetUserTaxPercentage(ctx, sub.UserId)
@@ -264,10 +285,25 @@ func SubscriptionUpdatePreview(ctx context.Context, req *UpdatePreviewInternalRe
 		effectImmediate = true
 	}
 
+	if len(req.ProrationBehavior) > 0 {
+		utility.AssertError(checkProrationBehavior(req.ProrationBehavior, req.EffectImmediate, hasIntervalChange), "invalid prorationBehavior")
+		effectImmediate = true
+	}
+
+	var commitmentEffectTime int64 = 0
+	if changed && !isUpgrade {
+		var err error
+		commitmentEffectTime, err = CheckCommitmentDowngrade(ctx, sub, sub.CurrentPeriodEnd, effectImmediate)
+		utility.AssertError(err, "commitment term")
+	}
+
 	var currentInvoice *bean.Invoice
 	var nextPeriodInvoice *bean.Invoice
 	var recurringDiscountCode string
 	var discountMessage string
+	if prorationDate > 0 && len(req.ProrationBehavior) > 0 && sub.Status == consts.SubStatusActive {
+		utility.Assert(prorationDate >= sub.CurrentPeriodStart && prorationDate <= sub.CurrentPeriodEnd, "prorationDate should be within the current period of subscription")
+	}
 	if prorationDate == 0 {
 		prorationDate = time.Now().Unix()
 		if sub.TestClock > prorationDate && !config2.GetConfigInstance().IsProd() {
@@ -335,8 +371,9 @@ func SubscriptionUpdatePreview(ctx context.Context, req *UpdatePreviewInternalRe
 	}
 
 	var prorationAmount int64
+	var nextInvoiceItem *invoice_compute.AdditionalInvoiceItem
 	if effectImmediate {
-		if sub.Status != consts.SubStatusActive || !config.GetMerchantSubscriptionConfig(ctx, sub.MerchantId).UpgradeProration {
+		if sub.Status != consts.SubStatusActive || (!config.GetMerchantSubscriptionConfig(ctx, sub.MerchantId).UpgradeProration && len(req.ProrationBehavior) == 0) {
 			// without proration, just generate next cycle
 			currentInvoice = invoice_compute.ComputeSubscriptionBillingCycleInvoiceDetailSimplify(ctx, &invoice_compute.CalculateInvoiceReq{
 				UserId:                 sub.UserId,
@@ -495,6 +532,7 @@ func SubscriptionUpdatePreview(ctx context.Context, req *UpdatePreviewInternalRe
 				})
 				prorationAmount = currentInvoice.TotalAmount
 			}
+			currentInvoice, nextInvoiceItem = applyProrationBehavior(req.ProrationBehavior, plan.PlanName, req.Quantity, currentInvoice)
 		}
 		prorationDate = currentInvoice.ProrationDate
 	} else {
@@ -559,6 +597,7 @@ func SubscriptionUpdatePreview(ctx context.Context, req *UpdatePreviewInternalRe
 			Metadata:               req.Metadata,
 			ApplyPromoCredit:       config3.CheckCreditConfigRecurring(ctx, sub.MerchantId, consts.CreditAccountTypePromo, currency),
 			ApplyPromoCreditAmount: applyPromoCreditAmount,
+			AdditionalItems:        nextInvoiceItems(nextInvoiceItem),
 		})
 	} else {
 		if len(req.DiscountCode) > 0 {
@@ -587,7 +626,7 @@ func SubscriptionUpdatePreview(ctx context.Context, req *UpdatePreviewInternalRe
 		})
 	}
 
-	if currentInvoice.TotalAmount <= 0 && !isUpgrade {
+	if currentInvoice.TotalAmount <= 0 && !isUpgrade && !isProrationBehaviorEffectImmediately(req.ProrationBehavior) && commitmentEffectTime == 0 {
 		effectImmediate = config.GetMerchantSubscriptionConfig(ctx, sub.MerchantId).DowngradeEffectImmediately
 	}
 
@@ -620,9 +659,90 @@ func SubscriptionUpdatePreview(ctx context.Context, req *UpdatePreviewInternalRe
 		GatewayPaymentType:    paymentType,
 		ApplyPromoCredit:      *req.ApplyPromoCredit,
 		ProrationAmount:       prorationAmount,
+		ProrationBehavior:     req.ProrationBehavior,
+		NextInvoiceItem:       nextInvoiceItem,
+		CommitmentEffectTime:  commitmentEffectTime,
 	}, nil
 }
 
+// isProrationBehaviorEffectImmediately returns true when the proration behavior effects the update immediately whatever the amount is,
+// create_prorations follows the downgrade config when nothing to charge
+func isProrationBehaviorEffectImmediately(behavior string) bool {
+	return behavior == consts.ProrationBehaviorProrateToNextInvoice ||
+		behavior == consts.ProrationBehaviorNone ||
+		behavior == consts.ProrationBehaviorAlwaysInvoice
+}
+
+// checkProrationBehavior returns error when the proration behavior not available for the update,
+// the proration behavior effects the update immediately and the recurring interval changed only charges the proration now
+func checkProrationBehavior(behavior string, effectImmediate int, hasIntervalChange bool) error {
+	if !pending_item.IsValidProrationBehavior(behavior) {
+		return gerror.New("prorationBehavior should be one of create_prorations|prorate_to_next_invoice|none|always_invoice")
+	}
+	if effectImmediate == 2 {
+		return gerror.New("prorationBehavior only available for the update effect immediately")
+	}
+	if hasIntervalChange && behavior != consts.ProrationBehaviorCreateProrations && behavior != consts.ProrationBehaviorAlwaysInvoice {
+		return gerror.New("prorationBehavior should be create_prorations or always_invoice while the recurring interval changed")
+	}
+	return nil
+}
+
+// applyProrationBehavior returns the proration invoice charged now and the proration moved to the next cycle invoice,
+// prorate_to_next_invoice moves the proration and always_invoice moves the credit, none drops the proration
+func applyProrationBehavior(behavior string, planName string, quantity int64, currentInvoice *bean.Invoice) (*bean.Invoice, *invoice_compute.AdditionalInvoiceItem) {
+	if behavior == consts.ProrationBehaviorProrateToNextInvoice ||
+		(behavior == consts.ProrationBehaviorAlwaysInvoice && currentInvoice.TotalAmount < 0) {
+		// the promo credit not consumed by the proration moved to the next cycle invoice
+		return zeroProrationInvoice(currentInvoice), &invoice_compute.AdditionalInvoiceItem{
+			Name:                   fmt.Sprintf("%s Proration", planName),
+			Description:            fmt.Sprintf("Proration of %d * %s (%s-%s)", quantity, planName, gtime.NewFromTimeStamp(currentInvoice.ProrationDate).Layout("2006-01-02"), gtime.NewFromTimeStamp(currentInvoice.PeriodEnd).Layout("2006-01-02")),
+			UnitAmountExcludingTax: currentInvoice.TotalAmountExcludingTax + currentInvoice.PromoCreditDiscountAmount,
+			Quantity:               1,
+			Proration:              true,
+		}
+	} else if behavior == consts.ProrationBehaviorNone {
+		return zeroProrationInvoice(currentInvoice), nil
+	}
+	return currentInvoice, nil
+}
+
+// zeroProrationInvoice keeps the period of the proration invoice with nothing to charge now
+func zeroProrationInvoice(one *bean.Invoice) *bean.Invoice {
+	return &bean.Invoice{
+		InvoiceName:                    one.InvoiceName,
+		BizType:                        consts.BizTypeSubscription,
+		ProductName:                    one.ProductName,
+		OriginAmount:                   0,
+		TotalAmount:                    0,
+		TotalAmountExcludingTax:        0,
+		DiscountAmount:                 0,
+		Currency:                       one.Currency,
+		TaxAmount:                      0,
+		SubscriptionAmount:             0,
+		SubscriptionAmountExcludingTax: 0,
+		Lines:                          make([]*bean.InvoiceItemSimplify, 0),
+		ProrationDate:                  one.ProrationDate,
+		PeriodStart:                    one.PeriodStart,
+		PeriodEnd:                      one.PeriodEnd,
+		BillingCycleAnchor:             one.BillingCycleAnchor,
+		Metadata:                       one.Metadata,
+		CountryCode:                    one.CountryCode,
+		VatNumber:                      one.VatNumber,
+		TaxPercentage:                  one.TaxPercentage,
+	}
+}
+
+// nextInvoiceItems returns a copy of the item for the next cycle invoice preview, the preview caps the credit in its items
+// while the pending invoice item keeps the whole proration
+func nextInvoiceItems(item *invoice_compute.AdditionalInvoiceItem) []*invoice_compute.AdditionalInvoiceItem {
+	if item == nil {
+		return nil
+	}
+	var copied = *item
+	return []*invoice_compute.AdditionalInvoiceItem{&copied}
+}
+
 type UpdateInternalReq struct {
 	SubscriptionId         string                      `json:"subscriptionId" dc:"SubscriptionId" v:"required"`
 	NewPlanId              uint64                      `json:"newPlanId" dc:"NewPlanId" v:"required"`
@@ -645,6 +765,7 @@ type UpdateInternalReq struct {
 	ProductData            *bean.PlanProductParam      `json:"productData"  dc:"ProductData"  `
 	ApplyPromoCredit       bool                        `json:"applyPromoCredit" dc:"apply promo credit or not"`
 	ApplyPromoCreditAmount *int64                      `json:"applyPromoCreditAmount"  dc:"apply promo credit amount, auto compute if not specified"`
+	ProrationBehavior      string                      `json:"prorationBehavior" dc:"ProrationBehavior, create_prorations|prorate_to_next_invoice|none|always_invoice, follow the subscription config if not specified"`
 }
 
 type UpdateInternalRes struct {
@@ -693,6 +814,7 @@ func SubscriptionUpdate(ctx context.Context, req *UpdateInternalReq, merchantMem
 		IsSubmit:               true,
 		ApplyPromoCredit:       unibee.Bool(req.ApplyPromoCredit),
 		ApplyPromoCreditAmount: req.ApplyPromoCreditAmount,
+		ProrationBehavior:      req.ProrationBehavior,
 	}, prorationDate, merchantMemberId)
 	if err != nil {
 		return nil, err
@@ -734,7 +856,7 @@ func SubscriptionUpdate(ctx context.Context, req *UpdateInternalReq, merchantMem
 			Link: "",
 			Note: "",
 		}, nil
-	} else if prepare.Invoice.TotalAmount <= 0 && !prepare.IsUpgrade {
+	} else if prepare.Invoice.TotalAmount <= 0 && !prepare.IsUpgrade && !isProrationBehaviorEffectImmediately(prepare.ProrationBehavior) && prepare.CommitmentEffectTime == 0 {
 		utility.Assert(prepare.EffectImmediate == config.GetMerchantSubscriptionConfig(ctx, sub.MerchantId).DowngradeEffectImmediately, "System Error, Cannot Effect Immediate With Negative Amount")
 	}
 
@@ -743,6 +865,10 @@ func SubscriptionUpdate(ctx context.Context, req *UpdateInternalReq, merchantMem
 	if prepare.EffectImmediate {
 		effectImmediate = 1
 		effectTime = gtime.Now().Timestamp()
+	} else if prepare.CommitmentEffectTime > effectTime {
+		// the downgrade noticed during the commitment term waits for the term end
+		effectTime = prepare.CommitmentEffectTime
+		prepare.Invoice.Metadata["CommitmentEffectTime"] = effectTime
 	}
 
 	prepare.Invoice.InvoiceId = utility.CreateInvoiceId() // pre generate invoiceId first
@@ -784,6 +910,22 @@ func SubscriptionUpdate(ctx context.Context, req *UpdateInternalReq, merchantMem
 	}
 	id, _ := result.LastInsertId()
 	one.Id = uint64(id)
+	if prepare.NextInvoiceItem != nil {
+		_, err = pending_item.CreatePendingInvoiceItem(ctx, &pending_item.CreateInternalReq{
+			Subscription:    prepare.Subscription,
+			Name:            prepare.NextInvoiceItem.Name,
+			Description:     prepare.NextInvoiceItem.Description,
+			UnitAmount:      prepare.NextInvoiceItem.UnitAmountExcludingTax,
+			Quantity:        prepare.NextInvoiceItem.Quantity,
+			Proration:       prepare.NextInvoiceItem.Proration,
+			Source:          consts.PendingInvoiceItemSourceSubscriptionUpdate,
+			PendingUpdateId: one.PendingUpdateId,
+		})
+		if err != nil {
+			err = gerror.Newf(`SubscriptionPendingInvoiceItem record insert failure %s`, err.Error())
+			return nil, err
+		}
+	}
 	if prepare.Invoice.Metadata == nil {
 		prepare.Invoice.Metadata = make(map[string]interface{})
 	}
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"testing"
+
+	"unibee/api/bean"
+	"unibee/internal/consts"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckProrationBehavior(t *testing.T) {
+	require.Nil(t, checkProrationBehavior(consts.ProrationBehaviorCreateProrations, 1, false))
+	require.Nil(t, checkProrationBehavior(consts.ProrationBehaviorProrateToNextInvoice, 0, false))
+	require.Nil(t, checkProrationBehavior(consts.ProrationBehaviorNone, 1, false))
+	require.Nil(t, checkProrationBehavior(consts.ProrationBehaviorAlwaysInvoice, 1, false))
+	require.NotNil(t, checkProrationBehavior("immediate", 1, false))
+	// the update effect at period end has no proration
+	require.NotNil(t, checkProrationBehavior(consts.ProrationBehaviorCreateProrations, 2, false))
+	// the recurring interval changed
+	require.Nil(t, checkProrationBehavior(consts.ProrationBehaviorCreateProrations, 1, true))
+	require.Nil(t, checkProrationBehavior(consts.ProrationBehaviorAlwaysInvoice, 1, true))
+	require.NotNil(t, checkProrationBehavior(consts.ProrationBehaviorProrateToNextInvoice, 1, true))
+	require.NotNil(t, checkProrationBehavior(consts.ProrationBehaviorNone, 1, true))
+}
+
+func prorationTestInvoice(totalAmountExcludingTax int64, promoCreditDiscountAmount int64) *bean.Invoice {
+	var taxAmount = totalAmountExcludingTax / 10
+	return &bean.Invoice{
+		InvoiceName:               "SubscriptionUpdate",
+		ProductName:               "Pro",
+		Currency:                  "USD",
+		OriginAmount:              totalAmountExcludingTax + taxAmount + promoCreditDiscountAmount,
+		TotalAmount:               totalAmountExcludingTax + taxAmount,
+		TotalAmountExcludingTax:   totalAmountExcludingTax,
+		TaxAmount:                 taxAmount,
+		TaxPercentage:             1000,
+		PromoCreditDiscountAmount: promoCreditDiscountAmount,
+		ProrationDate:             1700000000,
+		PeriodStart:               1699000000,
+		PeriodEnd:                 1701000000,
+		BillingCycleAnchor:        1699000000,
+		CountryCode:               "DE",
+		Lines:                     []*bean.InvoiceItemSimplify{{Amount: totalAmountExcludingTax + taxAmount, Tax: taxAmount}},
+	}
+}
+
+func TestZeroProrationInvoice(t *testing.T) {
+	one := prorationTestInvoice(1000, 200)
+	zero := zeroProrationInvoice(one)
+	require.Equal(t, int64(0), zero.TotalAmount)
+	require.Equal(t, int64(0), zero.TotalAmountExcludingTax)
+	require.Equal(t, int64(0), zero.TaxAmount)
+	require.Equal(t, int64(0), zero.OriginAmount)
+	require.Equal(t, int64(0), zero.PromoCreditDiscountAmount)
+	require.Len(t, zero.Lines, 0)
+	// the period of the proration kept
+	require.Equal(t, one.ProrationDate, zero.ProrationDate)
+	require.Equal(t, one.PeriodStart, zero.PeriodStart)
+	require.Equal(t, one.PeriodEnd, zero.PeriodEnd)
+	require.Equal(t, one.BillingCycleAnchor, zero.BillingCycleAnchor)
+	require.Equal(t, one.Currency, zero.Currency)
+	require.Equal(t, one.TaxPercentage, zero.TaxPercentage)
+	require.Equal(t, one.CountryCode, zero.CountryCode)
+}
+
+func TestApplyProrationBehavior(t *testing.T) {
+	t.Run("create_prorations charges the proration now", func(t *testing.T) {
+		one := prorationTestInvoice(1000, 0)
+		current, item := applyProrationBehavior(consts.ProrationBehaviorCreateProrations, "Pro", 1, one)
+		require.Equal(t, one, current)
+		require.Nil(t, item)
+		one = prorationTestInvoice(-1000, 0)
+		current, item = applyProrationBehavior(consts.ProrationBehaviorCreateProrations, "Pro", 1, one)
+		require.Equal(t, one, current)
+		require.Nil(t, item)
+	})
+	t.Run("prorate_to_next_invoice moves the proration excluding tax with the promo credit back", func(t *testing.T) {
+		current, item := applyProrationBehavior(consts.ProrationBehaviorProrateToNextInvoice, "Pro", 2, prorationTestInvoice(1000, 200))
+		require.Equal(t, int64(0), current.TotalAmount)
+		require.NotNil(t, item)
+		require.Equal(t, int64(1200), item.UnitAmountExcludingTax)
+		require.Equal(t, int64(1), item.Quantity)
+		require.True(t, item.Proration)
+		require.Equal(t, "Pro Proration", item.Name)
+		require.Nil(t, item.TaxPercentage)
+		// the downgrade credit moves as well
+		current, item = applyProrationBehavior(consts.ProrationBehaviorProrateToNextInvoice, "Pro", 1, prorationTestInvoice(-800, 0))
+		require.Equal(t, int64(0), current.TotalAmount)
+		require.Equal(t, int64(-800), item.UnitAmountExcludingTax)
+	})
+	t.Run("always_invoice charges now and moves the credit", func(t *testing.T) {
+		one := prorationTestInvoice(1000, 0)
+		current, item := applyProrationBehavior(consts.ProrationBehaviorAlwaysInvoice, "Pro", 1, one)
+		require.Equal(t, one, current)
+		require.Nil(t, item)
+		current, item = applyProrationBehavior(consts.ProrationBehaviorAlwaysInvoice, "Pro", 1, prorationTestInvoice(-800, 0))
+		require.Equal(t, int64(0), current.TotalAmount)
+		require.Equal(t, int64(-800), item.UnitAmountExcludingTax)
+	})
+	t.Run("none drops the proration", func(t *testing.T) {
+		current, item := applyProrationBehavior(consts.ProrationBehaviorNone, "Pro", 1, prorationTestInvoice(1000, 0))
+		require.Equal(t, int64(0), current.TotalAmount)
+		require.Len(t, current.Lines, 0)
+		require.Nil(t, item)
+		current, item = applyProrationBehavior(consts.ProrationBehaviorNone, "Pro", 1, prorationTestInvoice(-800, 0))
+		require.Equal(t, int64(0), current.TotalAmount)
+		require.Nil(t, item)
+	})
+}
+
+func TestNextInvoiceItems(t *testing.T) {
+	require.Nil(t, nextInvoiceItems(nil))
+	_, item := applyProrationBehavior(consts.ProrationBehaviorProrateToNextInvoice, "Pro", 1, prorationTestInvoice(-5000, 0))
+	items := nextInvoiceItems(item)
+	require.Len(t, items, 1)
+	// the preview caps the copy, the pending invoice item keeps the whole credit
+	items[0].UnitAmountExcludingTax = -1000
+	items[0].CarryForwardAmount = -4000
+	require.Equal(t, int64(-5000), item.UnitAmountExcludingTax)
+	require.Equal(t, int64(0), item.CarryForwardAmount)
+}
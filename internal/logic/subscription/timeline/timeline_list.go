@@ -64,6 +64,12 @@ func SubscriptionTimeLineList(ctx context.Context, req *SubscriptionTimeLineList
 		if invoice != nil {
 			paymentId = invoice.PaymentId
 		}
+		var scheduleId = ""
+		var schedulePhase = 0
+		if phase := query.GetSubscriptionSchedulePhaseByPeriodStart(ctx, one.SubscriptionId, one.PeriodStart); phase != nil {
+			scheduleId = phase.ScheduleId
+			schedulePhase = phase.PhaseIndex
+		}
 		timelines = append(timelines, &detail.SubscriptionTimeLineDetail{
 			MerchantId:      one.MerchantId,
 			UserId:          one.UserId,
@@ -83,6 +89,8 @@ func SubscriptionTimeLineList(ctx context.Context, req *SubscriptionTimeLineList
 			GatewayId:       one.GatewayId,
 			CreateTime:      one.CreateTime,
 			Status:          one.Status,
+			ScheduleId:      scheduleId,
+			SchedulePhase:   schedulePhase,
 		})
 	}
 
@@ -0,0 +1,33 @@
+package transfer
+
+import (
+	"context"
+
+	dao "unibee/internal/dao/default"
+	entity "unibee/internal/model/entity/default"
+)
+
+// GetTransferList returns the ownership transfers of the merchant, filtered by subscription and the user transferred from or to if specified
+func GetTransferList(ctx context.Context, merchantId uint64, subscriptionId string, userId uint64, page int, count int) ([]*entity.SubscriptionTransfer, int, error) {
+	var list []*entity.SubscriptionTransfer
+	var total = 0
+	q := dao.SubscriptionTransfer.Ctx(ctx).
+		Where(dao.SubscriptionTransfer.Columns().MerchantId, merchantId).
+		Where(dao.SubscriptionTransfer.Columns().IsDeleted, 0)
+	if len(subscriptionId) > 0 {
+		q = q.Where(dao.SubscriptionTransfer.Columns().SubscriptionId, subscriptionId)
+	}
+	if userId > 0 {
+		q = q.Where(q.Builder().
+			WhereOr(dao.SubscriptionTransfer.Columns().FromUserId, userId).
+			WhereOr(dao.SubscriptionTransfer.Columns().ToUserId, userId))
+	}
+	if count > 0 {
+		q = q.Limit(page*count, count)
+	}
+	err := q.OrderDesc(dao.SubscriptionTransfer.Columns().Id).ScanAndCount(&list, &total, true)
+	if err != nil {
+		return nil, 0, err
+	}
+	return list, total, nil
+}
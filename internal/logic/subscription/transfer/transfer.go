@@ -0,0 +1,327 @@
+package transfer
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	redismq2 "unibee/internal/cmd/redismq"
+	"unibee/internal/consts"
+	"unibee/internal/consumer/webhook/event"
+	subscription3 "unibee/internal/consumer/webhook/subscription"
+	dao "unibee/internal/dao/default"
+	"unibee/internal/logic/metric"
+	"unibee/internal/logic/metric_event"
+	"unibee/internal/logic/operation_log"
+	"unibee/internal/logic/user/sub_update"
+	entity "unibee/internal/model/entity/default"
+	"unibee/internal/query"
+	"unibee/utility"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+	redismq "github.com/jackyang-hk/go-redismq"
+)
+
+type TransferInternalReq struct {
+	MerchantId           uint64 `json:"merchantId" dc:"MerchantId"`
+	SubscriptionId       string `json:"subscriptionId" dc:"SubscriptionId"`
+	ToUserId             uint64 `json:"toUserId" dc:"ToUserId, the user the subscription transferred to"`
+	RequirePaymentMethod bool   `json:"requirePaymentMethod" dc:"RequirePaymentMethod, the new user should have default payment method if true"`
+	Reason               string `json:"reason" dc:"Reason"`
+}
+
+// IsTransferableSubscriptionStatus returns true when the subscription is not ended
+func IsTransferableSubscriptionStatus(status int) bool {
+	return status != consts.SubStatusCancelled && status != consts.SubStatusExpired && status != consts.SubStatusFailed
+}
+
+// IsTransferableInvoiceStatus returns true when the invoice is not issued yet, the ended invoices stay with the previous owner,
+// the processing invoices block the transfer as the payment is in flight on the payment method of the previous owner
+func IsTransferableInvoiceStatus(status int) bool {
+	return status == consts.InvoiceStatusInit || status == consts.InvoiceStatusPending
+}
+
+// userPaymentMethod returns the default gateway and payment method of the user
+func userPaymentMethod(user *entity.UserAccount) (uint64, string) {
+	if user == nil || len(user.GatewayId) == 0 {
+		return 0, ""
+	}
+	gatewayId, err := strconv.ParseUint(user.GatewayId, 10, 64)
+	if err != nil || gatewayId == 0 {
+		return 0, ""
+	}
+	return gatewayId, user.PaymentMethod
+}
+
+// SubscriptionTransfer moves the subscription to another user of the merchant, the unpaid invoices, pending items, pending updates,
+// schedules, seats, card-less trial and the usage not billed yet go with the subscription,
+// the paid invoices, payments and timelines stay with the previous owner as history, the transfer is refused while the invoice is in payment,
+// the billing cycle walk of the subscription waits until the transfer finished
+func SubscriptionTransfer(ctx context.Context, req *TransferInternalReq) (*entity.SubscriptionTransfer, error) {
+	utility.Assert(req != nil, "req not found")
+	utility.Assert(len(req.SubscriptionId) > 0, "subscriptionId not found")
+	utility.Assert(req.ToUserId > 0, "toUserId invalid")
+	sub := query.GetSubscriptionBySubscriptionId(ctx, req.SubscriptionId)
+	utility.Assert(sub != nil, "subscription not found")
+	utility.Assert(sub.MerchantId == req.MerchantId, "merchant not match")
+	utility.Assert(sub.Type == consts.SubTypeUniBeeControl, "subscription billing cycle not controlled by UniBee")
+	utility.Assert(IsTransferableSubscriptionStatus(sub.Status), "subscription already ended")
+	utility.Assert(sub.UserId != req.ToUserId, "subscription already belongs to the user")
+	toUser := query.GetUserAccountById(ctx, req.ToUserId)
+	utility.Assert(toUser != nil && toUser.MerchantId == sub.MerchantId, "user not found")
+	utility.Assert(toUser.IsDeleted == 0, "user already deleted")
+	plan := query.GetPlanById(ctx, sub.PlanId)
+	utility.Assert(plan != nil, "plan not found")
+	utility.Assert(query.GetLatestActiveOrIncompleteOrCreateSubscriptionByUserId(ctx, toUser.Id, sub.MerchantId, plan.ProductId) == nil, "the user has subscription of the same product already")
+	gatewayId, paymentMethodId := userPaymentMethod(toUser)
+	if req.RequirePaymentMethod {
+		utility.Assert(gatewayId > 0 && len(paymentMethodId) > 0, "the user has no default payment method")
+	}
+	if gatewayId == 0 {
+		gatewayId = sub.GatewayId
+	}
+
+	key := fmt.Sprintf("SubscriptionTransfer-%s", sub.SubscriptionId)
+	utility.Assert(utility.TryLock(ctx, key, 60), "subscription transfer in progress, please retry later")
+	defer utility.ReleaseLock(ctx, key)
+	// the billing cycle walk creates and charges the cycle invoice on the owner, it waits until the transfer finished
+	walkKey := fmt.Sprintf("SubscriptionCycleWalk-%s", sub.SubscriptionId)
+	utility.Assert(utility.TryLock(ctx, walkKey, 60), "subscription billing cycle in progress, please retry later")
+	defer utility.ReleaseLock(ctx, walkKey)
+
+	processingCount, err := countProcessingInvoices(ctx, sub, sub.UserId, false)
+	if err != nil {
+		return nil, err
+	}
+	utility.Assert(processingCount == 0, "subscription has processing invoice in payment, please cancel the invoice or wait for the payment finished")
+
+	var fromUserId = sub.UserId
+	var invoiceIds []string
+	var eventCount int64
+	err = dao.Subscription.DB().Transaction(ctx, func(ctx context.Context, transaction gdb.TX) error {
+		// the invoice may go in payment after the check above
+		processingCount, err := countProcessingInvoices(ctx, sub, fromUserId, true)
+		if err != nil {
+			return err
+		}
+		if processingCount > 0 {
+			return fmt.Errorf("subscription has processing invoice in payment, please cancel the invoice or wait for the payment finished")
+		}
+		result, err := dao.Subscription.Ctx(ctx).Data(g.Map{
+			dao.Subscription.Columns().UserId:                      toUser.Id,
+			dao.Subscription.Columns().CustomerEmail:               toUser.Email,
+			dao.Subscription.Columns().GatewayId:                   gatewayId,
+			dao.Subscription.Columns().GatewayDefaultPaymentMethod: paymentMethodId,
+			dao.Subscription.Columns().GmtModify:                   gtime.Now(),
+		}).Where(dao.Subscription.Columns().Id, sub.Id).
+			Where(dao.Subscription.Columns().UserId, fromUserId).
+			OmitNil().Update()
+		if err != nil {
+			return err
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected != 1 {
+			return fmt.Errorf("subscription owner changed, please retry")
+		}
+		invoiceIds, err = moveInvoices(ctx, sub, fromUserId, toUser, paymentMethodId)
+		if err != nil {
+			return err
+		}
+		err = moveFutureRecords(ctx, sub, fromUserId, toUser.Id)
+		if err != nil {
+			return err
+		}
+		eventCount, err = metric_event.TransferSubscriptionMetricEvents(ctx, sub, fromUserId, toUser)
+		return err
+	})
+	if err != nil {
+		g.Log().Errorf(ctx, "SubscriptionTransfer subscriptionId:%s err:%s", sub.SubscriptionId, err.Error())
+		return nil, err
+	}
+	sub.UserId = toUser.Id
+	sub.CustomerEmail = toUser.Email
+	sub.GatewayId = gatewayId
+	sub.GatewayDefaultPaymentMethod = paymentMethodId
+
+	sub_update.ClearUserDefaultSubscriptionForTransfer(ctx, fromUserId, sub.SubscriptionId)
+	sub_update.UpdateUserDefaultSubscriptionForPaymentSuccess(ctx, toUser.Id, sub.SubscriptionId)
+
+	one := &entity.SubscriptionTransfer{
+		MerchantId:       sub.MerchantId,
+		SubscriptionId:   sub.SubscriptionId,
+		TransferId:       utility.CreateSubscriptionTransferId(),
+		FromUserId:       fromUserId,
+		ToUserId:         toUser.Id,
+		PlanId:           sub.PlanId,
+		InvoiceIds:       strings.Join(invoiceIds, ","),
+		MetricEventCount: eventCount,
+		GatewayId:        gatewayId,
+		PaymentMethodId:  paymentMethodId,
+		Reason:           req.Reason,
+		CreateTime:       gtime.Now().Timestamp(),
+	}
+	_, err = dao.SubscriptionTransfer.Ctx(ctx).Data(one).OmitNil().Insert(one)
+	operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
+		MerchantId:     sub.MerchantId,
+		Target:         fmt.Sprintf("Subscription(%s)", sub.SubscriptionId),
+		Content:        fmt.Sprintf("Transfer(%d->%d)", fromUserId, toUser.Id),
+		UserId:         toUser.Id,
+		SubscriptionId: sub.SubscriptionId,
+		InvoiceId:      "",
+		PlanId:         sub.PlanId,
+		DiscountCode:   "",
+	}, err)
+	if err != nil {
+		return nil, err
+	}
+	subscription3.SendMerchantSubscriptionWebhookBackground(sub, -10000, event.UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_TRANSFERRED, map[string]interface{}{
+		"TransferId": one.TransferId,
+		"FromUserId": fromUserId,
+		"ToUserId":   toUser.Id,
+	})
+	for _, userId := range []uint64{fromUserId, toUser.Id} {
+		_, _ = redismq.Send(&redismq.Message{
+			Topic: redismq2.TopicUserMetricUpdate.Topic,
+			Tag:   redismq2.TopicUserMetricUpdate.Tag,
+			Body: utility.MarshalToJsonString(&metric.UserMetricUpdateMessage{
+				UserId:         userId,
+				SubscriptionId: sub.SubscriptionId,
+				Description:    "SubscriptionTransfer",
+			}),
+			CustomData: map[string]interface{}{"CreateFrom": utility.ReflectCurrentFunctionName()},
+		})
+	}
+	return one, nil
+}
+
+// countProcessingInvoices returns the count of the invoices of the subscription in payment on the owner,
+// the invoices counted are locked until the transaction ends if lock
+func countProcessingInvoices(ctx context.Context, sub *entity.Subscription, userId uint64, lock bool) (int, error) {
+	q := dao.Invoice.Ctx(ctx).
+		Where(dao.Invoice.Columns().SubscriptionId, sub.SubscriptionId).
+		Where(dao.Invoice.Columns().UserId, userId).
+		Where(dao.Invoice.Columns().Status, consts.InvoiceStatusProcessing).
+		Where(dao.Invoice.Columns().IsDeleted, 0)
+	if lock {
+		q = q.LockUpdate()
+	}
+	return q.Count()
+}
+
+// moveInvoices moves the unpaid invoices of the subscription to the new owner, returns the invoice ids moved
+func moveInvoices(ctx context.Context, sub *entity.Subscription, fromUserId uint64, toUser *entity.UserAccount, paymentMethodId string) ([]string, error) {
+	var list []*entity.Invoice
+	err := dao.Invoice.Ctx(ctx).
+		Where(dao.Invoice.Columns().SubscriptionId, sub.SubscriptionId).
+		Where(dao.Invoice.Columns().UserId, fromUserId).
+		WhereIn(dao.Invoice.Columns().Status, []int{consts.InvoiceStatusInit, consts.InvoiceStatusPending}).
+		Where(dao.Invoice.Columns().IsDeleted, 0).
+		Scan(&list)
+	if err != nil {
+		return nil, err
+	}
+	var invoiceIds = make([]string, 0)
+	for _, one := range list {
+		if !IsTransferableInvoiceStatus(one.Status) {
+			continue
+		}
+		result, err := dao.Invoice.Ctx(ctx).Data(g.Map{
+			dao.Invoice.Columns().UserId:               toUser.Id,
+			dao.Invoice.Columns().SendEmail:            toUser.Email,
+			dao.Invoice.Columns().GatewayPaymentMethod: paymentMethodId,
+			dao.Invoice.Columns().GmtModify:            gtime.Now(),
+		}).Where(dao.Invoice.Columns().Id, one.Id).
+			Where(dao.Invoice.Columns().Status, one.Status).
+			OmitNil().Update()
+		if err != nil {
+			return invoiceIds, err
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return invoiceIds, err
+		}
+		if affected != 1 {
+			return invoiceIds, fmt.Errorf("invoice %s status changed, please retry", one.InvoiceId)
+		}
+		invoiceIds = append(invoiceIds, one.InvoiceId)
+	}
+	return invoiceIds, nil
+}
+
+// moveFutureRecords moves the records of the subscription not applied yet to the new owner
+func moveFutureRecords(ctx context.Context, sub *entity.Subscription, fromUserId uint64, toUserId uint64) error {
+	_, err := dao.SubscriptionPendingInvoiceItem.Ctx(ctx).Data(g.Map{
+		dao.SubscriptionPendingInvoiceItem.Columns().UserId:    toUserId,
+		dao.SubscriptionPendingInvoiceItem.Columns().GmtModify: gtime.Now(),
+	}).Where(dao.SubscriptionPendingInvoiceItem.Columns().SubscriptionId, sub.SubscriptionId).
+		Where(dao.SubscriptionPendingInvoiceItem.Columns().UserId, fromUserId).
+		Where(dao.SubscriptionPendingInvoiceItem.Columns().Status, consts.PendingInvoiceItemStatusPending).
+		Update()
+	if err != nil {
+		return err
+	}
+	_, err = dao.SubscriptionPendingUpdate.Ctx(ctx).Data(g.Map{
+		dao.SubscriptionPendingUpdate.Columns().UserId:    toUserId,
+		dao.SubscriptionPendingUpdate.Columns().GmtModify: gtime.Now(),
+	}).Where(dao.SubscriptionPendingUpdate.Columns().SubscriptionId, sub.SubscriptionId).
+		Where(dao.SubscriptionPendingUpdate.Columns().UserId, fromUserId).
+		WhereIn(dao.SubscriptionPendingUpdate.Columns().Status, []int{consts.PendingSubStatusInit, consts.PendingSubStatusCreate}).
+		Update()
+	if err != nil {
+		return err
+	}
+	_, err = dao.SubscriptionSchedule.Ctx(ctx).Data(g.Map{
+		dao.SubscriptionSchedule.Columns().UserId:    toUserId,
+		dao.SubscriptionSchedule.Columns().GmtModify: gtime.Now(),
+	}).Where(dao.SubscriptionSchedule.Columns().SubscriptionId, sub.SubscriptionId).
+		Where(dao.SubscriptionSchedule.Columns().UserId, fromUserId).
+		Where(dao.SubscriptionSchedule.Columns().Status, consts.SubScheduleStatusActive).
+		Update()
+	if err != nil {
+		return err
+	}
+	_, err = dao.SubscriptionSeat.Ctx(ctx).Data(g.Map{
+		dao.SubscriptionSeat.Columns().UserId:    toUserId,
+		dao.SubscriptionSeat.Columns().GmtModify: gtime.Now(),
+	}).Where(dao.SubscriptionSeat.Columns().SubscriptionId, sub.SubscriptionId).
+		Where(dao.SubscriptionSeat.Columns().UserId, fromUserId).
+		Where(dao.SubscriptionSeat.Columns().Status, consts.SubSeatStatusAssigned).
+		Update()
+	if err != nil {
+		return err
+	}
+	_, err = dao.SubscriptionSeatChange.Ctx(ctx).Data(g.Map{
+		dao.SubscriptionSeatChange.Columns().UserId:    toUserId,
+		dao.SubscriptionSeatChange.Columns().GmtModify: gtime.Now(),
+	}).Where(dao.SubscriptionSeatChange.Columns().SubscriptionId, sub.SubscriptionId).
+		Where(dao.SubscriptionSeatChange.Columns().UserId, fromUserId).
+		Where(dao.SubscriptionSeatChange.Columns().Status, consts.SubSeatChangeStatusPending).
+		Update()
+	if err != nil {
+		return err
+	}
+	_, err = dao.SubscriptionOnetimeAddon.Ctx(ctx).Data(g.Map{
+		dao.SubscriptionOnetimeAddon.Columns().UserId:    toUserId,
+		dao.SubscriptionOnetimeAddon.Columns().GmtModify: gtime.Now(),
+	}).Where(dao.SubscriptionOnetimeAddon.Columns().SubscriptionId, sub.SubscriptionId).
+		Where(dao.SubscriptionOnetimeAddon.Columns().UserId, fromUserId).
+		Where(dao.SubscriptionOnetimeAddon.Columns().Status, 1).
+		Update()
+	if err != nil {
+		return err
+	}
+	_, err = dao.SubscriptionTrial.Ctx(ctx).Data(g.Map{
+		dao.SubscriptionTrial.Columns().UserId:    toUserId,
+		dao.SubscriptionTrial.Columns().GmtModify: gtime.Now(),
+	}).Where(dao.SubscriptionTrial.Columns().SubscriptionId, sub.SubscriptionId).
+		Where(dao.SubscriptionTrial.Columns().UserId, fromUserId).
+		Where(dao.SubscriptionTrial.Columns().Status, consts.SubTrialStatusTrialing).
+		Update()
+	return err
+}
@@ -0,0 +1,38 @@
+package transfer
+
+import (
+	"testing"
+
+	"unibee/internal/consts"
+	entity "unibee/internal/model/entity/default"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsTransferableStatus(t *testing.T) {
+	require.True(t, IsTransferableSubscriptionStatus(consts.SubStatusActive))
+	require.True(t, IsTransferableSubscriptionStatus(consts.SubStatusIncomplete))
+	require.True(t, IsTransferableSubscriptionStatus(consts.SubStatusSuspended))
+	require.False(t, IsTransferableSubscriptionStatus(consts.SubStatusCancelled))
+	require.False(t, IsTransferableSubscriptionStatus(consts.SubStatusExpired))
+	require.False(t, IsTransferableSubscriptionStatus(consts.SubStatusFailed))
+
+	require.True(t, IsTransferableInvoiceStatus(consts.InvoiceStatusPending))
+	require.False(t, IsTransferableInvoiceStatus(consts.InvoiceStatusProcessing))
+	require.False(t, IsTransferableInvoiceStatus(consts.InvoiceStatusPaid))
+	require.False(t, IsTransferableInvoiceStatus(consts.InvoiceStatusCancelled))
+	require.False(t, IsTransferableInvoiceStatus(consts.InvoiceStatusReversed))
+}
+
+func TestUserPaymentMethod(t *testing.T) {
+	gatewayId, paymentMethod := userPaymentMethod(&entity.UserAccount{GatewayId: "12", PaymentMethod: "pm_1"})
+	require.Equal(t, uint64(12), gatewayId)
+	require.Equal(t, "pm_1", paymentMethod)
+	gatewayId, paymentMethod = userPaymentMethod(&entity.UserAccount{GatewayId: "", PaymentMethod: "pm_1"})
+	require.Equal(t, uint64(0), gatewayId)
+	require.Equal(t, "", paymentMethod)
+	gatewayId, _ = userPaymentMethod(&entity.UserAccount{GatewayId: "invalid"})
+	require.Equal(t, uint64(0), gatewayId)
+	gatewayId, _ = userPaymentMethod(nil)
+	require.Equal(t, uint64(0), gatewayId)
+}
@@ -0,0 +1,143 @@
+package trial
+
+import (
+	"context"
+	"fmt"
+
+	"unibee/internal/consts"
+	"unibee/internal/consumer/webhook/event"
+	subscription3 "unibee/internal/consumer/webhook/subscription"
+	dao "unibee/internal/dao/default"
+	"unibee/internal/logic/operation_log"
+	"unibee/internal/logic/subscription/config"
+	"unibee/internal/logic/subscription/pause"
+	"unibee/internal/logic/subscription/service"
+	entity "unibee/internal/model/entity/default"
+	"unibee/internal/query"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+func finishTrial(ctx context.Context, one *entity.SubscriptionTrial, status int, timeNow int64) error {
+	result, err := dao.SubscriptionTrial.Ctx(ctx).Data(g.Map{
+		dao.SubscriptionTrial.Columns().Status:     status,
+		dao.SubscriptionTrial.Columns().FinishTime: timeNow,
+		dao.SubscriptionTrial.Columns().GmtModify:  gtime.Now(),
+	}).Where(dao.SubscriptionTrial.Columns().Id, one.Id).
+		Where(dao.SubscriptionTrial.Columns().Status, consts.SubTrialStatusTrialing).
+		OmitNil().Update()
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected != 1 {
+		return fmt.Errorf("trial already finished")
+	}
+	one.Status = status
+	one.FinishTime = timeNow
+	return nil
+}
+
+func trackPaymentMethod(ctx context.Context, one *entity.SubscriptionTrial, attached bool, timeNow int64) {
+	if (one.PaymentMethodTime > 0) == attached {
+		return
+	}
+	var paymentMethodTime int64 = 0
+	if attached {
+		paymentMethodTime = timeNow
+	}
+	_, err := dao.SubscriptionTrial.Ctx(ctx).Data(g.Map{
+		dao.SubscriptionTrial.Columns().PaymentMethodTime: paymentMethodTime,
+		dao.SubscriptionTrial.Columns().GmtModify:         gtime.Now(),
+	}).Where(dao.SubscriptionTrial.Columns().Id, one.Id).OmitNil().Update()
+	if err != nil {
+		g.Log().Errorf(ctx, "TrackTrialPaymentMethod trialId:%s err:%s", one.TrialId, err.Error())
+		return
+	}
+	one.PaymentMethodTime = paymentMethodTime
+}
+
+// syncTrialStatus finishes the trial when the subscription ended or entered the first paid period,
+// returns true if the trial finished
+func syncTrialStatus(ctx context.Context, one *entity.SubscriptionTrial, sub *entity.Subscription, timeNow int64) (bool, error) {
+	var status = 0
+	if sub == nil || sub.Status == consts.SubStatusCancelled {
+		status = consts.SubTrialStatusCancelled
+	} else if sub.Status == consts.SubStatusExpired || sub.Status == consts.SubStatusFailed {
+		status = consts.SubTrialStatusExpired
+	} else if sub.Status == consts.SubStatusActive && sub.CurrentPeriodStart >= one.TrialEnd {
+		status = consts.SubTrialStatusConverted
+	}
+	if status == 0 {
+		return false, nil
+	}
+	err := finishTrial(ctx, one, status, timeNow)
+	if err != nil {
+		return false, err
+	}
+	if status == consts.SubTrialStatusConverted {
+		subscription3.SendMerchantSubscriptionWebhookBackground(sub, -10000, event.UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_TRIAL_CONVERTED, map[string]interface{}{"TrialId": one.TrialId})
+	}
+	return true, nil
+}
+
+// HandleTrialEnd walks the card-less trial of the subscription in billing cycle,
+// the cycle invoice is held back while the trial without payment method ends with pause or cancel,
+// returns handled true if the billing cycle walk should stop
+func HandleTrialEnd(ctx context.Context, sub *entity.Subscription, timeNow int64) (handled bool, message string, err error) {
+	one := query.GetTrialingSubscriptionTrialBySubscriptionId(ctx, sub.SubscriptionId)
+	if one == nil {
+		return false, "", nil
+	}
+	finished, err := syncTrialStatus(ctx, one, sub, timeNow)
+	if err != nil || finished {
+		return false, "", err
+	}
+	attached := hasPaymentMethod(ctx, sub)
+	trackPaymentMethod(ctx, one, attached, timeNow)
+	if attached || one.TrialEndBehavior == consts.TrialEndBehaviorConvert || len(one.TrialEndBehavior) == 0 || sub.CancelAtPeriodEnd == 1 {
+		// charged at trial end as the other subscriptions
+		return false, "", nil
+	}
+	if timeNow < one.TrialEnd {
+		return true, "Nothing Todo As Trial Without Payment Method Not Ended", nil
+	}
+	var status int
+	switch one.TrialEndBehavior {
+	case consts.TrialEndBehaviorPause:
+		_, err = pause.SubscriptionPause(ctx, &pause.PauseInternalReq{
+			MerchantId:     sub.MerchantId,
+			SubscriptionId: sub.SubscriptionId,
+			PauseMode:      config.GetMerchantSubscriptionConfig(ctx, sub.MerchantId).PauseMode,
+			ResumeTime:     0,
+			Reason:         "TrialEndWithoutPaymentMethod",
+		})
+		status = consts.SubTrialStatusPaused
+	case consts.TrialEndBehaviorCancel:
+		err = service.SubscriptionCancelBySystem(ctx, sub.SubscriptionId, "TrialEndWithoutPaymentMethod")
+		status = consts.SubTrialStatusCancelled
+	default:
+		return false, "", nil
+	}
+	if err == nil {
+		err = finishTrial(ctx, one, status, timeNow)
+	}
+	operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
+		MerchantId:     sub.MerchantId,
+		Target:         fmt.Sprintf("SubscriptionTrial(%s)", one.TrialId),
+		Content:        fmt.Sprintf("TrialEnd(%s)", one.TrialEndBehavior),
+		UserId:         sub.UserId,
+		SubscriptionId: sub.SubscriptionId,
+		InvoiceId:      "",
+		PlanId:         sub.PlanId,
+		DiscountCode:   "",
+	}, err)
+	if err != nil {
+		return false, "", err
+	}
+	return true, fmt.Sprintf("Trial Without Payment Method Ended By %s", one.TrialEndBehavior), nil
+}
@@ -0,0 +1,104 @@
+package trial
+
+import (
+	"context"
+	"fmt"
+
+	"unibee/api/bean"
+	"unibee/internal/consts"
+	"unibee/internal/consumer/webhook/event"
+	subscription3 "unibee/internal/consumer/webhook/subscription"
+	dao "unibee/internal/dao/default"
+	"unibee/internal/logic/email"
+	"unibee/internal/logic/subscription/config"
+	entity "unibee/internal/model/entity/default"
+	"unibee/internal/query"
+	"unibee/utility"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+// NeedTrialEndReminder returns true when the trial without payment method ends within the reminder days and not reminded yet
+func NeedTrialEndReminder(one *entity.SubscriptionTrial, reminderDays int64, timeNow int64) bool {
+	if one == nil || one.Status != consts.SubTrialStatusTrialing || one.ReminderTime > 0 || one.PaymentMethodTime > 0 {
+		return false
+	}
+	if reminderDays <= 0 || timeNow >= one.TrialEnd {
+		return false
+	}
+	return timeNow >= one.TrialEnd-reminderDays*86400
+}
+
+// SendTrialEndReminders reminds the users of the trials ending soon to attach payment method,
+// the trials of the subscriptions ended or converted are finished as well
+func SendTrialEndReminders(ctx context.Context) {
+	var timeNow = gtime.Now().Timestamp()
+	var count = 100
+	var lastId uint64 = 0
+	for {
+		var list []*entity.SubscriptionTrial
+		err := dao.SubscriptionTrial.Ctx(ctx).
+			Where(dao.SubscriptionTrial.Columns().Status, consts.SubTrialStatusTrialing).
+			Where(dao.SubscriptionTrial.Columns().IsDeleted, 0).
+			WhereGT(dao.SubscriptionTrial.Columns().Id, lastId).
+			OrderAsc(dao.SubscriptionTrial.Columns().Id).
+			Limit(count).
+			Scan(&list)
+		if err != nil {
+			g.Log().Errorf(ctx, "SendTrialEndReminders error:%s", err.Error())
+			return
+		}
+		for _, one := range list {
+			lastId = one.Id
+			key := fmt.Sprintf("SendTrialEndReminders-%v", one.Id)
+			if !utility.TryLock(ctx, key, 60) {
+				continue
+			}
+			sub := query.GetSubscriptionBySubscriptionId(ctx, one.SubscriptionId)
+			finished, err := syncTrialStatus(ctx, one, sub, timeNow)
+			if err != nil {
+				g.Log().Errorf(ctx, "SendTrialEndReminders sync trialId:%s err:%s", one.TrialId, err.Error())
+			}
+			if !finished && sub != nil {
+				trackPaymentMethod(ctx, one, hasPaymentMethod(ctx, sub), timeNow)
+				if NeedTrialEndReminder(one, config.GetMerchantSubscriptionConfig(ctx, one.MerchantId).TrialReminderDays, utility.MaxInt64(timeNow, sub.TestClock)) {
+					sendTrialEndReminder(ctx, one, sub, timeNow)
+				}
+			}
+			utility.ReleaseLock(ctx, key)
+		}
+		if len(list) < count {
+			break
+		}
+	}
+}
+
+func sendTrialEndReminder(ctx context.Context, one *entity.SubscriptionTrial, sub *entity.Subscription, timeNow int64) {
+	user := query.GetUserAccountById(ctx, one.UserId)
+	plan := query.GetPlanById(ctx, one.PlanId)
+	merchant := query.GetMerchantById(ctx, one.MerchantId)
+	if user == nil || plan == nil || merchant == nil {
+		return
+	}
+	err := email.SendTemplateEmail(ctx, merchant.Id, user.Email, user.TimeZone, user.Language, email.TemplateSubscriptionTrialEndReminder, "", &bean.EmailTemplateVariable{
+		UserName:              user.FirstName + " " + user.LastName,
+		MerchantProductName:   plan.PlanName,
+		MerchantCustomerEmail: merchant.Email,
+		MerchantName:          query.GetMerchantCountryConfigName(ctx, merchant.Id, user.CountryCode),
+		PeriodEnd:             gtime.NewFromTimeStamp(one.TrialEnd),
+		Link:                  GetTrialLink(one),
+	})
+	if err != nil {
+		g.Log().Errorf(ctx, "SendTrialEndReminders trialId:%s err:%s", one.TrialId, err.Error())
+		return
+	}
+	subscription3.SendMerchantSubscriptionWebhookBackground(sub, int((one.TrialEnd-timeNow)/86400), event.UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_TRIAL_WILL_END, map[string]interface{}{"TrialId": one.TrialId, "TrialEnd": one.TrialEnd})
+	_, err = dao.SubscriptionTrial.Ctx(ctx).Data(g.Map{
+		dao.SubscriptionTrial.Columns().ReminderTime: timeNow,
+		dao.SubscriptionTrial.Columns().GmtModify:    gtime.Now(),
+	}).Where(dao.SubscriptionTrial.Columns().Id, one.Id).OmitNil().Update()
+	if err != nil {
+		g.Log().Errorf(ctx, "SendTrialEndReminders update trialId:%s err:%s", one.TrialId, err.Error())
+	}
+}
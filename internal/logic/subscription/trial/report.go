@@ -0,0 +1,107 @@
+package trial
+
+import (
+	"context"
+	"sort"
+
+	"unibee/api/bean"
+	"unibee/internal/consts"
+	dao "unibee/internal/dao/default"
+	entity "unibee/internal/model/entity/default"
+	"unibee/internal/query"
+)
+
+// SummarizeTrials aggregates the card-less trials by plan, the conversion rate counts the finished trials only
+func SummarizeTrials(list []*entity.SubscriptionTrial) []*bean.SubscriptionTrialReport {
+	var reports = make([]*bean.SubscriptionTrialReport, 0)
+	var planReports = make(map[uint64]*bean.SubscriptionTrialReport)
+	for _, one := range list {
+		if one == nil {
+			continue
+		}
+		report, ok := planReports[one.PlanId]
+		if !ok {
+			report = &bean.SubscriptionTrialReport{PlanId: one.PlanId}
+			planReports[one.PlanId] = report
+			reports = append(reports, report)
+		}
+		report.Started = report.Started + 1
+		if one.PaymentMethodTime > 0 {
+			report.PaymentMethodAttached = report.PaymentMethodAttached + 1
+		}
+		switch one.Status {
+		case consts.SubTrialStatusTrialing:
+			report.Trialing = report.Trialing + 1
+		case consts.SubTrialStatusConverted:
+			report.Converted = report.Converted + 1
+		case consts.SubTrialStatusPaused:
+			report.Paused = report.Paused + 1
+		case consts.SubTrialStatusCancelled:
+			report.Cancelled = report.Cancelled + 1
+		case consts.SubTrialStatusExpired:
+			report.Expired = report.Expired + 1
+		}
+	}
+	for _, report := range reports {
+		finished := report.Started - report.Trialing
+		if finished > 0 {
+			report.ConversionRate = report.Converted * 10000 / finished
+		}
+	}
+	sort.SliceStable(reports, func(i, j int) bool {
+		return reports[i].PlanId < reports[j].PlanId
+	})
+	return reports
+}
+
+// TrialReport returns the trial conversion per plan of the trials started between startTime and endTime
+func TrialReport(ctx context.Context, merchantId uint64, startTime int64, endTime int64) ([]*bean.SubscriptionTrialReport, error) {
+	var list []*entity.SubscriptionTrial
+	q := dao.SubscriptionTrial.Ctx(ctx).
+		Where(dao.SubscriptionTrial.Columns().MerchantId, merchantId).
+		Where(dao.SubscriptionTrial.Columns().IsDeleted, 0)
+	if startTime > 0 {
+		q = q.WhereGTE(dao.SubscriptionTrial.Columns().CreateTime, startTime)
+	}
+	if endTime > 0 {
+		q = q.WhereLTE(dao.SubscriptionTrial.Columns().CreateTime, endTime)
+	}
+	err := q.Scan(&list)
+	if err != nil {
+		return nil, err
+	}
+	reports := SummarizeTrials(list)
+	for _, report := range reports {
+		plan := query.GetPlanById(ctx, report.PlanId)
+		if plan != nil {
+			report.PlanName = plan.PlanName
+		}
+	}
+	return reports, nil
+}
+
+// GetTrialList returns the card-less trials of the merchant, filtered by subscription, user and status if specified
+func GetTrialList(ctx context.Context, merchantId uint64, subscriptionId string, userId uint64, status int, page int, count int) ([]*entity.SubscriptionTrial, int, error) {
+	var list []*entity.SubscriptionTrial
+	var total = 0
+	q := dao.SubscriptionTrial.Ctx(ctx).
+		Where(dao.SubscriptionTrial.Columns().MerchantId, merchantId).
+		Where(dao.SubscriptionTrial.Columns().IsDeleted, 0)
+	if len(subscriptionId) > 0 {
+		q = q.Where(dao.SubscriptionTrial.Columns().SubscriptionId, subscriptionId)
+	}
+	if userId > 0 {
+		q = q.Where(dao.SubscriptionTrial.Columns().UserId, userId)
+	}
+	if status > 0 {
+		q = q.Where(dao.SubscriptionTrial.Columns().Status, status)
+	}
+	if count > 0 {
+		q = q.Limit(page*count, count)
+	}
+	err := q.OrderDesc(dao.SubscriptionTrial.Columns().Id).ScanAndCount(&list, &total, true)
+	if err != nil {
+		return nil, 0, err
+	}
+	return list, total, nil
+}
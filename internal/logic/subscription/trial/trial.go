@@ -0,0 +1,205 @@
+package trial
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"unibee/api/bean"
+	"unibee/internal/consts"
+	"unibee/internal/controller/link"
+	dao "unibee/internal/dao/default"
+	"unibee/internal/logic/operation_log"
+	"unibee/internal/logic/payment/method"
+	"unibee/internal/logic/plan"
+	"unibee/internal/logic/subscription/service"
+	entity "unibee/internal/model/entity/default"
+	"unibee/internal/query"
+	"unibee/utility"
+	"unibee/utility/unibee"
+
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+type CreateInternalReq struct {
+	MerchantId     uint64                 `json:"merchantId" dc:"MerchantId"`
+	PlanId         uint64                 `json:"planId" dc:"PlanId"`
+	UserId         uint64                 `json:"userId" dc:"UserId"`
+	Currency       string                 `json:"currency" dc:"The currency"`
+	Quantity       int64                  `json:"quantity" dc:"Quantity，Default 1"`
+	AddonParams    []*bean.PlanAddonParam `json:"addonParams" dc:"addonParams"`
+	TrialEnd       int64                  `json:"trialEnd" dc:"trial_end, utc time, override plan's trial duration if specified"`
+	VatCountryCode string                 `json:"vatCountryCode" dc:"VatCountryCode, CountryName"`
+	VatNumber      string                 `json:"vatNumber" dc:"VatNumber"`
+	TaxPercentage  *int64                 `json:"taxPercentage" dc:"TaxPercentage，1000 = 10%"`
+	ReturnUrl      string                 `json:"returnUrl" dc:"ReturnUrl, back to returnUrl after the payment method attached"`
+	Metadata       map[string]interface{} `json:"metadata" dc:"Metadata，Map"`
+}
+
+// TrialCreate starts the trial subscription without gateway and payment method,
+// the trial ends by the plan's trial duration or the trialEnd specified
+func TrialCreate(ctx context.Context, req *CreateInternalReq) (*entity.SubscriptionTrial, *entity.Subscription, error) {
+	utility.Assert(req != nil, "req not found")
+	utility.Assert(req.UserId > 0, "userId invalid")
+	one := query.GetPlanById(ctx, req.PlanId)
+	utility.Assert(one != nil, "plan not found")
+	utility.Assert(one.MerchantId == req.MerchantId, "merchant not match")
+	utility.Assert(one.Type == consts.PlanTypeMain, "card-less trial only available for main plan")
+	utility.Assert(!strings.Contains(one.TrialDemand, "paymentMethod"), "card-less trial not available for plan with payment method demand")
+	timeNow := gtime.Now().Timestamp()
+	if req.TrialEnd > 0 {
+		utility.Assert(req.TrialEnd > timeNow, "trialEnd should be later than now")
+	} else {
+		utility.Assert(one.TrialDurationTime > 0, "plan has no trial, trialEnd should be specified")
+		utility.Assert(one.TrialAmount == 0, "card-less trial not available for paid trial")
+	}
+	createRes, err := service.SubscriptionCreate(ctx, &service.CreateInternalReq{
+		MerchantId:     req.MerchantId,
+		PlanId:         req.PlanId,
+		UserId:         req.UserId,
+		Currency:       req.Currency,
+		Quantity:       req.Quantity,
+		AddonParams:    req.AddonParams,
+		TrialEnd:       req.TrialEnd,
+		VatCountryCode: req.VatCountryCode,
+		VatNumber:      req.VatNumber,
+		TaxPercentage:  req.TaxPercentage,
+		ReturnUrl:      req.ReturnUrl,
+		Metadata:       req.Metadata,
+		CardLessTrial:  true,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	sub := query.GetSubscriptionBySubscriptionId(ctx, createRes.Subscription.SubscriptionId)
+	utility.Assert(sub != nil, "subscription not found")
+	trial := &entity.SubscriptionTrial{
+		MerchantId:       sub.MerchantId,
+		UserId:           sub.UserId,
+		SubscriptionId:   sub.SubscriptionId,
+		PlanId:           sub.PlanId,
+		TrialId:          utility.CreateSubscriptionTrialId(),
+		TrialStart:       sub.CurrentPeriodStart,
+		TrialEnd:         utility.MaxInt64(sub.CurrentPeriodEnd, sub.TrialEnd),
+		TrialEndBehavior: plan.GetTrialEndBehavior(one),
+		LinkToken:        utility.GenerateRandomAlphanumeric(40),
+		Status:           consts.SubTrialStatusTrialing,
+		CreateTime:       timeNow,
+	}
+	_, err = dao.SubscriptionTrial.Ctx(ctx).Data(trial).OmitNil().Insert(trial)
+	operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
+		MerchantId:     sub.MerchantId,
+		Target:         fmt.Sprintf("SubscriptionTrial(%s)", trial.TrialId),
+		Content:        fmt.Sprintf("New(%s)", trial.TrialEndBehavior),
+		UserId:         sub.UserId,
+		SubscriptionId: sub.SubscriptionId,
+		InvoiceId:      "",
+		PlanId:         sub.PlanId,
+		DiscountCode:   "",
+	}, err)
+	if err != nil {
+		return nil, nil, err
+	}
+	return trial, sub, nil
+}
+
+// GetTrialLink returns the hosted link the user attaches payment method to the trial through
+func GetTrialLink(one *entity.SubscriptionTrial) string {
+	if one == nil {
+		return ""
+	}
+	return link.GetSubscriptionTrialLink(one.TrialId, one.LinkToken)
+}
+
+// hasPaymentMethod returns true when the subscription can be charged automatically at trial end
+func hasPaymentMethod(ctx context.Context, sub *entity.Subscription) bool {
+	if sub.GatewayId > 0 && len(sub.GatewayDefaultPaymentMethod) > 0 {
+		return true
+	}
+	user := query.GetUserAccountById(ctx, sub.UserId)
+	if user == nil || len(user.GatewayId) == 0 || len(user.PaymentMethod) == 0 {
+		return false
+	}
+	gatewayId, err := strconv.ParseUint(user.GatewayId, 10, 64)
+	return err == nil && gatewayId > 0
+}
+
+// defaultPaymentMethodGateway returns the user's default gateway, or the first gateway of merchant the payment method can be attached to
+func defaultPaymentMethodGateway(ctx context.Context, sub *entity.Subscription) uint64 {
+	if sub.GatewayId > 0 {
+		return sub.GatewayId
+	}
+	user := query.GetUserAccountById(ctx, sub.UserId)
+	if user != nil && len(user.GatewayId) > 0 {
+		gatewayId, err := strconv.ParseUint(user.GatewayId, 10, 64)
+		if err == nil && gatewayId > 0 {
+			return gatewayId
+		}
+	}
+	for _, gateway := range query.GetMerchantGatewayList(ctx, sub.MerchantId, unibee.Bool(false)) {
+		if gateway.GatewayName == "stripe" || gateway.GatewayType == consts.GatewayTypePaypal {
+			return gateway.Id
+		}
+	}
+	return 0
+}
+
+// PaymentMethodLink returns the gateway link the user attaches payment method to the trial subscription through,
+// the default gateway is used if gatewayId not specified
+func PaymentMethodLink(ctx context.Context, one *entity.SubscriptionTrial, gatewayId uint64, returnUrl string) (string, error) {
+	utility.Assert(one != nil, "trial not found")
+	utility.Assert(one.Status == consts.SubTrialStatusTrialing, "trial already ended")
+	sub := query.GetSubscriptionBySubscriptionId(ctx, one.SubscriptionId)
+	utility.Assert(sub != nil, "subscription not found")
+	if gatewayId == 0 {
+		gatewayId = defaultPaymentMethodGateway(ctx, sub)
+	}
+	utility.Assert(gatewayId > 0, "no gateway available to attach payment method")
+	gateway := query.GetGatewayById(ctx, gatewayId)
+	utility.Assert(gateway != nil && gateway.MerchantId == sub.MerchantId, "gateway not found")
+	if len(returnUrl) == 0 {
+		returnUrl = sub.ReturnUrl
+	}
+	url, _ := method.NewPaymentMethod(ctx, &method.NewPaymentMethodInternalReq{
+		MerchantId:     sub.MerchantId,
+		UserId:         sub.UserId,
+		Currency:       sub.Currency,
+		GatewayId:      gatewayId,
+		SubscriptionId: sub.SubscriptionId,
+		RedirectUrl:    returnUrl,
+		Metadata:       map[string]interface{}{"TrialId": one.TrialId, "Action": "TrialPaymentMethod"},
+	})
+	if len(url) == 0 {
+		return "", fmt.Errorf("payment method link not available for gateway:%d", gatewayId)
+	}
+	return url, nil
+}
+
+type LinkCheckRes struct {
+	Link    string
+	Message string
+}
+
+// LinkCheck verifies the hosted trial link and returns the gateway link to redirect to
+func LinkCheck(ctx context.Context, trialId string, st string, gatewayId uint64) *LinkCheckRes {
+	one := query.GetSubscriptionTrialByTrialId(ctx, trialId)
+	if one == nil || len(st) == 0 || st != one.LinkToken {
+		return &LinkCheckRes{Message: "Invalid link"}
+	}
+	if one.Status != consts.SubTrialStatusTrialing {
+		return &LinkCheckRes{Message: "Trial Ended"}
+	}
+	var res = &LinkCheckRes{}
+	utility.Try(func() {
+		url, err := PaymentMethodLink(ctx, one, gatewayId, "")
+		if err != nil {
+			res.Message = err.Error()
+		} else {
+			res.Link = url
+		}
+	}, func(err interface{}) {
+		res.Message = fmt.Sprintf("%v", err)
+	})
+	return res
+}
@@ -0,0 +1,53 @@
+package trial
+
+import (
+	"testing"
+
+	"unibee/internal/consts"
+	entity "unibee/internal/model/entity/default"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNeedTrialEndReminder(t *testing.T) {
+	var trialEnd int64 = 1000000
+	one := &entity.SubscriptionTrial{TrialEnd: trialEnd, Status: consts.SubTrialStatusTrialing}
+	require.False(t, NeedTrialEndReminder(one, 3, trialEnd-3*86400-1))
+	require.True(t, NeedTrialEndReminder(one, 3, trialEnd-3*86400))
+	require.True(t, NeedTrialEndReminder(one, 3, trialEnd-1))
+	require.False(t, NeedTrialEndReminder(one, 3, trialEnd))
+	require.False(t, NeedTrialEndReminder(one, 0, trialEnd-1))
+
+	one.ReminderTime = trialEnd - 86400
+	require.False(t, NeedTrialEndReminder(one, 3, trialEnd-1))
+	one.ReminderTime = 0
+	one.PaymentMethodTime = trialEnd - 86400
+	require.False(t, NeedTrialEndReminder(one, 3, trialEnd-1))
+	one.PaymentMethodTime = 0
+	one.Status = consts.SubTrialStatusConverted
+	require.False(t, NeedTrialEndReminder(one, 3, trialEnd-1))
+}
+
+func TestSummarizeTrials(t *testing.T) {
+	reports := SummarizeTrials([]*entity.SubscriptionTrial{
+		{PlanId: 2, Status: consts.SubTrialStatusConverted, PaymentMethodTime: 100},
+		{PlanId: 2, Status: consts.SubTrialStatusConverted, PaymentMethodTime: 100},
+		{PlanId: 2, Status: consts.SubTrialStatusPaused},
+		{PlanId: 2, Status: consts.SubTrialStatusTrialing, PaymentMethodTime: 100},
+		{PlanId: 2, Status: consts.SubTrialStatusCancelled},
+		{PlanId: 1, Status: consts.SubTrialStatusTrialing},
+	})
+	require.Equal(t, 2, len(reports))
+	require.Equal(t, uint64(1), reports[0].PlanId)
+	require.Equal(t, int64(1), reports[0].Started)
+	require.Equal(t, int64(1), reports[0].Trialing)
+	require.Equal(t, int64(0), reports[0].ConversionRate)
+
+	require.Equal(t, int64(5), reports[1].Started)
+	require.Equal(t, int64(1), reports[1].Trialing)
+	require.Equal(t, int64(3), reports[1].PaymentMethodAttached)
+	require.Equal(t, int64(2), reports[1].Converted)
+	require.Equal(t, int64(1), reports[1].Paused)
+	require.Equal(t, int64(1), reports[1].Cancelled)
+	require.Equal(t, int64(5000), reports[1].ConversionRate)
+}
@@ -0,0 +1,161 @@
+package rules
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	dao "unibee/internal/dao/default"
+	"unibee/internal/logic/operation_log"
+	entity "unibee/internal/model/entity/default"
+	"unibee/internal/query"
+	"unibee/utility"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+type RuleInternalReq struct {
+	MerchantId    uint64  `json:"merchantId"`
+	RuleId        uint64  `json:"ruleId"`
+	Name          *string `json:"name"`
+	Jurisdiction  *string `json:"jurisdiction"`
+	CountryCode   *string `json:"countryCode"`
+	RegionCode    *string `json:"regionCode"`
+	City          *string `json:"city"`
+	ZipCodePrefix *string `json:"zipCodePrefix"`
+	TaxCategory   *string `json:"taxCategory"`
+	Rate          *int64  `json:"rate"`
+	Compound      *bool   `json:"compound"`
+	Priority      *int    `json:"priority"`
+}
+
+func NewTaxRule(ctx context.Context, req *RuleInternalReq) (*entity.MerchantTaxRule, error) {
+	utility.Assert(req != nil, "invalid request")
+	utility.Assert(req.MerchantId > 0, "invalid merchantId")
+	utility.Assert(req.Name != nil && len(strings.TrimSpace(*req.Name)) > 0, "name is required")
+	utility.Assert(req.CountryCode != nil && len(strings.TrimSpace(*req.CountryCode)) > 0, "countryCode is required")
+	utility.Assert(req.Rate != nil, "rate is required")
+	one := &entity.MerchantTaxRule{
+		MerchantId: req.MerchantId,
+		CreateTime: gtime.Now().Timestamp(),
+	}
+	applyRuleReq(one, req)
+	result, err := dao.MerchantTaxRule.Ctx(ctx).Data(one).OmitNil().Insert(one)
+	if err != nil {
+		return nil, gerror.Newf(`NewTaxRule record insert failure %s`, err)
+	}
+	id, _ := result.LastInsertId()
+	one.Id = uint64(id)
+	operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
+		MerchantId: one.MerchantId,
+		Target:     fmt.Sprintf("TaxRule(%v)", one.Id),
+		Content:    "New",
+	}, err)
+	return one, nil
+}
+
+func EditTaxRule(ctx context.Context, req *RuleInternalReq) (*entity.MerchantTaxRule, error) {
+	utility.Assert(req != nil, "invalid request")
+	one := query.GetMerchantTaxRuleById(ctx, req.RuleId)
+	utility.Assert(one != nil, "tax rule not found")
+	utility.Assert(one.MerchantId == req.MerchantId, "wrong merchant account")
+	utility.Assert(req.Name == nil || len(strings.TrimSpace(*req.Name)) > 0, "name is required")
+	utility.Assert(req.CountryCode == nil || len(strings.TrimSpace(*req.CountryCode)) > 0, "countryCode is required")
+	applyRuleReq(one, req)
+	_, err := dao.MerchantTaxRule.Ctx(ctx).Data(g.Map{
+		dao.MerchantTaxRule.Columns().Name:          one.Name,
+		dao.MerchantTaxRule.Columns().Jurisdiction:  one.Jurisdiction,
+		dao.MerchantTaxRule.Columns().CountryCode:   one.CountryCode,
+		dao.MerchantTaxRule.Columns().RegionCode:    one.RegionCode,
+		dao.MerchantTaxRule.Columns().City:          one.City,
+		dao.MerchantTaxRule.Columns().ZipCodePrefix: one.ZipCodePrefix,
+		dao.MerchantTaxRule.Columns().TaxCategory:   one.TaxCategory,
+		dao.MerchantTaxRule.Columns().Rate:          one.Rate,
+		dao.MerchantTaxRule.Columns().Compound:      one.Compound,
+		dao.MerchantTaxRule.Columns().Priority:      one.Priority,
+		dao.MerchantTaxRule.Columns().GmtModify:     gtime.Now(),
+	}).Where(dao.MerchantTaxRule.Columns().Id, one.Id).Update()
+	operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
+		MerchantId: one.MerchantId,
+		Target:     fmt.Sprintf("TaxRule(%v)", one.Id),
+		Content:    "Edit",
+	}, err)
+	if err != nil {
+		return nil, gerror.Newf(`EditTaxRule record update failure %s`, err)
+	}
+	return one, nil
+}
+
+func DeleteTaxRule(ctx context.Context, merchantId uint64, ruleId uint64) error {
+	one := query.GetMerchantTaxRuleById(ctx, ruleId)
+	utility.Assert(one != nil, "tax rule not found")
+	utility.Assert(one.MerchantId == merchantId, "wrong merchant account")
+	_, err := dao.MerchantTaxRule.Ctx(ctx).Data(g.Map{
+		dao.MerchantTaxRule.Columns().IsDeleted: 1,
+		dao.MerchantTaxRule.Columns().GmtModify: gtime.Now(),
+	}).Where(dao.MerchantTaxRule.Columns().Id, one.Id).Update()
+	operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
+		MerchantId: one.MerchantId,
+		Target:     fmt.Sprintf("TaxRule(%v)", one.Id),
+		Content:    "Delete",
+	}, err)
+	return err
+}
+
+func ListTaxRule(ctx context.Context, merchantId uint64, countryCode string) []*entity.MerchantTaxRule {
+	var list = make([]*entity.MerchantTaxRule, 0)
+	q := dao.MerchantTaxRule.Ctx(ctx).
+		Where(dao.MerchantTaxRule.Columns().MerchantId, merchantId).
+		Where(dao.MerchantTaxRule.Columns().IsDeleted, 0)
+	if len(countryCode) > 0 {
+		q = q.Where(dao.MerchantTaxRule.Columns().CountryCode, strings.ToUpper(countryCode))
+	}
+	err := q.OrderAsc(dao.MerchantTaxRule.Columns().CountryCode).
+		OrderAsc(dao.MerchantTaxRule.Columns().Priority).
+		OrderAsc(dao.MerchantTaxRule.Columns().Id).
+		Scan(&list)
+	if err != nil {
+		g.Log().Errorf(ctx, "ListTaxRule error:%s", err.Error())
+		return make([]*entity.MerchantTaxRule, 0)
+	}
+	return list
+}
+
+func applyRuleReq(one *entity.MerchantTaxRule, req *RuleInternalReq) {
+	if req.Name != nil {
+		one.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Jurisdiction != nil {
+		one.Jurisdiction = strings.TrimSpace(*req.Jurisdiction)
+	}
+	if req.CountryCode != nil {
+		one.CountryCode = strings.ToUpper(strings.TrimSpace(*req.CountryCode))
+	}
+	if req.RegionCode != nil {
+		one.RegionCode = strings.ToUpper(strings.TrimSpace(*req.RegionCode))
+	}
+	if req.City != nil {
+		one.City = strings.TrimSpace(*req.City)
+	}
+	if req.ZipCodePrefix != nil {
+		one.ZipCodePrefix = normalizeZipCode(*req.ZipCodePrefix)
+	}
+	if req.TaxCategory != nil {
+		one.TaxCategory = strings.TrimSpace(*req.TaxCategory)
+	}
+	if req.Rate != nil {
+		utility.Assert(*req.Rate >= 0 && *req.Rate <= 10000, "rate should between 0 and 10000")
+		one.Rate = *req.Rate
+	}
+	if req.Compound != nil {
+		one.Compound = 0
+		if *req.Compound {
+			one.Compound = 1
+		}
+	}
+	if req.Priority != nil {
+		one.Priority = *req.Priority
+	}
+}
@@ -0,0 +1,102 @@
+package rules
+
+import (
+	"context"
+	"math"
+	"strings"
+
+	"unibee/api/bean"
+	"unibee/internal/logic/tax/tax_bean"
+	entity "unibee/internal/model/entity/default"
+	"unibee/internal/query"
+	"unibee/utility"
+)
+
+const ProviderName = "local"
+
+// LocalRules computes the taxes from the merchant tax rules table
+type LocalRules struct {
+}
+
+func (l LocalRules) GetProviderName() string {
+	return ProviderName
+}
+
+func (l LocalRules) ComputeTax(ctx context.Context, req *tax_bean.ComputeTaxReq) (res *tax_bean.ComputeTaxRes, err error) {
+	utility.Assert(req != nil, "invalid request")
+	return ComputeTaxLines(query.GetMerchantTaxRuleListByCountry(ctx, req.MerchantId, req.CountryCode), req), nil
+}
+
+// ComputeTaxLines stacks all the rules matching the address and the tax category of each item in priority order,
+// a blank rule field matches any value, and a rule with a tax category replaces the rule without category
+// of the same name and jurisdiction, compound rules are computed on the amount plus the previous taxes of the item
+func ComputeTaxLines(rules []*entity.MerchantTaxRule, req *tax_bean.ComputeTaxReq) *tax_bean.ComputeTaxRes {
+	res := &tax_bean.ComputeTaxRes{Items: make([]*tax_bean.ComputeTaxItemResult, 0)}
+	for _, item := range req.Items {
+		result := &tax_bean.ComputeTaxItemResult{TaxLines: make([]*bean.InvoiceItemTaxLine, 0)}
+		res.Items = append(res.Items, result)
+		if item == nil {
+			continue
+		}
+		var previousTax int64 = 0
+		for _, rule := range matchRules(rules, req, item.TaxCategory) {
+			base := item.Amount
+			if rule.Compound == 1 {
+				base = base + previousTax
+			}
+			amount := int64(math.Round(float64(base) * utility.ConvertTaxPercentageToInternalFloat(rule.Rate)))
+			previousTax = previousTax + amount
+			result.TaxLines = append(result.TaxLines, &bean.InvoiceItemTaxLine{
+				Name:         rule.Name,
+				Jurisdiction: rule.Jurisdiction,
+				Rate:         rule.Rate,
+				Amount:       amount,
+				Compound:     rule.Compound == 1,
+			})
+		}
+	}
+	return res
+}
+
+func matchRules(rules []*entity.MerchantTaxRule, req *tax_bean.ComputeTaxReq, taxCategory string) []*entity.MerchantTaxRule {
+	var matched = make([]*entity.MerchantTaxRule, 0)
+	var index = make(map[string]int)
+	for _, rule := range rules {
+		if !matchAddress(rule, req) {
+			continue
+		}
+		if len(rule.TaxCategory) > 0 && !strings.EqualFold(rule.TaxCategory, taxCategory) {
+			continue
+		}
+		key := rule.Name + "|" + rule.Jurisdiction
+		if i, ok := index[key]; ok {
+			if len(matched[i].TaxCategory) == 0 && len(rule.TaxCategory) > 0 {
+				matched[i] = rule
+			}
+			continue
+		}
+		index[key] = len(matched)
+		matched = append(matched, rule)
+	}
+	return matched
+}
+
+func matchAddress(rule *entity.MerchantTaxRule, req *tax_bean.ComputeTaxReq) bool {
+	if !strings.EqualFold(rule.CountryCode, req.CountryCode) {
+		return false
+	}
+	if len(rule.RegionCode) > 0 && !strings.EqualFold(rule.RegionCode, strings.TrimSpace(req.RegionCode)) {
+		return false
+	}
+	if len(rule.City) > 0 && !strings.EqualFold(rule.City, strings.TrimSpace(req.City)) {
+		return false
+	}
+	if len(rule.ZipCodePrefix) > 0 && !strings.HasPrefix(normalizeZipCode(req.ZipCode), normalizeZipCode(rule.ZipCodePrefix)) {
+		return false
+	}
+	return true
+}
+
+func normalizeZipCode(zipCode string) string {
+	return strings.ToUpper(strings.NewReplacer(" ", "", "-", "").Replace(zipCode))
+}
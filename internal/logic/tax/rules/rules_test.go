@@ -0,0 +1,70 @@
+package rules
+
+import (
+	"testing"
+
+	"unibee/internal/logic/tax/tax_bean"
+	entity "unibee/internal/model/entity/default"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeTaxLines(t *testing.T) {
+	rules := []*entity.MerchantTaxRule{
+		{Id: 1, Name: "State Tax", Jurisdiction: "US-CA", CountryCode: "US", RegionCode: "CA", Rate: 600},
+		{Id: 2, Name: "State Tax", Jurisdiction: "US-CA", CountryCode: "US", RegionCode: "CA", TaxCategory: "saas", Rate: 0},
+		{Id: 3, Name: "County Tax", Jurisdiction: "US-CA-Los Angeles", CountryCode: "US", RegionCode: "CA", ZipCodePrefix: "900", Rate: 25, Priority: 1},
+		{Id: 4, Name: "City Tax", Jurisdiction: "US-CA-Los Angeles", CountryCode: "US", RegionCode: "CA", City: "Los Angeles", Rate: 100, Priority: 2},
+		{Id: 5, Name: "GST", Jurisdiction: "CA", CountryCode: "CA", Rate: 500},
+		{Id: 6, Name: "QST", Jurisdiction: "CA-QC", CountryCode: "CA", RegionCode: "QC", Rate: 1000, Compound: 1, Priority: 1},
+	}
+	t.Run("stacked state county and city taxes", func(t *testing.T) {
+		res := ComputeTaxLines(rules, &tax_bean.ComputeTaxReq{
+			CountryCode: "us",
+			RegionCode:  "ca",
+			City:        "los angeles",
+			ZipCode:     "90001-1234",
+			Items:       []*tax_bean.ComputeTaxItem{{Amount: 10000}, {Amount: 10000, TaxCategory: "saas"}},
+		})
+		require.Equal(t, 2, len(res.Items))
+		require.Equal(t, 3, len(res.Items[0].TaxLines))
+		require.Equal(t, int64(600), res.Items[0].TaxLines[0].Amount)
+		require.Equal(t, int64(25), res.Items[0].TaxLines[1].Amount)
+		require.Equal(t, int64(100), res.Items[0].TaxLines[2].Amount)
+		require.Equal(t, int64(725), res.Items[0].TaxAmount())
+		require.Equal(t, 3, len(res.Items[1].TaxLines))
+		require.Equal(t, int64(0), res.Items[1].TaxLines[0].Rate)
+		require.Equal(t, int64(125), res.Items[1].TaxAmount())
+	})
+	t.Run("address outside the local jurisdictions", func(t *testing.T) {
+		res := ComputeTaxLines(rules, &tax_bean.ComputeTaxReq{
+			CountryCode: "US",
+			RegionCode:  "CA",
+			City:        "San Diego",
+			ZipCode:     "92101",
+			Items:       []*tax_bean.ComputeTaxItem{{Amount: 10000}},
+		})
+		require.Equal(t, 1, len(res.Items[0].TaxLines))
+		require.Equal(t, int64(600), res.Items[0].TaxAmount())
+	})
+	t.Run("compound tax", func(t *testing.T) {
+		res := ComputeTaxLines(rules, &tax_bean.ComputeTaxReq{
+			CountryCode: "CA",
+			RegionCode:  "QC",
+			Items:       []*tax_bean.ComputeTaxItem{{Amount: 10000}, {Amount: -2000}},
+		})
+		require.Equal(t, 2, len(res.Items[0].TaxLines))
+		require.Equal(t, int64(500), res.Items[0].TaxLines[0].Amount)
+		require.Equal(t, int64(1050), res.Items[0].TaxLines[1].Amount)
+		require.True(t, res.Items[0].TaxLines[1].Compound)
+		require.Equal(t, int64(-310), res.Items[1].TaxAmount())
+	})
+	t.Run("no matching rule", func(t *testing.T) {
+		res := ComputeTaxLines(rules, &tax_bean.ComputeTaxReq{
+			CountryCode: "DE",
+			Items:       []*tax_bean.ComputeTaxItem{{Amount: 10000}},
+		})
+		require.Equal(t, 1, len(res.Items))
+		require.Equal(t, 0, len(res.Items[0].TaxLines))
+	})
+}
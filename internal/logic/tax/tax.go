@@ -0,0 +1,192 @@
+package tax
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+
+	"unibee/api/bean"
+	"unibee/internal/logic/merchant_config"
+	"unibee/internal/logic/merchant_config/update"
+	"unibee/internal/logic/tax/rules"
+	"unibee/internal/logic/tax/tax_bean"
+	"unibee/internal/query"
+	"unibee/utility"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+const (
+	MerchantTaxEngineConfig = "KEY_MERCHANT_TAX_ENGINE"
+	DefaultProviderName     = rules.ProviderName
+)
+
+type Config struct {
+	Enable   bool   `json:"enable"   dc:"Compute the invoice taxes with the tax engine instead of the single vat tax percentage"`
+	Provider string `json:"provider" dc:"Tax provider name, default local, the local provider computes from the merchant tax rules"`
+}
+
+var providers = make(map[string]TaxProvider)
+
+func init() {
+	RegisterTaxProvider(rules.LocalRules{})
+}
+
+// RegisterTaxProvider makes the tax provider available by its name, the later one replaces the provider with the same name
+func RegisterTaxProvider(provider TaxProvider) {
+	utility.Assert(provider != nil && len(provider.GetProviderName()) > 0, "invalid tax provider")
+	providers[provider.GetProviderName()] = provider
+}
+
+func GetTaxProvider(name string) TaxProvider {
+	if len(name) == 0 {
+		name = DefaultProviderName
+	}
+	return providers[name]
+}
+
+func GetTaxProviderNames() []string {
+	var names = make([]string, 0)
+	for name := range providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func GetMerchantTaxEngineConfig(ctx context.Context, merchantId uint64) *Config {
+	config := &Config{Provider: DefaultProviderName}
+	one := merchant_config.GetMerchantConfig(ctx, merchantId, MerchantTaxEngineConfig)
+	if one != nil && len(one.ConfigValue) > 0 {
+		_ = utility.UnmarshalFromJsonString(one.ConfigValue, config)
+	}
+	if len(config.Provider) == 0 {
+		config.Provider = DefaultProviderName
+	}
+	return config
+}
+
+func SetupMerchantTaxEngineConfig(ctx context.Context, merchantId uint64, config *Config) error {
+	utility.Assert(config != nil, "invalid config")
+	if len(config.Provider) == 0 {
+		config.Provider = DefaultProviderName
+	}
+	utility.Assert(GetTaxProvider(config.Provider) != nil, "tax provider not support, should be "+strings.Join(GetTaxProviderNames(), "|"))
+	return update.SetMerchantConfig(ctx, merchantId, MerchantTaxEngineConfig, utility.MarshalToJsonString(config))
+}
+
+// ComputeTax computes the tax lines with the merchant tax provider, whether the engine is enabled or not
+func ComputeTax(ctx context.Context, req *tax_bean.ComputeTaxReq) (*tax_bean.ComputeTaxRes, error) {
+	utility.Assert(req != nil, "invalid request")
+	config := GetMerchantTaxEngineConfig(ctx, req.MerchantId)
+	provider := GetTaxProvider(config.Provider)
+	if provider == nil {
+		return nil, gerror.Newf("tax provider %s not found", config.Provider)
+	}
+	res, err := provider.ComputeTax(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if res == nil || len(res.Items) != len(req.Items) {
+		return nil, gerror.Newf("tax provider %s result not match the items", config.Provider)
+	}
+	return res, nil
+}
+
+// ApplyTaxEngine replaces the tax of the invoice lines with the tax lines of the merchant tax engine,
+// the invoice keeps the vat tax percentage when the engine is disabled, fails or no tax rule matches
+func ApplyTaxEngine(ctx context.Context, merchantId uint64, userId uint64, invoice *bean.Invoice) {
+	if invoice == nil || len(invoice.Lines) == 0 {
+		return
+	}
+	if !GetMerchantTaxEngineConfig(ctx, merchantId).Enable {
+		return
+	}
+	req := &tax_bean.ComputeTaxReq{
+		MerchantId:  merchantId,
+		UserId:      userId,
+		Currency:    invoice.Currency,
+		CountryCode: invoice.CountryCode,
+		VatNumber:   invoice.VatNumber,
+		Items:       make([]*tax_bean.ComputeTaxItem, 0),
+	}
+	user := query.GetUserAccountById(ctx, userId)
+	if user != nil {
+		if len(req.CountryCode) == 0 {
+			req.CountryCode = user.CountryCode
+		}
+		req.RegionCode = user.RegionCode
+		req.City = user.City
+		req.ZipCode = user.ZipCode
+	}
+	if len(req.CountryCode) == 0 {
+		return
+	}
+	for _, line := range invoice.Lines {
+		var taxCategory = ""
+		if line.Plan != nil {
+			taxCategory = line.Plan.TaxCategory
+		}
+		req.Items = append(req.Items, &tax_bean.ComputeTaxItem{
+			Amount:      line.Amount - line.Tax,
+			TaxCategory: taxCategory,
+		})
+	}
+	res, err := ComputeTax(ctx, req)
+	if err != nil {
+		g.Log().Errorf(ctx, "ApplyTaxEngine merchantId:%d userId:%d error:%s", merchantId, userId, err.Error())
+		return
+	}
+	ApplyTaxResult(invoice, res)
+}
+
+// ApplyTaxResult sets the tax lines of the result to the invoice lines and moves the invoice totals by the tax difference,
+// the tax percentage of the lines and the invoice become the effective rate of their tax lines
+func ApplyTaxResult(invoice *bean.Invoice, res *tax_bean.ComputeTaxRes) bool {
+	if invoice == nil || res == nil || len(res.Items) != len(invoice.Lines) {
+		return false
+	}
+	var matched = false
+	for _, item := range res.Items {
+		if item != nil && len(item.TaxLines) > 0 {
+			matched = true
+			break
+		}
+	}
+	if !matched {
+		return false
+	}
+	var totalNet int64 = 0
+	var totalTax int64 = 0
+	for i, line := range invoice.Lines {
+		net := line.Amount - line.Tax
+		var tax int64 = 0
+		line.TaxLines = make([]*bean.InvoiceItemTaxLine, 0)
+		if res.Items[i] != nil {
+			tax = res.Items[i].TaxAmount()
+			line.TaxLines = res.Items[i].TaxLines
+		}
+		line.Tax = tax
+		line.Amount = net + tax
+		line.OriginAmount = line.Amount + line.DiscountAmount
+		line.TaxPercentage = effectiveTaxPercentage(net, tax)
+		totalNet = totalNet + net
+		totalTax = totalTax + tax
+	}
+	delta := totalTax - invoice.TaxAmount
+	invoice.TaxAmount = totalTax
+	invoice.TotalAmount = invoice.TotalAmount + delta
+	invoice.OriginAmount = invoice.OriginAmount + delta
+	invoice.SubscriptionAmount = invoice.SubscriptionAmount + delta
+	invoice.TaxPercentage = effectiveTaxPercentage(totalNet, totalTax)
+	return true
+}
+
+func effectiveTaxPercentage(net int64, tax int64) int64 {
+	if net == 0 {
+		return 0
+	}
+	return int64(math.Round(float64(tax) * 10000 / float64(net)))
+}
@@ -0,0 +1,39 @@
+package tax_bean
+
+import (
+	"unibee/api/bean"
+)
+
+type ComputeTaxReq struct {
+	MerchantId  uint64            `json:"merchantId"`
+	UserId      uint64            `json:"userId"`
+	Currency    string            `json:"currency"`
+	CountryCode string            `json:"countryCode"`
+	RegionCode  string            `json:"regionCode"  description:"state or province code"`
+	City        string            `json:"city"`
+	ZipCode     string            `json:"zipCode"`
+	VatNumber   string            `json:"vatNumber"`
+	Items       []*ComputeTaxItem `json:"items"`
+}
+
+type ComputeTaxItem struct {
+	Amount      int64  `json:"amount"      description:"amount excluding tax, cent"`
+	TaxCategory string `json:"taxCategory" description:"tax category of the item, from the plan"`
+}
+
+type ComputeTaxRes struct {
+	Items []*ComputeTaxItemResult `json:"items" description:"tax result of the items, same order as the request items"`
+}
+
+type ComputeTaxItemResult struct {
+	TaxLines []*bean.InvoiceItemTaxLine `json:"taxLines"`
+}
+
+// TaxAmount returns the sum of the tax lines of the item
+func (r *ComputeTaxItemResult) TaxAmount() int64 {
+	var amount int64 = 0
+	for _, taxLine := range r.TaxLines {
+		amount = amount + taxLine.Amount
+	}
+	return amount
+}
@@ -0,0 +1,11 @@
+package tax
+
+import (
+	"context"
+	"unibee/internal/logic/tax/tax_bean"
+)
+
+type TaxProvider interface {
+	GetProviderName() string
+	ComputeTax(ctx context.Context, req *tax_bean.ComputeTaxReq) (res *tax_bean.ComputeTaxRes, err error)
+}
@@ -0,0 +1,44 @@
+package tax
+
+import (
+	"testing"
+
+	"unibee/api/bean"
+	"unibee/internal/logic/tax/tax_bean"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyTaxResult(t *testing.T) {
+	invoice := &bean.Invoice{
+		OriginAmount:       12200,
+		TotalAmount:        11000,
+		TaxAmount:          1000,
+		TaxPercentage:      1000,
+		SubscriptionAmount: 12200,
+		Lines: []*bean.InvoiceItemSimplify{
+			{Amount: 8800, Tax: 800, OriginAmount: 10000, DiscountAmount: 1200, TaxPercentage: 1000},
+			{Amount: 2200, Tax: 200, OriginAmount: 2200, TaxPercentage: 1000},
+		},
+	}
+	require.False(t, ApplyTaxResult(invoice, &tax_bean.ComputeTaxRes{Items: []*tax_bean.ComputeTaxItemResult{{}, {}}}))
+	require.False(t, ApplyTaxResult(invoice, &tax_bean.ComputeTaxRes{Items: []*tax_bean.ComputeTaxItemResult{{}}}))
+	require.Equal(t, int64(1000), invoice.TaxAmount)
+
+	require.True(t, ApplyTaxResult(invoice, &tax_bean.ComputeTaxRes{Items: []*tax_bean.ComputeTaxItemResult{
+		{TaxLines: []*bean.InvoiceItemTaxLine{{Name: "GST", Rate: 500, Amount: 400}, {Name: "PST", Rate: 700, Amount: 560}}},
+		{},
+	}}))
+	require.Equal(t, int64(960), invoice.Lines[0].Tax)
+	require.Equal(t, int64(8960), invoice.Lines[0].Amount)
+	require.Equal(t, int64(10160), invoice.Lines[0].OriginAmount)
+	require.Equal(t, int64(1200), invoice.Lines[0].TaxPercentage)
+	require.Equal(t, int64(0), invoice.Lines[1].Tax)
+	require.Equal(t, int64(2000), invoice.Lines[1].Amount)
+	require.Equal(t, int64(0), invoice.Lines[1].TaxPercentage)
+	require.Equal(t, int64(960), invoice.TaxAmount)
+	require.Equal(t, int64(10960), invoice.TotalAmount)
+	require.Equal(t, int64(12160), invoice.OriginAmount)
+	require.Equal(t, int64(960), invoice.TaxPercentage)
+	require.Equal(t, 2, len(bean.SummaryInvoiceTaxLines(invoice.Lines)))
+}
@@ -0,0 +1,106 @@
+package tax
+
+import (
+	"context"
+	"strings"
+
+	"unibee/api/bean"
+	"unibee/internal/consts"
+	entity "unibee/internal/model/entity/default"
+	"unibee/internal/query"
+
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+// ApplyInvoiceTax applies the merchant tax engine and then the customer tax treatment to the computed invoice
+func ApplyInvoiceTax(ctx context.Context, merchantId uint64, userId uint64, invoice *bean.Invoice) {
+	if invoice == nil {
+		return
+	}
+	ApplyTaxEngine(ctx, merchantId, userId, invoice)
+	ApplyTaxTreatment(invoice, ResolveTaxTreatment(ctx, merchantId, userId, invoice.VatNumber))
+}
+
+// ResolveTaxTreatment returns the tax treatment of the customer, the reverse charge of a taxable customer
+// relies on the vat number validation history, so that billing never calls the vat gateway
+func ResolveTaxTreatment(ctx context.Context, merchantId uint64, userId uint64, vatNumber string) string {
+	user := query.GetUserAccountById(ctx, userId)
+	if user == nil {
+		return consts.InvoiceTaxTreatmentStandard
+	}
+	if len(vatNumber) == 0 {
+		vatNumber = user.VATNumber
+	}
+	var validVatCountryCode = ""
+	if len(vatNumber) > 0 && user.TaxStatus == consts.UserTaxStatusTaxable {
+		history := query.GetVatNumberValidateHistory(ctx, merchantId, vatNumber)
+		if history != nil && history.Valid == 1 {
+			validVatCountryCode = history.CountryCode
+			if len(validVatCountryCode) == 0 {
+				validVatCountryCode = user.CountryCode
+			}
+		}
+	}
+	var merchantCountryCode = ""
+	if merchant := query.GetMerchantById(ctx, merchantId); merchant != nil {
+		merchantCountryCode = merchant.CountryCode
+	}
+	return ComputeTaxTreatment(user, vatNumber, validVatCountryCode, merchantCountryCode, gtime.Now().Timestamp())
+}
+
+// ComputeTaxTreatment returns exempt for an exempt customer with an unexpired certificate, reverse charge for a
+// reverse charge customer with vat number, or for a taxable customer whose validated vat number belongs to another
+// country than the merchant, otherwise the standard treatment. A merchant without country code can not tell a
+// cross-border customer, its taxable customers stay standard
+func ComputeTaxTreatment(user *entity.UserAccount, vatNumber string, validVatCountryCode string, merchantCountryCode string, timeNow int64) string {
+	if user == nil {
+		return consts.InvoiceTaxTreatmentStandard
+	}
+	switch user.TaxStatus {
+	case consts.UserTaxStatusExempt:
+		if len(user.TaxExemptCertificate) > 0 && (user.TaxExemptExpireTime == 0 || user.TaxExemptExpireTime > timeNow) {
+			return consts.InvoiceTaxTreatmentExempt
+		}
+	case consts.UserTaxStatusReverseCharge:
+		if len(vatNumber) > 0 {
+			return consts.InvoiceTaxTreatmentReverseCharge
+		}
+	default:
+		if len(vatNumber) > 0 && len(validVatCountryCode) > 0 && len(strings.TrimSpace(merchantCountryCode)) > 0 && !sameVatCountry(validVatCountryCode, merchantCountryCode) {
+			return consts.InvoiceTaxTreatmentReverseCharge
+		}
+	}
+	return consts.InvoiceTaxTreatmentStandard
+}
+
+// sameVatCountry compares the vat country codes, VIES uses EL for Greece
+func sameVatCountry(vatCountryCode string, countryCode string) bool {
+	normalize := func(code string) string {
+		code = strings.ToUpper(strings.TrimSpace(code))
+		if code == "EL" {
+			return "GR"
+		}
+		return code
+	}
+	return len(countryCode) > 0 && normalize(vatCountryCode) == normalize(countryCode)
+}
+
+// ApplyTaxTreatment removes the tax of a reverse charge or exempt invoice and marks the treatment on the invoice
+func ApplyTaxTreatment(invoice *bean.Invoice, treatment string) {
+	if invoice == nil || treatment == consts.InvoiceTaxTreatmentStandard {
+		return
+	}
+	for _, line := range invoice.Lines {
+		line.Amount = line.Amount - line.Tax
+		line.OriginAmount = line.OriginAmount - line.Tax
+		line.Tax = 0
+		line.TaxPercentage = 0
+		line.TaxLines = nil
+	}
+	invoice.TotalAmount = invoice.TotalAmount - invoice.TaxAmount
+	invoice.OriginAmount = invoice.OriginAmount - invoice.TaxAmount
+	invoice.SubscriptionAmount = invoice.SubscriptionAmount - invoice.TaxAmount
+	invoice.TaxAmount = 0
+	invoice.TaxPercentage = 0
+	invoice.TaxTreatment = treatment
+}
@@ -0,0 +1,66 @@
+package tax
+
+import (
+	"testing"
+
+	"unibee/api/bean"
+	"unibee/internal/consts"
+	entity "unibee/internal/model/entity/default"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeTaxTreatment(t *testing.T) {
+	var timeNow int64 = 1700000000
+	require.Equal(t, consts.InvoiceTaxTreatmentStandard, ComputeTaxTreatment(nil, "", "", "", timeNow))
+	taxable := &entity.UserAccount{TaxStatus: consts.UserTaxStatusTaxable}
+	require.Equal(t, consts.InvoiceTaxTreatmentStandard, ComputeTaxTreatment(taxable, "", "", "DE", timeNow))
+	require.Equal(t, consts.InvoiceTaxTreatmentStandard, ComputeTaxTreatment(taxable, "FR123", "", "DE", timeNow))
+	require.Equal(t, consts.InvoiceTaxTreatmentStandard, ComputeTaxTreatment(taxable, "DE123", "DE", "DE", timeNow))
+	require.Equal(t, consts.InvoiceTaxTreatmentReverseCharge, ComputeTaxTreatment(taxable, "FR123", "FR", "DE", timeNow))
+	require.Equal(t, consts.InvoiceTaxTreatmentStandard, ComputeTaxTreatment(taxable, "EL123", "EL", "GR", timeNow))
+	require.Equal(t, consts.InvoiceTaxTreatmentStandard, ComputeTaxTreatment(taxable, "FR123", "FR", "", timeNow))
+	require.Equal(t, consts.InvoiceTaxTreatmentStandard, ComputeTaxTreatment(taxable, "FR123", "FR", " ", timeNow))
+
+	exempt := &entity.UserAccount{TaxStatus: consts.UserTaxStatusExempt, TaxExemptCertificate: "EX-001"}
+	require.Equal(t, consts.InvoiceTaxTreatmentExempt, ComputeTaxTreatment(exempt, "", "", "DE", timeNow))
+	exempt.TaxExemptExpireTime = timeNow - 1
+	require.Equal(t, consts.InvoiceTaxTreatmentStandard, ComputeTaxTreatment(exempt, "", "", "DE", timeNow))
+	exempt.TaxExemptExpireTime = 0
+	exempt.TaxExemptCertificate = ""
+	require.Equal(t, consts.InvoiceTaxTreatmentStandard, ComputeTaxTreatment(exempt, "", "", "DE", timeNow))
+
+	reverse := &entity.UserAccount{TaxStatus: consts.UserTaxStatusReverseCharge}
+	require.Equal(t, consts.InvoiceTaxTreatmentStandard, ComputeTaxTreatment(reverse, "", "", "DE", timeNow))
+	require.Equal(t, consts.InvoiceTaxTreatmentReverseCharge, ComputeTaxTreatment(reverse, "DE123", "", "DE", timeNow))
+}
+
+func TestApplyTaxTreatment(t *testing.T) {
+	invoice := &bean.Invoice{
+		OriginAmount:       12200,
+		TotalAmount:        11000,
+		TaxAmount:          1000,
+		TaxPercentage:      1000,
+		SubscriptionAmount: 12200,
+		Lines: []*bean.InvoiceItemSimplify{
+			{Amount: 8800, Tax: 800, OriginAmount: 10000, DiscountAmount: 1200, TaxPercentage: 1000, TaxLines: []*bean.InvoiceItemTaxLine{{Name: "VAT", Rate: 1000, Amount: 800}}},
+			{Amount: 2200, Tax: 200, OriginAmount: 2200, TaxPercentage: 1000},
+		},
+	}
+	ApplyTaxTreatment(invoice, consts.InvoiceTaxTreatmentStandard)
+	require.Equal(t, int64(1000), invoice.TaxAmount)
+	require.Equal(t, consts.InvoiceTaxTreatmentStandard, invoice.TaxTreatment)
+
+	ApplyTaxTreatment(invoice, consts.InvoiceTaxTreatmentReverseCharge)
+	require.Equal(t, consts.InvoiceTaxTreatmentReverseCharge, invoice.TaxTreatment)
+	require.Equal(t, int64(0), invoice.TaxAmount)
+	require.Equal(t, int64(0), invoice.TaxPercentage)
+	require.Equal(t, int64(10000), invoice.TotalAmount)
+	require.Equal(t, int64(11200), invoice.OriginAmount)
+	require.Equal(t, int64(11200), invoice.SubscriptionAmount)
+	require.Equal(t, int64(8000), invoice.Lines[0].Amount)
+	require.Equal(t, int64(9200), invoice.Lines[0].OriginAmount)
+	require.Equal(t, int64(0), invoice.Lines[0].Tax)
+	require.Nil(t, invoice.Lines[0].TaxLines)
+	require.Equal(t, int64(2000), invoice.Lines[1].Amount)
+}
@@ -77,3 +77,30 @@ func UpdateUserDefaultSubscriptionForPaymentSuccess(ctx context.Context, userId
 		}
 	}
 }
+
+func ClearUserDefaultSubscriptionForTransfer(ctx context.Context, userId uint64, subscriptionId string) {
+	if userId > 0 && len(subscriptionId) > 0 {
+		user := query.GetUserAccountById(ctx, userId)
+		if user != nil && user.SubscriptionId == subscriptionId {
+			_, err := dao.UserAccount.Ctx(ctx).Data(g.Map{
+				dao.UserAccount.Columns().PlanId:             0,
+				dao.UserAccount.Columns().SubscriptionId:     "",
+				dao.UserAccount.Columns().SubscriptionStatus: 0,
+				dao.UserAccount.Columns().SubscriptionName:   "",
+				dao.UserAccount.Columns().GmtModify:          gtime.Now(),
+			}).Where(dao.UserAccount.Columns().Id, userId).
+				Where(dao.UserAccount.Columns().SubscriptionId, subscriptionId).
+				OmitNil().Update()
+			if err != nil {
+				g.Log().Errorf(ctx, "ClearUserDefaultSubscriptionForTransfer err:%s", err.Error())
+			} else {
+				_, _ = redismq.Send(&redismq.Message{
+					Topic:      redismq2.TopicUserAccountUpdate.Topic,
+					Tag:        redismq2.TopicUserAccountUpdate.Tag,
+					Body:       fmt.Sprintf("%d", user.Id),
+					CustomData: map[string]interface{}{"CreateFrom": utility.ReflectCurrentFunctionName()},
+				})
+			}
+		}
+	}
+}
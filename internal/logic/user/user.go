@@ -132,7 +132,7 @@ func FrozenUser(ctx context.Context, userId int64) {
 	subs := query.GetLatestActiveOrIncompleteOrCreateSubscriptionsByUserId(ctx, one.Id, one.MerchantId)
 	for _, sub := range subs {
 		if sub != nil {
-			err = service.SubscriptionCancel(ctx, sub.SubscriptionId, false, false, "CancelledByAdminSuspendUser")
+			err = service.SubscriptionCancelBySystem(ctx, sub.SubscriptionId, "CancelledByAdminSuspendUser")
 			utility.AssertError(err, "server error")
 		}
 	}
@@ -257,6 +257,7 @@ func CreateUser(ctx context.Context, req *NewUserInternalReq) (one *entity.UserA
 		VATNumber:          req.VATNumber,
 		City:               req.City,
 		ZipCode:            req.ZipCode,
+		RegionCode:         req.RegionCode,
 		Custom:             req.Custom,
 		TaxPercentage:      taxPercentage,
 		Language:           req.Language,
@@ -352,6 +353,7 @@ func QueryOrCreateUser(ctx context.Context, req *NewUserInternalReq) (one *entit
 			dao.UserAccount.Columns().City:               req.City,
 			dao.UserAccount.Columns().Type:               req.Type,
 			dao.UserAccount.Columns().ZipCode:            req.ZipCode,
+			dao.UserAccount.Columns().RegionCode:         req.RegionCode,
 			dao.UserAccount.Columns().Language:           req.Language,
 			dao.UserAccount.Columns().Address:            req.Address,
 			dao.UserAccount.Columns().CompanyName:        req.CompanyName,
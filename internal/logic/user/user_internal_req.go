@@ -16,6 +16,7 @@ type NewUserInternalReq struct {
 	VATNumber          string `json:"vATNumber" dc:"vat number"`
 	City               string `json:"city" dc:"city"`
 	ZipCode            string `json:"zipCode" dc:"zip_code"`
+	RegionCode         string `json:"regionCode" dc:"state or province code, matched by the tax rules of tax engine"`
 	Custom             string `json:"custom" dc:"Custom"`
 	MerchantId         uint64 `json:"merchantId" dc:"MerchantId"`
 	Language           string `json:"language" dc:"Language"`
@@ -53,6 +53,8 @@ type Context struct {
 	TokenString       string
 	Token             *TokenClaims
 	PreloadData       *PreloadData
+	IdempotencyKey    string
+	IdempotencyHash   string
 }
 
 type ContextUser struct {
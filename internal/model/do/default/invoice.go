@@ -71,4 +71,12 @@ type Invoice struct {
 	PromoCreditDiscountAmount      interface{} // promo credit discount amount
 	PartialCreditPaidAmount        interface{} // partial credit paid amount
 	MetricCharge                   interface{} // invoice metric charge data
+	InvoiceNumber                  interface{} // sequential legal invoice number
+	TaxTreatment                   interface{} // tax treatment, empty-standard，reverse_charge，exempt
+	ReviewFinalizeTime             interface{} // utc time the draft invoice in review is finalised，0-not in review
+	ReviewHold                     interface{} // 0-not held，1-held, the draft invoice in review is not finalised automatically
+	ConsolidatedInvoiceId          interface{} // id of the consolidated invoice this cycle invoice is charged by
+	NetTermDays                    interface{} // payment terms in days of the net term invoice，0-not net term
+	PaidAmount                     interface{} // amount received by partial payments, cent
+	OverdueReminderTime            interface{} // utc time the last overdue reminder is sent
 }
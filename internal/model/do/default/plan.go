@@ -48,4 +48,9 @@ type Plan struct {
 	DisableAutoCharge         interface{} // disable auto-charge, 0-false,1-true
 	MetricCharge              interface{} // metric charge(json)
 	InternalName              interface{} //
+	TrialEndBehavior          interface{} // behaviour at trial end of the subscription without payment method，convert|pause|cancel, default convert
+	TaxCategory               interface{} // tax category of the plan, matched by tax rules
+	InvoiceReviewHours        interface{} // hours the cycle invoices stay in draft for review before finalised，0-review disabled
+	MinSeats                  interface{} // min seats (quantity) of the subscription，0-no limit
+	MaxSeats                  interface{} // max seats (quantity) of the subscription，0-no limit
 }
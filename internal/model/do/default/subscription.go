@@ -64,4 +64,8 @@ type Subscription struct {
 	LastTrackTime               interface{} // last subscription track time
 	ExternalSubscriptionId      interface{} // external_subscription_id
 	NextInvoiceData             interface{} // next_invoice_data
+	InvoiceReviewHours          interface{} // hours the cycle invoices stay in draft for review，0-follow plan，-1-review disabled
+	PauseMode                   interface{} // pause billing behaviour，keep_as_draft|void|mark_uncollectible
+	PauseTime                   interface{} // utc time the subscription paused, 0-not paused
+	ResumeTime                  interface{} // utc time the paused subscription resumes automatically, 0-resume manually
 }
@@ -46,4 +46,9 @@ type Plan struct {
 	DisableAutoCharge         int         `json:"disableAutoCharge"         description:"disable auto-charge, 0-false,1-true"`                                                                             // disable auto-charge, 0-false,1-true
 	MetricCharge              string      `json:"metricCharge"              description:"metric charge(json)"`                                                                                             // metric charge(json)
 	InternalName              string      `json:"internalName"              description:""`                                                                                                                //
+	TrialEndBehavior          string      `json:"trialEndBehavior"          description:"behaviour at trial end of the subscription without payment method，convert|pause|cancel, default convert"`         // behaviour at trial end of the subscription without payment method，convert|pause|cancel, default convert
+	TaxCategory               string      `json:"taxCategory"               description:"tax category of the plan, matched by tax rules"`                                                                  // tax category of the plan, matched by tax rules
+	InvoiceReviewHours        int         `json:"invoiceReviewHours"        description:"hours the cycle invoices stay in draft for review before finalised，0-review disabled"`                            // hours the cycle invoices stay in draft for review before finalised，0-review disabled
+	MinSeats                  int         `json:"minSeats"                  description:"min seats (quantity) of the subscription，0-no limit"`                                                             // min seats (quantity) of the subscription，0-no limit
+	MaxSeats                  int         `json:"maxSeats"                  description:"max seats (quantity) of the subscription，0-no limit"`                                                             // max seats (quantity) of the subscription，0-no limit
 }
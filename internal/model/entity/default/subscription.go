@@ -62,4 +62,8 @@ type Subscription struct {
 	LastTrackTime               int64       `json:"lastTrackTime"               description:"last subscription track time"`                                                                                                                                   // last subscription track time
 	ExternalSubscriptionId      string      `json:"externalSubscriptionId"      description:"external_subscription_id"`                                                                                                                                       // external_subscription_id
 	NextInvoiceData             string      `json:"nextInvoiceData"             description:"next_invoice_data"`                                                                                                                                              // next_invoice_data
+	InvoiceReviewHours          int         `json:"invoiceReviewHours"          description:"hours the cycle invoices stay in draft for review，0-follow plan，-1-review disabled"`                                                                             // hours the cycle invoices stay in draft for review，0-follow plan，-1-review disabled
+	PauseMode                   string      `json:"pauseMode"                   description:"pause billing behaviour，keep_as_draft|void|mark_uncollectible"`                                                                                                  // pause billing behaviour，keep_as_draft|void|mark_uncollectible
+	PauseTime                   int64       `json:"pauseTime"                   description:"utc time the subscription paused, 0-not paused"`                                                                                                                 // utc time the subscription paused, 0-not paused
+	ResumeTime                  int64       `json:"resumeTime"                  description:"utc time the paused subscription resumes automatically, 0-resume manually"`                                                                                      // utc time the paused subscription resumes automatically, 0-resume manually
 }
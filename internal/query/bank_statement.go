@@ -0,0 +1,36 @@
+package query
+
+import (
+	"context"
+	dao "unibee/internal/dao/default"
+	entity "unibee/internal/model/entity/default"
+)
+
+func GetBankStatementLineById(ctx context.Context, id uint64) (one *entity.BankStatementLine) {
+	if id <= 0 {
+		return nil
+	}
+	err := dao.BankStatementLine.Ctx(ctx).
+		Where(dao.BankStatementLine.Columns().Id, id).
+		Where(dao.BankStatementLine.Columns().IsDeleted, 0).
+		Scan(&one)
+	if err != nil {
+		one = nil
+	}
+	return
+}
+
+// GetBankStatementLineByKey returns the credit imported before with the same line key
+func GetBankStatementLineByKey(ctx context.Context, merchantId uint64, lineKey string) (one *entity.BankStatementLine) {
+	if merchantId <= 0 || len(lineKey) == 0 {
+		return nil
+	}
+	err := dao.BankStatementLine.Ctx(ctx).
+		Where(dao.BankStatementLine.Columns().MerchantId, merchantId).
+		Where(dao.BankStatementLine.Columns().LineKey, lineKey).
+		Scan(&one)
+	if err != nil {
+		one = nil
+	}
+	return
+}
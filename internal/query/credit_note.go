@@ -0,0 +1,35 @@
+package query
+
+import (
+	"context"
+	"unibee/internal/consts"
+	dao "unibee/internal/dao/default"
+	entity "unibee/internal/model/entity/default"
+)
+
+func GetCreditNoteByCreditNoteId(ctx context.Context, creditNoteId string) (one *entity.CreditNote) {
+	if len(creditNoteId) == 0 {
+		return nil
+	}
+	err := dao.CreditNote.Ctx(ctx).Where(dao.CreditNote.Columns().CreditNoteId, creditNoteId).Scan(&one)
+	if err != nil {
+		one = nil
+	}
+	return
+}
+
+// GetIssuedCreditNotesByInvoiceId returns the issued credit notes of the invoice, oldest first
+func GetIssuedCreditNotesByInvoiceId(ctx context.Context, invoiceId string) (list []*entity.CreditNote) {
+	if len(invoiceId) == 0 {
+		return make([]*entity.CreditNote, 0)
+	}
+	err := dao.CreditNote.Ctx(ctx).
+		Where(dao.CreditNote.Columns().InvoiceId, invoiceId).
+		Where(dao.CreditNote.Columns().Status, consts.CreditNoteStatusIssued).
+		OrderAsc(dao.CreditNote.Columns().Id).
+		Scan(&list)
+	if err != nil {
+		list = make([]*entity.CreditNote, 0)
+	}
+	return
+}
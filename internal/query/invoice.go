@@ -60,3 +60,17 @@ func GetSubLatestPaidInvoice(ctx context.Context, subId string) (one *entity.Inv
 	}
 	return one
 }
+
+func GetInvoicesByConsolidatedInvoiceId(ctx context.Context, consolidatedInvoiceId string) (list []*entity.Invoice) {
+	if len(consolidatedInvoiceId) == 0 {
+		return make([]*entity.Invoice, 0)
+	}
+	err := dao.Invoice.Ctx(ctx).
+		Where(dao.Invoice.Columns().ConsolidatedInvoiceId, consolidatedInvoiceId).
+		Where(dao.Invoice.Columns().IsDeleted, 0).
+		Scan(&list)
+	if err != nil || list == nil {
+		list = make([]*entity.Invoice, 0)
+	}
+	return
+}
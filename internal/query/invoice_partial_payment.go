@@ -0,0 +1,23 @@
+package query
+
+import (
+	"context"
+	dao "unibee/internal/dao/default"
+	entity "unibee/internal/model/entity/default"
+)
+
+// GetInvoicePartialPaymentsByInvoiceId returns the partial payments applied to the invoice, oldest first
+func GetInvoicePartialPaymentsByInvoiceId(ctx context.Context, invoiceId string) (list []*entity.InvoicePartialPayment) {
+	if len(invoiceId) == 0 {
+		return make([]*entity.InvoicePartialPayment, 0)
+	}
+	err := dao.InvoicePartialPayment.Ctx(ctx).
+		Where(dao.InvoicePartialPayment.Columns().InvoiceId, invoiceId).
+		Where(dao.InvoicePartialPayment.Columns().IsDeleted, 0).
+		OrderAsc(dao.InvoicePartialPayment.Columns().Id).
+		Scan(&list)
+	if err != nil || list == nil {
+		list = make([]*entity.InvoicePartialPayment, 0)
+	}
+	return
+}
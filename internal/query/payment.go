@@ -66,6 +66,20 @@ func GetPaymentByGatewayPaymentId(ctx context.Context, gatewayPaymentId string)
 	return
 }
 
+func GetPaymentByGatewayIdAndGatewayPaymentId(ctx context.Context, gatewayId uint64, gatewayPaymentId string) (one *entity.Payment) {
+	if gatewayId <= 0 || len(gatewayPaymentId) == 0 {
+		return nil
+	}
+	err := dao.Payment.Ctx(ctx).
+		Where(dao.Payment.Columns().GatewayId, gatewayId).
+		Where(dao.Payment.Columns().GatewayPaymentId, gatewayPaymentId).
+		OmitEmpty().Scan(&one)
+	if err != nil {
+		one = nil
+	}
+	return
+}
+
 func GetPaymentTimeLineByUniqueId(ctx context.Context, uniqueId string) (one *entity.PaymentTimeline) {
 	if len(uniqueId) == 0 {
 		return nil
@@ -0,0 +1,61 @@
+package query
+
+import (
+	"context"
+	dao "unibee/internal/dao/default"
+	entity "unibee/internal/model/entity/default"
+)
+
+func GetSettlementById(ctx context.Context, id uint64) (one *entity.GatewaySettlement) {
+	if id <= 0 {
+		return nil
+	}
+	err := dao.GatewaySettlement.Ctx(ctx).Where(dao.GatewaySettlement.Columns().Id, id).Scan(&one)
+	if err != nil {
+		one = nil
+	}
+	return
+}
+
+func GetSettlementByGatewaySettlementId(ctx context.Context, gatewayId uint64, gatewaySettlementId string) (one *entity.GatewaySettlement) {
+	if gatewayId <= 0 || len(gatewaySettlementId) == 0 {
+		return nil
+	}
+	err := dao.GatewaySettlement.Ctx(ctx).
+		Where(dao.GatewaySettlement.Columns().GatewayId, gatewayId).
+		Where(dao.GatewaySettlement.Columns().GatewaySettlementId, gatewaySettlementId).
+		Scan(&one)
+	if err != nil {
+		one = nil
+	}
+	return
+}
+
+func GetSettlementItemByGatewayTransactionId(ctx context.Context, gatewayId uint64, gatewayTransactionId string) (one *entity.GatewaySettlementItem) {
+	if gatewayId <= 0 || len(gatewayTransactionId) == 0 {
+		return nil
+	}
+	err := dao.GatewaySettlementItem.Ctx(ctx).
+		Where(dao.GatewaySettlementItem.Columns().GatewayId, gatewayId).
+		Where(dao.GatewaySettlementItem.Columns().GatewayTransactionId, gatewayTransactionId).
+		Scan(&one)
+	if err != nil {
+		one = nil
+	}
+	return
+}
+
+func GetSettlementItemList(ctx context.Context, settlementId uint64) (list []*entity.GatewaySettlementItem) {
+	if settlementId <= 0 {
+		return make([]*entity.GatewaySettlementItem, 0)
+	}
+	err := dao.GatewaySettlementItem.Ctx(ctx).
+		Where(dao.GatewaySettlementItem.Columns().SettlementId, settlementId).
+		Where(dao.GatewaySettlementItem.Columns().IsDeleted, 0).
+		OrderAsc(dao.GatewaySettlementItem.Columns().TransactionTime).
+		Scan(&list)
+	if err != nil {
+		list = make([]*entity.GatewaySettlementItem, 0)
+	}
+	return
+}
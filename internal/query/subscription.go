@@ -59,6 +59,24 @@ func GetLatestActiveOrIncompleteSubscriptionByUserId(ctx context.Context, userId
 	return
 }
 
+func GetLatestSuspendedSubscriptionByUserId(ctx context.Context, userId uint64, merchantId uint64, productId int64) (one *entity.Subscription) {
+	if userId <= 0 || merchantId <= 0 {
+		return nil
+	}
+	err := dao.Subscription.Ctx(ctx).
+		Where(dao.Subscription.Columns().UserId, userId).
+		Where(dao.Subscription.Columns().MerchantId, merchantId).
+		WhereIn(dao.Subscription.Columns().PlanId, GetPlanIdsByProductId(ctx, merchantId, productId)).
+		Where(dao.Subscription.Columns().IsDeleted, 0).
+		Where(dao.Subscription.Columns().Status, consts.SubStatusSuspended).
+		OrderDesc(dao.Subscription.Columns().GmtModify).
+		Scan(&one)
+	if err != nil {
+		one = nil
+	}
+	return
+}
+
 func GetLatestCreateOrProcessingSubscriptionByUserId(ctx context.Context, userId uint64, merchantId uint64, productId int64) (one *entity.Subscription) {
 	if userId <= 0 || merchantId <= 0 {
 		return nil
@@ -86,7 +104,7 @@ func GetLatestActiveOrIncompleteOrCreateSubscriptionByUserId(ctx context.Context
 		Where(dao.Subscription.Columns().MerchantId, merchantId).
 		WhereIn(dao.Subscription.Columns().PlanId, GetPlanIdsByProductId(ctx, merchantId, productId)).
 		Where(dao.Subscription.Columns().IsDeleted, 0).
-		WhereIn(dao.Subscription.Columns().Status, []int{consts.SubStatusPending, consts.SubStatusProcessing, consts.SubStatusActive, consts.SubStatusIncomplete}).
+		WhereIn(dao.Subscription.Columns().Status, []int{consts.SubStatusPending, consts.SubStatusProcessing, consts.SubStatusActive, consts.SubStatusIncomplete, consts.SubStatusSuspended}).
 		OrderDesc(dao.Subscription.Columns().GmtModify).
 		Scan(&one)
 	if err != nil {
@@ -103,7 +121,7 @@ func GetLatestActiveOrIncompleteOrCreateSubscriptionsByUserId(ctx context.Contex
 		Where(dao.Subscription.Columns().UserId, userId).
 		Where(dao.Subscription.Columns().MerchantId, merchantId).
 		Where(dao.Subscription.Columns().IsDeleted, 0).
-		WhereIn(dao.Subscription.Columns().Status, []int{consts.SubStatusPending, consts.SubStatusProcessing, consts.SubStatusActive, consts.SubStatusIncomplete}).
+		WhereIn(dao.Subscription.Columns().Status, []int{consts.SubStatusPending, consts.SubStatusProcessing, consts.SubStatusActive, consts.SubStatusIncomplete, consts.SubStatusSuspended}).
 		OrderDesc(dao.Subscription.Columns().GmtModify).
 		Scan(&list)
 	if err != nil || list == nil {
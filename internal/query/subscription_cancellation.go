@@ -0,0 +1,70 @@
+package query
+
+import (
+	"context"
+	"unibee/internal/consts"
+	dao "unibee/internal/dao/default"
+	entity "unibee/internal/model/entity/default"
+)
+
+func GetSubscriptionCancellationByCancellationId(ctx context.Context, cancellationId string) (one *entity.SubscriptionCancellation) {
+	if len(cancellationId) == 0 {
+		return nil
+	}
+	err := dao.SubscriptionCancellation.Ctx(ctx).
+		Where(dao.SubscriptionCancellation.Columns().CancellationId, cancellationId).
+		Where(dao.SubscriptionCancellation.Columns().IsDeleted, 0).
+		Scan(&one)
+	if err != nil {
+		one = nil
+	}
+	return
+}
+
+func GetSubscriptionRetentionOfferById(ctx context.Context, id uint64) (one *entity.SubscriptionRetentionOffer) {
+	if id <= 0 {
+		return nil
+	}
+	err := dao.SubscriptionRetentionOffer.Ctx(ctx).
+		Where(dao.SubscriptionRetentionOffer.Columns().Id, id).
+		Where(dao.SubscriptionRetentionOffer.Columns().IsDeleted, 0).
+		Scan(&one)
+	if err != nil {
+		one = nil
+	}
+	return
+}
+
+func GetActiveSubscriptionRetentionOffers(ctx context.Context, merchantId uint64) (list []*entity.SubscriptionRetentionOffer) {
+	list = make([]*entity.SubscriptionRetentionOffer, 0)
+	if merchantId <= 0 {
+		return list
+	}
+	err := dao.SubscriptionRetentionOffer.Ctx(ctx).
+		Where(dao.SubscriptionRetentionOffer.Columns().MerchantId, merchantId).
+		Where(dao.SubscriptionRetentionOffer.Columns().Status, consts.RetentionOfferStatusActive).
+		Where(dao.SubscriptionRetentionOffer.Columns().IsDeleted, 0).
+		OrderDesc(dao.SubscriptionRetentionOffer.Columns().Priority).
+		OrderAsc(dao.SubscriptionRetentionOffer.Columns().Id).
+		Scan(&list)
+	if err != nil {
+		list = make([]*entity.SubscriptionRetentionOffer, 0)
+	}
+	return
+}
+
+func GetStartedSubscriptionCancellationBySubscriptionId(ctx context.Context, subscriptionId string) (one *entity.SubscriptionCancellation) {
+	if len(subscriptionId) == 0 {
+		return nil
+	}
+	err := dao.SubscriptionCancellation.Ctx(ctx).
+		Where(dao.SubscriptionCancellation.Columns().SubscriptionId, subscriptionId).
+		Where(dao.SubscriptionCancellation.Columns().Status, consts.SubCancellationStatusStarted).
+		Where(dao.SubscriptionCancellation.Columns().IsDeleted, 0).
+		OrderDesc(dao.SubscriptionCancellation.Columns().Id).
+		Scan(&one)
+	if err != nil {
+		one = nil
+	}
+	return
+}
@@ -0,0 +1,38 @@
+package query
+
+import (
+	"context"
+	"unibee/internal/consts"
+	dao "unibee/internal/dao/default"
+	entity "unibee/internal/model/entity/default"
+)
+
+func GetSubscriptionCommitmentByCommitmentId(ctx context.Context, commitmentId string) (one *entity.SubscriptionCommitment) {
+	if len(commitmentId) == 0 {
+		return nil
+	}
+	err := dao.SubscriptionCommitment.Ctx(ctx).
+		Where(dao.SubscriptionCommitment.Columns().CommitmentId, commitmentId).
+		Where(dao.SubscriptionCommitment.Columns().IsDeleted, 0).
+		Scan(&one)
+	if err != nil {
+		one = nil
+	}
+	return
+}
+
+func GetActiveSubscriptionCommitmentBySubscriptionId(ctx context.Context, subscriptionId string) (one *entity.SubscriptionCommitment) {
+	if len(subscriptionId) == 0 {
+		return nil
+	}
+	err := dao.SubscriptionCommitment.Ctx(ctx).
+		Where(dao.SubscriptionCommitment.Columns().SubscriptionId, subscriptionId).
+		Where(dao.SubscriptionCommitment.Columns().Status, consts.SubCommitmentStatusActive).
+		Where(dao.SubscriptionCommitment.Columns().IsDeleted, 0).
+		OrderDesc(dao.SubscriptionCommitment.Columns().Id).
+		Scan(&one)
+	if err != nil {
+		one = nil
+	}
+	return
+}
@@ -0,0 +1,39 @@
+package query
+
+import (
+	"context"
+	dao "unibee/internal/dao/default"
+	entity "unibee/internal/model/entity/default"
+)
+
+func GetSubscriptionPendingInvoiceItemByItemId(ctx context.Context, itemId string) (one *entity.SubscriptionPendingInvoiceItem) {
+	if len(itemId) == 0 {
+		return nil
+	}
+	err := dao.SubscriptionPendingInvoiceItem.Ctx(ctx).
+		Where(dao.SubscriptionPendingInvoiceItem.Columns().ItemId, itemId).
+		Where(dao.SubscriptionPendingInvoiceItem.Columns().IsDeleted, 0).
+		Scan(&one)
+	if err != nil {
+		one = nil
+	}
+	return
+}
+
+func GetSubscriptionPendingInvoiceItemsByStatus(ctx context.Context, subscriptionId string, status []int) (list []*entity.SubscriptionPendingInvoiceItem) {
+	list = make([]*entity.SubscriptionPendingInvoiceItem, 0)
+	if len(subscriptionId) == 0 {
+		return list
+	}
+	q := dao.SubscriptionPendingInvoiceItem.Ctx(ctx).
+		Where(dao.SubscriptionPendingInvoiceItem.Columns().SubscriptionId, subscriptionId).
+		Where(dao.SubscriptionPendingInvoiceItem.Columns().IsDeleted, 0)
+	if len(status) > 0 {
+		q = q.WhereIn(dao.SubscriptionPendingInvoiceItem.Columns().Status, status)
+	}
+	err := q.OrderAsc(dao.SubscriptionPendingInvoiceItem.Columns().Id).Scan(&list)
+	if err != nil {
+		list = make([]*entity.SubscriptionPendingInvoiceItem, 0)
+	}
+	return
+}
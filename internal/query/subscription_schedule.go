@@ -0,0 +1,90 @@
+package query
+
+import (
+	"context"
+	"unibee/internal/consts"
+	dao "unibee/internal/dao/default"
+	entity "unibee/internal/model/entity/default"
+)
+
+func GetSubscriptionScheduleByScheduleId(ctx context.Context, scheduleId string) (one *entity.SubscriptionSchedule) {
+	if len(scheduleId) == 0 {
+		return nil
+	}
+	err := dao.SubscriptionSchedule.Ctx(ctx).
+		Where(dao.SubscriptionSchedule.Columns().ScheduleId, scheduleId).
+		Where(dao.SubscriptionSchedule.Columns().IsDeleted, 0).
+		Scan(&one)
+	if err != nil {
+		one = nil
+	}
+	return
+}
+
+func GetActiveSubscriptionScheduleBySubscriptionId(ctx context.Context, subscriptionId string) (one *entity.SubscriptionSchedule) {
+	if len(subscriptionId) == 0 {
+		return nil
+	}
+	err := dao.SubscriptionSchedule.Ctx(ctx).
+		Where(dao.SubscriptionSchedule.Columns().SubscriptionId, subscriptionId).
+		Where(dao.SubscriptionSchedule.Columns().Status, consts.SubScheduleStatusActive).
+		Where(dao.SubscriptionSchedule.Columns().IsDeleted, 0).
+		OrderDesc(dao.SubscriptionSchedule.Columns().Id).
+		Scan(&one)
+	if err != nil {
+		one = nil
+	}
+	return
+}
+
+func GetLatestSubscriptionScheduleBySubscriptionId(ctx context.Context, subscriptionId string) (one *entity.SubscriptionSchedule) {
+	if len(subscriptionId) == 0 {
+		return nil
+	}
+	err := dao.SubscriptionSchedule.Ctx(ctx).
+		Where(dao.SubscriptionSchedule.Columns().SubscriptionId, subscriptionId).
+		Where(dao.SubscriptionSchedule.Columns().IsDeleted, 0).
+		OrderDesc(dao.SubscriptionSchedule.Columns().Id).
+		Scan(&one)
+	if err != nil {
+		one = nil
+	}
+	return
+}
+
+// GetSubscriptionSchedulePhases returns the phases of the schedule ordered by phase index
+func GetSubscriptionSchedulePhases(ctx context.Context, scheduleId string) (list []*entity.SubscriptionSchedulePhase) {
+	if len(scheduleId) == 0 {
+		return make([]*entity.SubscriptionSchedulePhase, 0)
+	}
+	err := dao.SubscriptionSchedulePhase.Ctx(ctx).
+		Where(dao.SubscriptionSchedulePhase.Columns().ScheduleId, scheduleId).
+		Where(dao.SubscriptionSchedulePhase.Columns().IsDeleted, 0).
+		OrderAsc(dao.SubscriptionSchedulePhase.Columns().PhaseIndex).
+		Scan(&list)
+	if err != nil || list == nil {
+		list = make([]*entity.SubscriptionSchedulePhase, 0)
+	}
+	return
+}
+
+// GetSubscriptionSchedulePhaseByPeriodStart returns the started phase of the subscription covering the billing cycle starts at periodStart
+func GetSubscriptionSchedulePhaseByPeriodStart(ctx context.Context, subscriptionId string, periodStart int64) (one *entity.SubscriptionSchedulePhase) {
+	if len(subscriptionId) == 0 || periodStart <= 0 {
+		return nil
+	}
+	query := dao.SubscriptionSchedulePhase.Ctx(ctx)
+	err := query.
+		Where(dao.SubscriptionSchedulePhase.Columns().SubscriptionId, subscriptionId).
+		WhereIn(dao.SubscriptionSchedulePhase.Columns().Status, []int{consts.SubSchedulePhaseStatusActive, consts.SubSchedulePhaseStatusCompleted}).
+		WhereLTE(dao.SubscriptionSchedulePhase.Columns().StartTime, periodStart).
+		Where(dao.SubscriptionSchedulePhase.Columns().IsDeleted, 0).
+		Where(query.Builder().WhereOrGT(dao.SubscriptionSchedulePhase.Columns().EndTime, periodStart).
+			WhereOr(dao.SubscriptionSchedulePhase.Columns().EndTime, 0)).
+		OrderDesc(dao.SubscriptionSchedulePhase.Columns().StartTime).
+		Scan(&one)
+	if err != nil {
+		one = nil
+	}
+	return
+}
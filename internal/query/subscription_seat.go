@@ -0,0 +1,95 @@
+package query
+
+import (
+	"context"
+	"strings"
+	"unibee/internal/consts"
+	dao "unibee/internal/dao/default"
+	entity "unibee/internal/model/entity/default"
+)
+
+func GetSubscriptionSeatBySeatId(ctx context.Context, seatId string) (one *entity.SubscriptionSeat) {
+	if len(seatId) == 0 {
+		return nil
+	}
+	err := dao.SubscriptionSeat.Ctx(ctx).
+		Where(dao.SubscriptionSeat.Columns().SeatId, seatId).
+		Where(dao.SubscriptionSeat.Columns().IsDeleted, 0).
+		Scan(&one)
+	if err != nil {
+		one = nil
+	}
+	return
+}
+
+func GetAssignedSubscriptionSeats(ctx context.Context, subscriptionId string) (list []*entity.SubscriptionSeat) {
+	list = make([]*entity.SubscriptionSeat, 0)
+	if len(subscriptionId) == 0 {
+		return list
+	}
+	err := dao.SubscriptionSeat.Ctx(ctx).
+		Where(dao.SubscriptionSeat.Columns().SubscriptionId, subscriptionId).
+		Where(dao.SubscriptionSeat.Columns().Status, consts.SubSeatStatusAssigned).
+		Where(dao.SubscriptionSeat.Columns().IsDeleted, 0).
+		OrderAsc(dao.SubscriptionSeat.Columns().Id).
+		Scan(&list)
+	if err != nil {
+		list = make([]*entity.SubscriptionSeat, 0)
+	}
+	return
+}
+
+func CountAssignedSubscriptionSeats(ctx context.Context, subscriptionId string) int64 {
+	if len(subscriptionId) == 0 {
+		return 0
+	}
+	count, err := dao.SubscriptionSeat.Ctx(ctx).
+		Where(dao.SubscriptionSeat.Columns().SubscriptionId, subscriptionId).
+		Where(dao.SubscriptionSeat.Columns().Status, consts.SubSeatStatusAssigned).
+		Where(dao.SubscriptionSeat.Columns().IsDeleted, 0).
+		Count()
+	if err != nil {
+		return 0
+	}
+	return int64(count)
+}
+
+func GetAssignedSubscriptionSeatByMember(ctx context.Context, subscriptionId string, email string, externalId string) (one *entity.SubscriptionSeat) {
+	if len(subscriptionId) == 0 || (len(email) == 0 && len(externalId) == 0) {
+		return nil
+	}
+	q := dao.SubscriptionSeat.Ctx(ctx).
+		Where(dao.SubscriptionSeat.Columns().SubscriptionId, subscriptionId).
+		Where(dao.SubscriptionSeat.Columns().Status, consts.SubSeatStatusAssigned).
+		Where(dao.SubscriptionSeat.Columns().IsDeleted, 0)
+	if len(email) > 0 && len(externalId) > 0 {
+		q = q.Where(q.Builder().WhereOr(dao.SubscriptionSeat.Columns().Email, strings.ToLower(email)).WhereOr(dao.SubscriptionSeat.Columns().ExternalId, externalId))
+	} else if len(email) > 0 {
+		q = q.Where(dao.SubscriptionSeat.Columns().Email, strings.ToLower(email))
+	} else {
+		q = q.Where(dao.SubscriptionSeat.Columns().ExternalId, externalId)
+	}
+	err := q.Scan(&one)
+	if err != nil {
+		one = nil
+	}
+	return
+}
+
+func GetSubscriptionSeatChangesByPeriodStart(ctx context.Context, subscriptionId string, periodStart int64) (list []*entity.SubscriptionSeatChange) {
+	list = make([]*entity.SubscriptionSeatChange, 0)
+	if len(subscriptionId) == 0 {
+		return list
+	}
+	err := dao.SubscriptionSeatChange.Ctx(ctx).
+		Where(dao.SubscriptionSeatChange.Columns().SubscriptionId, subscriptionId).
+		Where(dao.SubscriptionSeatChange.Columns().PeriodStart, periodStart).
+		Where(dao.SubscriptionSeatChange.Columns().IsDeleted, 0).
+		OrderAsc(dao.SubscriptionSeatChange.Columns().ChangeTime).
+		OrderAsc(dao.SubscriptionSeatChange.Columns().Id).
+		Scan(&list)
+	if err != nil {
+		list = make([]*entity.SubscriptionSeatChange, 0)
+	}
+	return
+}
@@ -0,0 +1,38 @@
+package query
+
+import (
+	"context"
+	"unibee/internal/consts"
+	dao "unibee/internal/dao/default"
+	entity "unibee/internal/model/entity/default"
+)
+
+func GetSubscriptionTrialByTrialId(ctx context.Context, trialId string) (one *entity.SubscriptionTrial) {
+	if len(trialId) == 0 {
+		return nil
+	}
+	err := dao.SubscriptionTrial.Ctx(ctx).
+		Where(dao.SubscriptionTrial.Columns().TrialId, trialId).
+		Where(dao.SubscriptionTrial.Columns().IsDeleted, 0).
+		Scan(&one)
+	if err != nil {
+		one = nil
+	}
+	return
+}
+
+func GetTrialingSubscriptionTrialBySubscriptionId(ctx context.Context, subscriptionId string) (one *entity.SubscriptionTrial) {
+	if len(subscriptionId) == 0 {
+		return nil
+	}
+	err := dao.SubscriptionTrial.Ctx(ctx).
+		Where(dao.SubscriptionTrial.Columns().SubscriptionId, subscriptionId).
+		Where(dao.SubscriptionTrial.Columns().Status, consts.SubTrialStatusTrialing).
+		Where(dao.SubscriptionTrial.Columns().IsDeleted, 0).
+		OrderDesc(dao.SubscriptionTrial.Columns().Id).
+		Scan(&one)
+	if err != nil {
+		one = nil
+	}
+	return
+}
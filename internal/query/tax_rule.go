@@ -0,0 +1,39 @@
+package query
+
+import (
+	"context"
+	"strings"
+	dao "unibee/internal/dao/default"
+	entity "unibee/internal/model/entity/default"
+)
+
+func GetMerchantTaxRuleById(ctx context.Context, id uint64) (one *entity.MerchantTaxRule) {
+	if id <= 0 {
+		return nil
+	}
+	err := dao.MerchantTaxRule.Ctx(ctx).
+		Where(dao.MerchantTaxRule.Columns().Id, id).
+		Where(dao.MerchantTaxRule.Columns().IsDeleted, 0).
+		Scan(&one)
+	if err != nil {
+		one = nil
+	}
+	return
+}
+
+func GetMerchantTaxRuleListByCountry(ctx context.Context, merchantId uint64, countryCode string) (list []*entity.MerchantTaxRule) {
+	if merchantId <= 0 || len(countryCode) == 0 {
+		return make([]*entity.MerchantTaxRule, 0)
+	}
+	err := dao.MerchantTaxRule.Ctx(ctx).
+		Where(dao.MerchantTaxRule.Columns().MerchantId, merchantId).
+		Where(dao.MerchantTaxRule.Columns().CountryCode, strings.ToUpper(countryCode)).
+		Where(dao.MerchantTaxRule.Columns().IsDeleted, 0).
+		OrderAsc(dao.MerchantTaxRule.Columns().Priority).
+		OrderAsc(dao.MerchantTaxRule.Columns().Id).
+		Scan(&list)
+	if err != nil {
+		list = make([]*entity.MerchantTaxRule, 0)
+	}
+	return
+}
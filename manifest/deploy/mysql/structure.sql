@@ -17,6 +17,62 @@
 SET NAMES utf8mb4;
 SET FOREIGN_KEY_CHECKS = 0;
 
+-- ----------------------------
+-- Table structure for bank_statement
+-- ----------------------------
+DROP TABLE IF EXISTS `bank_statement`;
+CREATE TABLE `bank_statement` (
+                                  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT COMMENT 'id',
+                                  `merchant_id` bigint(20) unsigned NOT NULL DEFAULT '0' COMMENT 'merchant_id',
+                                  `format` varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT 'statement format, camt053|mt940|csv',
+                                  `file_name` varchar(256) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT 'imported file name',
+                                  `bank_statement_id` varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT 'statement id in the file',
+                                  `account` varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT 'bank account of the statement, iban or account number',
+                                  `currency` varchar(20) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT 'currency of the account',
+                                  `credit_count` int(11) NOT NULL DEFAULT '0' COMMENT 'count of imported credit lines',
+                                  `duplicate_count` int(11) NOT NULL DEFAULT '0' COMMENT 'count of credit lines skipped as imported before',
+                                  `auto_matched_count` int(11) NOT NULL DEFAULT '0' COMMENT 'count of lines matched and marked paid automatically',
+                                  `review_count` int(11) NOT NULL DEFAULT '0' COMMENT 'count of lines queued for review',
+                                  `unmatched_count` int(11) NOT NULL DEFAULT '0' COMMENT 'count of lines without matching invoice',
+                                  `gmt_create` timestamp NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'create time',
+                                  `gmt_modify` timestamp NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'update time',
+                                  `is_deleted` int(11) NOT NULL DEFAULT '0' COMMENT '0-UnDeleted，1-Deleted',
+                                  `create_time` bigint(20) DEFAULT NULL COMMENT 'create utc time',
+                                  PRIMARY KEY (`id`) USING BTREE,
+                                  KEY `idx_merchant` (`merchant_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Bank Statement';
+
+-- ----------------------------
+-- Table structure for bank_statement_line
+-- ----------------------------
+DROP TABLE IF EXISTS `bank_statement_line`;
+CREATE TABLE `bank_statement_line` (
+                                       `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT COMMENT 'id',
+                                       `merchant_id` bigint(20) unsigned NOT NULL DEFAULT '0' COMMENT 'merchant_id',
+                                       `statement_id` bigint(20) unsigned NOT NULL DEFAULT '0' COMMENT 'id of bank_statement',
+                                       `line_key` varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT 'unique key of the credit within the merchant, skip the credit imported again',
+                                       `bank_reference` varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT 'reference of the credit given by the bank',
+                                       `booking_time` bigint(20) NOT NULL DEFAULT '0' COMMENT 'booking utc time',
+                                       `currency` varchar(20) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT 'currency',
+                                       `amount` bigint(20) NOT NULL DEFAULT '0' COMMENT 'credit amount, cent',
+                                       `counterparty_name` varchar(256) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT 'name of the payer',
+                                       `counterparty_account` varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT 'account of the payer',
+                                       `remittance` varchar(1024) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT 'remittance information of the credit',
+                                       `status` int(11) NOT NULL DEFAULT '0' COMMENT 'status, 1-auto matched|2-need review|3-unmatched|4-manual matched|5-ignored',
+                                       `invoice_id` varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT 'matched invoice_id',
+                                       `confidence` int(11) NOT NULL DEFAULT '0' COMMENT 'confidence of the best match, 0-100',
+                                       `candidates` text CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci COMMENT 'json of the candidate invoices with confidence',
+                                       `note` varchar(1024) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT 'note of the match',
+                                       `gmt_create` timestamp NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'create time',
+                                       `gmt_modify` timestamp NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'update time',
+                                       `is_deleted` int(11) NOT NULL DEFAULT '0' COMMENT '0-UnDeleted，1-Deleted',
+                                       `create_time` bigint(20) DEFAULT NULL COMMENT 'create utc time',
+                                       PRIMARY KEY (`id`) USING BTREE,
+                                       UNIQUE KEY `bank_statement_line_unique` (`merchant_id`,`line_key`),
+                                       KEY `idx_statement` (`statement_id`),
+                                       KEY `idx_invoice` (`invoice_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Bank Statement Line';
+
 -- ----------------------------
 -- Table structure for country_rate
 -- ----------------------------
@@ -45,6 +101,39 @@ CREATE TABLE `country_rate` (
                                 UNIQUE KEY `unique` (`merchant_id`,`gateway`,`country_code`)
 ) ENGINE=InnoDB AUTO_INCREMENT=2925 DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Country Rate';
 
+-- ----------------------------
+-- Table structure for credit_note
+-- ----------------------------
+DROP TABLE IF EXISTS `credit_note`;
+CREATE TABLE `credit_note` (
+                             `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT COMMENT 'id',
+                             `merchant_id` bigint(20) unsigned NOT NULL DEFAULT '0' COMMENT 'merchant_id',
+                             `user_id` bigint(20) unsigned NOT NULL DEFAULT '0' COMMENT 'user_id',
+                             `credit_note_id` varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL COMMENT 'credit_note_id',
+                             `credit_note_number` varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT 'sequential number of the credit note series',
+                             `invoice_id` varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL COMMENT 'id of the credited invoice',
+                             `payment_id` varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci DEFAULT NULL COMMENT 'payment_id of the credited invoice',
+                             `refund_id` varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci DEFAULT NULL COMMENT 'refund_id, type 1 only',
+                             `credit_transaction_id` varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci DEFAULT NULL COMMENT 'credit transaction id, type 2 only',
+                             `subscription_id` varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci DEFAULT NULL COMMENT 'subscription_id',
+                             `type` int(11) NOT NULL DEFAULT '1' COMMENT '1-refund to the original payment method, 2-credit to customer balance',
+                             `status` int(11) NOT NULL DEFAULT '10' COMMENT '10-issued, 20-failed',
+                             `currency` varchar(20) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL COMMENT 'currency',
+                             `total_amount` bigint(20) NOT NULL DEFAULT '0' COMMENT 'total amount including tax, cent',
+                             `total_amount_excluding_tax` bigint(20) NOT NULL DEFAULT '0' COMMENT 'total amount excluding tax, cent',
+                             `tax_amount` bigint(20) NOT NULL DEFAULT '0' COMMENT 'tax amount, cent',
+                             `lines` text CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci COMMENT 'credited lines json',
+                             `reason` varchar(1024) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci DEFAULT NULL COMMENT 'reason',
+                             `link_token` varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci DEFAULT NULL COMMENT 'security token of the pdf link',
+                             `gmt_create` timestamp NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'create time',
+                             `gmt_modify` timestamp NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'update time',
+                             `create_time` bigint(20) DEFAULT NULL COMMENT 'create utc time',
+                             PRIMARY KEY (`id`) USING BTREE,
+                             UNIQUE KEY `unique_credit_note_id` (`credit_note_id`),
+                             KEY `idx_invoice_id` (`invoice_id`),
+                             KEY `idx_merchant_user` (`merchant_id`,`user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Credit Note';
+
 -- ----------------------------
 -- Table structure for email_default_template
 -- ----------------------------
@@ -100,6 +189,66 @@ CREATE TABLE `gateway_http_log` (
                                     PRIMARY KEY (`id`) USING BTREE
 ) ENGINE=InnoDB AUTO_INCREMENT=52564 DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Channel Http Log';
 
+-- ----------------------------
+-- Table structure for gateway_settlement
+-- ----------------------------
+DROP TABLE IF EXISTS `gateway_settlement`;
+CREATE TABLE `gateway_settlement` (
+                                      `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT COMMENT 'id',
+                                      `merchant_id` bigint(20) unsigned NOT NULL DEFAULT '0' COMMENT 'merchant_id',
+                                      `gateway_id` bigint(20) unsigned NOT NULL DEFAULT '0' COMMENT 'gateway_id',
+                                      `gateway_settlement_id` varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT 'gateway payout or settlement id',
+                                      `source` varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT 'source of the settlement, gateway|import',
+                                      `currency` varchar(20) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT 'currency',
+                                      `gross_amount` bigint(20) NOT NULL DEFAULT '0' COMMENT 'sum of transaction gross amount, cents',
+                                      `fee_amount` bigint(20) NOT NULL DEFAULT '0' COMMENT 'sum of transaction fee amount, cents',
+                                      `net_amount` bigint(20) NOT NULL DEFAULT '0' COMMENT 'settled amount, cents',
+                                      `status` int(11) NOT NULL DEFAULT '0' COMMENT 'status, 1-pending,2-paid,3-failed,4-cancelled',
+                                      `arrival_time` bigint(20) NOT NULL DEFAULT '0' COMMENT 'expected or actual arrival utc time of the deposit',
+                                      `item_count` int(11) NOT NULL DEFAULT '0' COMMENT 'count of transactions',
+                                      `matched_count` int(11) NOT NULL DEFAULT '0' COMMENT 'count of transactions matched with local payment or refund',
+                                      `data` text CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci COMMENT 'gateway raw data',
+                                      `gmt_create` timestamp NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'create time',
+                                      `gmt_modify` timestamp NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'update time',
+                                      `is_deleted` int(11) NOT NULL DEFAULT '0' COMMENT '0-UnDeleted，1-Deleted',
+                                      `create_time` bigint(20) DEFAULT NULL COMMENT 'create utc time',
+                                      PRIMARY KEY (`id`) USING BTREE,
+                                      UNIQUE KEY `gateway_settlement_unique` (`gateway_id`,`gateway_settlement_id`),
+                                      KEY `idx_merchant_arrival` (`merchant_id`,`arrival_time`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Gateway Settlement';
+
+-- ----------------------------
+-- Table structure for gateway_settlement_item
+-- ----------------------------
+DROP TABLE IF EXISTS `gateway_settlement_item`;
+CREATE TABLE `gateway_settlement_item` (
+                                           `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT COMMENT 'id',
+                                           `merchant_id` bigint(20) unsigned NOT NULL DEFAULT '0' COMMENT 'merchant_id',
+                                           `gateway_id` bigint(20) unsigned NOT NULL DEFAULT '0' COMMENT 'gateway_id',
+                                           `settlement_id` bigint(20) unsigned NOT NULL DEFAULT '0' COMMENT 'id of gateway_settlement',
+                                           `gateway_settlement_id` varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT 'gateway payout or settlement id',
+                                           `gateway_transaction_id` varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT 'gateway balance transaction id',
+                                           `type` varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT 'type, payment|refund|fee|dispute|adjustment|other',
+                                           `gateway_payment_id` varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT 'gateway_payment_id',
+                                           `gateway_refund_id` varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT 'gateway_refund_id',
+                                           `payment_id` varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT 'matched payment_id',
+                                           `refund_id` varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT 'matched refund_id',
+                                           `invoice_id` varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT 'matched invoice_id',
+                                           `currency` varchar(20) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT 'currency',
+                                           `gross_amount` bigint(20) NOT NULL DEFAULT '0' COMMENT 'gross amount, cents, negative for refund',
+                                           `fee_amount` bigint(20) NOT NULL DEFAULT '0' COMMENT 'fee amount, cents',
+                                           `net_amount` bigint(20) NOT NULL DEFAULT '0' COMMENT 'net amount, cents',
+                                           `transaction_time` bigint(20) NOT NULL DEFAULT '0' COMMENT 'transaction utc time',
+                                           `gmt_create` timestamp NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'create time',
+                                           `gmt_modify` timestamp NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'update time',
+                                           `is_deleted` int(11) NOT NULL DEFAULT '0' COMMENT '0-UnDeleted，1-Deleted',
+                                           `create_time` bigint(20) DEFAULT NULL COMMENT 'create utc time',
+                                           PRIMARY KEY (`id`) USING BTREE,
+                                           UNIQUE KEY `gateway_settlement_item_unique` (`gateway_id`,`gateway_transaction_id`),
+                                           KEY `idx_settlement` (`settlement_id`),
+                                           KEY `idx_payment` (`payment_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Gateway Settlement Item';
+
 -- ----------------------------
 -- Table structure for gateway_user
 -- ----------------------------
@@ -195,10 +344,43 @@ CREATE TABLE `invoice` (
                            `billing_cycle_anchor` bigint(20) DEFAULT NULL COMMENT 'billing_cycle_anchor',
                            `create_from` varchar(200) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci DEFAULT NULL COMMENT 'create from',
                            `meta_data` varchar(2000) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci DEFAULT NULL COMMENT 'meta_data(json)',
+                           `invoice_number` varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci DEFAULT NULL COMMENT 'sequential legal invoice number',
+                           `tax_treatment` varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci DEFAULT NULL COMMENT 'tax treatment, empty-standard，reverse_charge，exempt',
+                           `review_finalize_time` bigint(20) NOT NULL DEFAULT '0' COMMENT 'utc time the draft invoice in review is finalised，0-not in review',
+                           `review_hold` int(11) NOT NULL DEFAULT '0' COMMENT '0-not held，1-held, the draft invoice in review is not finalised automatically',
+                           `consolidated_invoice_id` varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci DEFAULT NULL COMMENT 'id of the consolidated invoice this cycle invoice is charged by',
+                           `net_term_days` int(11) NOT NULL DEFAULT '0' COMMENT 'payment terms in days of the net term invoice，0-not net term',
+                           `paid_amount` bigint(20) NOT NULL DEFAULT '0' COMMENT 'amount received by partial payments, cent',
+                           `overdue_reminder_time` bigint(20) NOT NULL DEFAULT '0' COMMENT 'utc time the last overdue reminder is sent',
                            PRIMARY KEY (`id`) USING BTREE,
-                           UNIQUE KEY `invoice_unique` (`unique_id`)
+                           UNIQUE KEY `invoice_unique` (`unique_id`),
+                           KEY `idx_merchant_invoice_number` (`merchant_id`,`invoice_number`),
+                           KEY `idx_consolidated_invoice_id` (`consolidated_invoice_id`)
 ) ENGINE=InnoDB AUTO_INCREMENT=2464 DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Invoice';
 
+-- ----------------------------
+-- Table structure for invoice_partial_payment
+-- ----------------------------
+DROP TABLE IF EXISTS `invoice_partial_payment`;
+CREATE TABLE `invoice_partial_payment` (
+                                           `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT COMMENT 'id',
+                                           `merchant_id` bigint(20) unsigned NOT NULL DEFAULT '0' COMMENT 'merchant_id',
+                                           `user_id` bigint(20) unsigned NOT NULL DEFAULT '0' COMMENT 'user_id',
+                                           `invoice_id` varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL COMMENT 'id of the invoice the payment is applied to',
+                                           `currency` varchar(20) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL COMMENT 'currency',
+                                           `amount` bigint(20) NOT NULL DEFAULT '0' COMMENT 'applied amount, cent',
+                                           `transfer_number` varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci DEFAULT NULL COMMENT 'bank transfer number or reference of the payment',
+                                           `reason` varchar(1024) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci DEFAULT NULL COMMENT 'reason',
+                                           `paid_time` bigint(20) NOT NULL DEFAULT '0' COMMENT 'utc time the payment is received',
+                                           `gmt_create` timestamp NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'create time',
+                                           `gmt_modify` timestamp NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'update time',
+                                           `is_deleted` int(11) NOT NULL DEFAULT '0' COMMENT '0-UnDeleted，1-Deleted',
+                                           `create_time` bigint(20) DEFAULT NULL COMMENT 'create utc time',
+                                           PRIMARY KEY (`id`) USING BTREE,
+                                           KEY `idx_invoice_id` (`invoice_id`),
+                                           KEY `idx_merchant_user` (`merchant_id`,`user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Invoice Partial Payment';
+
 -- ----------------------------
 -- Table structure for merchant
 -- ----------------------------
@@ -395,6 +577,23 @@ CREATE TABLE `merchant_gateway` (
                                     UNIQUE KEY `merchant_gateway_unique` (`merchant_id`,`gateway_name`)
 ) ENGINE=InnoDB AUTO_INCREMENT=52 DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Merchant Channel Config   Grab：https://developer.grab.com/docs/payment-otc/api/v2/#tag/otc-api   Klarna：https://docs.adyen.com/api-explorer/Checkout/latest/post/payments';
 
+-- ----------------------------
+-- Table structure for merchant_invoice_sequence
+-- ----------------------------
+DROP TABLE IF EXISTS `merchant_invoice_sequence`;
+CREATE TABLE `merchant_invoice_sequence` (
+                                             `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT COMMENT 'id',
+                                             `merchant_id` bigint(20) unsigned NOT NULL DEFAULT '0' COMMENT 'merchant_id',
+                                             `series` varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT 'number series, invoice|credit_note',
+                                             `period` varchar(16) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT 'counter period, empty when the counter never resets',
+                                             `current_value` bigint(20) NOT NULL DEFAULT '0' COMMENT 'last allocated counter value',
+                                             `gmt_create` timestamp NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'create time',
+                                             `gmt_modify` timestamp NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'update time',
+                                             `create_time` bigint(20) DEFAULT NULL COMMENT 'create utc time',
+                                             PRIMARY KEY (`id`) USING BTREE,
+                                             UNIQUE KEY `merchant_invoice_sequence_unique` (`merchant_id`,`series`,`period`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Merchant Invoice Number Sequence';
+
 -- ----------------------------
 -- Table structure for merchant_member
 -- ----------------------------
@@ -529,6 +728,31 @@ CREATE TABLE `merchant_role` (
                                  PRIMARY KEY (`id`) USING BTREE
 ) ENGINE=InnoDB AUTO_INCREMENT=62 DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Merchant Role';
 
+-- ----------------------------
+-- Table structure for merchant_tax_rule
+-- ----------------------------
+DROP TABLE IF EXISTS `merchant_tax_rule`;
+CREATE TABLE `merchant_tax_rule` (
+                                    `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT COMMENT 'id',
+                                    `merchant_id` bigint(20) unsigned NOT NULL DEFAULT '0' COMMENT 'merchant_id',
+                                    `name` varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT 'tax name shown on invoice, like GST, PST, State Tax',
+                                    `jurisdiction` varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT 'jurisdiction label, like US-CA, US-CA-Los Angeles',
+                                    `country_code` varchar(16) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT 'country code',
+                                    `region_code` varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT 'state or province code, empty matches all regions',
+                                    `city` varchar(200) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT 'city, empty matches all cities',
+                                    `zip_code_prefix` varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT 'zip code prefix, empty matches all zip codes',
+                                    `tax_category` varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT 'tax category of plan, empty matches all categories',
+                                    `rate` bigint(20) NOT NULL DEFAULT '0' COMMENT 'tax rate, 1000 = 10%',
+                                    `compound` int(11) NOT NULL DEFAULT '0' COMMENT '0-on amount excluding tax, 1-compound on amount and the previous taxes',
+                                    `priority` int(11) NOT NULL DEFAULT '0' COMMENT 'apply order, lower first',
+                                    `is_deleted` int(11) NOT NULL DEFAULT '0' COMMENT '0-UnDeleted，1-Deleted',
+                                    `gmt_create` timestamp NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'create time',
+                                    `gmt_modify` timestamp NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'update time',
+                                    `create_time` bigint(20) DEFAULT NULL COMMENT 'create utc time',
+                                    PRIMARY KEY (`id`) USING BTREE,
+                                    KEY `idx_merchant_country` (`merchant_id`,`country_code`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Merchant Tax Rule';
+
 -- ----------------------------
 -- Table structure for merchant_user_discount_code
 -- ----------------------------
@@ -834,6 +1058,11 @@ CREATE TABLE `plan` (
                         `trial_duration_time` bigint(20) DEFAULT NULL COMMENT 'duration of trial',
                         `trial_demand` varchar(200) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci DEFAULT NULL,
                         `cancel_at_trial_end` int(11) NOT NULL DEFAULT '0' COMMENT 'whether cancel at subscripiton first trial end，0-false | 1-true, will pass to cancelAtPeriodEnd of subscription',
+                        `trial_end_behavior` varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci DEFAULT NULL COMMENT 'behaviour at trial end of the subscription without payment method，convert|pause|cancel, default convert',
+                        `tax_category` varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci DEFAULT NULL COMMENT 'tax category of the plan, matched by tax rules',
+                        `invoice_review_hours` int(11) NOT NULL DEFAULT '0' COMMENT 'hours the cycle invoices stay in draft for review before finalised，0-review disabled',
+                        `min_seats` int(11) NOT NULL DEFAULT '0' COMMENT 'min seats (quantity) of the subscription，0-no limit',
+                        `max_seats` int(11) NOT NULL DEFAULT '0' COMMENT 'max seats (quantity) of the subscription，0-no limit',
                         PRIMARY KEY (`id`) USING BTREE
 ) ENGINE=InnoDB AUTO_INCREMENT=239 DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Plan';
 
@@ -929,6 +1158,10 @@ CREATE TABLE `subscription` (
                                 `gas_payer` varchar(200) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci DEFAULT NULL COMMENT 'who pay the gas, merchant|user',
                                 `current_period_paid` bigint(20) NOT NULL DEFAULT '0' COMMENT 'current period paid or not, 1-paid, other-the utc time to expire',
                                 `last_track_time` bigint(20) DEFAULT NULL COMMENT 'last subscription track time',
+                                `invoice_review_hours` int(11) NOT NULL DEFAULT '0' COMMENT 'hours the cycle invoices stay in draft for review，0-follow plan，-1-review disabled',
+                                `pause_mode` varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci DEFAULT NULL COMMENT 'pause billing behaviour，keep_as_draft|void|mark_uncollectible',
+                                `pause_time` bigint(20) NOT NULL DEFAULT '0' COMMENT 'utc time the subscription paused, 0-not paused',
+                                `resume_time` bigint(20) NOT NULL DEFAULT '0' COMMENT 'utc time the paused subscription resumes automatically, 0-resume manually',
                                 PRIMARY KEY (`id`) USING BTREE,
                                 UNIQUE KEY `subscription_unique` (`subscription_id`)
 ) ENGINE=InnoDB AUTO_INCREMENT=974 DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Subscription';
@@ -949,6 +1182,69 @@ CREATE TABLE `subscription_admin_note` (
                                            PRIMARY KEY (`id`) USING BTREE
 ) ENGINE=InnoDB AUTO_INCREMENT=81 DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci COMMENT='Subscription Admin Note';
 
+-- ----------------------------
+-- Table structure for subscription_cancellation
+-- ----------------------------
+DROP TABLE IF EXISTS `subscription_cancellation`;
+CREATE TABLE `subscription_cancellation` (
+                                           `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT COMMENT 'id',
+                                           `merchant_id` bigint(20) unsigned NOT NULL DEFAULT '0' COMMENT 'merchant id',
+                                           `user_id` bigint(20) unsigned NOT NULL DEFAULT '0' COMMENT 'userId',
+                                           `subscription_id` varchar(200) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT 'subscription id',
+                                           `plan_id` bigint(20) unsigned NOT NULL DEFAULT '0' COMMENT 'plan id of the subscription when the flow started',
+                                           `cancellation_id` varchar(200) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT 'cancellation flow unique id',
+                                           `reason` varchar(500) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci DEFAULT NULL COMMENT 'cancellation reason from the merchant reason list',
+                                           `reason_text` varchar(2000) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci DEFAULT NULL COMMENT 'free text of the reason',
+                                           `offer_ids` varchar(1000) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci DEFAULT NULL COMMENT 'json of the retention offer ids presented',
+                                           `accepted_offer_id` bigint(20) unsigned NOT NULL DEFAULT '0' COMMENT 'retention offer id accepted',
+                                           `accepted_offer_type` varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci DEFAULT NULL COMMENT 'type of the retention offer accepted，discount|pause|downgrade',
+                                           `status` int(11) NOT NULL DEFAULT '1' COMMENT 'status，1-Started｜2-Saved｜3-Cancelled',
+                                           `finish_time` bigint(20) NOT NULL DEFAULT '0' COMMENT 'utc time the subscription saved or cancelled',
+                                           `gmt_create` timestamp NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'create time',
+                                           `gmt_modify` timestamp NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'update time',
+                                           `is_deleted` int(11) NOT NULL DEFAULT '0' COMMENT '0-UnDeleted，1-Deleted',
+                                           `create_time` bigint(20) DEFAULT NULL COMMENT 'create utc time',
+                                           PRIMARY KEY (`id`) USING BTREE,
+                                           UNIQUE KEY `unique_cancellation_id` (`cancellation_id`),
+                                           KEY `idx_merchant_create_time` (`merchant_id`,`create_time`),
+                                           KEY `idx_subscription_id` (`subscription_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Subscription Cancellation Flow';
+
+-- ----------------------------
+-- Table structure for subscription_commitment
+-- ----------------------------
+DROP TABLE IF EXISTS `subscription_commitment`;
+CREATE TABLE `subscription_commitment` (
+                                         `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT COMMENT 'id',
+                                         `merchant_id` bigint(20) unsigned NOT NULL DEFAULT '0' COMMENT 'merchant id',
+                                         `user_id` bigint(20) unsigned NOT NULL DEFAULT '0' COMMENT 'userId',
+                                         `subscription_id` varchar(200) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT 'subscription id',
+                                         `commitment_id` varchar(200) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT 'commitment unique id',
+                                         `term_months` int(11) NOT NULL DEFAULT '0' COMMENT 'length of the minimum commitment term in months',
+                                         `renew_term_months` int(11) NOT NULL DEFAULT '0' COMMENT 'length of the term renewed automatically in months',
+                                         `auto_renew` int(11) NOT NULL DEFAULT '0' COMMENT 'whether the term renews at term end，0-false，1-true',
+                                         `notice_days` int(11) NOT NULL DEFAULT '0' COMMENT 'days before term end the cancellation should be noticed',
+                                         `early_termination_fee_percentage` bigint(20) NOT NULL DEFAULT '10000' COMMENT 'percentage of the remaining commitment charged at early termination，10000 = 100%',
+                                         `term_start` bigint(20) NOT NULL DEFAULT '0' COMMENT 'utc time the current term started',
+                                         `term_end` bigint(20) NOT NULL DEFAULT '0' COMMENT 'utc time the current term ends',
+                                         `term_count` int(11) NOT NULL DEFAULT '1' COMMENT 'count of the terms, increased by renewal',
+                                         `reminder_time` bigint(20) NOT NULL DEFAULT '0' COMMENT 'utc time the renewal reminder of the current term sent',
+                                         `cancel_at_term_end` int(11) NOT NULL DEFAULT '0' COMMENT 'whether the subscription is cancelled at term end as noticed，0-false，1-true',
+                                         `notice_time` bigint(20) NOT NULL DEFAULT '0' COMMENT 'utc time the cancellation at term end noticed',
+                                         `status` int(11) NOT NULL DEFAULT '1' COMMENT 'status，1-Active｜2-Completed｜3-Terminated｜4-Cancelled',
+                                         `termination_fee` bigint(20) NOT NULL DEFAULT '0' COMMENT 'early termination fee excluding tax, cent',
+                                         `termination_invoice_id` varchar(200) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT 'invoice id of the early termination fee',
+                                         `finish_time` bigint(20) NOT NULL DEFAULT '0' COMMENT 'utc time the commitment completed, terminated or cancelled',
+                                         `gmt_create` timestamp NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'create time',
+                                         `gmt_modify` timestamp NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'update time',
+                                         `is_deleted` int(11) NOT NULL DEFAULT '0' COMMENT '0-UnDeleted，1-Deleted',
+                                         `create_time` bigint(20) DEFAULT NULL COMMENT 'create utc time',
+                                         PRIMARY KEY (`id`) USING BTREE,
+                                         UNIQUE KEY `unique_commitment_id` (`commitment_id`),
+                                         KEY `idx_subscription_id` (`subscription_id`),
+                                         KEY `idx_merchant_status` (`merchant_id`,`status`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Subscription Commitment Term';
+
 -- ----------------------------
 -- Table structure for subscription_onetime_addon
 -- ----------------------------
@@ -969,6 +1265,39 @@ CREATE TABLE `subscription_onetime_addon` (
                                               PRIMARY KEY (`id`) USING BTREE
 ) ENGINE=InnoDB AUTO_INCREMENT=53 DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci COMMENT='Subscription one-time addon';
 
+-- ----------------------------
+-- Table structure for subscription_pending_invoice_item
+-- ----------------------------
+DROP TABLE IF EXISTS `subscription_pending_invoice_item`;
+CREATE TABLE `subscription_pending_invoice_item` (
+                                                     `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT COMMENT 'id',
+                                                     `merchant_id` bigint(20) unsigned NOT NULL DEFAULT '0' COMMENT 'merchant id',
+                                                     `user_id` bigint(20) unsigned NOT NULL DEFAULT '0' COMMENT 'userId',
+                                                     `subscription_id` varchar(200) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT 'subscription id',
+                                                     `item_id` varchar(200) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT 'pending invoice item unique id',
+                                                     `plan_id` bigint(20) unsigned NOT NULL DEFAULT '0' COMMENT 'plan id of the plan-based item, 0 for the amount-based item',
+                                                     `name` varchar(500) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci DEFAULT NULL COMMENT 'name',
+                                                     `description` varchar(1000) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci DEFAULT NULL COMMENT 'description',
+                                                     `currency` varchar(20) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT 'currency',
+                                                     `unit_amount` bigint(20) NOT NULL DEFAULT '0' COMMENT 'unit amount excluding tax, cent, negative for credit',
+                                                     `quantity` bigint(20) NOT NULL DEFAULT '1' COMMENT 'quantity',
+                                                     `amount` bigint(20) NOT NULL DEFAULT '0' COMMENT 'amount excluding tax, cent, unit_amount * quantity',
+                                                     `proration` int(11) NOT NULL DEFAULT '0' COMMENT 'proration or not，0-false | 1-true',
+                                                     `tax_percentage` bigint(20) NOT NULL DEFAULT '-1' COMMENT 'tax percentage override，1000 = 10%, -1 follow the invoice',
+                                                     `source` varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT 'source，SubscriptionUpdate|Merchant',
+                                                     `pending_update_id` varchar(200) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci DEFAULT NULL COMMENT 'pending update id of the subscription update created the item',
+                                                     `status` int(11) NOT NULL DEFAULT '1' COMMENT 'status，1-Pending｜2-Invoiced',
+                                                     `invoice_id` varchar(200) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci DEFAULT NULL COMMENT 'invoice id of the cycle invoice the item merged into',
+                                                     `invoice_time` bigint(20) NOT NULL DEFAULT '0' COMMENT 'utc time the item merged into invoice',
+                                                     `gmt_create` timestamp NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'create time',
+                                                     `gmt_modify` timestamp NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'update time',
+                                                     `is_deleted` int(11) NOT NULL DEFAULT '0' COMMENT '0-UnDeleted，1-Deleted',
+                                                     `create_time` bigint(20) DEFAULT NULL COMMENT 'create utc time',
+                                                     PRIMARY KEY (`id`) USING BTREE,
+                                                     UNIQUE KEY `unique_item_id` (`item_id`),
+                                                     KEY `idx_subscription_status` (`subscription_id`,`status`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Subscription Pending Invoice Item';
+
 -- ----------------------------
 -- Table structure for subscription_pending_update
 -- ----------------------------
@@ -1015,6 +1344,138 @@ CREATE TABLE `subscription_pending_update` (
                                                UNIQUE KEY `subscription_pending_update_unique` (`pending_update_id`)
 ) ENGINE=InnoDB AUTO_INCREMENT=411 DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Subscription Pending Update';
 
+-- ----------------------------
+-- Table structure for subscription_retention_offer
+-- ----------------------------
+DROP TABLE IF EXISTS `subscription_retention_offer`;
+CREATE TABLE `subscription_retention_offer` (
+                                              `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT COMMENT 'id',
+                                              `merchant_id` bigint(20) unsigned NOT NULL DEFAULT '0' COMMENT 'merchant id',
+                                              `name` varchar(500) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci DEFAULT NULL COMMENT 'name',
+                                              `description` varchar(1000) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci DEFAULT NULL COMMENT 'description shown to the customer',
+                                              `offer_type` varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT 'offer type，discount|pause|downgrade',
+                                              `discount_code` varchar(200) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci DEFAULT NULL COMMENT 'discount code applied to the next invoice, discount offer',
+                                              `pause_days` bigint(20) NOT NULL DEFAULT '0' COMMENT 'days the subscription paused before resumed automatically, 0 to resume manually, pause offer',
+                                              `target_plan_id` bigint(20) unsigned NOT NULL DEFAULT '0' COMMENT 'cheaper plan the subscription downgraded to at period end, downgrade offer',
+                                              `reasons` varchar(2000) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci DEFAULT NULL COMMENT 'json of the cancellation reasons the offer presented for, empty for all reasons',
+                                              `plan_ids` varchar(2000) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci DEFAULT NULL COMMENT 'json of the plan ids the offer presented for, empty for all plans',
+                                              `priority` int(11) NOT NULL DEFAULT '0' COMMENT 'offers presented by priority desc',
+                                              `status` int(11) NOT NULL DEFAULT '1' COMMENT 'status，1-Active｜2-Archived',
+                                              `gmt_create` timestamp NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'create time',
+                                              `gmt_modify` timestamp NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'update time',
+                                              `is_deleted` int(11) NOT NULL DEFAULT '0' COMMENT '0-UnDeleted，1-Deleted',
+                                              `create_time` bigint(20) DEFAULT NULL COMMENT 'create utc time',
+                                              PRIMARY KEY (`id`) USING BTREE,
+                                              KEY `idx_merchant_status` (`merchant_id`,`status`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Subscription Retention Offer';
+
+-- ----------------------------
+-- Table structure for subscription_schedule
+-- ----------------------------
+DROP TABLE IF EXISTS `subscription_schedule`;
+CREATE TABLE `subscription_schedule` (
+                                         `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT COMMENT 'id',
+                                         `merchant_id` bigint(20) unsigned NOT NULL DEFAULT '0' COMMENT 'merchant id',
+                                         `user_id` bigint(20) unsigned NOT NULL DEFAULT '0' COMMENT 'userId',
+                                         `subscription_id` varchar(200) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT 'subscription id',
+                                         `schedule_id` varchar(200) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT 'schedule unique id',
+                                         `status` int(11) NOT NULL DEFAULT '1' COMMENT 'status，1-Active｜2-Completed｜3-Released',
+                                         `end_behavior` varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT 'release' COMMENT 'behavior after the last phase，release|cancel',
+                                         `current_phase` int(11) NOT NULL DEFAULT '0' COMMENT 'index of the current phase, 0-not started',
+                                         `phase_cycle_count` int(11) NOT NULL DEFAULT '0' COMMENT 'billing cycles of the current phase started',
+                                         `last_period_start` bigint(20) NOT NULL DEFAULT '0' COMMENT 'period start of the last billing cycle walked by the schedule',
+                                         `start_time` bigint(20) NOT NULL DEFAULT '0' COMMENT 'utc time the first phase started',
+                                         `end_time` bigint(20) NOT NULL DEFAULT '0' COMMENT 'utc time the schedule completed or released',
+                                         `merchant_member_id` bigint(20) NOT NULL DEFAULT '0' COMMENT 'merchant member id of the creator',
+                                         `gmt_create` timestamp NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'create time',
+                                         `gmt_modify` timestamp NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'update time',
+                                         `is_deleted` int(11) NOT NULL DEFAULT '0' COMMENT '0-UnDeleted，1-Deleted',
+                                         `create_time` bigint(20) DEFAULT NULL COMMENT 'create utc time',
+                                         PRIMARY KEY (`id`) USING BTREE,
+                                         UNIQUE KEY `subscription_schedule_unique` (`schedule_id`),
+                                         KEY `idx_subscription` (`subscription_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Subscription Schedule';
+
+-- ----------------------------
+-- Table structure for subscription_schedule_phase
+-- ----------------------------
+DROP TABLE IF EXISTS `subscription_schedule_phase`;
+CREATE TABLE `subscription_schedule_phase` (
+                                               `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT COMMENT 'id',
+                                               `merchant_id` bigint(20) unsigned NOT NULL DEFAULT '0' COMMENT 'merchant id',
+                                               `subscription_id` varchar(200) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT 'subscription id',
+                                               `schedule_id` varchar(200) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT 'schedule id',
+                                               `phase_index` int(11) NOT NULL DEFAULT '0' COMMENT 'index of the phase, start with 1',
+                                               `plan_id` bigint(20) unsigned NOT NULL DEFAULT '0' COMMENT 'plan id of the phase',
+                                               `quantity` bigint(20) NOT NULL DEFAULT '1' COMMENT 'quantity of the phase',
+                                               `addon_data` varchar(1000) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci DEFAULT '' COMMENT 'plan addon data (json) of the phase',
+                                               `discount_code` varchar(200) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT 'recurring discount code of the phase',
+                                               `cycles` int(11) NOT NULL DEFAULT '0' COMMENT 'billing cycles of the phase, 0-renew on the phase without end, last phase only',
+                                               `status` int(11) NOT NULL DEFAULT '1' COMMENT 'status，1-Pending｜2-Active｜3-Completed｜4-Cancelled',
+                                               `start_time` bigint(20) NOT NULL DEFAULT '0' COMMENT 'utc time the phase started, period start of its first billing cycle',
+                                               `end_time` bigint(20) NOT NULL DEFAULT '0' COMMENT 'utc time the phase ended',
+                                               `pending_update_id` varchar(200) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT 'pending update applying the phase to the subscription',
+                                               `gmt_create` timestamp NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'create time',
+                                               `gmt_modify` timestamp NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'update time',
+                                               `is_deleted` int(11) NOT NULL DEFAULT '0' COMMENT '0-UnDeleted，1-Deleted',
+                                               `create_time` bigint(20) DEFAULT NULL COMMENT 'create utc time',
+                                               PRIMARY KEY (`id`) USING BTREE,
+                                               KEY `idx_schedule` (`schedule_id`),
+                                               KEY `idx_subscription` (`subscription_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Subscription Schedule Phase';
+
+-- ----------------------------
+-- Table structure for subscription_seat
+-- ----------------------------
+DROP TABLE IF EXISTS `subscription_seat`;
+CREATE TABLE `subscription_seat` (
+                                     `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT COMMENT 'id',
+                                     `merchant_id` bigint(20) unsigned NOT NULL DEFAULT '0' COMMENT 'merchant id',
+                                     `user_id` bigint(20) unsigned NOT NULL DEFAULT '0' COMMENT 'userId of the subscription owner',
+                                     `subscription_id` varchar(200) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT 'subscription id',
+                                     `seat_id` varchar(200) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT 'seat unique id',
+                                     `email` varchar(200) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci DEFAULT NULL COMMENT 'email of the seat member',
+                                     `external_id` varchar(200) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci DEFAULT NULL COMMENT 'external id of the seat member',
+                                     `name` varchar(200) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci DEFAULT NULL COMMENT 'name of the seat member',
+                                     `status` int(11) NOT NULL DEFAULT '1' COMMENT 'status，1-Assigned｜2-Removed',
+                                     `assign_time` bigint(20) NOT NULL DEFAULT '0' COMMENT 'utc time the seat assigned',
+                                     `remove_time` bigint(20) NOT NULL DEFAULT '0' COMMENT 'utc time the seat removed',
+                                     `gmt_create` timestamp NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'create time',
+                                     `gmt_modify` timestamp NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'update time',
+                                     `is_deleted` int(11) NOT NULL DEFAULT '0' COMMENT '0-UnDeleted，1-Deleted',
+                                     `create_time` bigint(20) DEFAULT NULL COMMENT 'create utc time',
+                                     PRIMARY KEY (`id`) USING BTREE,
+                                     UNIQUE KEY `subscription_seat_unique` (`seat_id`),
+                                     KEY `idx_subscription_status` (`subscription_id`,`status`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Subscription Seat';
+
+-- ----------------------------
+-- Table structure for subscription_seat_change
+-- ----------------------------
+DROP TABLE IF EXISTS `subscription_seat_change`;
+CREATE TABLE `subscription_seat_change` (
+                                            `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT COMMENT 'id',
+                                            `merchant_id` bigint(20) unsigned NOT NULL DEFAULT '0' COMMENT 'merchant id',
+                                            `user_id` bigint(20) unsigned NOT NULL DEFAULT '0' COMMENT 'userId',
+                                            `subscription_id` varchar(200) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT 'subscription id',
+                                            `from_quantity` bigint(20) NOT NULL DEFAULT '0' COMMENT 'seats before the change',
+                                            `to_quantity` bigint(20) NOT NULL DEFAULT '0' COMMENT 'seats after the change',
+                                            `proration_mode` varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT 'proration mode，immediate|true_up',
+                                            `change_time` bigint(20) NOT NULL DEFAULT '0' COMMENT 'utc time of the change',
+                                            `period_start` bigint(20) NOT NULL DEFAULT '0' COMMENT 'period start of the subscription when changed',
+                                            `period_end` bigint(20) NOT NULL DEFAULT '0' COMMENT 'period end of the subscription when changed',
+                                            `status` int(11) NOT NULL DEFAULT '1' COMMENT 'status，1-Pending true-up｜2-Billed｜3-Applied without true-up',
+                                            `pending_update_id` varchar(200) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci DEFAULT NULL COMMENT 'pending update id of the immediate change',
+                                            `invoice_id` varchar(200) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci DEFAULT NULL COMMENT 'invoice id billed the change',
+                                            `merchant_member_id` bigint(20) NOT NULL DEFAULT '0' COMMENT 'merchant member id',
+                                            `gmt_create` timestamp NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'create time',
+                                            `gmt_modify` timestamp NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'update time',
+                                            `is_deleted` int(11) NOT NULL DEFAULT '0' COMMENT '0-UnDeleted，1-Deleted',
+                                            `create_time` bigint(20) DEFAULT NULL COMMENT 'create utc time',
+                                            PRIMARY KEY (`id`) USING BTREE,
+                                            KEY `idx_subscription_period` (`subscription_id`,`period_start`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Subscription Seat Change';
+
 -- ----------------------------
 -- Table structure for subscription_timeline
 -- ----------------------------
@@ -1045,6 +1506,63 @@ CREATE TABLE `subscription_timeline` (
                                          UNIQUE KEY `subscription_timeline_unique` (`unique_id`)
 ) ENGINE=InnoDB AUTO_INCREMENT=1649 DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Subscription Timeline';
 
+-- ----------------------------
+-- Table structure for subscription_trial
+-- ----------------------------
+DROP TABLE IF EXISTS `subscription_trial`;
+CREATE TABLE `subscription_trial` (
+                                    `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT COMMENT 'id',
+                                    `merchant_id` bigint(20) unsigned NOT NULL DEFAULT '0' COMMENT 'merchant id',
+                                    `user_id` bigint(20) unsigned NOT NULL DEFAULT '0' COMMENT 'userId',
+                                    `subscription_id` varchar(200) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT 'subscription id',
+                                    `plan_id` bigint(20) unsigned NOT NULL DEFAULT '0' COMMENT 'plan id of the trial',
+                                    `trial_id` varchar(200) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT 'trial unique id',
+                                    `trial_start` bigint(20) NOT NULL DEFAULT '0' COMMENT 'utc time the trial started',
+                                    `trial_end` bigint(20) NOT NULL DEFAULT '0' COMMENT 'utc time the trial ends',
+                                    `trial_end_behavior` varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT 'behaviour at trial end without payment method，convert|pause|cancel',
+                                    `link_token` varchar(200) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT 'security token of the hosted payment method link',
+                                    `reminder_time` bigint(20) NOT NULL DEFAULT '0' COMMENT 'utc time the trial ending reminder sent',
+                                    `payment_method_time` bigint(20) NOT NULL DEFAULT '0' COMMENT 'utc time the payment method attached',
+                                    `status` int(11) NOT NULL DEFAULT '1' COMMENT 'status，1-Trialing｜2-Converted｜3-Paused｜4-Cancelled｜5-Expired',
+                                    `finish_time` bigint(20) NOT NULL DEFAULT '0' COMMENT 'utc time the trial converted or ended',
+                                    `gmt_create` timestamp NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'create time',
+                                    `gmt_modify` timestamp NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'update time',
+                                    `is_deleted` int(11) NOT NULL DEFAULT '0' COMMENT '0-UnDeleted，1-Deleted',
+                                    `create_time` bigint(20) DEFAULT NULL COMMENT 'create utc time',
+                                    PRIMARY KEY (`id`) USING BTREE,
+                                    UNIQUE KEY `unique_trial_id` (`trial_id`),
+                                    KEY `idx_subscription_id` (`subscription_id`),
+                                    KEY `idx_merchant_status` (`merchant_id`,`status`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Subscription Trial';
+
+-- ----------------------------
+-- Table structure for subscription_transfer
+-- ----------------------------
+DROP TABLE IF EXISTS `subscription_transfer`;
+CREATE TABLE `subscription_transfer` (
+                                       `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT COMMENT 'id',
+                                       `merchant_id` bigint(20) unsigned NOT NULL DEFAULT '0' COMMENT 'merchant id',
+                                       `subscription_id` varchar(200) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT 'subscription id',
+                                       `transfer_id` varchar(200) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT 'transfer unique id',
+                                       `from_user_id` bigint(20) unsigned NOT NULL DEFAULT '0' COMMENT 'userId the subscription transferred from',
+                                       `to_user_id` bigint(20) unsigned NOT NULL DEFAULT '0' COMMENT 'userId the subscription transferred to',
+                                       `plan_id` bigint(20) unsigned NOT NULL DEFAULT '0' COMMENT 'plan id of the subscription at transfer',
+                                       `invoice_ids` varchar(2000) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT 'unpaid invoice ids transferred, separated by comma',
+                                       `metric_event_count` bigint(20) NOT NULL DEFAULT '0' COMMENT 'count of unbilled metric events transferred',
+                                       `gateway_id` bigint(20) unsigned NOT NULL DEFAULT '0' COMMENT 'gateway id of the payment method used after transfer',
+                                       `payment_method_id` varchar(200) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT 'payment method used after transfer',
+                                       `reason` varchar(500) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT 'transfer reason',
+                                       `gmt_create` timestamp NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'create time',
+                                       `gmt_modify` timestamp NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'update time',
+                                       `is_deleted` int(11) NOT NULL DEFAULT '0' COMMENT '0-UnDeleted，1-Deleted',
+                                       `create_time` bigint(20) DEFAULT NULL COMMENT 'create utc time',
+                                       PRIMARY KEY (`id`) USING BTREE,
+                                       UNIQUE KEY `unique_transfer_id` (`transfer_id`),
+                                       KEY `idx_subscription_id` (`subscription_id`),
+                                       KEY `idx_merchant_from_user` (`merchant_id`,`from_user_id`),
+                                       KEY `idx_merchant_to_user` (`merchant_id`,`to_user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Subscription Ownership Transfer';
+
 -- ----------------------------
 -- Table structure for user_account
 -- ----------------------------
@@ -1100,6 +1618,13 @@ CREATE TABLE `user_account` (
                                 `status` int(11) NOT NULL DEFAULT '0' COMMENT '0-Active, 2-Suspend',
                                 `city` varchar(200) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci DEFAULT NULL COMMENT 'city',
                                 `zip_code` varchar(200) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci DEFAULT NULL COMMENT 'zip_code',
+                                `region_code` varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci DEFAULT NULL COMMENT 'state or province code, matched by tax rules',
+                                `tax_status` int(11) NOT NULL DEFAULT '0' COMMENT 'tax status，0-taxable，1-exempt，2-reverse charge',
+                                `tax_exempt_certificate` varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci DEFAULT NULL COMMENT 'tax exemption certificate reference',
+                                `tax_exempt_expire_time` bigint(20) NOT NULL DEFAULT '0' COMMENT 'tax exemption certificate expire utc time, 0-never expire',
+                                `consolidated_billing` int(11) NOT NULL DEFAULT '0' COMMENT 'consolidated billing，0-off，1-on, the cycle invoices of the subscriptions are merged into one',
+                                `consolidated_billing_anchor` bigint(20) NOT NULL DEFAULT '0' COMMENT 'billing cycle anchor the consolidated subscriptions are aligned to',
+                                `net_term_days` int(11) NOT NULL DEFAULT '0' COMMENT 'payment terms in days，0-due on receipt，30|60|90-net terms',
                                 PRIMARY KEY (`id`) USING BTREE,
                                 UNIQUE KEY `user_account_unique` (`merchant_id`,`email`)
 ) ENGINE=InnoDB AUTO_INCREMENT=2235428123 DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='User Account';
@@ -84,6 +84,34 @@ func CreatePendingUpdateId() string {
 	return fmt.Sprintf("subup%s%s", JodaTimePrefix(), GenerateRandomAlphanumeric(15))
 }
 
+func CreateSubscriptionScheduleId() string {
+	return fmt.Sprintf("subsch%s%s", JodaTimePrefix(), GenerateRandomAlphanumeric(15))
+}
+
+func CreateSubscriptionSeatId() string {
+	return fmt.Sprintf("subseat%s%s", JodaTimePrefix(), GenerateRandomAlphanumeric(15))
+}
+
+func CreatePendingInvoiceItemId() string {
+	return fmt.Sprintf("subpii%s%s", JodaTimePrefix(), GenerateRandomAlphanumeric(15))
+}
+
+func CreateSubscriptionCancellationId() string {
+	return fmt.Sprintf("subcan%s%s", JodaTimePrefix(), GenerateRandomAlphanumeric(15))
+}
+
+func CreateSubscriptionTrialId() string {
+	return fmt.Sprintf("subtrl%s%s", JodaTimePrefix(), GenerateRandomAlphanumeric(15))
+}
+
+func CreateSubscriptionTransferId() string {
+	return fmt.Sprintf("subtrf%s%s", JodaTimePrefix(), GenerateRandomAlphanumeric(15))
+}
+
+func CreateSubscriptionCommitmentId() string {
+	return fmt.Sprintf("subcmt%s%s", JodaTimePrefix(), GenerateRandomAlphanumeric(15))
+}
+
 func CreatePaymentId() string {
 	return fmt.Sprintf("pay%s%s", JodaTimePrefix(), GenerateRandomAlphanumeric(15))
 }
@@ -92,6 +120,10 @@ func CreateRefundId() string {
 	return fmt.Sprintf("ref%s%s", JodaTimePrefix(), GenerateRandomAlphanumeric(15))
 }
 
+func CreateCreditNoteId() string {
+	return fmt.Sprintf("cn%s%s", JodaTimePrefix(), GenerateRandomAlphanumeric(15))
+}
+
 const charset = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
 
 var seededRand = rand.New(rand.NewSource(time.Now().UnixNano()))